@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const backfillUsage = `Usage:
+  correlator backfill producer-names
+
+Commands:
+  producer-names   Re-derive producer_name of stored job runs and test results from their
+                   producer URL (after an improvement of the producer name extraction)`
+
+//nolint:forbidigo
+func runBackfill(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, backfillUsage)
+		os.Exit(1)
+	}
+
+	// Ctrl-C stops after the current batch's transaction; completed batches stay fixed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+
+	switch args[0] {
+	case "producer-names":
+		err = runBackfillProducerNames(ctx)
+	default:
+		err = fmt.Errorf("unknown backfill command %q\n\n%s", args[0], backfillUsage) //nolint:err113
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1) //nolint:gocritic // Deferred stop only releases the signal handler
+	}
+}
+
+// runBackfillProducerNames fixes stale or missing producer names in place.
+//
+//nolint:forbidigo
+func runBackfillProducerNames(ctx context.Context) error {
+	var result storage.ProducerBackfillResult
+
+	err := withLineageStore(ctx, func(store *storage.LineageStore) error {
+		var err error
+
+		result, err = store.BackfillProducerNames(ctx)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Scanned %d job runs: updated %d job runs, %d test results\n",
+		result.JobRunsScanned, result.JobRunsUpdated, result.TestResultsUpdated)
+
+	return nil
+}
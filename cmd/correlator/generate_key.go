@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,8 +14,9 @@ import (
 )
 
 const (
-	defaultClientID = "default"
-	generateTimeout = 10 * time.Second
+	defaultClientID    = "default"
+	defaultPermissions = "lineage:write"
+	generateTimeout    = 10 * time.Second
 )
 
 //nolint:forbidigo
@@ -23,13 +25,23 @@ func runGenerateKey(args []string) {
 	name := fs.String("name", "", "human-readable name for the API key (required)")
 	clientID := fs.String("client-id", defaultClientID, "client identifier for the key")
 	expires := fs.Duration("expires", 0, "key expiration duration (e.g., 720h for 30 days; 0 = no expiry)")
+	permissions := fs.String("permissions", defaultPermissions,
+		"comma-separated permissions (e.g., lineage:write,lineage:read)")
+	dailyQuota := fs.Int64("daily-quota", 0, "events the client may ingest per UTC day (0 = unlimited)")
+	monthlyQuota := fs.Int64("monthly-quota", 0, "events the client may ingest per calendar month (0 = unlimited)")
 
 	_ = fs.Parse(args)
 
 	// Validate required flags
 	if *name == "" {
 		fmt.Fprintln(os.Stderr, "Error: --name is required.")
-		fmt.Fprintf(os.Stderr, "\nUsage: correlator generate-key --name <name> [--client-id <id>] [--expires <duration>]\n")
+		fmt.Fprintf(os.Stderr, "\nUsage: correlator generate-key --name <name> [--client-id <id>] "+
+			"[--expires <duration>] [--permissions <list>] [--daily-quota <n>] [--monthly-quota <n>]\n")
+		os.Exit(1)
+	}
+
+	if *dailyQuota < 0 || *monthlyQuota < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --daily-quota and --monthly-quota cannot be negative.")
 		os.Exit(1)
 	}
 
@@ -79,9 +91,10 @@ func runGenerateKey(args []string) {
 		Key:         plaintextKey,
 		ClientID:    *clientID,
 		Name:        *name,
-		Permissions: []string{"lineage:write"},
+		Permissions: parsePermissions(*permissions),
 		CreatedAt:   time.Now(),
 		Active:      true,
+		Quota:       storage.EventQuota{Daily: *dailyQuota, Monthly: *monthlyQuota},
 	}
 
 	if *expires > 0 {
@@ -101,12 +114,24 @@ func runGenerateKey(args []string) {
 	// Print plaintext key to stdout (pipe-friendly)
 	fmt.Println(plaintextKey)
 
-	// Print metadata to stderr (human-friendly)
+	printGeneratedKey(apiKey)
+}
+
+// printGeneratedKey prints the metadata of a generated key to stderr (human-friendly).
+//
+//nolint:forbidigo
+func printGeneratedKey(apiKey *storage.APIKey) {
 	fmt.Fprintln(os.Stderr)
 	fmt.Fprintln(os.Stderr, "API key generated successfully.")
-	fmt.Fprintf(os.Stderr, "  Name:      %s\n", *name)
-	fmt.Fprintf(os.Stderr, "  Client ID: %s\n", *clientID)
-	fmt.Fprintf(os.Stderr, "  Key ID:    %s\n", keyID)
+	fmt.Fprintf(os.Stderr, "  Name:      %s\n", apiKey.Name)
+	fmt.Fprintf(os.Stderr, "  Client ID: %s\n", apiKey.ClientID)
+	fmt.Fprintf(os.Stderr, "  Key ID:    %s\n", apiKey.ID)
+	fmt.Fprintf(os.Stderr, "  Permissions: %s\n", strings.Join(apiKey.Permissions, ","))
+
+	if !apiKey.Quota.IsZero() {
+		fmt.Fprintf(os.Stderr, "  Quota:     %d events/day, %d events/month (0 = unlimited)\n",
+			apiKey.Quota.Daily, apiKey.Quota.Monthly)
+	}
 
 	if apiKey.ExpiresAt != nil {
 		fmt.Fprintf(os.Stderr, "  Expires:   %s\n", apiKey.ExpiresAt.Format(time.RFC3339))
@@ -114,3 +139,21 @@ func runGenerateKey(args []string) {
 		fmt.Fprintf(os.Stderr, "  Expires:   never\n")
 	}
 }
+
+// parsePermissions splits a comma-separated permission list, dropping blanks.
+// An empty list falls back to the default write-only permission.
+func parsePermissions(list string) []string {
+	var permissions []string
+
+	for _, p := range strings.Split(list, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			permissions = append(permissions, p)
+		}
+	}
+
+	if len(permissions) == 0 {
+		return []string{defaultPermissions}
+	}
+
+	return permissions
+}
@@ -19,6 +19,12 @@ func main() {
 		runStart(os.Args[2:])
 	case "generate-key":
 		runGenerateKey(os.Args[2:])
+	case "snapshot":
+		runSnapshot(os.Args[2:])
+	case "selftest":
+		runSelftest(os.Args[2:])
+	case "backfill":
+		runBackfill(os.Args[2:])
 	case "version":
 		runVersion()
 	case "help", "--help", "-h":
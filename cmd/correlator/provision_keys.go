@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/config"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const provisionTimeout = 30 * time.Second
+
+// errNoKeyOutput is returned when keys are to be provisioned without a way to hand out the plaintext.
+var errNoKeyOutput = errors.New(
+	"CORRELATOR_API_KEYS_FILE requires CORRELATOR_API_KEYS_OUTPUT or CORRELATOR_API_KEYS_LOG_PLAINTEXT=true")
+
+// errStaticKeysWithProvisioning is returned when keys would be provisioned into the read-only static key store.
+var errStaticKeysWithProvisioning = errors.New(
+	"CORRELATOR_API_KEYS_FILE cannot be combined with CORRELATOR_STATIC_API_KEYS_FILE (static keys are read-only)")
+
+// keyProvisioning configures API key provisioning at startup.
+type keyProvisioning struct {
+	file         string // YAML list of keys to provision (empty = disabled)
+	output       string // One-time file receiving new plaintext keys
+	logPlaintext bool   // Log new plaintext keys once instead (when no output file)
+}
+
+func loadKeyProvisioning() keyProvisioning {
+	return keyProvisioning{
+		file:         config.GetEnvStr("CORRELATOR_API_KEYS_FILE", ""),
+		output:       config.GetEnvStr("CORRELATOR_API_KEYS_OUTPUT", ""),
+		logPlaintext: config.GetEnvBool("CORRELATOR_API_KEYS_LOG_PLAINTEXT", false),
+	}
+}
+
+// validate fails fast before anything is provisioned: a key whose plaintext is never shown is unusable.
+func (p keyProvisioning) validate() error {
+	if p.file != "" && p.output == "" && !p.logPlaintext {
+		return errNoKeyOutput
+	}
+
+	return nil
+}
+
+// provision creates the keys declared in p.file that do not exist yet. Existing keys are never
+// regenerated, so restarts and repeated deploys are no-ops.
+func (p keyProvisioning) provision(logger *slog.Logger, store storage.APIKeyStore) error {
+	if p.file == "" {
+		return nil
+	}
+
+	specs, err := storage.LoadKeySpecs(p.file)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provisionTimeout)
+	defer cancel()
+
+	created, err := storage.ProvisionKeys(ctx, store, specs, func(keys []storage.ProvisionedKey) error {
+		if p.output != "" {
+			return storage.WriteProvisionedKeys(p.output, keys)
+		}
+
+		for _, k := range keys {
+			logger.Info("Provisioned API key (shown once; store it now)",
+				slog.String("plugin_id", k.PluginID),
+				slog.String("name", k.Name),
+				slog.String("key_id", k.ID),
+				slog.String("key", k.Key),
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("provision API keys: %w", err)
+	}
+
+	for _, k := range created {
+		logger.Info("API key provisioned",
+			slog.String("plugin_id", k.PluginID),
+			slog.String("name", k.Name),
+			slog.String("key_id", k.ID),
+			slog.String("key", storage.MaskKey(k.Key)),
+		)
+	}
+
+	logger.Info("API key provisioning complete",
+		slog.String("file", p.file),
+		slog.Int("declared", len(specs)),
+		slog.Int("created", len(created)),
+		slog.String("output", p.output),
+	)
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const (
+	defaultSelftestTimeout = 30 * time.Second
+	selftestClientID       = "correlator-selftest"
+)
+
+var errSelftestKeyMismatch = errors.New("stored key was not found by its plaintext value")
+
+// selftestCheck is one step of `correlator selftest`. Checks run in order; a check whose
+// dependency failed is reported as skipped.
+type selftestCheck struct {
+	name string
+	run  func(ctx context.Context, env *selftestEnv) (string, error)
+}
+
+// selftestEnv carries the resources that earlier checks open for later ones.
+type selftestEnv struct {
+	config *storage.Config
+	conn   *storage.Connection
+}
+
+// runSelftest verifies the database, schema, lineage write path, and key store without starting
+// the server, printing PASS/FAIL per check and exiting non-zero if any check failed.
+// Intended as a go/no-go gate in CD pipelines, before traffic is routed to a new release.
+//
+//nolint:forbidigo
+func runSelftest(args []string) {
+	fs := flag.NewFlagSet("selftest", flag.ExitOnError)
+	timeout := fs.Duration("timeout", defaultSelftestTimeout, "overall time limit for all checks")
+
+	_ = fs.Parse(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+
+	env := &selftestEnv{config: storage.LoadConfig()}
+	failed := runSelftestChecks(ctx, env, []selftestCheck{
+		{name: "database", run: selftestDatabase},
+		{name: "schema", run: selftestSchema},
+		{name: "job run", run: selftestJobRun},
+		{name: "key store", run: selftestKeyStore},
+	})
+
+	if env.conn != nil {
+		_ = env.conn.Close()
+	}
+
+	cancel()
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "\nSelf-test FAILED (%d check(s) failed)\n", failed)
+		os.Exit(1)
+	}
+
+	fmt.Fprintln(os.Stderr, "\nSelf-test passed")
+}
+
+// runSelftestChecks runs checks in order and prints one line per check. Once a check fails,
+// the remaining checks are skipped, since each depends on the ones before it.
+// Returns the number of failed checks.
+//
+//nolint:forbidigo
+func runSelftestChecks(ctx context.Context, env *selftestEnv, checks []selftestCheck) int {
+	failed := 0
+
+	for _, check := range checks {
+		if failed > 0 {
+			fmt.Fprintf(os.Stderr, "SKIP  %-10s previous check failed\n", check.name)
+
+			continue
+		}
+
+		start := time.Now()
+		detail, err := check.run(ctx, env)
+		elapsed := time.Since(start).Round(time.Millisecond)
+
+		if err != nil {
+			failed++
+
+			fmt.Fprintf(os.Stderr, "FAIL  %-10s %v (%s)\n", check.name, err, elapsed)
+
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "PASS  %-10s %s (%s)\n", check.name, detail, elapsed)
+	}
+
+	return failed
+}
+
+// selftestDatabase connects to DATABASE_URL (NewConnection pings before returning).
+func selftestDatabase(_ context.Context, env *selftestEnv) (string, error) {
+	conn, err := storage.NewConnection(env.config)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect (is DATABASE_URL set?): %w", err)
+	}
+
+	env.conn = conn
+
+	return "connected to " + env.config.MaskDatabaseURL(), nil
+}
+
+// selftestSchema verifies the applied migration version is one this build supports.
+func selftestSchema(ctx context.Context, env *selftestEnv) (string, error) {
+	version, err := storage.NewSchemaVersionChecker(env.conn, env.config.MigrationTable).VerifySchema(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("version %d (requires at least %d)", version, storage.MinSchemaVersion), nil
+}
+
+// selftestJobRun writes, reads, and deletes a throwaway job run.
+func selftestJobRun(ctx context.Context, env *selftestEnv) (string, error) {
+	lineageStore, err := storage.NewLineageStore(env.conn, env.config.CleanupInterval)
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize lineage store: %w", err)
+	}
+
+	defer func() { _ = lineageStore.Close() }()
+
+	if err := lineageStore.VerifyJobRunRoundTrip(ctx); err != nil {
+		return "", err
+	}
+
+	return "write, read, delete ok", nil
+}
+
+// selftestKeyStore adds a throwaway API key, looks it up by its plaintext value, and deletes it.
+// Key deletes are soft, so an inactive key for client correlator-selftest remains for the audit trail.
+func selftestKeyStore(ctx context.Context, env *selftestEnv) (string, error) {
+	keyStore, err := storage.NewPersistentKeyStore(env.conn)
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize key store: %w", err)
+	}
+
+	defer func() { _ = keyStore.Close() }()
+
+	plaintextKey, err := storage.GenerateAPIKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	apiKey := &storage.APIKey{
+		ID:          uuid.New().String(),
+		Key:         plaintextKey,
+		ClientID:    selftestClientID,
+		Name:        "selftest",
+		Permissions: []string{},
+		CreatedAt:   time.Now(),
+		Active:      true,
+	}
+
+	if err := keyStore.Add(ctx, apiKey); err != nil {
+		return "", fmt.Errorf("add: %w", err)
+	}
+
+	found, ok := keyStore.FindByKey(ctx, plaintextKey)
+
+	// Delete even when the lookup failed, so a broken lookup does not leave an active key behind
+	if err := keyStore.Delete(ctx, apiKey.ID); err != nil {
+		return "", fmt.Errorf("delete: %w", err)
+	}
+
+	if !ok || found.ID != apiKey.ID {
+		return "", fmt.Errorf("lookup: %w", errSelftestKeyMismatch)
+	}
+
+	return "add, lookup, delete ok", nil
+}
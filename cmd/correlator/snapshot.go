@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const snapshotUsage = `Usage:
+  correlator snapshot export --output <file> [--since <RFC3339>] [--until <RFC3339>] [--namespace <ns>]
+  correlator snapshot import --input <file|->`
+
+//nolint:forbidigo
+func runSnapshot(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, snapshotUsage)
+		os.Exit(1)
+	}
+
+	// Ctrl-C aborts the snapshot transaction (nothing is imported)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+
+	switch args[0] {
+	case "export":
+		err = runSnapshotExport(ctx, args[1:])
+	case "import":
+		err = runSnapshotImport(ctx, args[1:])
+	default:
+		err = fmt.Errorf("unknown snapshot command %q\n\n%s", args[0], snapshotUsage) //nolint:err113
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1) //nolint:gocritic // Deferred stop only releases the signal handler
+	}
+}
+
+// runSnapshotExport writes a lineage snapshot to a file.
+// Stdout is not supported as output because the store logs to stdout.
+//
+//nolint:forbidigo
+func runSnapshotExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("snapshot export", flag.ExitOnError)
+	output := fs.String("output", "", "file to write the NDJSON snapshot to (required)")
+	since := fs.String("since", "", "only runs whose last event is at or after this time (RFC3339)")
+	until := fs.String("until", "", "only runs whose last event is before this time (RFC3339)")
+	namespace := fs.String("namespace", "", "only runs in this job namespace")
+
+	_ = fs.Parse(args)
+
+	if *output == "" {
+		return fmt.Errorf("--output is required\n\n%s", snapshotUsage) //nolint:err113
+	}
+
+	filter := storage.SnapshotFilter{Namespace: *namespace}
+
+	var err error
+
+	if filter.Since, err = parseSnapshotTime("since", *since); err != nil {
+		return err
+	}
+
+	if filter.Until, err = parseSnapshotTime("until", *until); err != nil {
+		return err
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *output, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	writer := bufio.NewWriter(file)
+
+	var counts storage.SnapshotCounts
+
+	err = withLineageStore(ctx, func(store *storage.LineageStore) error {
+		counts, err = store.Export(ctx, writer, filter)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d job runs, %d datasets, %d lineage edges, %d idempotency keys to %s\n",
+		counts.JobRuns, counts.Datasets, counts.LineageEdges, counts.IdempotencyKeys, *output)
+
+	return nil
+}
+
+// runSnapshotImport loads a lineage snapshot from a file or stdin ("-").
+//
+//nolint:forbidigo
+func runSnapshotImport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("snapshot import", flag.ExitOnError)
+	input := fs.String("input", "", "NDJSON snapshot file to import, or - for stdin (required)")
+
+	_ = fs.Parse(args)
+
+	var reader io.Reader = os.Stdin
+
+	switch *input {
+	case "":
+		return fmt.Errorf("--input is required\n\n%s", snapshotUsage) //nolint:err113
+	case "-":
+	default:
+		file, err := os.Open(*input)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", *input, err)
+		}
+
+		defer func() { _ = file.Close() }()
+
+		reader = file
+	}
+
+	var result storage.SnapshotImportResult
+
+	err := withLineageStore(ctx, func(store *storage.LineageStore) error {
+		var err error
+
+		result, err = store.Import(ctx, bufio.NewReader(reader))
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	applied := result.Applied
+	fmt.Fprintf(os.Stderr, "Imported %d job runs, %d datasets, %d lineage edges, %d idempotency keys "+
+		"(%d records already up to date)\n",
+		applied.JobRuns, applied.Datasets, applied.LineageEdges, applied.IdempotencyKeys,
+		result.Records.Total()-applied.Total())
+	fmt.Fprintln(os.Stderr, "Correlation views refresh on the next ingested event or server restart.")
+
+	return nil
+}
+
+// withLineageStore connects to DATABASE_URL, runs fn with a lineage store, and releases both.
+// Shared by the offline maintenance commands (snapshot, backfill).
+func withLineageStore(ctx context.Context, fn func(store *storage.LineageStore) error) error {
+	storageConfig := storage.LoadConfig()
+
+	dbConn, err := storage.NewConnection(storageConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database (is DATABASE_URL set?): %w", err)
+	}
+
+	defer func() { _ = dbConn.Close() }()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	lineageStore, err := storage.NewLineageStore(dbConn, storageConfig.CleanupInterval)
+	if err != nil {
+		return fmt.Errorf("failed to initialize lineage store: %w", err)
+	}
+
+	defer func() { _ = lineageStore.Close() }()
+
+	return fn(lineageStore)
+}
+
+// parseSnapshotTime parses an optional RFC3339 flag value (empty = unbounded).
+func parseSnapshotTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s %q (expected RFC3339, e.g. 2026-01-31T00:00:00Z): %w",
+			name, value, err)
+	}
+
+	return t, nil
+}
@@ -16,6 +16,9 @@ import (
 	"github.com/correlator-io/correlator/internal/api"
 	"github.com/correlator-io/correlator/internal/api/middleware"
 	"github.com/correlator-io/correlator/internal/config"
+	"github.com/correlator-io/correlator/internal/correlation"
+	"github.com/correlator-io/correlator/internal/features"
+	"github.com/correlator-io/correlator/internal/health"
 	"github.com/correlator-io/correlator/internal/ingestion"
 	"github.com/correlator-io/correlator/internal/kafka"
 	"github.com/correlator-io/correlator/internal/storage"
@@ -41,6 +44,14 @@ func start(args []string) error {
 
 	_ = fs.Parse(args)
 
+	// SIGUSR1 enters maintenance mode, SIGUSR2 leaves it (e.g., kill -USR1 <pid> before DB maintenance).
+	// Registered first: an unhandled SIGUSR1 during startup would terminate the process. A signal that
+	// arrives before the server runs is applied once it does.
+	maintenanceSignals := make(chan os.Signal, 1)
+	signal.Notify(maintenanceSignals, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	defer signal.Stop(maintenanceSignals)
+
 	serverConfig := api.LoadServerConfig()
 
 	// Apply CLI overrides
@@ -52,13 +63,111 @@ func start(args []string) error {
 		serverConfig.Port = *port
 	}
 
+	// Read all remaining configuration up front so config.Validate sees every variable
+	middlewareConfig := middleware.LoadConfig()
+	serverConfig.RequestLogSampleRate = middlewareConfig.LogSampleRate
+	storageConfig := storage.LoadConfig()
+	kafkaConfig := kafka.LoadConfig()
+
+	authEnabled := config.GetEnvBool("CORRELATOR_AUTH_ENABLED", false)
+	keyCacheSize := config.GetEnvInt("CORRELATOR_AUTH_KEY_CACHE_SIZE", storage.DefaultKeyCacheSize)
+	keyCacheTTL := config.GetEnvDuration("CORRELATOR_AUTH_KEY_CACHE_TTL", storage.DefaultKeyCacheTTL)
+	keyProvisioning := loadKeyProvisioning()
+	staticKeysFile := config.GetEnvStr("CORRELATOR_STATIC_API_KEYS_FILE", "")
+
+	workerConfig := correlation.WorkerConfig{
+		Workers:   config.GetEnvInt("CORRELATOR_CORRELATION_WORKERS", correlation.DefaultWorkerCount),
+		QueueSize: config.GetEnvInt("CORRELATOR_CORRELATION_QUEUE_SIZE", correlation.DefaultWorkerQueueSize),
+	}
+
+	maxFacetSize := config.GetEnvInt("CORRELATOR_MAX_FACET_SIZE", ingestion.DefaultMaxFacetSize)
+	maxFacetDepth := config.GetEnvInt("CORRELATOR_MAX_FACET_DEPTH", ingestion.DefaultMaxFacetDepth)
+	maxDatasets := config.GetEnvInt("CORRELATOR_MAX_DATASETS_PER_EVENT", ingestion.DefaultMaxDatasetsPerEvent)
+	maxEventTimeSkew := config.GetEnvDuration("CORRELATOR_MAX_EVENT_TIME_SKEW", ingestion.DefaultMaxEventTimeSkew)
+	lineageDeleteEnabled := config.GetEnvBool("CORRELATOR_LINEAGE_DELETE_ENABLED", false)
+	producerPurgeEnabled := config.GetEnvBool("CORRELATOR_PRODUCER_PURGE_ENABLED", false)
+	runIDModeName := config.GetEnvStr("CORRELATOR_RUN_ID_MODE", string(ingestion.RunIDModePermissive))
+	outputsCheckName := config.GetEnvStr("CORRELATOR_COMPLETE_OUTPUTS_CHECK", string(ingestion.OutputsCheckOff))
+	outputsCheckOverridesList := config.GetEnvStr("CORRELATOR_COMPLETE_OUTPUTS_CHECK_NAMESPACES", "")
+	datasetRolesName := config.GetEnvStr("CORRELATOR_DATASET_ROLES_CHECK", string(ingestion.DatasetRolesWarn))
+	inPlaceDatasetsList := config.GetEnvStr("CORRELATOR_IN_PLACE_DATASETS", "")
+	datasetSchemeName := config.GetEnvStr("CORRELATOR_DATASET_SCHEME_CHECK", string(ingestion.DatasetSchemeOff))
+	supportedSchemes := ingestion.ParseDatasetSchemes(config.GetEnvStr("CORRELATOR_SUPPORTED_DATASET_SCHEMES", ""))
+	defaultDatasetNamespace := config.GetEnvStr("CORRELATOR_DEFAULT_DATASET_NAMESPACE", "")
+	defaultDatasetNamespacesList := config.GetEnvStr("CORRELATOR_DEFAULT_DATASET_NAMESPACES", "")
+	facetAllowlist := config.GetEnvStr("CORRELATOR_FACET_ALLOWLIST", "")
+	facetDenylist := config.GetEnvStr("CORRELATOR_FACET_DENYLIST", "")
+	featureFlagsCacheTTL := config.GetEnvDuration("CORRELATOR_FEATURE_FLAGS_CACHE_TTL", features.DefaultCacheTTL)
+
+	// Fail fast on values that do not parse instead of silently running with defaults
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Human-readable table on stderr; stdout carries the JSON logs
+	fmt.Fprintln(os.Stderr, "Effective configuration:")
+
+	_ = config.WriteSummary(os.Stderr)
+
 	if err := serverConfig.Validate(); err != nil {
 		return fmt.Errorf("invalid server configuration: %w", err)
 	}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	runIDMode, err := ingestion.ParseRunIDMode(runIDModeName)
+	if err != nil {
+		return fmt.Errorf("invalid CORRELATOR_RUN_ID_MODE: %w", err)
+	}
+
+	outputsCheck, err := ingestion.ParseOutputsCheckMode(outputsCheckName)
+	if err != nil {
+		return fmt.Errorf("invalid CORRELATOR_COMPLETE_OUTPUTS_CHECK: %w", err)
+	}
+
+	outputsCheckOverrides, err := ingestion.ParseOutputsCheckOverrides(outputsCheckOverridesList)
+	if err != nil {
+		return fmt.Errorf("invalid CORRELATOR_COMPLETE_OUTPUTS_CHECK_NAMESPACES: %w", err)
+	}
+
+	datasetRoles, err := ingestion.ParseDatasetRolesMode(datasetRolesName)
+	if err != nil {
+		return fmt.Errorf("invalid CORRELATOR_DATASET_ROLES_CHECK: %w", err)
+	}
+
+	inPlaceDatasets, err := ingestion.ParseInPlaceDatasets(inPlaceDatasetsList)
+	if err != nil {
+		return fmt.Errorf("invalid CORRELATOR_IN_PLACE_DATASETS: %w", err)
+	}
+
+	datasetScheme, err := ingestion.ParseDatasetSchemeMode(datasetSchemeName)
+	if err != nil {
+		return fmt.Errorf("invalid CORRELATOR_DATASET_SCHEME_CHECK: %w", err)
+	}
+
+	defaultDatasetNamespaces, err := ingestion.ParseDefaultNamespaces(defaultDatasetNamespacesList)
+	if err != nil {
+		return fmt.Errorf("invalid CORRELATOR_DEFAULT_DATASET_NAMESPACES: %w", err)
+	}
+
+	facetFilter, err := storage.ParseFacetFilter(facetAllowlist, facetDenylist)
+	if err != nil {
+		return fmt.Errorf("invalid CORRELATOR_FACET_ALLOWLIST/CORRELATOR_FACET_DENYLIST: %w", err)
+	}
+
+	if err := storageConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	if err := keyProvisioning.validate(); err != nil {
+		return fmt.Errorf("invalid API key provisioning: %w", err)
+	}
+
+	if staticKeysFile != "" && keyProvisioning.file != "" {
+		return errStaticKeysWithProvisioning
+	}
+
+	logger := slog.New(middleware.NewContextHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: serverConfig.LogLevel,
-	}))
+	})))
 
 	logger.Info("Starting Correlator service",
 		slog.String("service", serviceName),
@@ -76,9 +185,6 @@ func start(args []string) error {
 		slog.String("log_level", serverConfig.LogLevel.String()),
 	)
 
-	// Load rate limiter configuration
-	middlewareConfig := middleware.LoadConfig()
-
 	// Create rate limiter instance (graceful shutdown handled by server.Shutdown())
 	rateLimiter := middleware.NewInMemoryRateLimiter(middlewareConfig)
 
@@ -91,9 +197,6 @@ func start(args []string) error {
 		slog.Int("unauth_burst", middlewareConfig.UnAuthBurst),
 	)
 
-	// Load storage configuration
-	storageConfig := storage.LoadConfig()
-
 	dbConn, err := storage.NewConnection(storageConfig)
 	if err != nil {
 		return fmt.Errorf("database connection: %w", err)
@@ -103,21 +206,54 @@ func start(args []string) error {
 
 	var apiKeyStore storage.APIKeyStore
 
-	authEnabled := config.GetEnvBool("CORRELATOR_AUTH_ENABLED", false)
-	if authEnabled {
-		apiKeyStore, err = storage.NewPersistentKeyStore(dbConn)
+	switch {
+	case authEnabled && staticKeysFile != "":
+		staticKeys, err := storage.LoadStaticKeys(staticKeysFile)
+		if err != nil {
+			return err
+		}
+
+		apiKeyStore, err = storage.NewStaticKeyStore(staticKeys)
+		if err != nil {
+			return fmt.Errorf("static key store: %w", err)
+		}
+
+		logger.Info("API key authentication enabled with static keys",
+			slog.String("static_keys_file", staticKeysFile),
+			slog.Int("key_count", len(staticKeys)),
+			slog.String("note", "Keys are read at startup; restart to rotate or revoke"),
+		)
+	case authEnabled:
+		apiKeyStore, err = storage.NewPersistentKeyStore(dbConn,
+			storage.WithKeyCacheSize(keyCacheSize),
+			storage.WithKeyCacheTTL(keyCacheTTL),
+		)
 		if err != nil {
 			return fmt.Errorf("persistent key store: %w", err)
 		}
 
 		logger.Info("API key authentication enabled",
 			slog.String("database_url", storageConfig.MaskDatabaseURL()),
+			slog.Int("key_cache_size", keyCacheSize),
+			slog.Duration("key_cache_ttl", keyCacheTTL),
 		)
-	} else {
+
+		if err := keyProvisioning.provision(logger, apiKeyStore); err != nil {
+			return err
+		}
+	default:
 		logger.Warn("API key authentication disabled",
 			slog.String("security", "Only use in trusted networks (localhost, VPN, internal)"),
 			slog.String("note", "Set CORRELATOR_AUTH_ENABLED=true to enable API key authentication"),
 		)
+
+		if keyProvisioning.file != "" {
+			logger.Warn("CORRELATOR_API_KEYS_FILE ignored because authentication is disabled")
+		}
+
+		if staticKeysFile != "" {
+			logger.Warn("CORRELATOR_STATIC_API_KEYS_FILE ignored because authentication is disabled")
+		}
 	}
 
 	// Load dataset pattern configuration (optional - graceful degradation)
@@ -141,10 +277,37 @@ func start(args []string) error {
 			slog.String("canonical", pattern.Canonical))
 	}
 
+	// Fans out correlated incidents to live GET /api/v1/correlations/stream clients.
+	// Closed after the worker (defers run LIFO) so open streams end once nothing more can arrive.
+	correlationBroadcaster := correlation.NewBroadcaster(logger)
+
+	defer func() { _ = correlationBroadcaster.Close() }()
+
+	// Background correlation worker: notifies newly correlated incidents after ingestion.
+	// Closed after the lineage store (defers run LIFO) so the final hand-off is drained.
+	incidentNotifier := correlation.NewMultiNotifier(correlation.NewLogNotifier(logger), correlationBroadcaster)
+	correlationWorker := correlation.NewWorker(workerConfig, incidentNotifier, logger)
+
+	defer func() { _ = correlationWorker.Close() }()
+
+	// Last runs of background workers, reported by GET /api/v1/health/detailed
+	workers := health.NewWorkers()
+
 	lineageStore, err := storage.NewLineageStore(
 		dbConn, storageConfig.CleanupInterval,
 		storage.WithAliasResolver(resolver),
 		storage.WithViewRefreshDelay(storageConfig.ViewRefreshDelay),
+		storage.WithIncidentSink(correlationWorker),
+		storage.WithFacetAudit(storageConfig.FacetAudit),
+		storage.WithAssertionFacetIngestion(storageConfig.AssertionFacets),
+		storage.WithJobRunNotifications(storageConfig.JobRunNotify),
+		storage.WithFacetFilter(facetFilter),
+		storage.WithWorkers(workers),
+		storage.WithWriteBuffer(storage.WriteBufferConfig{
+			MaxEvents:     storageConfig.WriteBufferSize,
+			FlushInterval: storageConfig.WriteBufferFlushInterval,
+			Async:         storageConfig.WriteBufferAsync,
+		}),
 	)
 	if err != nil {
 		return fmt.Errorf("lineage store: %w", err)
@@ -152,10 +315,18 @@ func start(args []string) error {
 
 	defer func() { _ = lineageStore.Close() }()
 
+	correlationWorker.Start(lineageStore)
+
 	logger.Info("Lineage store initialized",
 		slog.String("database_url", storageConfig.MaskDatabaseURL()),
 		slog.Duration("cleanup_interval", storageConfig.CleanupInterval),
 		slog.Duration("view_refresh_delay", storageConfig.ViewRefreshDelay),
+		slog.Bool("facet_audit", storageConfig.FacetAudit),
+		slog.Bool("assertion_facet_ingestion", storageConfig.AssertionFacets),
+		slog.Bool("job_run_notify", storageConfig.JobRunNotify),
+		slog.Int("write_buffer_size", storageConfig.WriteBufferSize),
+		slog.String("facet_allowlist", facetAllowlist),
+		slog.String("facet_denylist", facetDenylist),
 		slog.Int("database_max_open_conns", storageConfig.MaxOpenConns),
 		slog.Int("database_max_idle_conns", storageConfig.MaxIdleConns),
 		slog.Duration("database_conn_max_lifetime", storageConfig.ConnMaxLifetime),
@@ -172,20 +343,62 @@ func start(args []string) error {
 
 	logger.Info("Resolved datasets lookup table initialized")
 
-	// Load Kafka consumer configuration (optional — disabled by default)
-	kafkaConfig := kafka.LoadConfig()
+	// Store the job aliases of .correlator.yaml, then canonicalize job identity with every stored alias
+	jobIdentity, err := loadJobIdentityResolver(initCtx, lineageStore, patternConfig.JobAliases)
+	if err != nil {
+		return fmt.Errorf("job aliases: %w", err)
+	}
+
+	logger.Info("Job aliases loaded", slog.Int("job_alias_count", jobIdentity.Len()))
+
+	// Validate Kafka consumer configuration (optional — disabled by default)
 	if err := kafkaConfig.Validate(); err != nil {
 		return fmt.Errorf("kafka configuration: %w", err)
 	}
 
 	// Create shared validator for all transports (thread-safe, no mutable state)
-	validator := ingestion.NewValidator()
+	validator := ingestion.NewValidator(
+		ingestion.WithMaxFacetSize(maxFacetSize),
+		ingestion.WithMaxFacetDepth(maxFacetDepth),
+		ingestion.WithMaxDatasetsPerEvent(maxDatasets),
+		ingestion.WithRunIDMode(runIDMode),
+		ingestion.WithCompleteOutputsCheck(outputsCheck, outputsCheckOverrides),
+		ingestion.WithDatasetRolesCheck(datasetRoles, inPlaceDatasets),
+		ingestion.WithDatasetSchemeCheck(datasetScheme, supportedSchemes),
+		ingestion.WithMaxEventTimeSkew(maxEventTimeSkew),
+		ingestion.WithDefaultDatasetNamespace(defaultDatasetNamespace, defaultDatasetNamespaces),
+	)
+
+	logger.Info("Event validator configured",
+		slog.Int("max_facet_size", maxFacetSize),
+		slog.Int("max_facet_depth", maxFacetDepth),
+		slog.Int("max_datasets_per_event", maxDatasets),
+		slog.String("run_id_mode", string(runIDMode)),
+		slog.String("complete_outputs_check", string(outputsCheck)),
+		slog.Any("complete_outputs_check_namespaces", outputsCheckOverrides),
+		slog.String("dataset_roles_check", string(datasetRoles)),
+		slog.Any("in_place_datasets", inPlaceDatasets),
+		slog.String("dataset_scheme_check", string(datasetScheme)),
+		slog.Any("supported_dataset_schemes", supportedSchemes),
+		slog.Duration("max_event_time_skew", maxEventTimeSkew),
+		slog.String("default_dataset_namespace", defaultDatasetNamespace),
+		slog.Any("default_dataset_namespaces", defaultDatasetNamespaces),
+	)
+
+	// Pre-store interceptors shared by all transports (see ingestion.EventInterceptor). Job identity
+	// canonicalization is the only built-in one: this is the extension point for
+	// organization-specific enrichment or rejection.
+	var interceptors ingestion.Interceptors
+
+	if jobIdentity.Len() > 0 {
+		interceptors = append(interceptors, jobIdentity)
+	}
 
 	// Create Kafka consumer (if enabled)
 	var consumer *kafka.Consumer
 
 	if kafkaConfig.Enabled {
-		consumer = kafka.NewConsumer(kafkaConfig, lineageStore, validator, logger)
+		consumer = kafka.NewConsumer(kafkaConfig, lineageStore, validator, interceptors, logger)
 
 		logger.Info("Kafka consumer configured",
 			slog.Any("brokers", kafkaConfig.Brokers),
@@ -201,6 +414,39 @@ func start(args []string) error {
 		kafkaHealthChecker = consumer
 	}
 
+	// Bulk job-run delete is for CI / e2e environments only; never register it unless asked to
+	var jobRunCleanupStore api.JobRunCleanupStore
+	if lineageDeleteEnabled {
+		jobRunCleanupStore = lineageStore
+
+		logger.Warn("Bulk job run delete enabled (DELETE /api/v1/lineage/job-runs) - do not use in production")
+	}
+
+	// Producer purge deletes a whole integration's lineage: opt-in, and only behind API key auth
+	var producerPurgeStore api.ProducerPurgeStore
+
+	switch {
+	case producerPurgeEnabled && apiKeyStore == nil:
+		logger.Warn("Producer purge requires authentication, DELETE /api/v1/producers/{producer} not registered",
+			slog.String("note", "Set CORRELATOR_AUTH_ENABLED=true to enable producer purge"),
+		)
+	case producerPurgeEnabled:
+		producerPurgeStore = lineageStore
+
+		logger.Warn("Producer purge enabled (DELETE /api/v1/producers/{producer}, admin scope)")
+	}
+
+	// Orphaned test result reconciliation is opt-in; created here so GET /metrics can report it
+	var (
+		orphanReconciler     *storage.OrphanReconciler
+		orphanReconciliation api.OrphanReconciliationReporter
+	)
+
+	if storageConfig.OrphanReconcileInterval > 0 {
+		orphanReconciler = storage.NewOrphanReconciler(lineageStore, storageConfig, logger)
+		orphanReconciliation = orphanReconciler
+	}
+
 	server := api.NewServer(serverConfig, api.Dependencies{
 		APIKeyStore:      apiKeyStore,
 		RateLimiter:      rateLimiter,
@@ -208,6 +454,22 @@ func start(args []string) error {
 		CorrelationStore: lineageStore,
 		ResolutionStore:  lineageStore,
 		KafkaHealth:      kafkaHealthChecker,
+		Validator:        validator,
+		Interceptors:     interceptors,
+		SchemaChecker:    storage.NewSchemaVersionChecker(dbConn, storageConfig.MigrationTable),
+
+		CorrelationSubscriber: correlationBroadcaster,
+		JobRunCleanupStore:    jobRunCleanupStore,
+		MaintenanceChecker:    lineageStore,
+		LineageBatchStore:     lineageStore,
+		ProducerStore:         lineageStore,
+		ProducerPurgeStore:    producerPurgeStore,
+		LineageSearchStore:    lineageStore,
+		IngestionQuotaStore:   lineageStore,
+		FeatureFlags:          features.NewFlags(lineageStore, featureFlagsCacheTTL, logger),
+		Workers:               workers,
+
+		OrphanReconciliation: orphanReconciliation,
 	}, api.BuildInfo{
 		Version:   version,
 		GitCommit: gitCommit,
@@ -222,6 +484,34 @@ func start(args []string) error {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
+	if storageConfig.AdaptivePool {
+		storage.NewPoolTuner(dbConn, storageConfig, logger).Start(ctx)
+
+		logger.Info("Adaptive database pool sizing enabled",
+			slog.Int("min_open_conns", storageConfig.AdaptivePoolMinConns),
+			slog.Int("max_open_conns", storageConfig.MaxOpenConns),
+			slog.Duration("interval", storageConfig.AdaptivePoolInterval),
+		)
+	}
+
+	if storageConfig.StaleRunAbortAfter > 0 {
+		storage.NewStaleRunSweeper(lineageStore, storageConfig, logger).Start(ctx)
+
+		logger.Info("Stale run sweeper enabled",
+			slog.Duration("abort_after", storageConfig.StaleRunAbortAfter),
+			slog.Duration("interval", storageConfig.StaleRunSweepInterval),
+			slog.Int("limit", storageConfig.StaleRunSweepLimit),
+		)
+	}
+
+	if orphanReconciler != nil {
+		orphanReconciler.Start(ctx)
+
+		logger.Info("Orphaned test result reconciliation enabled",
+			slog.Duration("interval", storageConfig.OrphanReconcileInterval),
+		)
+	}
+
 	// Start subsystems (non-blocking)
 	// A nil channel blocks forever on select — disabled subsystems are simply silent.
 	var consumerErrors <-chan error
@@ -231,6 +521,8 @@ func start(args []string) error {
 
 	serverErrors := server.ListenAndServe()
 
+	go handleMaintenanceSignals(ctx, server, consumer, maintenanceSignals, serverConfig.ShutdownTimeout)
+
 	// Block until shutdown signal or fatal subsystem error
 	select {
 	case err := <-consumerErrors:
@@ -248,14 +540,19 @@ func start(args []string) error {
 	// 1. Cancel context (stops Kafka consumer loop)
 	cancel()
 
-	// 2. Kafka consumer drain (wait for in-flight message)
+	// 2. Background workers that outlive a request finish their in-flight database work
+	if orphanReconciler != nil {
+		orphanReconciler.Wait()
+	}
+
+	// 3. Kafka consumer drain (wait for in-flight message)
 	if consumer != nil {
 		if err := consumer.Close(); err != nil {
 			logger.Error("Kafka consumer shutdown failed", slog.String("error", err.Error()))
 		}
 	}
 
-	// 3. HTTP server drain + dependency cleanup
+	// 4. HTTP server drain + dependency cleanup
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		logger.Error("Server shutdown failed", slog.String("error", err.Error()))
 	}
@@ -264,3 +561,61 @@ func start(args []string) error {
 
 	return nil
 }
+
+// loadJobIdentityResolver upserts the configured job aliases (last write wins) and returns a
+// resolver over every alias stored in job_aliases, including those added directly in the database.
+func loadJobIdentityResolver(
+	ctx context.Context, store *storage.LineageStore, configured []aliasing.JobAlias,
+) (*ingestion.JobIdentityResolver, error) {
+	aliases := make([]ingestion.JobAlias, 0, len(configured))
+	for _, alias := range configured {
+		aliases = append(aliases, ingestion.JobAlias(alias))
+	}
+
+	if err := store.UpsertJobAliases(ctx, aliases); err != nil {
+		return nil, err
+	}
+
+	stored, err := store.JobAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ingestion.NewJobIdentityResolver(stored), nil
+}
+
+// handleMaintenanceSignals toggles the server's maintenance mode until ctx is cancelled, pausing
+// the Kafka consumer (nil = disabled) alongside so no transport writes during maintenance.
+// Entering waits up to drainTimeout for in-flight requests to complete.
+func handleMaintenanceSignals(
+	ctx context.Context,
+	server *api.Server,
+	consumer *kafka.Consumer,
+	signals <-chan os.Signal,
+	drainTimeout time.Duration,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-signals:
+			if sig != syscall.SIGUSR1 {
+				server.LeaveMaintenance()
+
+				if consumer != nil {
+					consumer.Resume()
+				}
+
+				continue
+			}
+
+			if consumer != nil {
+				consumer.Pause()
+			}
+
+			drainCtx, cancel := context.WithTimeout(ctx, drainTimeout)
+			_ = server.EnterMaintenance(drainCtx) // Timeout is logged; maintenance mode stays on
+			cancel()
+		}
+	}
+}
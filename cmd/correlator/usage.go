@@ -10,6 +10,9 @@ func printUsage() {
 	fmt.Println("Commands:")
 	fmt.Println("  start          Start the Correlator server")
 	fmt.Println("  generate-key   Generate an API key for OpenLineage integrations")
+	fmt.Println("  snapshot       Export or import a lineage snapshot (disaster recovery, cloning)")
+	fmt.Println("  selftest       Verify database, schema, and key store before accepting traffic")
+	fmt.Println("  backfill       Recompute derived columns of stored data (e.g. producer names)")
 	fmt.Println("  version        Show version information")
 	fmt.Println("  help           Show this help message")
 	fmt.Println()
@@ -12,6 +12,14 @@
 //	    canonical: "postgresql://demo/marts.{name}"
 //
 // This transforms "demo_postgres/customers" → "postgresql://demo/marts.customers"
+//
+// The same file lists job aliases, which keep the run history of renamed jobs continuous:
+//
+//	job_aliases:
+//	  - namespace: "dbt://analytics"
+//	    name: "jaffle_shop.stg_orders"
+//	    canonical_namespace: "dbt://analytics"
+//	    canonical_name: "jaffle_shop.staging.orders"
 package aliasing
 
 import (
@@ -44,10 +52,22 @@ type (
 		Canonical string `yaml:"canonical"`
 	}
 
-	// Config holds dataset pattern configuration loaded from .correlator.yaml.
+	// JobAlias maps a job (namespace + name), e.g. the old name of a renamed dbt model, to the
+	// canonical job its runs are stored under. A later entry for the same job wins.
+	JobAlias struct {
+		Namespace          string `yaml:"namespace"`
+		Name               string `yaml:"name"`
+		CanonicalNamespace string `yaml:"canonical_namespace"` //nolint:tagliatelle // snake_case YAML
+		CanonicalName      string `yaml:"canonical_name"`      //nolint:tagliatelle // snake_case YAML
+	}
+
+	// Config holds dataset pattern and job alias configuration loaded from .correlator.yaml.
 	Config struct {
 		//nolint:tagliatelle // snake_case is intentional for YAML config files
 		DatasetPatterns []DatasetPattern `yaml:"dataset_patterns"`
+
+		//nolint:tagliatelle // snake_case is intentional for YAML config files
+		JobAliases []JobAlias `yaml:"job_aliases"`
 	}
 )
 
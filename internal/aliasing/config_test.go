@@ -276,3 +276,34 @@ dataset_patterns:
 	assert.Len(t, cfg.DatasetPatterns, 1)
 	assert.Equal(t, "postgres://host:5432/{name}", cfg.DatasetPatterns[0].Pattern)
 }
+
+func TestLoadConfig_JobAliases(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "correlator.yaml")
+
+	content := `
+job_aliases:
+  - namespace: "dbt://analytics"
+    name: "jaffle_shop.stg_orders"
+    canonical_namespace: "dbt://analytics"
+    canonical_name: "jaffle_shop.orders"
+`
+	err := os.WriteFile(configPath, []byte(content), 0644)
+	require.NoError(t, err)
+
+	cfg, err := LoadConfig(configPath)
+
+	require.NoError(t, err)
+	require.Len(t, cfg.JobAliases, 1)
+	assert.Equal(t, JobAlias{
+		Namespace:          "dbt://analytics",
+		Name:               "jaffle_shop.stg_orders",
+		CanonicalNamespace: "dbt://analytics",
+		CanonicalName:      "jaffle_shop.orders",
+	}, cfg.JobAliases[0])
+	assert.Empty(t, cfg.DatasetPatterns)
+}
@@ -5,19 +5,35 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
+	"github.com/correlator-io/correlator/internal/api/middleware"
 	"github.com/correlator-io/correlator/internal/config"
 )
 
 const (
-	defaultPort           int    = 8080
-	maxPort               int    = 65535
-	defaultHost           string = "0.0.0.0"
-	defaultCORSMaxAge     int    = 86400
-	defaultTimeout               = 30 * time.Second
-	defaultLogLevel              = slog.LevelInfo
-	defaultMaxRequestSize int64  = 1048576 // 1 MB (1024 * 1024 bytes)
+	defaultPort             int    = 8080
+	maxPort                 int    = 65535
+	defaultHost             string = "0.0.0.0"
+	defaultCORSMaxAge       int    = 86400
+	defaultTimeout                 = 30 * time.Second
+	defaultLogLevel                = slog.LevelInfo
+	defaultMaxRequestSize   int64  = 1048576  // 1 MB (1024 * 1024 bytes)
+	defaultMaxImportSize    int64  = 67108864 // 64 MB (64 * 1024 * 1024 bytes), total per import request
+	defaultMaxImportPart    int64  = 16777216 // 16 MB (16 * 1024 * 1024 bytes), per imported file
+	defaultTraceRequests    int    = 1000
+	defaultTraceRetention          = 15 * time.Minute
+	defaultIdempotencyKeys  int    = 10000
+	defaultIdempotencyTTL          = 24 * time.Hour
+	defaultDeadTuplePercent int    = 20
+	defaultRetryAfter              = 60 * time.Second
+	defaultShedRetryAfter          = 1 * time.Second
+	defaultBodyReadMinBytes int64  = 1024
+	defaultBodyReadWindow          = 10 * time.Second
+	defaultPageSize         int    = 20
+	defaultMaxPageSize      int    = 100
+	maxPercent              int    = 100
 )
 
 var (
@@ -38,6 +54,36 @@ var (
 
 	// ErrInvalidMaxRequestSize indicates the max request size is zero or negative.
 	ErrInvalidMaxRequestSize = errors.New("max request size must be positive")
+
+	// ErrInvalidMaxImportSize indicates the total or per-part import size limit is zero or negative.
+	ErrInvalidMaxImportSize = errors.New("max import size must be positive")
+
+	// ErrInvalidDeadTuplePercent indicates the table bloat warning threshold is outside 1-100.
+	ErrInvalidDeadTuplePercent = errors.New("dead tuple percent must be between 1 and 100")
+
+	// ErrInvalidRetryAfter indicates the maintenance mode Retry-After is zero or negative.
+	ErrInvalidRetryAfter = errors.New("maintenance retry-after must be positive")
+
+	// ErrInvalidMaxConcurrentRequests indicates the concurrent request limit is negative.
+	ErrInvalidMaxConcurrentRequests = errors.New("max concurrent requests must be zero (unlimited) or positive")
+
+	// ErrInvalidConcurrencyRetryAfter indicates the Retry-After of shed requests is zero or negative.
+	ErrInvalidConcurrencyRetryAfter = errors.New("concurrency retry-after must be positive")
+
+	// ErrInvalidBodyReadRate indicates a negative minimum body read rate.
+	ErrInvalidBodyReadRate = errors.New("body read min bytes and window must be zero (disabled) or positive")
+
+	// ErrInvalidContentTypeSniffSize indicates a negative Content-Type sniff size.
+	ErrInvalidContentTypeSniffSize = errors.New("content type sniff size must be zero (disabled) or positive")
+
+	// ErrInvalidPageSize indicates a negative page size, or a default page size above the max.
+	ErrInvalidPageSize = errors.New("page sizes must be zero (built-in default) or positive, default <= max")
+
+	// ErrInvalidCORSCredentials indicates credentialed CORS requests allowed from any origin ("*").
+	ErrInvalidCORSCredentials = errors.New("CORS credentials require an explicit list of allowed origins")
+
+	// ErrInvalidPreAuth indicates a pre-authentication header configured without trusted sources.
+	ErrInvalidPreAuth = errors.New("pre-authentication header requires trusted sources")
 )
 
 type (
@@ -51,19 +97,88 @@ type (
 		ShutdownTimeout    time.Duration
 		LogLevel           slog.Level
 		MaxRequestSize     int64
+		MaxImportSize      int64 // Total body limit for POST /api/v1/lineage/import and async batches
+		MaxImportPartSize  int64 // Per-file limit for POST /api/v1/lineage/import
 		CORSAllowedOrigins []string
 		CORSAllowedMethods []string
 		CORSAllowedHeaders []string
 		CORSMaxAge         int
+		// CORSAllowCredentials allows credentialed cross-origin requests (cookies, HTTP auth, or
+		// client certificates). The request origin is then echoed instead of "*".
+		CORSAllowCredentials bool
+
+		RequestLogSampleRate    int           // Log 1 in N successful requests (<= 1 = all); from middleware.Config
+		RequestTraceMaxRequests int           // Traces kept for GET /api/v1/trace/{correlationID} (0 = disabled)
+		RequestTraceRetention   time.Duration // Max age of a kept trace (0 = disabled)
+		IdempotencyMaxKeys      int           // Idempotency-Key responses kept for retries (0 = disabled)
+		IdempotencyKeyTTL       time.Duration // How long a response is replayed for its key (0 = disabled)
+
+		// TrustedProxies are CIDRs (or IPs) of reverse proxies whose X-Forwarded-For / X-Real-IP
+		// headers are believed. Empty = headers are ignored and the client IP is the peer address.
+		TrustedProxies []string
+
+		MaintenanceDeadTuplePercent int           // Dead-tuple % above which /health warns about a bloated table
+		MaintenanceRetryAfter       time.Duration // Retry-After sent with 503s while in maintenance mode
+
+		// ReadOnly rejects every mutating request (POST, PUT, PATCH, DELETE) with 405 whatever the
+		// caller's scopes, for an instance that only serves reads (e.g. a public analytics replica).
+		ReadOnly bool
+
+		// AllowImpersonation lets keys with the admin permission act as another plugin by sending
+		// X-Impersonate-Plugin (see middleware.Impersonate). When false the header is refused with 403.
+		AllowImpersonation bool
+
+		// Pre-authentication (see middleware.PreAuthenticate): requests from PreAuthTrustedSources
+		// (CIDRs or IPs of the mesh proxies) carrying PreAuthHeader are identified by it, with the
+		// permissions of PreAuthScopesHeader, instead of an API key. Empty PreAuthHeader = disabled.
+		PreAuthHeader         string
+		PreAuthScopesHeader   string
+		PreAuthTrustedSources []string
+
+		// EnableSeed registers POST /api/v1/debug/seed, which generates synthetic lineage for
+		// performance testing and demos (with authentication only). Never for production.
+		EnableSeed bool
+
+		MaxConcurrentRequests int           // Requests in flight before more are shed with 503 (0 = unlimited)
+		ConcurrencyRetryAfter time.Duration // Retry-After sent with 503s of shed requests
+
+		// Ingestion endpoints abort a request with 408 when fewer than BodyReadMinBytes of its body
+		// arrive within BodyReadWindow (0 for either = disabled; ReadTimeout still applies).
+		BodyReadMinBytes int64
+		BodyReadWindow   time.Duration
+
+		// ContentTypeSniffSize enables lenient Content-Type checks on lineage ingestion: a body
+		// sent without Content-Type is accepted as JSON when its first non-whitespace byte within
+		// this many bytes is '[' or '{'. A declared non-JSON type is still rejected. 0 = strict.
+		ContentTypeSniffSize int
+
+		// Pagination of list endpoints: limit defaults to DefaultPageSize and may not exceed
+		// MaxPageSize (0 for either = built-in default of 20 and 100).
+		DefaultPageSize int
+		MaxPageSize     int
+
+		// VerboseLogProducers are producers (a producer URL or one of its path segments, e.g.
+		// "dbt-core") or job namespaces whose events batch ingestion dumps at debug level, whatever
+		// LogLevel is. Empty = off.
+		VerboseLogProducers []string
+
+		// TLS: with TLSCertFile and TLSKeyFile (PEM) set the server serves HTTPS and HTTP/2;
+		// otherwise plain HTTP for deployments behind a TLS-terminating proxy. TLSClientCAFile
+		// additionally requires client certificates signed by one of its CAs (mTLS), on top of
+		// API key authentication.
+		TLSCertFile     string
+		TLSKeyFile      string
+		TLSClientCAFile string
 	}
 
 	// CORSConfig holds CORS configuration options.
 	// This is defined here to keep CORS configuration centralized.
 	CORSConfig struct {
-		AllowedOrigins []string
-		AllowedMethods []string
-		AllowedHeaders []string
-		MaxAge         int
+		AllowedOrigins   []string
+		AllowedMethods   []string
+		AllowedHeaders   []string
+		MaxAge           int
+		AllowCredentials bool
 	}
 )
 
@@ -77,6 +192,10 @@ func LoadServerConfig() *ServerConfig {
 		ShutdownTimeout: config.GetEnvDuration("CORRELATOR_SERVER_TIMEOUT", defaultTimeout),
 		LogLevel:        config.GetEnvLogLevel("CORRELATOR_SERVER_LOG_LEVEL", defaultLogLevel),
 		MaxRequestSize:  config.GetEnvInt64("CORRELATOR_MAX_REQUEST_SIZE", defaultMaxRequestSize),
+		MaxImportSize:   config.GetEnvInt64("CORRELATOR_MAX_IMPORT_SIZE", defaultMaxImportSize),
+		MaxImportPartSize: config.GetEnvInt64(
+			"CORRELATOR_MAX_IMPORT_PART_SIZE", defaultMaxImportPart,
+		),
 		CORSAllowedOrigins: config.ParseCommaSeparatedList(
 			config.GetEnvStr("CORRELATOR_CORS_ALLOWED_ORIGINS", "*"),
 		), // "*" is Development default - should be restricted in production
@@ -86,10 +205,45 @@ func LoadServerConfig() *ServerConfig {
 		CORSAllowedHeaders: config.ParseCommaSeparatedList(
 			config.GetEnvStr(
 				"CORRELATOR_CORS_ALLOWED_HEADERS",
-				"Content-Type,Authorization,X-Correlation-ID",
+				"Content-Type,Authorization,X-Correlation-ID,If-Unmodified-Since,Idempotency-Key",
 			),
 		),
-		CORSMaxAge: config.GetEnvInt("CORRELATOR_CORS_MAX_AGE", defaultCORSMaxAge),
+		CORSMaxAge:           config.GetEnvInt("CORRELATOR_CORS_MAX_AGE", defaultCORSMaxAge),
+		CORSAllowCredentials: config.GetEnvBool("CORRELATOR_CORS_ALLOW_CREDENTIALS", false),
+		RequestTraceMaxRequests: config.GetEnvInt(
+			"CORRELATOR_REQUEST_TRACE_MAX_REQUESTS", defaultTraceRequests,
+		),
+		RequestTraceRetention: config.GetEnvDuration(
+			"CORRELATOR_REQUEST_TRACE_RETENTION", defaultTraceRetention,
+		),
+		IdempotencyMaxKeys: config.GetEnvInt("CORRELATOR_IDEMPOTENCY_MAX_KEYS", defaultIdempotencyKeys),
+		IdempotencyKeyTTL:  config.GetEnvDuration("CORRELATOR_IDEMPOTENCY_KEY_TTL", defaultIdempotencyTTL),
+		TrustedProxies:     config.ParseCommaSeparatedList(config.GetEnvStr("CORRELATOR_TRUSTED_PROXIES", "")),
+		MaintenanceDeadTuplePercent: config.GetEnvInt(
+			"CORRELATOR_MAINTENANCE_DEAD_TUPLE_PERCENT", defaultDeadTuplePercent,
+		),
+		MaintenanceRetryAfter: config.GetEnvDuration("CORRELATOR_MAINTENANCE_RETRY_AFTER", defaultRetryAfter),
+		ReadOnly:              config.GetEnvBool("CORRELATOR_READ_ONLY", false),
+		AllowImpersonation:    config.GetEnvBool("CORRELATOR_ALLOW_IMPERSONATION", false),
+		PreAuthHeader:         config.GetEnvStr("CORRELATOR_PREAUTH_HEADER", ""),
+		PreAuthScopesHeader: config.GetEnvStr(
+			"CORRELATOR_PREAUTH_SCOPES_HEADER", middleware.DefaultPreAuthScopesHeader,
+		),
+		PreAuthTrustedSources: config.ParseCommaSeparatedList(
+			config.GetEnvStr("CORRELATOR_PREAUTH_TRUSTED_SOURCES", ""),
+		),
+		EnableSeed:            config.GetEnvBool("CORRELATOR_ENABLE_SEED", false),
+		MaxConcurrentRequests: config.GetEnvInt("CORRELATOR_MAX_CONCURRENT_REQUESTS", 0),
+		ConcurrencyRetryAfter: config.GetEnvDuration("CORRELATOR_CONCURRENCY_RETRY_AFTER", defaultShedRetryAfter),
+		BodyReadMinBytes:      config.GetEnvInt64("CORRELATOR_BODY_READ_MIN_BYTES", defaultBodyReadMinBytes),
+		BodyReadWindow:        config.GetEnvDuration("CORRELATOR_BODY_READ_WINDOW", defaultBodyReadWindow),
+		VerboseLogProducers:   config.ParseCommaSeparatedList(config.GetEnvStr("CORRELATOR_VERBOSE_LOG_PRODUCERS", "")),
+		ContentTypeSniffSize:  config.GetEnvInt("CORRELATOR_CONTENT_TYPE_SNIFF_SIZE", 0),
+		DefaultPageSize:       config.GetEnvInt("CORRELATOR_DEFAULT_PAGE_SIZE", defaultPageSize),
+		MaxPageSize:           config.GetEnvInt("CORRELATOR_MAX_PAGE_SIZE", defaultMaxPageSize),
+		TLSCertFile:           config.GetEnvStr("CORRELATOR_TLS_CERT_FILE", ""),
+		TLSKeyFile:            config.GetEnvStr("CORRELATOR_TLS_KEY_FILE", ""),
+		TLSClientCAFile:       config.GetEnvStr("CORRELATOR_TLS_CLIENT_CA_FILE", ""),
 	}
 }
 
@@ -101,10 +255,11 @@ func (c *ServerConfig) Address() string {
 // ToCORSConfig converts ServerConfig CORS fields to middleware.CORSConfigProvider.
 func (c *ServerConfig) ToCORSConfig() *CORSConfig {
 	return &CORSConfig{
-		AllowedOrigins: c.CORSAllowedOrigins,
-		AllowedMethods: c.CORSAllowedMethods,
-		AllowedHeaders: c.CORSAllowedHeaders,
-		MaxAge:         c.CORSMaxAge,
+		AllowedOrigins:   c.CORSAllowedOrigins,
+		AllowedMethods:   c.CORSAllowedMethods,
+		AllowedHeaders:   c.CORSAllowedHeaders,
+		MaxAge:           c.CORSMaxAge,
+		AllowCredentials: c.CORSAllowCredentials,
 	}
 }
 
@@ -128,6 +283,11 @@ func (c *CORSConfig) GetMaxAge() int {
 	return c.MaxAge
 }
 
+// GetAllowCredentials returns whether credentialed CORS requests are allowed.
+func (c *CORSConfig) GetAllowCredentials() bool {
+	return c.AllowCredentials
+}
+
 // Validate validates the server configuration.
 func (c *ServerConfig) Validate() error {
 	if c.Port <= 0 || c.Port > maxPort {
@@ -154,5 +314,106 @@ func (c *ServerConfig) Validate() error {
 		return fmt.Errorf("%w: got %d bytes", ErrInvalidMaxRequestSize, c.MaxRequestSize)
 	}
 
+	if c.MaxImportSize <= 0 || c.MaxImportPartSize <= 0 {
+		return fmt.Errorf("%w: got total=%d part=%d bytes",
+			ErrInvalidMaxImportSize, c.MaxImportSize, c.MaxImportPartSize)
+	}
+
+	if c.CORSAllowCredentials && slices.Contains(c.CORSAllowedOrigins, "*") {
+		return fmt.Errorf("%w: set CORRELATOR_CORS_ALLOWED_ORIGINS instead of \"*\"", ErrInvalidCORSCredentials)
+	}
+
+	if _, err := middleware.ParseTrustedProxies(c.TrustedProxies); err != nil {
+		return err
+	}
+
+	if err := c.validatePreAuth(); err != nil {
+		return err
+	}
+
+	if c.MaintenanceDeadTuplePercent < 1 || c.MaintenanceDeadTuplePercent > maxPercent {
+		return fmt.Errorf("%w: got %d", ErrInvalidDeadTuplePercent, c.MaintenanceDeadTuplePercent)
+	}
+
+	if c.MaintenanceRetryAfter <= 0 {
+		return fmt.Errorf("%w: got %v", ErrInvalidRetryAfter, c.MaintenanceRetryAfter)
+	}
+
+	if c.BodyReadMinBytes < 0 || c.BodyReadWindow < 0 {
+		return fmt.Errorf("%w: got %d bytes per %v", ErrInvalidBodyReadRate, c.BodyReadMinBytes, c.BodyReadWindow)
+	}
+
+	if err := c.validateTLS(); err != nil {
+		return err
+	}
+
+	if c.ContentTypeSniffSize < 0 {
+		return fmt.Errorf("%w: got %d bytes", ErrInvalidContentTypeSniffSize, c.ContentTypeSniffSize)
+	}
+
+	if err := c.validatePageSizes(); err != nil {
+		return err
+	}
+
+	return c.validateConcurrencyLimit()
+}
+
+// pageSizes returns the default and max limit of list endpoints, with the built-in defaults for
+// unset (zero) settings.
+func (c *ServerConfig) pageSizes() (int, int) {
+	defaultSize, maxSize := c.DefaultPageSize, c.MaxPageSize
+
+	if defaultSize == 0 {
+		defaultSize = min(defaultPageSize, max(maxSize, 1))
+	}
+
+	if maxSize == 0 {
+		maxSize = max(defaultMaxPageSize, defaultSize)
+	}
+
+	return defaultSize, maxSize
+}
+
+// validatePageSizes validates the pagination settings of list endpoints.
+func (c *ServerConfig) validatePageSizes() error {
+	defaultSize, maxSize := c.pageSizes()
+
+	if c.DefaultPageSize < 0 || c.MaxPageSize < 0 || defaultSize > maxSize {
+		return fmt.Errorf("%w: got default=%d max=%d", ErrInvalidPageSize, c.DefaultPageSize, c.MaxPageSize)
+	}
+
+	return nil
+}
+
+// validatePreAuth validates the pre-authentication settings. A header without trusted sources
+// is refused rather than ignored: it means the operator expects pre-authentication to work.
+func (c *ServerConfig) validatePreAuth() error {
+	if c.PreAuthHeader == "" {
+		return nil
+	}
+
+	sources, err := middleware.ParseTrustedProxies(c.PreAuthTrustedSources)
+	if err != nil {
+		return err
+	}
+
+	if len(sources) == 0 {
+		return fmt.Errorf("%w: set CORRELATOR_PREAUTH_TRUSTED_SOURCES for header %q", ErrInvalidPreAuth, c.PreAuthHeader)
+	}
+
+	return nil
+}
+
+// validateConcurrencyLimit validates the load shedding settings. The Retry-After is only used,
+// and therefore only checked, when a limit is configured.
+func (c *ServerConfig) validateConcurrencyLimit() error {
+	if c.MaxConcurrentRequests < 0 {
+		return fmt.Errorf("%w: got %d", ErrInvalidMaxConcurrentRequests, c.MaxConcurrentRequests)
+	}
+
+	if c.MaxConcurrentRequests > 0 && c.ConcurrencyRetryAfter <= 0 {
+		return fmt.Errorf("%w: got %v", ErrInvalidConcurrencyRetryAfter, c.ConcurrencyRetryAfter)
+	}
+
 	return nil
 }
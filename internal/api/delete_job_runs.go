@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// permissionAdmin is the API key scope required for destructive administrative operations.
+const permissionAdmin = "admin"
+
+type (
+	// JobRunCleanupStore is the interface the API layer uses to bulk-delete test data.
+	// Defined here (consumer in api package) following the Dependency Inversion Principle.
+	//
+	// Implemented by: storage.LineageStore.
+	JobRunCleanupStore interface {
+		DeleteJobRuns(ctx context.Context, filter storage.JobRunDeleteFilter) (storage.JobRunDeleteResult, error)
+	}
+
+	// deleteJobRunsResponse is the response from DELETE /api/v1/lineage/job-runs.
+	deleteJobRunsResponse struct {
+		Namespace string              `json:"namespace"`
+		Before    time.Time           `json:"before"`
+		DryRun    bool                `json:"dry_run"` //nolint:tagliatelle
+		Deleted   deleteJobRunsCounts `json:"deleted"`
+	}
+
+	// deleteJobRunsCounts counts rows removed (or that would be removed on a dry run).
+	deleteJobRunsCounts struct {
+		JobRuns      int64 `json:"job_runs"`      //nolint:tagliatelle
+		LineageEdges int64 `json:"lineage_edges"` //nolint:tagliatelle
+		TestResults  int64 `json:"test_results"`  //nolint:tagliatelle
+		Datasets     int64 `json:"datasets"`
+	}
+)
+
+// handleDeleteJobRuns handles DELETE /api/v1/lineage/job-runs.
+// Deletes job runs in a namespace recorded before a cutoff, with their lineage edges, test results,
+// and datasets nothing else references, in one transaction. Meant for CI / e2e data cleanup.
+//
+// Query Parameters:
+//   - namespace: exact job namespace (required)
+//   - before: RFC 3339 timestamp; only runs first recorded before it (required)
+//   - limit: max runs per call, oldest first (1-10000, default: 10000)
+//   - dry_run: "true" to return the counts without deleting
+//
+// Unfiltered deletes are refused with 400. Call repeatedly until deleted.job_runs is 0 to
+// remove more than limit runs.
+//
+// Requires the admin permission when authentication is enabled (403 otherwise).
+// The route is only registered when CORRELATOR_LINEAGE_DELETE_ENABLED=true.
+func (s *Server) handleDeleteJobRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	correlationID := middleware.GetCorrelationID(ctx)
+
+	if !s.requirePermission(w, r, permissionAdmin) {
+		return
+	}
+
+	filter, err := parseJobRunDeleteFilter(r)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
+
+		return
+	}
+
+	result, err := s.jobRunCleanupStore.DeleteJobRuns(ctx, filter)
+	if errors.Is(err, storage.ErrUnfilteredJobRunDelete) {
+		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
+
+		return
+	}
+
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete job runs",
+			slog.String("correlation_id", correlationID),
+			slog.String("namespace", filter.Namespace),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to delete job runs"))
+
+		return
+	}
+
+	clientCtx, _ := middleware.GetClientContext(ctx)
+
+	s.logger.WarnContext(ctx, "Job runs deleted via API",
+		slog.String("correlation_id", correlationID),
+		slog.String("client_id", clientCtx.ClientID),
+		slog.String("namespace", filter.Namespace),
+		slog.Time("before", filter.Before),
+		slog.Bool("dry_run", filter.DryRun),
+		slog.Int64("job_runs", result.JobRuns),
+	)
+
+	data, err := json.Marshal(deleteJobRunsResponse{
+		Namespace: filter.Namespace,
+		Before:    filter.Before,
+		DryRun:    filter.DryRun,
+		Deleted: deleteJobRunsCounts{
+			JobRuns:      result.JobRuns,
+			LineageEdges: result.LineageEdges,
+			TestResults:  result.TestResults,
+			Datasets:     result.Datasets,
+		},
+	})
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// parseJobRunDeleteFilter parses and validates the delete query parameters.
+// namespace and before are mandatory so a request can never match every run.
+func parseJobRunDeleteFilter(r *http.Request) (storage.JobRunDeleteFilter, error) {
+	q := r.URL.Query()
+
+	filter := storage.JobRunDeleteFilter{
+		Namespace: strings.TrimSpace(q.Get("namespace")),
+		Limit:     storage.MaxJobRunDeleteLimit,
+	}
+
+	if filter.Namespace == "" {
+		return filter, &paramError{param: "namespace", msg: "is required (unfiltered deletes are refused)"}
+	}
+
+	beforeStr := q.Get("before")
+	if beforeStr == "" {
+		return filter, &paramError{param: "before", msg: "is required (unfiltered deletes are refused)"}
+	}
+
+	before, err := time.Parse(time.RFC3339, beforeStr)
+	if err != nil {
+		return filter, &paramError{param: "before", msg: "must be valid ISO8601 timestamp"}
+	}
+
+	filter.Before = before
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > storage.MaxJobRunDeleteLimit {
+			return filter, &paramError{param: "limit", msg: "must be between 1 and 10000"}
+		}
+
+		filter.Limit = limit
+	}
+
+	if dryRunStr := q.Get("dry_run"); dryRunStr != "" {
+		dryRun, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			return filter, &paramError{param: "dry_run", msg: "must be true or false"}
+		}
+
+		filter.DryRun = dryRun
+	}
+
+	return filter, nil
+}
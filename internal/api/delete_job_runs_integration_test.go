@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// setupDeleteTestServer starts a server with bulk delete enabled and returns it with an admin
+// key and a lineage:write-only key.
+func setupDeleteTestServer(ctx context.Context, t *testing.T) (*httptest.Server, string, string) {
+	t.Helper()
+
+	server := setupAPITestServer(ctx, t, []testAPIKey{
+		{id: "delete-admin-key", permissions: []string{"admin", "lineage:write"}},
+		{id: "delete-write-key", permissions: []string{"lineage:write"}},
+	}, func(_ *ServerConfig, deps *Dependencies, lineageStore *storage.LineageStore) {
+		deps.JobRunCleanupStore = lineageStore
+	})
+
+	return server.httpServer, server.keys["delete-admin-key"], server.keys["delete-write-key"]
+}
+
+func TestDeleteJobRuns_Endpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	httpServer, adminKey, writeKey := setupDeleteTestServer(ctx, t)
+
+	deleteRuns := func(apiKey string, query url.Values) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+			httpServer.URL+"/api/v1/lineage/job-runs?"+query.Encode(), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+
+		resp, err := httpServer.Client().Do(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	before := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+
+	t.Run("RequiresAdmin", func(t *testing.T) {
+		resp := deleteRuns(writeKey, url.Values{"namespace": {"test://e2e"}, "before": {before}})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("RefusesUnfilteredDelete", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{},
+			{"namespace": {"test://e2e"}},
+			{"before": {before}},
+			{"namespace": {"test://e2e"}, "before": {"yesterday"}},
+			{"namespace": {"test://e2e"}, "before": {before}, "limit": {"0"}},
+		} {
+			resp := deleteRuns(adminKey, query)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "query %v", query)
+			assert.Equal(t, contentTypeProblemJSON, resp.Header.Get("Content-Type"))
+		}
+	})
+
+	t.Run("DeletesWithFilters", func(t *testing.T) {
+		resp := deleteRuns(adminKey, url.Values{"namespace": {"test://e2e"}, "before": {before}, "dry_run": {"true"}})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body deleteJobRunsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+		assert.Equal(t, "test://e2e", body.Namespace)
+		assert.True(t, body.DryRun)
+		assert.Equal(t, int64(0), body.Deleted.JobRuns)
+	})
+}
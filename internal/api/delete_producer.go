@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+type (
+	// ProducerPurgeStore is the interface the API layer uses to delete all lineage of a producer.
+	// Defined here (consumer in api package) following the Dependency Inversion Principle.
+	//
+	// Implemented by: storage.LineageStore.
+	ProducerPurgeStore interface {
+		PurgeProducer(ctx context.Context, producerName string) (int, error)
+	}
+
+	// purgeProducerResponse is the response from DELETE /api/v1/producers/{producer}.
+	purgeProducerResponse struct {
+		ProducerName string `json:"producer_name"`    //nolint:tagliatelle
+		JobRuns      int    `json:"job_runs_deleted"` //nolint:tagliatelle
+	}
+)
+
+// handlePurgeProducer handles DELETE /api/v1/producers/{producer}.
+// Deletes every job run of a producer (named as GET /api/v1/producers lists it) with its lineage
+// edges and test results, and the datasets no other producer's runs reference. Meant for
+// offboarding an integration.
+//
+// Query Parameters:
+//   - confirm: the producer name again (required); guards against deleting the wrong producer
+//
+// Only registered with authentication enabled (see routes.go); requires the admin permission (403 otherwise).
+func (s *Server) handlePurgeProducer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	correlationID := middleware.GetCorrelationID(ctx)
+
+	if !s.requirePermission(w, r, permissionAdmin) {
+		return
+	}
+
+	producer, err := parseProducerPurge(r)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
+
+		return
+	}
+
+	deleted, err := s.producerPurge.PurgeProducer(ctx, producer)
+	if errors.Is(err, storage.ErrMissingProducerName) {
+		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
+
+		return
+	}
+
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to purge producer",
+			slog.String("correlation_id", correlationID),
+			slog.String("producer", producer),
+			slog.Int("job_runs_deleted", deleted),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to purge producer"))
+
+		return
+	}
+
+	clientCtx, _ := middleware.GetClientContext(ctx)
+
+	s.logger.WarnContext(ctx, "Producer purged via API",
+		slog.String("correlation_id", correlationID),
+		slog.String("client_id", clientCtx.ClientID),
+		slog.String("producer", producer),
+		slog.Int("job_runs_deleted", deleted),
+	)
+
+	data, err := json.Marshal(purgeProducerResponse{ProducerName: producer, JobRuns: deleted})
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// parseProducerPurge returns the producer to purge once the request confirms it: the confirm
+// query parameter must repeat the producer name exactly.
+func parseProducerPurge(r *http.Request) (string, error) {
+	producer := strings.TrimSpace(r.PathValue("producer"))
+	if producer == "" {
+		return "", &paramError{param: "producer", msg: "is required"}
+	}
+
+	if r.URL.Query().Get("confirm") != producer {
+		return "", &paramError{param: "confirm", msg: "must repeat the producer name to purge"}
+	}
+
+	return producer, nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// setupPurgeTestServer starts a server with a producer purge store and returns it with its
+// lineage store, an admin key and a lineage:write-only key. Without auth the server gets no key store.
+func setupPurgeTestServer(
+	ctx context.Context, t *testing.T, auth bool,
+) (*httptest.Server, *storage.LineageStore, string, string) {
+	t.Helper()
+
+	server := setupAPITestServer(ctx, t, []testAPIKey{
+		{id: "purge-admin-key", permissions: []string{"admin", "lineage:write"}},
+		{id: "purge-write-key", permissions: []string{"lineage:write"}},
+	}, func(_ *ServerConfig, deps *Dependencies, lineageStore *storage.LineageStore) {
+		deps.ProducerStore = lineageStore
+		deps.ProducerPurgeStore = lineageStore
+
+		if !auth {
+			deps.APIKeyStore = nil
+		}
+	})
+
+	return server.httpServer, server.lineageStore, server.keys["purge-admin-key"], server.keys["purge-write-key"]
+}
+
+func TestPurgeProducer_Endpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	httpServer, lineageStore, adminKey, writeKey := setupPurgeTestServer(ctx, t, true)
+
+	for i, engine := range []string{"legacy-plugin", "legacy-plugin", "spark"} {
+		_, _, err := lineageStore.StoreEvent(ctx, &ingestion.RunEvent{
+			EventTime:        time.Now(),
+			EventType:        ingestion.EventTypeComplete,
+			Producer:         "https://github.com/OpenLineage/OpenLineage/tree/1.9.0/integration/spark",
+			SchemaURL:        "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+			Run:              ingestion.Run{ID: uuid.New().String(), Facets: ingestion.Facets{}},
+			Job:              ingestion.Job{Namespace: "spark://cluster", Name: "job_" + string(rune('a'+i))},
+			ProcessingEngine: &ingestion.ProcessingEngine{Name: engine},
+		})
+		require.NoError(t, err)
+	}
+
+	purge := func(apiKey, producer string, query url.Values) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+			httpServer.URL+"/api/v1/producers/"+url.PathEscape(producer)+"?"+query.Encode(), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+
+		resp, err := httpServer.Client().Do(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	t.Run("RequiresAdmin", func(t *testing.T) {
+		resp := purge(writeKey, "legacy-plugin", url.Values{"confirm": {"legacy-plugin"}})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("RequiresConfirmation", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{},
+			{"confirm": {""}},
+			{"confirm": {"spark"}},
+			{"confirm": {"Legacy-Plugin"}},
+		} {
+			resp := purge(adminKey, "legacy-plugin", query)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "query %v", query)
+			assert.Equal(t, contentTypeProblemJSON, resp.Header.Get("Content-Type"))
+		}
+	})
+
+	t.Run("PurgesConfirmedProducer", func(t *testing.T) {
+		resp := purge(adminKey, "legacy-plugin", url.Values{"confirm": {"legacy-plugin"}})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body purgeProducerResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, purgeProducerResponse{ProducerName: "legacy-plugin", JobRuns: 2}, body)
+
+		summaries, err := lineageStore.QueryProducerSummaries(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		assert.Equal(t, "spark", summaries[0].ProducerName)
+	})
+}
+
+func TestPurgeProducer_NotRegisteredWithoutAuth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	httpServer, _, _, _ := setupPurgeTestServer(ctx, t, false)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+		httpServer.URL+"/api/v1/producers/legacy-plugin?confirm=legacy-plugin", nil)
+	require.NoError(t, err)
+
+	resp, err := httpServer.Client().Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
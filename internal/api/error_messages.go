@@ -0,0 +1,169 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+)
+
+// defaultLanguage is the language problem details are written in and the fallback
+// when Accept-Language names no supported language.
+const defaultLanguage = "en"
+
+// errorMessageCatalog translates problem titles and common details, keyed by language
+// and then by the English message. Messages without an entry (e.g. details that embed
+// request values) are returned in English. The problem type URI and status are never
+// translated, so clients should branch on those rather than on title or detail.
+//
+//nolint:gochecknoglobals // Read-only message catalog
+var errorMessageCatalog = map[string]map[string]string{
+	"es": {
+		// Titles
+		"Bad Request":            "Solicitud incorrecta",
+		"Unauthorized":           "No autorizado",
+		"Forbidden":              "Prohibido",
+		"Not Found":              "No encontrado",
+		"Method Not Allowed":     "Método no permitido",
+		"Conflict":               "Conflicto",
+		"Precondition Failed":    "Precondición fallida",
+		"Payload Too Large":      "Carga demasiado grande",
+		"Unsupported Media Type": "Tipo de medio no admitido",
+		"Unprocessable Entity":   "Entidad no procesable",
+		"Internal Server Error":  "Error interno del servidor",
+		"Quota Exceeded":         "Cuota excedida",
+		// Details
+		"Content-Type must be application/json":        "Content-Type debe ser application/json",
+		"Content-Type must be multipart/form-data":     "Content-Type debe ser multipart/form-data",
+		"Request body cannot be empty":                 "El cuerpo de la solicitud no puede estar vacío",
+		"Request body must be a JSON object":           "El cuerpo de la solicitud debe ser un objeto JSON",
+		"Invalid JSON request body":                    "El cuerpo de la solicitud no es JSON válido",
+		"Event array cannot be empty":                  "El arreglo de eventos no puede estar vacío",
+		"Missing incident ID":                          "Falta el ID del incidente",
+		"Invalid incident ID: must be a numeric value": "ID de incidente no válido: debe ser un valor numérico",
+		"Incident not found":                           "Incidente no encontrado",
+		"API key not found":                            "Clave de API no encontrada",
+		"The requested resource was not found":         "No se encontró el recurso solicitado",
+		"Failed to encode response":                    "No se pudo codificar la respuesta",
+		"Multipart body must contain at least one file part": "El cuerpo multipart debe contener al menos " +
+			"una parte de archivo",
+	},
+	"fr": {
+		// Titles
+		"Bad Request":            "Requête incorrecte",
+		"Unauthorized":           "Non autorisé",
+		"Forbidden":              "Interdit",
+		"Not Found":              "Introuvable",
+		"Method Not Allowed":     "Méthode non autorisée",
+		"Conflict":               "Conflit",
+		"Precondition Failed":    "Échec de la précondition",
+		"Payload Too Large":      "Charge utile trop volumineuse",
+		"Unsupported Media Type": "Type de média non pris en charge",
+		"Unprocessable Entity":   "Entité non traitable",
+		"Internal Server Error":  "Erreur interne du serveur",
+		"Quota Exceeded":         "Quota dépassé",
+		// Details
+		"Content-Type must be application/json":        "Content-Type doit être application/json",
+		"Content-Type must be multipart/form-data":     "Content-Type doit être multipart/form-data",
+		"Request body cannot be empty":                 "Le corps de la requête ne peut pas être vide",
+		"Request body must be a JSON object":           "Le corps de la requête doit être un objet JSON",
+		"Invalid JSON request body":                    "Le corps de la requête n'est pas un JSON valide",
+		"Event array cannot be empty":                  "Le tableau d'événements ne peut pas être vide",
+		"Missing incident ID":                          "Identifiant d'incident manquant",
+		"Invalid incident ID: must be a numeric value": "Identifiant d'incident invalide : doit être numérique",
+		"Incident not found":                           "Incident introuvable",
+		"API key not found":                            "Clé d'API introuvable",
+		"The requested resource was not found":         "La ressource demandée est introuvable",
+		"Failed to encode response":                    "Impossible d'encoder la réponse",
+		"Multipart body must contain at least one file part": "Le corps multipart doit contenir au moins " +
+			"une partie fichier",
+	},
+	"de": {
+		// Titles
+		"Bad Request":            "Ungültige Anfrage",
+		"Unauthorized":           "Nicht autorisiert",
+		"Forbidden":              "Verboten",
+		"Not Found":              "Nicht gefunden",
+		"Method Not Allowed":     "Methode nicht erlaubt",
+		"Conflict":               "Konflikt",
+		"Precondition Failed":    "Vorbedingung fehlgeschlagen",
+		"Payload Too Large":      "Nutzlast zu groß",
+		"Unsupported Media Type": "Nicht unterstützter Medientyp",
+		"Unprocessable Entity":   "Nicht verarbeitbare Entität",
+		"Internal Server Error":  "Interner Serverfehler",
+		"Quota Exceeded":         "Kontingent überschritten",
+		// Details
+		"Content-Type must be application/json":        "Content-Type muss application/json sein",
+		"Content-Type must be multipart/form-data":     "Content-Type muss multipart/form-data sein",
+		"Request body cannot be empty":                 "Der Anfragetext darf nicht leer sein",
+		"Request body must be a JSON object":           "Der Anfragetext muss ein JSON-Objekt sein",
+		"Invalid JSON request body":                    "Der Anfragetext ist kein gültiges JSON",
+		"Event array cannot be empty":                  "Das Event-Array darf nicht leer sein",
+		"Missing incident ID":                          "Incident-ID fehlt",
+		"Invalid incident ID: must be a numeric value": "Ungültige Incident-ID: muss numerisch sein",
+		"Incident not found":                           "Incident nicht gefunden",
+		"API key not found":                            "API-Schlüssel nicht gefunden",
+		"The requested resource was not found":         "Die angeforderte Ressource wurde nicht gefunden",
+		"Failed to encode response":                    "Antwort konnte nicht kodiert werden",
+		"Multipart body must contain at least one file part": "Der Multipart-Text muss mindestens " +
+			"einen Dateiteil enthalten",
+	},
+}
+
+// negotiateLanguage picks the supported language with the highest quality value from an
+// Accept-Language header (RFC 9110 section 12.5.4). Region subtags match their base
+// language ("es-MX" selects "es"). Returns defaultLanguage when nothing supported is
+// acceptable.
+func negotiateLanguage(acceptLanguage string) string {
+	best, bestQuality := defaultLanguage, 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		quality := 1.0
+
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if base == "*" {
+			base = defaultLanguage
+		}
+
+		if base != defaultLanguage && errorMessageCatalog[base] == nil {
+			continue
+		}
+
+		// Strictly greater keeps the first of equally preferred languages
+		if quality > bestQuality {
+			best, bestQuality = base, quality
+		}
+	}
+
+	return best
+}
+
+// localizeProblem returns a copy of problem with title and detail translated into
+// language where the catalog has a translation. The original is left unchanged.
+func localizeProblem(problem *ProblemDetail, language string) *ProblemDetail {
+	messages := errorMessageCatalog[language]
+	if messages == nil {
+		return problem
+	}
+
+	localized := *problem
+
+	if title, ok := messages[problem.Title]; ok {
+		localized.Title = title
+	}
+
+	if detail, ok := messages[problem.Detail]; ok {
+		localized.Detail = detail
+	}
+
+	return &localized
+}
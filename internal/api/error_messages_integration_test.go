@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteErrorResponse_AcceptLanguage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	write := func(
+		t *testing.T, acceptLanguage string, problem *ProblemDetail,
+	) (*httptest.ResponseRecorder, ProblemDetail) {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage", nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+
+		rr := httptest.NewRecorder()
+		WriteErrorResponse(rr, req, logger, problem)
+
+		var body ProblemDetail
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+		return rr, body
+	}
+
+	t.Run("Translates Title And Known Detail", func(t *testing.T) {
+		problem := BadRequest("Request body cannot be empty")
+		rr, body := write(t, "es-MX,es;q=0.9,en;q=0.5", problem)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "es", rr.Header().Get("Content-Language"))
+		assert.Equal(t, "Accept-Language", rr.Header().Get("Vary"))
+		assert.Equal(t, "Solicitud incorrecta", body.Title)
+		assert.Equal(t, "El cuerpo de la solicitud no puede estar vacío", body.Detail)
+		assert.Equal(t, "https://getcorrelator.io/problems/400", body.Type, "type must stay language-neutral")
+		assert.Equal(t, http.StatusBadRequest, body.Status)
+		assert.Equal(t, "Bad Request", problem.Title, "caller's problem must not be modified")
+	})
+
+	t.Run("Keeps Untranslated Detail In English", func(t *testing.T) {
+		_, body := write(t, "de", UnprocessableEntity("mute_days must be <= 365"))
+
+		assert.Equal(t, "Nicht verarbeitbare Entität", body.Title)
+		assert.Equal(t, "mute_days must be <= 365", body.Detail)
+	})
+
+	t.Run("Honors Quality Values", func(t *testing.T) {
+		rr, body := write(t, "de;q=0.3, fr;q=0.8", NotFound("Incident not found"))
+
+		assert.Equal(t, "fr", rr.Header().Get("Content-Language"))
+		assert.Equal(t, "Introuvable", body.Title)
+		assert.Equal(t, "Incident introuvable", body.Detail)
+	})
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+	}{
+		{name: "No Header", acceptLanguage: ""},
+		{name: "Unsupported Language", acceptLanguage: "ja-JP,ja;q=0.9"},
+		{name: "Wildcard", acceptLanguage: "*"},
+		{name: "Supported Language Refused", acceptLanguage: "es;q=0, ja"},
+		{name: "Malformed Header", acceptLanguage: "es;q=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run("Falls Back To English For "+tt.name, func(t *testing.T) {
+			rr, body := write(t, tt.acceptLanguage, BadRequest("Request body cannot be empty"))
+
+			assert.Equal(t, "en", rr.Header().Get("Content-Language"))
+			assert.Equal(t, "Bad Request", body.Title)
+			assert.Equal(t, "Request body cannot be empty", body.Detail)
+		})
+	}
+}
@@ -20,6 +20,7 @@ var commonProblemTypes = map[int]string{
 	http.StatusForbidden:             "https://getcorrelator.io/problems/403",
 	http.StatusNotFound:              "https://getcorrelator.io/problems/404",
 	http.StatusMethodNotAllowed:      "https://getcorrelator.io/problems/405",
+	http.StatusRequestTimeout:        "https://getcorrelator.io/problems/408",
 	http.StatusRequestEntityTooLarge: "https://getcorrelator.io/problems/413",
 	http.StatusUnsupportedMediaType:  "https://getcorrelator.io/problems/415",
 	http.StatusConflict:              "https://getcorrelator.io/problems/409",
@@ -27,6 +28,10 @@ var commonProblemTypes = map[int]string{
 	http.StatusInternalServerError:   "https://getcorrelator.io/problems/500",
 }
 
+// quotaExceededProblemType identifies requests rejected by a plugin's ingestion quota, so clients
+// can tell them from rate limiting (also 429).
+const quotaExceededProblemType = "https://getcorrelator.io/problems/quota-exceeded"
+
 // ProblemDetail represents an RFC 7807 Problem Details structure.
 // See https://tools.ietf.org/html/rfc7807 for specification.
 type ProblemDetail struct {
@@ -36,6 +41,12 @@ type ProblemDetail struct {
 	Detail        string `json:"detail,omitempty"`
 	Instance      string `json:"instance,omitempty"`
 	CorrelationID string `json:"correlation_id,omitempty"` //nolint: tagliatelle
+
+	// Extension members locating a malformed JSON body (see InvalidJSON)
+	Offset int64  `json:"offset,omitempty"` // Bytes read before the error
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+	Field  string `json:"field,omitempty"` // Path of the mistyped field
 }
 
 // NewProblemDetail creates a new RFC 7807 Problem Detail.
@@ -70,6 +81,8 @@ func (p *ProblemDetail) WithCorrelationID(correlationID string) *ProblemDetail {
 
 // WriteErrorResponse writes an RFC 7807 compliant error response.
 // Uses marshal-first pattern to ensure encoding errors are caught before headers are sent.
+// Title and detail are translated per the request's Accept-Language header when the
+// message catalog has them (English otherwise); type and status stay language-neutral.
 func WriteErrorResponse(w http.ResponseWriter, r *http.Request, logger *slog.Logger, problem *ProblemDetail) {
 	correlationID := middleware.GetCorrelationID(r.Context())
 
@@ -83,8 +96,10 @@ func WriteErrorResponse(w http.ResponseWriter, r *http.Request, logger *slog.Log
 		problem.Instance = r.URL.Path
 	}
 
+	language := negotiateLanguage(r.Header.Get("Accept-Language"))
+
 	// Marshal FIRST (before writing anything) - fail fast if encoding fails
-	body, err := json.Marshal(problem)
+	body, err := json.Marshal(localizeProblem(problem, language))
 	if err != nil {
 		logger.Error("Failed to marshal error response",
 			slog.String("correlation_id", correlationID),
@@ -102,6 +117,8 @@ func WriteErrorResponse(w http.ResponseWriter, r *http.Request, logger *slog.Log
 
 	// Now write headers and body atomically
 	w.Header().Set("Content-Type", contentTypeProblemJSON)
+	w.Header().Set("Content-Language", language)
+	w.Header().Add("Vary", "Accept-Language")
 	w.WriteHeader(problem.Status)
 
 	if _, err := w.Write(body); err != nil {
@@ -172,6 +189,15 @@ func Forbidden(detail string) *ProblemDetail {
 	)
 }
 
+// RequestTimeout creates a 408 Request Timeout problem.
+func RequestTimeout(detail string) *ProblemDetail {
+	return NewProblemDetail(
+		http.StatusRequestTimeout,
+		"Request Timeout",
+		detail,
+	)
+}
+
 // PayloadTooLarge creates a 413 Payload Too Large problem.
 func PayloadTooLarge(detail string) *ProblemDetail {
 	return NewProblemDetail(
@@ -207,3 +233,25 @@ func Conflict(detail string) *ProblemDetail {
 		detail,
 	)
 }
+
+// PreconditionFailed creates a 412 Precondition Failed problem.
+func PreconditionFailed(detail string) *ProblemDetail {
+	return NewProblemDetail(
+		http.StatusPreconditionFailed,
+		"Precondition Failed",
+		detail,
+	)
+}
+
+// QuotaExceeded creates a 429 Too Many Requests problem of type quota-exceeded: the plugin used
+// up its daily or monthly ingestion quota. Unlike a rate limit, retrying soon does not help.
+func QuotaExceeded(detail string) *ProblemDetail {
+	problem := NewProblemDetail(
+		http.StatusTooManyRequests,
+		"Quota Exceeded",
+		detail,
+	)
+	problem.Type = quotaExceededProblemType
+
+	return problem
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// parseFields reads the ?fields= query parameter (comma-separated top-level JSON field names
+// of T, e.g. ?fields=job,resolution_status) for a sparse fieldset response.
+//
+// Returns nil fields when the parameter is absent or empty (full response). Unknown names are
+// always rejected with a 400 ProblemDetail, even for fields omitted from a particular response
+// by omitempty, so a typo fails loudly instead of silently returning less data.
+func parseFields[T any](r *http.Request) ([]string, *ProblemDetail) {
+	raw := r.URL.Query().Get("fields")
+	if raw == "" {
+		return nil, nil
+	}
+
+	known := jsonFieldNames(reflect.TypeFor[T]())
+
+	var fields, unknown []string
+
+	for name := range strings.SplitSeq(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(fields, name) {
+			continue
+		}
+
+		if !known[name] {
+			unknown = append(unknown, name)
+
+			continue
+		}
+
+		fields = append(fields, name)
+	}
+
+	if len(unknown) > 0 {
+		return nil, BadRequest(fmt.Sprintf("Unknown field(s) in fields parameter: %s", strings.Join(unknown, ", ")))
+	}
+
+	return fields, nil
+}
+
+// marshalFields encodes v as JSON keeping only the given top-level fields.
+// With no fields the full object is encoded (same as json.Marshal).
+func marshalFields(v any, fields []string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(fields) == 0 {
+		return data, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	for name := range object {
+		if !slices.Contains(fields, name) {
+			delete(object, name)
+		}
+	}
+
+	return json.Marshal(object)
+}
+
+// jsonFieldNames returns the JSON names of the exported fields of struct type t,
+// including promoted fields of embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" && f.Anonymous && f.Type.Kind() == reflect.Struct {
+			for embedded := range jsonFieldNames(f.Type) {
+				names[embedded] = true
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		names[name] = true
+	}
+
+	return names
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/correlator-io/correlator/internal/api/middleware"
@@ -20,10 +19,20 @@ import (
 //   - correlated_incidents = incidents with lineage edges (in incident_correlation_view)
 //   - total_incidents = ALL failed/error test results
 //   - If total_incidents = 0, returns 1.0 (no incidents = healthy)
+//
+// Query Parameters:
+//   - fields: Optional comma-separated top-level fields to return (e.g., correlation_rate)
 func (s *Server) handleGetCorrelationHealth(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	correlationID := middleware.GetCorrelationID(ctx)
 
+	fields, problem := parseFields[CorrelationHealthResponse](r)
+	if problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
 	health, err := s.correlationStore.QueryCorrelationHealth(ctx)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Failed to query correlation health",
@@ -37,7 +46,7 @@ func (s *Server) handleGetCorrelationHealth(w http.ResponseWriter, r *http.Reque
 
 	response := mapHealthToResponse(health)
 
-	data, err := json.Marshal(response)
+	data, err := marshalFields(response, fields)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Failed to marshal correlation health response",
 			"correlation_id", correlationID,
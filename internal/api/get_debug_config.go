@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/config"
+)
+
+// handleGetDebugConfig handles GET /api/v1/debug/config.
+// Returns the configuration the server is actually running with: the resolved server settings
+// (including command line overrides), whether authentication, maintenance mode and read-only mode
+// are on, and every environment variable read at startup with its effective value and source.
+//
+// Secrets never leave the process: variables naming a secret are fully masked and passwords in
+// connection strings are redacted (see config.Settings).
+//
+// Only registered with authentication enabled (see routes.go); requires the admin permission (403 otherwise).
+func (s *Server) handleGetDebugConfig(w http.ResponseWriter, r *http.Request) {
+	if !s.requirePermission(w, r, permissionAdmin) {
+		return
+	}
+
+	settings := config.Settings()
+	environment := make([]ConfigSetting, 0, len(settings))
+
+	for _, setting := range settings {
+		environment = append(environment, ConfigSetting(setting))
+	}
+
+	response := DebugConfigResponse{
+		Version:         s.buildInfo.Version,
+		AuthEnabled:     s.apiKeyStore != nil,
+		MaintenanceMode: s.maintenanceMode.Enabled(),
+		ReadOnly:        s.config.ReadOnly,
+		Server: DebugServerConfig{
+			Host:                  s.config.Host,
+			Port:                  s.config.Port,
+			ReadTimeout:           s.config.ReadTimeout.String(),
+			WriteTimeout:          s.config.WriteTimeout.String(),
+			ShutdownTimeout:       s.config.ShutdownTimeout.String(),
+			LogLevel:              s.config.LogLevel.String(),
+			MaxRequestSize:        s.config.MaxRequestSize,
+			MaxConcurrentRequests: s.config.MaxConcurrentRequests,
+			CORSAllowedOrigins:    s.config.CORSAllowedOrigins,
+			TrustedProxies:        s.config.TrustedProxies,
+		},
+		Environment: environment,
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		s.logger.ErrorContext(r.Context(), "Failed to marshal debug config response",
+			slog.String("correlation_id", middleware.GetCorrelationID(r.Context())),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
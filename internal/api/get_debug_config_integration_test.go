@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/config"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+func TestGetDebugConfig_Endpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	httpServer, adminKey, writeKey := setupKeyAdminTestServer(ctx, t)
+
+	// pragma: allowlist nextline secret
+	t.Setenv("TEST_DEBUG_DATABASE_URL", "postgres://correlator:hunter2@db:5432/correlator?sslpassword=s3cret")
+	t.Setenv("TEST_DEBUG_WEBHOOK_TOKEN", "tok-abc123")
+	config.GetEnvStr("TEST_DEBUG_DATABASE_URL", "")
+	config.GetEnvStr("TEST_DEBUG_WEBHOOK_TOKEN", "")
+
+	getConfig := func(apiKey string) (*http.Response, []byte) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+"/api/v1/debug/config", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+
+		resp, err := httpServer.Client().Do(req)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp, body
+	}
+
+	t.Run("RequiresAdmin", func(t *testing.T) {
+		resp, _ := getConfig(writeKey)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("ReturnsMaskedConfiguration", func(t *testing.T) {
+		resp, body := getConfig(adminKey)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+
+		for _, secret := range []string{"hunter2", "s3cret", "tok-abc123", adminKey, writeKey} {
+			assert.NotContains(t, string(body), secret, "Secrets must never be returned")
+		}
+
+		var response DebugConfigResponse
+		require.NoError(t, json.Unmarshal(body, &response))
+
+		assert.True(t, response.AuthEnabled)
+		assert.False(t, response.MaintenanceMode)
+		assert.Equal(t, "localhost", response.Server.Host)
+		assert.Equal(t, 8080, response.Server.Port)
+		assert.Equal(t, "30s", response.Server.ReadTimeout)
+
+		settings := make(map[string]ConfigSetting, len(response.Environment))
+		for _, setting := range response.Environment {
+			settings[setting.Key] = setting
+		}
+
+		assert.Equal(t, ConfigSetting{
+			Key:    "TEST_DEBUG_DATABASE_URL",
+			Value:  "postgres://correlator:xxxxx@db:5432/correlator?sslpassword=xxxxx",
+			Source: "env",
+		}, settings["TEST_DEBUG_DATABASE_URL"])
+		assert.Equal(t, "********", settings["TEST_DEBUG_WEBHOOK_TOKEN"].Value)
+	})
+}
+
+func TestGetDebugConfig_NotRegisteredWithoutAuth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	server := setupAPITestServer(ctx, t, nil, func(_ *ServerConfig, deps *Dependencies, _ *storage.LineageStore) {
+		deps.APIKeyStore = nil
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.httpServer.URL+"/api/v1/debug/config", nil)
+	require.NoError(t, err)
+
+	resp, err := server.httpServer.Client().Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
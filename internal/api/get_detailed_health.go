@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+)
+
+const (
+	componentDatabase    = "database"
+	componentSchema      = "schema"
+	componentRateLimiter = "rate_limiter"
+	componentAPIKeyStore = "api_key_store"
+
+	// workerLineageBatches is the async lineage batch worker, as reported in the detailed health.
+	workerLineageBatches = "lineage_batches"
+)
+
+// statusSeverity orders component statuses for the overall detailed health status.
+// Disabled and warning components never make the overall status worse.
+var statusSeverity = map[string]int{ //nolint:gochecknoglobals
+	statusHealthy:   0,
+	statusDegraded:  1,
+	statusUnhealthy: 2, //nolint:mnd
+}
+
+// RateLimiterHealthChecker is implemented by rate limiters backed by a shared store (e.g. Redis)
+// to report whether the store is reachable. In-memory limiters need no check.
+type RateLimiterHealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// detailedHealthResponse is the JSON response for GET /api/v1/health/detailed.
+type detailedHealthResponse struct {
+	Status     string                                `json:"status"`
+	Version    string                                `json:"version"`
+	Uptime     string                                `json:"uptime,omitempty"`
+	Components map[string]*detailedComponentResponse `json:"components"`
+	Workers    map[string]*detailedComponentResponse `json:"workers"`
+}
+
+// detailedComponentResponse is the JSON representation of one subsystem or background worker.
+type detailedComponentResponse struct {
+	Status    string     `json:"status"`
+	Message   string     `json:"message"`
+	LatencyMs *int64     `json:"latency_ms,omitempty"` //nolint:tagliatelle
+	Version   int64      `json:"version,omitempty"`
+	LastRun   *time.Time `json:"last_run,omitempty"` //nolint:tagliatelle
+}
+
+// handleGetDetailedHealth handles GET /api/v1/health/detailed.
+// Reports every subsystem with a status and a message, for status pages:
+//   - database: connectivity and latency
+//   - schema: applied migration version ("disabled" without a schema checker)
+//   - rate_limiter: in-memory, or whether a shared backend is reachable ("disabled" when off)
+//   - api_key_store: connectivity ("disabled" when authentication is off)
+//   - kafka: consumer health ("disabled" when Kafka ingestion is off)
+//   - workers: last run of each background worker; a failed last run is "degraded"
+//
+// The overall status is the worst component or worker status (disabled ones are ignored).
+//
+// Response codes:
+//   - 200 OK: Overall status healthy or degraded
+//   - 503 Service Unavailable: Any component unhealthy
+func (s *Server) handleGetDetailedHealth(w http.ResponseWriter, r *http.Request) {
+	correlationID := middleware.GetCorrelationID(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), deepReadyTimeout)
+	defer cancel()
+
+	response := &detailedHealthResponse{
+		Status:  statusHealthy,
+		Version: s.buildInfo.Version,
+		Components: map[string]*detailedComponentResponse{
+			componentDatabase:    fromReadyCheck(runReadyCheck(ctx, s.ingestionStore.HealthCheck), "connected"),
+			componentSchema:      s.detailedSchemaHealth(ctx),
+			componentRateLimiter: s.detailedRateLimiterHealth(ctx),
+			componentAPIKeyStore: s.detailedAPIKeyStoreHealth(ctx),
+			componentKafka:       s.detailedKafkaHealth(ctx),
+		},
+		Workers: s.detailedWorkersHealth(),
+	}
+
+	if !s.startTime.IsZero() {
+		response.Uptime = time.Since(s.startTime).Round(time.Second).String()
+	}
+
+	for _, group := range []map[string]*detailedComponentResponse{response.Components, response.Workers} {
+		for _, component := range group {
+			response.Status = worseStatus(response.Status, component.Status)
+		}
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("Failed to encode detailed health response",
+			slog.String("correlation_id", correlationID),
+			slog.String("error", err.Error()),
+		)
+
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode detailed health response"))
+
+		return
+	}
+
+	httpStatus := http.StatusOK
+	if response.Status == statusUnhealthy {
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(httpStatus)
+
+	if _, err := w.Write(data); err != nil {
+		s.logger.Error("Failed to write detailed health response",
+			slog.String("correlation_id", correlationID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *Server) detailedSchemaHealth(ctx context.Context) *detailedComponentResponse {
+	if s.schemaChecker == nil {
+		return &detailedComponentResponse{Status: statusDisabled, Message: "schema version check not configured"}
+	}
+
+	check := s.checkSchemaReady(ctx)
+	component := fromReadyCheck(check, fmt.Sprintf("migration %d applied", check.Version))
+	component.Version = check.Version
+
+	return component
+}
+
+func (s *Server) detailedRateLimiterHealth(ctx context.Context) *detailedComponentResponse {
+	switch limiter := s.rateLimiter.(type) {
+	case nil:
+		return &detailedComponentResponse{Status: statusDisabled, Message: "rate limiting disabled"}
+	case RateLimiterHealthChecker:
+		return fromReadyCheck(runReadyCheck(ctx, limiter.HealthCheck), "backend reachable")
+	default:
+		return &detailedComponentResponse{Status: statusHealthy, Message: "in-memory (per instance)"}
+	}
+}
+
+func (s *Server) detailedAPIKeyStoreHealth(ctx context.Context) *detailedComponentResponse {
+	if s.apiKeyStore == nil {
+		return &detailedComponentResponse{Status: statusDisabled, Message: "authentication disabled"}
+	}
+
+	return fromReadyCheck(runReadyCheck(ctx, s.apiKeyStore.HealthCheck), "connected")
+}
+
+func (s *Server) detailedKafkaHealth(ctx context.Context) *detailedComponentResponse {
+	result := s.healthChecker.checkKafka(ctx)
+	component := &detailedComponentResponse{Status: result.Status, Message: result.Error}
+
+	switch {
+	case result.Status == statusDisabled:
+		component.Message = "Kafka ingestion disabled"
+	case component.Message == "":
+		component.Message = "consuming"
+	}
+
+	if result.Status != statusDisabled {
+		component.LatencyMs = &result.LatencyMs
+	}
+
+	return component
+}
+
+// detailedWorkersHealth reports the last run of every tracked background worker. A worker that
+// has not run yet is healthy; one whose last run failed is degraded (it retries on its own).
+func (s *Server) detailedWorkersHealth() map[string]*detailedComponentResponse {
+	runs := s.workers.Runs()
+	workers := make(map[string]*detailedComponentResponse, len(runs))
+
+	for _, run := range runs {
+		worker := &detailedComponentResponse{Status: statusHealthy, Message: "last run succeeded"}
+
+		switch {
+		case run.LastRun.IsZero():
+			worker.Message = "not run yet"
+		case run.Error != "":
+			worker.Status = statusDegraded
+			worker.Message = "last run failed: " + run.Error
+		}
+
+		if !run.LastRun.IsZero() {
+			lastRun := run.LastRun.UTC()
+			worker.LastRun = &lastRun
+		}
+
+		workers[run.Name] = worker
+	}
+
+	return workers
+}
+
+// fromReadyCheck maps a dependency check to a detailed component; healthyMessage describes a
+// passing check, a failing one reports its error.
+func fromReadyCheck(check *readyCheckResponse, healthyMessage string) *detailedComponentResponse {
+	component := &detailedComponentResponse{
+		Status:    check.Status,
+		Message:   healthyMessage,
+		LatencyMs: &check.LatencyMs,
+	}
+
+	if check.Error != "" {
+		component.Message = check.Error
+	}
+
+	return component
+}
+
+// worseStatus returns the more severe of two statuses (see statusSeverity).
+func worseStatus(current, candidate string) string {
+	severity, ranked := statusSeverity[candidate]
+	if ranked && severity > statusSeverity[current] {
+		return candidate
+	}
+
+	return current
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/health"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// unreachableRateLimiter is a shared-backend rate limiter whose backend is down.
+type unreachableRateLimiter struct{}
+
+func (unreachableRateLimiter) Allow(string) bool { return true }
+
+func (unreachableRateLimiter) HealthCheck(context.Context) error {
+	return errors.New("dial tcp redis:6379: connect: connection refused")
+}
+
+func getDetailedHealth(t *testing.T, server *Server) (int, detailedHealthResponse) {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/health/detailed", nil))
+
+	var resp detailedHealthResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp), "body: %s", rr.Body.String())
+
+	return rr.Code, resp
+}
+
+func TestDetailedHealthEndpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	t.Run("Healthy Components And Workers", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		server.workers = health.NewWorkers()
+		server.workers.Register(storage.WorkerViewRefresh)
+		server.workers.Record(storage.WorkerIdempotencyCleanup, nil)
+
+		code, resp := getDetailedHealth(t, server)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, statusHealthy, resp.Status)
+		assert.Equal(t, "0.0.0-test", resp.Version)
+
+		database := resp.Components[componentDatabase]
+		require.NotNil(t, database)
+		assert.Equal(t, statusHealthy, database.Status)
+		assert.Equal(t, "connected", database.Message)
+		require.NotNil(t, database.LatencyMs)
+
+		for _, name := range []string{componentSchema, componentRateLimiter, componentAPIKeyStore, componentKafka} {
+			require.NotNil(t, resp.Components[name], name)
+			assert.Equal(t, statusDisabled, resp.Components[name].Status, name)
+			assert.NotEmpty(t, resp.Components[name].Message, name)
+		}
+
+		cleanup := resp.Workers[storage.WorkerIdempotencyCleanup]
+		require.NotNil(t, cleanup)
+		assert.Equal(t, statusHealthy, cleanup.Status)
+		assert.NotNil(t, cleanup.LastRun)
+
+		refresh := resp.Workers[storage.WorkerViewRefresh]
+		require.NotNil(t, refresh)
+		assert.Equal(t, "not run yet", refresh.Message)
+		assert.Nil(t, refresh.LastRun)
+	})
+
+	t.Run("In-Memory Rate Limiter Is Healthy", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		limiter := createTestRateLimiter(10, 10, 10)
+		t.Cleanup(limiter.Close)
+
+		server.rateLimiter = limiter
+
+		_, resp := getDetailedHealth(t, server)
+
+		assert.Equal(t, statusHealthy, resp.Components[componentRateLimiter].Status)
+		assert.Equal(t, "in-memory (per instance)", resp.Components[componentRateLimiter].Message)
+	})
+
+	t.Run("Failed Worker Run Degrades Overall Status", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		server.workers = health.NewWorkers()
+		server.workers.Record(storage.WorkerViewRefresh, errors.New("statement timeout"))
+
+		code, resp := getDetailedHealth(t, server)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, statusDegraded, resp.Status)
+		assert.Equal(t, "last run failed: statement timeout", resp.Workers[storage.WorkerViewRefresh].Message)
+	})
+
+	t.Run("Unreachable Rate Limiter Backend Makes Overall Unhealthy", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		server.rateLimiter = unreachableRateLimiter{}
+
+		code, resp := getDetailedHealth(t, server)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, statusUnhealthy, resp.Status)
+
+		limiter := resp.Components[componentRateLimiter]
+		assert.Equal(t, statusUnhealthy, limiter.Status)
+		assert.Contains(t, limiter.Message, "connection refused")
+		assert.Equal(t, statusHealthy, resp.Components[componentDatabase].Status)
+	})
+}
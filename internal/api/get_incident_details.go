@@ -2,7 +2,7 @@ package api
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -19,11 +19,25 @@ const defaultMaxDepth = 10
 // Path Parameters:
 //   - id: Test result ID (numeric string)
 //
+// Query Parameters:
+//   - fields: Optional comma-separated top-level fields to return (e.g., job,resolution_status)
+//   - column_lineage: Optional boolean; when true, adds the column-level lineage the producing
+//     run reported for the dataset (OpenLineage columnLineage facet)
+//   - explain: Optional boolean; when true, adds the candidate runs considered for the
+//     correlation and why the selected one won (off by default: one more query)
+//
 // Response: IncidentDetailResponse with test, dataset, job, upstream, and downstream info.
 func (s *Server) handleGetIncidentDetails(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	correlationID := middleware.GetCorrelationID(ctx)
 
+	fields, problem := parseFields[IncidentDetailResponse](r)
+	if problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
 	idStr := r.PathValue("id")
 	if idStr == "" {
 		WriteErrorResponse(w, r, s.logger, BadRequest("Missing incident ID"))
@@ -38,6 +52,20 @@ func (s *Server) handleGetIncidentDetails(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	includeColumnLineage, problem := parseBoolParam(r, "column_lineage")
+	if problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	includeExplanation, problem := parseBoolParam(r, "explain")
+	if problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
 	incident, err := s.correlationStore.QueryIncidentByID(ctx, id)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Failed to query incident",
@@ -58,7 +86,15 @@ func (s *Server) handleGetIncidentDetails(w http.ResponseWriter, r *http.Request
 
 	response := s.assembleIncidentDetailResponse(ctx, id, correlationID, incident)
 
-	data, err := json.Marshal(response)
+	if includeColumnLineage && incident.RunID != "" {
+		response.ColumnLineage = s.queryIncidentColumnLineage(ctx, id, correlationID, incident)
+	}
+
+	if includeExplanation {
+		response.Explanation = s.explainIncidentCorrelation(ctx, id, correlationID, incident)
+	}
+
+	data, err := marshalFields(response, fields)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Failed to marshal incident response",
 			"correlation_id", correlationID,
@@ -70,11 +106,32 @@ func (s *Server) handleGetIncidentDetails(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Last-Modified tracks the resolution status, the only mutable part of an incident; use it as
+	// If-Unmodified-Since on PATCH /api/v1/incidents/{id}/status.
+	if response.ResolvedAt != nil {
+		w.Header().Set("Last-Modified", response.ResolvedAt.UTC().Format(http.TimeFormat))
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(data)
 }
 
+// parseBoolParam parses an optional boolean query parameter (absent = false).
+func parseBoolParam(r *http.Request, name string) (bool, *ProblemDetail) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, BadRequest(fmt.Sprintf("Invalid %s: must be true or false", name))
+	}
+
+	return value, nil
+}
+
 // assembleIncidentDetailResponse gathers upstream, downstream, orphan, and orchestration
 // data for a single incident and composes the full API response. All sub-queries are
 // non-fatal; partial results are returned rather than failing the request.
@@ -160,6 +217,52 @@ func (s *Server) assembleIncidentDetailResponse(
 	return mapIncidentToDetail(incident, upstream, downstream, orphanDatasetSet, orchestrationChain, otherAttempts)
 }
 
+// queryIncidentColumnLineage returns the column lineage the incident's producing run reported
+// for the tested dataset. Non-fatal like the other detail sub-queries: returns nil on error.
+func (s *Server) queryIncidentColumnLineage(
+	ctx context.Context,
+	id int64,
+	correlationID string,
+	incident *correlation.Incident,
+) *ColumnLineageDetail {
+	lineage, err := s.correlationStore.QueryColumnLineage(ctx, incident.DatasetURN, incident.RunID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to query column lineage",
+			"correlation_id", correlationID,
+			"incident_id", id,
+			"dataset_urn", incident.DatasetURN,
+			"run_id", incident.RunID,
+			"error", err.Error(),
+		)
+
+		return nil
+	}
+
+	return mapColumnLineage(lineage)
+}
+
+// explainIncidentCorrelation explains which run the incident was correlated to and why.
+// Non-fatal: returns nil (explanation omitted) if the candidates cannot be queried.
+func (s *Server) explainIncidentCorrelation(
+	ctx context.Context,
+	id int64,
+	correlationID string,
+	incident *correlation.Incident,
+) *ExplanationDetail {
+	candidates, err := s.correlationStore.QueryCorrelationCandidates(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to query correlation candidates",
+			"correlation_id", correlationID,
+			"incident_id", id,
+			"error", err.Error(),
+		)
+
+		return nil
+	}
+
+	return mapExplanation(correlation.ExplainCorrelation(incident, candidates))
+}
+
 // mapIncidentToDetail converts a domain Incident with lineage results to API response.
 // The orphanDatasetSet is used to determine the correlation status.
 func mapIncidentToDetail(
@@ -207,13 +310,17 @@ func mapIncidentToDetail(
 		}
 
 		response.Job = &JobDetail{
-			Name:        inc.JobName,
-			Namespace:   inc.JobNamespace,
-			RunID:       inc.RunID,
-			Producer:    inc.JobProducerName,
-			Status:      jobStatus,
-			StartedAt:   inc.JobStartedAt,
-			CompletedAt: jobCompletedAt,
+			Name:               inc.JobName,
+			Namespace:          inc.JobNamespace,
+			RunID:              inc.RunID,
+			Producer:           inc.JobProducerName,
+			Status:             jobStatus,
+			StartedAt:          inc.JobStartedAt,
+			CompletedAt:        jobCompletedAt,
+			OpenLineageVersion: inc.JobOpenLineageVersion,
+			NominalStartTime:   inc.JobNominalStartTime,
+			NominalEndTime:     inc.JobNominalEndTime,
+			IngestedByPlugin:   inc.JobIngestedBy,
 		}
 
 		if inc.ParentRunID != "" {
@@ -230,6 +337,15 @@ func mapIncidentToDetail(
 		if len(orchestrationChain) > 0 {
 			response.Job.Orchestration = mapOrchestrationChain(orchestrationChain)
 		}
+
+		if inc.JobError != nil {
+			response.Job.Error = &JobErrorDetail{
+				Message:             inc.JobError.Message,
+				StackTrace:          inc.JobError.StackTrace,
+				ProgrammingLanguage: inc.JobError.ProgrammingLanguage,
+				Classification:      inc.JobError.Classification,
+			}
+		}
 	}
 
 	if len(otherAttempts) > 0 {
@@ -305,6 +421,68 @@ func mapDownstreamResults(results []correlation.DownstreamResult) []DownstreamDa
 	return datasets
 }
 
+// mapColumnLineage groups domain column edges by output column, keeping the store's order.
+func mapColumnLineage(lineage *correlation.ColumnLineage) *ColumnLineageDetail {
+	detail := &ColumnLineageDetail{
+		Columns:         []ColumnLineageColumn{},
+		UnmappedColumns: []string{},
+	}
+
+	for _, edge := range lineage.Edges {
+		last := len(detail.Columns) - 1
+		if last < 0 || detail.Columns[last].Name != edge.OutputColumn {
+			detail.Columns = append(detail.Columns, ColumnLineageColumn{Name: edge.OutputColumn})
+			last++
+		}
+
+		detail.Columns[last].Inputs = append(detail.Columns[last].Inputs, ColumnLineageInput{
+			DatasetURN:            edge.InputDatasetURN,
+			Column:                edge.InputColumn,
+			TransformationType:    edge.TransformationType,
+			TransformationSubtype: edge.TransformationSubtype,
+		})
+	}
+
+	detail.UnmappedColumns = append(detail.UnmappedColumns, lineage.UnmappedColumns...)
+
+	return detail
+}
+
+// mapExplanation converts a correlation explanation to the API response.
+func mapExplanation(explanation *correlation.CorrelationExplanation) *ExplanationDetail {
+	detail := &ExplanationDetail{
+		Rule:       explanation.Rule,
+		Reason:     explanation.Reason,
+		Candidates: make([]CandidateRunDetail, 0, len(explanation.Candidates)),
+	}
+
+	for _, c := range explanation.Candidates {
+		candidate := CandidateRunDetail{
+			RunID:             c.RunID,
+			JobName:           c.JobName,
+			JobNamespace:      c.JobNamespace,
+			Producer:          c.JobProducerName,
+			Status:            c.JobStatus,
+			StartedAt:         c.JobStartedAt,
+			CompletedAt:       c.JobCompletedAt,
+			OutputDatasetURN:  c.OutputURN,
+			DatasetMatch:      c.DatasetMatch,
+			StartedBeforeTest: c.StartedBeforeTest,
+			ProducerMatch:     c.ProducerMatch,
+			Selected:          c.Selected,
+		}
+
+		if c.TimeDelta != nil {
+			seconds := c.TimeDelta.Seconds()
+			candidate.TimeDeltaSeconds = &seconds
+		}
+
+		detail.Candidates = append(detail.Candidates, candidate)
+	}
+
+	return detail
+}
+
 // determineCorrelationStatus determines the correlation status of an incident.
 //
 // Status Logic:
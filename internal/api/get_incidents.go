@@ -15,8 +15,7 @@ type (
 	// incidentListParams holds parsed query parameters for incident list.
 	incidentListParams struct {
 		since        *time.Time
-		limit        int
-		offset       int
+		pagination   *correlation.Pagination
 		statusFilter correlation.ResolutionStatusFilter
 		windowDays   int
 	}
@@ -29,11 +28,6 @@ type (
 )
 
 const (
-	// Pagination defaults and limits.
-	defaultLimit = 20
-	maxLimit     = 100
-	minLimit     = 1
-
 	defaultWindowDays = 7
 	maxWindowDays     = 90
 )
@@ -48,31 +42,28 @@ func (e *paramError) Error() string {
 // Query Parameters:
 //   - status: "failed" | "all" (default: "all") - Note: view already filters to failed/error
 //   - since: ISO8601 timestamp (filter incidents after this time)
-//   - limit: 1-100 (default: 20)
+//   - limit: 1-MaxPageSize (default: DefaultPageSize, see ServerConfig)
 //   - offset: >= 0 (default: 0)
 //
-// Response: IncidentListResponse with incidents sorted by executed_at DESC.
+// Response: IncidentListResponse with incidents sorted by executed_at DESC, or with
+// "Accept: application/x-ndjson" one IncidentSummary per line (total in X-Total-Count).
 func (s *Server) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	correlationID := middleware.GetCorrelationID(ctx)
 
 	// Parse query parameters
-	params, err := parseIncidentListParams(r)
+	params, err := s.parseIncidentListParams(r)
 	if err != nil {
 		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
 
 		return
 	}
 
-	// Build filter and pagination from query parameters
+	// Build filter from query parameters
 	filter := buildIncidentFilter(params)
-	pagination := &correlation.Pagination{
-		Limit:  params.limit,
-		Offset: params.offset,
-	}
 
 	// Query incidents from store (with database-level pagination)
-	result, err := s.correlationStore.QueryIncidents(ctx, filter, pagination)
+	result, err := s.correlationStore.QueryIncidents(ctx, filter, params.pagination)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Failed to query incidents",
 			"correlation_id", correlationID,
@@ -110,11 +101,26 @@ func (s *Server) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 		summaries = append(summaries, mapIncidentToSummary(inc, downstreamCounts, orphanDatasetSet))
 	}
 
+	w.Header().Add("Vary", "Accept")
+
+	if acceptsNDJSON(r) {
+		w.Header().Set(totalCountHeader, strconv.Itoa(result.Total))
+
+		if err := writeNDJSON(w, summaries); err != nil {
+			s.logger.WarnContext(ctx, "Failed to write incidents NDJSON response",
+				"correlation_id", correlationID,
+				"error", err.Error(),
+			)
+		}
+
+		return
+	}
+
 	response := IncidentListResponse{
 		Incidents:   summaries,
 		Total:       result.Total,
-		Limit:       params.limit,
-		Offset:      params.offset,
+		Limit:       params.pagination.Limit,
+		Offset:      params.pagination.Offset,
 		OrphanCount: len(orphanDatasetSet),
 	}
 
@@ -135,12 +141,10 @@ func (s *Server) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 }
 
 // parseIncidentListParams parses and validates query parameters.
-func parseIncidentListParams(r *http.Request) (*incidentListParams, error) {
+func (s *Server) parseIncidentListParams(r *http.Request) (*incidentListParams, error) {
 	q := r.URL.Query()
 
 	params := &incidentListParams{
-		limit:        defaultLimit,
-		offset:       0,
 		statusFilter: correlation.StatusFilterActive,
 	}
 
@@ -168,10 +172,13 @@ func parseIncidentListParams(r *http.Request) (*incidentListParams, error) {
 		params.since = &t
 	}
 
-	if err := parsePaginationParams(q, params); err != nil {
+	pagination, err := s.parsePagination(r)
+	if err != nil {
 		return nil, err
 	}
 
+	params.pagination = pagination
+
 	return params, nil
 }
 
@@ -195,37 +202,6 @@ func parseWindowParam(q url.Values, params *incidentListParams) error {
 	return nil
 }
 
-// parsePaginationParams parses limit and offset query parameters.
-func parsePaginationParams(q url.Values, params *incidentListParams) error {
-	if limitStr := q.Get("limit"); limitStr != "" {
-		limit, err := strconv.Atoi(limitStr)
-		if err != nil {
-			return &paramError{param: "limit", msg: "must be a valid integer"}
-		}
-
-		if limit < minLimit || limit > maxLimit {
-			return &paramError{param: "limit", msg: "must be between 1 and 100"}
-		}
-
-		params.limit = limit
-	}
-
-	if offsetStr := q.Get("offset"); offsetStr != "" {
-		offset, err := strconv.Atoi(offsetStr)
-		if err != nil {
-			return &paramError{param: "offset", msg: "must be a valid integer"}
-		}
-
-		if offset < 0 {
-			return &paramError{param: "offset", msg: "must be >= 0"}
-		}
-
-		params.offset = offset
-	}
-
-	return nil
-}
-
 // buildIncidentFilter creates a correlation.IncidentFilter from parsed parameters.
 func buildIncidentFilter(params *incidentListParams) *correlation.IncidentFilter {
 	filter := &correlation.IncidentFilter{
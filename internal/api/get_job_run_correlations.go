@@ -0,0 +1,176 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/correlation"
+)
+
+// maxImplicatedRuns caps the implicated runs listed per test result; a dataset written by every
+// run of an hourly job would otherwise list them all.
+const maxImplicatedRuns = 10
+
+// handleGetJobRunCorrelations handles GET /api/v1/job-runs/{jobRunID}/correlations.
+// Returns the test results the run reported, each with its correlation status and the upstream
+// runs implicated in it: the "incident page" of a failed run in one call.
+//
+// Requires the lineage:read permission when authentication is enabled (403 otherwise).
+//
+// Path Parameters:
+//   - jobRunID: Job run ID (UUID)
+//
+// Query Parameters:
+//   - limit: 1-MaxPageSize test results (default: MaxPageSize, see ServerConfig); failed and
+//     errored tests come first, so the first page holds the incidents of runs with thousands of
+//     tests
+//   - offset: >= 0 (default: 0)
+//
+// The work per request is bounded: one page of test results, one candidate query for the page,
+// and at most maxImplicatedRuns implicated runs per test result.
+func (s *Server) handleGetJobRunCorrelations(w http.ResponseWriter, r *http.Request) {
+	if !s.requirePermission(w, r, permissionLineageRead) {
+		return
+	}
+
+	ctx := r.Context()
+	correlationID := middleware.GetCorrelationID(ctx)
+
+	runID := r.PathValue("jobRunID")
+	if _, err := uuid.Parse(runID); err != nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest("Invalid job run ID: must be a UUID"))
+
+		return
+	}
+
+	pagination, err := s.parsePagination(r)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
+
+		return
+	}
+
+	// Unlike other list endpoints, the first page is as large as allowed (see above)
+	if r.URL.Query().Get("limit") == "" {
+		_, pagination.Limit = s.config.pageSizes()
+	}
+
+	result, err := s.correlationStore.QueryJobRunTestResults(ctx, runID, pagination)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to query job run test results",
+			"correlation_id", correlationID,
+			"run_id", runID,
+			"error", err.Error(),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to query job run test results"))
+
+		return
+	}
+
+	if result == nil {
+		WriteErrorResponse(w, r, s.logger, NotFound("Job run not found"))
+
+		return
+	}
+
+	testResultIDs := make([]int64, 0, len(result.TestResults))
+	for _, tr := range result.TestResults {
+		testResultIDs = append(testResultIDs, tr.TestResultID)
+	}
+
+	candidates, err := s.correlationStore.QueryCorrelationCandidatesBatch(ctx, testResultIDs)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to query correlation candidates",
+			"correlation_id", correlationID,
+			"run_id", runID,
+			"error", err.Error(),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to correlate job run test results"))
+
+		return
+	}
+
+	response := JobRunCorrelationsResponse{
+		JobRun: JobRunSummary{
+			RunID:            result.RunID,
+			Name:             result.JobName,
+			Namespace:        result.JobNamespace,
+			Status:           result.JobStatus,
+			IngestedByPlugin: result.IngestedBy,
+		},
+		TestResults: make([]TestResultCorrelation, 0, len(result.TestResults)),
+		Total:       result.Total,
+		Limit:       pagination.Limit,
+		Offset:      pagination.Offset,
+	}
+
+	for _, tr := range result.TestResults {
+		response.TestResults = append(response.TestResults, mapTestResultCorrelation(tr, candidates[tr.TestResultID]))
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to marshal job run correlations response",
+			"correlation_id", correlationID,
+			"run_id", runID,
+			"error", err.Error(),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// mapTestResultCorrelation correlates one test result with its candidate runs through the same
+// selection rule as the incident views (correlation.ExplainCorrelation).
+//
+// Status Logic:
+//   - "not_applicable": The test did not fail or error, so it is not an incident
+//   - "unknown": The test failed but no run output its dataset
+//   - "correlated": At least one run output the tested dataset
+func mapTestResultCorrelation(
+	tr correlation.JobRunTestResult,
+	candidates []correlation.CorrelationCandidate,
+) TestResultCorrelation {
+	detail := TestResultCorrelation{
+		ID:                strconv.FormatInt(tr.TestResultID, 10),
+		TestName:          tr.TestName,
+		TestType:          tr.TestType,
+		Status:            tr.Status,
+		Message:           tr.Message,
+		DatasetURN:        tr.DatasetURN,
+		ExecutedAt:        tr.ExecutedAt,
+		Producer:          tr.ProducerName,
+		CorrelationStatus: CorrelationStatusNotApplicable,
+		ImplicatedRuns:    make([]CandidateRunDetail, 0),
+	}
+
+	if !isFailedStatus(tr.Status) {
+		return detail
+	}
+
+	explanation := mapExplanation(correlation.ExplainCorrelation(&correlation.Incident{
+		DatasetURN:       tr.DatasetURN,
+		TestExecutedAt:   tr.ExecutedAt,
+		TestProducerName: tr.ProducerName,
+	}, candidates))
+
+	detail.CorrelationStatus = CorrelationStatusUnknown
+	if len(explanation.Candidates) > 0 {
+		detail.CorrelationStatus = CorrelationStatusCorrelated
+	}
+
+	detail.CorrelationReason = explanation.Reason
+	detail.ImplicatedRunCount = len(explanation.Candidates)
+	detail.ImplicatedRuns = explanation.Candidates[:min(len(explanation.Candidates), maxImplicatedRuns)]
+
+	return detail
+}
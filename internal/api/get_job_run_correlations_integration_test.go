@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobRunCorrelations_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	now := time.Now()
+	runID := uuid.New().String()
+	datasetURN := "postgresql://prod-db/public.orders"
+	unproducedURN := "postgresql://prod-db/public.refunds"
+
+	failedID := setupIncidentTestData(ctx, t, ts, runID, datasetURN, now)
+
+	_, err := ts.db.ExecContext(ctx, `
+		INSERT INTO datasets (dataset_urn, name, namespace) VALUES ($1, 'refunds', 'public')
+	`, unproducedURN)
+	require.NoError(t, err)
+
+	var passedID, uncorrelatedID int64
+
+	err = ts.db.QueryRowContext(ctx, `
+		INSERT INTO test_results (test_name, test_type, dataset_urn, run_id, status, executed_at)
+		VALUES ('unique_orders_id', 'unique', $1, $2, 'passed', $3)
+		RETURNING id
+	`, datasetURN, runID, now.Add(time.Minute)).Scan(&passedID)
+	require.NoError(t, err)
+
+	err = ts.db.QueryRowContext(ctx, `
+		INSERT INTO test_results (test_name, test_type, dataset_urn, run_id, status, message, executed_at)
+		VALUES ('not_null_refunds_id', 'not_null', $1, $2, 'error', 'relation does not exist', $3)
+		RETURNING id
+	`, unproducedURN, runID, now.Add(-time.Minute)).Scan(&uncorrelatedID)
+	require.NoError(t, err)
+
+	require.NoError(t, ts.lineageStore.InitResolvedDatasets(ctx))
+
+	_, err = ts.db.ExecContext(ctx, "SELECT refresh_correlation_views()")
+	require.NoError(t, err, "Failed to refresh views")
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	t.Run("CorrelatesEveryTestOfTheRun", func(t *testing.T) {
+		rr := get("/api/v1/job-runs/" + runID + "/correlations")
+		require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+
+		var response JobRunCorrelationsResponse
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		assert.Equal(t, runID, response.JobRun.RunID)
+		assert.Equal(t, "test-job", response.JobRun.Name)
+		assert.Equal(t, 3, response.Total)
+		assert.Equal(t, defaultMaxPageSize, response.Limit)
+		require.Len(t, response.TestResults, 3)
+
+		// Failures first, most recent first
+		failed, uncorrelated, passed := response.TestResults[0], response.TestResults[1], response.TestResults[2]
+
+		assert.Equal(t, strconv.FormatInt(failedID, 10), failed.ID)
+		assert.Equal(t, CorrelationStatusCorrelated, failed.CorrelationStatus)
+		assert.Equal(t, 1, failed.ImplicatedRunCount)
+		require.Len(t, failed.ImplicatedRuns, 1)
+		assert.Equal(t, runID, failed.ImplicatedRuns[0].RunID)
+		assert.True(t, failed.ImplicatedRuns[0].Selected)
+		assert.NotEmpty(t, failed.CorrelationReason)
+
+		assert.Equal(t, strconv.FormatInt(uncorrelatedID, 10), uncorrelated.ID)
+		assert.Equal(t, CorrelationStatusUnknown, uncorrelated.CorrelationStatus)
+		assert.Empty(t, uncorrelated.ImplicatedRuns)
+
+		assert.Equal(t, strconv.FormatInt(passedID, 10), passed.ID)
+		assert.Equal(t, CorrelationStatusNotApplicable, passed.CorrelationStatus)
+		assert.Empty(t, passed.ImplicatedRuns)
+		assert.Empty(t, passed.CorrelationReason)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		rr := get("/api/v1/job-runs/" + runID + "/correlations?limit=1&offset=2")
+		require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+
+		var response JobRunCorrelationsResponse
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		assert.Equal(t, 3, response.Total)
+		require.Len(t, response.TestResults, 1)
+		assert.Equal(t, strconv.FormatInt(passedID, 10), response.TestResults[0].ID)
+	})
+
+	t.Run("UnknownRun", func(t *testing.T) {
+		rr := get("/api/v1/job-runs/" + uuid.New().String() + "/correlations")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("InvalidRunID", func(t *testing.T) {
+		rr := get("/api/v1/job-runs/not-a-uuid/correlations")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("InvalidLimit", func(t *testing.T) {
+		rr := get("/api/v1/job-runs/" + runID + "/correlations?limit=1000")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
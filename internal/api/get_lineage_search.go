@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// maxLineageSearchQueryLength bounds ?q=; longer fragments are not names anyone types.
+const maxLineageSearchQueryLength = 200
+
+type (
+	// LineageSearchStore is the interface the API layer uses to search datasets and jobs by name.
+	// Defined here (consumer in api package) following the Dependency Inversion Principle.
+	//
+	// Implemented by: storage.LineageStore.
+	LineageSearchStore interface {
+		SearchLineage(
+			ctx context.Context,
+			query string,
+			searchType storage.LineageSearchType,
+			limit int,
+		) ([]storage.LineageSearchResult, error)
+	}
+
+	// lineageSearchResponse is the response from GET /api/v1/lineage/search.
+	lineageSearchResponse struct {
+		Query   string                `json:"query"`
+		Type    string                `json:"type,omitempty"`
+		Results []lineageSearchResult `json:"results"`
+	}
+
+	// lineageSearchResult is a matched dataset or job.
+	lineageSearchResult struct {
+		Type       string     `json:"type"`
+		Name       string     `json:"name"`
+		Namespace  string     `json:"namespace"`
+		DatasetURN string     `json:"dataset_urn,omitempty"` //nolint:tagliatelle
+		JobRunID   string     `json:"job_run_id,omitempty"`  //nolint:tagliatelle
+		State      string     `json:"state,omitempty"`
+		UpdatedAt  *time.Time `json:"updated_at,omitempty"` //nolint:tagliatelle
+		Score      float64    `json:"score"`
+	}
+
+	// lineageSearchParams are the validated query parameters of GET /api/v1/lineage/search.
+	lineageSearchParams struct {
+		query      string
+		searchType storage.LineageSearchType
+		limit      int
+	}
+)
+
+// handleLineageSearch handles GET /api/v1/lineage/search.
+// Finds datasets whose URN and jobs whose name contain a fragment ("orders", "customer_pii"),
+// case-insensitive, closest names first. The entry point of the lineage explorer: dataset results
+// carry the URN and the run that last produced the dataset, job results the latest run and its
+// state, so each links to GET /api/v1/job-runs/{jobRunID}/correlations.
+//
+// Query Parameters:
+//   - q: fragment to search for (3-200 characters, required)
+//   - type: "dataset" or "job" (default: both)
+//   - limit: results, as for list endpoints (default: 20), capped at storage.MaxLineageSearchLimit
+//
+// Requires the lineage:read permission when authentication is enabled (403 otherwise).
+func (s *Server) handleLineageSearch(w http.ResponseWriter, r *http.Request) {
+	if !s.requirePermission(w, r, permissionLineageRead) {
+		return
+	}
+
+	ctx := r.Context()
+
+	params, err := s.parseLineageSearchParams(r)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
+
+		return
+	}
+
+	results, err := s.lineageSearch.SearchLineage(ctx, params.query, params.searchType, params.limit)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to search lineage",
+			slog.String("correlation_id", middleware.GetCorrelationID(ctx)),
+			slog.String("query", params.query),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to search lineage"))
+
+		return
+	}
+
+	response := lineageSearchResponse{
+		Query:   params.query,
+		Type:    string(params.searchType),
+		Results: make([]lineageSearchResult, 0, len(results)),
+	}
+
+	for _, result := range results {
+		item := lineageSearchResult{
+			Type:       string(result.Type),
+			Name:       result.Name,
+			Namespace:  result.Namespace,
+			DatasetURN: result.DatasetURN,
+			JobRunID:   result.RunID,
+			State:      result.State,
+			Score:      result.Score,
+		}
+
+		if !result.UpdatedAt.IsZero() {
+			item.UpdatedAt = &result.UpdatedAt
+		}
+
+		response.Results = append(response.Results, item)
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// parseLineageSearchParams parses and validates the search query parameters.
+// limit is parsed like the list endpoints' page size and clamped to storage.MaxLineageSearchLimit;
+// results are not paged, so an offset is refused rather than ignored.
+func (s *Server) parseLineageSearchParams(r *http.Request) (lineageSearchParams, error) {
+	q := r.URL.Query()
+
+	params := lineageSearchParams{query: strings.TrimSpace(q.Get("q"))}
+
+	n := utf8.RuneCountInString(params.query)
+	if n < storage.MinLineageSearchLength || n > maxLineageSearchQueryLength {
+		return params, &paramError{param: "q", msg: fmt.Sprintf("must be between %d and %d characters",
+			storage.MinLineageSearchLength, maxLineageSearchQueryLength)}
+	}
+
+	switch searchType := storage.LineageSearchType(q.Get("type")); searchType {
+	case "", storage.LineageSearchDatasets, storage.LineageSearchJobs:
+		params.searchType = searchType
+	default:
+		return params, &paramError{param: "type", msg: "must be dataset or job"}
+	}
+
+	pagination, err := s.parsePagination(r)
+	if err != nil {
+		return params, err
+	}
+
+	if pagination.Offset > 0 {
+		return params, &paramError{param: "offset", msg: "is not supported; narrow the query instead"}
+	}
+
+	params.limit = min(pagination.Limit, storage.MaxLineageSearchLimit)
+
+	return params, nil
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLineageSearch verifies that datasets and jobs are found by name fragment with the run to
+// navigate to, and that the parameters and the lineage:read permission are enforced.
+func TestLineageSearch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	// test-job reads postgres public.orders and writes s3 /analytics/orders.parquet
+	event := createValidLineageEvent("search-run", "COMPLETE", time.Now().UTC())
+	event.Job.Name = "load_orders"
+
+	rr := ts.postLineageEvents(t, []LineageEvent{event})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	writeKey := addTestAPIKey(ctx, t, ts.keyStore, testAPIKey{
+		id:          "search-write-key",
+		clientID:    "search-write",
+		permissions: []string{"lineage:write"},
+	})
+
+	search := func(t *testing.T, apiKey string, query url.Values) (*httptest.ResponseRecorder, lineageSearchResponse) {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/lineage/search?"+query.Encode(), nil)
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		var response lineageSearchResponse
+		if rr.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		}
+
+		return rr, response
+	}
+
+	t.Run("Datasets", func(t *testing.T) {
+		rr, response := search(t, ts.apiKey, url.Values{"q": {"orders"}, "type": {"dataset"}})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		assert.Equal(t, "orders", response.Query)
+		assert.Equal(t, "dataset", response.Type)
+		require.Len(t, response.Results, 2)
+
+		urns := make(map[string]lineageSearchResult)
+		for _, result := range response.Results {
+			assert.Equal(t, "dataset", result.Type)
+			urns[result.DatasetURN] = result
+		}
+
+		output, ok := urns["s3://data-lake//analytics/orders.parquet"]
+		require.True(t, ok, "results: %+v", response.Results)
+		assert.Equal(t, event.Run.ID, output.JobRunID, "output links to the run that produced it")
+
+		input, ok := urns["postgresql://prod-db/public.orders"]
+		require.True(t, ok, "results: %+v", response.Results)
+		assert.Empty(t, input.JobRunID)
+	})
+
+	t.Run("Jobs", func(t *testing.T) {
+		rr, response := search(t, ts.apiKey, url.Values{"q": {"ORDERS"}, "type": {"job"}})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		require.Len(t, response.Results, 1)
+
+		job := response.Results[0]
+		assert.Equal(t, "job", job.Type)
+		assert.Equal(t, "load_orders", job.Name)
+		assert.Equal(t, "default", job.Namespace)
+		assert.Equal(t, event.Run.ID, job.JobRunID)
+		assert.Equal(t, "COMPLETE", job.State)
+		assert.NotNil(t, job.UpdatedAt)
+	})
+
+	t.Run("AllTypesWithLimit", func(t *testing.T) {
+		rr, response := search(t, ts.apiKey, url.Values{"q": {"orders"}})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Len(t, response.Results, 3)
+
+		rr, response = search(t, ts.apiKey, url.Values{"q": {"orders"}, "limit": {"1"}})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Len(t, response.Results, 1)
+	})
+
+	t.Run("RejectsInvalidParameters", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{},
+			{"q": {"or"}},
+			{"q": {"orders"}, "type": {"table"}},
+			{"q": {"orders"}, "limit": {"0"}},
+			{"q": {"orders"}, "limit": {"101"}},
+			{"q": {"orders"}, "offset": {"20"}},
+		} {
+			rr, _ := search(t, ts.apiKey, query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, "query %v", query)
+			assert.Equal(t, contentTypeProblemJSON, rr.Header().Get("Content-Type"))
+		}
+	})
+
+	t.Run("RequiresLineageRead", func(t *testing.T) {
+		rr, _ := search(t, writeKey, url.Values{"q": {"orders"}})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
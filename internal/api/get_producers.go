@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// defaultProducersWindow is how far back GET /api/v1/producers looks without ?since=.
+const defaultProducersWindow = 7 * 24 * time.Hour
+
+type (
+	// ProducerStore is the interface the API layer uses to summarize producer activity.
+	// Defined here (consumer in api package) following the Dependency Inversion Principle.
+	//
+	// Implemented by: storage.LineageStore.
+	ProducerStore interface {
+		QueryProducerSummaries(ctx context.Context, since time.Time) ([]storage.ProducerSummary, error)
+	}
+
+	// producersResponse is the response from GET /api/v1/producers.
+	producersResponse struct {
+		Since     time.Time         `json:"since"`
+		Producers []producerSummary `json:"producers"`
+	}
+
+	// producerSummary is the recent activity of one producer.
+	producerSummary struct {
+		ProducerName  string           `json:"producer_name"`   //nolint:tagliatelle
+		LastEventTime time.Time        `json:"last_event_time"` //nolint:tagliatelle
+		RunCount      int64            `json:"run_count"`       //nolint:tagliatelle
+		States        map[string]int64 `json:"states"`
+	}
+)
+
+// handleListProducers handles GET /api/v1/producers.
+// Summarizes every producer that reported job runs in the window: name (the processing_engine
+// facet's engine when present, else derived from the producer URL), latest event time, number
+// of runs, and runs by current state. An operational view of integrations: a producer
+// missing from the list (or with an old last_event_time) stopped emitting, one with many FAIL
+// runs is erroring.
+//
+// Query Parameters:
+//   - since: RFC 3339 timestamp; only runs whose latest event is at or after it
+//     (default: 7 days ago)
+func (s *Server) handleListProducers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	correlationID := middleware.GetCorrelationID(ctx)
+
+	since, err := parseProducersSince(r)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
+
+		return
+	}
+
+	summaries, err := s.producerStore.QueryProducerSummaries(ctx, since)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to query producer summaries",
+			slog.String("correlation_id", correlationID),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to query producers"))
+
+		return
+	}
+
+	response := producersResponse{Since: since, Producers: make([]producerSummary, 0, len(summaries))}
+	for _, summary := range summaries {
+		response.Producers = append(response.Producers, producerSummary{
+			ProducerName:  summary.ProducerName,
+			LastEventTime: summary.LastEventTime,
+			RunCount:      summary.JobRuns,
+			States:        summary.States,
+		})
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// parseProducersSince parses the since query parameter, defaulting to defaultProducersWindow ago.
+func parseProducersSince(r *http.Request) (time.Time, error) {
+	sinceStr := r.URL.Query().Get("since")
+	if sinceStr == "" {
+		return time.Now().Add(-defaultProducersWindow).UTC(), nil
+	}
+
+	since, err := time.Parse(time.RFC3339, sinceStr)
+	if err != nil {
+		return time.Time{}, &paramError{param: "since", msg: "must be valid ISO8601 timestamp"}
+	}
+
+	return since, nil
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListProducers verifies that producers with recent runs are listed with their run counts
+// and state distribution, and that older runs fall outside the window.
+func TestListProducers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	now := time.Now().UTC()
+
+	withProducer := func(runID, eventType, producer string, eventTime time.Time) LineageEvent {
+		event := createValidLineageEvent(runID, eventType, eventTime)
+		event.Producer = producer
+
+		return event
+	}
+
+	const (
+		dbt     = "https://github.com/dbt-labs/dbt-core/tree/1.5.0"
+		airflow = "https://github.com/apache/airflow/tree/2.7.0"
+		spark   = "https://github.com/OpenLineage/OpenLineage/tree/1.0.0/integration/spark"
+	)
+
+	rr := ts.postLineageEvents(t, []LineageEvent{
+		withProducer("dbt-ok", "START", dbt, now.Add(-2*time.Hour)),
+		withProducer("dbt-ok", "COMPLETE", dbt, now.Add(-time.Hour)),
+		withProducer("dbt-failed", "START", dbt, now.Add(-2*time.Hour)),
+		withProducer("dbt-failed", "FAIL", dbt, now.Add(-90*time.Minute)),
+		withProducer("airflow-running", "START", airflow, now.Add(-30*time.Minute)),
+		withProducer("spark-stale", "START", spark, now.Add(-30*24*time.Hour)),
+	})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	listProducers := func(t *testing.T, query url.Values) (*httptest.ResponseRecorder, producersResponse) {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/producers?"+query.Encode(), nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		var response producersResponse
+		if rr.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		}
+
+		return rr, response
+	}
+
+	t.Run("default window", func(t *testing.T) {
+		rr, response := listProducers(t, url.Values{})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		require.Len(t, response.Producers, 2, "spark's only run is older than the default window")
+
+		airflowSummary, dbtSummary := response.Producers[0], response.Producers[1]
+
+		assert.Equal(t, "airflow", airflowSummary.ProducerName, "most recently active producer first")
+		assert.Equal(t, int64(1), airflowSummary.RunCount)
+		assert.Equal(t, map[string]int64{"START": 1}, airflowSummary.States)
+
+		assert.Equal(t, "dbt-core", dbtSummary.ProducerName)
+		assert.Equal(t, int64(2), dbtSummary.RunCount)
+		assert.Equal(t, map[string]int64{"COMPLETE": 1, "FAIL": 1}, dbtSummary.States)
+		assert.WithinDuration(t, now.Add(-time.Hour), dbtSummary.LastEventTime, time.Second)
+	})
+
+	t.Run("since", func(t *testing.T) {
+		since := now.Add(-60 * 24 * time.Hour).Format(time.RFC3339)
+
+		rr, response := listProducers(t, url.Values{"since": {since}})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		require.Len(t, response.Producers, 3)
+		assert.Equal(t, "spark", response.Producers[2].ProducerName)
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		rr, _ := listProducers(t, url.Values{"since": {"yesterday"}})
+		validateRFC7807Response(t, rr, http.StatusBadRequest)
+	})
+}
+
+// TestListProducers_ProcessingEngine verifies that runs carrying a processing_engine run facet
+// are summarized under the engine name, and runs without one under the name derived from the
+// producer URL.
+func TestListProducers_ProcessingEngine(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	now := time.Now().UTC()
+
+	// A producer URL outside the GitHub layout only yields its host name
+	const producer = "https://lineage.example.com/emitter"
+
+	withEngine := createValidLineageEvent("engine-run", "COMPLETE", now.Add(-time.Hour))
+	withEngine.Producer = producer
+	withEngine.Run.Facets["processing_engine"] = map[string]interface{}{
+		"_producer": producer,
+		"name":      "spark",
+		"version":   "3.5.0",
+	}
+
+	withoutEngine := createValidLineageEvent("plain-run", "COMPLETE", now.Add(-2*time.Hour))
+	withoutEngine.Producer = producer
+
+	rr := ts.postLineageEvents(t, []LineageEvent{withEngine, withoutEngine})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/producers", nil)
+	req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+	rr = httptest.NewRecorder()
+	ts.server.httpServer.Handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var response producersResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Producers, 2)
+
+	assert.Equal(t, "spark", response.Producers[0].ProducerName, "engine name preferred over the producer URL")
+	assert.Equal(t, int64(1), response.Producers[0].RunCount)
+	assert.Equal(t, "lineage.example.com", response.Producers[1].ProducerName, "fallback to the producer URL")
+	assert.Equal(t, int64(1), response.Producers[1].RunCount)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+)
+
+// handleGetRequestTrace handles GET /api/v1/trace/{correlationID}.
+// Returns the recorded lifecycle of a recent request (received, auth, validation, store, completed)
+// so operators can debug a failed call from the X-Correlation-ID the client saw.
+//
+// Path Parameters:
+//   - correlationID: value of the X-Correlation-ID response header
+//
+// Traces are kept in memory only, bounded by CORRELATOR_REQUEST_TRACE_MAX_REQUESTS and
+// CORRELATOR_REQUEST_TRACE_RETENTION; older or unknown requests return 404.
+//
+// Requires the admin permission when authentication is enabled (403 otherwise): traces cover the
+// requests of every client, including who authenticated them.
+func (s *Server) handleGetRequestTrace(w http.ResponseWriter, r *http.Request) {
+	if !s.requirePermission(w, r, permissionAdmin) {
+		return
+	}
+
+	trace, ok := s.requestTracer.Lookup(r.PathValue("correlationID"))
+	if !ok {
+		WriteErrorResponse(w, r, s.logger, NotFound("No trace recorded for this correlation ID"))
+
+		return
+	}
+
+	data, err := json.Marshal(mapRequestTrace(&trace))
+	if err != nil {
+		s.logger.ErrorContext(r.Context(), "Failed to marshal request trace response",
+			slog.String("correlation_id", middleware.GetCorrelationID(r.Context())),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// mapRequestTrace maps a recorded trace to the API response.
+func mapRequestTrace(trace *middleware.RequestTrace) RequestTraceResponse {
+	events := make([]RequestTraceEvent, 0, len(trace.Events))
+
+	for _, e := range trace.Events {
+		events = append(events, RequestTraceEvent{Stage: e.Stage, Detail: e.Detail, At: e.At})
+	}
+
+	return RequestTraceResponse{
+		CorrelationID: trace.CorrelationID,
+		Method:        trace.Method,
+		Path:          trace.Path,
+		StartedAt:     trace.StartedAt,
+		StatusCode:    trace.StatusCode,
+		DurationMs:    float64(trace.Duration) / float64(time.Millisecond),
+		Events:        events,
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// traceTestServer is a real HTTP server with request tracing enabled.
+type traceTestServer struct {
+	httpServer *httptest.Server
+	adminKey   string // admin
+	readKey    string // lineage:read only
+	writeKey   string // lineage:write only
+}
+
+func setupTraceTestServer(ctx context.Context, t *testing.T) *traceTestServer {
+	t.Helper()
+
+	server := setupAPITestServer(ctx, t, []testAPIKey{
+		{id: "trace-admin-key", permissions: []string{"admin"}},
+		{id: "trace-read-key", permissions: []string{"lineage:read"}},
+		{id: "trace-write-key", permissions: []string{"lineage:write"}},
+	}, func(cfg *ServerConfig, _ *Dependencies, _ *storage.LineageStore) {
+		cfg.RequestTraceMaxRequests = 100
+		cfg.RequestTraceRetention = time.Minute
+	})
+
+	return &traceTestServer{
+		httpServer: server.httpServer,
+		adminKey:   server.keys["trace-admin-key"],
+		readKey:    server.keys["trace-read-key"],
+		writeKey:   server.keys["trace-write-key"],
+	}
+}
+
+func (ts *traceTestServer) do(
+	ctx context.Context, t *testing.T, method, path, apiKey, body string,
+) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, method, ts.httpServer.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ts.httpServer.Client().Do(req)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+func TestGetRequestTrace_ReturnsLifecycleOfFailedIngest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTraceTestServer(ctx, t)
+
+	// Missing run.runId fails validation with 422
+	ingest := ts.do(ctx, t, http.MethodPost, "/api/v1/lineage", ts.writeKey, `{
+		"eventType": "START",
+		"eventTime": "2026-02-10T15:00:00Z",
+		"producer": "https://github.com/dbt-labs/dbt-core",
+		"schemaURL": "https://openlineage.io/spec/2-0-2/OpenLineage.json#/definitions/RunEvent",
+		"run": {},
+		"job": {"namespace": "dbt://analytics", "name": "transform_orders"}
+	}`)
+	require.Equal(t, http.StatusUnprocessableEntity, ingest.StatusCode)
+
+	correlationID := ingest.Header.Get("X-Correlation-ID")
+	require.NotEmpty(t, correlationID)
+
+	resp := ts.do(ctx, t, http.MethodGet, "/api/v1/trace/"+correlationID, ts.adminKey, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var trace RequestTraceResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&trace))
+
+	assert.Equal(t, correlationID, trace.CorrelationID)
+	assert.Equal(t, http.MethodPost, trace.Method)
+	assert.Equal(t, "/api/v1/lineage", trace.Path)
+	assert.Equal(t, http.StatusUnprocessableEntity, trace.StatusCode)
+
+	stages := make([]string, 0, len(trace.Events))
+	for _, e := range trace.Events {
+		stages = append(stages, e.Stage)
+	}
+
+	assert.Equal(t, []string{"received", "auth", "validation", "completed"}, stages)
+	assert.Contains(t, trace.Events[2].Detail, "failed")
+}
+
+func TestGetRequestTrace_NotFoundAndPermissions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTraceTestServer(ctx, t)
+
+	resp := ts.do(ctx, t, http.MethodGet, "/api/v1/trace/does-not-exist", ts.adminKey, "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Traces expose other clients' requests: reading lineage is not enough
+	for _, key := range []string{ts.readKey, ts.writeKey} {
+		resp = ts.do(ctx, t, http.MethodGet, "/api/v1/trace/does-not-exist", key, "")
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, contentTypeProblemJSON, resp.Header.Get("Content-Type"))
+	}
+}
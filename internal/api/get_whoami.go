@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+)
+
+// handleGetWhoami handles GET /api/v1/whoami.
+// Returns the plugin the request authenticated as: its ID, name, the API key ID, the granted
+// permissions, and when the key expires (null = never). The key value is never returned.
+//
+// Plugins call it before sending data to check that their key works and carries the scopes
+// they need. Only registered when authentication is enabled; any authenticated key may call it.
+func (s *Server) handleGetWhoami(w http.ResponseWriter, r *http.Request) {
+	clientCtx, authenticated := middleware.GetClientContext(r.Context())
+	if !authenticated {
+		WriteErrorResponse(w, r, s.logger, Unauthorized("Missing API key"))
+
+		return
+	}
+
+	permissions := clientCtx.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	data, err := json.Marshal(WhoamiResponse{
+		PluginID:    clientCtx.ClientID,
+		Name:        clientCtx.Name,
+		KeyID:       clientCtx.KeyID,
+		Permissions: permissions,
+		ExpiresAt:   clientCtx.KeyExpiresAt,
+	})
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
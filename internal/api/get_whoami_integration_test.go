@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWhoami_Endpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	httpServer, adminKey, writeKey := setupKeyAdminTestServer(ctx, t)
+
+	whoami := func(apiKey string) (*http.Response, []byte) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+"/api/v1/whoami", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Correlation-ID", "whoami-test")
+
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+
+		resp, err := httpServer.Client().Do(req)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp, body
+	}
+
+	t.Run("RequiresAuthentication", func(t *testing.T) {
+		resp, _ := whoami("")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("ReturnsStoredKeyScopes", func(t *testing.T) {
+		for _, tc := range []struct {
+			apiKey      string
+			id          string
+			permissions []string
+		}{
+			{writeKey, "patch-target", []string{"lineage:write"}},
+			{adminKey, "patch-admin", []string{"admin"}},
+		} {
+			resp, body := whoami(tc.apiKey)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "whoami-test", resp.Header.Get("X-Correlation-ID"))
+			assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+			assert.NotContains(t, string(body), tc.apiKey, "The API key must never be returned")
+
+			var response WhoamiResponse
+			require.NoError(t, json.Unmarshal(body, &response))
+
+			assert.Equal(t, WhoamiResponse{
+				PluginID:    tc.id,
+				Name:        tc.id,
+				KeyID:       tc.id,
+				Permissions: tc.permissions,
+			}, response)
+		}
+	})
+}
@@ -2,10 +2,13 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/correlator-io/correlator/internal/health"
 	"github.com/correlator-io/correlator/internal/ingestion"
+	"github.com/correlator-io/correlator/internal/storage"
 )
 
 // KafkaHealthChecker is the interface the API layer uses to check Kafka health.
@@ -17,14 +20,28 @@ type KafkaHealthChecker interface {
 	HealthCheck(ctx context.Context) *health.ComponentResult
 }
 
+// MaintenanceChecker is the interface the API layer uses to read table vacuum statistics.
+// Defined here (consumer in api package) following the Dependency Inversion Principle.
+//
+// Implemented by: storage.LineageStore.
+type MaintenanceChecker interface {
+	MaintenanceStats(ctx context.Context) ([]storage.TableMaintenance, error)
+}
+
 const (
 	statusHealthy   = "healthy"
 	statusDegraded  = "degraded"
 	statusUnhealthy = "unhealthy"
 	statusDisabled  = "disabled"
+	statusWarning   = "warning" // Component works but needs operator attention; does not change overall status
 
-	componentPostgres = "postgres"
-	componentKafka    = "kafka"
+	componentPostgres    = "postgres"
+	componentKafka       = "kafka"
+	componentMaintenance = "maintenance"
+
+	// maintenanceMinDeadTuples keeps small tables from being reported as bloated:
+	// a high ratio over a handful of rows is noise, not a vacuum problem.
+	maintenanceMinDeadTuples = 1000
 )
 
 // JSON response types for GET /health — transport-layer only.
@@ -40,10 +57,11 @@ type systemHealthResponse struct {
 
 // componentCheckResponse is the JSON representation of a single dependency check.
 type componentCheckResponse struct {
-	Status    string                `json:"status"`
-	LatencyMs int64                 `json:"latency_ms"` //nolint:tagliatelle
-	Error     string                `json:"error,omitempty"`
-	Details   *kafkaDetailsResponse `json:"details,omitempty"`
+	Status    string                     `json:"status"`
+	LatencyMs int64                      `json:"latency_ms"` //nolint:tagliatelle
+	Error     string                     `json:"error,omitempty"`
+	Details   *kafkaDetailsResponse      `json:"details,omitempty"`
+	Tables    []tableMaintenanceResponse `json:"tables,omitempty"`
 }
 
 // kafkaDetailsResponse is the JSON representation of Kafka-specific diagnostics.
@@ -55,6 +73,16 @@ type kafkaDetailsResponse struct {
 	Errors        int64  `json:"errors"`
 }
 
+// tableMaintenanceResponse is the JSON representation of one table's vacuum status.
+type tableMaintenanceResponse struct {
+	Table          string     `json:"table"`
+	LiveTuples     int64      `json:"live_tuples"`      //nolint:tagliatelle
+	DeadTuples     int64      `json:"dead_tuples"`      //nolint:tagliatelle
+	DeadTupleRatio float64    `json:"dead_tuple_ratio"` //nolint:tagliatelle
+	Bloated        bool       `json:"bloated"`
+	LastAutoVacuum *time.Time `json:"last_autovacuum"` //nolint:tagliatelle
+}
+
 // SystemHealth is the internal (non-JSON) aggregated health result.
 type SystemHealth struct {
 	Status      string
@@ -68,6 +96,9 @@ type SystemHealth struct {
 type HealthChecker struct {
 	store ingestion.Store
 	kafka KafkaHealthChecker
+
+	maintenance    MaintenanceChecker
+	bloatThreshold float64 // Dead-tuple ratio above which the maintenance check warns
 }
 
 // NewHealthChecker creates a health checker. kafkaChecker may be nil when Kafka is disabled.
@@ -78,12 +109,22 @@ func NewHealthChecker(store ingestion.Store, kafkaChecker KafkaHealthChecker) *H
 	}
 }
 
+// WithMaintenance enables the table bloat check: a table whose dead-tuple ratio exceeds
+// bloatThreshold (0-1) is reported with status "warning". checker may be nil (check disabled).
+func (h *HealthChecker) WithMaintenance(checker MaintenanceChecker, bloatThreshold float64) *HealthChecker {
+	h.maintenance = checker
+	h.bloatThreshold = bloatThreshold
+
+	return h
+}
+
 // Check runs all dependency health checks and returns the aggregated result.
 func (h *HealthChecker) Check(ctx context.Context) *SystemHealth {
-	checks := make(map[string]*health.ComponentResult, 2) //nolint:mnd
+	checks := make(map[string]*health.ComponentResult, 3) //nolint:mnd
 
 	checks[componentPostgres] = h.checkPostgres(ctx)
 	checks[componentKafka] = h.checkKafka(ctx)
+	checks[componentMaintenance] = h.checkMaintenance(ctx)
 
 	status := h.deriveStatus(checks)
 
@@ -128,6 +169,19 @@ func mapComponentResult(r *health.ComponentResult) *componentCheckResponse {
 		}
 	}
 
+	if d, ok := r.Details.(*health.MaintenanceDetails); ok {
+		for _, t := range d.Tables {
+			resp.Tables = append(resp.Tables, tableMaintenanceResponse{
+				Table:          t.Table,
+				LiveTuples:     t.LiveTuples,
+				DeadTuples:     t.DeadTuples,
+				DeadTupleRatio: t.DeadTupleRatio,
+				Bloated:        t.Bloated,
+				LastAutoVacuum: t.LastAutoVacuum,
+			})
+		}
+	}
+
 	return resp
 }
 
@@ -161,12 +215,71 @@ func (h *HealthChecker) checkKafka(ctx context.Context) *health.ComponentResult
 	return h.kafka.HealthCheck(ctx)
 }
 
+// checkMaintenance reports dead-tuple bloat of the high-churn tables.
+// Bloat (or failing to read the statistics) is a warning, never unhealthy: ingestion still works,
+// it is an early signal to tune autovacuum before queries slow down.
+func (h *HealthChecker) checkMaintenance(ctx context.Context) *health.ComponentResult {
+	if h.maintenance == nil {
+		return &health.ComponentResult{
+			Status: statusDisabled,
+		}
+	}
+
+	start := time.Now()
+
+	stats, err := h.maintenance.MaintenanceStats(ctx)
+	latencyMs := time.Since(start).Milliseconds()
+
+	if err != nil {
+		return &health.ComponentResult{
+			Status:    statusWarning,
+			LatencyMs: latencyMs,
+			Error:     "table statistics unavailable: " + err.Error(),
+		}
+	}
+
+	details := &health.MaintenanceDetails{Threshold: h.bloatThreshold}
+
+	var bloated []string
+
+	for _, m := range stats {
+		ratio := m.DeadTupleRatio()
+		isBloated := ratio > h.bloatThreshold && m.DeadTuples >= maintenanceMinDeadTuples
+
+		if isBloated {
+			bloated = append(bloated, fmt.Sprintf("%s (%.0f%% dead tuples)", m.Table, ratio*100)) //nolint:mnd
+		}
+
+		details.Tables = append(details.Tables, health.TableBloat{
+			Table:          m.Table,
+			LiveTuples:     m.LiveTuples,
+			DeadTuples:     m.DeadTuples,
+			DeadTupleRatio: ratio,
+			Bloated:        isBloated,
+			LastAutoVacuum: m.LastAutoVacuum,
+		})
+	}
+
+	result := &health.ComponentResult{
+		Status:    statusHealthy,
+		LatencyMs: latencyMs,
+		Details:   details,
+	}
+
+	if len(bloated) > 0 {
+		result.Status = statusWarning
+		result.Error = "tables need vacuum tuning: " + strings.Join(bloated, ", ")
+	}
+
+	return result
+}
+
 // deriveStatus computes the overall system status from individual checks.
 //
 // Rules:
 //   - DB unhealthy → "unhealthy" (nothing works without the database)
 //   - Any non-disabled check unhealthy but DB up → "degraded" (HTTP ingestion still works)
-//   - All checks healthy or disabled → "healthy"
+//   - All checks healthy, warning, or disabled → "healthy"
 func (h *HealthChecker) deriveStatus(checks map[string]*health.ComponentResult) string {
 	pgCheck, pgExists := checks[componentPostgres]
 	if pgExists && pgCheck.Status == statusUnhealthy {
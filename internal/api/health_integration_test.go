@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -246,3 +247,340 @@ func TestReadyEndpointUsesIngestionStore(t *testing.T) {
 		assert.Equal(t, "storage unavailable", rr.Body.String())
 	})
 }
+
+// TestHeadHealthEndpoints verifies that HEAD on the probes reports the GET status and headers
+// without a body. GET routes serve HEAD and net/http drops the body, so the requests go through a
+// real HTTP server rather than a recorder.
+func TestHeadHealthEndpoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	do := func(t *testing.T, httpServer *httptest.Server, method, path string) (*http.Response, string) {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(ctx, method, httpServer.URL+path, nil)
+		require.NoError(t, err)
+
+		resp, err := httpServer.Client().Do(req)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp, string(body)
+	}
+
+	server, _ := setupHealthTestServer(ctx, t, nil)
+	httpServer := httptest.NewServer(server.httpServer.Handler)
+	t.Cleanup(httpServer.Close)
+
+	for _, path := range []string{"/ping", "/ready", "/health"} {
+		t.Run("HEAD "+path+" Returns 200 With Empty Body", func(t *testing.T) {
+			getResp, _ := do(t, httpServer, http.MethodGet, path)
+			resp, body := do(t, httpServer, http.MethodHead, path)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, getResp.StatusCode, resp.StatusCode, "HEAD must report the same status as GET")
+			assert.Equal(t, getResp.Header.Get("Content-Type"), resp.Header.Get("Content-Type"))
+			assert.Empty(t, body, "HEAD response must not include a body")
+		})
+	}
+
+	t.Run("HEAD Ready Returns 503 With Empty Body When DB Down", func(t *testing.T) {
+		server, testDB := setupHealthTestServer(ctx, t, nil)
+		httpServer := httptest.NewServer(server.httpServer.Handler)
+		t.Cleanup(httpServer.Close)
+
+		err := testDB.Connection.Close()
+		require.NoError(t, err)
+
+		resp, body := do(t, httpServer, http.MethodHead, "/ready")
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Empty(t, body)
+	})
+}
+
+// TestLivezEndpoint verifies that /livez answers 200 ahead of the middleware chain: no correlation
+// ID, and no dependency involved, so it stays green while /ready fails on a database outage.
+func TestLivezEndpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	server, testDB := setupHealthTestServer(ctx, t, nil)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+
+		return rr
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		rr := serve(method, "/livez")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Empty(t, rr.Header().Get("X-Correlation-ID"), "/livez must bypass the middleware chain")
+	}
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPost, "/livez").Code,
+		"Only GET and HEAD are answered early")
+
+	require.NoError(t, testDB.Connection.Close())
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/ready").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/livez").Code, "Liveness must not depend on storage")
+}
+
+func TestDeepReadyEndpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*Server, *config.TestDatabase) {
+		t.Helper()
+
+		server, testDB := setupHealthTestServer(ctx, t, nil)
+		server.schemaChecker = storage.NewSchemaVersionChecker(storage.WrapConnection(testDB.Connection), "")
+
+		return server, testDB
+	}
+
+	getDeepReady := func(t *testing.T, server *Server) (*httptest.ResponseRecorder, readyResponse) {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, "/ready?deep=true", nil)
+		rr := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		var body readyResponse
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+		return rr, body
+	}
+
+	t.Run("Deep Ready Returns 200 With Per-Dependency Checks", func(t *testing.T) {
+		server, _ := setup(t)
+
+		rr, body := getDeepReady(t, server)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, statusReady, body.Status)
+		require.Contains(t, body.Checks, readyCheckLineageStore)
+		require.Contains(t, body.Checks, readyCheckAPIKeyStore)
+		require.Contains(t, body.Checks, readyCheckSchema)
+		assert.Equal(t, statusHealthy, body.Checks[readyCheckLineageStore].Status)
+		assert.Equal(t, statusDisabled, body.Checks[readyCheckAPIKeyStore].Status)
+		assert.Equal(t, statusHealthy, body.Checks[readyCheckSchema].Status)
+		assert.GreaterOrEqual(t, body.Checks[readyCheckSchema].Version, storage.MinSchemaVersion)
+	})
+
+	t.Run("Deep Ready Returns 503 When Schema Is Dirty", func(t *testing.T) {
+		server, testDB := setup(t)
+
+		_, err := testDB.Connection.ExecContext(ctx, "UPDATE schema_migrations SET dirty = true")
+		require.NoError(t, err)
+
+		rr, body := getDeepReady(t, server)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, statusNotReady, body.Status)
+		assert.Equal(t, statusHealthy, body.Checks[readyCheckLineageStore].Status)
+		assert.Equal(t, statusUnhealthy, body.Checks[readyCheckSchema].Status)
+		assert.Contains(t, body.Checks[readyCheckSchema].Error, "dirty")
+	})
+
+	t.Run("Deep Ready Returns 503 When No Migration Is Applied", func(t *testing.T) {
+		server, testDB := setup(t)
+
+		_, err := testDB.Connection.ExecContext(ctx, "DELETE FROM schema_migrations")
+		require.NoError(t, err)
+
+		rr, body := getDeepReady(t, server)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, statusUnhealthy, body.Checks[readyCheckSchema].Status)
+		assert.Equal(t, storage.ErrSchemaNotMigrated.Error(), body.Checks[readyCheckSchema].Error)
+	})
+
+	t.Run("Shallow Ready Ignores Schema State", func(t *testing.T) {
+		server, testDB := setup(t)
+
+		_, err := testDB.Connection.ExecContext(ctx, "DELETE FROM schema_migrations")
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+		rr := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "ready", rr.Body.String())
+	})
+}
+
+// mockMaintenanceChecker implements MaintenanceChecker for testing without real table bloat.
+type mockMaintenanceChecker struct {
+	stats []storage.TableMaintenance
+	err   error
+}
+
+func (m *mockMaintenanceChecker) MaintenanceStats(_ context.Context) ([]storage.TableMaintenance, error) {
+	return m.stats, m.err
+}
+
+func TestHealthMaintenanceCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	bloated := &mockMaintenanceChecker{
+		stats: []storage.TableMaintenance{
+			{Table: "datasets", LiveTuples: 1200, DeadTuples: 40},
+			{Table: "job_runs", LiveTuples: 52000, DeadTuples: 28000},
+			{Table: "lineage_edges", LiveTuples: 10, DeadTuples: 90}, // High ratio, too few dead tuples
+		},
+	}
+
+	getHealth := func(t *testing.T, server *Server) systemHealthResponse {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		rr := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var resp systemHealthResponse
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+		return resp
+	}
+
+	t.Run("Disabled Without Maintenance Checker", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+
+		resp := getHealth(t, server)
+
+		require.Contains(t, resp.Checks, componentMaintenance)
+		assert.Equal(t, statusDisabled, resp.Checks[componentMaintenance].Status)
+	})
+
+	t.Run("Warning On Bloated Table Keeps Overall Status Healthy", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		server.healthChecker.WithMaintenance(bloated, 0.2)
+
+		resp := getHealth(t, server)
+
+		assert.Equal(t, statusHealthy, resp.Status)
+
+		check := resp.Checks[componentMaintenance]
+		require.NotNil(t, check, "Expected maintenance check")
+		assert.Equal(t, statusWarning, check.Status)
+		assert.Contains(t, check.Error, "job_runs (35% dead tuples)")
+		assert.NotContains(t, check.Error, "lineage_edges")
+
+		require.Len(t, check.Tables, 3)
+		assert.False(t, check.Tables[0].Bloated)
+		assert.True(t, check.Tables[1].Bloated)
+		assert.InDelta(t, 0.35, check.Tables[1].DeadTupleRatio, 0.001)
+		assert.False(t, check.Tables[2].Bloated)
+	})
+
+	t.Run("Healthy Below Threshold", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		server.healthChecker.WithMaintenance(bloated, 0.5)
+
+		check := getHealth(t, server).Checks[componentMaintenance]
+		require.NotNil(t, check, "Expected maintenance check")
+		assert.Equal(t, statusHealthy, check.Status)
+		assert.Empty(t, check.Error)
+	})
+
+	t.Run("Warning When Statistics Unavailable", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		server.healthChecker.WithMaintenance(&mockMaintenanceChecker{err: storage.ErrNoDatabaseConnection}, 0.2)
+
+		resp := getHealth(t, server)
+
+		assert.Equal(t, statusHealthy, resp.Status)
+		assert.Equal(t, statusWarning, resp.Checks[componentMaintenance].Status)
+		assert.Contains(t, resp.Checks[componentMaintenance].Error, "table statistics unavailable")
+	})
+
+	t.Run("Metrics Exports Dead Tuple Gauges", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		server.maintenanceChecker = bloated
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		rr := httptest.NewRecorder()
+		server.handleMetrics(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, contentTypePrometheusText, rr.Header().Get("Content-Type"))
+
+		body := rr.Body.String()
+		assert.Contains(t, body, "# TYPE correlator_table_dead_tuple_ratio gauge")
+		assert.Contains(t, body, `correlator_table_dead_tuple_ratio{table="job_runs"} 0.35`)
+		assert.Contains(t, body, `correlator_table_dead_tuples{table="datasets"} 40`)
+	})
+
+	t.Run("Metrics Returns 503 When Statistics Unavailable", func(t *testing.T) {
+		server, _ := setupHealthTestServer(ctx, t, nil)
+		server.maintenanceChecker = &mockMaintenanceChecker{err: storage.ErrNoDatabaseConnection}
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		rr := httptest.NewRecorder()
+		server.handleMetrics(rr, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+}
+
+// TestMaintenanceMode verifies that maintenance mode rejects business endpoints with 503 and
+// Retry-After while the health probes keep reporting healthy.
+func TestMaintenanceMode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	server, _ := setupHealthTestServer(ctx, t, nil)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+
+		return rr
+	}
+
+	require.NoError(t, server.EnterMaintenance(ctx))
+
+	rr := serve(http.MethodPost, "/api/v1/lineage")
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+	assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+
+	for _, path := range []string{"/ping", "/ready", "/health"} {
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, path).Code, "%s must stay green", path)
+	}
+
+	server.LeaveMaintenance()
+
+	rr = serve(http.MethodPost, "/api/v1/lineage")
+	assert.NotEqual(t, http.StatusServiceUnavailable, rr.Code)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+
+	"github.com/correlator-io/correlator/internal/config"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+type (
+	// testAPIKey describes an API key to register for a test.
+	testAPIKey struct {
+		id          string
+		clientID    string // Defaults to id
+		permissions []string
+		quota       storage.EventQuota
+	}
+
+	// apiTestServer is a real HTTP server over a migrated test database.
+	apiTestServer struct {
+		httpServer   *httptest.Server
+		db           *sql.DB
+		lineageStore *storage.LineageStore
+		keys         map[string]string // Plaintext API keys by ID
+	}
+)
+
+// addTestAPIKey registers key in keyStore and returns its plaintext value.
+func addTestAPIKey(ctx context.Context, t *testing.T, keyStore storage.APIKeyStore, key testAPIKey) string {
+	t.Helper()
+
+	plaintext, err := storage.GenerateAPIKey()
+	require.NoError(t, err, "Failed to generate API key")
+
+	clientID := key.clientID
+	if clientID == "" {
+		clientID = key.id
+	}
+
+	require.NoError(t, keyStore.Add(ctx, &storage.APIKey{
+		ID:          key.id,
+		Key:         plaintext,
+		ClientID:    clientID,
+		Name:        key.id,
+		Permissions: key.permissions,
+		CreatedAt:   time.Now(),
+		Active:      true,
+		Quota:       key.quota,
+	}), "Failed to add API key")
+
+	return plaintext
+}
+
+// setupAPITestServer starts a server with authentication enabled, the lineage store as its
+// ingestion and correlation store, and keys registered. configure, if not nil, adjusts the config
+// and dependencies before the server is created (e.g. to enable an optional endpoint).
+// Everything is cleaned up with the test.
+func setupAPITestServer(
+	ctx context.Context,
+	t *testing.T,
+	keys []testAPIKey,
+	configure func(cfg *ServerConfig, deps *Dependencies, lineageStore *storage.LineageStore),
+) *apiTestServer {
+	t.Helper()
+
+	testDB := config.SetupTestDatabase(ctx, t)
+	storageConn := storage.WrapConnection(testDB.Connection)
+
+	keyStore, err := storage.NewPersistentKeyStore(storageConn)
+	require.NoError(t, err, "Failed to create key store")
+
+	lineageStore, err := storage.NewLineageStore(storageConn, 1*time.Hour) //nolint:contextcheck
+	require.NoError(t, err, "Failed to create lineage store")
+
+	ts := &apiTestServer{
+		db:           testDB.Connection,
+		lineageStore: lineageStore,
+		keys:         make(map[string]string, len(keys)),
+	}
+
+	for _, key := range keys {
+		ts.keys[key.id] = addTestAPIKey(ctx, t, keyStore, key)
+	}
+
+	cfg := &ServerConfig{
+		Port:               8080,
+		Host:               "localhost",
+		ReadTimeout:        30 * time.Second,
+		WriteTimeout:       30 * time.Second,
+		ShutdownTimeout:    30 * time.Second,
+		LogLevel:           slog.LevelInfo,
+		MaxRequestSize:     defaultMaxRequestSize,
+		CORSAllowedOrigins: []string{"*"},
+		CORSAllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
+		CORSAllowedHeaders: []string{"Content-Type", "Authorization", "X-Correlation-ID"},
+		CORSMaxAge:         86400,
+	}
+
+	deps := Dependencies{
+		APIKeyStore:      keyStore,
+		IngestionStore:   lineageStore,
+		CorrelationStore: lineageStore,
+	}
+
+	if configure != nil {
+		configure(cfg, &deps, lineageStore)
+	}
+
+	server := NewServer(cfg, deps, BuildInfo{Version: "0.0.0-test"})
+	ts.httpServer = httptest.NewServer(server.httpServer.Handler)
+
+	t.Cleanup(func() {
+		ts.httpServer.Close()
+		_ = keyStore.Close()
+		_ = lineageStore.Close()
+		_ = testDB.Connection.Close()
+		_ = testcontainers.TerminateContainer(testDB.Container)
+	})
+
+	return ts
+}
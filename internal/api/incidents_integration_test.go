@@ -121,6 +121,80 @@ func TestListIncidents_Integration(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 	})
 
+	t.Run("ListIncidents_ConfiguredPageSizes", func(t *testing.T) {
+		ts.server.config.DefaultPageSize = 3
+		ts.server.config.MaxPageSize = 10
+
+		t.Cleanup(func() {
+			ts.server.config.DefaultPageSize = 0
+			ts.server.config.MaxPageSize = 0
+		})
+
+		list := func(query string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents"+query, nil)
+			req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+			rr := httptest.NewRecorder()
+			ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+			return rr
+		}
+
+		// Default: the configured page size
+		rr := list("")
+		require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+
+		var response IncidentListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, 3, response.Limit, "Default limit should be the configured page size")
+
+		// Explicit: up to the configured max
+		rr = list("?limit=10")
+		require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, 10, response.Limit, "Explicit limit should be honored")
+
+		// Over max: rejected, naming the configured max
+		rr = list("?limit=11")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "must be between 1 and 10")
+	})
+
+	t.Run("ListIncidents_NDJSON", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents", nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+		req.Header.Set("Accept", "application/x-ndjson")
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+		assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+
+		total, err := strconv.Atoi(rr.Header().Get("X-Total-Count"))
+		require.NoError(t, err, "X-Total-Count should carry the total")
+		assert.GreaterOrEqual(t, total, 1)
+
+		// One valid IncidentSummary per line, as many as the rows of the JSON response
+		lines := strings.Split(strings.TrimSuffix(rr.Body.String(), "\n"), "\n")
+		for i, line := range lines {
+			var summary IncidentSummary
+			require.NoError(t, json.Unmarshal([]byte(line), &summary), "line %d is not valid JSON: %s", i, line)
+			assert.NotEmpty(t, summary.ID, "line %d should be an incident", i)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/incidents", nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr = httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		var response IncidentListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Len(t, lines, len(response.Incidents), "NDJSON should have one line per row")
+		assert.Equal(t, response.Total, total)
+	})
+
 	t.Run("ListIncidents_InvalidOffset", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents?offset=-1", nil)
 		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
@@ -194,6 +268,42 @@ func TestGetIncidentDetails_Integration(t *testing.T) {
 		// Verify job details
 		assert.Equal(t, runID, response.Job.RunID)
 		assert.Equal(t, "dbt", response.Job.Producer)
+
+		assert.Nil(t, response.Explanation, "Explanation must be opt-in")
+	})
+
+	t.Run("GetIncidentDetails_SparseFields", func(t *testing.T) {
+		endpoint := fmt.Sprintf("/api/v1/incidents/%d?fields=id,%%20job,resolution_status", testResultID)
+		req := httptest.NewRequest(http.MethodGet, endpoint, nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+
+		var response map[string]json.RawMessage
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		assert.Len(t, response, 3)
+		assert.Contains(t, response, "id")
+		assert.Contains(t, response, "job")
+		assert.Contains(t, response, "resolution_status")
+		assert.NotContains(t, response, "upstream", "unrequested fields must be omitted")
+		assert.NotContains(t, response, "downstream", "unrequested fields must be omitted")
+	})
+
+	t.Run("GetIncidentDetails_UnknownField", func(t *testing.T) {
+		endpoint := fmt.Sprintf("/api/v1/incidents/%d?fields=job,current_state", testResultID)
+		req := httptest.NewRequest(http.MethodGet, endpoint, nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "current_state")
 	})
 
 	t.Run("GetIncidentDetails_NotFound", func(t *testing.T) {
@@ -206,6 +316,108 @@ func TestGetIncidentDetails_Integration(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
 
+	t.Run("GetIncidentDetails_ColumnLineage", func(t *testing.T) {
+		_, err := ts.db.ExecContext(ctx, `
+			UPDATE datasets SET facets = '{"schema": {"fields": [{"name": "order_id"}, {"name": "total"}]}}'
+			WHERE dataset_urn = $1
+		`, datasetURN)
+		require.NoError(t, err)
+
+		_, err = ts.db.ExecContext(ctx, `
+			INSERT INTO column_lineage_edges (
+				run_id, output_dataset_urn, output_column, input_dataset_urn, input_column,
+				transformation_type, transformation_subtype
+			) VALUES ($1, $2, 'order_id', 'postgresql://prod-db/raw.orders', 'id', 'DIRECT', 'IDENTITY')
+		`, runID, datasetURN)
+		require.NoError(t, err)
+
+		get := func(t *testing.T, query string) IncidentDetailResponse {
+			t.Helper()
+
+			endpoint := fmt.Sprintf("/api/v1/incidents/%d%s", testResultID, query)
+			req := httptest.NewRequest(http.MethodGet, endpoint, nil)
+			req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+			rr := httptest.NewRecorder()
+			ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+
+			var response IncidentDetailResponse
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+			return response
+		}
+
+		assert.Nil(t, get(t, "").ColumnLineage, "column lineage must be opt-in")
+
+		response := get(t, "?column_lineage=true")
+		require.NotNil(t, response.ColumnLineage)
+		assert.Equal(t, []ColumnLineageColumn{{
+			Name: "order_id",
+			Inputs: []ColumnLineageInput{{
+				DatasetURN:            "postgresql://prod-db/raw.orders",
+				Column:                "id",
+				TransformationType:    "DIRECT",
+				TransformationSubtype: "IDENTITY",
+			}},
+		}}, response.ColumnLineage.Columns)
+		assert.Equal(t, []string{"total"}, response.ColumnLineage.UnmappedColumns)
+	})
+
+	t.Run("GetIncidentDetails_InvalidColumnLineage", func(t *testing.T) {
+		endpoint := fmt.Sprintf("/api/v1/incidents/%d?column_lineage=maybe", testResultID)
+		req := httptest.NewRequest(http.MethodGet, endpoint, nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "column_lineage")
+	})
+
+	t.Run("GetIncidentDetails_Explain", func(t *testing.T) {
+		endpoint := fmt.Sprintf("/api/v1/incidents/%d?explain=true", testResultID)
+		req := httptest.NewRequest(http.MethodGet, endpoint, nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+
+		var response IncidentDetailResponse
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.NotNil(t, response.Explanation)
+
+		assert.Equal(t, "latest_started_run", response.Explanation.Rule)
+		assert.Contains(t, response.Explanation.Reason, "only job run")
+		require.Len(t, response.Explanation.Candidates, 1)
+
+		candidate := response.Explanation.Candidates[0]
+		assert.Equal(t, runID, candidate.RunID)
+		assert.Equal(t, datasetURN, candidate.OutputDatasetURN)
+		assert.Equal(t, "exact", candidate.DatasetMatch)
+		assert.True(t, candidate.StartedBeforeTest)
+		assert.True(t, candidate.Selected)
+		assert.Nil(t, candidate.TimeDeltaSeconds, "Run has not completed")
+	})
+
+	t.Run("GetIncidentDetails_InvalidExplain", func(t *testing.T) {
+		endpoint := fmt.Sprintf("/api/v1/incidents/%d?explain=maybe", testResultID)
+		req := httptest.NewRequest(http.MethodGet, endpoint, nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "explain")
+	})
+
 	t.Run("GetIncidentDetails_InvalidID", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents/invalid", nil)
 		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
@@ -719,6 +931,56 @@ func TestPatchIncidentStatus_Integration(t *testing.T) {
 		expectedExpiry := time.Now().Add(7 * 24 * time.Hour)
 		assert.WithinDuration(t, expectedExpiry, *resp.MuteExpiresAt, 5*time.Second)
 	})
+
+	t.Run("conditional update after concurrent change - 412", func(t *testing.T) {
+		tid := setupIncidentTestData(ctx, t, ts, uuid.New().String(), "postgresql://prod-db/public.patch_412", now)
+
+		require.NoError(t, ts.lineageStore.InitResolvedDatasets(ctx))
+
+		_, err := ts.db.ExecContext(ctx, "SELECT refresh_correlation_views()")
+		require.NoError(t, err)
+
+		endpoint := fmt.Sprintf("/api/v1/incidents/%d/status", tid)
+		patch := func(body, ifUnmodifiedSince string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPatch, endpoint, strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+			req.Header.Set("Content-Type", "application/json")
+
+			if ifUnmodifiedSince != "" {
+				req.Header.Set("If-Unmodified-Since", ifUnmodifiedSince)
+			}
+
+			rr := httptest.NewRecorder()
+			ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+			return rr
+		}
+
+		// Writer A read the (open) incident a minute ago
+		readAt := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+
+		// Writer B acknowledges it in the meantime
+		rr := patch(`{"status":"acknowledged"}`, "")
+		require.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+
+		// Writer A's mute would be a valid transition, but is based on a stale read
+		rr = patch(`{"status":"muted","reason":"expected"}`, readAt)
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code, "Response: %s", rr.Body.String())
+
+		// After re-reading, the Last-Modified of the current state satisfies the precondition
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/incidents/%d", tid), nil)
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		get := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(get, req)
+		require.Equal(t, http.StatusOK, get.Code)
+
+		lastModified := get.Header().Get("Last-Modified")
+		require.NotEmpty(t, lastModified)
+
+		rr = patch(`{"status":"muted","reason":"expected"}`, lastModified)
+		assert.Equal(t, http.StatusOK, rr.Code, "Response: %s", rr.Body.String())
+	})
 }
 
 // TestGetIncidentCounts_Integration tests GET /api/v1/incidents/counts endpoint.
@@ -1,12 +1,14 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,8 +30,8 @@ func (s *Server) handleLineageEvent(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 	correlationID := middleware.GetCorrelationID(r.Context())
 
-	if !hasJSONContentType(r.Header.Get("Content-Type")) {
-		WriteErrorResponse(w, r, s.logger, UnsupportedMediaType("Content-Type must be application/json"))
+	if problem := s.checkLineageContentType(r); problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
 
 		return
 	}
@@ -48,9 +50,16 @@ func (s *Server) handleLineageEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	body, err := io.ReadAll(io.LimitReader(r.Body, s.config.MaxRequestSize))
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, bodyReadProblem(err))
+
+		return
+	}
+
 	var event LineageEvent
 
-	if err := json.NewDecoder(io.LimitReader(r.Body, s.config.MaxRequestSize)).Decode(&event); err != nil {
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&event); err != nil {
 		if err == io.EOF {
 			WriteErrorResponse(w, r, s.logger, BadRequest("Request body cannot be empty"))
 
@@ -62,7 +71,7 @@ func (s *Server) handleLineageEvent(w http.ResponseWriter, r *http.Request) {
 			slog.String("error", err.Error()),
 		)
 
-		WriteErrorResponse(w, r, s.logger, BadRequest("Invalid JSON: "+err.Error()))
+		WriteErrorResponse(w, r, s.logger, InvalidJSON(err, body))
 
 		return
 	}
@@ -73,29 +82,42 @@ func (s *Server) handleLineageEvent(w http.ResponseWriter, r *http.Request) {
 	normalized := normalizeInputsAndOutputs([]*ingestion.RunEvent{runEvent})
 	runEvent = normalized[0]
 
-	if err := s.validator.ValidateRunEvent(runEvent); err != nil {
-		s.logger.ErrorContext(r.Context(), "failed to validate run_event",
-			slog.String("correlation_id", correlationID),
-			slog.String("error", err.Error()),
-		)
+	if problem := s.admitLineageEvent(r, correlationID, runEvent); problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
 
-		WriteErrorResponse(w, r, s.logger, UnprocessableEntity(err.Error()))
+		return
+	}
+
+	runEvent.IngestedBy = ingestingPlugin(r.Context())
+
+	reservation, problem := s.consumeIngestionQuota(r.Context(), runEvent.IngestedBy, 1)
+	if problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
 
 		return
 	}
 
 	stored, duplicate, err := s.ingestionStore.StoreEvent(r.Context(), runEvent)
+	if !stored {
+		s.releaseIngestionQuota(r.Context(), reservation, 1)
+	}
+
 	if err != nil {
 		s.logger.Error("Failed to store event",
 			slog.String("correlation_id", correlationID),
 			slog.String("error", err.Error()),
 		)
 
+		middleware.RecordTrace(r.Context(), middleware.TraceStageStore, "failed: "+err.Error())
+
 		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to store event"))
 
 		return
 	}
 
+	middleware.RecordTrace(r.Context(), middleware.TraceStageStore,
+		fmt.Sprintf("stored=%t duplicate=%t", stored, duplicate))
+
 	s.logger.Info("Lineage event processed",
 		slog.String("correlation_id", correlationID),
 		slog.Bool("stored", stored),
@@ -107,6 +129,42 @@ func (s *Server) handleLineageEvent(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// admitLineageEvent validates a single event and runs the interceptors on it (see
+// ingestion.EventInterceptor). Returns a 422 problem if the event is invalid or rejected.
+func (s *Server) admitLineageEvent(r *http.Request, correlationID string, event *ingestion.RunEvent) *ProblemDetail {
+	if err := s.eventValidator(isBackfillRequest(r)).ValidateRunEvent(event); err != nil {
+		s.logger.ErrorContext(r.Context(), "failed to validate run_event",
+			slog.String("correlation_id", correlationID),
+			slog.String("error", err.Error()),
+		)
+
+		middleware.RecordTrace(r.Context(), middleware.TraceStageValidation, "failed: "+err.Error())
+
+		return UnprocessableEntity(err.Error())
+	}
+
+	// OL spec leaves no room for warnings in the (empty) body: they are logged and traced only
+	if warnings := s.eventWarnings(correlationID, event); len(warnings) > 0 {
+		middleware.RecordTrace(r.Context(), middleware.TraceStageValidation,
+			"passed with warnings: "+strings.Join(warnings, "; "))
+	} else {
+		middleware.RecordTrace(r.Context(), middleware.TraceStageValidation, "passed")
+	}
+
+	if err := s.interceptors.BeforeStore(r.Context(), event); err != nil {
+		s.logger.WarnContext(r.Context(), "run_event rejected by interceptor",
+			slog.String("correlation_id", correlationID),
+			slog.String("error", err.Error()),
+		)
+
+		middleware.RecordTrace(r.Context(), middleware.TraceStageValidation, "rejected: "+err.Error())
+
+		return UnprocessableEntity(err.Error())
+	}
+
+	return nil
+}
+
 // handleLineageEvents handles OpenLineage event ingestion.
 // POST /api/v1/lineage/batch - Ingest batch OpenLineage events
 //
@@ -120,12 +178,26 @@ func (s *Server) handleLineageEvent(w http.ResponseWriter, r *http.Request) {
 // Success responses:
 //   - 200 OK: All events stored or duplicates (idempotency)
 //   - 207 Multi-Status: Partial success (some stored, some failed)
+//   - 202 Accepted: Queued for async processing ("Prefer: respond-async", see handleAsyncLineageBatch)
+//
+// Atomic batches ("X-Batch-Atomic: true" or "?atomic=true", see storeAtomicBatch) are all-or-nothing:
+// 200 when every event is stored, otherwise 422 with nothing stored. They are always processed
+// synchronously.
 func (s *Server) handleLineageEvents(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 	correlationID := middleware.GetCorrelationID(r.Context())
 
-	if !hasJSONContentType(r.Header.Get("Content-Type")) {
-		WriteErrorResponse(w, r, s.logger, UnsupportedMediaType("Content-Type must be application/json"))
+	if problem := s.checkLineageContentType(r); problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	// Async is opt-in; without a batch store the preference is ignored (RFC 7240 allows this)
+	atomic := isAtomicBatchRequest(r)
+
+	if s.lineageBatchStore != nil && prefersRespondAsync(r.Header) && !atomic {
+		s.handleAsyncLineageBatch(w, r)
 
 		return
 	}
@@ -143,8 +215,9 @@ func (s *Server) handleLineageEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	s.logger.Debug("lineage events ingested", slog.Any("events", events))
+	s.verboseEvents.logEvents(r.Context(), correlationID, events)
 
-	sortedEvents, validationErrors, problem := s.validateEvents(events)
+	sortedEvents, validationErrors, problem := s.validateEvents(s.eventValidator(isBackfillRequest(r)), events)
 	if problem != nil {
 		s.logger.ErrorContext(r.Context(), "Failed to validate events",
 			slog.String("correlation_id", correlationID),
@@ -152,12 +225,22 @@ func (s *Server) handleLineageEvents(w http.ResponseWriter, r *http.Request) {
 			slog.Any("validation_errors", validationErrors),
 		)
 
+		middleware.RecordTrace(r.Context(), middleware.TraceStageValidation, "failed: "+problem.Detail)
+
 		WriteErrorResponse(w, r, s.logger, problem)
 
 		return
 	}
 
-	storeResults, problem := s.storeValidEvents(r.Context(), sortedEvents, validationErrors)
+	middleware.RecordTrace(r.Context(), middleware.TraceStageValidation,
+		fmt.Sprintf("%d of %d events invalid", countErrors(validationErrors), len(sortedEvents)))
+
+	store := s.storeValidEvents
+	if atomic {
+		store = s.storeAtomicBatch
+	}
+
+	storeResults, problem := store(r.Context(), ingestingPlugin(r.Context()), sortedEvents, validationErrors)
 	if problem != nil {
 		s.logger.ErrorContext(r.Context(), "Failed to store events",
 			slog.String("correlation_id", correlationID),
@@ -165,6 +248,8 @@ func (s *Server) handleLineageEvents(w http.ResponseWriter, r *http.Request) {
 			slog.Any("problem", problem),
 		)
 
+		middleware.RecordTrace(r.Context(), middleware.TraceStageStore, "failed: "+problem.Detail)
+
 		WriteErrorResponse(w, r, s.logger, problem)
 
 		return
@@ -172,6 +257,9 @@ func (s *Server) handleLineageEvents(w http.ResponseWriter, r *http.Request) {
 
 	response := s.buildLineageResponse(correlationID, sortedEvents, validationErrors, storeResults)
 
+	middleware.RecordTrace(r.Context(), middleware.TraceStageStore, fmt.Sprintf("status=%s successful=%d failed=%d",
+		response.Status, response.Summary.Successful, response.Summary.Failed))
+
 	statusCode := s.sendLineageResponse(w, r, response)
 
 	duration := time.Since(startTime)
@@ -227,11 +315,15 @@ func (s *Server) parseLineageRequest(r *http.Request) ([]*ingestion.RunEvent, *P
 		return nil, BadRequest("Request body cannot be empty")
 	}
 
+	body, err := io.ReadAll(io.LimitReader(r.Body, s.config.MaxRequestSize))
+	if err != nil {
+		return nil, bodyReadProblem(err)
+	}
+
 	var events []LineageEvent
 
-	decoder := json.NewDecoder(io.LimitReader(r.Body, s.config.MaxRequestSize))
-	if err := decoder.Decode(&events); err != nil {
-		return nil, BadRequest("Invalid JSON: " + err.Error())
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&events); err != nil {
+		return nil, InvalidJSON(err, body)
 	}
 
 	if len(events) == 0 {
@@ -266,14 +358,52 @@ func normalizeInputsAndOutputs(events []*ingestion.RunEvent) []*ingestion.RunEve
 	return events
 }
 
+// backfillHeader marks an ingestion request as a backfill: events are accepted whatever their
+// eventTime (no skew check, see ingestion.WithMaxEventTimeSkew).
+const backfillHeader = "X-Correlator-Backfill"
+
+// isBackfillRequest reports whether the request carries "X-Correlator-Backfill: true".
+func isBackfillRequest(r *http.Request) bool {
+	backfill, err := strconv.ParseBool(r.Header.Get(backfillHeader))
+
+	return err == nil && backfill
+}
+
+// atomicBatchHeader (or the "atomic" query parameter) requests an atomic batch: all events are
+// stored in one transaction, or none are (see storeAtomicBatch).
+const atomicBatchHeader = "X-Batch-Atomic"
+
+// isAtomicBatchRequest reports whether the request carries "X-Batch-Atomic: true" or "?atomic=true".
+func isAtomicBatchRequest(r *http.Request) bool {
+	value := r.Header.Get(atomicBatchHeader)
+	if value == "" {
+		value = r.URL.Query().Get("atomic")
+	}
+
+	atomic, err := strconv.ParseBool(value)
+
+	return err == nil && atomic
+}
+
+// eventValidator returns the validator for an ingestion request. Backfills skip the event time
+// skew check, since they carry old events by design.
+func (s *Server) eventValidator(backfill bool) *ingestion.Validator {
+	if backfill {
+		return s.validator.WithoutEventTimeSkewCheck()
+	}
+
+	return s.validator
+}
+
 // validateEvents validates event sequence and individual events.
 // Returns sorted events, validation errors per event, or a ProblemDetail if sequence validation fails.
 //
 // Performs:
 //   - Event sequence validation (for single-run batches only)
 //   - Sorting by eventTime
-//   - Individual event validation using domain validator
+//   - Individual event validation using validator (see eventValidator)
 func (s *Server) validateEvents(
+	validator *ingestion.Validator,
 	events []*ingestion.RunEvent,
 ) ([]*ingestion.RunEvent, []error, *ProblemDetail) {
 	// Validate event sequence (for single-run batches only)
@@ -296,8 +426,7 @@ func (s *Server) validateEvents(
 	validationErrors := make([]error, len(sortedEvents))
 
 	for i := range sortedEvents {
-		// Validate using shared validator (created once in constructor)
-		if err := s.validator.ValidateRunEvent(sortedEvents[i]); err != nil {
+		if err := validator.ValidateRunEvent(sortedEvents[i]); err != nil {
 			validationErrors[i] = err
 		}
 	}
@@ -305,27 +434,44 @@ func (s *Server) validateEvents(
 	return sortedEvents, validationErrors, nil
 }
 
-// storeValidEvents filters valid events and stores them in the database.
+// countErrors returns the number of non-nil errors in a per-event error slice.
+func countErrors(errs []error) int {
+	n := 0
+
+	for _, err := range errs {
+		if err != nil {
+			n++
+		}
+	}
+
+	return n
+}
+
+// storeValidEvents filters valid events and stores them in the database, recorded as ingested by
+// plugin (see ingestingPlugin).
 // Returns store results (sparse array with nil for invalid events) or a ProblemDetail on catastrophic failure.
 //
+// Valid events pass the server's interceptors first (see ingestion.EventInterceptor); a rejection
+// is recorded in validationErrors, so it is reported like a validation failure of that event.
+// Then they are counted against plugin's ingestion quota; when it is exhausted nothing is stored
+// and a 429 quota-exceeded problem is returned. Events that are not stored are given back.
+//
 // This function implements the critical bug fix: filters out invalid events before passing to storage,
 // preventing nil pointer panics in the storage layer.
 func (s *Server) storeValidEvents(
 	ctx context.Context,
+	plugin string,
 	events []*ingestion.RunEvent,
 	validationErrors []error,
 ) ([]*ingestion.EventStoreResult, *ProblemDetail) {
 	correlationID := middleware.GetCorrelationID(ctx)
 
 	// Filter out invalid events (don't send nil pointers to storage)
-	validEvents := make([]*ingestion.RunEvent, 0, len(events))
-	validIndexes := make([]int, 0, len(events))
+	validEvents, validIndexes := s.admitValidEvents(ctx, plugin, events, validationErrors)
 
-	for i := range events {
-		if validationErrors[i] == nil {
-			validEvents = append(validEvents, events[i])
-			validIndexes = append(validIndexes, i)
-		}
+	reservation, problem := s.consumeIngestionQuota(ctx, plugin, len(validEvents))
+	if problem != nil {
+		return nil, problem
 	}
 
 	// Store only valid events
@@ -334,6 +480,7 @@ func (s *Server) storeValidEvents(
 	if len(validEvents) > 0 {
 		validResults, err := s.ingestionStore.StoreEvents(ctx, validEvents)
 		if err != nil {
+			s.releaseIngestionQuota(ctx, reservation, len(validEvents))
 			s.logger.Error("Failed to store events",
 				slog.String("correlation_id", correlationID),
 				slog.String("error", err.Error()),
@@ -342,6 +489,8 @@ func (s *Server) storeValidEvents(
 			return nil, InternalServerError("Failed to store events")
 		}
 
+		s.releaseIngestionQuota(ctx, reservation, unstoredEvents(validResults))
+
 		// Map results back to original indexes (sparse array)
 		for i, validIdx := range validIndexes {
 			storeResults[validIdx] = validResults[i]
@@ -351,6 +500,83 @@ func (s *Server) storeValidEvents(
 	return storeResults, nil
 }
 
+// storeAtomicBatch is storeValidEvents for atomic batches (see isAtomicBatchRequest): the events are
+// stored in one transaction (see ingestion.Store.StoreEventsAtomic), or not at all.
+//
+// When any event is invalid or rejected by an interceptor nothing is stored, and the other events
+// fail with ingestion.ErrBatchRolledBack, so the response is a 422 that lists every event.
+func (s *Server) storeAtomicBatch(
+	ctx context.Context,
+	plugin string,
+	events []*ingestion.RunEvent,
+	validationErrors []error,
+) ([]*ingestion.EventStoreResult, *ProblemDetail) {
+	validEvents, validIndexes := s.admitValidEvents(ctx, plugin, events, validationErrors)
+	storeResults := make([]*ingestion.EventStoreResult, len(events))
+
+	if len(validEvents) < len(events) {
+		for _, i := range validIndexes {
+			storeResults[i] = &ingestion.EventStoreResult{Event: events[i], Error: ingestion.ErrBatchRolledBack}
+		}
+
+		return storeResults, nil
+	}
+
+	reservation, problem := s.consumeIngestionQuota(ctx, plugin, len(validEvents))
+	if problem != nil {
+		return nil, problem
+	}
+
+	results, err := s.ingestionStore.StoreEventsAtomic(ctx, validEvents)
+	if err != nil {
+		s.releaseIngestionQuota(ctx, reservation, len(validEvents))
+		s.logger.Error("Failed to store atomic batch",
+			slog.String("correlation_id", middleware.GetCorrelationID(ctx)),
+			slog.String("error", err.Error()),
+		)
+
+		return nil, InternalServerError("Failed to store events")
+	}
+
+	s.releaseIngestionQuota(ctx, reservation, unstoredEvents(results))
+
+	// Every event is valid: results line up with events
+	copy(storeResults, results)
+
+	return storeResults, nil
+}
+
+// admitValidEvents returns the events without a validation error, in order, with their indexes in
+// events. Each passes the server's interceptors first; a rejection is recorded in validationErrors
+// and leaves the event out. Admitted events are marked as ingested by plugin.
+func (s *Server) admitValidEvents(
+	ctx context.Context,
+	plugin string,
+	events []*ingestion.RunEvent,
+	validationErrors []error,
+) ([]*ingestion.RunEvent, []int) {
+	validEvents := make([]*ingestion.RunEvent, 0, len(events))
+	validIndexes := make([]int, 0, len(events))
+
+	for i := range events {
+		if validationErrors[i] != nil {
+			continue
+		}
+
+		if err := s.interceptors.BeforeStore(ctx, events[i]); err != nil {
+			validationErrors[i] = err
+
+			continue
+		}
+
+		events[i].IngestedBy = plugin
+		validEvents = append(validEvents, events[i])
+		validIndexes = append(validIndexes, i)
+	}
+
+	return validEvents, validIndexes
+}
+
 // buildLineageResponse builds OpenLineage-compliant batch response.
 // Only includes failed events (OpenLineage spec), not successful ones.
 //
@@ -366,6 +592,8 @@ func (s *Server) buildLineageResponse(
 	failedEvents := make([]FailedEvent, 0)
 	successful, failed, retriable, nonRetriable := 0, 0, 0, 0
 
+	var warnings []EventWarning
+
 	for i := range events {
 		// Check validation error first
 		if validationErrors[i] != nil {
@@ -430,6 +658,10 @@ func (s *Server) buildLineageResponse(
 		// Success (stored or duplicate)
 		// OpenLineage spec: duplicates are idempotent success (not failures)
 		successful++
+
+		for _, reason := range s.eventWarnings(correlationID, events[i]) {
+			warnings = append(warnings, EventWarning{Index: i, Reason: reason})
+		}
 	}
 
 	// Determine overall status
@@ -448,11 +680,38 @@ func (s *Server) buildLineageResponse(
 			NonRetriable: nonRetriable,
 		},
 		FailedEvents:  failedEvents,
+		Warnings:      warnings,
 		CorrelationID: correlationID,
 		Timestamp:     time.Now().UTC().Format(time.RFC3339),
 	}
 }
 
+// eventWarnings returns the soft validation findings (see ingestion.Validator.Warnings) for an
+// accepted event and logs them, so producer health can be monitored without rejecting events.
+func (s *Server) eventWarnings(correlationID string, event *ingestion.RunEvent) []string {
+	warnings := s.validator.Warnings(event)
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	reasons := make([]string, len(warnings))
+
+	for i, warning := range warnings {
+		reasons[i] = warning.Error()
+
+		s.logger.Warn("Event accepted with validation warning",
+			slog.String("correlation_id", correlationID),
+			slog.String("run_id", event.Run.ID),
+			slog.String("job_namespace", event.Job.Namespace),
+			slog.String("job_name", event.Job.Name),
+			slog.String("producer", event.Producer),
+			slog.String("reason", reasons[i]),
+		)
+	}
+
+	return reasons
+}
+
 // determineStatusCode determines HTTP status code from OpenLineage response.
 //
 // Status code logic:
@@ -515,6 +774,16 @@ func (s *Server) sendLineageResponse(
 	return statusCode
 }
 
+// ingestingPlugin returns the plugin (API key client ID) of the authenticated request, or
+// ingestion.AnonymousPlugin when authentication is disabled.
+func ingestingPlugin(ctx context.Context) string {
+	if clientCtx, ok := middleware.GetClientContext(ctx); ok && clientCtx.ClientID != "" {
+		return clientCtx.ClientID
+	}
+
+	return ingestion.AnonymousPlugin
+}
+
 // mapLineageRequest maps an API request type to the domain model.
 // This explicit mapping layer decouples the API contract from internal domain types.
 //
@@ -522,11 +791,14 @@ func (s *Server) sendLineageResponse(
 //   - Whitespace trimming on string fields
 //   - Dataset URN normalization (critical for multi-tool correlation)
 //   - Nil facets initialization to empty maps
+//   - Error facet parsing for FAIL/ABORT events (ingestion.ParseRunError)
+//   - Nominal time facet parsing (ingestion.ParseNominalTime)
+//   - Processing engine facet parsing (ingestion.ParseProcessingEngine)
 //
 // Validation is delegated to the domain layer (ingestion.Validator.ValidateRunEvent)
 // following Clean Architecture principles: domain owns its invariants.
 func mapLineageRequest(req *LineageEvent) *ingestion.RunEvent {
-	return &ingestion.RunEvent{
+	event := &ingestion.RunEvent{
 		EventTime: ingestion.ParseEventTime(req.EventTime),
 		EventType: ingestion.EventType(strings.TrimSpace(req.EventType)),
 		Producer:  strings.TrimSpace(req.Producer),
@@ -536,6 +808,12 @@ func mapLineageRequest(req *LineageEvent) *ingestion.RunEvent {
 		Inputs:    mapDatasets(req.Inputs),
 		Outputs:   mapDatasets(req.Outputs),
 	}
+
+	event.Error = ingestion.ParseRunError(event.EventType, event.Run.Facets)
+	event.NominalTime = ingestion.ParseNominalTime(event.Run.Facets)
+	event.ProcessingEngine = ingestion.ParseProcessingEngine(event.Run.Facets)
+
+	return event
 }
 
 // mapRunRequest maps API Run model to domain Run model.
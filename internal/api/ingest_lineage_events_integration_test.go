@@ -6,10 +6,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 
 	"github.com/correlator-io/correlator/internal/config"
+	"github.com/correlator-io/correlator/internal/ingestion"
 	"github.com/correlator-io/correlator/internal/storage"
 )
 
@@ -29,6 +32,7 @@ type testServer struct {
 	apiKey       string
 	db           *sql.DB               // For database verification helpers
 	lineageStore *storage.LineageStore // For calling InitResolvedDatasets in tests
+	keyStore     storage.APIKeyStore   // For adding API keys of other plugins in tests
 }
 
 // setupTestServer creates a fully configured test server with all dependencies.
@@ -71,10 +75,14 @@ func setupTestServer(ctx context.Context, t *testing.T) *testServer {
 		ShutdownTimeout:    30 * time.Second,
 		LogLevel:           slog.LevelInfo,
 		MaxRequestSize:     defaultMaxRequestSize,
+		MaxImportSize:      defaultMaxImportSize,
+		MaxImportPartSize:  defaultMaxImportPart,
 		CORSAllowedOrigins: []string{"*"},
 		CORSAllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
 		CORSAllowedHeaders: []string{"Content-Type", "Authorization", "X-Correlation-ID"},
 		CORSMaxAge:         86400,
+		IdempotencyMaxKeys: 100,
+		IdempotencyKeyTTL:  time.Hour,
 	}
 
 	// Create server with dependencies (no rate limiter for lineage tests)
@@ -84,6 +92,11 @@ func setupTestServer(ctx context.Context, t *testing.T) *testServer {
 		IngestionStore:   lineageStore,
 		CorrelationStore: lineageStore,
 		ResolutionStore:  lineageStore,
+
+		LineageBatchStore:   lineageStore,
+		ProducerStore:       lineageStore,
+		IngestionQuotaStore: lineageStore,
+		LineageSearchStore:  lineageStore,
 	}, BuildInfo{})
 
 	// Register cleanup (closure captures dependencies)
@@ -99,6 +112,7 @@ func setupTestServer(ctx context.Context, t *testing.T) *testServer {
 		apiKey:       testAPIKey,
 		db:           testDB.Connection,
 		lineageStore: lineageStore,
+		keyStore:     keyStore,
 	}
 }
 
@@ -493,6 +507,76 @@ func TestLineageHandler_BatchPartialSuccess(t *testing.T) {
 	ts.assertEventNotStored(ctx, t, invalidEvent.Run.ID)
 }
 
+var errSandboxNotOnboarded = errors.New("namespace sandbox is not onboarded")
+
+// TestLineageHandler_Interceptors tests pre-store interceptors: an enriching one whose facet is
+// stored, and a rejecting one whose message fails only its event.
+// Expected: 207 Multi-Status on the batch endpoint, 422 on the single-event endpoint.
+func TestLineageHandler_Interceptors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	ts.server.interceptors = ingestion.Interceptors{
+		ingestion.EventInterceptorFunc(func(_ context.Context, event *ingestion.RunEvent) error {
+			if event.Job.Namespace == "finance" {
+				event.Run.Facets["costCenter"] = map[string]interface{}{"id": "cc-42"}
+			}
+
+			return nil
+		}),
+		ingestion.EventInterceptorFunc(func(_ context.Context, event *ingestion.RunEvent) error {
+			if event.Job.Namespace == "sandbox" {
+				return errSandboxNotOnboarded
+			}
+
+			return nil
+		}),
+	}
+
+	now := time.Now()
+	enriched := createValidLineageEvent("interceptor-enriched", "START", now)
+	enriched.Job.Namespace = "finance"
+	rejected := createValidLineageEvent("interceptor-rejected", "START", now)
+	rejected.Job.Namespace = "sandbox"
+
+	rr := ts.postLineageEvents(t, []LineageEvent{enriched, rejected})
+
+	response := validateLineageResponse(t, rr, http.StatusMultiStatus)
+	require.NotNil(t, response, "Failed to validate response")
+
+	assert.Equal(t, 1, response.Summary.Successful, "Expected the enriched event to be stored")
+	require.Len(t, response.FailedEvents, 1, "Expected the rejected event to fail")
+	assert.Equal(t, 1, response.FailedEvents[0].Index)
+	assert.Equal(t, "event rejected: namespace sandbox is not onboarded", response.FailedEvents[0].Reason)
+	assert.False(t, response.FailedEvents[0].Retriable, "Rejections are non-retriable")
+
+	ts.verifyEventStored(ctx, t, enriched.Run.ID, "START")
+	ts.assertEventNotStored(ctx, t, rejected.Run.ID)
+
+	var costCenter string
+
+	err := ts.db.QueryRowContext(ctx,
+		`SELECT metadata->'run_facets'->'costCenter'->>'id' FROM job_runs WHERE run_id = $1`,
+		enriched.Run.ID,
+	).Scan(&costCenter)
+	require.NoError(t, err, "Failed to read stored run facets")
+	assert.Equal(t, "cc-42", costCenter, "Expected the enriched facet to be stored")
+
+	single := createValidLineageEvent("interceptor-single", "START", now)
+	single.Job.Namespace = "sandbox"
+
+	rr = ts.postLineageEvent(t, single)
+
+	validateRFC7807Response(t, rr, http.StatusUnprocessableEntity)
+	assert.Contains(t, rr.Body.String(), "namespace sandbox is not onboarded")
+
+	ts.assertEventNotStored(ctx, t, single.Run.ID)
+}
+
 // TestLineageHandler_BatchAllRejected tests batch where all events fail validation.
 // Expected: 422 Unprocessable Entity with per-event error details.
 func TestLineageHandler_BatchAllRejected(t *testing.T) {
@@ -547,6 +631,147 @@ func TestLineageHandler_BatchAllRejected(t *testing.T) {
 	}
 }
 
+// TestLineageHandler_AtomicBatch tests atomic batches ("X-Batch-Atomic: true" or "?atomic=true").
+// Expected: 200 when every event is stored; 422 with a reason per event and nothing stored when
+// one event fails.
+func TestLineageHandler_AtomicBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	postAtomic := func(target string, header bool, events []LineageEvent) *httptest.ResponseRecorder {
+		body, err := json.Marshal(events)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		if header {
+			req.Header.Set("X-Batch-Atomic", "true")
+		}
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	now := time.Now()
+
+	t.Run("all events succeed", func(t *testing.T) {
+		event1 := createValidLineageEvent("atomic-ok-1", "START", now)
+		event2 := createValidLineageEvent("atomic-ok-2", "START", now)
+
+		rr := postAtomic("/api/v1/lineage/batch", true, []LineageEvent{event1, event2})
+
+		response := validateLineageResponse(t, rr, http.StatusOK)
+		require.NotNil(t, response, "Failed to validate response")
+
+		assert.Equal(t, 2, response.Summary.Successful)
+		assert.Empty(t, response.FailedEvents)
+
+		ts.verifyEventStored(ctx, t, event1.Run.ID, "START")
+		ts.verifyEventStored(ctx, t, event2.Run.ID, "START")
+	})
+
+	t.Run("one invalid event rolls back the batch", func(t *testing.T) {
+		valid1 := createValidLineageEvent("atomic-fail-1", "START", now)
+		invalid := createValidLineageEvent("atomic-fail-2", "START", now)
+		invalid.Job.Name = "" // Invalid: missing required field
+		valid2 := createValidLineageEvent("atomic-fail-3", "START", now)
+
+		rr := postAtomic("/api/v1/lineage/batch?atomic=true", false, []LineageEvent{valid1, invalid, valid2})
+
+		response := validateLineageResponse(t, rr, http.StatusUnprocessableEntity)
+		require.NotNil(t, response, "Failed to validate response")
+
+		assert.Equal(t, "error", response.Status)
+		assert.Equal(t, 0, response.Summary.Successful)
+		assert.Equal(t, 3, response.Summary.Failed)
+		require.Len(t, response.FailedEvents, 3)
+
+		assert.Contains(t, response.FailedEvents[1].Reason, "job.name")
+		assert.Equal(t, ingestion.ErrBatchRolledBack.Error(), response.FailedEvents[0].Reason)
+		assert.Equal(t, ingestion.ErrBatchRolledBack.Error(), response.FailedEvents[2].Reason)
+
+		ts.assertEventNotStored(ctx, t, valid1.Run.ID)
+		ts.assertEventNotStored(ctx, t, invalid.Run.ID)
+		ts.assertEventNotStored(ctx, t, valid2.Run.ID)
+	})
+
+	t.Run("one storage failure rolls back the batch", func(t *testing.T) {
+		completed := createValidLineageEvent("atomic-terminal", "COMPLETE", now)
+		validateLineageResponse(t, ts.postLineageEvents(t, []LineageEvent{completed}), http.StatusOK)
+
+		valid := createValidLineageEvent("atomic-before-restart", "START", now)
+		restart := createValidLineageEvent("atomic-terminal", "START", now.Add(time.Minute))
+
+		rr := postAtomic("/api/v1/lineage/batch", true, []LineageEvent{valid, restart})
+
+		response := validateLineageResponse(t, rr, http.StatusUnprocessableEntity)
+		require.NotNil(t, response, "Failed to validate response")
+		require.Len(t, response.FailedEvents, 2)
+
+		assert.Equal(t, ingestion.ErrBatchRolledBack.Error(), response.FailedEvents[0].Reason)
+		assert.Contains(t, response.FailedEvents[1].Reason, "terminal state")
+
+		ts.assertEventNotStored(ctx, t, valid.Run.ID)
+	})
+}
+
+// TestLineageHandler_VerboseProducerLogging tests that only events of producers selected with
+// CORRELATOR_VERBOSE_LOG_PRODUCERS (by producer path segment or job namespace) are dumped.
+func TestLineageHandler_VerboseProducerLogging(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	var logs bytes.Buffer
+
+	ts.server.verboseEvents = newVerboseEventLogger([]string{"dbt-core", "sandbox"}, &logs)
+
+	now := time.Now()
+
+	dbtEvent := createValidLineageEvent("verbose-dbt", "START", now)
+	dbtEvent.Producer = "https://github.com/dbt-labs/dbt-core/tree/1.5.0"
+
+	sandboxEvent := createValidLineageEvent("verbose-sandbox", "START", now)
+	sandboxEvent.Job.Namespace = "sandbox"
+
+	quietEvent := createValidLineageEvent("verbose-quiet", "START", now)
+
+	rr := ts.postLineageEvents(t, []LineageEvent{dbtEvent, sandboxEvent, quietEvent})
+	validateLineageResponse(t, rr, http.StatusOK)
+
+	var loggedRunIDs []string
+
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+
+		assert.Equal(t, "DEBUG", record["level"])
+		assert.NotEmpty(t, record["event"], "Expected the full event")
+
+		event, err := json.Marshal(record["event"])
+		require.NoError(t, err)
+
+		for _, runID := range []string{dbtEvent.Run.ID, sandboxEvent.Run.ID, quietEvent.Run.ID} {
+			if strings.Contains(string(event), runID) {
+				loggedRunIDs = append(loggedRunIDs, runID)
+			}
+		}
+	}
+
+	assert.Equal(t, []string{dbtEvent.Run.ID, sandboxEvent.Run.ID}, loggedRunIDs)
+}
+
 // TestLineageHandler_DuplicateEvent tests idempotency - same event twice returns 200 OK both times.
 // Expected: First request stores, second request returns duplicate (both 200 OK).
 func TestLineageHandler_DuplicateEvent(t *testing.T) {
@@ -595,6 +820,162 @@ func TestLineageHandler_DuplicateEvent(t *testing.T) {
 	ts.verifyEventStored(ctx, t, runID, "START")
 }
 
+// TestLineageHandler_CompleteWithoutOutputs tests the optional COMPLETE outputs check.
+// Expected: warn mode stores the event and reports a warning; reject mode fails it with 422.
+func TestLineageHandler_CompleteWithoutOutputs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	now := time.Now()
+
+	t.Run("warn", func(t *testing.T) {
+		ts.server.validator = ingestion.NewValidator(ingestion.WithCompleteOutputsCheck(ingestion.OutputsCheckWarn, nil))
+
+		withOutputs := createValidLineageEvent("outputs-warn-ok", "COMPLETE", now)
+		withoutOutputs := createValidLineageEvent("outputs-warn", "COMPLETE", now)
+		withoutOutputs.Outputs = nil
+
+		response := validateLineageResponse(t, ts.postLineageEvents(t, []LineageEvent{withOutputs, withoutOutputs}),
+			http.StatusOK)
+		require.NotNil(t, response, "Failed to validate response")
+
+		assert.Equal(t, 2, response.Summary.Successful, "Warned events are accepted")
+		require.Len(t, response.Warnings, 1)
+		assert.Equal(t, 1, response.Warnings[0].Index)
+		assert.Equal(t, "COMPLETE event has no outputs (job default/test-job)", response.Warnings[0].Reason)
+
+		ts.verifyEventStored(ctx, t, withoutOutputs.Run.ID, "COMPLETE")
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		ts.server.validator = ingestion.NewValidator(ingestion.WithCompleteOutputsCheck(ingestion.OutputsCheckReject,
+			map[string]ingestion.OutputsCheckMode{"exempt": ingestion.OutputsCheckOff}))
+
+		rejected := createValidLineageEvent("outputs-reject", "COMPLETE", now)
+		rejected.Outputs = nil
+		exempt := createValidLineageEvent("outputs-exempt", "COMPLETE", now)
+		exempt.Job.Namespace = "exempt"
+		exempt.Outputs = nil
+
+		response := validateLineageResponse(t, ts.postLineageEvents(t, []LineageEvent{rejected, exempt}),
+			http.StatusMultiStatus)
+		require.NotNil(t, response, "Failed to validate response")
+
+		require.Len(t, response.FailedEvents, 1)
+		assert.Equal(t, 0, response.FailedEvents[0].Index)
+		assert.Contains(t, response.FailedEvents[0].Reason, "COMPLETE event has no outputs")
+		assert.Empty(t, response.Warnings)
+
+		ts.assertEventNotStored(ctx, t, rejected.Run.ID)
+		ts.verifyEventStored(ctx, t, exempt.Run.ID, "COMPLETE")
+	})
+}
+
+// TestLineageHandler_EventTimeSkew tests the event time skew check.
+// Expected: events too far from server time fail with 422; backfill requests skip the check.
+func TestLineageHandler_EventTimeSkew(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+	ts.server.validator = ingestion.NewValidator(ingestion.WithMaxEventTimeSkew(time.Hour))
+
+	now := time.Now()
+
+	t.Run("future event rejected with 422", func(t *testing.T) {
+		event := createValidLineageEvent("skew-future", "START", now.AddDate(3, 0, 0))
+
+		rr := ts.postLineageEvent(t, event)
+
+		validateRFC7807Response(t, rr, http.StatusUnprocessableEntity)
+		assert.Contains(t, rr.Body.String(), "eventTime is too far from server time")
+		ts.assertEventNotStored(ctx, t, event.Run.ID)
+	})
+
+	t.Run("past event rejected in batch", func(t *testing.T) {
+		old := createValidLineageEvent("skew-past", "START", now.Add(-2*time.Hour))
+		current := createValidLineageEvent("skew-current", "START", now)
+
+		response := validateLineageResponse(t, ts.postLineageEvents(t, []LineageEvent{old, current}),
+			http.StatusMultiStatus)
+		require.NotNil(t, response, "Failed to validate response")
+
+		require.Len(t, response.FailedEvents, 1)
+		assert.Equal(t, 0, response.FailedEvents[0].Index)
+		assert.Contains(t, response.FailedEvents[0].Reason, "behind")
+
+		ts.assertEventNotStored(ctx, t, old.Run.ID)
+		ts.verifyEventStored(ctx, t, current.Run.ID, "START")
+	})
+
+	t.Run("backfill header skips the check", func(t *testing.T) {
+		event := createValidLineageEvent("skew-backfill", "START", now.AddDate(-1, 0, 0))
+
+		body, err := json.Marshal([]LineageEvent{event})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage/batch", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+		req.Header.Set("X-Correlator-Backfill", "true")
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		validateLineageResponse(t, rr, http.StatusOK)
+		ts.verifyEventStored(ctx, t, event.Run.ID, "START")
+	})
+}
+
+// TestLineageHandler_IdempotencyKey verifies that a retried batch with the same Idempotency-Key
+// gets the original response replayed, and that reusing the key for a different body is rejected.
+func TestLineageHandler_IdempotencyKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	post := func(events []LineageEvent, idempotencyKey string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(events)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage/batch", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	event := createValidLineageEvent("idempotent-batch", "START", time.Now())
+
+	first := post([]LineageEvent{event}, "ci-run-42")
+	validateLineageResponse(t, first, http.StatusOK)
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	retry := post([]LineageEvent{event}, "ci-run-42")
+	assert.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"), "Retry should be replayed, not reprocessed")
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+
+	other := createValidLineageEvent("idempotent-batch-other", "START", time.Now())
+
+	reused := post([]LineageEvent{other}, "ci-run-42")
+	validateRFC7807Response(t, reused, http.StatusUnprocessableEntity)
+	ts.assertEventNotStored(ctx, t, other.Run.ID)
+}
+
 // TestLineageHandler_RequestTooLarge tests request size limit enforcement.
 // Expected: 413 Payload Too Large.
 func TestLineageHandler_RequestTooLarge(t *testing.T) {
@@ -671,6 +1052,58 @@ func TestLineageHandler_InvalidJSON(t *testing.T) {
 	validateRFC7807Response(t, rr, http.StatusBadRequest)
 }
 
+// TestLineageHandler_InvalidJSONLocation verifies that syntax and type errors are located by
+// line, column, offset, and (for type mismatches) field in the 400 response.
+func TestLineageHandler_InvalidJSONLocation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	tests := []struct {
+		name   string
+		body   string
+		want   ProblemDetail
+		detail string
+	}{
+		{
+			name:   "syntax error",
+			body:   "[\n {\"eventTime\": \"2025-01-01T00:00:00Z\",\n  \"run\": }\n]",
+			want:   ProblemDetail{Offset: 51, Line: 3, Column: 10},
+			detail: "Invalid JSON at line 3, column 10: invalid character '}' looking for beginning of value",
+		},
+		{
+			name:   "type mismatch",
+			body:   "[\n {\"eventTime\": 42}\n]",
+			want:   ProblemDetail{Offset: 19, Line: 2, Column: 17, Field: "0.eventTime"},
+			detail: "Invalid JSON at line 2, column 17: field 0.eventTime expected string, got number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+			rr := httptest.NewRecorder()
+			ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+			validateRFC7807Response(t, rr, http.StatusBadRequest)
+
+			var problem ProblemDetail
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+			assert.Equal(t, tt.detail, problem.Detail)
+			assert.Equal(t, tt.want.Offset, problem.Offset)
+			assert.Equal(t, tt.want.Line, problem.Line)
+			assert.Equal(t, tt.want.Column, problem.Column)
+			assert.Equal(t, tt.want.Field, problem.Field)
+		})
+	}
+}
+
 // TestLineageHandler_EmptyBatch tests empty event array handling.
 // Expected: 400 Bad Request.
 func TestLineageHandler_EmptyBatch(t *testing.T) {
@@ -743,9 +1176,7 @@ func TestLineageHandler_EmptyBody(t *testing.T) {
 }
 
 // TestLineageHandler_InvalidMethod tests that only POST is allowed.
-// Expected: 404 Not Found for GET (method-specific route pattern doesn't match).
-// Note: Go 1.22+ ServeMux with "POST /path" returns 404 for GET /path (not 405),
-// because GET /path doesn't match any registered route pattern.
+// Expected: 405 Method Not Allowed for GET with "Allow: POST" (the path exists, the method doesn't).
 func TestLineageHandler_InvalidMethod(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -761,8 +1192,9 @@ func TestLineageHandler_InvalidMethod(t *testing.T) {
 	rr := httptest.NewRecorder()
 	ts.server.httpServer.Handler.ServeHTTP(rr, req)
 
-	// Validate RFC 7807 error response structure (404 from catch-all handler)
-	validateRFC7807Response(t, rr, http.StatusNotFound)
+	// Validate RFC 7807 error response structure (405 from catch-all handler)
+	validateRFC7807Response(t, rr, http.StatusMethodNotAllowed)
+	assert.Equal(t, http.MethodPost, rr.Header().Get("Allow"))
 }
 
 // TestLineageHandler_RealDBTEvent tests ingestion of a real dbt OpenLineage event from testdata.
@@ -1080,6 +1512,147 @@ func TestSingleEvent_WrongContentType(t *testing.T) {
 	validateRFC7807Response(t, rr, http.StatusUnsupportedMediaType)
 }
 
+// TestSingleEvent_ContentTypeCharset tests charset validation of the JSON Content-Type.
+// Expected: UTF-8 (explicit, any case, or absent) is accepted; other charsets get 415.
+func TestSingleEvent_ContentTypeCharset(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	tests := []struct {
+		name        string
+		contentType string
+		wantStatus  int
+	}{
+		{"utf-8", "application/json; charset=utf-8", http.StatusOK},
+		{"uppercase UTF-8", "application/json; charset=UTF-8", http.StatusOK},
+		{"no charset", "application/json", http.StatusOK},
+		{"unsupported charset", "application/json; charset=utf-16", http.StatusUnsupportedMediaType},
+		{"json subtype", "application/jsonl", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := createValidLineageEvent("single-charset-"+tt.name, "START", time.Now())
+			body, err := json.Marshal(event)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage", bytes.NewReader(body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+			rr := httptest.NewRecorder()
+			ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+			if tt.wantStatus != http.StatusOK {
+				validateRFC7807Response(t, rr, tt.wantStatus)
+
+				return
+			}
+
+			assert.Equal(t, http.StatusOK, rr.Code, "Response body: %s", rr.Body.String())
+		})
+	}
+
+	t.Run("unsupported charset detail", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json; charset=ISO-8859-1")
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Contains(t, rr.Body.String(), `Unsupported charset \"ISO-8859-1\"`)
+	})
+}
+
+// TestLineageHandler_ContentTypeSniffing tests lenient Content-Type checks
+// (ServerConfig.ContentTypeSniffSize). Expected: with sniffing on, a JSON body without
+// Content-Type is accepted; a declared non-JSON type, or a missing one on a non-JSON body, gets
+// 415. With sniffing off, a missing Content-Type gets 415.
+func TestLineageHandler_ContentTypeSniffing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	post := func(t *testing.T, path, contentType, body string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	marshal := func(t *testing.T, v any) string {
+		t.Helper()
+
+		body, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		return string(body)
+	}
+
+	t.Run("strict mode rejects missing Content-Type", func(t *testing.T) {
+		body := marshal(t, createValidLineageEvent("sniff-strict", "START", time.Now()))
+
+		validateRFC7807Response(t, post(t, "/api/v1/lineage", "", body), http.StatusUnsupportedMediaType)
+	})
+
+	ts.server.config.ContentTypeSniffSize = 512
+
+	t.Cleanup(func() { ts.server.config.ContentTypeSniffSize = 0 })
+
+	t.Run("missing Content-Type with JSON object", func(t *testing.T) {
+		event := createValidLineageEvent("sniff-missing-single", "START", time.Now())
+
+		rr := post(t, "/api/v1/lineage", "", "\n  "+marshal(t, event))
+		assert.Equal(t, http.StatusOK, rr.Code, "Response body: %s", rr.Body.String())
+
+		ts.verifyEventStored(ctx, t, event.Run.ID, "START")
+	})
+
+	t.Run("missing Content-Type with JSON array", func(t *testing.T) {
+		events := []LineageEvent{createValidLineageEvent("sniff-missing-batch", "START", time.Now())}
+
+		response := validateLineageResponse(t, post(t, "/api/v1/lineage/batch", "", marshal(t, events)), http.StatusOK)
+		require.NotNil(t, response)
+		assert.Equal(t, 1, response.Summary.Successful)
+	})
+
+	t.Run("missing Content-Type with non-JSON body", func(t *testing.T) {
+		validateRFC7807Response(t, post(t, "/api/v1/lineage", "", "event=START"), http.StatusUnsupportedMediaType)
+	})
+
+	t.Run("correct Content-Type", func(t *testing.T) {
+		body := marshal(t, createValidLineageEvent("sniff-correct", "START", time.Now()))
+
+		rr := post(t, "/api/v1/lineage", "application/json", body)
+		assert.Equal(t, http.StatusOK, rr.Code, "Response body: %s", rr.Body.String())
+	})
+
+	t.Run("wrong Content-Type with JSON body", func(t *testing.T) {
+		event := createValidLineageEvent("sniff-wrong", "START", time.Now())
+
+		validateRFC7807Response(t, post(t, "/api/v1/lineage", "text/plain", marshal(t, event)),
+			http.StatusUnsupportedMediaType)
+		ts.assertEventNotStored(ctx, t, event.Run.ID)
+	})
+}
+
 // TestSingleEvent_EmptyBody tests empty body on single-event endpoint.
 // Expected: 400 Bad Request.
 func TestSingleEvent_EmptyBody(t *testing.T) {
@@ -0,0 +1,363 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+var (
+	errImportFileEmpty   = errors.New("file contains no events")
+	errImportValueFormat = errors.New("expected a JSON object or array of events")
+)
+
+// importPart is a file part read from a multipart import request.
+// Err is set when the part was rejected before parsing (e.g., per-part size limit).
+type importPart struct {
+	filename string
+	data     []byte
+	err      error
+}
+
+// handleLineageImport handles file-oriented OpenLineage imports for backfills.
+// POST /api/v1/lineage/import - multipart/form-data with one or more file parts.
+//
+// Each file part may contain a JSON array of events, a single event object, or NDJSON
+// (one event per line). Every file is processed as an independent batch using the same
+// validation and storage path as POST /api/v1/lineage/batch. Non-file form fields are ignored.
+//
+// Request validation (returns RFC 7807):
+//   - 415 Unsupported Media Type: Content-Type must be multipart/form-data
+//   - 413 Payload Too Large: Request body exceeds MaxImportSize
+//   - 400 Bad Request: Malformed multipart body or no file parts
+//
+// Per-file limits: a part larger than MaxImportPartSize is rejected in its file result
+// without affecting other files.
+//
+// Success responses (ImportResponse):
+//   - 200 OK: All events in all files stored or duplicates
+//   - 207 Multi-Status: Some events or files failed, some succeeded
+//   - 422 Unprocessable Entity: Nothing was stored
+func (s *Server) handleLineageImport(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+	correlationID := middleware.GetCorrelationID(r.Context())
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		WriteErrorResponse(w, r, s.logger, UnsupportedMediaType("Content-Type must be multipart/form-data"))
+
+		return
+	}
+
+	if r.ContentLength > 0 && r.ContentLength > s.config.MaxImportSize {
+		WriteErrorResponse(w, r, s.logger, PayloadTooLarge(
+			fmt.Sprintf("Request body exceeds maximum import size of %d bytes", s.config.MaxImportSize),
+		))
+
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.config.MaxImportSize)
+
+	// Read every part before storing anything, so a request over the total limit
+	// is rejected without partially importing earlier files.
+	parts, problem := s.readImportParts(r)
+	if problem != nil {
+		s.logger.ErrorContext(r.Context(), "Failed to read lineage import",
+			slog.String("correlation_id", correlationID),
+			slog.Any("problem", problem),
+		)
+
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	response := &ImportResponse{
+		Files:         make([]ImportFileResult, 0, len(parts)),
+		CorrelationID: correlationID,
+	}
+
+	for _, part := range parts {
+		result := s.importFile(r.Context(), correlationID, part)
+
+		response.Summary.Received += result.Summary.Received
+		response.Summary.Successful += result.Summary.Successful
+		response.Summary.Failed += result.Summary.Failed
+		response.Summary.Retriable += result.Summary.Retriable
+		response.Summary.NonRetriable += result.Summary.NonRetriable
+		response.Files = append(response.Files, result)
+	}
+
+	statusCode := s.sendImportResponse(w, r, response)
+
+	s.logger.Info("Lineage import processed",
+		slog.String("correlation_id", correlationID),
+		slog.String("status", response.Status),
+		slog.Int("files", len(response.Files)),
+		slog.Int("received", response.Summary.Received),
+		slog.Int("successful", response.Summary.Successful),
+		slog.Int("failed", response.Summary.Failed),
+		slog.Int("status_code", statusCode),
+		slog.Duration("duration", time.Since(startTime)),
+	)
+}
+
+// readImportParts reads all file parts from the multipart body.
+// Returns a ProblemDetail for request-level failures (malformed body, total size exceeded, no files).
+func (s *Server) readImportParts(r *http.Request) ([]importPart, *ProblemDetail) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, BadRequest("Invalid multipart body: " + err.Error())
+	}
+
+	var parts []importPart
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, importReadProblem(err, s.config.MaxImportSize)
+		}
+
+		if part.FileName() == "" {
+			_ = part.Close() // Non-file form field: ignored
+
+			continue
+		}
+
+		imported, err := readImportPart(part, s.config.MaxImportPartSize)
+		if err != nil {
+			return nil, importReadProblem(err, s.config.MaxImportSize)
+		}
+
+		parts = append(parts, imported)
+	}
+
+	if len(parts) == 0 {
+		return nil, BadRequest("Multipart body must contain at least one file part")
+	}
+
+	return parts, nil
+}
+
+// readImportPart reads a single file part, enforcing the per-part size limit.
+// An oversized part is returned with err set; the error return is reserved for read failures.
+func readImportPart(part *multipart.Part, maxPartSize int64) (importPart, error) {
+	defer func() { _ = part.Close() }()
+
+	imported := importPart{filename: part.FileName()}
+
+	data, err := io.ReadAll(io.LimitReader(part, maxPartSize+1))
+	if err != nil {
+		return imported, err
+	}
+
+	if int64(len(data)) > maxPartSize {
+		imported.err = fmt.Errorf("file exceeds maximum size of %d bytes", maxPartSize)
+
+		return imported, nil
+	}
+
+	imported.data = data
+
+	return imported, nil
+}
+
+// importReadProblem maps a multipart read error to a ProblemDetail.
+func importReadProblem(err error, maxImportSize int64) *ProblemDetail {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return PayloadTooLarge(fmt.Sprintf("Request body exceeds maximum import size of %d bytes", maxImportSize))
+	}
+
+	if errors.Is(err, middleware.ErrBodyTooSlow) {
+		return bodyReadProblem(err)
+	}
+
+	return BadRequest("Invalid multipart body: " + err.Error())
+}
+
+// importFile processes one file part as a batch and returns its result.
+// Reuses the batch endpoint's validation, storage, and response classification.
+func (s *Server) importFile(ctx context.Context, correlationID string, part importPart) ImportFileResult {
+	if part.err != nil {
+		return rejectedImportFile(part.filename, 0, part.err.Error())
+	}
+
+	events, err := decodeImportFile(part.data)
+	if err != nil {
+		return rejectedImportFile(part.filename, 0, err.Error())
+	}
+
+	runEvents := make([]*ingestion.RunEvent, len(events))
+
+	for i := range events {
+		runEvents[i] = mapLineageRequest(&events[i])
+	}
+
+	runEvents = normalizeInputsAndOutputs(runEvents)
+
+	// Imports are backfills, so the event time skew check does not apply
+	sortedEvents, validationErrors, problem := s.validateEvents(s.eventValidator(true), runEvents)
+	if problem != nil {
+		return rejectedImportFile(part.filename, len(runEvents), problem.Detail)
+	}
+
+	storeResults, problem := s.storeValidEvents(ctx, ingestingPlugin(ctx), sortedEvents, validationErrors)
+	if problem != nil {
+		return rejectedImportFile(part.filename, len(runEvents), problem.Detail)
+	}
+
+	batch := s.buildLineageResponse(correlationID, sortedEvents, validationErrors, storeResults)
+
+	return ImportFileResult{
+		Filename:     part.filename,
+		Status:       importStatus(batch.Summary.Successful, batch.Summary.Failed),
+		Summary:      batch.Summary,
+		FailedEvents: batch.FailedEvents,
+		Warnings:     batch.Warnings,
+	}
+}
+
+// rejectedImportFile builds the result for a file that was rejected as a whole.
+func rejectedImportFile(filename string, received int, reason string) ImportFileResult {
+	return ImportFileResult{
+		Filename: filename,
+		Status:   "error",
+		Summary: ResponseSummary{
+			Received:     received,
+			Failed:       received,
+			NonRetriable: received,
+		},
+		FailedEvents: []FailedEvent{},
+		Error:        reason,
+	}
+}
+
+// decodeImportFile parses a file part as a stream of JSON values.
+// Each value is either an event object or an array of events, which covers a JSON array,
+// a single event, and NDJSON (one event object per line).
+func decodeImportFile(data []byte) ([]LineageEvent, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	var events []LineageEvent
+
+	for valueIndex := 0; ; valueIndex++ {
+		var raw json.RawMessage
+
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON at value %d: %w", valueIndex, err)
+		}
+
+		trimmed := bytes.TrimSpace(raw)
+
+		switch {
+		case len(trimmed) > 0 && trimmed[0] == '[':
+			var batch []LineageEvent
+			if err := json.Unmarshal(trimmed, &batch); err != nil {
+				return nil, fmt.Errorf("invalid JSON at value %d: %w", valueIndex, err)
+			}
+
+			events = append(events, batch...)
+		case len(trimmed) > 0 && trimmed[0] == '{':
+			var event LineageEvent
+			if err := json.Unmarshal(trimmed, &event); err != nil {
+				return nil, fmt.Errorf("invalid JSON at value %d: %w", valueIndex, err)
+			}
+
+			events = append(events, event)
+		default:
+			return nil, fmt.Errorf("%w at value %d", errImportValueFormat, valueIndex)
+		}
+	}
+
+	if len(events) == 0 {
+		return nil, errImportFileEmpty
+	}
+
+	return events, nil
+}
+
+// importStatus classifies success/failure counts using the batch endpoint's status vocabulary.
+func importStatus(successful, failed int) string {
+	switch {
+	case failed == 0:
+		return "success"
+	case successful > 0:
+		return "partial_success"
+	default:
+		return "error"
+	}
+}
+
+// sendImportResponse sets the aggregate status, marshals, and writes the import response.
+// Returns the HTTP status code for logging purposes.
+//
+// Status code logic:
+//   - 200 OK: Nothing failed
+//   - 207 Multi-Status: Some events stored, some events or files failed
+//   - 422 Unprocessable Entity: Nothing stored
+func (s *Server) sendImportResponse(w http.ResponseWriter, r *http.Request, response *ImportResponse) int {
+	failed := response.Summary.Failed
+
+	for _, file := range response.Files {
+		if file.Error != "" && file.Summary.Received == 0 {
+			failed++ // File rejected before any event could be counted
+		}
+	}
+
+	response.Status = importStatus(response.Summary.Successful, failed)
+	response.Timestamp = time.Now().UTC().Format(time.RFC3339)
+
+	statusCode := http.StatusOK
+
+	switch response.Status {
+	case "partial_success":
+		statusCode = http.StatusMultiStatus
+	case "error":
+		statusCode = http.StatusUnprocessableEntity
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("Failed to marshal import response",
+			slog.String("correlation_id", response.CorrelationID),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if _, err := w.Write(data); err != nil {
+		s.logger.Error("Failed to write import response",
+			slog.String("correlation_id", response.CorrelationID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return statusCode
+}
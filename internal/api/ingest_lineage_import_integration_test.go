@@ -0,0 +1,253 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importFilePart is a file part for a multipart import request.
+type importFilePart struct {
+	filename string
+	content  []byte
+}
+
+// postLineageImport is a helper to POST file parts to the multipart import endpoint.
+// formFields are written as non-file parts before the files.
+func (ts *testServer) postLineageImport(
+	t *testing.T,
+	files []importFilePart,
+	formFields map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	for name, value := range formFields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+
+	for _, file := range files {
+		part, err := writer.CreateFormFile("files", file.filename)
+		require.NoError(t, err)
+
+		_, err = part.Write(file.content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+	rr := httptest.NewRecorder()
+	ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// parseImportResponse asserts the status code and decodes the ImportResponse body.
+func parseImportResponse(t *testing.T, rr *httptest.ResponseRecorder, expectedStatus int) *ImportResponse {
+	t.Helper()
+
+	require.Equal(t, expectedStatus, rr.Code, "Response body: %s", rr.Body.String())
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var response ImportResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response), "Failed to parse response JSON")
+	assert.NotEmpty(t, response.CorrelationID, "Missing correlation_id")
+	assert.NotEmpty(t, response.Timestamp, "Missing timestamp")
+
+	return &response
+}
+
+// toNDJSON encodes events one per line.
+func toNDJSON(t *testing.T, events ...LineageEvent) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		require.NoError(t, encoder.Encode(event))
+	}
+
+	return buf.Bytes()
+}
+
+// TestLineageImport_JSONAndNDJSONFiles tests importing a JSON array file and an NDJSON file.
+// Expected: 200 OK with per-file summaries and all events stored.
+func TestLineageImport_JSONAndNDJSONFiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	now := time.Now()
+	event1 := createValidLineageEvent("import-run-1", "START", now)
+	event2 := createValidLineageEvent("import-run-2", "START", now)
+	event3 := createValidLineageEvent("import-run-3", "START", now)
+
+	jsonFile, err := json.Marshal([]LineageEvent{event1, event2})
+	require.NoError(t, err)
+
+	rr := ts.postLineageImport(t, []importFilePart{
+		{filename: "january.json", content: jsonFile},
+		{filename: "february.ndjson", content: toNDJSON(t, event3)},
+	}, map[string]string{"source": "backfill"})
+
+	response := parseImportResponse(t, rr, http.StatusOK)
+
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, 3, response.Summary.Received)
+	assert.Equal(t, 3, response.Summary.Successful)
+	require.Len(t, response.Files, 2, "Non-file form fields must not produce file results")
+
+	assert.Equal(t, "january.json", response.Files[0].Filename)
+	assert.Equal(t, 2, response.Files[0].Summary.Successful)
+	assert.Equal(t, "february.ndjson", response.Files[1].Filename)
+	assert.Equal(t, 1, response.Files[1].Summary.Successful)
+
+	ts.verifyEventStored(ctx, t, event1.Run.ID, "START")
+	ts.verifyEventStored(ctx, t, event2.Run.ID, "START")
+	ts.verifyEventStored(ctx, t, event3.Run.ID, "START")
+}
+
+// TestLineageImport_PartialFailure tests that a malformed file does not block other files.
+// Expected: 207 Multi-Status with a file-level error for the malformed file.
+func TestLineageImport_PartialFailure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	valid := createValidLineageEvent("import-partial", "START", time.Now())
+	invalid := createValidLineageEvent("import-invalid", "START", time.Now())
+	invalid.Job.Name = "" // Fails domain validation
+
+	rr := ts.postLineageImport(t, []importFilePart{
+		{filename: "good.ndjson", content: toNDJSON(t, valid, invalid)},
+		{filename: "broken.json", content: []byte(`[{"eventType": `)},
+	}, nil)
+
+	response := parseImportResponse(t, rr, http.StatusMultiStatus)
+
+	assert.Equal(t, "partial_success", response.Status)
+	require.Len(t, response.Files, 2)
+
+	good := response.Files[0]
+	assert.Equal(t, "partial_success", good.Status)
+	assert.Equal(t, 1, good.Summary.Successful)
+	assert.Equal(t, 1, good.Summary.Failed)
+	require.Len(t, good.FailedEvents, 1)
+	assert.Equal(t, 1, good.FailedEvents[0].Index, "Index must be relative to the file")
+
+	broken := response.Files[1]
+	assert.Equal(t, "error", broken.Status)
+	assert.Contains(t, broken.Error, "invalid JSON")
+
+	ts.verifyEventStored(ctx, t, valid.Run.ID, "START")
+	ts.assertEventNotStored(ctx, t, invalid.Run.ID)
+}
+
+// TestLineageImport_PartTooLarge tests the per-part size limit.
+// Expected: the oversized file is rejected in its result; other files are still imported.
+func TestLineageImport_PartTooLarge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	event := createValidLineageEvent("import-small", "START", time.Now())
+	small := toNDJSON(t, event)
+
+	ts.server.config.MaxImportPartSize = int64(len(small))
+
+	rr := ts.postLineageImport(t, []importFilePart{
+		{filename: "small.ndjson", content: small},
+		{filename: "large.ndjson", content: bytes.Repeat([]byte(" "), len(small)+1)},
+	}, nil)
+
+	response := parseImportResponse(t, rr, http.StatusMultiStatus)
+
+	require.Len(t, response.Files, 2)
+	assert.Equal(t, "success", response.Files[0].Status)
+	assert.Equal(t, "error", response.Files[1].Status)
+	assert.Contains(t, response.Files[1].Error, "exceeds maximum size")
+
+	ts.verifyEventStored(ctx, t, event.Run.ID, "START")
+}
+
+// TestLineageImport_TotalTooLarge tests the total request size limit.
+// Expected: 413 Payload Too Large and nothing stored.
+func TestLineageImport_TotalTooLarge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	event := createValidLineageEvent("import-total", "START", time.Now())
+	ts.server.config.MaxImportSize = 256
+
+	rr := ts.postLineageImport(t, []importFilePart{
+		{filename: "events.ndjson", content: toNDJSON(t, event, event, event)},
+	}, nil)
+
+	validateRFC7807Response(t, rr, http.StatusRequestEntityTooLarge)
+	ts.assertEventNotStored(ctx, t, event.Run.ID)
+}
+
+// TestLineageImport_RequestErrors tests request-level validation.
+func TestLineageImport_RequestErrors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	t.Run("wrong content type", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage/import", bytes.NewReader([]byte("[]")))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		validateRFC7807Response(t, rr, http.StatusUnsupportedMediaType)
+	})
+
+	t.Run("no file parts", func(t *testing.T) {
+		rr := ts.postLineageImport(t, nil, map[string]string{"source": "backfill"})
+
+		validateRFC7807Response(t, rr, http.StatusBadRequest)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		rr := ts.postLineageImport(t, []importFilePart{{filename: "empty.json", content: []byte("\n")}}, nil)
+
+		response := parseImportResponse(t, rr, http.StatusUnprocessableEntity)
+		require.Len(t, response.Files, 1)
+		assert.Equal(t, "file contains no events", response.Files[0].Error)
+	})
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/ingestion"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// IngestionQuotaStore is the interface the API layer uses to count ingested events against
+// per-plugin quotas. Defined here (consumer in api package) following the Dependency Inversion
+// Principle.
+//
+// Implemented by: storage.LineageStore.
+type IngestionQuotaStore interface {
+	ConsumeIngestionQuota(ctx context.Context, plugin string, quota storage.EventQuota, events int) error
+	ReleaseIngestionQuota(ctx context.Context, plugin string, quota storage.EventQuota, at time.Time, events int) error
+}
+
+// quotaReservation is the quota consumeIngestionQuota counted for a request before storing its
+// events; releaseIngestionQuota gives back the events that were not stored.
+type quotaReservation struct {
+	plugin string
+	quota  storage.EventQuota
+	at     time.Time
+}
+
+// consumeIngestionQuota counts the events about to be stored for plugin against its daily and
+// monthly quota (see storage.PluginQuota), read from the plugin's API keys. Returns a 429
+// quota-exceeded problem when the events would exceed either quota; nothing of the request may be
+// stored then. Otherwise returns the reservation to release the events that end up not stored
+// (nil when nothing was counted), so only stored events use up the quota.
+//
+// No-op when quotas are disabled, authentication is off, or for the anonymous plugin. A failure
+// to read keys or update counters is logged and the events are admitted: quotas protect storage
+// capacity and must not turn a database hiccup into lost lineage.
+func (s *Server) consumeIngestionQuota(
+	ctx context.Context,
+	plugin string,
+	events int,
+) (*quotaReservation, *ProblemDetail) {
+	if s.quotaStore == nil || s.apiKeyStore == nil || plugin == ingestion.AnonymousPlugin || events == 0 {
+		return nil, nil
+	}
+
+	logger := s.logger.With(
+		slog.String("correlation_id", middleware.GetCorrelationID(ctx)),
+		slog.String("plugin", plugin),
+	)
+
+	keys, err := s.apiKeyStore.ListByClientID(ctx, plugin)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to read plugin keys, ingestion quota not enforced",
+			slog.String("error", err.Error()))
+
+		return nil, nil
+	}
+
+	reservation := &quotaReservation{plugin: plugin, at: time.Now()}
+	reservation.quota = storage.PluginQuota(keys, reservation.at)
+
+	err = s.quotaStore.ConsumeIngestionQuota(ctx, plugin, reservation.quota, events)
+	if errors.Is(err, storage.ErrQuotaExceeded) {
+		logger.WarnContext(ctx, "Ingestion quota exceeded", slog.String("error", err.Error()))
+
+		return nil, QuotaExceeded(err.Error())
+	}
+
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to update ingestion quota usage, quota not enforced",
+			slog.String("error", err.Error()))
+
+		return nil, nil
+	}
+
+	return reservation, nil
+}
+
+// releaseIngestionQuota gives back events of reservation that were not stored (duplicates and
+// failures). No-op for a nil reservation. A failure is logged: the events stay counted.
+func (s *Server) releaseIngestionQuota(ctx context.Context, reservation *quotaReservation, events int) {
+	if reservation == nil || events == 0 {
+		return
+	}
+
+	err := s.quotaStore.ReleaseIngestionQuota(ctx, reservation.plugin, reservation.quota, reservation.at, events)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to release ingestion quota of events not stored",
+			slog.String("correlation_id", middleware.GetCorrelationID(ctx)),
+			slog.String("plugin", reservation.plugin),
+			slog.Int("events", events),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// unstoredEvents returns the number of results whose event was not stored.
+func unstoredEvents(results []*ingestion.EventStoreResult) int {
+	n := 0
+
+	for _, result := range results {
+		if result != nil && !result.Stored {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// TestIngestionQuota verifies that a plugin over its ingestion quota is rejected with a
+// quota-exceeded problem while other plugins keep ingesting, and that only stored events count.
+func TestIngestionQuota(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	addKey := func(t *testing.T, plugin string, quota storage.EventQuota) string {
+		t.Helper()
+
+		return addTestAPIKey(ctx, t, ts.keyStore, testAPIKey{
+			id:          plugin + "-key-id",
+			clientID:    plugin,
+			permissions: []string{"lineage:write"},
+			quota:       quota,
+		})
+	}
+
+	dbtKey := addKey(t, "dbt", storage.EventQuota{Daily: 3})
+	airflowKey := addKey(t, "airflow", storage.EventQuota{})
+
+	// One event time for all events: posting a run ID again is a duplicate
+	eventTime := time.Now().UTC()
+
+	post := func(t *testing.T, key string, runIDs ...string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		events := make([]LineageEvent, 0, len(runIDs))
+		for _, runID := range runIDs {
+			events = append(events, createValidLineageEvent(runID, "START", eventTime))
+		}
+
+		body, err := json.Marshal(events)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage/batch", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+key)
+
+		rr := httptest.NewRecorder()
+		ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	rr := post(t, dbtKey, "dbt-run-1", "dbt-run-2")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	t.Run("plugin over quota is rejected", func(t *testing.T) {
+		rr := post(t, dbtKey, "dbt-run-3", "dbt-run-4")
+		require.Equal(t, http.StatusTooManyRequests, rr.Code, rr.Body.String())
+
+		var problem ProblemDetail
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+		assert.Equal(t, "https://getcorrelator.io/problems/quota-exceeded", problem.Type)
+		assert.Contains(t, problem.Detail, "day quota of 3 events")
+
+		var stored int
+		require.NoError(t, ts.db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM job_runs WHERE ingested_by_plugin = 'dbt'`).Scan(&stored))
+		assert.Equal(t, 2, stored, "nothing of a rejected request is stored")
+	})
+
+	t.Run("duplicates do not use up the quota", func(t *testing.T) {
+		// A retry of a stored event is admitted, then given back: the last event of the quota remains
+		rr := post(t, dbtKey, "dbt-run-1")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("remaining quota is still usable", func(t *testing.T) {
+		rr := post(t, dbtKey, "dbt-run-3")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = post(t, dbtKey, "dbt-run-4")
+		require.Equal(t, http.StatusTooManyRequests, rr.Code, rr.Body.String())
+	})
+
+	t.Run("other plugins proceed", func(t *testing.T) {
+		runIDs := make([]string, 10)
+		for i := range runIDs {
+			runIDs[i] = fmt.Sprintf("airflow-run-%d", i)
+		}
+
+		rr := post(t, airflowKey, runIDs...)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = post(t, ts.apiKey, "test-client-run")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// InvalidJSON creates a 400 Bad Request problem for a JSON decode error of body that tells the
+// client where the problem is: the detail names the line and column, and the offset, line,
+// column, and (for type mismatches) field extension members carry the same for tooling.
+//
+// Syntax errors (*json.SyntaxError) and truncated bodies are located at the offending byte;
+// type mismatches (*json.UnmarshalTypeError) at the end of the mistyped value. Other decode
+// errors fall back to "Invalid JSON: <error>".
+func InvalidJSON(err error, body []byte) *ProblemDetail {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		offset    int64
+		reason    string
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset, reason = syntaxErr.Offset, syntaxErr.Error()
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		offset = typeErr.Offset
+		reason = fmt.Sprintf("field %s expected %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+		reason = fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		offset, reason = int64(len(body)), "unexpected end of JSON input"
+	default:
+		return BadRequest("Invalid JSON: " + err.Error())
+	}
+
+	line, column := jsonPosition(body, offset)
+
+	problem := BadRequest(fmt.Sprintf("Invalid JSON at line %d, column %d: %s", line, column, reason))
+	problem.Offset = offset
+	problem.Line = line
+	problem.Column = column
+
+	if typeErr != nil {
+		problem.Field = typeErr.Field
+	}
+
+	return problem
+}
+
+// jsonPosition returns the 1-based line and column of the last byte read before a decode error
+// at offset (the offending byte for syntax errors).
+func jsonPosition(body []byte, offset int64) (int, int) {
+	pos := int(min(max(offset-1, 0), int64(len(body))))
+	prefix := body[:pos]
+
+	return bytes.Count(prefix, []byte{'\n'}) + 1, pos - bytes.LastIndexByte(prefix, '\n')
+}
@@ -0,0 +1,436 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/ingestion"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const (
+	// preferRespondAsync is the RFC 7240 preference that opts a batch into async processing.
+	preferRespondAsync = "respond-async"
+
+	// lineageBatchChunkSize is the number of events stored per transaction batch (and per
+	// progress update) while processing an async batch.
+	lineageBatchChunkSize = 500
+	// lineageBatchPollInterval is how often the worker looks for batches submitted to other
+	// instances or left behind by a dead worker.
+	lineageBatchPollInterval = 5 * time.Second
+	// lineageBatchStaleAfter is how long a processing batch may go without a progress update
+	// before another worker reclaims it.
+	lineageBatchStaleAfter = 10 * time.Minute
+	// lineageBatchReleaseTimeout bounds handing an interrupted batch back on shutdown.
+	lineageBatchReleaseTimeout = 5 * time.Second
+)
+
+// errLineageBatchRejected is recorded on a batch that failed as a whole (e.g., an invalid event
+// sequence or a storage failure); per-event failures are reported in the batch result instead.
+var errLineageBatchRejected = errors.New("batch rejected")
+
+// LineageBatchStore queues async lineage batches and tracks their progress.
+// Defined here (consumer in api package) following the Dependency Inversion Principle.
+//
+// Implemented by: storage.LineageStore.
+type LineageBatchStore interface {
+	CreateLineageBatch(
+		ctx context.Context, clientID, correlationID string, events json.RawMessage, totalEvents int, backfill bool,
+	) (string, error)
+	ClaimLineageBatch(ctx context.Context, staleAfter time.Duration) (*storage.ClaimedLineageBatch, error)
+	UpdateLineageBatchProgress(ctx context.Context, batchID string, processed, processing int) error
+	CompleteLineageBatch(ctx context.Context, batchID string, result json.RawMessage) error
+	FailLineageBatch(ctx context.Context, batchID, message string) error
+	ReleaseLineageBatch(ctx context.Context, batchID string) error
+	GetLineageBatch(ctx context.Context, batchID string) (*storage.LineageBatch, error)
+}
+
+// prefersRespondAsync reports whether the request carries "Prefer: respond-async" (RFC 7240).
+// Preferences are comma-separated and may appear in several Prefer headers.
+func prefersRespondAsync(header http.Header) bool {
+	for _, value := range header.Values("Prefer") {
+		for _, preference := range strings.Split(value, ",") {
+			name, _, _ := strings.Cut(preference, ";")
+			name, _, _ = strings.Cut(name, "=")
+
+			if strings.EqualFold(strings.TrimSpace(name), preferRespondAsync) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// handleAsyncLineageBatch queues a POST /api/v1/lineage/batch body sent with
+// "Prefer: respond-async" and responds 202 Accepted with the batch ID.
+//
+// Only the request shape is checked up front (JSON array of events, not empty); event
+// validation and storage happen in the background with the same rules as the synchronous
+// path. The body limit is MaxImportSize, since async batches are meant for backfills.
+func (s *Server) handleAsyncLineageBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	correlationID := middleware.GetCorrelationID(ctx)
+
+	if r.ContentLength > 0 && r.ContentLength > s.config.MaxImportSize {
+		WriteErrorResponse(w, r, s.logger, PayloadTooLarge(
+			fmt.Sprintf("Request body exceeds maximum async batch size of %d bytes", s.config.MaxImportSize),
+		))
+
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.config.MaxImportSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			WriteErrorResponse(w, r, s.logger, PayloadTooLarge(
+				fmt.Sprintf("Request body exceeds maximum async batch size of %d bytes", s.config.MaxImportSize),
+			))
+
+			return
+		}
+
+		WriteErrorResponse(w, r, s.logger, bodyReadProblem(err))
+
+		return
+	}
+
+	if len(body) == 0 {
+		WriteErrorResponse(w, r, s.logger, BadRequest("Request body cannot be empty"))
+
+		return
+	}
+
+	var events []LineageEvent
+
+	if err := json.Unmarshal(body, &events); err != nil {
+		WriteErrorResponse(w, r, s.logger, InvalidJSON(err, body))
+
+		return
+	}
+
+	if len(events) == 0 {
+		WriteErrorResponse(w, r, s.logger, BadRequest("Event array cannot be empty"))
+
+		return
+	}
+
+	clientCtx, _ := middleware.GetClientContext(ctx)
+
+	batchID, err := s.lineageBatchStore.CreateLineageBatch(
+		ctx, clientCtx.ClientID, correlationID, body, len(events), isBackfillRequest(r),
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to queue lineage batch",
+			slog.String("correlation_id", correlationID),
+			slog.Int("event_count", len(events)),
+			slog.String("error", err.Error()),
+		)
+
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to queue batch"))
+
+		return
+	}
+
+	s.wakeLineageBatchWorker()
+
+	middleware.RecordTrace(ctx, middleware.TraceStageStore,
+		fmt.Sprintf("queued batch %s (%d events)", batchID, len(events)))
+
+	statusURL := "/api/v1/lineage/batches/" + batchID
+
+	data, err := json.Marshal(LineageBatchAcceptedResponse{
+		BatchID:       batchID,
+		Status:        storage.LineageBatchPending,
+		TotalEvents:   len(events),
+		StatusURL:     statusURL,
+		CorrelationID: correlationID,
+	})
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", statusURL)
+	w.Header().Set("Preference-Applied", preferRespondAsync)
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write(data)
+}
+
+// handleGetLineageBatch handles GET /api/v1/lineage/batches/{batch_id}.
+// Returns the progress of an async batch and, once done, its batch response.
+//
+// A batch is only visible to the API key client that submitted it; other clients get 404.
+// Completed batches are kept for 7 days.
+func (s *Server) handleGetLineageBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	batchID := r.PathValue("batch_id")
+
+	batch, err := s.lineageBatchStore.GetLineageBatch(ctx, batchID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get lineage batch",
+			slog.String("correlation_id", middleware.GetCorrelationID(ctx)),
+			slog.String("batch_id", batchID),
+			slog.String("error", err.Error()),
+		)
+
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to get batch"))
+
+		return
+	}
+
+	clientCtx, _ := middleware.GetClientContext(ctx)
+	if batch == nil || batch.ClientID != clientCtx.ClientID {
+		WriteErrorResponse(w, r, s.logger, NotFound("Batch not found"))
+
+		return
+	}
+
+	data, err := json.Marshal(mapLineageBatch(batch))
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// mapLineageBatch converts a storage batch to the API status response.
+func mapLineageBatch(batch *storage.LineageBatch) LineageBatchStatusResponse {
+	pending := batch.TotalEvents - batch.ProcessedEvents - batch.ProcessingEvents
+	if batch.Status == storage.LineageBatchFailed {
+		pending = 0
+	}
+
+	return LineageBatchStatusResponse{
+		BatchID: batch.ID,
+		Status:  batch.Status,
+		Progress: LineageBatchProgress{
+			Total:      batch.TotalEvents,
+			Pending:    max(pending, 0),
+			Processing: batch.ProcessingEvents,
+			Done:       batch.ProcessedEvents,
+		},
+		Result:        batch.Result,
+		Error:         batch.Error,
+		CorrelationID: batch.CorrelationID,
+		CreatedAt:     batch.CreatedAt,
+		StartedAt:     batch.StartedAt,
+		CompletedAt:   batch.CompletedAt,
+	}
+}
+
+// startLineageBatchWorker starts the background goroutine that processes queued batches.
+// No-op when async batches are disabled.
+func (s *Server) startLineageBatchWorker() {
+	if s.lineageBatchStore == nil {
+		return
+	}
+
+	s.workers.Register(workerLineageBatches)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.batchWorkerCancel = cancel
+
+	s.batchWorkerWg.Add(1)
+
+	go s.runLineageBatchWorker(ctx)
+}
+
+// stopLineageBatchWorker cancels the batch worker and waits for it to hand back an interrupted
+// batch, or for ctx to end.
+func (s *Server) stopLineageBatchWorker(ctx context.Context) {
+	if s.batchWorkerCancel == nil {
+		return
+	}
+
+	s.batchWorkerCancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.batchWorkerWg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.Warn("Lineage batch worker did not stop before shutdown deadline")
+	}
+}
+
+// wakeLineageBatchWorker nudges the worker to claim a newly queued batch without waiting for
+// the next poll. Never blocks.
+func (s *Server) wakeLineageBatchWorker() {
+	select {
+	case s.batchWorkerWake <- struct{}{}:
+	default:
+	}
+}
+
+// runLineageBatchWorker processes queued batches one at a time until ctx is cancelled.
+func (s *Server) runLineageBatchWorker(ctx context.Context) {
+	defer s.batchWorkerWg.Done()
+
+	ticker := time.NewTicker(lineageBatchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the queue before waiting again
+		for ctx.Err() == nil {
+			if !s.processNextLineageBatch(ctx) {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.batchWorkerWake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNextLineageBatch claims and processes one batch.
+// Returns false when there was nothing to claim (or the claim failed).
+func (s *Server) processNextLineageBatch(ctx context.Context) bool {
+	batch, err := s.lineageBatchStore.ClaimLineageBatch(ctx, lineageBatchStaleAfter)
+	if ctx.Err() == nil {
+		s.workers.Record(workerLineageBatches, err)
+	}
+
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("Failed to claim lineage batch", slog.String("error", err.Error()))
+		}
+
+		return false
+	}
+
+	if batch == nil {
+		return false
+	}
+
+	startTime := time.Now()
+	logger := s.logger.With(
+		slog.String("batch_id", batch.ID),
+		slog.String("correlation_id", batch.CorrelationID),
+	)
+
+	response, err := s.processLineageBatch(ctx, batch)
+
+	switch {
+	case ctx.Err() != nil:
+		// Shutdown: hand the batch back so another instance (or the next start) picks it up
+		releaseCtx, cancel := context.WithTimeout(context.Background(), lineageBatchReleaseTimeout)
+		defer cancel()
+
+		if err := s.lineageBatchStore.ReleaseLineageBatch(releaseCtx, batch.ID); err != nil { //nolint:contextcheck
+			logger.Warn("Failed to release interrupted lineage batch", slog.String("error", err.Error()))
+		}
+
+		logger.Info("Lineage batch interrupted by shutdown, released for reprocessing")
+	case err != nil:
+		if err := s.lineageBatchStore.FailLineageBatch(ctx, batch.ID, err.Error()); err != nil {
+			logger.Error("Failed to mark lineage batch failed", slog.String("error", err.Error()))
+		}
+
+		logger.Warn("Lineage batch failed", slog.String("error", err.Error()))
+	default:
+		if err := s.completeLineageBatch(ctx, batch.ID, response); err != nil {
+			logger.Error("Failed to complete lineage batch", slog.String("error", err.Error()))
+
+			return true
+		}
+
+		logger.Info("Lineage batch processed",
+			slog.String("status", response.Status),
+			slog.Int("received", response.Summary.Received),
+			slog.Int("successful", response.Summary.Successful),
+			slog.Int("failed", response.Summary.Failed),
+			slog.Duration("duration", time.Since(startTime)),
+		)
+	}
+
+	return true
+}
+
+// processLineageBatch validates and stores a claimed batch in chunks of lineageBatchChunkSize,
+// recording progress after each chunk, and builds the same response as the synchronous path.
+// Returns an error when the batch as a whole cannot be processed.
+func (s *Server) processLineageBatch(
+	ctx context.Context,
+	batch *storage.ClaimedLineageBatch,
+) (*LineageResponse, error) {
+	var requests []LineageEvent
+
+	if err := json.Unmarshal(batch.Events, &requests); err != nil {
+		return nil, fmt.Errorf("%w: invalid JSON: %w", errLineageBatchRejected, err)
+	}
+
+	runEvents := make([]*ingestion.RunEvent, len(requests))
+	for i := range requests {
+		runEvents[i] = mapLineageRequest(&requests[i])
+	}
+
+	events, validationErrors, problem := s.validateEvents(
+		s.eventValidator(batch.Backfill), normalizeInputsAndOutputs(runEvents),
+	)
+	if problem != nil {
+		return nil, fmt.Errorf("%w: %s", errLineageBatchRejected, problem.Detail)
+	}
+
+	// The batch outlives the submitting request, so its plugin comes from the stored client ID
+	plugin := ingestion.AnonymousPlugin
+	if batch.ClientID != "" {
+		plugin = batch.ClientID
+	}
+
+	storeResults := make([]*ingestion.EventStoreResult, len(events))
+
+	for start := 0; start < len(events); start += lineageBatchChunkSize {
+		end := min(start+lineageBatchChunkSize, len(events))
+
+		if err := s.lineageBatchStore.UpdateLineageBatchProgress(ctx, batch.ID, start, end-start); err != nil {
+			return nil, fmt.Errorf("failed to record progress: %w", err)
+		}
+
+		results, problem := s.storeValidEvents(ctx, plugin, events[start:end], validationErrors[start:end])
+		if problem != nil {
+			return nil, fmt.Errorf("%w: %s", errLineageBatchRejected, problem.Detail)
+		}
+
+		copy(storeResults[start:], results)
+	}
+
+	response := s.buildLineageResponse(batch.CorrelationID, events, validationErrors, storeResults)
+	determineStatusCode(response) // Sets "partial_success" like the synchronous response
+
+	return response, nil
+}
+
+// completeLineageBatch stores the batch response and marks the batch done.
+func (s *Server) completeLineageBatch(ctx context.Context, batchID string, response *LineageResponse) error {
+	result, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to encode batch response: %w", err)
+	}
+
+	return s.lineageBatchStore.CompleteLineageBatch(ctx, batchID, result)
+}
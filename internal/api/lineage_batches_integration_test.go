@@ -0,0 +1,184 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// postAsyncLineageEvents POSTs events to the batch endpoint with "Prefer: respond-async".
+func (ts *testServer) postAsyncLineageEvents(t *testing.T, events []LineageEvent) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(events)
+	require.NoError(t, err, "Failed to marshal lineage events")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage/batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+	req.Header.Set("Prefer", "respond-async")
+
+	rr := httptest.NewRecorder()
+	ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// getLineageBatch GETs the status of an async batch.
+func (ts *testServer) getLineageBatch(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+	rr := httptest.NewRecorder()
+	ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestLineageBatch_AsyncProcessing_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	now := time.Now()
+	events := []LineageEvent{
+		createValidLineageEvent("async-run-1", "START", now),
+		createValidLineageEvent("async-run-1", "COMPLETE", now.Add(time.Second)),
+		createValidLineageEvent("async-run-2", "START", now),
+	}
+
+	rr := ts.postAsyncLineageEvents(t, events)
+	require.Equal(t, http.StatusAccepted, rr.Code, "Body: %s", rr.Body.String())
+	assert.Equal(t, "respond-async", rr.Header().Get("Preference-Applied"))
+
+	var accepted LineageBatchAcceptedResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &accepted))
+	assert.Equal(t, storage.LineageBatchPending, accepted.Status)
+	assert.Equal(t, 3, accepted.TotalEvents)
+	assert.Equal(t, "/api/v1/lineage/batches/"+accepted.BatchID, accepted.StatusURL)
+	assert.Equal(t, accepted.StatusURL, rr.Header().Get("Location"))
+
+	// Nothing is stored until the worker picks the batch up
+	rr = ts.getLineageBatch(t, accepted.StatusURL)
+	require.Equal(t, http.StatusOK, rr.Code, "Body: %s", rr.Body.String())
+
+	var status LineageBatchStatusResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.Equal(t, storage.LineageBatchPending, status.Status)
+	assert.Equal(t, LineageBatchProgress{Total: 3, Pending: 3}, status.Progress)
+	assert.Empty(t, status.Result)
+	assert.Nil(t, status.StartedAt)
+
+	require.True(t, ts.server.processNextLineageBatch(ctx), "Worker should claim the queued batch")
+	assert.False(t, ts.server.processNextLineageBatch(ctx), "Queue should be empty")
+
+	rr = ts.getLineageBatch(t, accepted.StatusURL)
+	require.Equal(t, http.StatusOK, rr.Code, "Body: %s", rr.Body.String())
+
+	status = LineageBatchStatusResponse{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.Equal(t, storage.LineageBatchDone, status.Status)
+	assert.Equal(t, LineageBatchProgress{Total: 3, Done: 3}, status.Progress)
+	assert.NotNil(t, status.StartedAt)
+	assert.NotNil(t, status.CompletedAt)
+
+	var result LineageResponse
+	require.NoError(t, json.Unmarshal(status.Result, &result))
+	assert.Equal(t, "success", result.Status)
+	assert.Equal(t, 3, result.Summary.Received)
+	assert.Equal(t, 3, result.Summary.Successful)
+
+	assert.Equal(t, 1, ts.countStoredEvents(ctx, t, events[0].Run.ID), "Worker should store the first run")
+	assert.Equal(t, 1, ts.countStoredEvents(ctx, t, events[2].Run.ID), "Worker should store the second run")
+}
+
+func TestLineageBatch_NotFound_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	t.Run("unknown batch", func(t *testing.T) {
+		rr := ts.getLineageBatch(t, "/api/v1/lineage/batches/6f1f6f5e-0000-4000-8000-000000000000")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("malformed batch id", func(t *testing.T) {
+		rr := ts.getLineageBatch(t, "/api/v1/lineage/batches/not-a-uuid")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("batch of another client", func(t *testing.T) {
+		batchID, err := ts.lineageStore.CreateLineageBatch(ctx, "other-client", "corr-1", json.RawMessage(`[{}]`), 1, false)
+		require.NoError(t, err)
+
+		rr := ts.getLineageBatch(t, "/api/v1/lineage/batches/"+batchID)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestLineageBatch_Validation_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	t.Run("empty array", func(t *testing.T) {
+		rr := ts.postAsyncLineageEvents(t, []LineageEvent{})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("without preference stays synchronous", func(t *testing.T) {
+		rr := ts.postLineageEvents(t, []LineageEvent{createValidLineageEvent("sync-run", "START", time.Now())})
+		assert.Equal(t, http.StatusOK, rr.Code, "Body: %s", rr.Body.String())
+	})
+}
+
+func TestPrefersRespondAsync(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		name   string
+		values []string
+		want   bool
+	}{
+		{name: "absent", values: nil, want: false},
+		{name: "respond-async", values: []string{"respond-async"}, want: true},
+		{name: "case insensitive", values: []string{"Respond-Async"}, want: true},
+		{name: "among preferences", values: []string{"return=minimal, respond-async, wait=10"}, want: true},
+		{name: "in second header", values: []string{"return=minimal", "respond-async"}, want: true},
+		{name: "with parameter", values: []string{"respond-async; foo=bar"}, want: true},
+		{name: "other preference", values: []string{"return=representation"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for _, v := range tt.values {
+				header.Add("Prefer", v)
+			}
+
+			assert.Equal(t, tt.want, prefersRespondAsync(header))
+		})
+	}
+}
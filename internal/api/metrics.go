@@ -0,0 +1,90 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// contentTypePrometheusText is the media type of the Prometheus text exposition format.
+const contentTypePrometheusText = "text/plain; version=0.0.4; charset=utf-8"
+
+// OrphanReconciliationReporter is the interface the API layer uses to export orphaned test result
+// reconciliation counts. Defined here (consumer in api package) following the Dependency
+// Inversion Principle.
+//
+// Implemented by: storage.OrphanReconciler.
+type OrphanReconciliationReporter interface {
+	Stats() storage.OrphanReconciliationStats
+}
+
+// handleMetrics handles GET /metrics in the Prometheus text exposition format.
+//
+// Gauges (one series per table: job_runs, datasets, lineage_edges):
+//   - correlator_table_dead_tuple_ratio: dead / (live + dead) tuples
+//   - correlator_table_dead_tuples: dead tuples awaiting vacuum
+//
+// With orphan reconciliation enabled (see storage.OrphanReconciler):
+//   - correlator_orphaned_test_results_matched_total (counter): orphans linked to a late job run
+//   - correlator_orphaned_test_results (gauge): orphans still unmatched after the last pass
+//
+// Values are read from pg_stat_user_tables on every scrape (no caching). Public like the
+// health probes so scrapers need no API key. Returns 503 if the statistics cannot be read.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	stats, err := s.maintenanceChecker.MaintenanceStats(ctx)
+	if err != nil {
+		s.logger.Error("Failed to read table statistics for metrics",
+			slog.String("correlation_id", middleware.GetCorrelationID(r.Context())),
+			slog.String("error", err.Error()),
+		)
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("table statistics unavailable"))
+
+		return
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString("# HELP correlator_table_dead_tuple_ratio Dead tuples / (live + dead tuples) " +
+		"from pg_stat_user_tables.\n")
+	buf.WriteString("# TYPE correlator_table_dead_tuple_ratio gauge\n")
+
+	for _, m := range stats {
+		fmt.Fprintf(&buf, "correlator_table_dead_tuple_ratio{table=%q} %g\n", m.Table, m.DeadTupleRatio())
+	}
+
+	buf.WriteString("# HELP correlator_table_dead_tuples Dead tuples awaiting vacuum from pg_stat_user_tables.\n")
+	buf.WriteString("# TYPE correlator_table_dead_tuples gauge\n")
+
+	for _, m := range stats {
+		fmt.Fprintf(&buf, "correlator_table_dead_tuples{table=%q} %d\n", m.Table, m.DeadTuples)
+	}
+
+	if s.orphanReconciliation != nil {
+		orphans := s.orphanReconciliation.Stats()
+
+		buf.WriteString("# HELP correlator_orphaned_test_results_matched_total Orphaned test results " +
+			"reassociated with a job run ingested after them.\n")
+		buf.WriteString("# TYPE correlator_orphaned_test_results_matched_total counter\n")
+		fmt.Fprintf(&buf, "correlator_orphaned_test_results_matched_total %d\n", orphans.Matched)
+
+		buf.WriteString("# HELP correlator_orphaned_test_results Orphaned test results left unmatched " +
+			"by the last reconciliation pass.\n")
+		buf.WriteString("# TYPE correlator_orphaned_test_results gauge\n")
+		fmt.Fprintf(&buf, "correlator_orphaned_test_results %d\n", orphans.StillOrphaned)
+	}
+
+	w.Header().Set("Content-Type", contentTypePrometheusText)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
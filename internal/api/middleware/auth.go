@@ -236,8 +236,9 @@ func authenticateRequest(
 func Authenticate(store storage.APIKeyStore, logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Check if this path bypasses authentication (public endpoints)
-			if publicEndpoints[r.URL.Path] {
+			// Check if this path bypasses authentication (public endpoints), or a trusted proxy
+			// already authenticated the request (see PreAuthenticate)
+			if publicEndpoints[r.URL.Path] || isPreAuthenticated(r.Context()) {
 				next.ServeHTTP(w, r)
 
 				return
@@ -266,14 +267,17 @@ func Authenticate(store storage.APIKeyStore, logger *slog.Logger) func(http.Hand
 
 			// Enrich context with client information
 			clientCtx := ClientContext{
-				ClientID:    authenticated.ClientID,
-				Name:        authenticated.Name,
-				Permissions: authenticated.Permissions,
-				KeyID:       authenticated.ID,
-				AuthTime:    time.Now(),
+				ClientID:     authenticated.ClientID,
+				Name:         authenticated.Name,
+				Permissions:  authenticated.Permissions,
+				KeyID:        authenticated.ID,
+				KeyExpiresAt: authenticated.ExpiresAt,
+				AuthTime:     time.Now(),
 			}
 			ctx := SetClientContext(r.Context(), clientCtx)
 
+			RecordTrace(ctx, TraceStageAuth, "authenticated client_id="+clientCtx.ClientID)
+
 			// Log successful authentication
 			logger.Info("API key authenticated",
 				slog.String("client_id", clientCtx.ClientID),
@@ -317,12 +321,15 @@ func writeAuthError(w http.ResponseWriter, r *http.Request, logger *slog.Logger,
 		statusCode = http.StatusUnauthorized
 	}
 
+	RecordTrace(r.Context(), TraceStageAuth, "rejected: "+err.Error())
+
 	// Log authentication failure (no sensitive data)
 	logger.Warn("Authentication failed",
 		slog.String("reason", err.Error()),
 		slog.String("correlation_id", correlationID),
 		slog.String("endpoint", r.URL.Path),
 		slog.String("remote_addr", r.RemoteAddr),
+		slog.String("client_ip", ClientIP(r)),
 		slog.String("user_agent", r.UserAgent()),
 	)
 
@@ -353,12 +360,22 @@ func writeRFC7807Error(
 	var title string
 
 	switch statusCode {
+	case http.StatusBadRequest:
+		title = "Bad Request"
+	case http.StatusConflict:
+		title = "Conflict"
+	case http.StatusUnprocessableEntity:
+		title = "Unprocessable Entity"
 	case http.StatusUnauthorized:
 		title = "Unauthorized"
 	case http.StatusForbidden:
 		title = "Forbidden"
+	case http.StatusMethodNotAllowed:
+		title = "Method Not Allowed"
 	case http.StatusTooManyRequests:
 		title = "Too Many Requests"
+	case http.StatusServiceUnavailable:
+		title = "Service Unavailable"
 	default:
 		title = "Authentication Failed"
 	}
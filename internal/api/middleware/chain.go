@@ -4,6 +4,8 @@ package middleware
 import (
 	"log/slog"
 	"net/http"
+	"net/netip"
+	"time"
 
 	"github.com/correlator-io/correlator/internal/storage"
 )
@@ -23,7 +25,7 @@ type (
 //	    middleware.WithRecovery(logger),
 //	    middleware.WithAuth(store, logger),
 //	    middleware.WithRateLimit(limiter, logger),
-//	    middleware.WithRequestLogger(logger),
+//	    middleware.WithRequestLogger(logger, 1),
 //	    middleware.WithCORS(corsConfig),
 //	)
 func Apply(handler http.Handler, options ...Option) http.Handler {
@@ -64,6 +66,33 @@ func WithAuth(store storage.APIKeyStore, logger *slog.Logger) Option {
 	}
 }
 
+// WithPreAuth returns an option that trusts the plugin identity and scopes set by a proxy in
+// cfg.IdentityHeader, from cfg.TrustedSources only (see PreAuthenticate). Must precede WithAuth.
+// If store is nil (authentication disabled) or cfg.IdentityHeader is empty, this option is skipped
+// (no middleware applied).
+func WithPreAuth(store storage.APIKeyStore, cfg PreAuthConfig, logger *slog.Logger) Option {
+	if store == nil || cfg.IdentityHeader == "" {
+		return func(next http.Handler) http.Handler {
+			return next // No-op if pre-authentication not configured
+		}
+	}
+
+	return PreAuthenticate(cfg, logger)
+}
+
+// WithImpersonation returns an option that lets admin keys act as another plugin via the
+// X-Impersonate-Plugin header (see Impersonate). The header is refused unless enabled is true.
+// If store is nil (authentication disabled), this option is skipped (no middleware applied).
+func WithImpersonation(store storage.APIKeyStore, enabled bool, logger *slog.Logger) Option {
+	if store == nil {
+		return func(next http.Handler) http.Handler {
+			return next // No-op without authentication: there is no admin to impersonate from
+		}
+	}
+
+	return Impersonate(store, enabled, logger)
+}
+
 // WithRateLimit returns an option that adds rate limiting middleware.
 // If limiter is nil, this option is skipped (no middleware applied).
 func WithRateLimit(limiter RateLimiter, logger *slog.Logger) Option {
@@ -79,9 +108,10 @@ func WithRateLimit(limiter RateLimiter, logger *slog.Logger) Option {
 }
 
 // WithRequestLogger returns an option that adds request logging middleware.
-func WithRequestLogger(logger *slog.Logger) Option {
+// Only 1 in sampleRate successful requests is logged (<= 1 logs all); errors are always logged.
+func WithRequestLogger(logger *slog.Logger, sampleRate int) Option {
 	return func(next http.Handler) http.Handler {
-		return RequestLogger(logger)(next)
+		return RequestLogger(logger, sampleRate)(next)
 	}
 }
 
@@ -91,3 +121,63 @@ func WithCORS(config CORSConfigProvider) Option {
 		return CORS(config)(next)
 	}
 }
+
+// WithTracing returns an option that adds request tracing middleware.
+// If tracer is nil, this option is skipped (no middleware applied).
+func WithTracing(tracer *RequestTracer) Option {
+	if tracer == nil {
+		return func(next http.Handler) http.Handler {
+			return next // No-op if tracing disabled
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return Tracing(tracer)(next)
+	}
+}
+
+// WithRealIP returns an option that resolves the client IP for ClientIP.
+// Forwarding headers are only honored from trustedProxies (none = always use RemoteAddr).
+func WithRealIP(trustedProxies []netip.Prefix) Option {
+	return func(next http.Handler) http.Handler {
+		return RealIP(trustedProxies)(next)
+	}
+}
+
+// WithMaintenance returns an option that rejects business requests while mode is enabled.
+// If mode is nil, this option is skipped (no middleware applied).
+func WithMaintenance(mode *MaintenanceMode, logger *slog.Logger) Option {
+	if mode == nil {
+		return func(next http.Handler) http.Handler {
+			return next // No-op if maintenance mode not configured
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return Maintenance(mode, logger)(next)
+	}
+}
+
+// WithReadOnly returns an option that rejects mutating requests with 405.
+// If enabled is false, this option is skipped (no middleware applied).
+func WithReadOnly(enabled bool, logger *slog.Logger) Option {
+	if !enabled {
+		return func(next http.Handler) http.Handler {
+			return next // No-op if the server accepts writes
+		}
+	}
+
+	return ReadOnly(logger)
+}
+
+// WithConcurrencyLimit returns an option that sheds requests beyond limit in flight with 503.
+// If limit is 0 or negative, this option is skipped (no middleware applied).
+func WithConcurrencyLimit(limit int, retryAfter time.Duration, logger *slog.Logger) Option {
+	if limit <= 0 {
+		return func(next http.Handler) http.Handler {
+			return next // No-op if no limit configured
+		}
+	}
+
+	return ConcurrencyLimit(limit, retryAfter, logger)
+}
@@ -25,8 +25,19 @@ type ClientContext struct {
 	// KeyID is the API key ID used for authentication (for audit logging)
 	KeyID string
 
+	// KeyExpiresAt is when the API key expires (nil = never)
+	KeyExpiresAt *time.Time
+
 	// AuthTime is the timestamp when authentication occurred (for latency tracking)
 	AuthTime time.Time
+
+	// ImpersonatedBy is the client ID of the admin key acting as ClientID (see Impersonate);
+	// empty when the request is not impersonated. KeyID is then the admin's key.
+	ImpersonatedBy string
+
+	// PreAuthenticated is true when a trusted proxy authenticated the request (see
+	// PreAuthenticate) instead of an API key. KeyID is then empty.
+	PreAuthenticated bool
 }
 
 // GetClientContext extracts client context from the request context.
@@ -0,0 +1,145 @@
+// Package middleware provides HTTP middleware components for the Correlator API.
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ErrInvalidTrustedProxy is returned by ParseTrustedProxies for an entry that is neither a CIDR nor an IP.
+var ErrInvalidTrustedProxy = errors.New("invalid trusted proxy")
+
+// clientIPKey is the context key for the resolved client IP.
+type clientIPKey struct{}
+
+// ParseTrustedProxies parses proxy CIDRs (e.g., "10.0.0.0/8"). A bare IP is treated as a
+// single-address prefix. Empty entries are ignored.
+func ParseTrustedProxies(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+
+			continue
+		}
+
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q must be a CIDR (e.g., 10.0.0.0/8) or IP", ErrInvalidTrustedProxy, entry)
+		}
+
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+
+	return prefixes, nil
+}
+
+// RealIP creates a middleware that resolves the client IP once per request and makes it
+// available through ClientIP.
+//
+// Forwarding headers are honored only when the direct peer (RemoteAddr) is in trustedProxies;
+// otherwise any client could spoof its IP by sending X-Forwarded-For. With no trusted proxies
+// the client IP is always the peer address.
+func RealIP(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolveClientIP(r, trustedProxies)
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, ip)))
+		})
+	}
+}
+
+// ClientIP returns the real client IP of r.
+//
+// Behind the RealIP middleware this is the address resolved from X-Forwarded-For / X-Real-IP
+// when the peer is a trusted proxy. Otherwise (or outside the middleware) it is the host part
+// of RemoteAddr.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+
+	return remoteHost(r.RemoteAddr)
+}
+
+// resolveClientIP determines the client IP of r given the trusted proxy ranges.
+//
+// X-Forwarded-For is read right to left, skipping trusted proxies: the first untrusted address
+// is the client (entries left of it were supplied by the client and cannot be trusted). If every
+// hop is trusted, the leftmost address is used. X-Real-IP is the fallback when X-Forwarded-For
+// is absent or malformed.
+func resolveClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	peer := remoteHost(r.RemoteAddr)
+
+	peerAddr, err := netip.ParseAddr(peer)
+	if err != nil || !isTrusted(peerAddr, trustedProxies) {
+		return peer
+	}
+
+	if hops := forwardedFor(r.Header.Values("X-Forwarded-For")); len(hops) > 0 {
+		for i := len(hops) - 1; i >= 0; i-- {
+			if !isTrusted(hops[i], trustedProxies) || i == 0 {
+				return hops[i].String()
+			}
+		}
+	}
+
+	if realIP, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return realIP.Unmap().String()
+	}
+
+	return peer
+}
+
+// forwardedFor parses all X-Forwarded-For header lines into addresses, in order.
+// Returns nil if any entry is not a valid IP, so a malformed chain is never partially trusted.
+func forwardedFor(headers []string) []netip.Addr {
+	var hops []netip.Addr
+
+	for _, header := range headers {
+		for _, entry := range strings.Split(header, ",") {
+			addr, err := netip.ParseAddr(strings.TrimSpace(entry))
+			if err != nil {
+				return nil
+			}
+
+			hops = append(hops, addr.Unmap())
+		}
+	}
+
+	return hops
+}
+
+func isTrusted(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remoteHost strips the port from a RemoteAddr ("ip:port"), returning it unchanged if it has none.
+func remoteHost(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+
+	return host
+}
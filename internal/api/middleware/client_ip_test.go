@@ -0,0 +1,162 @@
+// Package middleware provides HTTP middleware components for the Correlator API.
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// resolveThroughRealIP runs a request through the RealIP middleware and returns ClientIP as seen
+// by the handler.
+func resolveThroughRealIP(t *testing.T, trusted []string, remoteAddr string, headers map[string]string) string {
+	t.Helper()
+
+	proxies, err := ParseTrustedProxies(trusted)
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() error = %v", err)
+	}
+
+	var got string
+
+	handler := RealIP(proxies)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = ClientIP(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/lineage", nil)
+	req.RemoteAddr = remoteAddr
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	return got
+}
+
+func TestClientIP(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	ingress := []string{"10.0.0.0/8"}
+
+	tests := []struct {
+		name       string
+		trusted    []string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{
+			name:       "no trusted proxies ignores forwarding headers",
+			remoteAddr: "10.0.0.5:41000",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "untrusted peer cannot spoof X-Forwarded-For",
+			trusted:    ingress,
+			remoteAddr: "198.51.100.9:41000",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7", "X-Real-IP": "203.0.113.8"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "trusted peer uses X-Forwarded-For",
+			trusted:    ingress,
+			remoteAddr: "10.0.0.5:41000",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "rightmost untrusted hop wins over client-supplied entries",
+			trusted:    ingress,
+			remoteAddr: "10.0.0.5:41000",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, 203.0.113.7, 10.1.2.3"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "all hops trusted uses leftmost",
+			trusted:    ingress,
+			remoteAddr: "10.0.0.5:41000",
+			headers:    map[string]string{"X-Forwarded-For": "10.9.9.9, 10.1.2.3"},
+			want:       "10.9.9.9",
+		},
+		{
+			name:       "falls back to X-Real-IP",
+			trusted:    ingress,
+			remoteAddr: "10.0.0.5:41000",
+			headers:    map[string]string{"X-Real-IP": "203.0.113.8"},
+			want:       "203.0.113.8",
+		},
+		{
+			name:       "malformed X-Forwarded-For falls back to X-Real-IP",
+			trusted:    ingress,
+			remoteAddr: "10.0.0.5:41000",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7, unknown", "X-Real-IP": "203.0.113.8"},
+			want:       "203.0.113.8",
+		},
+		{
+			name:       "trusted peer without headers uses peer",
+			trusted:    ingress,
+			remoteAddr: "10.0.0.5:41000",
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "single trusted IPv6 address",
+			trusted:    []string{"::1"},
+			remoteAddr: "[::1]:41000",
+			headers:    map[string]string{"X-Forwarded-For": "2001:db8::7"},
+			want:       "2001:db8::7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveThroughRealIP(t, tt.trusted, tt.remoteAddr, tt.headers); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIP_WithoutMiddleware(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	if got := ClientIP(req); got != "192.0.2.1" {
+		t.Errorf("ClientIP() = %q, want peer address 192.0.2.1", got)
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	prefixes, err := ParseTrustedProxies([]string{"10.0.0.0/8", " 192.168.1.10 ", "", "fd00::/8"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() error = %v", err)
+	}
+
+	if len(prefixes) != 3 {
+		t.Fatalf("ParseTrustedProxies() returned %d prefixes, want 3", len(prefixes))
+	}
+
+	if got := prefixes[1].String(); got != "192.168.1.10/32" {
+		t.Errorf("bare IP parsed as %s, want 192.168.1.10/32", got)
+	}
+
+	for _, invalid := range []string{"10.0.0.0/33", "ingress.local", "10.0.0"} {
+		if _, err := ParseTrustedProxies([]string{invalid}); !errors.Is(err, ErrInvalidTrustedProxy) {
+			t.Errorf("ParseTrustedProxies(%q) error = %v, want ErrInvalidTrustedProxy", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// TraceStageConcurrencyLimit is recorded when a request is shed by the concurrency limit.
+const TraceStageConcurrencyLimit = "concurrency_limit"
+
+// ConcurrencyLimit returns middleware that admits at most limit requests at a time and answers
+// any further request immediately with an RFC 7807 503 and a Retry-After header (rounded up to
+// whole seconds, at least 1), instead of letting it queue for a database connection until it
+// times out. Public endpoints (see RegisterPublicEndpoint) always pass.
+//
+// Unlike RateLimit, which bounds request rates per client, this bounds the total work in
+// progress and sheds load under a thundering herd. Open SSE streams hold a slot until they end.
+func ConcurrencyLimit(limit int, retryAfter time.Duration, logger *slog.Logger) func(http.Handler) http.Handler {
+	slots := make(chan struct{}, limit)
+	retryAfterHeader := retryAfterSeconds(retryAfter)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if publicEndpoints[r.URL.Path] {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			select {
+			case slots <- struct{}{}:
+			default:
+				correlationID := GetCorrelationID(r.Context())
+
+				RecordTrace(r.Context(), TraceStageConcurrencyLimit, "rejected")
+
+				w.Header().Set("Retry-After", retryAfterHeader)
+
+				detail := "Server is at its concurrent request limit. Please retry later."
+				if err := writeRFC7807Error(w, r, http.StatusServiceUnavailable, detail, correlationID); err != nil {
+					logger.Error("failed to write response with RFC 7807 error format",
+						slog.String("correlation_id", correlationID),
+						slog.String("path", r.URL.Path),
+						slog.String("detail", detail),
+						slog.String("error", err.Error()),
+					)
+
+					http.Error(w, detail, http.StatusServiceUnavailable)
+				}
+
+				return
+			}
+
+			defer func() { <-slots }()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// retryAfterSeconds formats d as a Retry-After header value in whole seconds (rounded up, at least 1).
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(d.Seconds()))))
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrencyLimit_ShedsExcessRequests verifies that with N requests in flight the N+1th
+// request gets an immediate 503 with Retry-After, public endpoints still pass, and a slot is
+// reusable once a request completes.
+func TestConcurrencyLimit_ShedsExcessRequests(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	RegisterPublicEndpoint("/ping")
+
+	const limit = 2
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/lineage/batch" {
+			started <- struct{}{}
+			<-release
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	chain := Apply(handler,
+		WithCorrelationID(),
+		WithConcurrencyLimit(limit, 1500*time.Millisecond, slog.Default()),
+	)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		chain.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return rr
+	}
+
+	var wg sync.WaitGroup
+
+	codes := make(chan int, limit)
+
+	for range limit {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			codes <- serve("/api/v1/lineage/batch").Code
+		}()
+
+		<-started
+	}
+
+	rr := serve("/api/v1/lineage")
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 for request %d, got %d", limit+1, rr.Code)
+	}
+
+	if got := rr.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Expected Retry-After rounded up to 2, got %q", got)
+	}
+
+	if got := rr.Header().Get("Content-Type"); got != contentTypeProblemJSON {
+		t.Errorf("Expected %s, got %q", contentTypeProblemJSON, got)
+	}
+
+	if rr := serve("/ping"); rr.Code != http.StatusOK {
+		t.Errorf("Expected public endpoint to bypass the limit, got %d", rr.Code)
+	}
+
+	close(release)
+	wg.Wait()
+	close(codes)
+
+	for code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("Expected admitted request to complete with 200, got %d", code)
+		}
+	}
+
+	if rr := serve("/api/v1/lineage"); rr.Code != http.StatusOK {
+		t.Errorf("Expected 200 once slots are free, got %d", rr.Code)
+	}
+}
+
+// TestWithConcurrencyLimit_Disabled verifies that a limit of 0 applies no middleware.
+func TestWithConcurrencyLimit_Disabled(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	WithConcurrencyLimit(0, time.Second, slog.Default())(handler).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/lineage", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected 200 with the limit disabled, got %d", rr.Code)
+	}
+}
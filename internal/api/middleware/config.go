@@ -7,7 +7,7 @@ import (
 	"github.com/correlator-io/correlator/internal/config"
 )
 
-// Config holds rate limiter configuration.
+// Config holds rate limiter and request logging configuration.
 //
 // Rate limits specify requests per second (RPS) for three tiers:
 //   - Global: Applied to all requests
@@ -31,6 +31,9 @@ type Config struct {
 	CleanupInterval time.Duration // Default: 5 minutes
 	IdleTimeout     time.Duration // Default: 1 hour
 	MaxClients      int           // Default: 10,000
+
+	// Request logging: log 1 in LogSampleRate successful requests; 4xx/5xx are always logged
+	LogSampleRate int // Default: 1 (log every request)
 }
 
 // LoadConfig loads middleware config from environment variables with fallback to defaults.
@@ -54,5 +57,7 @@ func LoadConfig() *Config {
 		),
 		IdleTimeout: config.GetEnvDuration("CORRELATOR_RATE_LIMIT_IDLE_TIMEOUT", rateLimiterIdleTimeout),
 		MaxClients:  config.GetEnvInt("CORRELATOR_RATE_LIMIT_MAX_CLIENTS", maxClients),
+		// Request log sampling
+		LogSampleRate: config.GetEnvInt("CORRELATOR_LOG_SAMPLE_RATE", defaultLogSampleRate),
 	}
 }
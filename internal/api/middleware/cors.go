@@ -3,6 +3,7 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -14,14 +15,21 @@ type CORSConfigProvider interface {
 	GetAllowedMethods() []string
 	GetAllowedHeaders() []string
 	GetMaxAge() int
+	GetAllowCredentials() bool
 }
 
 // CORS creates a middleware that handles Cross-Origin Resource Sharing (CORS).
+//
+// Browsers reject "Access-Control-Allow-Origin: *" on credentialed requests, so when credentials
+// are allowed the middleware echoes the request origin (if in the allowlist) and adds
+// "Access-Control-Allow-Credentials: true". Whenever the allowed origin depends on the request,
+// responses carry "Vary: Origin" so caches (and the browser's preflight cache) do not serve one
+// origin's headers to another.
 func CORS(config CORSConfigProvider) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Set all CORS headers
-			setCORSOriginHeader(w, r, config.GetAllowedOrigins())
+			setCORSOriginHeader(w, r, config.GetAllowedOrigins(), config.GetAllowCredentials())
 			setCORSMethodsHeader(w, config.GetAllowedMethods())
 			setCORSHeadersHeader(w, config.GetAllowedHeaders())
 			setCORSMaxAgeHeader(w, config.GetMaxAge())
@@ -39,24 +47,36 @@ func CORS(config CORSConfigProvider) func(http.Handler) http.Handler {
 }
 
 // setCORSOriginHeader sets the Access-Control-Allow-Origin header based on allowed origins.
-func setCORSOriginHeader(w http.ResponseWriter, r *http.Request, allowedOrigins []string) {
+//
+// A lone "*" allows any origin, without credentials only: echoing every origin with credentials
+// would let any site make authenticated requests (ServerConfig.Validate refuses that combination,
+// and no origin is allowed if it gets here anyway). A disallowed origin gets no
+// Access-Control-Allow-Origin header, so the browser blocks the response.
+func setCORSOriginHeader(w http.ResponseWriter, r *http.Request, allowedOrigins []string, allowCredentials bool) {
 	if len(allowedOrigins) == 0 {
 		return
 	}
 
 	if len(allowedOrigins) == 1 && allowedOrigins[0] == "*" {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if !allowCredentials {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
 
 		return
 	}
 
+	// The response now depends on the Origin header, even when the origin is rejected
+	w.Header().Add("Vary", "Origin")
+
 	origin := r.Header.Get("Origin")
-	for _, allowedOrigin := range allowedOrigins {
-		if origin == allowedOrigin {
-			w.Header().Set("Access-Control-Allow-Origin", origin)
+	if origin == "" || !slices.Contains(allowedOrigins, origin) {
+		return
+	}
 
-			break
-		}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+
+	if allowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
 	}
 }
 
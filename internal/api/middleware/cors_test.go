@@ -0,0 +1,151 @@
+// Package middleware provides HTTP middleware components for the Correlator API.
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testCORSConfig is a static CORSConfigProvider.
+type testCORSConfig struct {
+	origins     []string
+	credentials bool
+}
+
+func (c testCORSConfig) GetAllowedOrigins() []string { return c.origins }
+func (c testCORSConfig) GetAllowedMethods() []string { return []string{"GET", "POST"} }
+func (c testCORSConfig) GetAllowedHeaders() []string { return []string{"Content-Type", "X-Api-Key"} }
+func (c testCORSConfig) GetMaxAge() int              { return 600 }
+func (c testCORSConfig) GetAllowCredentials() bool   { return c.credentials }
+
+// serveCORS runs a request from origin through the CORS middleware.
+// Returns the response and whether the wrapped handler ran.
+func serveCORS(config testCORSConfig, method, origin string) (*httptest.ResponseRecorder, bool) {
+	called := false
+
+	handler := CORS(config)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(method, "/api/v1/incidents", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		req.Header.Set("Access-Control-Request-Headers", "X-Api-Key")
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr, called
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		name            string
+		config          testCORSConfig
+		origin          string
+		wantOrigin      string
+		wantCredentials string
+		wantVary        bool
+	}{
+		{
+			name:       "wildcard without credentials",
+			config:     testCORSConfig{origins: []string{"*"}},
+			origin:     "https://app.example.com",
+			wantOrigin: "*",
+		},
+		{
+			name:   "wildcard with credentials allows no origin",
+			config: testCORSConfig{origins: []string{"*"}, credentials: true},
+			origin: "https://evil.example.com",
+		},
+		{
+			name:            "allowlisted origin with credentials",
+			config:          testCORSConfig{origins: []string{"https://app.example.com"}, credentials: true},
+			origin:          "https://app.example.com",
+			wantOrigin:      "https://app.example.com",
+			wantCredentials: "true",
+			wantVary:        true,
+		},
+		{
+			name:       "allowlisted origin without credentials",
+			config:     testCORSConfig{origins: []string{"https://app.example.com"}},
+			origin:     "https://app.example.com",
+			wantOrigin: "https://app.example.com",
+			wantVary:   true,
+		},
+		{
+			name:     "disallowed origin is blocked",
+			config:   testCORSConfig{origins: []string{"https://app.example.com"}, credentials: true},
+			origin:   "https://evil.example.com",
+			wantVary: true,
+		},
+		{
+			name:     "same-origin request without Origin header",
+			config:   testCORSConfig{origins: []string{"https://app.example.com"}, credentials: true},
+			wantVary: true,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, method := range []string{http.MethodGet, http.MethodOptions} {
+			t.Run(tt.name+" "+method, func(t *testing.T) {
+				rr, _ := serveCORS(tt.config, method, tt.origin)
+
+				if got := rr.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+					t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+				}
+
+				if got := rr.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+					t.Errorf("Access-Control-Allow-Credentials = %q, want %q", got, tt.wantCredentials)
+				}
+
+				if got := rr.Header().Get("Vary") == "Origin"; got != tt.wantVary {
+					t.Errorf("Vary: Origin = %v, want %v (Vary = %q)", got, tt.wantVary, rr.Header().Get("Vary"))
+				}
+			})
+		}
+	}
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	config := testCORSConfig{origins: []string{"https://app.example.com"}, credentials: true}
+
+	rr, called := serveCORS(config, http.MethodOptions, "https://app.example.com")
+
+	if called {
+		t.Error("Preflight should be answered by the middleware, not the handler")
+	}
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", rr.Code, http.StatusNoContent)
+	}
+
+	if got := rr.Header().Get("Access-Control-Allow-Headers"); got != "Content-Type, X-Api-Key" {
+		t.Errorf("Access-Control-Allow-Headers = %q", got)
+	}
+
+	if got := rr.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Access-Control-Max-Age = %q, want 600", got)
+	}
+
+	rr, called = serveCORS(config, http.MethodGet, "https://app.example.com")
+	if !called || rr.Code != http.StatusOK {
+		t.Errorf("Credentialed request should reach the handler (called = %v, status = %d)", called, rr.Code)
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Deprecation describes an endpoint (or a field or parameter of one) that is going away, so
+// clients can detect upcoming removals programmatically instead of from release notes.
+type Deprecation struct {
+	Since  time.Time // When it was deprecated: the Deprecation header (RFC 9745), required
+	Sunset time.Time // When it stops working: the Sunset header (RFC 8594), zero = not scheduled
+	Link   string    // Migration guide, sent as Link rel="deprecation"; empty = none
+}
+
+// Deprecated returns middleware that announces d on every response of the wrapped route,
+// including error responses. Register deprecated routes with it, e.g.
+//
+//	mux.Handle("GET /api/v1/old", middleware.Deprecated(middleware.Deprecation{
+//	    Since:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+//	    Sunset: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+//	    Link:   "https://getcorrelator.io/docs/migrations/old",
+//	})(s.handleOld))
+func Deprecated(d Deprecation) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			SetDeprecationHeaders(w.Header(), d)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetDeprecationHeaders sets the Deprecation, Sunset, and Link headers for d. Handlers use it
+// directly when only part of a request is deprecated, e.g. a query parameter that was used.
+//
+// Example:
+//
+//	Deprecation: @1767225600
+//	Sunset: Wed, 01 Jul 2026 00:00:00 GMT
+//	Link: <https://getcorrelator.io/docs/migrations/old>; rel="deprecation"; type="text/html"
+func SetDeprecationHeaders(h http.Header, d Deprecation) {
+	h.Set("Deprecation", "@"+strconv.FormatInt(d.Since.Unix(), 10))
+
+	if !d.Sunset.IsZero() {
+		h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	if d.Link != "" {
+		h.Add("Link", "<"+d.Link+`>; rel="deprecation"; type="text/html"`)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestDeprecated_EmitsHeaders verifies that a route registered with Deprecated announces its
+// deprecation and sunset on every response, and other routes are unaffected.
+func TestDeprecated_EmitsHeaders(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/v1/old", Deprecated(Deprecation{
+		Since:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2026, 7, 1, 0, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+		Link:   "https://getcorrelator.io/docs/migrations/old",
+	})(ok))
+	mux.Handle("GET /api/v1/new", ok)
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/old", nil))
+
+	for header, want := range map[string]string{
+		"Deprecation": "@1767225600",
+		"Sunset":      "Tue, 30 Jun 2026 22:00:00 GMT",
+		"Link":        `<https://getcorrelator.io/docs/migrations/old>; rel="deprecation"; type="text/html"`,
+	} {
+		if got := rr.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/new", nil))
+
+	for _, header := range []string{"Deprecation", "Sunset", "Link"} {
+		if got := rr.Header().Get(header); got != "" {
+			t.Errorf("Expected no %s header on a current route, got %q", header, got)
+		}
+	}
+}
+
+func TestSetDeprecationHeaders_WithoutSunset(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	h := http.Header{}
+	SetDeprecationHeaders(h, Deprecation{Since: time.Unix(1688169599, 0)})
+
+	if got := h.Get("Deprecation"); got != "@1688169599" {
+		t.Errorf("Deprecation = %q, want %q", got, "@1688169599")
+	}
+
+	if got := h.Get("Sunset"); got != "" {
+		t.Errorf("Expected no Sunset header, got %q", got)
+	}
+
+	if got := h.Get("Link"); got != "" {
+		t.Errorf("Expected no Link header, got %q", got)
+	}
+}
@@ -0,0 +1,387 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header identifying retries of the same request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set to "true" on responses replayed from the idempotency cache.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// TraceStageIdempotency is recorded when a request is answered from the idempotency cache
+	// or rejected as a conflicting reuse of its key.
+	TraceStageIdempotency = "idempotency"
+
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentResponseSize bounds the response body kept per key; larger responses are not cached.
+	maxIdempotentResponseSize = 1 << 20
+)
+
+type (
+	// IdempotencyCache keeps the responses of recent requests that carried an Idempotency-Key,
+	// so a retry of the same request (e.g., a CI job re-running a POST after a timeout) gets
+	// the original response instead of being processed again.
+	//
+	// Properties:
+	//   - Scoped: keys are per client, method and path; two clients may use the same key
+	//   - Bounded by count: at most maxKeys keys are kept; the oldest is evicted first
+	//   - Bounded by age: responses older than ttl are never replayed and are evicted lazily
+	//   - In memory: each server instance has its own cache (retries must reach the same
+	//     instance to be deduplicated)
+	IdempotencyCache struct {
+		maxKeys int
+		ttl     time.Duration
+		now     func() time.Time
+
+		mu      sync.Mutex
+		ring    []*idempotentResponse
+		next    int
+		entries map[string]*idempotentResponse
+	}
+
+	// idempotentResponse is the recorded outcome of one keyed request.
+	// Until done is set, the original request is still in flight.
+	idempotentResponse struct {
+		key         string
+		bodyHash    [sha256.Size]byte
+		statusCode  int
+		contentType string
+		body        []byte
+		storedAt    time.Time
+		done        bool
+	}
+
+	// idempotencyRecorder passes the response through while keeping a copy of it.
+	idempotencyRecorder struct {
+		http.ResponseWriter
+
+		statusCode int
+		body       bytes.Buffer
+		overflow   bool
+	}
+
+	// hashingReader hashes everything read from the request body.
+	hashingReader struct {
+		io.ReadCloser
+
+		hash hash.Hash
+		read int64
+		err  error
+	}
+)
+
+// NewIdempotencyCache creates a cache that keeps up to maxKeys responses for at most ttl.
+// Returns nil (deduplication disabled) when either bound is not positive.
+func NewIdempotencyCache(maxKeys int, ttl time.Duration) *IdempotencyCache {
+	if maxKeys <= 0 || ttl <= 0 {
+		return nil
+	}
+
+	return &IdempotencyCache{
+		maxKeys: maxKeys,
+		ttl:     ttl,
+		now:     time.Now,
+		ring:    make([]*idempotentResponse, maxKeys),
+		entries: make(map[string]*idempotentResponse, maxKeys),
+	}
+}
+
+// Len returns the number of keys currently held (including in-flight and expired ones not yet evicted).
+func (c *IdempotencyCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// reserve returns the entry recorded for key, or reserves key for a new in-flight request
+// (returning nil). A copy of the entry is returned, so it can be read without the lock.
+func (c *IdempotencyCache) reserve(key string) *idempotentResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		if !entry.done || c.now().Sub(entry.storedAt) <= c.ttl {
+			clone := *entry
+
+			return &clone
+		}
+
+		delete(c.entries, key)
+	}
+
+	if evicted := c.ring[c.next]; evicted != nil && c.entries[evicted.key] == evicted {
+		delete(c.entries, evicted.key)
+	}
+
+	entry := &idempotentResponse{key: key, storedAt: c.now()}
+	c.ring[c.next] = entry
+	c.entries[key] = entry
+	c.next = (c.next + 1) % c.maxKeys
+
+	return nil
+}
+
+// complete records the response of the in-flight request holding key.
+func (c *IdempotencyCache) complete(key string, response idempotentResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Evicted while in flight: nothing to record
+	entry, ok := c.entries[key]
+	if !ok || entry.done {
+		return
+	}
+
+	entry.bodyHash = response.bodyHash
+	entry.statusCode = response.statusCode
+	entry.contentType = response.contentType
+	entry.body = response.body
+	entry.storedAt = c.now()
+	entry.done = true
+}
+
+// release drops the reservation of a request whose response is not cached, so a retry is
+// processed again.
+func (c *IdempotencyCache) release(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok && !entry.done {
+		delete(c.entries, key)
+	}
+}
+
+// Idempotency returns middleware that deduplicates requests carrying an Idempotency-Key header.
+//
+// The first request with a key is processed normally and its response (status, Content-Type
+// and body) is kept for the cache TTL. A retry with the same key, method, path and body
+// (compared by SHA-256, up to maxBodySize bytes) gets that response replayed with
+// Idempotent-Replayed: true. Reusing a key with a different body is rejected with 422; a retry
+// arriving while the original is still in flight is rejected with 409.
+//
+// Transient failures (5xx, 409, 429) are not cached, so their retries are processed again.
+// Requests without the header, and all requests when cache is nil, pass through unchanged.
+//
+// Must run after Auth so keys are scoped to the authenticated client.
+func Idempotency(cache *IdempotencyCache, maxBodySize int64, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cache == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+			if idempotencyKey == "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if len(idempotencyKey) > maxIdempotencyKeyLength {
+				writeIdempotencyError(w, r, logger, http.StatusBadRequest,
+					"Idempotency-Key must be at most 255 characters")
+
+				return
+			}
+
+			var clientID string
+			if clientCtx, ok := GetClientContext(r.Context()); ok {
+				clientID = clientCtx.ClientID
+			}
+
+			key := clientID + "\x00" + r.Method + " " + r.URL.Path + "\x00" + idempotencyKey
+
+			if previous := cache.reserve(key); previous != nil {
+				replayIdempotentResponse(w, r, previous, maxBodySize, logger)
+
+				return
+			}
+
+			body := &hashingReader{ReadCloser: r.Body, hash: sha256.New()}
+			r.Body = body
+
+			recorder := &idempotencyRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			completed := false
+
+			defer func() {
+				if !completed {
+					cache.release(key)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			// The body hash must cover the whole body, including what the handler did not read
+			bodyHash, ok := body.sum(maxBodySize)
+			if !ok || !recorder.cacheable() {
+				return
+			}
+
+			cache.complete(key, idempotentResponse{
+				bodyHash:    bodyHash,
+				statusCode:  recorder.statusCode,
+				contentType: recorder.Header().Get("Content-Type"),
+				body:        recorder.body.Bytes(),
+			})
+
+			completed = true
+		})
+	}
+}
+
+// replayIdempotentResponse answers a request whose key is already known.
+func replayIdempotentResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	previous *idempotentResponse,
+	maxBodySize int64,
+	logger *slog.Logger,
+) {
+	if !previous.done {
+		RecordTrace(r.Context(), TraceStageIdempotency, "conflict: original request in flight")
+		writeIdempotencyError(w, r, logger, http.StatusConflict,
+			"A request with this Idempotency-Key is still being processed. Please retry later.")
+
+		return
+	}
+
+	body := &hashingReader{ReadCloser: r.Body, hash: sha256.New()}
+
+	bodyHash, ok := body.sum(maxBodySize)
+	if errors.Is(body.err, ErrBodyTooSlow) {
+		writeIdempotencyError(w, r, logger, http.StatusRequestTimeout, "Request body arrived too slowly")
+
+		return
+	}
+
+	if body.err != nil && !errors.Is(body.err, errBodyTooLarge) {
+		writeIdempotencyError(w, r, logger, http.StatusBadRequest, "Failed to read request body")
+
+		return
+	}
+
+	if !ok || bodyHash != previous.bodyHash {
+		RecordTrace(r.Context(), TraceStageIdempotency, "rejected: key reused with a different body")
+		writeIdempotencyError(w, r, logger, http.StatusUnprocessableEntity,
+			"Idempotency-Key was already used for a request with a different body")
+
+		return
+	}
+
+	RecordTrace(r.Context(), TraceStageIdempotency, "replayed")
+
+	if previous.contentType != "" {
+		w.Header().Set("Content-Type", previous.contentType)
+	}
+
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(previous.statusCode)
+	_, _ = w.Write(previous.body)
+}
+
+// writeIdempotencyError writes an RFC 7807 error, falling back to plain text.
+func writeIdempotencyError(w http.ResponseWriter, r *http.Request, logger *slog.Logger, statusCode int, detail string) {
+	correlationID := GetCorrelationID(r.Context())
+
+	if err := writeRFC7807Error(w, r, statusCode, detail, correlationID); err != nil {
+		logger.Error("failed to write response with RFC 7807 error format",
+			slog.String("correlation_id", correlationID),
+			slog.String("path", r.URL.Path),
+			slog.String("detail", detail),
+			slog.String("error", err.Error()),
+		)
+
+		http.Error(w, detail, statusCode)
+	}
+}
+
+// errBodyTooLarge is recorded by hashingReader.sum when the body exceeds the hash limit.
+var errBodyTooLarge = errors.New("request body too large to hash")
+
+func (h *hashingReader) Read(p []byte) (int, error) {
+	n, err := h.ReadCloser.Read(p)
+	h.hash.Write(p[:n])
+	h.read += int64(n)
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		h.err = err
+	}
+
+	return n, err
+}
+
+// sum drains the rest of the body (at most limit bytes in total) and returns its hash.
+// Returns false if the body could not be read completely.
+func (h *hashingReader) sum(limit int64) ([sha256.Size]byte, bool) {
+	var sum [sha256.Size]byte
+
+	if h.err == nil && h.read <= limit {
+		// One byte over the limit tells an oversized body from one of exactly limit bytes
+		_, _ = io.Copy(io.Discard, io.LimitReader(h, limit+1-h.read))
+	}
+
+	if h.err == nil && h.read > limit {
+		h.err = errBodyTooLarge
+	}
+
+	if h.err != nil {
+		return sum, false
+	}
+
+	copy(sum[:], h.hash.Sum(nil))
+
+	return sum, true
+}
+
+func (rec *idempotencyRecorder) WriteHeader(statusCode int) {
+	rec.statusCode = statusCode
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *idempotencyRecorder) Write(b []byte) (int, error) {
+	if !rec.overflow {
+		if rec.body.Len()+len(b) > maxIdempotentResponseSize {
+			rec.overflow = true
+			rec.body = bytes.Buffer{}
+		} else {
+			rec.body.Write(b)
+		}
+	}
+
+	return rec.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (rec *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// cacheable reports whether a retry should get this response instead of being processed again.
+func (rec *idempotencyRecorder) cacheable() bool {
+	switch {
+	case rec.overflow:
+		return false
+	case rec.statusCode >= http.StatusInternalServerError:
+		return false
+	case rec.statusCode == http.StatusConflict, rec.statusCode == http.StatusTooManyRequests:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,240 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingHandler echoes the request body with a per-call counter, so tests can tell a
+// processed request from a replayed one.
+type countingHandler struct {
+	calls  int
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+
+	body, _ := io.ReadAll(r.Body)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(h.status)
+	_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(h.calls) + `,"body":"` + string(body) + `"}`))
+}
+
+// serveIdempotent sends one POST with the given key and body through the Idempotency middleware.
+func serveIdempotent(handler http.Handler, clientID, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	if clientID != "" {
+		req = req.WithContext(SetClientContext(req.Context(), ClientContext{ClientID: clientID}))
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func newIdempotentHandler(cache *IdempotencyCache, next http.Handler) http.Handler {
+	return Idempotency(cache, 1024, slog.New(slog.NewTextHandler(io.Discard, nil)))(next)
+}
+
+// TestIdempotency_ReplaysRetry verifies that a retry with the same key and body gets the
+// original response without reaching the handler.
+func TestIdempotency_ReplaysRetry(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	next := &countingHandler{status: http.StatusOK}
+	handler := newIdempotentHandler(NewIdempotencyCache(10, time.Hour), next)
+
+	first := serveIdempotent(handler, "ci", "/api/v1/lineage/batch", "key-1", "events")
+	retry := serveIdempotent(handler, "ci", "/api/v1/lineage/batch", "key-1", "events")
+
+	if next.calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", next.calls)
+	}
+
+	if retry.Code != http.StatusOK || retry.Body.String() != first.Body.String() {
+		t.Errorf("Expected replay of %d %s, got %d %s", first.Code, first.Body, retry.Code, retry.Body)
+	}
+
+	if retry.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Expected replayed Content-Type, got %q", retry.Header().Get("Content-Type"))
+	}
+
+	if first.Header().Get(IdempotentReplayedHeader) != "" || retry.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("Expected only the retry to be marked replayed")
+	}
+}
+
+// TestIdempotency_KeyScope verifies that keys are scoped by client and path, and that requests
+// without a key are never deduplicated.
+func TestIdempotency_KeyScope(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	next := &countingHandler{status: http.StatusOK}
+	handler := newIdempotentHandler(NewIdempotencyCache(10, time.Hour), next)
+
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+	serveIdempotent(handler, "dbt", "/api/v1/lineage", "key-1", "events")
+	serveIdempotent(handler, "ci", "/api/v1/lineage/batch", "key-1", "events")
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "", "events")
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "", "events")
+
+	if next.calls != 5 {
+		t.Errorf("Expected every request to be processed, handler ran %d times", next.calls)
+	}
+}
+
+// TestIdempotency_RejectsReuseWithDifferentBody verifies the 422 for a key reused with another body.
+func TestIdempotency_RejectsReuseWithDifferentBody(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	next := &countingHandler{status: http.StatusOK}
+	handler := newIdempotentHandler(NewIdempotencyCache(10, time.Hour), next)
+
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+
+	rec := serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "other events")
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+
+	if rec.Header().Get("Content-Type") != contentTypeProblemJSON {
+		t.Errorf("Expected RFC 7807 response, got Content-Type %q", rec.Header().Get("Content-Type"))
+	}
+
+	if next.calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", next.calls)
+	}
+}
+
+// TestIdempotency_InFlightConflict verifies that a retry arriving while the original request
+// is still being processed is rejected with 409.
+func TestIdempotency_InFlightConflict(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	cache := NewIdempotencyCache(10, time.Hour)
+
+	var retry *httptest.ResponseRecorder
+
+	var handler http.Handler
+
+	handler = newIdempotentHandler(cache, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if retry == nil {
+			retry = serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	first := serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+
+	if first.Code != http.StatusOK {
+		t.Errorf("Expected original request to succeed, got %d", first.Code)
+	}
+
+	if retry.Code != http.StatusConflict {
+		t.Errorf("Expected in-flight retry to get %d, got %d", http.StatusConflict, retry.Code)
+	}
+}
+
+// TestIdempotency_TransientFailuresNotCached verifies that 5xx responses are not replayed.
+func TestIdempotency_TransientFailuresNotCached(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	cache := NewIdempotencyCache(10, time.Hour)
+	next := &countingHandler{status: http.StatusServiceUnavailable}
+	handler := newIdempotentHandler(cache, next)
+
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+
+	next.status = http.StatusOK
+
+	rec := serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+	if rec.Code != http.StatusOK || next.calls != 2 {
+		t.Errorf("Expected retry to be processed again, got %d after %d calls", rec.Code, next.calls)
+	}
+
+	if cache.Len() != 1 {
+		t.Errorf("Expected the successful retry to be cached, cache holds %d keys", cache.Len())
+	}
+}
+
+// TestIdempotency_ExpiryAndEviction verifies the age and count bounds of the cache.
+func TestIdempotency_ExpiryAndEviction(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewIdempotencyCache(2, time.Hour)
+	cache.now = func() time.Time { return now }
+
+	next := &countingHandler{status: http.StatusOK}
+	handler := newIdempotentHandler(cache, next)
+
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+
+	now = now.Add(time.Hour + time.Second)
+
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+
+	if next.calls != 2 {
+		t.Errorf("Expected expired key to be processed again, handler ran %d times", next.calls)
+	}
+
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-2", "events")
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-3", "events")
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected cache bounded at 2 keys, holds %d", cache.Len())
+	}
+
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-3", "events")
+
+	if next.calls != 4 {
+		t.Errorf("Expected newest key to be replayed, handler ran %d times", next.calls)
+	}
+}
+
+// TestIdempotency_Disabled verifies that a nil cache passes every request through.
+func TestIdempotency_Disabled(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	if NewIdempotencyCache(0, time.Hour) != nil || NewIdempotencyCache(10, 0) != nil {
+		t.Error("Expected nil cache for non-positive bounds")
+	}
+
+	next := &countingHandler{status: http.StatusOK}
+	handler := newIdempotentHandler(nil, next)
+
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+	serveIdempotent(handler, "ci", "/api/v1/lineage", "key-1", "events")
+
+	if next.calls != 2 {
+		t.Errorf("Expected both requests processed, handler ran %d times", next.calls)
+	}
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const (
+	// ImpersonatePluginHeader names the plugin (API key client ID) an admin request acts as.
+	ImpersonatePluginHeader = "X-Impersonate-Plugin"
+
+	// TraceStageImpersonation is recorded when a request is impersonated or refused impersonation.
+	TraceStageImpersonation = "impersonation"
+
+	// impersonationPermission is the permission a key needs to impersonate plugins.
+	impersonationPermission = "admin"
+)
+
+// Impersonate returns middleware that lets an authenticated admin request act as another plugin,
+// so support engineers can reproduce a plugin's behavior (rate limit bucket, permissions, ingested
+// by) without its key. Must run after Authenticate.
+//
+// A request with X-Impersonate-Plugin: <pluginID> whose key has the admin permission continues
+// with the ClientContext of that plugin: its client ID and the permissions of its active,
+// unexpired keys. KeyID stays the admin's key and ImpersonatedBy records the admin's client ID;
+// every impersonated request is logged as an audit entry.
+//
+// The header is never ignored on an authenticated request: it is refused with 403 when enabled is
+// false or the key lacks the admin permission, and with 400 for a plugin without active keys.
+// Requests without the header, unauthenticated requests, and public endpoints pass unchanged.
+func Impersonate(store storage.APIKeyStore, enabled bool, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			plugin := strings.TrimSpace(r.Header.Get(ImpersonatePluginHeader))
+
+			admin, authenticated := GetClientContext(r.Context())
+			if plugin == "" || !authenticated || publicEndpoints[r.URL.Path] {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			var refusal string
+
+			switch {
+			case !enabled:
+				refusal = "Plugin impersonation is disabled on this server"
+			case !slices.Contains(admin.Permissions, impersonationPermission):
+				refusal = "API key lacks required permission: " + impersonationPermission
+			}
+
+			if refusal != "" {
+				refuseImpersonation(w, r, logger, http.StatusForbidden, refusal, admin, plugin)
+
+				return
+			}
+
+			impersonated, found, err := impersonatedContext(r, store, admin, plugin)
+			if err != nil {
+				logger.Error("failed to look up impersonated plugin",
+					slog.String("correlation_id", GetCorrelationID(r.Context())),
+					slog.String("plugin", plugin),
+					slog.String("error", err.Error()),
+				)
+				refuseImpersonation(w, r, logger, http.StatusServiceUnavailable,
+					"Failed to look up impersonated plugin", admin, plugin)
+
+				return
+			}
+
+			if !found {
+				refuseImpersonation(w, r, logger, http.StatusBadRequest,
+					"Unknown plugin (no active API key): "+plugin, admin, plugin)
+
+				return
+			}
+
+			RecordTrace(r.Context(), TraceStageImpersonation,
+				"client_id="+admin.ClientID+" impersonating client_id="+plugin)
+
+			logger.Info("audit: API key impersonating plugin",
+				slog.String("audit", "impersonation"),
+				slog.String("admin_client_id", admin.ClientID),
+				slog.String("admin_key_id", admin.KeyID),
+				slog.String("plugin", plugin),
+				slog.String("method", r.Method),
+				slog.String("endpoint", r.URL.Path),
+				slog.String("correlation_id", GetCorrelationID(r.Context())),
+				slog.String("client_ip", ClientIP(r)),
+			)
+
+			next.ServeHTTP(w, r.WithContext(SetClientContext(r.Context(), impersonated)))
+		})
+	}
+}
+
+// impersonatedContext returns the ClientContext of plugin as seen by admin. Reports false when
+// the plugin has no active, unexpired key.
+func impersonatedContext(
+	r *http.Request,
+	store storage.APIKeyStore,
+	admin ClientContext,
+	plugin string,
+) (ClientContext, bool, error) {
+	keys, err := store.ListByClientID(r.Context(), plugin)
+	if err != nil {
+		return ClientContext{}, false, err
+	}
+
+	now := time.Now()
+
+	var (
+		name        string
+		permissions []string
+	)
+
+	for _, key := range keys {
+		if !key.Active || (key.ExpiresAt != nil && !key.ExpiresAt.After(now)) {
+			continue
+		}
+
+		if name == "" {
+			name = key.Name
+		}
+
+		for _, permission := range key.Permissions {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	if name == "" {
+		return ClientContext{}, false, nil
+	}
+
+	return ClientContext{
+		ClientID:       plugin,
+		Name:           name,
+		Permissions:    permissions,
+		KeyID:          admin.KeyID,
+		KeyExpiresAt:   admin.KeyExpiresAt,
+		AuthTime:       admin.AuthTime,
+		ImpersonatedBy: admin.ClientID,
+	}, true, nil
+}
+
+// refuseImpersonation writes an RFC 7807 error for a refused impersonation and logs it.
+func refuseImpersonation(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *slog.Logger,
+	status int,
+	detail string,
+	admin ClientContext,
+	plugin string,
+) {
+	correlationID := GetCorrelationID(r.Context())
+
+	RecordTrace(r.Context(), TraceStageImpersonation, "rejected: "+detail)
+
+	logger.Warn("audit: plugin impersonation refused",
+		slog.String("audit", "impersonation"),
+		slog.String("client_id", admin.ClientID),
+		slog.String("key_id", admin.KeyID),
+		slog.String("plugin", plugin),
+		slog.String("reason", detail),
+		slog.String("endpoint", r.URL.Path),
+		slog.String("correlation_id", correlationID),
+	)
+
+	if err := writeRFC7807Error(w, r, status, detail, correlationID); err != nil {
+		logger.Error("failed to write response with RFC 7807 error format",
+			slog.String("correlation_id", correlationID),
+			slog.String("path", r.URL.Path),
+			slog.String("detail", detail),
+			slog.String("error", err.Error()),
+		)
+
+		http.Error(w, detail, status)
+	}
+}
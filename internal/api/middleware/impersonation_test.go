@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// impersonationTestStore returns a key store with an admin key, a plain lineage key, and two keys
+// of the dbt plugin, and the plaintext admin and lineage keys.
+func impersonationTestStore(t *testing.T) (storage.APIKeyStore, string, string) {
+	t.Helper()
+
+	store := storage.NewInMemoryKeyStore()
+
+	add := func(id, clientID string, permissions ...string) string {
+		key, err := storage.GenerateAPIKey()
+		if err != nil {
+			t.Fatalf("Failed to generate key: %v", err)
+		}
+
+		err = store.Add(context.Background(), &storage.APIKey{
+			ID:          id,
+			Key:         key,
+			ClientID:    clientID,
+			Name:        id,
+			Permissions: permissions,
+			CreatedAt:   time.Now(),
+			Active:      true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to add key: %v", err)
+		}
+
+		return key
+	}
+
+	adminKey := add("admin-key", "support", "admin", "lineage:read")
+	lineageKey := add("airflow-key", "airflow", "lineage:write")
+	add("dbt-write", "dbt", "lineage:write")
+	add("dbt-read", "dbt", "lineage:read", "lineage:write")
+
+	return store, adminKey, lineageKey
+}
+
+// impersonationRequest serves a request with the given key and X-Impersonate-Plugin through
+// authentication and impersonation, and returns the response and the ClientContext the handler saw.
+func impersonationRequest(
+	store storage.APIKeyStore,
+	enabled bool,
+	key, plugin string,
+) (*httptest.ResponseRecorder, ClientContext) {
+	var seen ClientContext
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = GetClientContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}
+
+	chain := Apply(http.HandlerFunc(handler),
+		WithCorrelationID(),
+		WithAuth(store, slog.Default()),
+		WithImpersonation(store, enabled, slog.Default()),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage", nil)
+	req.Header.Set("Authorization", "Bearer "+key)
+
+	if plugin != "" {
+		req.Header.Set(ImpersonatePluginHeader, plugin)
+	}
+
+	rr := httptest.NewRecorder()
+	chain.ServeHTTP(rr, req)
+
+	return rr, seen
+}
+
+// TestImpersonate_AdminAllowed verifies that an admin key acts as the impersonated plugin, with
+// the permissions of its keys, while the admin's key ID stays on record.
+func TestImpersonate_AdminAllowed(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store, adminKey, _ := impersonationTestStore(t)
+
+	rr, seen := impersonationRequest(store, true, adminKey, "dbt")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for admin impersonation, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if seen.ClientID != "dbt" {
+		t.Errorf("Expected client ID dbt, got %q", seen.ClientID)
+	}
+
+	if seen.ImpersonatedBy != "support" {
+		t.Errorf("Expected ImpersonatedBy support, got %q", seen.ImpersonatedBy)
+	}
+
+	if seen.KeyID != "admin-key" {
+		t.Errorf("Expected the admin key ID to stay on record, got %q", seen.KeyID)
+	}
+
+	slices.Sort(seen.Permissions)
+
+	if want := []string{"lineage:read", "lineage:write"}; !slices.Equal(seen.Permissions, want) {
+		t.Errorf("Expected permissions %v of the plugin's keys, got %v", want, seen.Permissions)
+	}
+
+	if slices.Contains(seen.Permissions, "admin") {
+		t.Error("Impersonated request must not keep the admin permission")
+	}
+}
+
+// TestImpersonate_Refused verifies that the header is refused rather than ignored when it cannot
+// be honored, and that requests without it are untouched.
+func TestImpersonate_Refused(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store, adminKey, lineageKey := impersonationTestStore(t)
+
+	tests := []struct {
+		name    string
+		enabled bool
+		key     string
+		plugin  string
+		want    int
+	}{
+		{name: "non-admin key", enabled: true, key: lineageKey, plugin: "dbt", want: http.StatusForbidden},
+		{name: "impersonation disabled", enabled: false, key: adminKey, plugin: "dbt", want: http.StatusForbidden},
+		{name: "unknown plugin", enabled: true, key: adminKey, plugin: "spark", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, seen := impersonationRequest(store, tt.enabled, tt.key, tt.plugin)
+			if rr.Code != tt.want {
+				t.Fatalf("Expected %d, got %d: %s", tt.want, rr.Code, rr.Body.String())
+			}
+
+			if got := rr.Header().Get("Content-Type"); got != contentTypeProblemJSON {
+				t.Errorf("Expected %s, got %q", contentTypeProblemJSON, got)
+			}
+
+			if seen.ClientID != "" {
+				t.Errorf("Refused request must not reach the handler, got client %q", seen.ClientID)
+			}
+		})
+	}
+
+	t.Run("no header", func(t *testing.T) {
+		rr, seen := impersonationRequest(store, false, lineageKey, "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected 200 without the header, got %d", rr.Code)
+		}
+
+		if seen.ClientID != "airflow" || seen.ImpersonatedBy != "" {
+			t.Errorf("Expected the key's own client, got %+v", seen)
+		}
+	})
+}
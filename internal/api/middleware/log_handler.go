@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+)
+
+// correlationIDAttr is the log attribute key of the correlation ID.
+const correlationIDAttr = "correlation_id"
+
+// ContextHandler is a slog.Handler that adds the request's correlation ID to every record logged
+// with a request context (logger.InfoContext(ctx, ...) and friends), so handlers do not have to
+// pass slog.String("correlation_id", ...) themselves.
+//
+// Records already carrying a correlation_id attribute (passed explicitly or through
+// logger.With) are left as they are, so a log line never has the key twice. Records logged
+// without a context, or with one outside a request, get no attribute.
+type ContextHandler struct {
+	next slog.Handler
+
+	// hasCorrelationID is set once WithAttrs added a correlation_id to every record.
+	hasCorrelationID bool
+}
+
+// NewContextHandler wraps next with correlation ID injection.
+func NewContextHandler(next slog.Handler) *ContextHandler {
+	return &ContextHandler{next: next}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the correlation ID of ctx to r, unless r already has one, and passes it on.
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.hasCorrelationID {
+		return h.next.Handle(ctx, r)
+	}
+
+	correlationID, ok := ctx.Value(correlationIDKey{}).(string)
+	if !ok || recordHasAttr(r, correlationIDAttr) {
+		return h.next.Handle(ctx, r)
+	}
+
+	r = r.Clone() // Never append to attributes shared with the caller's record
+	r.AddAttrs(slog.String(correlationIDAttr, correlationID))
+
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler whose records carry attrs.
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	hasCorrelationID := h.hasCorrelationID
+
+	for _, attr := range attrs {
+		if attr.Key == correlationIDAttr {
+			hasCorrelationID = true
+		}
+	}
+
+	return &ContextHandler{next: h.next.WithAttrs(attrs), hasCorrelationID: hasCorrelationID}
+}
+
+// WithGroup returns a handler that nests later attributes, including the correlation ID, in group name.
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{next: h.next.WithGroup(name), hasCorrelationID: h.hasCorrelationID}
+}
+
+// recordHasAttr reports whether r has a top-level attribute named key.
+func recordHasAttr(r slog.Record, key string) bool {
+	found := false
+
+	r.Attrs(func(attr slog.Attr) bool {
+		found = attr.Key == key
+
+		return !found
+	})
+
+	return found
+}
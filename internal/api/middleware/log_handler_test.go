@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// logRequest serves a request with X-Correlation-ID through the CorrelationID middleware to a
+// handler that logs with the request context, and returns the log lines.
+func logRequest(t *testing.T, correlationID string, log func(ctx context.Context, logger *slog.Logger)) []string {
+	t.Helper()
+
+	var logs bytes.Buffer
+
+	logger := slog.New(NewContextHandler(slog.NewJSONHandler(&logs, nil)))
+
+	handler := CorrelationID()(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		log(r.Context(), logger)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/incidents", nil)
+	req.Header.Set("X-Correlation-ID", correlationID)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	return strings.Split(strings.TrimSpace(logs.String()), "\n")
+}
+
+// decodeLogLine decodes a JSON log line into its attributes.
+func decodeLogLine(t *testing.T, line string) map[string]any {
+	t.Helper()
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("Failed to decode log line %q: %v", line, err)
+	}
+
+	return entry
+}
+
+func TestContextHandler_AddsCorrelationID(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	lines := logRequest(t, "abc123def456", func(ctx context.Context, logger *slog.Logger) {
+		logger.InfoContext(ctx, "Incidents listed", slog.Int("count", 3))
+		logger.With(slog.String("component", "incidents")).ErrorContext(ctx, "Query failed")
+	})
+
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %v", len(lines), lines)
+	}
+
+	for _, line := range lines {
+		if got := decodeLogLine(t, line)["correlation_id"]; got != "abc123def456" {
+			t.Errorf("Expected correlation_id abc123def456, got %v in %s", got, line)
+		}
+	}
+}
+
+func TestContextHandler_ExplicitCorrelationIDNotDuplicated(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	lines := logRequest(t, "abc123def456", func(ctx context.Context, logger *slog.Logger) {
+		logger.InfoContext(ctx, "Explicit", slog.String("correlation_id", GetCorrelationID(ctx)))
+		logger.With(slog.String("correlation_id", "abc123def456")).InfoContext(ctx, "Explicit via With")
+	})
+
+	for _, line := range lines {
+		if n := strings.Count(line, `"correlation_id"`); n != 1 {
+			t.Errorf("Expected correlation_id once, got %d times in %s", n, line)
+		}
+	}
+}
+
+func TestContextHandler_NoRequestContext(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	var logs bytes.Buffer
+
+	logger := slog.New(NewContextHandler(slog.NewJSONHandler(&logs, nil)))
+	logger.InfoContext(context.Background(), "Background work")
+	logger.Info("No context")
+
+	if strings.Contains(logs.String(), "correlation_id") {
+		t.Errorf("Expected no correlation_id outside a request, got %s", logs.String())
+	}
+}
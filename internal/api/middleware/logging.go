@@ -4,11 +4,22 @@ package middleware
 import (
 	"log/slog"
 	"net/http"
+	"sync/atomic"
 	"time"
 )
 
+// defaultLogSampleRate logs every request.
+const defaultLogSampleRate = 1
+
 // RequestLogger creates a middleware that logs HTTP requests with structured logging.
-func RequestLogger(logger *slog.Logger) func(http.Handler) http.Handler {
+//
+// At high request rates, sampleRate reduces log volume: only every sampleRate-th request is
+// logged in full (started + completed). Requests that end with a 4xx or 5xx status are always
+// logged (their completion line), so error visibility is unaffected. sampleRate <= 1 logs
+// every request.
+func RequestLogger(logger *slog.Logger, sampleRate int) func(http.Handler) http.Handler {
+	var requests atomic.Uint64
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -19,18 +30,28 @@ func RequestLogger(logger *slog.Logger) func(http.Handler) http.Handler {
 			// Create a response writer wrapper to capture status code
 			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
+			// The status is unknown until the handler returns, so the decision covers successes only
+			sampled := sampleRate <= 1 || requests.Add(1)%uint64(sampleRate) == 1
+
 			// Log request start
-			logger.Info("HTTP request started",
-				slog.String("method", r.Method),
-				slog.String("path", r.URL.Path),
-				slog.String("remote_addr", r.RemoteAddr),
-				slog.String("user_agent", r.UserAgent()),
-				slog.String("correlation_id", correlationID),
-			)
+			if sampled {
+				logger.Info("HTTP request started",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.String("remote_addr", r.RemoteAddr),
+					slog.String("client_ip", ClientIP(r)),
+					slog.String("user_agent", r.UserAgent()),
+					slog.String("correlation_id", correlationID),
+				)
+			}
 
 			// Process request
 			next.ServeHTTP(rw, r)
 
+			if !sampled && rw.statusCode < http.StatusBadRequest {
+				return
+			}
+
 			// Calculate duration
 			duration := time.Since(start)
 
@@ -57,3 +78,9 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap exposes the underlying writer so http.ResponseController can reach Flush and
+// SetWriteDeadline (needed by streaming responses).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,122 @@
+// Package middleware provides HTTP middleware components for the Correlator API.
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// countCompletedLogs counts "HTTP request completed" lines per status code in JSON log output.
+func countCompletedLogs(t *testing.T, logs *bytes.Buffer) map[int]int {
+	t.Helper()
+
+	counts := make(map[int]int)
+	decoder := json.NewDecoder(logs)
+
+	for decoder.More() {
+		var entry struct {
+			Msg        string `json:"msg"`
+			StatusCode int    `json:"status_code"` //nolint:tagliatelle
+		}
+
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatalf("Failed to decode log line: %v", err)
+		}
+
+		if entry.Msg == "HTTP request completed" {
+			counts[entry.StatusCode]++
+		}
+	}
+
+	return counts
+}
+
+// TestRequestLogger_Sampling verifies that successful requests are logged at the configured
+// 1-in-N rate while 4xx and 5xx responses are never dropped.
+func TestRequestLogger_Sampling(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	const (
+		sampleRate = 10
+		requests   = 1000
+	)
+
+	var logs bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	// Every 7th request fails with 500, every 11th with 404, the rest succeed
+	handler := RequestLogger(logger, sampleRate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("status") {
+		case "500":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "404":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+
+	want := make(map[int]int)
+
+	for i := range requests {
+		target := "/api/v1/lineage"
+
+		switch {
+		case i%7 == 0:
+			target += "?status=500"
+			want[http.StatusInternalServerError]++
+		case i%11 == 0:
+			target += "?status=404"
+			want[http.StatusNotFound]++
+		default:
+			want[http.StatusOK]++
+		}
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	got := countCompletedLogs(t, &logs)
+
+	for _, status := range []int{http.StatusInternalServerError, http.StatusNotFound} {
+		if got[status] != want[status] {
+			t.Errorf("Expected all %d responses with status %d logged, got %d", want[status], status, got[status])
+		}
+	}
+
+	// Sampling counts all requests, so roughly 1 in sampleRate of the successes is logged
+	expected := want[http.StatusOK] / sampleRate
+	if got[http.StatusOK] < expected*8/10 || got[http.StatusOK] > expected*12/10 {
+		t.Errorf("Expected about %d of %d successful requests logged, got %d",
+			expected, want[http.StatusOK], got[http.StatusOK])
+	}
+}
+
+// TestRequestLogger_NoSampling verifies that a sample rate of 1 or less logs every request.
+func TestRequestLogger_NoSampling(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	for _, sampleRate := range []int{0, 1} {
+		var logs bytes.Buffer
+
+		handler := RequestLogger(slog.New(slog.NewJSONHandler(&logs, nil)), sampleRate)(
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }),
+		)
+
+		for range 5 {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ping", nil))
+		}
+
+		if got := countCompletedLogs(t, &logs)[http.StatusOK]; got != 5 {
+			t.Errorf("sampleRate %d: expected 5 requests logged, got %d", sampleRate, got)
+		}
+	}
+}
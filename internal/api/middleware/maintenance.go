@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TraceStageMaintenance is recorded when a request is rejected by maintenance mode.
+const TraceStageMaintenance = "maintenance"
+
+// MaintenanceMode is a runtime switch that rejects business requests with 503 while enabled,
+// e.g. during planned database maintenance. Public endpoints (health probes) always pass, so
+// load balancers keep the instance in rotation and the process keeps running.
+//
+// It also counts in-flight business requests, so Enable can wait for them to drain before
+// the maintenance is considered in full effect. Safe for concurrent use.
+type MaintenanceMode struct {
+	retryAfter time.Duration
+
+	mu       sync.Mutex
+	enabled  bool
+	inFlight int
+	drained  chan struct{} // Closed when inFlight reaches 0; nil when nobody is waiting
+}
+
+// NewMaintenanceMode creates a disabled maintenance mode. retryAfter is sent as the
+// Retry-After header of rejected requests (rounded up to whole seconds, at least 1).
+func NewMaintenanceMode(retryAfter time.Duration) *MaintenanceMode {
+	return &MaintenanceMode{retryAfter: retryAfter}
+}
+
+// Enabled reports whether new business requests are being rejected.
+func (m *MaintenanceMode) Enabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.enabled
+}
+
+// Enable starts rejecting new business requests immediately, then blocks until the requests
+// already in flight have completed. Returns ctx.Err() if ctx ends first; maintenance mode
+// stays enabled either way. Long-lived streams (SSE) count as in flight, so bound ctx.
+func (m *MaintenanceMode) Enable(ctx context.Context) error {
+	m.mu.Lock()
+	m.enabled = true
+
+	if m.inFlight == 0 {
+		m.mu.Unlock()
+
+		return nil
+	}
+
+	if m.drained == nil {
+		m.drained = make(chan struct{})
+	}
+
+	drained, inFlight := m.drained, m.inFlight
+	m.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for %d in-flight requests: %w", inFlight, ctx.Err())
+	}
+}
+
+// Disable stops rejecting business requests.
+func (m *MaintenanceMode) Disable() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.enabled = false
+}
+
+// begin admits a business request unless maintenance mode is enabled.
+func (m *MaintenanceMode) begin() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.enabled {
+		return false
+	}
+
+	m.inFlight++
+
+	return true
+}
+
+// end marks an admitted request as completed and wakes Enable when the last one finishes.
+func (m *MaintenanceMode) end() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight--
+
+	if m.inFlight == 0 && m.drained != nil {
+		close(m.drained)
+		m.drained = nil
+	}
+}
+
+// retryAfterSeconds returns the Retry-After header value.
+func (m *MaintenanceMode) retryAfterSeconds() string {
+	return retryAfterSeconds(m.retryAfter)
+}
+
+// Maintenance returns middleware that rejects requests with an RFC 7807 503 and a Retry-After
+// header while mode is enabled. Public endpoints (see RegisterPublicEndpoint) always pass.
+func Maintenance(mode *MaintenanceMode, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if publicEndpoints[r.URL.Path] {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if !mode.begin() {
+				correlationID := GetCorrelationID(r.Context())
+
+				RecordTrace(r.Context(), TraceStageMaintenance, "rejected")
+
+				w.Header().Set("Retry-After", mode.retryAfterSeconds())
+
+				detail := "Service is in maintenance mode. Please retry later."
+				if err := writeRFC7807Error(w, r, http.StatusServiceUnavailable, detail, correlationID); err != nil {
+					logger.Error("failed to write response with RFC 7807 error format",
+						slog.String("correlation_id", correlationID),
+						slog.String("path", r.URL.Path),
+						slog.String("detail", detail),
+						slog.String("error", err.Error()),
+					)
+
+					http.Error(w, detail, http.StatusServiceUnavailable)
+				}
+
+				return
+			}
+
+			defer mode.end()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
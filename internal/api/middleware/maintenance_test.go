@@ -0,0 +1,159 @@
+// Package middleware provides HTTP middleware components for the Correlator API.
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// serveMaintenance runs one GET request for path through the Maintenance middleware.
+func serveMaintenance(mode *MaintenanceMode, path string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	chain := Apply(handler, WithCorrelationID(), WithMaintenance(mode, slog.Default()))
+	chain.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return rr
+}
+
+// TestMaintenance_RejectsBusinessRequests verifies that an enabled maintenance mode returns an
+// RFC 7807 503 with Retry-After for business endpoints while public endpoints pass.
+func TestMaintenance_RejectsBusinessRequests(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	RegisterPublicEndpoint("/ping")
+
+	mode := NewMaintenanceMode(90 * time.Second)
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	if rr := serveMaintenance(mode, "/api/v1/lineage", ok); rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200 while maintenance mode is disabled, got %d", rr.Code)
+	}
+
+	if err := mode.Enable(context.Background()); err != nil {
+		t.Fatalf("Enable with no in-flight requests: %v", err)
+	}
+
+	rr := serveMaintenance(mode, "/api/v1/lineage", ok)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 in maintenance mode, got %d", rr.Code)
+	}
+
+	if got := rr.Header().Get("Retry-After"); got != "90" {
+		t.Errorf("Expected Retry-After 90, got %q", got)
+	}
+
+	if got := rr.Header().Get("Content-Type"); got != contentTypeProblemJSON {
+		t.Errorf("Expected %s, got %q", contentTypeProblemJSON, got)
+	}
+
+	var problem map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to parse problem: %v", err)
+	}
+
+	if problem["title"] != "Service Unavailable" {
+		t.Errorf("Expected title Service Unavailable, got %v", problem["title"])
+	}
+
+	if rr := serveMaintenance(mode, "/ping", ok); rr.Code != http.StatusOK {
+		t.Errorf("Expected public endpoint to pass in maintenance mode, got %d", rr.Code)
+	}
+
+	mode.Disable()
+
+	if rr := serveMaintenance(mode, "/api/v1/lineage", ok); rr.Code != http.StatusOK {
+		t.Errorf("Expected 200 after Disable, got %d", rr.Code)
+	}
+}
+
+// TestMaintenance_EnableWaitsForInFlight verifies that Enable rejects new requests at once but
+// only returns after requests that were already running have completed.
+func TestMaintenance_EnableWaitsForInFlight(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	mode := NewMaintenanceMode(time.Second)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	finished := make(chan int)
+
+	go func() {
+		rr := serveMaintenance(mode, "/api/v1/lineage/batch", func(w http.ResponseWriter, _ *http.Request) {
+			close(started)
+			<-release
+			w.WriteHeader(http.StatusOK)
+		})
+		finished <- rr.Code
+	}()
+
+	<-started
+
+	enabled := make(chan error)
+
+	go func() { enabled <- mode.Enable(context.Background()) }()
+
+	// Wait until Enable has switched the mode on, then check new requests are rejected
+	for !mode.Enabled() {
+		time.Sleep(time.Millisecond)
+	}
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+	if rr := serveMaintenance(mode, "/api/v1/lineage", ok); rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected new request to be rejected while draining, got %d", rr.Code)
+	}
+
+	select {
+	case err := <-enabled:
+		t.Fatalf("Enable returned before the in-flight request completed: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+
+	if code := <-finished; code != http.StatusOK {
+		t.Errorf("Expected in-flight request to complete with 200, got %d", code)
+	}
+
+	if err := <-enabled; err != nil {
+		t.Errorf("Enable after drain: %v", err)
+	}
+}
+
+// TestMaintenance_EnableTimeout verifies that Enable gives up waiting when ctx ends but stays enabled.
+func TestMaintenance_EnableTimeout(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	mode := NewMaintenanceMode(0)
+	if !mode.begin() {
+		t.Fatal("Expected request to be admitted")
+	}
+
+	defer mode.end()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := mode.Enable(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	if !mode.Enabled() {
+		t.Error("Maintenance mode should stay enabled after a drain timeout")
+	}
+
+	if got := mode.retryAfterSeconds(); got != "1" {
+		t.Errorf("Expected Retry-After to be at least 1 second, got %q", got)
+	}
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPreAuthScopesHeader carries the permissions of a pre-authenticated request when
+	// PreAuthConfig.ScopesHeader is not set.
+	DefaultPreAuthScopesHeader = "X-Authenticated-Scopes"
+
+	// maxPreAuthIdentityLength bounds the identity header; longer values are not honored.
+	maxPreAuthIdentityLength = 512
+)
+
+// preAuthenticatedKey marks a request whose identity PreAuthenticate took from the trusted header.
+// Unexported, so nothing outside this package can make Authenticate skip the API key check.
+type preAuthenticatedKey struct{}
+
+// PreAuthConfig configures pre-authenticated requests (see PreAuthenticate).
+type PreAuthConfig struct {
+	// IdentityHeader names the header carrying the plugin identity set by the proxy that
+	// authenticated the request (e.g. an Envoy sidecar checking SPIFFE IDs). Empty = disabled.
+	IdentityHeader string
+
+	// ScopesHeader names the header carrying the granted permissions, comma- or space-separated
+	// (default DefaultPreAuthScopesHeader). Absent = no permissions.
+	ScopesHeader string
+
+	// TrustedSources are the CIDRs of the proxies allowed to set the headers, matched against
+	// the direct peer address. Empty = the headers are never honored.
+	TrustedSources []netip.Prefix
+}
+
+// PreAuthenticate returns middleware that trusts the identity a proxy already authenticated, for
+// internal traffic behind a service mesh: a request from a trusted source carrying
+// cfg.IdentityHeader continues with that plugin identity and the permissions of
+// cfg.ScopesHeader, and Authenticate does not check an API key for it. Must run before
+// Authenticate.
+//
+// The headers are only honored when the direct peer (RemoteAddr, never X-Forwarded-For) is in
+// cfg.TrustedSources, so clients cannot forge an identity by sending the header themselves. From
+// any other source they are ignored (and logged) and the request needs an API key as usual. The
+// trusted proxies must strip the headers from the requests they forward.
+func PreAuthenticate(cfg PreAuthConfig, logger *slog.Logger) func(http.Handler) http.Handler {
+	if cfg.ScopesHeader == "" {
+		cfg.ScopesHeader = DefaultPreAuthScopesHeader
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity := r.Header.Get(cfg.IdentityHeader)
+			if identity == "" || publicEndpoints[r.URL.Path] {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			peer := remoteHost(r.RemoteAddr)
+
+			peerAddr, err := netip.ParseAddr(peer)
+			if err != nil || !isTrusted(peerAddr, cfg.TrustedSources) {
+				logger.Warn("Ignoring pre-authentication header from untrusted source",
+					slog.String("header", cfg.IdentityHeader),
+					slog.String("remote_addr", r.RemoteAddr),
+					slog.String("correlation_id", GetCorrelationID(r.Context())),
+					slog.String("endpoint", r.URL.Path),
+				)
+
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			identity, ok := validatePreAuthIdentity(identity)
+			if !ok {
+				logger.Warn("Ignoring invalid pre-authenticated identity",
+					slog.String("header", cfg.IdentityHeader),
+					slog.String("remote_addr", r.RemoteAddr),
+					slog.String("correlation_id", GetCorrelationID(r.Context())),
+				)
+
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			clientCtx := ClientContext{
+				ClientID:         identity,
+				Name:             identity,
+				Permissions:      parseScopes(r.Header.Values(cfg.ScopesHeader)),
+				AuthTime:         time.Now(),
+				PreAuthenticated: true,
+			}
+
+			ctx := context.WithValue(SetClientContext(r.Context(), clientCtx), preAuthenticatedKey{}, true)
+
+			RecordTrace(ctx, TraceStageAuth, "pre-authenticated client_id="+identity)
+
+			logger.Info("Request pre-authenticated by trusted proxy",
+				slog.String("client_id", identity),
+				slog.Any("permissions", clientCtx.Permissions),
+				slog.String("remote_addr", r.RemoteAddr),
+				slog.String("correlation_id", GetCorrelationID(r.Context())),
+				slog.String("endpoint", r.URL.Path),
+			)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// isPreAuthenticated reports whether PreAuthenticate set the client of ctx.
+func isPreAuthenticated(ctx context.Context) bool {
+	preAuthenticated, _ := ctx.Value(preAuthenticatedKey{}).(bool)
+
+	return preAuthenticated
+}
+
+// validatePreAuthIdentity trims an identity header value. Rejects empty values, values longer
+// than maxPreAuthIdentityLength, and values with control characters (header injection).
+func validatePreAuthIdentity(identity string) (string, bool) {
+	identity = strings.TrimSpace(identity)
+	if identity == "" || len(identity) > maxPreAuthIdentityLength {
+		return "", false
+	}
+
+	for _, c := range identity {
+		if c < ' ' || c == 0x7f {
+			return "", false
+		}
+	}
+
+	return identity, true
+}
+
+// parseScopes splits scopes header values on commas and whitespace, dropping duplicates.
+func parseScopes(values []string) []string {
+	var scopes []string
+
+	for _, value := range values {
+		for _, scope := range strings.FieldsFunc(value, func(c rune) bool { return c == ',' || c == ' ' || c == '\t' }) {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	return scopes
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const testPreAuthHeader = "X-Authenticated-Plugin"
+
+// preAuthRequest serves a request from remoteAddr with the given headers through pre-authentication
+// (trusting 10.0.0.0/8) and authentication, and returns the response and the ClientContext the
+// handler saw.
+func preAuthRequest(
+	t *testing.T,
+	store storage.APIKeyStore,
+	remoteAddr string,
+	headers map[string]string,
+) (*httptest.ResponseRecorder, ClientContext) {
+	t.Helper()
+
+	sources, err := ParseTrustedProxies([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() error = %v", err)
+	}
+
+	var seen ClientContext
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = GetClientContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}
+
+	chain := Apply(http.HandlerFunc(handler),
+		WithCorrelationID(),
+		WithPreAuth(store, PreAuthConfig{IdentityHeader: testPreAuthHeader, TrustedSources: sources},
+			slog.New(slog.DiscardHandler)),
+		WithAuth(store, slog.New(slog.DiscardHandler)),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage", nil)
+	req.RemoteAddr = remoteAddr
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	rr := httptest.NewRecorder()
+	chain.ServeHTTP(rr, req)
+
+	return rr, seen
+}
+
+// preAuthTestStore returns a key store with one lineage:write key of the airflow plugin, and the
+// plaintext key.
+func preAuthTestStore(t *testing.T) (storage.APIKeyStore, string) {
+	t.Helper()
+
+	key, err := storage.GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	store := storage.NewInMemoryKeyStore()
+
+	err = store.Add(context.Background(), &storage.APIKey{
+		ID:          "airflow-key",
+		Key:         key,
+		ClientID:    "airflow",
+		Name:        "airflow",
+		Permissions: []string{"lineage:write"},
+		CreatedAt:   time.Now(),
+		Active:      true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to add key: %v", err)
+	}
+
+	return store, key
+}
+
+// TestPreAuthenticate_TrustedSourceHonored verifies that a request from a trusted source is
+// identified by the header, with the scopes of the scopes header, without an API key.
+func TestPreAuthenticate_TrustedSourceHonored(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store, _ := preAuthTestStore(t)
+
+	rr, seen := preAuthRequest(t, store, "10.1.2.3:41000", map[string]string{
+		testPreAuthHeader:          "spiffe://prod.internal/ns/data/sa/dbt",
+		DefaultPreAuthScopesHeader: "lineage:write, lineage:read lineage:write",
+	})
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if seen.ClientID != "spiffe://prod.internal/ns/data/sa/dbt" {
+		t.Errorf("Expected ClientID from the header, got %q", seen.ClientID)
+	}
+
+	if want := []string{"lineage:write", "lineage:read"}; !slices.Equal(seen.Permissions, want) {
+		t.Errorf("Expected permissions %v, got %v", want, seen.Permissions)
+	}
+
+	if !seen.PreAuthenticated || seen.KeyID != "" {
+		t.Errorf("Expected a pre-authenticated context without key, got %+v", seen)
+	}
+}
+
+// TestPreAuthenticate_UntrustedSourceFallsBackToKeyAuth verifies that the header is ignored from
+// any other source, including one claiming a trusted address in X-Forwarded-For, and that the
+// request then needs a valid API key.
+func TestPreAuthenticate_UntrustedSourceFallsBackToKeyAuth(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store, key := preAuthTestStore(t)
+
+	forged := map[string]string{
+		testPreAuthHeader:          "admin-plugin",
+		DefaultPreAuthScopesHeader: "admin",
+		"X-Forwarded-For":          "10.1.2.3",
+	}
+
+	rr, _ := preAuthRequest(t, store, "203.0.113.7:52000", forged)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without API key, got %d", rr.Code)
+	}
+
+	forged["Authorization"] = "Bearer " + key
+
+	rr, seen := preAuthRequest(t, store, "203.0.113.7:52000", forged)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 with API key, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if seen.ClientID != "airflow" || seen.PreAuthenticated {
+		t.Errorf("Expected the API key's client, got %+v", seen)
+	}
+
+	if !slices.Equal(seen.Permissions, []string{"lineage:write"}) {
+		t.Errorf("Expected the API key's permissions, got %v", seen.Permissions)
+	}
+
+	// An invalid identity from a trusted source is ignored as well
+	rr, _ = preAuthRequest(t, store, "10.1.2.3:41000", map[string]string{testPreAuthHeader: "dbt\x00admin"})
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for an invalid identity, got %d", rr.Code)
+	}
+}
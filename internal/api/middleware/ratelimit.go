@@ -3,7 +3,9 @@ package middleware
 
 import (
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -39,6 +41,22 @@ type (
 		Allow(clientID string) bool
 	}
 
+	// RateLimitReporter is implemented by rate limiters that can report the state of the bucket
+	// a client's requests are checked against. RateLimit emits X-RateLimit-* headers only for
+	// limiters implementing it.
+	RateLimitReporter interface {
+		// Status returns the current state of clientID's bucket (the unauthenticated bucket for
+		// an empty clientID).
+		Status(clientID string) RateLimitStatus
+	}
+
+	// RateLimitStatus is the state of a rate limit bucket.
+	RateLimitStatus struct {
+		Limit     int           // Bucket capacity (burst)
+		Remaining int           // Whole requests left before the bucket is empty
+		Reset     time.Duration // Time until the bucket is full again (0 = full)
+	}
+
 	// InMemoryRateLimiter implements RateLimiter using golang.org/x/time/rate.
 	//
 	// Provides three-tier rate limiting:
@@ -50,7 +68,9 @@ type (
 	// Burst capacity allows temporary bursts above the sustained rate.
 	//
 	// Memory cleanup runs periodically to prevent unbounded growth.
-	// Clients idle longer than IdleTimeout are removed.
+	// Clients idle longer than IdleTimeout are removed. A client's last access time is
+	// refreshed while holding the map read lock, so cleanup (which takes the write lock)
+	// never reclaims a bucket that a concurrent request has just looked up.
 	//
 	// Suitable for single-node MVP deployments. For distributed systems,
 	// use RedisRateLimiter.
@@ -61,6 +81,8 @@ type (
 		mu              sync.RWMutex
 		cleanupTicker   *time.Ticker
 		done            chan struct{}
+		closeOnce       sync.Once
+		now             func() time.Time // Injectable clock for tests
 
 		// Configuration (stored for creating new client limiters and cleanup)
 		clientRPS       int
@@ -108,6 +130,7 @@ func NewInMemoryRateLimiter(config *Config) *InMemoryRateLimiter {
 		perClient:       make(map[string]*clientLimiter),
 		unauthenticated: rate.NewLimiter(rate.Limit(config.UnAuthRPS), unauthBurst),
 		done:            make(chan struct{}),
+		now:             time.Now,
 		clientRPS:       config.ClientRPS,
 		clientBurst:     clientBurst,
 		cleanupInterval: config.CleanupInterval,
@@ -168,9 +191,15 @@ func (rl *InMemoryRateLimiter) Allow(clientID string) bool {
 		return rl.unauthenticated.Allow()
 	}
 
-	// Authenticated request - get or create client limiter
+	// Authenticated request - get or create client limiter.
+	// lastAccess is refreshed under the read lock so cleanup cannot reclaim the bucket in between.
 	rl.mu.RLock()
 	cl, ok := rl.perClient[clientID]
+
+	if ok {
+		cl.touch(rl.now())
+	}
+
 	rl.mu.RUnlock()
 
 	if !ok {
@@ -180,7 +209,7 @@ func (rl *InMemoryRateLimiter) Allow(clientID string) bool {
 		if cl, ok = rl.perClient[clientID]; !ok {
 			cl = &clientLimiter{
 				limiter:    rate.NewLimiter(rate.Limit(rl.clientRPS), rl.clientBurst),
-				lastAccess: time.Now(),
+				lastAccess: rl.now(),
 			}
 
 			rl.perClient[clientID] = cl
@@ -198,22 +227,57 @@ func (rl *InMemoryRateLimiter) Allow(clientID string) bool {
 					"threshold_percent", thresholdPercentage,
 					"recommendation", "investigate potential client ID proliferation or increase max_clients limit")
 			}
+		} else {
+			cl.touch(rl.now()) // Created by a concurrent request
 		}
 
 		rl.mu.Unlock()
 	}
 
-	// Update last access time (for cleanup)
-	cl.mu.Lock()
-	cl.lastAccess = time.Now()
-	cl.mu.Unlock()
-
 	// Check client-specific limit
 	return cl.limiter.Allow()
 }
 
+// Status reports the state of clientID's bucket (the unauthenticated bucket for an empty
+// clientID). Implements the RateLimitReporter interface.
+//
+// The global bucket is shared by all clients and is not reported: a request rejected by the
+// global limit may still see requests remaining in its own bucket.
+func (rl *InMemoryRateLimiter) Status(clientID string) RateLimitStatus {
+	limiter := rl.unauthenticated
+
+	if clientID != "" {
+		rl.mu.RLock()
+		cl, ok := rl.perClient[clientID]
+		rl.mu.RUnlock()
+
+		if !ok {
+			// No request seen yet (or reclaimed): the client starts with a full bucket
+			return RateLimitStatus{Limit: rl.clientBurst, Remaining: rl.clientBurst}
+		}
+
+		limiter = cl.limiter
+	}
+
+	return bucketStatus(limiter, time.Now())
+}
+
+// bucketStatus reports the tokens left in limiter at now and how long it takes to refill.
+func bucketStatus(limiter *rate.Limiter, now time.Time) RateLimitStatus {
+	burst := limiter.Burst()
+	tokens := max(limiter.TokensAt(now), 0)
+
+	var reset time.Duration
+	if missing := float64(burst) - tokens; missing > 0 && limiter.Limit() > 0 {
+		reset = time.Duration(missing / float64(limiter.Limit()) * float64(time.Second))
+	}
+
+	return RateLimitStatus{Limit: burst, Remaining: int(tokens), Reset: reset}
+}
+
 // Close stops the cleanup goroutine and releases resources.
 // Must be called when the InMemoryRateLimiter is no longer needed.
+// This method is safe to call multiple times.
 //
 // Note: Close() is not part of the RateLimiter interface to allow
 // implementations that don't require cleanup (e.g., RedisRateLimiter
@@ -223,11 +287,13 @@ func (rl *InMemoryRateLimiter) Allow(clientID string) bool {
 //	    closer.Close()
 //	}
 func (rl *InMemoryRateLimiter) Close() {
-	if rl.cleanupTicker != nil {
-		rl.cleanupTicker.Stop()
-	}
+	rl.closeOnce.Do(func() {
+		if rl.cleanupTicker != nil {
+			rl.cleanupTicker.Stop()
+		}
 
-	close(rl.done)
+		close(rl.done)
+	})
 }
 
 // startCleanup starts a background goroutine that periodically removes
@@ -257,14 +323,16 @@ func (rl *InMemoryRateLimiter) startCleanup() {
 }
 
 // cleanup removes client limiters that haven't been accessed recently.
-func (rl *InMemoryRateLimiter) cleanup() {
+// Returns the number of reclaimed limiters.
+func (rl *InMemoryRateLimiter) cleanup() int {
 	// Use config value if set, otherwise use default
 	idleTimeout := rl.idleTimeout
 	if idleTimeout == 0 {
 		idleTimeout = rateLimiterIdleTimeout
 	}
 
-	now := time.Now()
+	now := rl.now()
+	reclaimed := 0
 
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
@@ -276,8 +344,27 @@ func (rl *InMemoryRateLimiter) cleanup() {
 
 		if now.Sub(lastAccess) > idleTimeout {
 			delete(rl.perClient, clientID)
+
+			reclaimed++
 		}
 	}
+
+	if reclaimed > 0 {
+		slog.Debug("rate limiter reclaimed idle client limiters",
+			"reclaimed", reclaimed,
+			"remaining_clients", len(rl.perClient),
+			"idle_timeout", idleTimeout,
+		)
+	}
+
+	return reclaimed
+}
+
+// touch records an access to the client limiter.
+func (cl *clientLimiter) touch(now time.Time) {
+	cl.mu.Lock()
+	cl.lastAccess = now
+	cl.mu.Unlock()
 }
 
 // RateLimit returns a middleware that enforces rate limits on incoming requests.
@@ -290,6 +377,13 @@ func (rl *InMemoryRateLimiter) cleanup() {
 // When a request exceeds the rate limit, the middleware returns a 429 (Too Many Requests)
 // response with RFC 7807 error format.
 //
+// When the limiter implements RateLimitReporter, every rate-limited response (allowed or 429)
+// carries the state of the client's bucket after the request, so clients can slow down before
+// they are rejected:
+//   - X-RateLimit-Limit: bucket capacity (burst)
+//   - X-RateLimit-Remaining: requests left
+//   - X-RateLimit-Reset: seconds until the bucket is full again (rounded up)
+//
 // The middleware must be placed after authentication middleware in the chain to access
 // ClientContext for per-client rate limiting.
 //
@@ -327,10 +421,18 @@ func RateLimit(limiter RateLimiter, logger *slog.Logger) func(http.Handler) http
 			}
 
 			// Check rate limit
-			if !limiter.Allow(clientID) {
+			allowed := limiter.Allow(clientID)
+
+			if reporter, ok := limiter.(RateLimitReporter); ok {
+				setRateLimitHeaders(w.Header(), reporter.Status(clientID))
+			}
+
+			if !allowed {
 				// Get correlation ID for error response
 				correlationID := GetCorrelationID(r.Context())
 
+				RecordTrace(r.Context(), TraceStageRateLimit, "rejected")
+
 				// Write RFC 7807 compliant error response
 				detail := "Rate limit exceeded. Please retry after some time."
 				if err := writeRFC7807Error(w, r, http.StatusTooManyRequests, detail, correlationID); err != nil {
@@ -353,3 +455,10 @@ func RateLimit(limiter RateLimiter, logger *slog.Logger) func(http.Handler) http
 		})
 	}
 }
+
+// setRateLimitHeaders writes status as X-RateLimit-* headers.
+func setRateLimitHeaders(header http.Header, status RateLimitStatus) {
+	header.Set("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	header.Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(status.Reset.Seconds()))))
+}
@@ -325,6 +325,80 @@ func TestRateLimiter_CleanupPreservesActiveClients(t *testing.T) {
 	}
 }
 
+// TestRateLimiter_ReclaimsIdlePluginsAfterTTL registers many short-lived plugins, advances
+// the limiter clock past the idle TTL, and verifies only idle buckets are reclaimed.
+func TestRateLimiter_ReclaimsIdlePluginsAfterTTL(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	const (
+		pluginCount = 1000
+		activeCount = 10
+	)
+
+	rl := NewInMemoryRateLimiter(&Config{
+		GlobalRPS:   pluginCount * 10,
+		ClientRPS:   50,
+		UnAuthRPS:   10,
+		IdleTimeout: time.Hour,
+		MaxClients:  pluginCount * 2,
+	})
+	defer rl.Close()
+
+	now := time.Now()
+	rl.now = func() time.Time { return now }
+
+	for i := range pluginCount {
+		if !rl.Allow(fmt.Sprintf("plugin-%d", i)) {
+			t.Fatalf("first request for plugin-%d should succeed", i)
+		}
+	}
+
+	// Advance to just before the TTL: nothing is reclaimed yet
+	now = now.Add(59 * time.Minute)
+
+	if reclaimed := rl.cleanup(); reclaimed != 0 {
+		t.Fatalf("cleanup() reclaimed %d before TTL, want 0", reclaimed)
+	}
+
+	// Keep a few plugins active, then advance past the TTL of the others
+	for i := range activeCount {
+		rl.Allow(fmt.Sprintf("plugin-%d", i))
+	}
+
+	now = now.Add(2 * time.Minute)
+
+	if reclaimed := rl.cleanup(); reclaimed != pluginCount-activeCount {
+		t.Errorf("cleanup() reclaimed %d, want %d", reclaimed, pluginCount-activeCount)
+	}
+
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	if len(rl.perClient) != activeCount {
+		t.Errorf("remaining clients = %d, want %d", len(rl.perClient), activeCount)
+	}
+
+	for i := range activeCount {
+		if _, ok := rl.perClient[fmt.Sprintf("plugin-%d", i)]; !ok {
+			t.Errorf("active plugin-%d should have been preserved", i)
+		}
+	}
+}
+
+// TestRateLimiter_CloseIsIdempotent verifies Close can be called more than once.
+func TestRateLimiter_CloseIsIdempotent(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	rl := NewInMemoryRateLimiter(&Config{GlobalRPS: 100, ClientRPS: 50, UnAuthRPS: 10})
+
+	rl.Close()
+	rl.Close()
+}
+
 // TestRateLimitMiddleware_RequestAllowed verifies that requests under
 // the rate limit are allowed to proceed to the next handler.
 func TestRateLimitMiddleware_RequestAllowed(t *testing.T) {
@@ -567,3 +641,85 @@ func TestRateLimitMiddleware_AuthenticatedVsUnauthenticated(t *testing.T) {
 		t.Errorf("11th authenticated request should be rate limited, got status %d", rec.Code)
 	}
 }
+
+// TestRateLimitMiddleware_RateLimitHeaders verifies that every response carries the client's
+// bucket state and that X-RateLimit-Remaining decrements across successive requests,
+// including on the 429 that empties it.
+func TestRateLimitMiddleware_RateLimitHeaders(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	rl := NewInMemoryRateLimiter(&Config{
+		GlobalRPS:   100,
+		ClientRPS:   1,
+		ClientBurst: 3,
+		UnAuthRPS:   10,
+	})
+	defer rl.Close()
+
+	handler := RateLimit(rl, slog.New(slog.DiscardHandler))(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req = req.WithContext(SetClientContext(req.Context(), ClientContext{ClientID: testClient}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	for i, want := range []struct {
+		code      int
+		remaining string
+		reset     string
+	}{
+		{http.StatusOK, "2", "1"},
+		{http.StatusOK, "1", "2"},
+		{http.StatusOK, "0", "3"},
+		{http.StatusTooManyRequests, "0", "3"},
+	} {
+		rec := serve()
+
+		if rec.Code != want.code {
+			t.Errorf("request %d: expected status %d, got %d", i+1, want.code, rec.Code)
+		}
+
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "3" {
+			t.Errorf("request %d: expected X-RateLimit-Limit 3, got %q", i+1, got)
+		}
+
+		if got := rec.Header().Get("X-RateLimit-Remaining"); got != want.remaining {
+			t.Errorf("request %d: expected X-RateLimit-Remaining %s, got %q", i+1, want.remaining, got)
+		}
+
+		if got := rec.Header().Get("X-RateLimit-Reset"); got != want.reset {
+			t.Errorf("request %d: expected X-RateLimit-Reset %s, got %q", i+1, want.reset, got)
+		}
+	}
+}
+
+// TestRateLimiter_StatusOfUnseenClient verifies that a client without a bucket yet reports a
+// full one, and that the unauthenticated bucket is reported for an empty client ID.
+func TestRateLimiter_StatusOfUnseenClient(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	rl := NewInMemoryRateLimiter(&Config{GlobalRPS: 100, ClientRPS: 5, UnAuthRPS: 2})
+	defer rl.Close()
+
+	if got := rl.Status("unseen"); got != (RateLimitStatus{Limit: 10, Remaining: 10}) {
+		t.Errorf("expected a full client bucket, got %+v", got)
+	}
+
+	rl.Allow("")
+
+	if got := rl.Status(""); got.Limit != 4 || got.Remaining != 3 || got.Reset <= 0 {
+		t.Errorf("expected the unauthenticated bucket with 3 of 4 left, got %+v", got)
+	}
+}
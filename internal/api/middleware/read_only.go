@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// TraceStageReadOnly is recorded when a request is rejected by read-only mode.
+const TraceStageReadOnly = "read_only"
+
+// readOnlyAllowedMethods is the Allow header of requests rejected by ReadOnly.
+const readOnlyAllowedMethods = "GET, HEAD, OPTIONS"
+
+// isReadMethod reports whether method never changes server state.
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// ReadOnly returns middleware that rejects every mutating request (POST, PUT, PATCH, DELETE, ...)
+// with an RFC 7807 405 and an Allow header, whatever the scopes of the caller's API key, for
+// instances that only serve reads (e.g. a public analytics replica). Reads and public endpoints
+// (see RegisterPublicEndpoint) always pass.
+func ReadOnly(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isReadMethod(r.Method) || publicEndpoints[r.URL.Path] {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			correlationID := GetCorrelationID(r.Context())
+
+			RecordTrace(r.Context(), TraceStageReadOnly, "rejected: "+r.Method)
+
+			w.Header().Set("Allow", readOnlyAllowedMethods)
+
+			detail := "Server is read-only: " + r.Method + " requests are not allowed."
+			if err := writeRFC7807Error(w, r, http.StatusMethodNotAllowed, detail, correlationID); err != nil {
+				logger.Error("failed to write response with RFC 7807 error format",
+					slog.String("correlation_id", correlationID),
+					slog.String("path", r.URL.Path),
+					slog.String("detail", detail),
+					slog.String("error", err.Error()),
+				)
+
+				http.Error(w, detail, http.StatusMethodNotAllowed)
+			}
+		})
+	}
+}
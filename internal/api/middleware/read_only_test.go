@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestReadOnly_RejectsMutatingRequests verifies that read-only mode answers mutating requests
+// with an RFC 7807 405 and an Allow header while reads pass.
+func TestReadOnly_RejectsMutatingRequests(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+	chain := Apply(http.HandlerFunc(ok), WithCorrelationID(), WithReadOnly(true, slog.Default()))
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		rr := httptest.NewRecorder()
+		chain.ServeHTTP(rr, httptest.NewRequest(method, "/api/v1/incidents", nil))
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("Expected %s to pass in read-only mode, got %d", method, rr.Code)
+		}
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		rr := httptest.NewRecorder()
+		chain.ServeHTTP(rr, httptest.NewRequest(method, "/api/v1/lineage", nil))
+
+		if rr.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected 405 for %s in read-only mode, got %d", method, rr.Code)
+
+			continue
+		}
+
+		if got := rr.Header().Get("Allow"); got != "GET, HEAD, OPTIONS" {
+			t.Errorf("Expected Allow GET, HEAD, OPTIONS, got %q", got)
+		}
+
+		if got := rr.Header().Get("Content-Type"); got != contentTypeProblemJSON {
+			t.Errorf("Expected %s, got %q", contentTypeProblemJSON, got)
+		}
+
+		var problem map[string]interface{}
+		if err := json.Unmarshal(rr.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("Failed to parse problem: %v", err)
+		}
+
+		if problem["title"] != "Method Not Allowed" {
+			t.Errorf("Expected title Method Not Allowed, got %v", problem["title"])
+		}
+	}
+}
+
+// TestReadOnly_Disabled verifies that WithReadOnly(false) lets every method through.
+func TestReadOnly_Disabled(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+	chain := Apply(http.HandlerFunc(ok), WithReadOnly(false, slog.Default()))
+
+	rr := httptest.NewRecorder()
+	chain.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/lineage", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected POST to pass without read-only mode, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrBodyTooSlow is returned by request body reads wrapped by MinBodyRate when the client sends
+// fewer than the minimum bytes within a window. Handlers answer it with a 408 Request Timeout.
+var ErrBodyTooSlow = errors.New("request body arrived too slowly")
+
+// MinBodyRate returns middleware that aborts reading a request body when fewer than minBytes
+// arrive within window: the blocked read is interrupted through the connection read deadline
+// and returns an error wrapping ErrBodyTooSlow. Each window starts once the previous one has
+// seen minBytes, so a client that keeps sending at the minimum rate is never cut off.
+//
+// Unlike the server read timeout, which bounds the whole request regardless of progress, this
+// frees the handler (and its concurrency slot) of a client trickling a large body a few bytes
+// at a time. Disabled when minBytes or window is zero or negative.
+func MinBodyRate(minBytes int64, window time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if minBytes <= 0 || window <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			body := newMinRateBody(r.Body, http.NewResponseController(w), minBytes, window)
+			defer body.stop()
+
+			r.Body = body
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// minRateBody enforces MinBodyRate on a request body. A timer per window interrupts a read
+// blocked on a silent client; a read that returns after its window expired with too few bytes
+// fails the same way (covering writers without read deadline support).
+type minRateBody struct {
+	io.ReadCloser
+
+	controller *http.ResponseController
+	minBytes   int64
+	window     time.Duration
+
+	mu          sync.Mutex
+	timer       *time.Timer
+	windowStart time.Time
+	windowBytes int64
+	tooSlow     bool
+	stopped     bool
+}
+
+func newMinRateBody(
+	body io.ReadCloser,
+	controller *http.ResponseController,
+	minBytes int64,
+	window time.Duration,
+) *minRateBody {
+	b := &minRateBody{
+		ReadCloser:  body,
+		controller:  controller,
+		minBytes:    minBytes,
+		window:      window,
+		windowStart: time.Now(),
+	}
+
+	b.timer = time.AfterFunc(window, b.expire)
+
+	return b
+}
+
+func (b *minRateBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		return n, err
+	}
+
+	now := time.Now()
+	b.windowBytes += int64(n)
+
+	switch {
+	case b.windowBytes >= b.minBytes:
+		b.windowStart, b.windowBytes = now, 0
+		b.timer.Reset(b.window)
+	case b.tooSlow || now.Sub(b.windowStart) >= b.window:
+		b.tooSlow = true
+
+		return n, fmt.Errorf("%w: fewer than %d bytes received within %v", ErrBodyTooSlow, b.minBytes, b.window)
+	}
+
+	if err != nil {
+		// Body fully read (or failed): no more windows to enforce
+		b.stopLocked()
+	}
+
+	return n, err
+}
+
+// Close stops enforcing the rate and closes the underlying body.
+func (b *minRateBody) Close() error {
+	b.stop()
+
+	return b.ReadCloser.Close()
+}
+
+// expire runs when a window ends without minBytes: it interrupts a blocked read by moving the
+// connection read deadline to now. Writers without deadline support are caught by Read instead.
+func (b *minRateBody) expire() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		return
+	}
+
+	b.tooSlow = true
+	_ = b.controller.SetReadDeadline(time.Now())
+}
+
+// stop ends rate enforcement once the handler is done with the body.
+func (b *minRateBody) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.stopLocked()
+}
+
+func (b *minRateBody) stopLocked() {
+	b.stopped = true
+	b.timer.Stop()
+}
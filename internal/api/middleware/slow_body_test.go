@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowReader returns chunk bytes of body per Read, sleeping delay before each one.
+type slowReader struct {
+	body  []byte
+	chunk int
+	delay time.Duration
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	if len(s.body) == 0 {
+		return 0, io.EOF
+	}
+
+	time.Sleep(s.delay)
+
+	n := copy(p[:min(len(p), s.chunk)], s.body)
+	s.body = s.body[n:]
+
+	return n, nil
+}
+
+// readBodyHandler reads the whole body and reports the outcome through readErr and readLen.
+func readBodyHandler(readErr *error, readLen *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		*readErr, *readLen = err, len(body)
+
+		if errors.Is(err, ErrBodyTooSlow) {
+			w.WriteHeader(http.StatusRequestTimeout)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestMinBodyRate_AbortsSlowBody(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	var (
+		readErr error
+		readLen int
+	)
+
+	handler := MinBodyRate(100, 50*time.Millisecond)(readBodyHandler(&readErr, &readLen))
+
+	body := &slowReader{body: bytes.Repeat([]byte("x"), 1000), chunk: 1, delay: 20 * time.Millisecond}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage", io.NopCloser(body))
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if !errors.Is(readErr, ErrBodyTooSlow) {
+		t.Fatalf("Expected ErrBodyTooSlow, got %v", readErr)
+	}
+
+	if readLen >= 100 {
+		t.Errorf("Expected the read to stop within the first window, read %d bytes", readLen)
+	}
+
+	if rr.Code != http.StatusRequestTimeout {
+		t.Errorf("Expected status 408, got %d", rr.Code)
+	}
+}
+
+func TestMinBodyRate_AllowsSteadyBody(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	var (
+		readErr error
+		readLen int
+	)
+
+	handler := MinBodyRate(10, 100*time.Millisecond)(readBodyHandler(&readErr, &readLen))
+
+	// 10 bytes every 20ms for 200ms: slow overall, but every window sees the minimum
+	body := &slowReader{body: bytes.Repeat([]byte("x"), 100), chunk: 10, delay: 20 * time.Millisecond}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage", io.NopCloser(body))
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if readErr != nil {
+		t.Fatalf("Expected the body to be read, got %v", readErr)
+	}
+
+	if readLen != 100 {
+		t.Errorf("Expected 100 bytes, got %d", readLen)
+	}
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rr.Code)
+	}
+}
+
+func TestMinBodyRate_Disabled(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	for _, tc := range []struct {
+		name     string
+		minBytes int64
+		window   time.Duration
+	}{
+		{"zero min bytes", 0, time.Second},
+		{"zero window", 1024, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := io.NopCloser(strings.NewReader("{}"))
+
+			handler := MinBodyRate(tc.minBytes, tc.window)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				if r.Body != body {
+					t.Error("Expected the request body to be left unwrapped")
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage", body)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		})
+	}
+}
+
+// TestMinBodyRate_InterruptsStalledClient verifies that a read blocked on a client that stopped
+// sending is interrupted once the window expires, instead of waiting for the server read timeout.
+func TestMinBodyRate_InterruptsStalledClient(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	var (
+		readErr error
+		readLen int
+	)
+
+	server := httptest.NewServer(MinBodyRate(100, 100*time.Millisecond)(readBodyHandler(&readErr, &readLen)))
+	defer server.Close()
+
+	pr, pw := io.Pipe()
+	defer func() { _ = pw.Close() }()
+
+	go func() {
+		// A few bytes, then silence
+		_, _ = pw.Write([]byte(`{"eventType":`))
+	}()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL, pr)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	start := time.Now()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the stalled read to be interrupted after the window, took %v", elapsed)
+	}
+
+	if resp.StatusCode != http.StatusRequestTimeout {
+		t.Errorf("Expected status 408, got %d", resp.StatusCode)
+	}
+
+	if !errors.Is(readErr, ErrBodyTooSlow) {
+		t.Errorf("Expected ErrBodyTooSlow, got %v", readErr)
+	}
+}
@@ -0,0 +1,191 @@
+// Package middleware provides HTTP middleware components for the Correlator API.
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Trace stages recorded for every traced request. Handlers may record additional stages.
+const (
+	TraceStageReceived   = "received"
+	TraceStageAuth       = "auth"
+	TraceStageRateLimit  = "rate_limit"
+	TraceStageValidation = "validation"
+	TraceStageStore      = "store"
+	TraceStageCompleted  = "completed"
+)
+
+type (
+	// TraceEvent is a single step in a request's lifecycle.
+	TraceEvent struct {
+		Stage  string
+		Detail string
+		At     time.Time
+	}
+
+	// RequestTrace is the recorded lifecycle of one request, keyed by its correlation ID.
+	RequestTrace struct {
+		CorrelationID string
+		Method        string
+		Path          string
+		StartedAt     time.Time
+		StatusCode    int
+		Duration      time.Duration
+		Events        []TraceEvent
+	}
+
+	// RequestTracer keeps the traces of recently completed requests in a bounded ring buffer.
+	//
+	// Properties:
+	//   - Bounded by count: at most maxRequests traces are kept; the oldest is evicted first
+	//   - Bounded by age: traces older than retention are never returned and are evicted lazily
+	//   - Last writer wins: a client reusing an X-Correlation-ID replaces the earlier trace
+	//
+	// Traces are added when a request completes, so in-flight requests (e.g., open SSE streams)
+	// are not visible until they finish.
+	RequestTracer struct {
+		maxRequests int
+		retention   time.Duration
+		now         func() time.Time
+
+		mu   sync.Mutex
+		ring []*RequestTrace
+		next int
+		byID map[string]*RequestTrace
+	}
+
+	// requestTraceKey is the context key for the in-flight trace.
+	requestTraceKey struct{}
+)
+
+// NewRequestTracer creates a tracer that keeps up to maxRequests traces for at most retention.
+// Returns nil (tracing disabled) when either bound is not positive.
+func NewRequestTracer(maxRequests int, retention time.Duration) *RequestTracer {
+	if maxRequests <= 0 || retention <= 0 {
+		return nil
+	}
+
+	return &RequestTracer{
+		maxRequests: maxRequests,
+		retention:   retention,
+		now:         time.Now,
+		ring:        make([]*RequestTrace, maxRequests),
+		byID:        make(map[string]*RequestTrace, maxRequests),
+	}
+}
+
+// Lookup returns a copy of the trace recorded for correlationID.
+// Returns false if no trace exists or it is older than the retention window.
+func (t *RequestTracer) Lookup(correlationID string) (RequestTrace, bool) {
+	if t == nil {
+		return RequestTrace{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trace, ok := t.byID[correlationID]
+	if !ok {
+		return RequestTrace{}, false
+	}
+
+	if t.expired(trace) {
+		delete(t.byID, correlationID)
+
+		return RequestTrace{}, false
+	}
+
+	clone := *trace
+	clone.Events = append([]TraceEvent(nil), trace.Events...)
+
+	return clone, true
+}
+
+// Len returns the number of traces currently retained (including expired ones not yet evicted).
+func (t *RequestTracer) Len() int {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.byID)
+}
+
+// add stores a completed trace, evicting the oldest slot once the ring is full.
+func (t *RequestTracer) add(trace *RequestTrace) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if evicted := t.ring[t.next]; evicted != nil && t.byID[evicted.CorrelationID] == evicted {
+		delete(t.byID, evicted.CorrelationID)
+	}
+
+	t.ring[t.next] = trace
+	t.byID[trace.CorrelationID] = trace
+	t.next = (t.next + 1) % t.maxRequests
+}
+
+func (t *RequestTracer) expired(trace *RequestTrace) bool {
+	return t.now().Sub(trace.StartedAt) > t.retention
+}
+
+// RecordTrace appends a lifecycle event to the request's trace.
+// No-op when tracing is disabled or ctx does not belong to a traced request.
+//
+// Must be called from the request's handler goroutine.
+func RecordTrace(ctx context.Context, stage, detail string) {
+	trace, ok := ctx.Value(requestTraceKey{}).(*RequestTrace)
+	if !ok {
+		return
+	}
+
+	trace.Events = append(trace.Events, TraceEvent{Stage: stage, Detail: detail, At: time.Now()})
+}
+
+// Tracing creates a middleware that records each request's lifecycle in tracer, keyed by the
+// correlation ID set by the CorrelationID middleware.
+//
+// The middleware records "received" on entry and "completed" (with status code and duration)
+// on exit; downstream middleware and handlers add their own stages with RecordTrace.
+// Public endpoints (health probes) are not traced so they cannot flood the buffer.
+func Tracing(tracer *RequestTracer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if publicEndpoints[r.URL.Path] {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			start := time.Now()
+			trace := &RequestTrace{
+				CorrelationID: GetCorrelationID(r.Context()),
+				Method:        r.Method,
+				Path:          r.URL.Path,
+				StartedAt:     start,
+				Events:        []TraceEvent{{Stage: TraceStageReceived, At: start}},
+			}
+
+			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			defer func() {
+				trace.StatusCode = rw.statusCode
+				trace.Duration = time.Since(start)
+				trace.Events = append(trace.Events, TraceEvent{
+					Stage:  TraceStageCompleted,
+					Detail: http.StatusText(rw.statusCode),
+					At:     time.Now(),
+				})
+
+				tracer.add(trace)
+			}()
+
+			next.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), requestTraceKey{}, trace)))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package middleware provides HTTP middleware components for the Correlator API.
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// serveTraced runs one request with the given correlation ID through CorrelationID and Tracing.
+func serveTraced(tracer *RequestTracer, path, correlationID string, handler http.HandlerFunc) {
+	chain := Apply(handler, WithCorrelationID(), WithTracing(tracer))
+
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	req.Header.Set("X-Correlation-ID", correlationID)
+
+	chain.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+// TestTracing_RecordsLifecycle verifies that a traced request records received, handler stages,
+// and completed with the final status code.
+func TestTracing_RecordsLifecycle(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tracer := NewRequestTracer(10, time.Minute)
+
+	serveTraced(tracer, "/api/v1/lineage", "trace-1", func(w http.ResponseWriter, r *http.Request) {
+		RecordTrace(r.Context(), TraceStageValidation, "failed: run.runId is required")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	})
+
+	trace, ok := tracer.Lookup("trace-1")
+	if !ok {
+		t.Fatal("Expected trace for trace-1")
+	}
+
+	if trace.Method != http.MethodPost || trace.Path != "/api/v1/lineage" {
+		t.Errorf("Unexpected request line: %s %s", trace.Method, trace.Path)
+	}
+
+	if trace.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, trace.StatusCode)
+	}
+
+	stages := make([]string, 0, len(trace.Events))
+	for _, e := range trace.Events {
+		stages = append(stages, e.Stage)
+	}
+
+	expected := []string{TraceStageReceived, TraceStageValidation, TraceStageCompleted}
+	if fmt.Sprint(stages) != fmt.Sprint(expected) {
+		t.Errorf("Expected stages %v, got %v", expected, stages)
+	}
+}
+
+// TestTracing_EvictsOldestWhenFull verifies the count bound of the ring buffer.
+func TestTracing_EvictsOldestWhenFull(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tracer := NewRequestTracer(2, time.Minute)
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	for _, id := range []string{"a", "b", "c"} {
+		serveTraced(tracer, "/api/v1/lineage", id, ok)
+	}
+
+	if _, found := tracer.Lookup("a"); found {
+		t.Error("Oldest trace should have been evicted")
+	}
+
+	for _, id := range []string{"b", "c"} {
+		if _, found := tracer.Lookup(id); !found {
+			t.Errorf("Expected trace %q to be retained", id)
+		}
+	}
+
+	if tracer.Len() != 2 {
+		t.Errorf("Expected 2 retained traces, got %d", tracer.Len())
+	}
+
+	// A reused correlation ID replaces the earlier trace without losing another one
+	serveTraced(tracer, "/api/v1/lineage/batch", "c", ok)
+
+	trace, _ := tracer.Lookup("c")
+	if trace.Path != "/api/v1/lineage/batch" {
+		t.Errorf("Expected latest trace for reused ID, got path %q", trace.Path)
+	}
+}
+
+// TestTracing_ExpiresAfterRetention verifies the age bound.
+func TestTracing_ExpiresAfterRetention(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tracer := NewRequestTracer(10, time.Minute)
+	now := time.Now()
+	tracer.now = func() time.Time { return now }
+
+	serveTraced(tracer, "/api/v1/lineage", "old", func(http.ResponseWriter, *http.Request) {})
+
+	if _, found := tracer.Lookup("old"); !found {
+		t.Fatal("Expected trace within retention")
+	}
+
+	tracer.now = func() time.Time { return now.Add(2 * time.Minute) }
+
+	if _, found := tracer.Lookup("old"); found {
+		t.Error("Trace older than retention should not be returned")
+	}
+}
+
+// TestTracing_SkipsPublicEndpointsAndDisabledTracer verifies that probes are not traced and that
+// a disabled (nil) tracer is safe everywhere.
+func TestTracing_SkipsPublicEndpointsAndDisabledTracer(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	RegisterPublicEndpoint("/ping")
+
+	tracer := NewRequestTracer(10, time.Minute)
+	serveTraced(tracer, "/ping", "probe", func(http.ResponseWriter, *http.Request) {})
+
+	if _, found := tracer.Lookup("probe"); found {
+		t.Error("Public endpoints should not be traced")
+	}
+
+	disabled := NewRequestTracer(0, time.Minute)
+	if disabled != nil {
+		t.Fatal("Expected nil tracer when maxRequests is 0")
+	}
+
+	serveTraced(disabled, "/api/v1/lineage", "untraced", func(_ http.ResponseWriter, r *http.Request) {
+		RecordTrace(r.Context(), TraceStageStore, "stored=true") // Must not panic
+	})
+
+	if _, found := disabled.Lookup("untraced"); found {
+		t.Error("Disabled tracer should not return traces")
+	}
+}
@@ -207,6 +207,25 @@ func TestAuthenticationIntegration(t *testing.T) {
 		verifyRFC7807Error(t, rr, http.StatusUnauthorized)
 	})
 
+	t.Run("CORS Preflight Bypasses Authentication", func(t *testing.T) {
+		// Browsers never send credentials on preflight requests
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/incidents", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		req.Header.Set("Access-Control-Request-Headers", "Authorization")
+
+		rr := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code, "Response body: %s", rr.Body.String())
+		assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, Authorization, X-Correlation-ID", rr.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "86400", rr.Header().Get("Access-Control-Max-Age"))
+		assert.Empty(t, rr.Header().Get("WWW-Authenticate"), "Preflight must not carry an auth challenge")
+		assert.Empty(t, rr.Body.String())
+	})
+
 	t.Run("Invalid API Key Returns 401", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/health/correlation", nil)
 		req.Header.Set("Authorization", "Bearer "+"correlator_ak_"+string(make([]byte, 64)))
@@ -485,6 +504,20 @@ func TestPublicEndpointRateLimitBypass(t *testing.T) {
 		)
 	})
 
+	t.Run("CORS Preflight Bypasses Rate Limiting", func(t *testing.T) {
+		// A browser preflights every cross-origin request: they must never be throttled
+		for i := 0; i < 100; i++ {
+			req := httptest.NewRequest(http.MethodOptions, "/api/v1/lineage", nil)
+			req.Header.Set("Origin", "https://app.example.com")
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+			rr := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(rr, req)
+
+			require.Equalf(t, http.StatusNoContent, rr.Code, "Preflight %d: response body: %s", i, rr.Body.String())
+		}
+	})
+
 	t.Run("Protected Endpoint Still Enforces Rate Limits", func(t *testing.T) {
 		// Verify /api/v1/health/correlation DOES get rate limited (it's protected)
 		// With 2 RPS client limit, we should hit rate limit quickly
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// contentTypeNDJSON is the media type of newline-delimited JSON: one JSON object per line,
+	// for consumers (jq, Spark) that process large lists incrementally instead of parsing one
+	// array. List endpoints serve it on request (Accept); application/json stays the default.
+	contentTypeNDJSON = "application/x-ndjson"
+
+	// totalCountHeader carries the total of a paginated list served as NDJSON, which has no
+	// envelope for it.
+	totalCountHeader = "X-Total-Count"
+
+	// ndjsonFlushLines is how many lines are buffered before they are flushed to the client.
+	ndjsonFlushLines = 100
+)
+
+// acceptsNDJSON reports whether the Accept header of r asks for NDJSON: application/x-ndjson is
+// listed with a non-zero quality at least that of application/json.
+func acceptsNDJSON(r *http.Request) bool {
+	ndjsonQuality, jsonQuality := 0.0, 0.0
+
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case contentTypeNDJSON:
+			ndjsonQuality = max(ndjsonQuality, quality)
+		case "application/json":
+			jsonQuality = max(jsonQuality, quality)
+		}
+	}
+
+	return ndjsonQuality > 0 && ndjsonQuality >= jsonQuality
+}
+
+// writeNDJSON writes items as a 200 NDJSON response, one object per line, flushing every
+// ndjsonFlushLines lines so the client can start processing before the last one is written.
+// Once the first line is out the status can't change, so errors are only returned for logging.
+func writeNDJSON[T any](w http.ResponseWriter, items []T) error {
+	w.Header().Set("Content-Type", contentTypeNDJSON)
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w) // Encode terminates every object with "\n"
+	controller := http.NewResponseController(w)
+
+	for i, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+
+		if (i+1)%ndjsonFlushLines == 0 {
+			_ = controller.Flush()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/correlator-io/correlator/internal/correlation"
+)
+
+// parsePagination parses the limit and offset query parameters shared by all list endpoints, so
+// they paginate alike. limit defaults to ServerConfig.DefaultPageSize and must be between 1 and
+// ServerConfig.MaxPageSize; offset defaults to 0. Returns a *paramError (400) for invalid values.
+func (s *Server) parsePagination(r *http.Request) (*correlation.Pagination, error) {
+	defaultSize, maxSize := s.config.pageSizes()
+	q := r.URL.Query()
+
+	pagination := &correlation.Pagination{Limit: defaultSize}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, &paramError{param: "limit", msg: "must be a valid integer"}
+		}
+
+		if limit < 1 || limit > maxSize {
+			return nil, &paramError{param: "limit", msg: "must be between 1 and " + strconv.Itoa(maxSize)}
+		}
+
+		pagination.Limit = limit
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			return nil, &paramError{param: "offset", msg: "must be a valid integer"}
+		}
+
+		if offset < 0 {
+			return nil, &paramError{param: "offset", msg: "must be >= 0"}
+		}
+
+		pagination.Offset = offset
+	}
+
+	return pagination, nil
+}
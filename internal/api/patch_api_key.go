@@ -0,0 +1,227 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const (
+	// contentTypeMergePatch is the media type of an RFC 7396 JSON Merge Patch.
+	contentTypeMergePatch = "application/merge-patch+json"
+
+	// permissionLineageWrite is the API key scope required to ingest lineage events.
+	permissionLineageWrite = "lineage:write"
+)
+
+// knownPermissions are the API key scopes a key may be granted.
+var knownPermissions = []string{ //nolint:gochecknoglobals
+	permissionAdmin, permissionLineageRead, permissionLineageWrite,
+}
+
+type (
+	// apiKeyResponse is the API representation of a key. The key value (or its hash) is never returned.
+	apiKeyResponse struct {
+		ID          string     `json:"id"`
+		ClientID    string     `json:"client_id"` //nolint:tagliatelle
+		Name        string     `json:"name"`
+		Permissions []string   `json:"permissions"`
+		CreatedAt   time.Time  `json:"created_at"` //nolint:tagliatelle
+		ExpiresAt   *time.Time `json:"expires_at"` //nolint:tagliatelle
+		Active      bool       `json:"active"`
+	}
+)
+
+// handlePatchAPIKey handles PATCH /api/v1/keys/{id}.
+// Applies an RFC 7396 JSON Merge Patch to the key's metadata and returns the updated key.
+//
+// Patchable members:
+//   - name: non-empty string
+//   - permissions: array of known scopes (admin, lineage:read, lineage:write)
+//   - expires_at: RFC 3339 timestamp in the future, or null to never expire
+//
+// Omitted members are left unchanged. The key value itself is immutable: a patch containing
+// "key" (or any other read-only member) is rejected with 422.
+//
+// Requires Content-Type: application/merge-patch+json (415 otherwise) and the admin permission
+// when authentication is enabled (403 otherwise).
+func (s *Server) handlePatchAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	correlationID := middleware.GetCorrelationID(ctx)
+
+	if !s.requirePermission(w, r, permissionAdmin) {
+		return
+	}
+
+	if !hasMergePatchContentType(r.Header.Get("Content-Type")) {
+		WriteErrorResponse(w, r, s.logger, UnsupportedMediaType("Content-Type must be "+contentTypeMergePatch))
+
+		return
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || patch == nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest("Request body must be a JSON object"))
+
+		return
+	}
+
+	keyID := r.PathValue("id")
+
+	apiKey, err := s.apiKeyStore.FindByID(ctx, keyID)
+	if errors.Is(err, storage.ErrKeyNotFound) {
+		WriteErrorResponse(w, r, s.logger, NotFound("API key not found"))
+
+		return
+	}
+
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to query API key for patch",
+			slog.String("correlation_id", correlationID),
+			slog.String("key_id", keyID),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to query API key"))
+
+		return
+	}
+
+	if problem := applyAPIKeyPatch(apiKey, patch, time.Now()); problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	err = s.apiKeyStore.Update(ctx, apiKey)
+	if errors.Is(err, storage.ErrKeyStoreReadOnly) {
+		WriteErrorResponse(w, r, s.logger, Conflict("API keys are static configuration; edit the key file and restart"))
+
+		return
+	}
+
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to update API key",
+			slog.String("correlation_id", correlationID),
+			slog.String("key_id", keyID),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to update API key"))
+
+		return
+	}
+
+	clientCtx, _ := middleware.GetClientContext(ctx)
+
+	s.logger.InfoContext(ctx, "API key updated via API",
+		slog.String("correlation_id", correlationID),
+		slog.String("client_id", clientCtx.ClientID),
+		slog.String("key_id", keyID),
+		slog.Any("fields", slices.Sorted(maps.Keys(patch))),
+	)
+
+	data, err := json.Marshal(mapAPIKeyToResponse(apiKey))
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// applyAPIKeyPatch merges patch into apiKey, validating every member before changing anything.
+func applyAPIKeyPatch(apiKey *storage.APIKey, patch map[string]json.RawMessage, now time.Time) *ProblemDetail {
+	if _, ok := patch["key"]; ok {
+		return UnprocessableEntity("key is immutable; create a new key instead")
+	}
+
+	updated := *apiKey
+
+	for _, field := range slices.Sorted(maps.Keys(patch)) {
+		value := patch[field]
+		isNull := bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+
+		switch field {
+		case "name":
+			var name string
+			if isNull || json.Unmarshal(value, &name) != nil || strings.TrimSpace(name) == "" {
+				return UnprocessableEntity("name must be a non-empty string")
+			}
+
+			updated.Name = strings.TrimSpace(name)
+		case "permissions":
+			var permissions []string
+			if isNull || json.Unmarshal(value, &permissions) != nil {
+				return UnprocessableEntity("permissions must be an array of strings")
+			}
+
+			for _, p := range permissions {
+				if !slices.Contains(knownPermissions, p) {
+					return UnprocessableEntity("unknown permission " + p + "; must be one of: " +
+						strings.Join(knownPermissions, ", "))
+				}
+			}
+
+			updated.Permissions = permissions
+		case "expires_at":
+			if isNull {
+				updated.ExpiresAt = nil
+
+				continue
+			}
+
+			var expiresAt time.Time
+			if err := json.Unmarshal(value, &expiresAt); err != nil {
+				return UnprocessableEntity("expires_at must be an RFC 3339 timestamp or null")
+			}
+
+			if !expiresAt.After(now) {
+				return UnprocessableEntity("expires_at must be in the future")
+			}
+
+			updated.ExpiresAt = &expiresAt
+		default:
+			return UnprocessableEntity(field + " cannot be patched; patchable fields: name, permissions, expires_at")
+		}
+	}
+
+	*apiKey = updated
+
+	return nil
+}
+
+func mapAPIKeyToResponse(apiKey *storage.APIKey) apiKeyResponse {
+	permissions := apiKey.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return apiKeyResponse{
+		ID:          apiKey.ID,
+		ClientID:    apiKey.ClientID,
+		Name:        apiKey.Name,
+		Permissions: permissions,
+		CreatedAt:   apiKey.CreatedAt,
+		ExpiresAt:   apiKey.ExpiresAt,
+		Active:      apiKey.Active,
+	}
+}
+
+// hasMergePatchContentType checks if Content-Type is application/merge-patch+json (parameters allowed).
+func hasMergePatchContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && mediaType == contentTypeMergePatch
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupKeyAdminTestServer starts a server with authentication enabled and returns it with an
+// admin key and a lineage:write-only key (whose ID is "patch-target").
+func setupKeyAdminTestServer(ctx context.Context, t *testing.T) (*httptest.Server, string, string) {
+	t.Helper()
+
+	server := setupAPITestServer(ctx, t, []testAPIKey{
+		{id: "patch-admin", permissions: []string{"admin"}},
+		{id: "patch-target", permissions: []string{"lineage:write"}},
+	}, nil)
+
+	return server.httpServer, server.keys["patch-admin"], server.keys["patch-target"]
+}
+
+func TestPatchAPIKey_Endpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	httpServer, adminKey, writeKey := setupKeyAdminTestServer(ctx, t)
+
+	patchKey := func(apiKey, id, contentType, body string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch,
+			httpServer.URL+"/api/v1/keys/"+id, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		req.Header.Set("Content-Type", contentType)
+
+		resp, err := httpServer.Client().Do(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	t.Run("RequiresAdmin", func(t *testing.T) {
+		resp := patchKey(writeKey, "patch-target", contentTypeMergePatch, `{"name":"self-promoted"}`)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("RequiresMergePatchContentType", func(t *testing.T) {
+		resp := patchKey(adminKey, "patch-target", "application/json", `{"name":"renamed"}`)
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		resp := patchKey(adminKey, "no-such-key", contentTypeMergePatch, `{"name":"renamed"}`)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("RejectsInvalidPatches", func(t *testing.T) {
+		for _, body := range []string{
+			`{"key":"correlator_ak_replacement"}`,
+			`{"name":"renamed","key":null}`,
+			`{"permissions":["lineage:write","superuser"]}`,
+			`{"expires_at":"2001-01-01T00:00:00Z"}`,
+			`{"name":""}`,
+			`{"client_id":"someone-else"}`,
+		} {
+			resp := patchKey(adminKey, "patch-target", contentTypeMergePatch, body)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "body %s", body)
+		}
+	})
+
+	t.Run("UpdatesOnlyPatchedFields", func(t *testing.T) {
+		expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+		resp := patchKey(adminKey, "patch-target", contentTypeMergePatch+"; charset=utf-8",
+			`{"permissions":["lineage:write","lineage:read"],"expires_at":"`+expiresAt.Format(time.RFC3339)+`"}`)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body apiKeyResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+		assert.Equal(t, "patch-target", body.Name, "name was not in the patch")
+		assert.Equal(t, []string{"lineage:write", "lineage:read"}, body.Permissions)
+		require.NotNil(t, body.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*body.ExpiresAt))
+
+		// null removes the expiry; the plaintext key keeps working throughout
+		resp = patchKey(adminKey, "patch-target", contentTypeMergePatch, `{"expires_at":null}`)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body = apiKeyResponse{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Nil(t, body.ExpiresAt)
+		assert.Equal(t, []string{"lineage:write", "lineage:read"}, body.Permissions)
+	})
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+const (
+	// maxSeedRuns bounds the job runs of one seed request. Runs are spaced seedRunInterval apart,
+	// so the oldest stays within the default event time skew (24h).
+	maxSeedRuns = 1000
+
+	// maxSeedDatasets bounds the inputs and the outputs of each seeded run.
+	maxSeedDatasets = 50
+
+	// defaultSeedNamespace is the job namespace of seeded runs, so they can be removed with
+	// DELETE /api/v1/lineage/job-runs?namespace=correlator-seed.
+	defaultSeedNamespace = "correlator-seed"
+
+	// seedDatasetNamespace is the dataset namespace of seeded inputs and outputs.
+	seedDatasetNamespace = "postgres://seed-warehouse:5432"
+
+	// seedProducer is the producer URL of seeded events. They also carry a processing_engine facet
+	// naming seedEngine, so GET /api/v1/producers lists them apart from real integrations.
+	seedProducer = "https://github.com/correlator-io/correlator/tree/0.0.0/seed"
+	seedEngine   = "correlator-seed"
+
+	seedRunInterval = time.Minute
+	seedRunDuration = 30 * time.Second
+)
+
+// seedStates are the states a seeded run may end in.
+var seedStates = []ingestion.EventType{ //nolint:gochecknoglobals
+	ingestion.EventTypeStart, ingestion.EventTypeRunning, ingestion.EventTypeComplete,
+	ingestion.EventTypeFail, ingestion.EventTypeAbort, ingestion.EventTypeOther,
+}
+
+type (
+	// seedRequest is the request body of POST /api/v1/debug/seed.
+	seedRequest struct {
+		Runs      int      `json:"runs"`
+		Inputs    *int     `json:"inputs"`
+		Outputs   *int     `json:"outputs"`
+		States    []string `json:"states"`
+		Namespace string   `json:"namespace"`
+	}
+
+	// seedResponse is the response from POST /api/v1/debug/seed.
+	seedResponse struct {
+		Namespace string `json:"namespace"`
+		JobRuns   int    `json:"job_runs"` //nolint:tagliatelle
+		Events    int    `json:"events"`
+		Failed    int    `json:"failed"`
+	}
+
+	// seedPlan is a validated seedRequest.
+	seedPlan struct {
+		runs      int
+		inputs    int
+		outputs   int
+		states    []ingestion.EventType
+		namespace string
+	}
+)
+
+// handleDebugSeed handles POST /api/v1/debug/seed.
+// Generates synthetic job runs for performance testing, demos and UI development, and stores
+// them through the regular ingestion path (validation, interceptors, ingestion quota, storage).
+//
+// Request body (JSON object, every member optional except runs):
+//   - runs: job runs to generate (1-1000)
+//   - inputs, outputs: datasets per run (0-50, default: 1 each); run N reads the outputs of
+//     run N-1, so the runs form one lineage chain
+//   - states: final states assigned to the runs in turn (START, RUNNING, COMPLETE, FAIL, ABORT,
+//     OTHER; default: ["COMPLETE"]); each run gets a START event and, unless it ends in START,
+//     an event with its final state. FAIL runs carry an errorMessage facet
+//   - namespace: job namespace of the runs (default: correlator-seed)
+//
+// Responds with the number of runs generated and of events stored or failed.
+//
+// Never for production: the route is only registered when CORRELATOR_ENABLE_SEED=true and
+// authentication is enabled, and requires the admin permission (403 otherwise).
+func (s *Server) handleDebugSeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !s.requirePermission(w, r, permissionAdmin) {
+		return
+	}
+
+	var req seedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest("Request body must be a JSON object"))
+
+		return
+	}
+
+	plan, err := parseSeedRequest(&req)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, BadRequest(err.Error()))
+
+		return
+	}
+
+	events := plan.events(time.Now())
+
+	runEvents := make([]*ingestion.RunEvent, len(events))
+	for i := range events {
+		runEvents[i] = mapLineageRequest(&events[i])
+	}
+
+	runEvents, validationErrors, problem := s.validateEvents(s.validator, runEvents)
+	if problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	storeResults, problem := s.storeValidEvents(ctx, ingestingPlugin(ctx), runEvents, validationErrors)
+	if problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	response := seedResponse{Namespace: plan.namespace, JobRuns: plan.runs}
+
+	for i := range runEvents {
+		if validationErrors[i] != nil || storeResults[i] == nil || storeResults[i].Error != nil {
+			response.Failed++
+		} else {
+			response.Events++
+		}
+	}
+
+	clientCtx, _ := middleware.GetClientContext(ctx)
+
+	s.logger.WarnContext(ctx, "Synthetic lineage seeded via API",
+		slog.String("correlation_id", middleware.GetCorrelationID(ctx)),
+		slog.String("client_id", clientCtx.ClientID),
+		slog.String("namespace", plan.namespace),
+		slog.Int("job_runs", plan.runs),
+		slog.Int("events", response.Events),
+		slog.Int("failed", response.Failed),
+	)
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// parseSeedRequest validates a seed request and fills in defaults.
+func parseSeedRequest(req *seedRequest) (*seedPlan, error) {
+	plan := &seedPlan{
+		runs:      req.Runs,
+		inputs:    1,
+		outputs:   1,
+		states:    []ingestion.EventType{ingestion.EventTypeComplete},
+		namespace: strings.TrimSpace(req.Namespace),
+	}
+
+	if plan.runs < 1 || plan.runs > maxSeedRuns {
+		return nil, &paramError{param: "runs", msg: fmt.Sprintf("must be between 1 and %d", maxSeedRuns)}
+	}
+
+	if req.Inputs != nil {
+		plan.inputs = *req.Inputs
+	}
+
+	if req.Outputs != nil {
+		plan.outputs = *req.Outputs
+	}
+
+	if plan.inputs < 0 || plan.inputs > maxSeedDatasets {
+		return nil, &paramError{param: "inputs", msg: fmt.Sprintf("must be between 0 and %d", maxSeedDatasets)}
+	}
+
+	if plan.outputs < 0 || plan.outputs > maxSeedDatasets {
+		return nil, &paramError{param: "outputs", msg: fmt.Sprintf("must be between 0 and %d", maxSeedDatasets)}
+	}
+
+	if len(req.States) > 0 {
+		plan.states = make([]ingestion.EventType, 0, len(req.States))
+
+		for _, state := range req.States {
+			eventType := ingestion.EventType(strings.ToUpper(strings.TrimSpace(state)))
+			if !slices.Contains(seedStates, eventType) {
+				return nil, &paramError{
+					param: "states",
+					msg:   "must only contain START, RUNNING, COMPLETE, FAIL, ABORT or OTHER",
+				}
+			}
+
+			plan.states = append(plan.states, eventType)
+		}
+	}
+
+	if plan.namespace == "" {
+		plan.namespace = defaultSeedNamespace
+	}
+
+	return plan, nil
+}
+
+// events generates the lineage events of the plan. Runs start seedRunInterval apart, the last one
+// seedRunInterval before now, and end seedRunDuration after they start.
+func (p *seedPlan) events(now time.Time) []LineageEvent {
+	events := make([]LineageEvent, 0, 2*p.runs) //nolint:mnd // START + final state
+
+	for i := range p.runs {
+		runID := uuid.New().String()
+		state := p.states[i%len(p.states)]
+		startTime := now.Add(-time.Duration(p.runs-i) * seedRunInterval)
+
+		inputs := make([]Dataset, p.inputs)
+		for k := range inputs {
+			inputs[k] = Dataset{Namespace: seedDatasetNamespace, Name: seedInputName(i, k, p.outputs)}
+		}
+
+		outputs := make([]Dataset, p.outputs)
+		for k := range outputs {
+			outputs[k] = Dataset{Namespace: seedDatasetNamespace, Name: fmt.Sprintf("seed.model_%d_%d", i, k)}
+		}
+
+		event := func(eventType ingestion.EventType, eventTime time.Time) LineageEvent {
+			return LineageEvent{
+				EventType: string(eventType),
+				EventTime: eventTime.UTC().Format(time.RFC3339Nano),
+				Producer:  seedProducer,
+				SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/RunEvent",
+				Run: Run{ID: runID, Facets: map[string]interface{}{
+					ingestion.ProcessingEngineFacet: map[string]interface{}{"name": seedEngine, "version": "0.0.0"},
+				}},
+				Job:     Job{Namespace: p.namespace, Name: fmt.Sprintf("seed_job_%d", i)},
+				Inputs:  inputs,
+				Outputs: outputs,
+			}
+		}
+
+		events = append(events, event(ingestion.EventTypeStart, startTime))
+
+		if state == ingestion.EventTypeStart {
+			continue
+		}
+
+		final := event(state, startTime.Add(seedRunDuration))
+		if state == ingestion.EventTypeFail {
+			final.Run.Facets[ingestion.ErrorMessageFacet] = map[string]interface{}{
+				"message":             fmt.Sprintf("synthetic failure of seed_job_%d", i),
+				"programmingLanguage": "SQL",
+			}
+		}
+
+		events = append(events, final)
+	}
+
+	return events
+}
+
+// seedInputName returns the name of input k of run i: an output of the previous run, so the runs
+// form one lineage chain, or a source table for the first run (and when runs have no outputs).
+func seedInputName(i, k, outputs int) string {
+	if i == 0 || outputs == 0 {
+		return fmt.Sprintf("seed.source_%d", k)
+	}
+
+	return fmt.Sprintf("seed.model_%d_%d", i-1, k%outputs)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// setupSeedTestServer starts a server with seeding enabled and returns it with its database,
+// an admin key and a lineage:write-only key.
+func setupSeedTestServer(ctx context.Context, t *testing.T) (*httptest.Server, *sql.DB, string, string) {
+	t.Helper()
+
+	server := setupAPITestServer(ctx, t, []testAPIKey{
+		{id: "seed-admin-key", permissions: []string{"admin", "lineage:write"}},
+		{id: "seed-write-key", permissions: []string{"lineage:write"}},
+	}, func(cfg *ServerConfig, _ *Dependencies, _ *storage.LineageStore) {
+		cfg.EnableSeed = true
+	})
+
+	return server.httpServer, server.db, server.keys["seed-admin-key"], server.keys["seed-write-key"]
+}
+
+func TestDebugSeed_NotRegisteredWithoutAuth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	server := setupAPITestServer(ctx, t, nil, func(cfg *ServerConfig, deps *Dependencies, _ *storage.LineageStore) {
+		cfg.EnableSeed = true
+		deps.APIKeyStore = nil
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		server.httpServer.URL+"/api/v1/debug/seed", bytes.NewBufferString(`{"runs": 1}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := server.httpServer.Client().Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	var runs int
+	require.NoError(t, server.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM job_runs`).Scan(&runs))
+	assert.Zero(t, runs)
+}
+
+func TestDebugSeed_Endpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	httpServer, db, adminKey, writeKey := setupSeedTestServer(ctx, t)
+
+	seed := func(apiKey, body string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			httpServer.URL+"/api/v1/debug/seed", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := httpServer.Client().Do(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	t.Run("RequiresAdmin", func(t *testing.T) {
+		resp := seed(writeKey, `{"runs": 1}`)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("RejectsInvalidRequests", func(t *testing.T) {
+		for _, body := range []string{
+			`not json`,
+			`{}`,
+			`{"runs": 1001}`,
+			`{"runs": 1, "inputs": -1}`,
+			`{"runs": 1, "outputs": 51}`,
+			`{"runs": 1, "states": ["DONE"]}`,
+		} {
+			resp := seed(adminKey, body)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "body %s", body)
+			assert.Equal(t, contentTypeProblemJSON, resp.Header.Get("Content-Type"))
+		}
+	})
+
+	t.Run("SeedsRequestedRuns", func(t *testing.T) {
+		resp := seed(adminKey, `{"runs": 5, "inputs": 2, "outputs": 1, "states": ["COMPLETE", "FAIL", "RUNNING"]}`)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body seedResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+		assert.Equal(t, seedResponse{Namespace: defaultSeedNamespace, JobRuns: 5, Events: 10, Failed: 0}, body)
+
+		states := make(map[string]int)
+
+		rows, err := db.QueryContext(ctx,
+			`SELECT current_state, COUNT(*) FROM job_runs WHERE job_namespace = $1 GROUP BY current_state`,
+			defaultSeedNamespace)
+		require.NoError(t, err)
+
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			var (
+				state string
+				runs  int
+			)
+
+			require.NoError(t, rows.Scan(&state, &runs))
+
+			states[state] = runs
+		}
+
+		require.NoError(t, rows.Err())
+		assert.Equal(t, map[string]int{"COMPLETE": 2, "FAIL": 2, "RUNNING": 1}, states)
+
+		// Run N reads the output of run N-1: one chain through all seeded runs
+		var inputEdges, chainedInputs int
+
+		err = db.QueryRowContext(ctx, `
+			SELECT COUNT(*), COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM lineage_edges o WHERE o.dataset_urn = le.dataset_urn AND o.edge_type = 'output'
+			))
+			FROM lineage_edges le
+			JOIN job_runs jr ON jr.run_id = le.run_id
+			WHERE jr.job_namespace = $1 AND le.edge_type = 'input'`, defaultSeedNamespace,
+		).Scan(&inputEdges, &chainedInputs)
+		require.NoError(t, err)
+		assert.Equal(t, 10, inputEdges, "5 runs x 2 inputs")
+		assert.Equal(t, 8, chainedInputs, "every run but the first reads outputs of the previous run")
+	})
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+)
+
+// SchemaChecker is the interface the API layer uses to verify the database schema version.
+// Defined here (consumer in api package) following the Dependency Inversion Principle.
+//
+// Implemented by: storage.SchemaVersionChecker.
+type SchemaChecker interface {
+	// VerifySchema returns the applied migration version, or an error when no migration
+	// is applied, the last migration is dirty, or the schema is older than the server requires.
+	VerifySchema(ctx context.Context) (int64, error)
+}
+
+const (
+	// deepReadyTimeout bounds the whole deep readiness check so a slow dependency
+	// cannot hang the probe. Kept short: deep checks are for operators and rollouts, not hot loops.
+	deepReadyTimeout = 3 * time.Second
+
+	statusReady    = "ready"
+	statusNotReady = "not_ready"
+
+	readyCheckLineageStore = "lineage_store"
+	readyCheckAPIKeyStore  = "api_key_store"
+	readyCheckSchema       = "schema"
+)
+
+// readyResponse is the JSON response for GET /ready?deep=true.
+type readyResponse struct {
+	Status string                         `json:"status"`
+	Checks map[string]*readyCheckResponse `json:"checks"`
+}
+
+// readyCheckResponse is the JSON representation of a single deep readiness check.
+type readyCheckResponse struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"` //nolint:tagliatelle
+	Error     string `json:"error,omitempty"`
+	Version   int64  `json:"version,omitempty"`
+}
+
+// handleDeepReady verifies every dependency needed to serve traffic and reports each one.
+//
+// Checks:
+//   - lineage_store: ingestion store connectivity
+//   - api_key_store: API key store connectivity ("disabled" when auth is off)
+//   - schema: at least one migration applied, not dirty, and at or above the required version
+//     ("disabled" when no schema checker is configured)
+//
+// Response codes:
+//   - 200 OK: All enabled checks healthy
+//   - 503 Service Unavailable: Any enabled check failed or timed out
+func (s *Server) handleDeepReady(w http.ResponseWriter, r *http.Request) {
+	correlationID := middleware.GetCorrelationID(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), deepReadyTimeout)
+	defer cancel()
+
+	checks := map[string]*readyCheckResponse{
+		readyCheckLineageStore: runReadyCheck(ctx, s.ingestionStore.HealthCheck),
+		readyCheckAPIKeyStore:  s.checkAPIKeyStoreReady(ctx),
+		readyCheckSchema:       s.checkSchemaReady(ctx),
+	}
+
+	response := &readyResponse{Status: statusReady, Checks: checks}
+
+	for name, check := range checks {
+		if check.Status != statusUnhealthy {
+			continue
+		}
+
+		response.Status = statusNotReady
+
+		s.logger.Error("Deep readiness check failed",
+			slog.String("correlation_id", correlationID),
+			slog.String("check", name),
+			slog.String("error", check.Error),
+		)
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("Failed to encode readiness response",
+			slog.String("correlation_id", correlationID),
+			slog.String("error", err.Error()),
+		)
+
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode readiness response"))
+
+		return
+	}
+
+	httpStatus := http.StatusOK
+	if response.Status == statusNotReady {
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+
+	if _, err := w.Write(data); err != nil {
+		s.logger.Error("Failed to write readiness response",
+			slog.String("correlation_id", correlationID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *Server) checkAPIKeyStoreReady(ctx context.Context) *readyCheckResponse {
+	if s.apiKeyStore == nil {
+		return &readyCheckResponse{Status: statusDisabled}
+	}
+
+	return runReadyCheck(ctx, s.apiKeyStore.HealthCheck)
+}
+
+func (s *Server) checkSchemaReady(ctx context.Context) *readyCheckResponse {
+	if s.schemaChecker == nil {
+		return &readyCheckResponse{Status: statusDisabled}
+	}
+
+	var version int64
+
+	result := runReadyCheck(ctx, func(ctx context.Context) error {
+		var err error
+
+		version, err = s.schemaChecker.VerifySchema(ctx)
+
+		return err
+	})
+	result.Version = version
+
+	return result
+}
+
+// runReadyCheck times a single dependency check and maps its error to a check response.
+func runReadyCheck(ctx context.Context, check func(context.Context) error) *readyCheckResponse {
+	start := time.Now()
+
+	err := check(ctx)
+	latencyMs := time.Since(start).Milliseconds()
+
+	if err != nil {
+		return &readyCheckResponse{
+			Status:    statusUnhealthy,
+			LatencyMs: latencyMs,
+			Error:     err.Error(),
+		}
+	}
+
+	return &readyCheckResponse{
+		Status:    statusHealthy,
+		LatencyMs: latencyMs,
+	}
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// Replay event outcomes reported in ReplayEventResult.Status.
+const (
+	replayStatusStored    = "stored"
+	replayStatusDuplicate = "duplicate"
+	replayStatusFailed    = "failed"
+)
+
+// handleReplayLineageEvents handles lineage event replay for recovery after an outage.
+// POST /api/v1/lineage/events/replay - JSON array of RunEvents (same body as /api/v1/lineage/batch).
+//
+// Events go through the batch endpoint's validation and storage path, so the idempotency keys
+// decide what is already present: a duplicate is reported as such (not as an error) and is not
+// processed again, which means no view refresh, incident auto-resolution, correlation, or
+// job_run_changes notification is fired for it. Only newly stored events trigger those.
+//
+// Unlike the batch endpoint, the response (ReplayResponse) lists every event with its outcome,
+// indexed by position in the request (not the eventTime-sorted processing order).
+//
+// Request validation (returns RFC 7807):
+//   - 415 Unsupported Media Type: Content-Type must be application/json
+//   - 413 Payload Too Large: Request body exceeds MaxRequestSize
+//   - 400 Bad Request: Empty body, invalid JSON, or empty event array
+//   - 422 Unprocessable Entity: Invalid event sequence
+//
+// Success responses (ReplayResponse):
+//   - 200 OK: Every event stored or duplicate
+//   - 207 Multi-Status: Some events failed, some stored or duplicate
+//   - 422 Unprocessable Entity: All events failed
+func (s *Server) handleReplayLineageEvents(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+	correlationID := middleware.GetCorrelationID(r.Context())
+
+	if problem := s.checkLineageContentType(r); problem != nil {
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	events, problem := s.parseLineageRequest(r)
+	if problem != nil {
+		s.logger.ErrorContext(r.Context(), "Failed to parse replay events",
+			slog.String("correlation_id", correlationID),
+			slog.Any("problem", problem),
+		)
+
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	// Processing may reorder events by eventTime; report against the request order.
+	requestIndex := make(map[*ingestion.RunEvent]int, len(events))
+	for i, event := range events {
+		requestIndex[event] = i
+	}
+
+	// Replayed events are old by definition, so the event time skew check does not apply
+	sortedEvents, validationErrors, problem := s.validateEvents(s.eventValidator(true), events)
+	if problem != nil {
+		middleware.RecordTrace(r.Context(), middleware.TraceStageValidation, "failed: "+problem.Detail)
+
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	middleware.RecordTrace(r.Context(), middleware.TraceStageValidation,
+		fmt.Sprintf("%d of %d events invalid", countErrors(validationErrors), len(sortedEvents)))
+
+	storeResults, problem := s.storeValidEvents(r.Context(), ingestingPlugin(r.Context()), sortedEvents, validationErrors)
+	if problem != nil {
+		middleware.RecordTrace(r.Context(), middleware.TraceStageStore, "failed: "+problem.Detail)
+
+		WriteErrorResponse(w, r, s.logger, problem)
+
+		return
+	}
+
+	response := s.buildReplayResponse(correlationID, sortedEvents, requestIndex, validationErrors, storeResults)
+
+	middleware.RecordTrace(r.Context(), middleware.TraceStageStore, fmt.Sprintf("replay stored=%d duplicate=%d failed=%d",
+		response.Summary.Stored, response.Summary.Duplicate, response.Summary.Failed))
+
+	statusCode := s.sendReplayResponse(w, r, response)
+
+	s.logger.Info("Lineage replay processed",
+		slog.String("correlation_id", correlationID),
+		slog.String("status", response.Status),
+		slog.Int("received", response.Summary.Received),
+		slog.Int("stored", response.Summary.Stored),
+		slog.Int("duplicate", response.Summary.Duplicate),
+		slog.Int("failed", response.Summary.Failed),
+		slog.Int("status_code", statusCode),
+		slog.Duration("duration", time.Since(startTime)),
+	)
+}
+
+// buildReplayResponse classifies each event as stored, duplicate, or failed and attaches the
+// validation warnings of accepted events. events, validationErrors, and storeResults are in
+// processing order; requestIndex maps each event back to its position in the request.
+func (s *Server) buildReplayResponse(
+	correlationID string,
+	events []*ingestion.RunEvent,
+	requestIndex map[*ingestion.RunEvent]int,
+	validationErrors []error,
+	storeResults []*ingestion.EventStoreResult,
+) *ReplayResponse {
+	results := make([]ReplayEventResult, len(events))
+	summary := ReplaySummary{Received: len(events)}
+
+	for i, event := range events {
+		result := ReplayEventResult{
+			Index:     requestIndex[event],
+			RunID:     event.Run.ID,
+			EventType: string(event.EventType),
+		}
+
+		switch {
+		case validationErrors[i] != nil:
+			result.Status, result.Reason = replayStatusFailed, validationErrors[i].Error()
+		case storeResults[i] == nil:
+			result.Status, result.Reason = replayStatusFailed, "storage result missing"
+		case storeResults[i].Error != nil:
+			result.Status, result.Reason = replayStatusFailed, storeResults[i].Error.Error()
+		case storeResults[i].Duplicate:
+			result.Status = replayStatusDuplicate
+		default:
+			result.Status = replayStatusStored
+		}
+
+		if result.Status != replayStatusFailed {
+			result.Warnings = s.eventWarnings(correlationID, event)
+		}
+
+		switch result.Status {
+		case replayStatusStored:
+			summary.Stored++
+		case replayStatusDuplicate:
+			summary.Duplicate++
+		default:
+			summary.Failed++
+		}
+
+		results[result.Index] = result
+	}
+
+	return &ReplayResponse{
+		Status:        importStatus(summary.Stored+summary.Duplicate, summary.Failed),
+		Summary:       summary,
+		Events:        results,
+		CorrelationID: correlationID,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// sendReplayResponse marshals and writes the replay response.
+// Returns the HTTP status code for logging purposes.
+func (s *Server) sendReplayResponse(w http.ResponseWriter, r *http.Request, response *ReplayResponse) int {
+	statusCode := http.StatusOK
+
+	switch response.Status {
+	case "partial_success":
+		statusCode = http.StatusMultiStatus
+	case "error":
+		statusCode = http.StatusUnprocessableEntity
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("Failed to marshal replay response",
+			slog.String("correlation_id", response.CorrelationID),
+			slog.String("error", err.Error()),
+		)
+		WriteErrorResponse(w, r, s.logger, InternalServerError("Failed to encode response"))
+
+		return http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if _, err := w.Write(data); err != nil {
+		s.logger.Error("Failed to write replay response",
+			slog.String("correlation_id", response.CorrelationID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return statusCode
+}
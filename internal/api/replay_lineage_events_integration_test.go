@@ -0,0 +1,119 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postLineageReplay is a helper to POST OpenLineage events to the replay endpoint.
+func (ts *testServer) postLineageReplay(t *testing.T, events []LineageEvent) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(events)
+	require.NoError(t, err, "Failed to marshal lineage events")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/lineage/events/replay", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+ts.apiKey)
+
+	rr := httptest.NewRecorder()
+	ts.server.httpServer.Handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// parseReplayResponse asserts the status code and decodes the ReplayResponse body.
+func parseReplayResponse(t *testing.T, rr *httptest.ResponseRecorder, expectedStatus int) *ReplayResponse {
+	t.Helper()
+
+	require.Equal(t, expectedStatus, rr.Code, "Response body: %s", rr.Body.String())
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var response ReplayResponse
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response), "Failed to parse response JSON")
+	assert.NotEmpty(t, response.CorrelationID, "Missing correlation_id")
+	assert.NotEmpty(t, response.Timestamp, "Missing timestamp")
+
+	return &response
+}
+
+// TestLineageReplay_ReportsDuplicatesStoredAndFailed tests replaying a queue in which one event
+// already landed before the outage, one did not, and one is invalid.
+// Expected: 207 with a per-event report; the duplicate is not an error and is not stored twice.
+func TestLineageReplay_ReportsDuplicatesStoredAndFailed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	now := time.Now()
+	landed := createValidLineageEvent("replay-landed", "START", now)
+	lost := createValidLineageEvent("replay-lost", "START", now)
+	invalid := createValidLineageEvent("replay-invalid", "START", now)
+	invalid.Job.Name = ""
+
+	validateLineageResponse(t, ts.postLineageEvents(t, []LineageEvent{landed}), http.StatusOK)
+
+	response := parseReplayResponse(t, ts.postLineageReplay(t, []LineageEvent{lost, landed, invalid}),
+		http.StatusMultiStatus)
+
+	assert.Equal(t, "partial_success", response.Status)
+	assert.Equal(t, ReplaySummary{Received: 3, Stored: 1, Duplicate: 1, Failed: 1}, response.Summary)
+	require.Len(t, response.Events, 3, "Every event must be reported")
+
+	assert.Equal(t, ReplayEventResult{
+		Index: 0, RunID: lost.Run.ID, EventType: "START", Status: replayStatusStored,
+	}, response.Events[0])
+	assert.Equal(t, ReplayEventResult{
+		Index: 1, RunID: landed.Run.ID, EventType: "START", Status: replayStatusDuplicate,
+	}, response.Events[1])
+	assert.Equal(t, 2, response.Events[2].Index)
+	assert.Equal(t, replayStatusFailed, response.Events[2].Status)
+	assert.Contains(t, response.Events[2].Reason, "job.name")
+
+	assert.Equal(t, 1, ts.countStoredEvents(ctx, t, landed.Run.ID), "Duplicate must not be stored again")
+	ts.verifyEventStored(ctx, t, lost.Run.ID, "START")
+	ts.assertEventNotStored(ctx, t, invalid.Run.ID)
+}
+
+// TestLineageReplay_AllDuplicatesInRequestOrder tests replaying a single-run queue that fully
+// landed, sent out of eventTime order.
+// Expected: 200 with every event reported as duplicate at its request index.
+func TestLineageReplay_AllDuplicatesInRequestOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	now := time.Now()
+	start := createValidLineageEvent("replay-complete-run", "START", now)
+	complete := createValidLineageEvent("replay-complete-run", "COMPLETE", now.Add(time.Minute))
+
+	validateLineageResponse(t, ts.postLineageEvents(t, []LineageEvent{start, complete}), http.StatusOK)
+
+	// COMPLETE first: processing sorts by eventTime, the report must not
+	response := parseReplayResponse(t, ts.postLineageReplay(t, []LineageEvent{complete, start}), http.StatusOK)
+
+	assert.Equal(t, "success", response.Status)
+	assert.Equal(t, ReplaySummary{Received: 2, Duplicate: 2}, response.Summary)
+	require.Len(t, response.Events, 2)
+
+	for i, eventType := range []string{"COMPLETE", "START"} {
+		assert.Equal(t, i, response.Events[i].Index)
+		assert.Equal(t, eventType, response.Events[i].EventType)
+		assert.Equal(t, replayStatusDuplicate, response.Events[i].Status)
+	}
+}
@@ -2,10 +2,17 @@
 package api
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,20 +41,52 @@ type (
 	}
 )
 
+// routableMethods are the methods probed when building the Allow header of a 405 response.
+var routableMethods = []string{ //nolint:gochecknoglobals
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
 // Routes sets up all HTTP routes for the API server.
+// Routes being phased out are wrapped with middleware.Deprecated, so their responses carry the
+// Deprecation and Sunset headers clients can detect programmatically.
 func (s *Server) setupRoutes(mux *http.ServeMux) {
 	// Public health endpoints
 	s.registerPublicRoutes(
 		mux,
-		Route{"GET /ping", s.handlePing},     // K8s liveness probe
+		Route{"GET /ping", s.handlePing},     // Liveness probe (/livez skips the middleware chain)
 		Route{"GET /ready", s.handleReady},   // K8s readiness probe
 		Route{"GET /health", s.handleHealth}, // Basic health check - status, uptime, version
-		Route{"/", s.handleNotFound},         // Catch-all handler for 404 responses
+		Route{"/", s.notFoundHandler(mux)},   // Catch-all handler for 404/405 responses
 	)
 
-	// Lineage endpoints
-	mux.HandleFunc("POST /api/v1/lineage", s.handleLineageEvent)        // Single event (standard OL API)
-	mux.HandleFunc("POST /api/v1/lineage/batch", s.handleLineageEvents) // Batch events
+	// Prometheus scrape endpoint (public like the probes)
+	if s.maintenanceChecker != nil {
+		s.registerPublicRoutes(mux, Route{"GET /metrics", s.handleMetrics})
+	}
+
+	// Lineage endpoints (retries carrying the same Idempotency-Key get the original response,
+	// bodies trickling in slower than the minimum rate are aborted with 408)
+	mux.Handle("POST /api/v1/lineage", s.ingest(s.handleLineageEvent))        // Single event (standard OL API)
+	mux.Handle("POST /api/v1/lineage/batch", s.ingest(s.handleLineageEvents)) // Batch events
+	// Multipart file import (backfills)
+	mux.Handle("POST /api/v1/lineage/import", s.ingest(s.handleLineageImport))
+	// Recovery replay (per-event report)
+	mux.Handle("POST /api/v1/lineage/events/replay", s.ingest(s.handleReplayLineageEvents))
+
+	// Async batch progress (POST /api/v1/lineage/batch with Prefer: respond-async)
+	if s.lineageBatchStore != nil {
+		mux.HandleFunc("GET /api/v1/lineage/batches/{batch_id}", s.handleGetLineageBatch)
+	}
+
+	// Dataset and job search by name fragment (lineage explorer)
+	if s.lineageSearch != nil {
+		mux.HandleFunc("GET /api/v1/lineage/search", s.handleLineageSearch)
+	}
+
+	// Bulk test-data cleanup (admin, opt-in)
+	if s.jobRunCleanupStore != nil {
+		mux.HandleFunc("DELETE /api/v1/lineage/job-runs", s.handleDeleteJobRuns)
+	}
 
 	// Correlation endpoints (UI)
 	if s.correlationStore != nil {
@@ -55,6 +94,49 @@ func (s *Server) setupRoutes(mux *http.ServeMux) {
 		mux.HandleFunc("GET /api/v1/incidents/counts", s.handleGetIncidentCounts)
 		mux.HandleFunc("GET /api/v1/incidents/{id}", s.handleGetIncidentDetails)
 		mux.HandleFunc("GET /api/v1/health/correlation", s.handleGetCorrelationHealth)
+		mux.HandleFunc("GET /api/v1/job-runs/{jobRunID}/correlations", s.handleGetJobRunCorrelations)
+	}
+
+	// Producer activity (integration health)
+	if s.producerStore != nil {
+		mux.HandleFunc("GET /api/v1/producers", s.handleListProducers)
+	}
+
+	// Offboarding: delete all lineage of a producer (admin, confirmed). Never registered without
+	// authentication, where requirePermission lets every request through
+	if s.producerPurge != nil && s.apiKeyStore != nil {
+		mux.HandleFunc("DELETE /api/v1/producers/{producer}", s.handlePurgeProducer)
+	}
+
+	// Live correlation feed (SSE)
+	if s.correlationSubscriber != nil {
+		mux.HandleFunc("GET /api/v1/correlations/stream", s.handleStreamCorrelations)
+	}
+
+	// Request trace lookup (debugging by X-Correlation-ID)
+	if s.requestTracer != nil {
+		mux.HandleFunc("GET /api/v1/trace/{correlationID}", s.handleGetRequestTrace)
+	}
+
+	// Per-subsystem health for status pages
+	mux.HandleFunc("GET /api/v1/health/detailed", s.handleGetDetailedHealth)
+
+	// Admin-only routes are never registered without authentication, where requirePermission
+	// lets every request through
+	if s.apiKeyStore != nil {
+		// Effective configuration (admin diagnostics)
+		mux.HandleFunc("GET /api/v1/debug/config", s.handleGetDebugConfig)
+
+		// Synthetic lineage for load tests and demos (opt-in, never in production)
+		if s.config.EnableSeed {
+			mux.HandleFunc("POST /api/v1/debug/seed", s.handleDebugSeed)
+		}
+	}
+
+	// API key administration
+	if s.apiKeyStore != nil {
+		mux.HandleFunc("PATCH /api/v1/keys/{id}", s.handlePatchAPIKey)
+		mux.HandleFunc("GET /api/v1/whoami", s.handleGetWhoami)
 	}
 
 	// Resolution endpoints (write operations)
@@ -83,6 +165,7 @@ func (s *Server) setupRoutes(mux *http.ServeMux) {
 func (s *Server) registerPublicRoutes(mux *http.ServeMux, routes ...Route) {
 	validHTTPMethods := map[string]bool{
 		"GET":    true,
+		"HEAD":   true,
 		"POST":   true,
 		"PUT":    true,
 		"PATCH":  true,
@@ -115,6 +198,26 @@ func (s *Server) registerPublicRoutes(mux *http.ServeMux, routes ...Route) {
 	}
 }
 
+// livezPath is the strict liveness probe, answered ahead of the middleware chain (see withLivez).
+const livezPath = "/livez"
+
+// withLivez answers GET and HEAD /livez with a bare 200 before next (the middleware chain) runs,
+// so liveness means only that the process serves HTTP. No correlation ID, tracing, maintenance
+// mode, auth, rate limiting or logging is involved, and no dependency is touched: a database or
+// Kafka outage must fail /ready, never liveness (a failed liveness probe restarts the pod, which
+// cannot fix a downstream dependency). /ping remains for existing probes.
+func withLivez(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == livezPath && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // handlePing responds to ping requests for basic server validation.
 func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
 	correlationID := middleware.GetCorrelationID(r.Context())
@@ -138,10 +241,19 @@ func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
 // the HTTP and Kafka ingestion paths. If the ingestion store is unhealthy, neither
 // ingestion path can function.
 //
+// The default check is shallow and fast for K8s probes. Pass ?deep=true for an opt-in
+// check of every dependency and the schema version (see handleDeepReady).
+//
 // Response codes:
 //   - 200 OK: Storage backend is healthy and ready to accept traffic
 //   - 503 Service Unavailable: Storage backend is unhealthy or unreachable
 func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if deep, _ := strconv.ParseBool(r.URL.Query().Get("deep")); deep {
+		s.handleDeepReady(w, r)
+
+		return
+	}
+
 	correlationID := middleware.GetCorrelationID(r.Context())
 
 	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
@@ -228,13 +340,117 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleNotFound returns RFC 7807 compliant 404 responses for unknown endpoints.
-func (s *Server) handleNotFound(w http.ResponseWriter, r *http.Request) {
-	WriteErrorResponse(w, r, s.logger, NotFound("The requested resource was not found"))
+// notFoundHandler returns the catch-all handler for requests that match no route in mux.
+//
+// The "/" catch-all matches every method, so Go's ServeMux never produces its own 405. Instead
+// this handler probes mux for the other methods on the same path:
+//   - Path routed for other methods → RFC 7807 405 with an Allow header (e.g., POST /ping)
+//   - Unknown path → RFC 7807 404
+func (s *Server) notFoundHandler(mux *http.ServeMux) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if allowed := allowedMethods(mux, r); len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			WriteErrorResponse(w, r, s.logger, MethodNotAllowed(
+				"Method "+r.Method+" is not allowed on "+r.URL.Path+"; allowed: "+strings.Join(allowed, ", ")))
+
+			return
+		}
+
+		WriteErrorResponse(w, r, s.logger, NotFound("The requested resource was not found"))
+	}
+}
+
+// allowedMethods returns the methods routed for r's path in mux, excluding the catch-all.
+// A GET route also allows HEAD, matching ServeMux semantics.
+func allowedMethods(mux *http.ServeMux, r *http.Request) []string {
+	probe := r.Clone(r.Context())
+
+	var allowed []string
+
+	for _, method := range routableMethods {
+		probe.Method = method
+
+		if _, pattern := mux.Handler(probe); pattern != "" && pattern != "/" {
+			allowed = append(allowed, method)
+		}
+	}
+
+	return allowed
+}
+
+// idempotent deduplicates retries of a write endpoint by Idempotency-Key (see middleware.Idempotency).
+// Bodies are hashed up to MaxImportSize, the largest body any lineage endpoint accepts.
+func (s *Server) idempotent(next http.HandlerFunc) http.Handler {
+	return middleware.Idempotency(s.idempotencyCache, s.config.MaxImportSize, s.logger)(next)
 }
 
-// hasJSONContentType checks if Content-Type header starts with "application/json".
-// This allows charset parameters (e.g., "application/json; charset=utf-8").
-func hasJSONContentType(contentType string) bool {
-	return strings.HasPrefix(strings.TrimSpace(contentType), "application/json")
+// ingest wraps a lineage ingestion endpoint: idempotent, and aborting bodies that arrive slower
+// than BodyReadMinBytes per BodyReadWindow (see middleware.MinBodyRate).
+func (s *Server) ingest(next http.HandlerFunc) http.Handler {
+	return middleware.MinBodyRate(s.config.BodyReadMinBytes, s.config.BodyReadWindow)(s.idempotent(next))
+}
+
+// checkJSONContentType returns a 415 problem unless contentType is application/json with a
+// UTF-8 charset, the only encoding request bodies are decoded as. A missing charset means UTF-8
+// (RFC 8259); "utf-8" and "utf8" are accepted in any case. Rejecting other charsets (e.g.
+// "charset=utf-16") keeps mis-encoded bodies from being silently misread.
+func checkJSONContentType(contentType string) *ProblemDetail {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/json" {
+		return UnsupportedMediaType("Content-Type must be application/json")
+	}
+
+	charset, ok := params["charset"]
+	if ok && !strings.EqualFold(charset, "utf-8") && !strings.EqualFold(charset, "utf8") {
+		return UnsupportedMediaType(fmt.Sprintf("Unsupported charset %q: request bodies must be UTF-8", charset))
+	}
+
+	return nil
+}
+
+// checkLineageContentType checks the Content-Type of a lineage ingestion request (see
+// checkJSONContentType). With ContentTypeSniffSize set, a request that declares no Content-Type
+// is accepted when the first non-whitespace byte within the first ContentTypeSniffSize bytes of
+// its body opens a JSON array or object, and a warning is logged. The sniffed bytes stay in
+// r.Body. A declared type that is not JSON is always rejected.
+func (s *Server) checkLineageContentType(r *http.Request) *ProblemDetail {
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "" || s.config.ContentTypeSniffSize <= 0 {
+		return checkJSONContentType(contentType)
+	}
+
+	reader := bufio.NewReaderSize(r.Body, s.config.ContentTypeSniffSize)
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{reader, r.Body}
+
+	// Peek returns fewer bytes (with io.EOF) for shorter bodies
+	head, err := reader.Peek(s.config.ContentTypeSniffSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return bodyReadProblem(err)
+	}
+
+	head = bytes.TrimLeft(head, " \t\r\n")
+	if len(head) == 0 || (head[0] != '[' && head[0] != '{') {
+		return checkJSONContentType(contentType)
+	}
+
+	s.logger.WarnContext(r.Context(), "Request without Content-Type accepted as JSON",
+		slog.String("correlation_id", middleware.GetCorrelationID(r.Context())),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+	)
+
+	return nil
+}
+
+// bodyReadProblem maps a request body read error to a ProblemDetail: 408 when the client sent
+// the body too slowly (see middleware.MinBodyRate), 400 otherwise.
+func bodyReadProblem(err error) *ProblemDetail {
+	if errors.Is(err, middleware.ErrBodyTooSlow) {
+		return RequestTimeout(err.Error())
+	}
+
+	return BadRequest("Failed to read request body")
 }
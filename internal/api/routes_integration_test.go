@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodNotAllowed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	server, _ := setupHealthTestServer(ctx, t, nil)
+
+	serve := func(t *testing.T, method, path string) (*httptest.ResponseRecorder, ProblemDetail) {
+		t.Helper()
+
+		req := httptest.NewRequest(method, path, nil)
+		rr := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, contentTypeProblemJSON, rr.Header().Get("Content-Type"))
+
+		var problem ProblemDetail
+
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+
+		return rr, problem
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		allow  string
+	}{
+		{name: "POST On Ping", method: http.MethodPost, path: "/ping", allow: "GET, HEAD"},
+		{name: "GET On Lineage", method: http.MethodGet, path: "/api/v1/lineage", allow: "POST"},
+		{name: "DELETE On Incident", method: http.MethodDelete, path: "/api/v1/incidents/42", allow: "GET, HEAD"},
+		{name: "GET On Incident Status", method: http.MethodGet, path: "/api/v1/incidents/42/status", allow: "PATCH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" Returns 405 With Allow Header", func(t *testing.T) {
+			rr, problem := serve(t, tt.method, tt.path)
+
+			assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+			assert.Equal(t, tt.allow, rr.Header().Get("Allow"))
+			assert.Equal(t, http.StatusMethodNotAllowed, problem.Status)
+			assert.Equal(t, "Method Not Allowed", problem.Title)
+			assert.Contains(t, problem.Detail, tt.allow)
+		})
+	}
+
+	t.Run("Unknown Path Returns 404 Without Allow Header", func(t *testing.T) {
+		rr, problem := serve(t, http.MethodGet, "/api/v1/does-not-exist")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Empty(t, rr.Header().Get("Allow"))
+		assert.Equal(t, http.StatusNotFound, problem.Status)
+	})
+
+	t.Run("Unknown Path Returns 404 For Any Method", func(t *testing.T) {
+		rr, _ := serve(t, http.MethodPost, "/api/v1/lineage/events")
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Empty(t, rr.Header().Get("Allow"))
+	})
+}
@@ -10,11 +10,14 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/correlator-io/correlator/internal/api/middleware"
 	"github.com/correlator-io/correlator/internal/correlation"
+	"github.com/correlator-io/correlator/internal/features"
+	"github.com/correlator-io/correlator/internal/health"
 	"github.com/correlator-io/correlator/internal/ingestion"
 	"github.com/correlator-io/correlator/internal/storage"
 )
@@ -32,7 +35,32 @@ type Server struct {
 	correlationStore correlation.Store           // Optional: enables correlation API endpoints (nil = disabled)
 	resolutionStore  correlation.ResolutionStore // Optional: enables resolution write endpoints (nil = disabled)
 	validator        *ingestion.Validator        // Shared validator (thread-safe, created once)
+	interceptors     ingestion.Interceptors      // Pre-store enrichment / rejection hooks (nil = none)
 	healthChecker    *HealthChecker              // Dependency health checker for /health endpoint
+	schemaChecker    SchemaChecker               // Optional: schema version check for /ready?deep=true (nil = disabled)
+	requestTracer    *middleware.RequestTracer   // Optional: enables GET /api/v1/trace/{correlationID} (nil = disabled)
+
+	correlationSubscriber CorrelationSubscriber       // Optional: enables the correlation SSE stream (nil = disabled)
+	jobRunCleanupStore    JobRunCleanupStore          // Optional: enables DELETE /api/v1/lineage/job-runs (nil = disabled)
+	maintenanceChecker    MaintenanceChecker          // Optional: table bloat in /health and GET /metrics (nil = disabled)
+	maintenanceMode       *middleware.MaintenanceMode // Rejects business requests with 503 while enabled
+	shutdown              chan struct{}               // Closed when HTTP shutdown begins, ending long-lived streams
+	shutdownOnce          sync.Once
+
+	lineageBatchStore LineageBatchStore   // Optional: enables async batches (nil = Prefer: respond-async ignored)
+	producerStore     ProducerStore       // Optional: enables GET /api/v1/producers (nil = disabled)
+	producerPurge     ProducerPurgeStore  // Optional: DELETE /api/v1/producers/{producer} (nil or no auth = disabled)
+	lineageSearch     LineageSearchStore  // Optional: enables GET /api/v1/lineage/search (nil = disabled)
+	quotaStore        IngestionQuotaStore // Optional: enforces per-plugin ingestion quotas (nil = disabled)
+	batchWorkerWake   chan struct{}       // Signals the batch worker that a batch was queued
+	batchWorkerCancel context.CancelFunc  // Stops the batch worker (nil until ListenAndServe)
+	batchWorkerWg     sync.WaitGroup
+
+	idempotencyCache *middleware.IdempotencyCache // Optional: replays responses to Idempotency-Key retries (nil = off)
+	workers          *health.Workers              // Optional: background worker runs in the detailed health (nil = none)
+
+	orphanReconciliation OrphanReconciliationReporter // Optional: orphan reconciliation counts on GET /metrics
+	verboseEvents        *verboseEventLogger          // Optional: per-event dumps for selected producers (nil = off)
 }
 
 // BuildInfo holds build-time metadata injected via -ldflags.
@@ -58,6 +86,22 @@ type Dependencies struct {
 	CorrelationStore correlation.Store           // REQUIRED — panics if nil
 	ResolutionStore  correlation.ResolutionStore // nil = resolution endpoints disabled
 	KafkaHealth      KafkaHealthChecker          // nil = Kafka disabled in /health
+	Validator        *ingestion.Validator        // nil = default validator (default facet limits)
+	Interceptors     ingestion.Interceptors      // nil = events are stored as validated (run in order)
+	SchemaChecker    SchemaChecker               // nil = schema check disabled in /ready?deep=true
+
+	CorrelationSubscriber CorrelationSubscriber // nil = GET /api/v1/correlations/stream disabled
+	JobRunCleanupStore    JobRunCleanupStore    // nil = DELETE /api/v1/lineage/job-runs disabled
+	MaintenanceChecker    MaintenanceChecker    // nil = table bloat check and GET /metrics disabled
+	LineageBatchStore     LineageBatchStore     // nil = async batches and GET /api/v1/lineage/batches/{id} disabled
+	ProducerStore         ProducerStore         // nil = GET /api/v1/producers disabled
+	ProducerPurgeStore    ProducerPurgeStore    // nil (or no APIKeyStore) = DELETE /api/v1/producers/{producer} disabled
+	LineageSearchStore    LineageSearchStore    // nil = GET /api/v1/lineage/search disabled
+	IngestionQuotaStore   IngestionQuotaStore   // nil = per-plugin ingestion quotas not enforced
+	FeatureFlags          *features.Flags       // nil = every feature flag evaluates as off
+	Workers               *health.Workers       // nil = no background workers in GET /api/v1/health/detailed
+
+	OrphanReconciliation OrphanReconciliationReporter // nil = no orphan reconciliation metrics
 }
 
 // NewServer creates a new HTTP server instance with structured logging and middleware stack.
@@ -67,10 +111,11 @@ type Dependencies struct {
 //   - deps: Runtime dependencies (stores, middleware, health checkers)
 //   - build: Build-time metadata (version, commit, build time)
 func NewServer(cfg *ServerConfig, deps Dependencies, build BuildInfo) *Server {
-	// Create structured logger with configured log level
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	// Create structured logger with configured log level; records logged with a request context
+	// carry its correlation ID
+	logger := slog.New(middleware.NewContextHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: cfg.LogLevel,
-	}))
+	})))
 
 	if deps.IngestionStore == nil || deps.CorrelationStore == nil {
 		logger.Error("LineageStore is required - cannot start server without core functionality")
@@ -80,8 +125,14 @@ func NewServer(cfg *ServerConfig, deps Dependencies, build BuildInfo) *Server {
 	// Create base HTTP mux
 	mux := http.NewServeMux()
 
-	// Create validator once (thread-safe, no mutable state)
-	validator := ingestion.NewValidator()
+	// Create validator once (thread-safe, no mutable state) unless the caller shares one
+	validator := deps.Validator
+	if validator == nil {
+		validator = ingestion.NewValidator()
+	}
+
+	healthChecker := NewHealthChecker(deps.IngestionStore, deps.KafkaHealth).
+		WithMaintenance(deps.MaintenanceChecker, float64(cfg.MaintenanceDeadTuplePercent)/100) //nolint:mnd
 
 	// Create server instance for route setup
 	server := &Server{
@@ -94,7 +145,29 @@ func NewServer(cfg *ServerConfig, deps Dependencies, build BuildInfo) *Server {
 		correlationStore: deps.CorrelationStore,
 		resolutionStore:  deps.ResolutionStore,
 		validator:        validator,
-		healthChecker:    NewHealthChecker(deps.IngestionStore, deps.KafkaHealth),
+		interceptors:     deps.Interceptors,
+		healthChecker:    healthChecker,
+		schemaChecker:    deps.SchemaChecker,
+		requestTracer:    middleware.NewRequestTracer(cfg.RequestTraceMaxRequests, cfg.RequestTraceRetention),
+
+		correlationSubscriber: deps.CorrelationSubscriber,
+		jobRunCleanupStore:    deps.JobRunCleanupStore,
+		maintenanceChecker:    deps.MaintenanceChecker,
+		maintenanceMode:       middleware.NewMaintenanceMode(cfg.MaintenanceRetryAfter),
+		shutdown:              make(chan struct{}),
+
+		lineageBatchStore: deps.LineageBatchStore,
+		producerStore:     deps.ProducerStore,
+		producerPurge:     deps.ProducerPurgeStore,
+		lineageSearch:     deps.LineageSearchStore,
+		quotaStore:        deps.IngestionQuotaStore,
+		batchWorkerWake:   make(chan struct{}, 1),
+
+		idempotencyCache: middleware.NewIdempotencyCache(cfg.IdempotencyMaxKeys, cfg.IdempotencyKeyTTL),
+		workers:          deps.Workers,
+
+		orphanReconciliation: deps.OrphanReconciliation,
+		verboseEvents:        newVerboseEventLogger(cfg.VerboseLogProducers, os.Stdout),
 	}
 
 	// Set up all API routes
@@ -113,33 +186,115 @@ func NewServer(cfg *ServerConfig, deps Dependencies, build BuildInfo) *Server {
 		logger.Warn("RateLimiter not configured - rate limiting middleware disabled")
 	}
 
+	if server.requestTracer != nil {
+		logger.Info("Request tracing enabled",
+			slog.Int("max_requests", cfg.RequestTraceMaxRequests),
+			slog.Duration("retention", cfg.RequestTraceRetention),
+		)
+	}
+
+	if server.idempotencyCache != nil {
+		logger.Info("Idempotency-Key deduplication enabled",
+			slog.Int("max_keys", cfg.IdempotencyMaxKeys),
+			slog.Duration("ttl", cfg.IdempotencyKeyTTL),
+		)
+	}
+
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		// Validate rejects this at startup; fail closed (trust no forwarding headers) if it slipped through
+		logger.Error("Ignoring invalid trusted proxies", slog.String("error", err.Error()))
+	} else if len(trustedProxies) > 0 {
+		logger.Info("Trusted proxies configured", slog.Any("trusted_proxies", cfg.TrustedProxies))
+	}
+
+	if cfg.ReadOnly {
+		logger.Info("Read-only mode enabled - mutating requests are rejected with 405")
+	}
+
+	if cfg.EnableSeed && deps.APIKeyStore == nil {
+		logger.Warn("CORRELATOR_ENABLE_SEED ignored - POST /api/v1/debug/seed requires authentication")
+	} else if cfg.EnableSeed {
+		logger.Warn("Synthetic lineage seeding enabled (POST /api/v1/debug/seed) - " +
+			"NEVER enable in production: anyone with an admin key can flood the store with fake runs")
+	}
+
+	preAuthSources, err := middleware.ParseTrustedProxies(cfg.PreAuthTrustedSources)
+	if err != nil {
+		// Validate rejects this at startup; fail closed (trust no pre-authentication header)
+		logger.Error("Ignoring invalid pre-authentication trusted sources", slog.String("error", err.Error()))
+	}
+
+	if cfg.PreAuthHeader != "" && deps.APIKeyStore != nil {
+		logger.Warn("Pre-authentication enabled - requests from trusted sources are identified by a header "+
+			"instead of an API key",
+			slog.String("header", cfg.PreAuthHeader),
+			slog.String("scopes_header", cfg.PreAuthScopesHeader),
+			slog.Any("trusted_sources", cfg.PreAuthTrustedSources),
+		)
+	}
+
+	if cfg.AllowImpersonation && deps.APIKeyStore != nil {
+		logger.Warn("Plugin impersonation enabled - admin keys may act as any plugin via X-Impersonate-Plugin")
+	}
+
+	if deps.FeatureFlags != nil {
+		logger.Info("Feature flags enabled")
+	}
+
 	// LineageStore is always configured (we panic if nil above)
 	logger.Info("Lineage store configured - all api endpoints enabled")
 
 	// Apply middleware chain using functional options pattern.
 	// Middleware executes in the order listed (top-to-bottom):
 	//   1. CorrelationID - generate correlation ID for all responses
-	//   2. Recovery - catch panics in all downstream middleware
-	//   3. Auth - identify client and set ClientContext (optional)
-	//   4. RateLimit - block requests before expensive operations (optional)
-	//   5. RequestLogger - log only legitimate requests (not rate-limited spam)
-	//   6. CORS - lightweight header manipulation
+	//   2. RealIP - resolve the client IP (forwarding headers only from trusted proxies)
+	//   3. Tracing - record the request lifecycle by correlation ID, including recovered panics (optional)
+	//   4. Recovery - catch panics in all downstream middleware
+	//   5. CORS - answer preflights before auth (browsers never send credentials on them) and put
+	//      CORS headers on every response, including 401/429/503, so browser clients can read errors
+	//   6. ReadOnly - reject mutating requests with 405 on a read-only instance, whatever the scopes (optional)
+	//   7. Maintenance - reject business requests with 503 while in maintenance mode (before auth hits the DB)
+	//   8. ConcurrencyLimit - shed requests beyond the in-flight limit with 503 (before auth hits the DB) (optional)
+	//   9. PreAuth - trust the identity header of mesh proxies, from trusted sources only (with auth, optional)
+	//  10. Auth - identify client and set ClientContext (optional)
+	//  11. Impersonation - let admin keys act as another plugin, or refuse the header (with auth)
+	//  12. RateLimit - block requests before expensive operations, per (impersonated) client (optional)
+	//  13. FeatureFlags - snapshot the feature flags of the (impersonated) plugin for handlers (optional)
+	//  14. RequestLogger - log only legitimate requests (not rate-limited spam), successes sampled
 	handler := middleware.Apply(mux,
 		middleware.WithCorrelationID(),
+		middleware.WithRealIP(trustedProxies),
+		middleware.WithTracing(server.requestTracer),
 		middleware.WithRecovery(logger),
+		middleware.WithCORS(cfg.ToCORSConfig()),
+		middleware.WithReadOnly(cfg.ReadOnly, logger),
+		middleware.WithMaintenance(server.maintenanceMode, logger),
+		middleware.WithConcurrencyLimit(cfg.MaxConcurrentRequests, cfg.ConcurrencyRetryAfter, logger),
+		middleware.WithPreAuth(deps.APIKeyStore, middleware.PreAuthConfig{
+			IdentityHeader: cfg.PreAuthHeader,
+			ScopesHeader:   cfg.PreAuthScopesHeader,
+			TrustedSources: preAuthSources,
+		}, logger),
 		middleware.WithAuth(deps.APIKeyStore, logger),
+		middleware.WithImpersonation(deps.APIKeyStore, cfg.AllowImpersonation, logger),
 		middleware.WithRateLimit(deps.RateLimiter, logger),
-		middleware.WithRequestLogger(logger),
-		middleware.WithCORS(cfg.ToCORSConfig()),
+		features.Middleware(deps.FeatureFlags),
+		middleware.WithRequestLogger(logger, cfg.RequestLogSampleRate),
 	)
 
 	httpServer := &http.Server{
 		Addr:         cfg.Address(),
-		Handler:      handler,
+		Handler:      withLivez(handler), // /livez bypasses the whole middleware chain
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
 	}
 
+	// http.Server.Shutdown waits for active requests; end SSE streams so it can complete
+	httpServer.RegisterOnShutdown(func() {
+		server.shutdownOnce.Do(func() { close(server.shutdown) })
+	})
+
 	// Set the httpServer field for the existing server instance
 	server.httpServer = httpServer
 
@@ -148,7 +303,9 @@ func NewServer(cfg *ServerConfig, deps Dependencies, build BuildInfo) *Server {
 
 // ListenAndServe starts the HTTP server in a background goroutine and returns
 // an error channel. The server runs until Shutdown is called or a fatal error
-// occurs (e.g., port already in use).
+// occurs (e.g., port already in use, unreadable TLS files).
+//
+// With TLS configured (see ServerConfig.TLSEnabled) it serves HTTPS with HTTP/2; plain HTTP otherwise.
 //
 // Callers should select on the returned channel and call Shutdown for cleanup.
 // This method is non-blocking — it returns immediately after starting the server.
@@ -165,17 +322,21 @@ func NewServer(cfg *ServerConfig, deps Dependencies, build BuildInfo) *Server {
 func (s *Server) ListenAndServe() <-chan error {
 	s.startTime = time.Now()
 
+	s.startLineageBatchWorker()
+
 	serverErrors := make(chan error, 1)
 
 	go func() {
 		s.logger.Info("Starting Correlator API server",
 			slog.String("address", s.config.Address()),
+			slog.Bool("tls", s.config.TLSEnabled()),
+			slog.Bool("mtls", s.config.TLSClientCAFile != ""),
 			slog.Duration("read_timeout", s.config.ReadTimeout),
 			slog.Duration("write_timeout", s.config.WriteTimeout),
 			slog.Duration("shutdown_timeout", s.config.ShutdownTimeout),
 		)
 
-		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := s.serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Error("Server failed to start",
 				slog.String("address", s.config.Address()),
 				slog.String("error", err.Error()),
@@ -188,15 +349,33 @@ func (s *Server) ListenAndServe() <-chan error {
 	return serverErrors
 }
 
+// serve runs the HTTP server until it is shut down: HTTPS (with HTTP/2) when TLS is configured,
+// plain HTTP otherwise.
+func (s *Server) serve() error {
+	tlsConfig, err := s.config.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	if tlsConfig == nil {
+		return s.httpServer.ListenAndServe()
+	}
+
+	s.httpServer.TLSConfig = tlsConfig
+
+	return s.httpServer.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+}
+
 // Shutdown gracefully shuts down the HTTP server and closes all dependencies.
 // The provided context controls the shutdown deadline. If the context expires
 // before shutdown completes, in-flight requests are forcibly terminated.
 //
 // Shutdown order:
 //  1. HTTP server drain (stop accepting new connections, finish in-flight requests)
-//  2. Close API key store
-//  3. Close rate limiter
-//  4. Close ingestion store
+//  2. Stop the async batch worker (an interrupted batch is released for reprocessing)
+//  3. Close API key store
+//  4. Close rate limiter
+//  5. Close ingestion store
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Initiating server shutdown")
 
@@ -209,6 +388,8 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
 
+	s.stopLineageBatchWorker(ctx)
+
 	// Close all dependencies (best-effort - log failures but continue shutdown)
 	s.closeDependency("API key store", s.apiKeyStore)
 	s.closeDependency("rate limiter", s.rateLimiter)
@@ -221,6 +402,32 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// EnterMaintenance switches the server to maintenance mode: business endpoints immediately
+// respond 503 with Retry-After while /ping, /ready, /health and /metrics keep serving, so the
+// load balancer keeps the instance in rotation. Blocks until in-flight business requests have
+// completed or ctx ends (maintenance mode stays on either way; the error reports the timeout).
+func (s *Server) EnterMaintenance(ctx context.Context) error {
+	s.logger.Warn("Entering maintenance mode - rejecting business requests with 503")
+
+	if err := s.maintenanceMode.Enable(ctx); err != nil {
+		s.logger.Warn("Maintenance mode enabled before in-flight requests completed",
+			slog.String("error", err.Error()),
+		)
+
+		return err
+	}
+
+	s.logger.Warn("Maintenance mode in full effect - no business requests in flight")
+
+	return nil
+}
+
+// LeaveMaintenance ends maintenance mode and resumes serving business endpoints.
+func (s *Server) LeaveMaintenance() {
+	s.maintenanceMode.Disable()
+	s.logger.Info("Left maintenance mode - serving business requests")
+}
+
 // Start starts the HTTP server and blocks until shutdown signal (SIGINT/SIGTERM).
 // This is a convenience wrapper around ListenAndServe + Shutdown for simple
 // single-subsystem deployments. When running multiple subsystems (e.g., HTTP + Kafka),
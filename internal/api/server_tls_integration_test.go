@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCertificate is a generated certificate with its PEM encoding.
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCertificate creates a certificate for 127.0.0.1 signed by parent (self-signed CA when
+// parent is nil).
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeTestFile writes data to name in dir and returns its path.
+func writeTestFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+// TestServer_TLS starts the server with TLS (and then mTLS) on a free port and verifies that
+// clients speak HTTP/2 over HTTPS, that mTLS rejects clients without a certificate during the
+// handshake, and that API key authentication still applies to clients with one.
+func TestServer_TLS(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupTestServer(ctx, t)
+
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "correlator-test-ca", nil)
+	serverCert := newTestCertificate(t, "correlator", ca)
+	clientCert := newTestCertificate(t, "dbt-ol", ca)
+
+	caFile := writeTestFile(t, dir, "ca.pem", ca.certPEM)
+	certFile := writeTestFile(t, dir, "server.pem", serverCert.certPEM)
+	keyFile := writeTestFile(t, dir, "server-key.pem", serverCert.keyPEM)
+
+	baseConfig := *ts.server.config
+	baseConfig.MaintenanceDeadTuplePercent = defaultDeadTuplePercent
+	baseConfig.MaintenanceRetryAfter = defaultRetryAfter
+
+	startServer := func(clientCAFile string) string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		port := listener.Addr().(*net.TCPAddr).Port
+		require.NoError(t, listener.Close())
+
+		cfg := baseConfig
+		cfg.Host = "127.0.0.1"
+		cfg.Port = port
+		cfg.TLSCertFile = certFile
+		cfg.TLSKeyFile = keyFile
+		cfg.TLSClientCAFile = clientCAFile
+		require.NoError(t, cfg.Validate())
+
+		server := NewServer(&cfg, Dependencies{
+			APIKeyStore:      ts.server.apiKeyStore,
+			IngestionStore:   ts.lineageStore,
+			CorrelationStore: ts.lineageStore,
+		}, BuildInfo{})
+
+		serverErrors := server.ListenAndServe()
+
+		t.Cleanup(func() {
+			_ = server.httpServer.Shutdown(ctx) // Dependencies are closed by setupTestServer
+		})
+
+		require.Eventually(t, func() bool {
+			select {
+			case err := <-serverErrors:
+				t.Fatalf("server failed to start: %v", err)
+			default:
+			}
+
+			conn, err := net.Dial("tcp", cfg.Address())
+			if err == nil {
+				_ = conn.Close()
+			}
+
+			return err == nil
+		}, 5*time.Second, 20*time.Millisecond)
+
+		return "https://" + cfg.Address()
+	}
+
+	newClient := func(certificates ...tls.Certificate) *http.Client {
+		roots := x509.NewCertPool()
+		roots.AddCert(ca.cert)
+
+		return &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: certificates, MinVersion: tls.VersionTLS12},
+				ForceAttemptHTTP2: true,
+			},
+		}
+	}
+
+	get := func(client *http.Client, url, apiKey string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		require.NoError(t, err)
+
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+
+		return resp, err
+	}
+
+	t.Run("HTTPS with HTTP/2", func(t *testing.T) {
+		baseURL := startServer("")
+
+		resp, err := get(newClient(), baseURL+"/ping", "")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, resp.ProtoMajor, "HTTP/2 must be negotiated over TLS")
+	})
+
+	t.Run("mTLS", func(t *testing.T) {
+		baseURL := startServer(caFile)
+
+		_, err := get(newClient(), baseURL+"/ping", "")
+		require.Error(t, err, "clients without a certificate must fail the handshake")
+
+		pair, err := tls.X509KeyPair(clientCert.certPEM, clientCert.keyPEM)
+		require.NoError(t, err)
+
+		client := newClient(pair)
+
+		resp, err := get(client, baseURL+"/ping", "")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, resp.ProtoMajor)
+
+		// A client certificate does not replace the API key
+		resp, err = get(client, baseURL+"/api/v1/incidents", "")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		resp, err = get(client, baseURL+"/api/v1/incidents", ts.apiKey)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("IncompleteConfigRejected", func(t *testing.T) {
+		cfg := baseConfig
+		cfg.TLSCertFile = certFile
+		require.ErrorIs(t, cfg.Validate(), ErrIncompleteTLSConfig)
+
+		cfg = baseConfig
+		cfg.TLSClientCAFile = caFile
+		require.ErrorIs(t, cfg.Validate(), ErrClientCAWithoutTLS)
+	})
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/correlation"
+)
+
+// CorrelationSubscriber is the interface the API layer uses to receive newly correlated incidents.
+// Defined here (consumer in api package) following the Dependency Inversion Principle.
+//
+// Implemented by: correlation.Broadcaster.
+type CorrelationSubscriber interface {
+	Subscribe(buffer int) *correlation.Subscription
+}
+
+const (
+	// permissionLineageRead is the API key scope required to read correlations.
+	permissionLineageRead = "lineage:read"
+
+	// streamHeartbeatInterval is how often an SSE comment is sent on an idle stream so proxies
+	// and load balancers do not close the connection.
+	streamHeartbeatInterval = 15 * time.Second
+
+	// streamEventName is the SSE event type for correlated incidents.
+	streamEventName = "correlation"
+)
+
+// handleStreamCorrelations handles GET /api/v1/correlations/stream.
+// Streams newly correlated incidents as server-sent events (text/event-stream).
+//
+// Query Parameters (optional, exact match):
+//   - namespace: only incidents whose producing job is in this namespace
+//   - producer: only incidents whose producing job was emitted by this producer (e.g., "dbt")
+//
+// Each incident is sent as:
+//
+//	id: <incident id>
+//	event: correlation
+//	data: <CorrelationStreamEvent JSON>
+//
+// An SSE comment (": heartbeat") is sent every streamHeartbeatInterval. Only incidents
+// correlated after the client connects are delivered; there is no replay.
+// The stream ends when the client disconnects or the server shuts down.
+//
+// Requires the lineage:read permission when authentication is enabled (403 otherwise).
+func (s *Server) handleStreamCorrelations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	correlationID := middleware.GetCorrelationID(ctx)
+
+	if !s.requirePermission(w, r, permissionLineageRead) {
+		return
+	}
+
+	namespace := strings.TrimSpace(r.URL.Query().Get("namespace"))
+	producer := strings.TrimSpace(r.URL.Query().Get("producer"))
+
+	controller := http.NewResponseController(w)
+
+	// Streams outlive the server's WriteTimeout; clear the deadline for this connection only.
+	// Errors mean the writer does not support deadlines (e.g., test recorders) and are safe to ignore.
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	sub := s.correlationSubscriber.Subscribe(correlation.DefaultSubscriberBuffer)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx response buffering
+	w.WriteHeader(http.StatusOK)
+
+	if err := controller.Flush(); err != nil {
+		s.logger.ErrorContext(ctx, "Correlation stream unsupported by response writer",
+			slog.String("correlation_id", correlationID),
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	s.logger.InfoContext(ctx, "Correlation stream opened",
+		slog.String("correlation_id", correlationID),
+		slog.String("namespace", namespace),
+		slog.String("producer", producer),
+	)
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	sent := 0
+
+	defer func() {
+		s.logger.InfoContext(ctx, "Correlation stream closed",
+			slog.String("correlation_id", correlationID),
+			slog.Int("events_sent", sent),
+			slog.Int64("events_dropped", sub.Dropped()),
+		)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return // Client disconnected
+		case <-s.shutdown:
+			return
+		case incident, ok := <-sub.Events():
+			if !ok {
+				return // Broadcaster closed
+			}
+
+			if !matchesStreamFilter(incident, namespace, producer) {
+				continue
+			}
+
+			if err := writeCorrelationEvent(w, incident); err != nil {
+				return
+			}
+
+			if err := controller.Flush(); err != nil {
+				return
+			}
+
+			sent++
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+
+			if err := controller.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// matchesStreamFilter reports whether an incident passes the optional namespace/producer filters.
+func matchesStreamFilter(incident *correlation.Incident, namespace, producer string) bool {
+	if namespace != "" && incident.JobNamespace != namespace {
+		return false
+	}
+
+	if producer != "" && incident.JobProducerName != producer {
+		return false
+	}
+
+	return true
+}
+
+// writeCorrelationEvent writes one incident as an SSE event.
+// The JSON payload is a single line, so it never needs multi-line data: framing.
+func writeCorrelationEvent(w http.ResponseWriter, incident *correlation.Incident) error {
+	data, err := json.Marshal(mapIncidentToStreamEvent(incident))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", incident.TestResultID, streamEventName, data)
+
+	return err
+}
+
+// mapIncidentToStreamEvent maps a correlated incident to the SSE payload.
+func mapIncidentToStreamEvent(incident *correlation.Incident) CorrelationStreamEvent {
+	event := CorrelationStreamEvent{
+		ID:           strconv.FormatInt(incident.TestResultID, 10),
+		TestName:     incident.TestName,
+		TestType:     incident.TestType,
+		TestStatus:   incident.TestStatus,
+		DatasetURN:   incident.DatasetURN,
+		DatasetName:  incident.DatasetName,
+		JobName:      incident.JobName,
+		JobNamespace: incident.JobNamespace,
+		JobRunID:     incident.RunID,
+		JobStatus:    incident.JobStatus,
+		Producer:     incident.JobProducerName,
+		ExecutedAt:   incident.TestExecutedAt,
+	}
+
+	if incident.JobError != nil {
+		event.JobError = incident.JobError.Message
+	}
+
+	return event
+}
+
+// requirePermission writes 403 Forbidden and returns false when authentication is enabled and the
+// authenticated client lacks the permission. With authentication disabled every request is allowed,
+// matching the rest of the API.
+func (s *Server) requirePermission(w http.ResponseWriter, r *http.Request, permission string) bool {
+	if s.apiKeyStore == nil {
+		return true
+	}
+
+	clientCtx, authenticated := middleware.GetClientContext(r.Context())
+	if authenticated && slices.Contains(clientCtx.Permissions, permission) {
+		return true
+	}
+
+	s.logger.Warn("Permission denied",
+		slog.String("correlation_id", middleware.GetCorrelationID(r.Context())),
+		slog.String("client_id", clientCtx.ClientID),
+		slog.String("permission", permission),
+		slog.String("endpoint", r.URL.Path),
+	)
+
+	WriteErrorResponse(w, r, s.logger, Forbidden("API key lacks required permission: "+permission))
+
+	return false
+}
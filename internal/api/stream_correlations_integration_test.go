@@ -0,0 +1,180 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/correlation"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// streamTestServer is a real HTTP server with the correlation stream enabled.
+type streamTestServer struct {
+	httpServer  *httptest.Server
+	broadcaster *correlation.Broadcaster
+	readKey     string // lineage:read
+	writeKey    string // lineage:write only
+}
+
+func setupStreamTestServer(ctx context.Context, t *testing.T) *streamTestServer {
+	t.Helper()
+
+	broadcaster := correlation.NewBroadcaster(nil)
+
+	server := setupAPITestServer(ctx, t, []testAPIKey{
+		{id: "stream-read-key", permissions: []string{"lineage:read"}},
+		{id: "stream-write-key", permissions: []string{"lineage:write"}},
+	}, func(_ *ServerConfig, deps *Dependencies, _ *storage.LineageStore) {
+		deps.CorrelationSubscriber = broadcaster
+	})
+
+	// Registered after the server's cleanup, so open streams end before the server closes
+	t.Cleanup(func() { _ = broadcaster.Close() })
+
+	return &streamTestServer{
+		httpServer:  server.httpServer,
+		broadcaster: broadcaster,
+		readKey:     server.keys["stream-read-key"],
+		writeKey:    server.keys["stream-write-key"],
+	}
+}
+
+// openStream starts a stream request and returns the response once headers arrive.
+func (ts *streamTestServer) openStream(ctx context.Context, t *testing.T, apiKey, query string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		ts.httpServer.URL+"/api/v1/correlations/stream"+query, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := ts.httpServer.Client().Do(req)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+// waitForSubscribers waits until the broadcaster has n live subscriptions.
+func (ts *streamTestServer) waitForSubscribers(t *testing.T, n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool { return ts.broadcaster.Subscribers() == n },
+		5*time.Second, 10*time.Millisecond, "expected %d stream subscribers", n)
+}
+
+// readStreamEvent reads SSE lines until a complete event and returns its id, type, and data.
+func readStreamEvent(t *testing.T, reader *bufio.Reader) (string, string, CorrelationStreamEvent) {
+	t.Helper()
+
+	var id, eventType string
+
+	var payload CorrelationStreamEvent
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			eventType = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &payload))
+		case line == "" && id != "":
+			return id, eventType, payload
+		}
+	}
+}
+
+func TestStreamCorrelations_DeliversFilteredEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupStreamTestServer(ctx, t)
+
+	resp := ts.openStream(ctx, t, ts.readKey, "?namespace=dbt://analytics&producer=dbt")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	ts.waitForSubscribers(t, 1)
+
+	// Filtered out: different producer, then different namespace
+	require.NoError(t, ts.broadcaster.NotifyIncident(ctx, &correlation.Incident{
+		TestResultID: 1, JobNamespace: "dbt://analytics", JobProducerName: "airflow",
+	}))
+	require.NoError(t, ts.broadcaster.NotifyIncident(ctx, &correlation.Incident{
+		TestResultID: 2, JobNamespace: "airflow://prod", JobProducerName: "dbt",
+	}))
+	require.NoError(t, ts.broadcaster.NotifyIncident(ctx, &correlation.Incident{
+		TestResultID:    3,
+		TestName:        "not_null_orders_id",
+		DatasetURN:      "postgres://prod/analytics.orders",
+		JobName:         "transform_orders",
+		JobNamespace:    "dbt://analytics",
+		JobProducerName: "dbt",
+		RunID:           "019c628f-d07e-7000-8000-000000000000",
+		JobError:        &correlation.JobError{Message: "relation does not exist"},
+	}))
+
+	id, eventType, payload := readStreamEvent(t, bufio.NewReader(resp.Body))
+
+	assert.Equal(t, "3", id)
+	assert.Equal(t, "correlation", eventType)
+	assert.Equal(t, "3", payload.ID)
+	assert.Equal(t, "not_null_orders_id", payload.TestName)
+	assert.Equal(t, "transform_orders", payload.JobName)
+	assert.Equal(t, "dbt", payload.Producer)
+	assert.Equal(t, "relation does not exist", payload.JobError)
+}
+
+func TestStreamCorrelations_RequiresLineageRead(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupStreamTestServer(ctx, t)
+
+	resp := ts.openStream(ctx, t, ts.writeKey, "")
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, contentTypeProblemJSON, resp.Header.Get("Content-Type"))
+	assert.Equal(t, 0, ts.broadcaster.Subscribers())
+}
+
+func TestStreamCorrelations_UnsubscribesOnDisconnect(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	ts := setupStreamTestServer(ctx, t)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	resp := ts.openStream(streamCtx, t, ts.readKey, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	ts.waitForSubscribers(t, 1)
+
+	cancel()
+
+	ts.waitForSubscribers(t, 0)
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	// ErrIncompleteTLSConfig indicates only one of the TLS certificate and key files is set.
+	ErrIncompleteTLSConfig = errors.New("TLS requires both a certificate and a key file")
+
+	// ErrClientCAWithoutTLS indicates a client CA (mTLS) without a server certificate and key.
+	ErrClientCAWithoutTLS = errors.New("TLS client CA requires a TLS certificate and key file")
+
+	// ErrInvalidTLSClientCA indicates a client CA file without any PEM certificate.
+	ErrInvalidTLSClientCA = errors.New("TLS client CA file contains no PEM certificate")
+)
+
+// TLSEnabled reports whether the server serves HTTPS (TLSCertFile and TLSKeyFile set).
+func (c *ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// validateTLS checks that the TLS settings are complete. File contents are checked when the
+// server starts (see TLSConfig).
+func (c *ServerConfig) validateTLS() error {
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return ErrIncompleteTLSConfig
+	}
+
+	if c.TLSClientCAFile != "" && !c.TLSEnabled() {
+		return ErrClientCAWithoutTLS
+	}
+
+	return nil
+}
+
+// TLSConfig returns the TLS configuration of the server, or nil when TLS is disabled.
+//
+// TLS 1.2 is the minimum version. HTTP/2 is negotiated via ALPN by net/http. With
+// TLSClientCAFile set, clients must present a certificate signed by one of its CAs (mTLS);
+// the handshake fails otherwise, before any request reaches API key authentication.
+func (c *ServerConfig) TLSConfig() (*tls.Config, error) {
+	if !c.TLSEnabled() {
+		return nil, nil //nolint:nilnil // Plain HTTP is the default, not an error
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if c.TLSClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	pemData, err := os.ReadFile(c.TLSClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TLS client CA file: %w", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(pemData) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTLSClientCA, c.TLSClientCAFile)
+	}
+
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return tlsConfig, nil
+}
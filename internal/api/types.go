@@ -2,6 +2,7 @@
 package api
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -39,6 +40,43 @@ type (
 		RetryContext        *RunRetryContextSummary `json:"retry_context"`             //nolint:tagliatelle
 	}
 
+	// JobRunCorrelationsResponse represents the response for GET /api/v1/job-runs/{jobRunID}/correlations:
+	// a page of the test results the run reported, each with its correlation.
+	JobRunCorrelationsResponse struct {
+		JobRun      JobRunSummary           `json:"job_run"`      //nolint:tagliatelle
+		TestResults []TestResultCorrelation `json:"test_results"` //nolint:tagliatelle
+		Total       int                     `json:"total"`
+		Limit       int                     `json:"limit"`
+		Offset      int                     `json:"offset"`
+	}
+
+	// JobRunSummary identifies the job run of a JobRunCorrelationsResponse.
+	JobRunSummary struct {
+		RunID            string `json:"run_id"` //nolint:tagliatelle
+		Name             string `json:"name"`
+		Namespace        string `json:"namespace"`
+		Status           string `json:"status"`
+		IngestedByPlugin string `json:"ingested_by_plugin,omitempty"` //nolint:tagliatelle
+	}
+
+	// TestResultCorrelation is one test result of a job run with the upstream runs implicated in
+	// it: the runs that output the tested dataset, selected run first (see CandidateRunDetail).
+	// ImplicatedRuns is capped at maxImplicatedRuns; ImplicatedRunCount counts them all.
+	TestResultCorrelation struct {
+		ID                 string               `json:"id"`
+		TestName           string               `json:"test_name"` //nolint:tagliatelle
+		TestType           string               `json:"test_type"` //nolint:tagliatelle
+		Status             string               `json:"status"`
+		Message            string               `json:"message,omitempty"`
+		DatasetURN         string               `json:"dataset_urn"` //nolint:tagliatelle
+		ExecutedAt         time.Time            `json:"executed_at"` //nolint:tagliatelle
+		Producer           string               `json:"producer"`
+		CorrelationStatus  string               `json:"correlation_status"`           //nolint:tagliatelle
+		CorrelationReason  string               `json:"correlation_reason,omitempty"` //nolint:tagliatelle
+		ImplicatedRuns     []CandidateRunDetail `json:"implicated_runs"`              //nolint:tagliatelle
+		ImplicatedRunCount int                  `json:"implicated_run_count"`         //nolint:tagliatelle
+	}
+
 	// IncidentDetailResponse represents the response for GET /api/v1/incidents/{id}.
 	// Contains full incident information including test details, dataset, job, and lineage.
 	IncidentDetailResponse struct {
@@ -55,6 +93,60 @@ type (
 		ResolvedAt        *time.Time             `json:"resolved_at,omitempty"`       //nolint:tagliatelle
 		MuteExpiresAt     *time.Time             `json:"mute_expires_at,omitempty"`   //nolint:tagliatelle
 		RetryContext      *RunRetryContextDetail `json:"retry_context"`               //nolint:tagliatelle
+		ColumnLineage     *ColumnLineageDetail   `json:"column_lineage,omitempty"`    //nolint:tagliatelle
+		Explanation       *ExplanationDetail     `json:"explanation,omitempty"`
+	}
+
+	// ExplanationDetail explains why the incident was correlated to its job run: every run that
+	// output the failing dataset, in selection order, and why the first one won. Only present
+	// when requested with ?explain=true.
+	ExplanationDetail struct {
+		Rule       string               `json:"rule"`
+		Reason     string               `json:"reason"`
+		Candidates []CandidateRunDetail `json:"candidates"`
+	}
+
+	// CandidateRunDetail is one run the incident could be correlated to, with its match signals.
+	// TimeDeltaSeconds is the test execution time minus the run's completion time (negative if
+	// the run completed after the test ran); omitted while the run has not completed.
+	CandidateRunDetail struct {
+		RunID             string     `json:"run_id"`        //nolint:tagliatelle
+		JobName           string     `json:"job_name"`      //nolint:tagliatelle
+		JobNamespace      string     `json:"job_namespace"` //nolint:tagliatelle
+		Producer          string     `json:"producer"`
+		Status            string     `json:"status"`
+		StartedAt         time.Time  `json:"started_at"`                   //nolint:tagliatelle
+		CompletedAt       *time.Time `json:"completed_at,omitempty"`       //nolint:tagliatelle
+		OutputDatasetURN  string     `json:"output_dataset_urn"`           //nolint:tagliatelle
+		DatasetMatch      string     `json:"dataset_match"`                //nolint:tagliatelle
+		TimeDeltaSeconds  *float64   `json:"time_delta_seconds,omitempty"` //nolint:tagliatelle
+		StartedBeforeTest bool       `json:"started_before_test"`          //nolint:tagliatelle
+		ProducerMatch     bool       `json:"producer_match"`               //nolint:tagliatelle
+		Selected          bool       `json:"selected"`
+	}
+
+	// ColumnLineageDetail is the column-level lineage of the incident's dataset, as reported by
+	// the producing run. Only present when requested with ?column_lineage=true.
+	//
+	// Lineage is often partial: Columns lists the output columns the producer reported lineage
+	// for, UnmappedColumns the schema columns it did not.
+	ColumnLineageDetail struct {
+		Columns         []ColumnLineageColumn `json:"columns"`
+		UnmappedColumns []string              `json:"unmapped_columns"` //nolint:tagliatelle
+	}
+
+	// ColumnLineageColumn is an output column and the input columns it is derived from.
+	ColumnLineageColumn struct {
+		Name   string               `json:"name"`
+		Inputs []ColumnLineageInput `json:"inputs"`
+	}
+
+	// ColumnLineageInput is one source column of an output column.
+	ColumnLineageInput struct {
+		DatasetURN            string `json:"dataset_urn"` //nolint:tagliatelle
+		Column                string `json:"column"`
+		TransformationType    string `json:"transformation_type,omitempty"`    //nolint:tagliatelle
+		TransformationSubtype string `json:"transformation_subtype,omitempty"` //nolint:tagliatelle
 	}
 
 	// TestDetail contains test information for incident detail view.
@@ -82,15 +174,110 @@ type (
 	// job shows non-terminal state (e.g., RUNNING) but the parent has completed.
 	// This ensures the frontend receives the accurate effective status without fallback logic.
 	JobDetail struct {
-		Name          string              `json:"name"`
-		Namespace     string              `json:"namespace"`
-		RunID         string              `json:"run_id"` //nolint:tagliatelle
-		Producer      string              `json:"producer"`
-		Status        string              `json:"status"`
-		StartedAt     time.Time           `json:"started_at"`             //nolint:tagliatelle
-		CompletedAt   *time.Time          `json:"completed_at,omitempty"` //nolint:tagliatelle
-		Parent        *ParentJob          `json:"parent,omitempty"`
-		Orchestration []OrchestrationNode `json:"orchestration,omitempty"`
+		Name               string              `json:"name"`
+		Namespace          string              `json:"namespace"`
+		RunID              string              `json:"run_id"` //nolint:tagliatelle
+		Producer           string              `json:"producer"`
+		Status             string              `json:"status"`
+		StartedAt          time.Time           `json:"started_at"`             //nolint:tagliatelle
+		CompletedAt        *time.Time          `json:"completed_at,omitempty"` //nolint:tagliatelle
+		Parent             *ParentJob          `json:"parent,omitempty"`
+		Orchestration      []OrchestrationNode `json:"orchestration,omitempty"`
+		Error              *JobErrorDetail     `json:"error,omitempty"`
+		OpenLineageVersion string              `json:"openlineage_version,omitempty"` //nolint:tagliatelle
+		NominalStartTime   *time.Time          `json:"nominal_start_time,omitempty"`  //nolint:tagliatelle
+		NominalEndTime     *time.Time          `json:"nominal_end_time,omitempty"`    //nolint:tagliatelle
+		IngestedByPlugin   string              `json:"ingested_by_plugin,omitempty"`  //nolint:tagliatelle
+	}
+
+	// CorrelationStreamEvent is the data payload of a GET /api/v1/correlations/stream event.
+	// A compact view of a newly correlated incident; use GET /api/v1/incidents/{id} for details.
+	CorrelationStreamEvent struct {
+		ID           string    `json:"id"`
+		TestName     string    `json:"test_name"`           //nolint:tagliatelle
+		TestType     string    `json:"test_type"`           //nolint:tagliatelle
+		TestStatus   string    `json:"test_status"`         //nolint:tagliatelle
+		DatasetURN   string    `json:"dataset_urn"`         //nolint:tagliatelle
+		DatasetName  string    `json:"dataset_name"`        //nolint:tagliatelle
+		JobName      string    `json:"job_name"`            //nolint:tagliatelle
+		JobNamespace string    `json:"job_namespace"`       //nolint:tagliatelle
+		JobRunID     string    `json:"job_run_id"`          //nolint:tagliatelle
+		JobStatus    string    `json:"job_status"`          //nolint:tagliatelle
+		JobError     string    `json:"job_error,omitempty"` //nolint:tagliatelle
+		Producer     string    `json:"producer"`
+		ExecutedAt   time.Time `json:"executed_at"` //nolint:tagliatelle
+	}
+
+	// RequestTraceResponse is the response of GET /api/v1/trace/{correlationID}:
+	// the recorded lifecycle of one recent request.
+	RequestTraceResponse struct {
+		CorrelationID string              `json:"correlation_id"` //nolint:tagliatelle
+		Method        string              `json:"method"`
+		Path          string              `json:"path"`
+		StartedAt     time.Time           `json:"started_at"`  //nolint:tagliatelle
+		StatusCode    int                 `json:"status_code"` //nolint:tagliatelle
+		DurationMs    float64             `json:"duration_ms"` //nolint:tagliatelle
+		Events        []RequestTraceEvent `json:"events"`
+	}
+
+	// RequestTraceEvent is one lifecycle step of a traced request
+	// (received, auth, rate_limit, validation, store, completed).
+	RequestTraceEvent struct {
+		Stage  string    `json:"stage"`
+		Detail string    `json:"detail,omitempty"`
+		At     time.Time `json:"at"`
+	}
+
+	// DebugConfigResponse is the response of GET /api/v1/debug/config: the effective
+	// configuration of the running server, with secrets masked.
+	DebugConfigResponse struct {
+		Version         string            `json:"version"`
+		AuthEnabled     bool              `json:"auth_enabled"`     //nolint:tagliatelle
+		MaintenanceMode bool              `json:"maintenance_mode"` //nolint:tagliatelle
+		ReadOnly        bool              `json:"read_only"`        //nolint:tagliatelle
+		Server          DebugServerConfig `json:"server"`
+		Environment     []ConfigSetting   `json:"environment"`
+	}
+
+	// DebugServerConfig holds the resolved HTTP server settings, after command line overrides.
+	DebugServerConfig struct {
+		Host                  string   `json:"host"`
+		Port                  int      `json:"port"`
+		ReadTimeout           string   `json:"read_timeout"`            //nolint:tagliatelle
+		WriteTimeout          string   `json:"write_timeout"`           //nolint:tagliatelle
+		ShutdownTimeout       string   `json:"shutdown_timeout"`        //nolint:tagliatelle
+		LogLevel              string   `json:"log_level"`               //nolint:tagliatelle
+		MaxRequestSize        int64    `json:"max_request_size"`        //nolint:tagliatelle
+		MaxConcurrentRequests int      `json:"max_concurrent_requests"` //nolint:tagliatelle
+		CORSAllowedOrigins    []string `json:"cors_allowed_origins"`    //nolint:tagliatelle
+		TrustedProxies        []string `json:"trusted_proxies"`         //nolint:tagliatelle
+	}
+
+	// ConfigSetting is one environment variable read at startup with its effective value
+	// ("default" or "env" source). Secret values are masked.
+	ConfigSetting struct {
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Source string `json:"source"`
+	}
+
+	// WhoamiResponse is the response of GET /api/v1/whoami: the plugin the request authenticated
+	// as. The API key value is never returned.
+	WhoamiResponse struct {
+		PluginID    string     `json:"plugin_id"` //nolint:tagliatelle
+		Name        string     `json:"name"`
+		KeyID       string     `json:"key_id"` //nolint:tagliatelle
+		Permissions []string   `json:"permissions"`
+		ExpiresAt   *time.Time `json:"expires_at"` //nolint:tagliatelle
+	}
+
+	// JobErrorDetail contains the failure detail from the run's errorMessage facet.
+	// Omitted when the run did not fail or the producer sent no error facet.
+	JobErrorDetail struct {
+		Message             string `json:"message"`
+		StackTrace          string `json:"stack_trace,omitempty"`          //nolint:tagliatelle
+		ProgrammingLanguage string `json:"programming_language,omitempty"` //nolint:tagliatelle
+		Classification      string `json:"classification,omitempty"`
 	}
 
 	// ParentJob contains immediate parent job information for incident detail view.
@@ -211,11 +398,12 @@ type (
 	//   - correlation_id: Request correlation ID for tracing
 	//   - timestamp: Response generation time (ISO8601)
 	LineageResponse struct {
-		Status        string          `json:"status"`         // "success" or "error" (OpenLineage spec)
-		Summary       ResponseSummary `json:"summary"`        // Event counts (received, successful, failed, retriable)
-		FailedEvents  []FailedEvent   `json:"failed_events"`  //nolint: tagliatelle // Only failed events
-		CorrelationID string          `json:"correlation_id"` //nolint: tagliatelle // Correlator extension
-		Timestamp     string          `json:"timestamp"`      // Correlator extension
+		Status        string          `json:"status"`             // "success" or "error" (OpenLineage spec)
+		Summary       ResponseSummary `json:"summary"`            // Event counts (received, successful, failed, retriable)
+		FailedEvents  []FailedEvent   `json:"failed_events"`      //nolint: tagliatelle // Only failed events
+		Warnings      []EventWarning  `json:"warnings,omitempty"` // Correlator extension
+		CorrelationID string          `json:"correlation_id"`     //nolint: tagliatelle // Correlator extension
+		Timestamp     string          `json:"timestamp"`          // Correlator extension
 	}
 
 	// ResponseSummary provides aggregate counts for batch processing.
@@ -236,6 +424,98 @@ type (
 		Retriable bool   `json:"retriable"` // True if transient failure (can retry)
 	}
 
+	// EventWarning describes a soft validation finding for an accepted event (Correlator extension),
+	// e.g. a COMPLETE event without outputs when CORRELATOR_COMPLETE_OUTPUTS_CHECK=warn.
+	// Warned events are still stored and counted as successful.
+	EventWarning struct {
+		Index  int    `json:"index"`  // Event index in original batch (0-based)
+		Reason string `json:"reason"` // Human-readable warning
+	}
+
+	// ImportResponse is the aggregated result of a multipart lineage import.
+	// Each file part is processed as an independent batch; Summary sums the per-file counts.
+	ImportResponse struct {
+		Status        string             `json:"status"`         // "success", "partial_success", or "error"
+		Summary       ResponseSummary    `json:"summary"`        // Event counts across all files
+		Files         []ImportFileResult `json:"files"`          // One entry per file part, in upload order
+		CorrelationID string             `json:"correlation_id"` //nolint: tagliatelle // Correlator extension
+		Timestamp     string             `json:"timestamp"`
+	}
+
+	// ImportFileResult describes the outcome of a single file part in a multipart import.
+	// Error is set when the whole file was rejected (invalid JSON, size limit, invalid sequence);
+	// otherwise Summary and FailedEvents describe per-event outcomes (indexes are within the file).
+	ImportFileResult struct {
+		Filename     string          `json:"filename"`
+		Status       string          `json:"status"` // "success", "partial_success", or "error"
+		Summary      ResponseSummary `json:"summary"`
+		FailedEvents []FailedEvent   `json:"failed_events"`      //nolint: tagliatelle
+		Warnings     []EventWarning  `json:"warnings,omitempty"` // Indexes are within the file
+		Error        string          `json:"error,omitempty"`    // File-level rejection reason
+	}
+
+	// LineageBatchAcceptedResponse is the 202 response of POST /api/v1/lineage/batch with
+	// "Prefer: respond-async". Poll StatusURL (also sent as Location) for progress.
+	LineageBatchAcceptedResponse struct {
+		BatchID       string `json:"batch_id"`       //nolint:tagliatelle
+		Status        string `json:"status"`         // Always "pending"
+		TotalEvents   int    `json:"total_events"`   //nolint:tagliatelle
+		StatusURL     string `json:"status_url"`     //nolint:tagliatelle
+		CorrelationID string `json:"correlation_id"` //nolint:tagliatelle
+	}
+
+	// LineageBatchStatusResponse is the response of GET /api/v1/lineage/batches/{batch_id}.
+	// Result is the LineageResponse the synchronous endpoint would have returned (set once
+	// Status is "done"); Error is the batch-level rejection reason (set once Status is "failed").
+	LineageBatchStatusResponse struct {
+		BatchID       string               `json:"batch_id"` //nolint:tagliatelle
+		Status        string               `json:"status"`   // "pending", "processing", "done", or "failed"
+		Progress      LineageBatchProgress `json:"progress"`
+		Result        json.RawMessage      `json:"result,omitempty"`
+		Error         string               `json:"error,omitempty"`
+		CorrelationID string               `json:"correlation_id"`         //nolint:tagliatelle
+		CreatedAt     time.Time            `json:"created_at"`             //nolint:tagliatelle
+		StartedAt     *time.Time           `json:"started_at,omitempty"`   //nolint:tagliatelle
+		CompletedAt   *time.Time           `json:"completed_at,omitempty"` //nolint:tagliatelle
+	}
+
+	// LineageBatchProgress counts the events of an async batch by processing state.
+	LineageBatchProgress struct {
+		Total      int `json:"total"`
+		Pending    int `json:"pending"`
+		Processing int `json:"processing"`
+		Done       int `json:"done"`
+	}
+
+	// ReplayResponse is the per-event report of a lineage replay.
+	// Unlike LineageResponse it lists every event, so operators can see which events of a
+	// recovery queue had already landed (duplicate) and which were stored by the replay.
+	ReplayResponse struct {
+		Status        string              `json:"status"`         // "success", "partial_success", or "error"
+		Summary       ReplaySummary       `json:"summary"`        // Event counts by outcome
+		Events        []ReplayEventResult `json:"events"`         // One entry per event, in request order
+		CorrelationID string              `json:"correlation_id"` //nolint:tagliatelle // Correlator extension
+		Timestamp     string              `json:"timestamp"`
+	}
+
+	// ReplaySummary counts replayed events by outcome. Received = Stored + Duplicate + Failed.
+	ReplaySummary struct {
+		Received  int `json:"received"`
+		Stored    int `json:"stored"`    // Newly stored by this replay
+		Duplicate int `json:"duplicate"` // Already present (idempotency key recorded)
+		Failed    int `json:"failed"`    // Failed validation or storage
+	}
+
+	// ReplayEventResult is the outcome of a single replayed event.
+	ReplayEventResult struct {
+		Index     int      `json:"index"`              // Event index in the request (0-based)
+		RunID     string   `json:"run_id"`             //nolint:tagliatelle
+		EventType string   `json:"event_type"`         //nolint:tagliatelle
+		Status    string   `json:"status"`             // "stored", "duplicate", or "failed"
+		Reason    string   `json:"reason,omitempty"`   // Failure reason (failed only)
+		Warnings  []string `json:"warnings,omitempty"` // Soft validation findings (stored or duplicate only)
+	}
+
 	// LineageEvent model represents an event in the payload of an API request to ingest OpenLineage events.
 	// This is separate from the domain model (ingestion.RunEvent) to decouple
 	// the API contract from internal domain types.
@@ -291,4 +571,8 @@ const (
 
 	// CorrelationStatusUnknown indicates the correlation status cannot be determined.
 	CorrelationStatusUnknown = "unknown"
+
+	// CorrelationStatusNotApplicable marks a test result that is not an incident (passed, skipped,
+	// or warning), so there is nothing to correlate.
+	CorrelationStatusNotApplicable = "not_applicable"
 )
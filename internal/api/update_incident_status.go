@@ -39,6 +39,10 @@ type (
 
 // handleUpdateIncidentStatus handles PATCH /api/v1/incidents/{id}/status.
 // Validates the requested state transition and applies it via the resolution store.
+//
+// Optimistic concurrency: with an If-Unmodified-Since header (e.g., the Last-Modified of a
+// previous GET /api/v1/incidents/{id}), the change is rejected with 412 if another client
+// changed the status after that time. A header that is not a valid HTTP date is ignored.
 func (s *Server) handleUpdateIncidentStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	correlationID := middleware.GetCorrelationID(ctx)
@@ -57,6 +61,10 @@ func (s *Server) handleUpdateIncidentStatus(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if since, err := http.ParseTime(r.Header.Get("If-Unmodified-Since")); err == nil {
+		req.UnmodifiedSince = &since
+	}
+
 	incident, err := s.correlationStore.QueryIncidentByID(ctx, testResultID)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Failed to query incident for status update",
@@ -90,6 +98,12 @@ func (s *Server) handleUpdateIncidentStatus(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
+		if errors.Is(err, storage.ErrResolutionModified) {
+			WriteErrorResponse(w, r, s.logger, PreconditionFailed(err.Error()))
+
+			return
+		}
+
 		s.logger.ErrorContext(ctx, "Failed to set resolution",
 			"correlation_id", correlationID,
 			"incident_id", testResultID,
@@ -148,8 +162,8 @@ func parseIncidentID(r *http.Request) (int64, string, *ProblemDetail) {
 
 // parseAndValidateStatusBody reads and validates the PATCH request body.
 func parseAndValidateStatusBody(r *http.Request) (*correlation.ResolutionRequest, *ProblemDetail) {
-	if !hasJSONContentType(r.Header.Get("Content-Type")) {
-		return nil, UnsupportedMediaType("Content-Type must be application/json")
+	if problem := checkJSONContentType(r.Header.Get("Content-Type")); problem != nil {
+		return nil, problem
 	}
 
 	var body updateStatusRequest
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// verboseEventLogger dumps every ingested event of selected producers at debug level, whatever
+// the server log level, to debug one misbehaving integration in production without turning on
+// debug logging for all traffic (see ServerConfig.VerboseLogProducers).
+type verboseEventLogger struct {
+	producers []string
+	logger    *slog.Logger
+}
+
+// newVerboseEventLogger returns a logger writing debug records to w for events whose producer
+// or job namespace matches one of producers, or nil when producers is empty.
+func newVerboseEventLogger(producers []string, w io.Writer) *verboseEventLogger {
+	if len(producers) == 0 {
+		return nil
+	}
+
+	return &verboseEventLogger{
+		producers: producers,
+		logger:    slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+}
+
+// matches reports whether event comes from a selected producer. An entry matches the job
+// namespace, the full producer URL, or one of its path segments, so "dbt-core" selects
+// "https://github.com/dbt-labs/dbt-core/tree/1.5.0".
+func (l *verboseEventLogger) matches(event *ingestion.RunEvent) bool {
+	segments := strings.Split(strings.TrimSuffix(event.Producer, "/"), "/")
+
+	for _, producer := range l.producers {
+		if producer == event.Job.Namespace || producer == event.Producer || slices.Contains(segments, producer) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// logEvents dumps the events of selected producers. A nil logger logs nothing.
+func (l *verboseEventLogger) logEvents(ctx context.Context, correlationID string, events []*ingestion.RunEvent) {
+	if l == nil {
+		return
+	}
+
+	for i, event := range events {
+		if !l.matches(event) {
+			continue
+		}
+
+		l.logger.DebugContext(ctx, "Lineage event from verbose-logged producer",
+			slog.String("correlation_id", correlationID),
+			slog.Int("event_index", i),
+			slog.String("producer", event.Producer),
+			slog.String("job_namespace", event.Job.Namespace),
+			slog.Any("event", event),
+		)
+	}
+}
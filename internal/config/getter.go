@@ -20,9 +20,13 @@ import (
 //	s := GetEnvStr("HOST", "localhost")
 func GetEnvStr(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
+		recordEnv(key, value, value, "", true)
+
 		return value
 	}
 
+	recordEnv(key, "", defaultValue, "", true)
+
 	return defaultValue
 }
 
@@ -38,10 +42,18 @@ func GetEnvStr(key, defaultValue string) string {
 func GetEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
+			recordEnv(key, value, strconv.Itoa(intValue), formatInt, true)
+
 			return intValue
 		}
+
+		recordEnv(key, value, strconv.Itoa(defaultValue), formatInt, false)
+
+		return defaultValue
 	}
 
+	recordEnv(key, "", strconv.Itoa(defaultValue), formatInt, true)
+
 	return defaultValue
 }
 
@@ -57,10 +69,18 @@ func GetEnvInt(key string, defaultValue int) int {
 func GetEnvInt64(key string, defaultValue int64) int64 {
 	if value := os.Getenv(key); value != "" {
 		if int64Value, err := strconv.ParseInt(value, 10, 64); err == nil {
+			recordEnv(key, value, strconv.FormatInt(int64Value, 10), formatInt, true)
+
 			return int64Value
 		}
+
+		recordEnv(key, value, strconv.FormatInt(defaultValue, 10), formatInt, false)
+
+		return defaultValue
 	}
 
+	recordEnv(key, "", strconv.FormatInt(defaultValue, 10), formatInt, true)
+
 	return defaultValue
 }
 
@@ -78,12 +98,22 @@ func GetEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		switch strings.ToLower(strings.TrimSpace(value)) {
 		case "true", "1", "yes":
+			recordEnv(key, value, "true", formatBool, true)
+
 			return true
 		case "false", "0", "no":
+			recordEnv(key, value, "false", formatBool, true)
+
 			return false
 		}
+
+		recordEnv(key, value, strconv.FormatBool(defaultValue), formatBool, false)
+
+		return defaultValue
 	}
 
+	recordEnv(key, "", strconv.FormatBool(defaultValue), formatBool, true)
+
 	return defaultValue
 }
 
@@ -99,10 +129,18 @@ func GetEnvBool(key string, defaultValue bool) bool {
 func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
+			recordEnv(key, value, duration.String(), formatDuration, true)
+
 			return duration
 		}
+
+		recordEnv(key, value, defaultValue.String(), formatDuration, false)
+
+		return defaultValue
 	}
 
+	recordEnv(key, "", defaultValue.String(), formatDuration, true)
+
 	return defaultValue
 }
 
@@ -117,21 +155,39 @@ func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
 //	l := GetEnvStr("LOG_LEVEL", "debug")
 func GetEnvLogLevel(key string, defaultValue slog.Level) slog.Level {
 	if value := os.Getenv(key); value != "" {
-		switch strings.ToLower(strings.TrimSpace(value)) {
-		case "debug":
-			return slog.LevelDebug
-		case "info":
-			return slog.LevelInfo
-		case "warn", "warning":
-			return slog.LevelWarn
-		case "error":
-			return slog.LevelError
+		level, ok := parseLogLevel(value)
+		if ok {
+			recordEnv(key, value, strings.ToLower(level.String()), formatLogLevel, true)
+
+			return level
 		}
+
+		recordEnv(key, value, strings.ToLower(defaultValue.String()), formatLogLevel, false)
+
+		return defaultValue
 	}
 
+	recordEnv(key, "", strings.ToLower(defaultValue.String()), formatLogLevel, true)
+
 	return defaultValue
 }
 
+// parseLogLevel maps a case-insensitive level name to a slog.Level.
+func parseLogLevel(value string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn", "warning":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return slog.LevelInfo, false
+	}
+}
+
 // ParseCommaSeparatedList parses a comma-separated string into a slice of trimmed strings.
 // Empty values are filtered out.
 func ParseCommaSeparatedList(input string) []string {
@@ -26,8 +26,9 @@ const (
 // TestDatabase encapsulates test database resources for cleanup.
 // Used by integration tests across multiple packages to maintain consistent test infrastructure.
 type TestDatabase struct {
-	Container  *postgres.PostgresContainer
-	Connection *sql.DB
+	Container        *postgres.PostgresContainer
+	Connection       *sql.DB
+	ConnectionString string // DSN of Connection, for tests that open their own connections (e.g., LISTEN)
 }
 
 // SetupTestDatabase creates a PostgreSQL container and runs migrations.
@@ -90,8 +91,9 @@ func SetupTestDatabase(ctx context.Context, t *testing.T) *TestDatabase {
 	}
 
 	return &TestDatabase{
-		Container:  pgContainer,
-		Connection: conn,
+		Container:        pgContainer,
+		Connection:       conn,
+		ConnectionString: connStr,
 	}
 }
 
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Expected formats reported for environment variables that fail to parse.
+const (
+	formatInt      = "integer (e.g. 100)"
+	formatBool     = "boolean (true/false, 1/0, yes/no)"
+	formatDuration = "duration (e.g. 500ms, 30s, 5m, 1h)"
+	formatLogLevel = "log level (debug, info, warn, error)"
+
+	// maskedValue replaces secret values in the configuration summary.
+	maskedValue = "********"
+)
+
+type (
+	// InvalidEnvVar describes an environment variable whose value could not be parsed.
+	// The getter that read it fell back to Default.
+	InvalidEnvVar struct {
+		Key      string
+		Value    string
+		Expected string
+		Default  string
+	}
+
+	// Setting is the effective value of one environment variable read through a GetEnv* getter,
+	// with secrets masked (see Settings).
+	Setting struct {
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Source string `json:"source"` // "env" or "default"
+	}
+
+	// ValidationError lists every invalid environment variable found by Validate.
+	ValidationError struct {
+		Invalid []InvalidEnvVar
+	}
+
+	// envReading records one environment variable read through a GetEnv* getter.
+	envReading struct {
+		key       string
+		raw       string // Value as set in the environment ("" if unset)
+		effective string // Value the application uses (parsed value or default)
+		expected  string // Expected format; "" for free-form strings
+		valid     bool
+	}
+
+	// envRegistry records every environment variable read, in first-read order.
+	envRegistry struct {
+		mu       sync.Mutex
+		readings map[string]envReading
+		order    []string
+	}
+)
+
+// secretKeywords mark environment variable names, URL query parameters, and key=value
+// connection string keys whose values are secrets.
+var secretKeywords = []string{ //nolint:gochecknoglobals
+	"PASSWORD", "SECRET", "TOKEN", "API_KEY", "APIKEY", "CREDENTIAL",
+}
+
+// dsnSecretRegex matches secret parameters of key=value connection strings
+// (e.g., "host=db password='p w' sslmode=disable"), including quoted values.
+var dsnSecretRegex = regexp.MustCompile( //nolint:gochecknoglobals
+	`(?i)\b(\w*(?:password|secret|token|credential)\w*)\s*=\s*('(?:[^'\\]|\\.)*'|\S+)`)
+
+// registry is process-wide because environment variables are: every GetEnv* call records here,
+// so Validate and WriteSummary cover all packages' configuration without each one registering.
+var registry = newEnvRegistry() //nolint:gochecknoglobals // Process-wide record of env reads
+
+func newEnvRegistry() *envRegistry {
+	return &envRegistry{readings: make(map[string]envReading)}
+}
+
+// recordEnv records a getter's result for Validate and WriteSummary. A later read of the same
+// key replaces the earlier one but keeps its position.
+func recordEnv(key, raw, effective, expected string, valid bool) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, seen := registry.readings[key]; !seen {
+		registry.order = append(registry.order, key)
+	}
+
+	registry.readings[key] = envReading{
+		key:       key,
+		raw:       raw,
+		effective: effective,
+		expected:  expected,
+		valid:     valid,
+	}
+}
+
+// snapshot returns the recorded readings in first-read order.
+func (r *envRegistry) snapshot() []envReading {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	readings := make([]envReading, 0, len(r.order))
+	for _, key := range r.order {
+		readings = append(readings, r.readings[key])
+	}
+
+	return readings
+}
+
+// Validate reports every environment variable read so far whose value could not be parsed.
+// The GetEnv* getters fall back to defaults on bad input; call Validate after loading all
+// configuration at startup so a typo fails fast instead of silently using the default.
+//
+// Returns nil if all values parsed, otherwise a *ValidationError listing each offender.
+//
+// Example:
+//
+//	serverConfig := api.LoadServerConfig()
+//	storageConfig := storage.LoadConfig()
+//	if err := config.Validate(); err != nil {
+//	    return fmt.Errorf("invalid configuration: %w", err)
+//	}
+func Validate() error {
+	var invalid []InvalidEnvVar
+
+	for _, reading := range registry.snapshot() {
+		if reading.valid {
+			continue
+		}
+
+		invalid = append(invalid, InvalidEnvVar{
+			Key:      reading.key,
+			Value:    maskEnvValue(reading.key, reading.raw),
+			Expected: reading.expected,
+			Default:  reading.effective,
+		})
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Invalid: invalid}
+}
+
+// Error lists each invalid variable on its own line.
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d invalid environment variable(s):", len(e.Invalid))
+
+	for _, v := range e.Invalid {
+		fmt.Fprintf(&b, "\n  %s=%q: expected %s", v.Key, v.Value, v.Expected)
+	}
+
+	return b.String()
+}
+
+// WriteSummary writes a table of every environment variable read so far with its effective
+// value and whether it came from the environment or a default. Secrets are masked.
+//
+// Output:
+//
+//	VARIABLE                 VALUE                               SOURCE
+//	CORRELATOR_SERVER_PORT   8080                                default
+//	DATABASE_URL             postgres://user:xxxxx@db:5432/app   env
+func WriteSummary(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0) //nolint:mnd // Column padding
+
+	_, _ = fmt.Fprintln(tw, "VARIABLE\tVALUE\tSOURCE")
+
+	for _, setting := range Settings() {
+		value := setting.Value
+		if value == "" {
+			value = "(empty)"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", setting.Key, value, setting.Source)
+	}
+
+	return tw.Flush()
+}
+
+// Settings returns every environment variable read so far, in first-read order, with its
+// effective value and whether it came from the environment or a default. Secrets are masked,
+// so the result is safe to log or serve to operators.
+func Settings() []Setting {
+	readings := registry.snapshot()
+	settings := make([]Setting, 0, len(readings))
+
+	for _, reading := range readings {
+		source := "default"
+		if reading.raw != "" {
+			source = "env"
+		}
+
+		settings = append(settings, Setting{
+			Key:    reading.key,
+			Value:  maskEnvValue(reading.key, reading.effective),
+			Source: source,
+		})
+	}
+
+	return settings
+}
+
+// maskEnvValue hides secrets: values of keys that name a secret are fully masked, and
+// passwords embedded in URLs (e.g., DATABASE_URL, including secret query parameters) or in
+// key=value connection strings are redacted.
+func maskEnvValue(key, value string) string {
+	if value == "" {
+		return ""
+	}
+
+	if isSecretName(key) {
+		return maskedValue
+	}
+
+	if u, err := url.Parse(value); err == nil && u.Scheme != "" && u.Host != "" {
+		return redactURL(u)
+	}
+
+	return dsnSecretRegex.ReplaceAllString(value, "${1}="+maskedValue)
+}
+
+// redactURL masks the password and the values of secret query parameters of u.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	query := u.Query()
+
+	for param := range query {
+		if isSecretName(param) {
+			query.Set(param, "xxxxx") // Same placeholder as url.Redacted; '*' would be percent-encoded
+			redacted.RawQuery = query.Encode()
+		}
+	}
+
+	return redacted.Redacted()
+}
+
+// isSecretName reports whether an environment variable or parameter name denotes a secret.
+func isSecretName(name string) bool {
+	upper := strings.ToUpper(name)
+
+	for _, keyword := range secretKeywords {
+		if strings.Contains(upper, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
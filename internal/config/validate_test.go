@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useFreshRegistry isolates a test from env reads recorded by other tests.
+func useFreshRegistry(t *testing.T) {
+	t.Helper()
+
+	previous := registry
+	registry = newEnvRegistry()
+
+	t.Cleanup(func() { registry = previous })
+}
+
+func TestValidate_CollectsAllInvalidValues(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	useFreshRegistry(t)
+
+	t.Setenv("TEST_RPS", "fast")
+	t.Setenv("TEST_TIMEOUT", "5 minutes")
+	t.Setenv("TEST_LOG_LEVEL", "verbose")
+	t.Setenv("TEST_ENABLED", "maybe")
+	t.Setenv("TEST_PORT", "9090")
+
+	assert.Equal(t, 100, GetEnvInt("TEST_RPS", 100))
+	assert.Equal(t, 30*time.Second, GetEnvDuration("TEST_TIMEOUT", 30*time.Second))
+	assert.Equal(t, slog.LevelInfo, GetEnvLogLevel("TEST_LOG_LEVEL", slog.LevelInfo))
+	assert.False(t, GetEnvBool("TEST_ENABLED", false))
+	assert.Equal(t, 9090, GetEnvInt("TEST_PORT", 8080))
+
+	err := Validate()
+	require.Error(t, err)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+
+	keys := make([]string, 0, len(validationErr.Invalid))
+	for _, v := range validationErr.Invalid {
+		keys = append(keys, v.Key)
+	}
+
+	assert.Equal(t, []string{"TEST_RPS", "TEST_TIMEOUT", "TEST_LOG_LEVEL", "TEST_ENABLED"}, keys)
+	assert.Equal(t, InvalidEnvVar{
+		Key: "TEST_TIMEOUT", Value: "5 minutes", Expected: formatDuration, Default: "30s",
+	}, validationErr.Invalid[1])
+	assert.Contains(t, err.Error(), `TEST_RPS="fast": expected integer`)
+}
+
+func TestValidate_NoInvalidValues(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	useFreshRegistry(t)
+
+	t.Setenv("TEST_LOG_LEVEL", "WARN")
+
+	GetEnvStr("TEST_UNSET_HOST", "localhost")
+	GetEnvLogLevel("TEST_LOG_LEVEL", slog.LevelInfo)
+
+	assert.NoError(t, Validate())
+}
+
+func TestWriteSummary_MasksSecrets(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	useFreshRegistry(t)
+
+	t.Setenv("TEST_DATABASE_URL", "postgres://correlator:hunter2@db:5432/correlator") // pragma: allowlist secret
+	t.Setenv("TEST_WEBHOOK_TOKEN", "abc123")
+
+	GetEnvStr("TEST_DATABASE_URL", "")
+	GetEnvStr("TEST_WEBHOOK_TOKEN", "")
+	GetEnvInt("TEST_PORT", 8080)
+	GetEnvStr("TEST_OPTIONAL", "")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSummary(&buf))
+
+	out := buf.String()
+
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "abc123")
+	assert.Contains(t, out, "postgres://correlator:xxxxx@db:5432/correlator")
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, []string{"VARIABLE", "VALUE", "SOURCE"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"TEST_WEBHOOK_TOKEN", maskedValue, "env"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"TEST_PORT", "8080", "default"}, strings.Fields(lines[3]))
+	assert.Equal(t, []string{"TEST_OPTIONAL", "(empty)", "default"}, strings.Fields(lines[4]))
+}
+
+func TestSettings(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	useFreshRegistry(t)
+
+	t.Setenv("TEST_PORT", "9090")
+
+	GetEnvInt("TEST_PORT", 8080)
+	GetEnvBool("TEST_ENABLED", true)
+
+	assert.Equal(t, []Setting{
+		{Key: "TEST_PORT", Value: "9090", Source: "env"},
+		{Key: "TEST_ENABLED", Value: "true", Source: "default"},
+	}, Settings())
+}
+
+// TestMaskEnvValue verifies that no part of a secret survives masking, whatever form the
+// connection string takes.
+func TestMaskEnvValue(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  string
+	}{
+		{"secret key name", "TEST_WEBHOOK_TOKEN", "abc123", maskedValue},
+		// pragma: allowlist nextline secret
+		{"URL password", "DATABASE_URL", "postgres://u:hunter2@db:5432/app", "postgres://u:xxxxx@db:5432/app"},
+		{
+			"URL secret query parameter", "DATABASE_URL",
+			"postgres://db:5432/app?sslmode=require&sslpassword=hunter2",
+			"postgres://db:5432/app?sslmode=require&sslpassword=xxxxx",
+		},
+		{
+			"key=value DSN", "DATABASE_URL",
+			"host=db password=hunter2 sslmode=disable",
+			"host=db password=" + maskedValue + " sslmode=disable",
+		},
+		{
+			"key=value DSN quoted", "DATABASE_URL",
+			"host=db password = 'hun ter2' user=u",
+			"host=db password=" + maskedValue + " user=u",
+		},
+		{"plain value", "CORRELATOR_KAFKA_BROKERS", "kafka-1:9092,kafka-2:9092", "kafka-1:9092,kafka-2:9092"},
+		{"empty", "TEST_WEBHOOK_TOKEN", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := maskEnvValue(tt.key, tt.value)
+
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, got, "hunter2")
+			assert.NotContains(t, got, "ter2")
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package correlation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultSubscriberBuffer is the number of incidents buffered per subscriber before
+// further incidents are dropped for that subscriber.
+const DefaultSubscriberBuffer = 64
+
+type (
+	// Broadcaster is a Notifier that fans out each correlated incident to all live subscribers.
+	//
+	// Properties:
+	//   - Non-blocking: a slow subscriber never stalls the worker; incidents that do not fit in its
+	//     buffer are dropped for that subscriber only (counted in Subscription.Dropped)
+	//   - Ephemeral: subscribers only receive incidents published after they subscribe
+	//   - Closeable: Close ends every subscription so long-lived consumers (SSE streams) return
+	//
+	// Usage:
+	//
+	//	broadcaster := correlation.NewBroadcaster(logger)
+	//	worker := correlation.NewWorker(cfg, correlation.NewMultiNotifier(logNotifier, broadcaster), logger)
+	//	sub := broadcaster.Subscribe(correlation.DefaultSubscriberBuffer)
+	//	defer sub.Close()
+	//	for incident := range sub.Events() { ... }
+	Broadcaster struct {
+		logger *slog.Logger
+
+		mu     sync.RWMutex
+		subs   map[*Subscription]struct{}
+		closed bool
+	}
+
+	// Subscription is a single consumer of a Broadcaster.
+	// Events is closed when the subscription or the broadcaster is closed.
+	Subscription struct {
+		broadcaster *Broadcaster
+		events      chan *Incident
+		closeOnce   sync.Once
+		dropped     atomic.Int64
+	}
+
+	// MultiNotifier calls several notifiers in order for each incident.
+	MultiNotifier struct {
+		notifiers []Notifier
+	}
+)
+
+// NewBroadcaster creates an empty broadcaster.
+func NewBroadcaster(logger *slog.Logger) *Broadcaster {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Broadcaster{
+		logger: logger,
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber with the given buffer size
+// (<= 0 uses DefaultSubscriberBuffer). Subscribing to a closed broadcaster returns a
+// subscription whose Events channel is already closed.
+func (b *Broadcaster) Subscribe(buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultSubscriberBuffer
+	}
+
+	sub := &Subscription{
+		broadcaster: b,
+		events:      make(chan *Incident, buffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		sub.closeOnce.Do(func() { close(sub.events) })
+
+		return sub
+	}
+
+	b.subs[sub] = struct{}{}
+
+	return sub
+}
+
+// Subscribers returns the number of live subscriptions.
+func (b *Broadcaster) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subs)
+}
+
+// NotifyIncident publishes the incident to every subscriber without blocking.
+// Always returns nil: delivery to subscribers is best-effort.
+func (b *Broadcaster) NotifyIncident(_ context.Context, incident *Incident) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subs {
+		select {
+		case sub.events <- incident:
+		default:
+			dropped := sub.dropped.Add(1)
+
+			b.logger.Warn("Correlation subscriber buffer full, dropping incident",
+				slog.Int64("test_result_id", incident.TestResultID),
+				slog.Int64("dropped_total", dropped),
+			)
+		}
+	}
+
+	return nil
+}
+
+// Close ends all subscriptions and rejects new ones. Safe to call multiple times.
+func (b *Broadcaster) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+
+	b.closed = true
+
+	for sub := range b.subs {
+		sub.closeOnce.Do(func() { close(sub.events) })
+		delete(b.subs, sub)
+	}
+
+	return nil
+}
+
+// Events returns the channel of published incidents.
+func (s *Subscription) Events() <-chan *Incident {
+	return s.events
+}
+
+// Dropped returns the number of incidents dropped because this subscriber's buffer was full.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close unsubscribes and closes Events. Safe to call multiple times.
+func (s *Subscription) Close() {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+
+	delete(s.broadcaster.subs, s)
+	s.closeOnce.Do(func() { close(s.events) })
+}
+
+// NewMultiNotifier creates a Notifier that forwards each incident to all notifiers.
+// Nil notifiers are skipped.
+func NewMultiNotifier(notifiers ...Notifier) *MultiNotifier {
+	nonNil := make([]Notifier, 0, len(notifiers))
+
+	for _, n := range notifiers {
+		if n != nil {
+			nonNil = append(nonNil, n)
+		}
+	}
+
+	return &MultiNotifier{notifiers: nonNil}
+}
+
+// NotifyIncident calls every notifier, even if an earlier one fails, and joins their errors.
+func (m *MultiNotifier) NotifyIncident(ctx context.Context, incident *Incident) error {
+	var errs []error
+
+	for _, n := range m.notifiers {
+		if err := n.NotifyIncident(ctx, incident); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,108 @@
+package correlation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotifyFailed = errors.New("notify failed")
+
+// failingNotifier always fails.
+type failingNotifier struct{}
+
+func (failingNotifier) NotifyIncident(context.Context, *Incident) error { return errNotifyFailed }
+
+func newTestBroadcaster() *Broadcaster {
+	return NewBroadcaster(discardLogger())
+}
+
+func TestBroadcaster_FansOutToAllSubscribers(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	b := newTestBroadcaster()
+	first := b.Subscribe(4)
+	second := b.Subscribe(4)
+
+	require.NoError(t, b.NotifyIncident(context.Background(), &Incident{TestResultID: 7}))
+
+	for _, sub := range []*Subscription{first, second} {
+		select {
+		case incident := <-sub.Events():
+			assert.Equal(t, int64(7), incident.TestResultID)
+		default:
+			t.Fatal("subscriber did not receive the incident")
+		}
+	}
+}
+
+func TestBroadcaster_SlowSubscriberDropsWithoutBlocking(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	b := newTestBroadcaster()
+	slow := b.Subscribe(1)
+	fast := b.Subscribe(4)
+
+	for id := range int64(3) {
+		require.NoError(t, b.NotifyIncident(context.Background(), &Incident{TestResultID: id}))
+	}
+
+	assert.Equal(t, int64(2), slow.Dropped())
+	assert.Equal(t, int64(0), fast.Dropped())
+	assert.Len(t, fast.Events(), 3)
+}
+
+func TestBroadcaster_Close(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	b := newTestBroadcaster()
+	sub := b.Subscribe(1)
+
+	sub.Close()
+	sub.Close() // Idempotent
+
+	_, open := <-sub.Events()
+	assert.False(t, open, "Events must be closed after Subscription.Close")
+	assert.Equal(t, 0, b.Subscribers())
+
+	live := b.Subscribe(1)
+	require.NoError(t, b.Close())
+	require.NoError(t, b.Close())
+
+	_, open = <-live.Events()
+	assert.False(t, open, "Events must be closed after Broadcaster.Close")
+
+	late := b.Subscribe(1)
+	_, open = <-late.Events()
+	assert.False(t, open, "Subscribing after Close must return a closed subscription")
+
+	live.Close() // Closing after the broadcaster must not panic
+	require.NoError(t, b.NotifyIncident(context.Background(), &Incident{TestResultID: 1}))
+}
+
+func TestMultiNotifier_CallsAllAndJoinsErrors(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	b := newTestBroadcaster()
+	sub := b.Subscribe(1)
+	recorder := &recordingNotifier{}
+
+	multi := NewMultiNotifier(failingNotifier{}, nil, recorder, b)
+
+	err := multi.NotifyIncident(context.Background(), &Incident{TestResultID: 42})
+	require.ErrorIs(t, err, errNotifyFailed)
+
+	assert.Equal(t, []int64{42}, recorder.ids())
+	assert.Len(t, sub.Events(), 1, "a failing notifier must not stop later notifiers")
+}
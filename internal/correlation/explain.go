@@ -0,0 +1,153 @@
+package correlation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Dataset match kinds of a CandidateExplanation.
+const (
+	// DatasetMatchExact means the run output the dataset under the test's own URN.
+	DatasetMatchExact = "exact"
+	// DatasetMatchResolved means the run output the dataset under another URN that a dataset
+	// pattern resolves to the same canonical URN (cross-tool correlation).
+	DatasetMatchResolved = "resolved"
+)
+
+// SelectionRuleLatestStart is the rule the incident views apply when several runs output the
+// failing dataset: the most recently started run wins, ties broken by run ID.
+const SelectionRuleLatestStart = "latest_started_run"
+
+type (
+	// CorrelationCandidate is a job run that output the dataset of a failed test, i.e. one of
+	// the runs the incident could be correlated to.
+	//
+	// Fields:
+	//   - OutputURN: The URN the run output the dataset under; differs from TestDatasetURN
+	//     when the match went through a dataset pattern
+	//   - TestDatasetURN: The URN the test reported
+	//   - JobCompletedAt: nil while the run has not completed
+	CorrelationCandidate struct {
+		RunID           string
+		JobName         string
+		JobNamespace    string
+		JobStatus       string
+		JobStartedAt    time.Time
+		JobCompletedAt  *time.Time
+		JobProducerName string
+		OutputURN       string
+		TestDatasetURN  string
+	}
+
+	// CorrelationExplanation explains which job run an incident was correlated to and why.
+	//
+	// Fields:
+	//   - Rule: The selection rule applied (SelectionRuleLatestStart)
+	//   - Reason: Human-readable summary of why the selected run won
+	//   - Candidates: Every candidate run in selection order; the first one is selected
+	CorrelationExplanation struct {
+		Rule       string
+		Reason     string
+		Candidates []CandidateExplanation
+	}
+
+	// CandidateExplanation is one candidate run with the signals that matter when judging
+	// the correlation.
+	//
+	// Fields:
+	//   - DatasetMatch: DatasetMatchExact or DatasetMatchResolved
+	//   - TimeDelta: Test execution time minus run completion time (negative if the run completed
+	//     after the test ran); nil while the run has not completed
+	//   - StartedBeforeTest: The run started before the test executed
+	//   - ProducerMatch: The run and the test were reported by the same tool
+	//   - Selected: This is the run the incident is correlated to
+	CandidateExplanation struct {
+		CorrelationCandidate
+
+		DatasetMatch      string
+		TimeDelta         *time.Duration
+		StartedBeforeTest bool
+		ProducerMatch     bool
+		Selected          bool
+	}
+)
+
+// ExplainCorrelation explains the correlation of incident given its candidate runs.
+//
+// Candidates are ranked by SelectionRuleLatestStart, the rule the incident views apply, so the
+// first candidate is the run the incident reports. The remaining signals (dataset match, time
+// delta, producer match) do not affect the selection; they show how plausible each candidate is.
+//
+// Returns an explanation with no candidates (and a reason saying so) when candidates is empty.
+func ExplainCorrelation(incident *Incident, candidates []CorrelationCandidate) *CorrelationExplanation {
+	explanation := &CorrelationExplanation{
+		Rule:       SelectionRuleLatestStart,
+		Candidates: make([]CandidateExplanation, 0, len(candidates)),
+	}
+
+	ranked := slices.Clone(candidates)
+	slices.SortStableFunc(ranked, func(a, b CorrelationCandidate) int {
+		if c := b.JobStartedAt.Compare(a.JobStartedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.RunID, b.RunID)
+	})
+
+	for i, candidate := range ranked {
+		explanation.Candidates = append(explanation.Candidates,
+			explainCandidate(incident, candidate, i == 0))
+	}
+
+	explanation.Reason = selectionReason(incident, ranked)
+
+	return explanation
+}
+
+// explainCandidate derives the match signals of one candidate.
+func explainCandidate(incident *Incident, candidate CorrelationCandidate, selected bool) CandidateExplanation {
+	explained := CandidateExplanation{
+		CorrelationCandidate: candidate,
+		DatasetMatch:         DatasetMatchExact,
+		StartedBeforeTest:    candidate.JobStartedAt.Before(incident.TestExecutedAt),
+		ProducerMatch: incident.TestProducerName != "" &&
+			strings.EqualFold(incident.TestProducerName, candidate.JobProducerName),
+		Selected: selected,
+	}
+
+	if candidate.OutputURN != candidate.TestDatasetURN {
+		explained.DatasetMatch = DatasetMatchResolved
+	}
+
+	if candidate.JobCompletedAt != nil {
+		delta := incident.TestExecutedAt.Sub(*candidate.JobCompletedAt)
+		explained.TimeDelta = &delta
+	}
+
+	return explained
+}
+
+// selectionReason summarizes why the first of the ranked candidates was selected.
+func selectionReason(incident *Incident, ranked []CorrelationCandidate) string {
+	switch len(ranked) {
+	case 0:
+		return fmt.Sprintf("No job run output dataset %s", incident.DatasetURN)
+	case 1:
+		return fmt.Sprintf("Run %s is the only job run that output dataset %s", ranked[0].RunID, incident.DatasetURN)
+	}
+
+	winner, runnerUp := ranked[0], ranked[1]
+
+	if winner.JobStartedAt.Equal(runnerUp.JobStartedAt) {
+		return fmt.Sprintf("Run %s is the most recently started of %d job runs that output dataset %s "+
+			"(tied with run %s on start time, broken by run ID)",
+			winner.RunID, len(ranked), incident.DatasetURN, runnerUp.RunID)
+	}
+
+	return fmt.Sprintf("Run %s is the most recently started of %d job runs that output dataset %s "+
+		"(started %s after run %s)",
+		winner.RunID, len(ranked), incident.DatasetURN,
+		winner.JobStartedAt.Sub(runnerUp.JobStartedAt).Round(time.Second), runnerUp.RunID)
+}
@@ -0,0 +1,100 @@
+package correlation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainCorrelation(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	executedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	incident := &Incident{
+		DatasetURN:       "postgresql://prod/public.orders",
+		TestExecutedAt:   executedAt,
+		TestProducerName: "dbt",
+	}
+
+	completed := func(d time.Duration) *time.Time {
+		at := executedAt.Add(d)
+
+		return &at
+	}
+
+	candidate := func(runID string, startedAt time.Duration, outputURN string) CorrelationCandidate {
+		return CorrelationCandidate{
+			RunID:           runID,
+			JobName:         "orders",
+			JobNamespace:    "dbt_prod",
+			JobStartedAt:    executedAt.Add(startedAt),
+			JobProducerName: "dbt",
+			OutputURN:       outputURN,
+			TestDatasetURN:  incident.DatasetURN,
+		}
+	}
+
+	t.Run("latest started run wins", func(t *testing.T) {
+		older := candidate("run-a", -2*time.Hour, incident.DatasetURN)
+		older.JobCompletedAt = completed(-90 * time.Minute)
+		older.JobProducerName = "airflow"
+
+		newer := candidate("run-b", -30*time.Minute, "orders_alias")
+		newer.JobCompletedAt = completed(-10 * time.Minute)
+
+		explanation := ExplainCorrelation(incident, []CorrelationCandidate{older, newer})
+
+		assert.Equal(t, SelectionRuleLatestStart, explanation.Rule)
+		assert.Equal(t, "Run run-b is the most recently started of 2 job runs that output dataset "+
+			"postgresql://prod/public.orders (started 1h30m0s after run run-a)", explanation.Reason)
+		require.Len(t, explanation.Candidates, 2)
+
+		winner, loser := explanation.Candidates[0], explanation.Candidates[1]
+
+		assert.Equal(t, "run-b", winner.RunID)
+		assert.True(t, winner.Selected)
+		assert.Equal(t, DatasetMatchResolved, winner.DatasetMatch)
+		assert.True(t, winner.ProducerMatch)
+		assert.True(t, winner.StartedBeforeTest)
+		require.NotNil(t, winner.TimeDelta)
+		assert.Equal(t, 10*time.Minute, *winner.TimeDelta)
+
+		assert.Equal(t, "run-a", loser.RunID)
+		assert.False(t, loser.Selected)
+		assert.Equal(t, DatasetMatchExact, loser.DatasetMatch)
+		assert.False(t, loser.ProducerMatch)
+		assert.Equal(t, 90*time.Minute, *loser.TimeDelta)
+	})
+
+	t.Run("run started after the test and still running", func(t *testing.T) {
+		explanation := ExplainCorrelation(incident, []CorrelationCandidate{
+			candidate("run-c", 5*time.Minute, incident.DatasetURN),
+		})
+
+		require.Len(t, explanation.Candidates, 1)
+		assert.Contains(t, explanation.Reason, "only job run")
+		assert.False(t, explanation.Candidates[0].StartedBeforeTest)
+		assert.Nil(t, explanation.Candidates[0].TimeDelta)
+	})
+
+	t.Run("start time tie broken by run ID", func(t *testing.T) {
+		explanation := ExplainCorrelation(incident, []CorrelationCandidate{
+			candidate("run-z", -time.Hour, incident.DatasetURN),
+			candidate("run-y", -time.Hour, incident.DatasetURN),
+		})
+
+		assert.Equal(t, "run-y", explanation.Candidates[0].RunID)
+		assert.Contains(t, explanation.Reason, "tied with run run-z")
+	})
+
+	t.Run("no candidates", func(t *testing.T) {
+		explanation := ExplainCorrelation(incident, nil)
+
+		assert.Empty(t, explanation.Candidates)
+		assert.Equal(t, "No job run output dataset postgresql://prod/public.orders", explanation.Reason)
+	})
+}
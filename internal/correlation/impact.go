@@ -0,0 +1,139 @@
+// Package correlation provides correlation engine functionality for linking incidents to job runs.
+package correlation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ImpactMaxDepth is the maximum number of hops ImpactAnalysis follows downstream.
+const ImpactMaxDepth = 10
+
+// ErrEmptyDatasetURN is returned by ImpactAnalysis when no root dataset is given.
+var ErrEmptyDatasetURN = errors.New("dataset URN is required")
+
+type (
+	// ConsumerReader looks up the job runs that consumed datasets and what they produced.
+	//
+	// Implemented by: storage.LineageStore (via correlation.Store).
+	ConsumerReader interface {
+		QueryDatasetConsumers(ctx context.Context, datasetURNs []string) ([]DatasetConsumer, error)
+	}
+
+	// DatasetConsumer is one hop of downstream lineage: a job run that read InputURN and
+	// wrote OutputURN. OutputURN is empty for a run without outputs (e.g., a test-only job).
+	DatasetConsumer struct {
+		InputURN     string
+		RunID        string
+		JobNamespace string
+		JobName      string
+		OutputURN    string
+	}
+
+	// Impact is the downstream blast radius of a failed dataset.
+	//
+	// Fields:
+	//   - DatasetURN: The root (failed) dataset
+	//   - Datasets: Affected downstream datasets, closest first
+	//   - Runs: Job runs that consumed the root or an affected dataset, closest first
+	//   - Severity: Sum of the dataset weights (higher = wider, closer blast radius)
+	//   - Truncated: True if lineage continues past ImpactMaxDepth
+	Impact struct {
+		DatasetURN string
+		Datasets   []ImpactedDataset
+		Runs       []ImpactedRun
+		Severity   float64
+		Truncated  bool
+	}
+
+	// ImpactedDataset is a downstream dataset at its shortest distance from the root.
+	// Distance 1 = written by a job that read the root. Weight = 1 / Distance.
+	ImpactedDataset struct {
+		DatasetURN string
+		Distance   int
+		Weight     float64
+	}
+
+	// ImpactedRun is a job run that consumed the root (Distance 1) or an affected dataset.
+	ImpactedRun struct {
+		RunID        string
+		JobNamespace string
+		JobName      string
+		Distance     int
+		Weight       float64
+	}
+)
+
+// ImpactAnalysis traverses downstream lineage from datasetURN and scores the blast radius.
+//
+// The traversal is breadth-first, one QueryDatasetConsumers call per level, so every dataset
+// and run is recorded once at its shortest distance. A dataset reachable by several paths
+// (a diamond) is never double-counted, and cycles terminate. Closer datasets weigh more:
+// weight = 1 / distance, so a direct dependant counts 1, one two hops away 0.5.
+//
+// Returns an empty Impact (Severity 0) when nothing consumed datasetURN.
+func ImpactAnalysis(ctx context.Context, reader ConsumerReader, datasetURN string) (*Impact, error) {
+	if datasetURN == "" {
+		return nil, ErrEmptyDatasetURN
+	}
+
+	impact := &Impact{DatasetURN: datasetURN}
+
+	seenDatasets := map[string]bool{datasetURN: true}
+	seenRuns := make(map[string]bool)
+	frontier := []string{datasetURN}
+
+	for distance := 1; len(frontier) > 0; distance++ {
+		consumers, err := reader.QueryDatasetConsumers(ctx, frontier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query consumers at distance %d: %w", distance, err)
+		}
+
+		if distance > ImpactMaxDepth {
+			impact.Truncated = len(consumers) > 0
+
+			break
+		}
+
+		weight := 1 / float64(distance)
+		frontier = nil
+
+		for _, c := range consumers {
+			if !seenRuns[c.RunID] {
+				seenRuns[c.RunID] = true
+				impact.Runs = append(impact.Runs, ImpactedRun{
+					RunID:        c.RunID,
+					JobNamespace: c.JobNamespace,
+					JobName:      c.JobName,
+					Distance:     distance,
+					Weight:       weight,
+				})
+			}
+
+			if c.OutputURN == "" || seenDatasets[c.OutputURN] {
+				continue
+			}
+
+			seenDatasets[c.OutputURN] = true
+			frontier = append(frontier, c.OutputURN)
+			impact.Datasets = append(impact.Datasets, ImpactedDataset{
+				DatasetURN: c.OutputURN,
+				Distance:   distance,
+				Weight:     weight,
+			})
+			impact.Severity += weight
+		}
+	}
+
+	slices.SortStableFunc(impact.Datasets, func(a, b ImpactedDataset) int {
+		return cmp.Or(cmp.Compare(a.Distance, b.Distance), cmp.Compare(a.DatasetURN, b.DatasetURN))
+	})
+	slices.SortStableFunc(impact.Runs, func(a, b ImpactedRun) int {
+		return cmp.Or(cmp.Compare(a.Distance, b.Distance), cmp.Compare(a.RunID, b.RunID))
+	})
+
+	return impact, nil
+}
@@ -0,0 +1,152 @@
+package correlation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsumerReader serves downstream edges from an in-memory graph: input URN → consumers.
+type fakeConsumerReader struct {
+	graph map[string][]DatasetConsumer
+	calls int
+	err   error
+}
+
+func (r *fakeConsumerReader) QueryDatasetConsumers(_ context.Context, urns []string) ([]DatasetConsumer, error) {
+	r.calls++
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var consumers []DatasetConsumer
+	for _, urn := range urns {
+		consumers = append(consumers, r.graph[urn]...)
+	}
+
+	return consumers, nil
+}
+
+// edge builds a consumer of input: run reads input and writes output.
+func edge(input, run, output string) DatasetConsumer {
+	return DatasetConsumer{InputURN: input, RunID: run, JobNamespace: "dbt", JobName: "job_" + run, OutputURN: output}
+}
+
+func TestImpactAnalysis(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	ctx := context.Background()
+
+	t.Run("chain weights by distance", func(t *testing.T) {
+		reader := &fakeConsumerReader{graph: map[string][]DatasetConsumer{
+			"raw":   {edge("raw", "r1", "stg")},
+			"stg":   {edge("stg", "r2", "mart")},
+			"mart":  {edge("mart", "r3", "")}, // Test-only job without outputs
+			"other": {edge("other", "r9", "unrelated")},
+		}}
+
+		impact, err := ImpactAnalysis(ctx, reader, "raw")
+		require.NoError(t, err)
+
+		assert.Equal(t, "raw", impact.DatasetURN)
+		assert.Equal(t, []ImpactedDataset{
+			{DatasetURN: "stg", Distance: 1, Weight: 1},
+			{DatasetURN: "mart", Distance: 2, Weight: 0.5},
+		}, impact.Datasets)
+		require.Len(t, impact.Runs, 3)
+		assert.Equal(t, "r3", impact.Runs[2].RunID)
+		assert.Equal(t, 3, impact.Runs[2].Distance)
+		assert.InDelta(t, 1.5, impact.Severity, 1e-9)
+		assert.False(t, impact.Truncated)
+	})
+
+	t.Run("diamond counts shared dataset once at shortest distance", func(t *testing.T) {
+		// raw → a → mart, raw → b → c → mart, and raw → mart directly via r5
+		reader := &fakeConsumerReader{graph: map[string][]DatasetConsumer{
+			"raw": {edge("raw", "r1", "a"), edge("raw", "r2", "b"), edge("raw", "r5", "mart")},
+			"a":   {edge("a", "r3", "mart")},
+			"b":   {edge("b", "r4", "c")},
+			"c":   {edge("c", "r6", "mart")},
+		}}
+
+		impact, err := ImpactAnalysis(ctx, reader, "raw")
+		require.NoError(t, err)
+
+		assert.Equal(t, []ImpactedDataset{
+			{DatasetURN: "a", Distance: 1, Weight: 1},
+			{DatasetURN: "b", Distance: 1, Weight: 1},
+			{DatasetURN: "mart", Distance: 1, Weight: 1},
+			{DatasetURN: "c", Distance: 2, Weight: 0.5},
+		}, impact.Datasets)
+		assert.Len(t, impact.Runs, 6)
+		assert.InDelta(t, 3.5, impact.Severity, 1e-9)
+	})
+
+	t.Run("run with several inputs counted once", func(t *testing.T) {
+		reader := &fakeConsumerReader{graph: map[string][]DatasetConsumer{
+			"raw": {edge("raw", "r1", "a"), edge("raw", "r2", "b")},
+			"a":   {edge("a", "join", "mart")},
+			"b":   {edge("b", "join", "mart")},
+		}}
+
+		impact, err := ImpactAnalysis(ctx, reader, "raw")
+		require.NoError(t, err)
+
+		assert.Len(t, impact.Datasets, 3)
+		assert.Len(t, impact.Runs, 3)
+	})
+
+	t.Run("cycle terminates", func(t *testing.T) {
+		reader := &fakeConsumerReader{graph: map[string][]DatasetConsumer{
+			"raw": {edge("raw", "r1", "a")},
+			"a":   {edge("a", "r2", "raw")},
+		}}
+
+		impact, err := ImpactAnalysis(ctx, reader, "raw")
+		require.NoError(t, err)
+
+		assert.Equal(t, []ImpactedDataset{{DatasetURN: "a", Distance: 1, Weight: 1}}, impact.Datasets)
+		assert.Len(t, impact.Runs, 2)
+	})
+
+	t.Run("no consumers", func(t *testing.T) {
+		impact, err := ImpactAnalysis(ctx, &fakeConsumerReader{}, "raw")
+		require.NoError(t, err)
+
+		assert.Empty(t, impact.Datasets)
+		assert.Empty(t, impact.Runs)
+		assert.Zero(t, impact.Severity)
+	})
+
+	t.Run("stops at max depth", func(t *testing.T) {
+		graph := map[string][]DatasetConsumer{}
+		for i := range ImpactMaxDepth + 5 {
+			in, out := string(rune('a'+i)), string(rune('a'+i+1))
+			graph[in] = []DatasetConsumer{edge(in, "r"+in, out)}
+		}
+
+		reader := &fakeConsumerReader{graph: graph}
+
+		impact, err := ImpactAnalysis(ctx, reader, "a")
+		require.NoError(t, err)
+
+		assert.Len(t, impact.Datasets, ImpactMaxDepth)
+		assert.True(t, impact.Truncated)
+		assert.Equal(t, ImpactMaxDepth+1, reader.calls)
+	})
+
+	t.Run("empty URN", func(t *testing.T) {
+		_, err := ImpactAnalysis(ctx, &fakeConsumerReader{}, "")
+		require.ErrorIs(t, err, ErrEmptyDatasetURN)
+	})
+
+	t.Run("reader error", func(t *testing.T) {
+		_, err := ImpactAnalysis(ctx, &fakeConsumerReader{err: errLookupFailed}, "raw")
+		require.ErrorIs(t, err, errLookupFailed)
+	})
+}
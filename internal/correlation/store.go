@@ -207,6 +207,63 @@ type Store interface {
 	// current incident itself. Uses (test_name, dataset_urn, root_parent_run_id) grouping.
 	// Returns nil if the incident has no root_parent_run_id or no siblings exist.
 	QueryOtherAttempts(ctx context.Context, testResultID int64) ([]RunRetryAttempt, error)
+
+	// QueryDatasetConsumers returns one hop of downstream lineage: every job run with an input
+	// edge to one of datasetURNs, paired with each of its output datasets.
+	// Used by ImpactAnalysis to walk the downstream graph level by level.
+	//
+	// Returns:
+	//   - One DatasetConsumer per (input, run, output); OutputURN is empty for runs without outputs
+	//   - Self-loops (a run reading and writing the same dataset) are excluded
+	//   - Empty slice if nothing consumed the datasets
+	//   - Error if query fails or context is cancelled
+	QueryDatasetConsumers(ctx context.Context, datasetURNs []string) ([]DatasetConsumer, error)
+
+	// QueryColumnLineage returns the column-level lineage runID reported for datasetURN.
+	//
+	// Returns:
+	//   - Edges ordered by output column, then input dataset and column
+	//   - Schema columns without an edge as UnmappedColumns (partial lineage)
+	//   - Empty ColumnLineage (not nil) if the run reported none
+	//   - Error if query fails or context is cancelled
+	//
+	// Used by:
+	//   - GET /api/v1/incidents/{id}?column_lineage=true
+	QueryColumnLineage(ctx context.Context, datasetURN string, runID string) (*ColumnLineage, error)
+
+	// QueryCorrelationCandidates returns every job run the incident view correlates testResultID
+	// to, i.e. every run that output the failing dataset (directly or through a dataset pattern).
+	//
+	// Returns:
+	//   - One CorrelationCandidate per run, most recently started first (see ExplainCorrelation)
+	//   - Empty slice if the test result is not an incident
+	//   - Error if query fails or context is cancelled
+	//
+	// Used by:
+	//   - GET /api/v1/incidents/{id}?explain=true
+	QueryCorrelationCandidates(ctx context.Context, testResultID int64) ([]CorrelationCandidate, error)
+
+	// QueryCorrelationCandidatesBatch is QueryCorrelationCandidates for several test results in
+	// one query, avoiding N+1 queries when correlating every test of a job run.
+	//
+	// Returns:
+	//   - Map of test_result_id -> candidates (missing keys are not incidents or have no candidates)
+	//   - Error if query fails or context is cancelled
+	//
+	// Used by:
+	//   - GET /api/v1/job-runs/{jobRunID}/correlations
+	QueryCorrelationCandidatesBatch(ctx context.Context, testResultIDs []int64) (map[int64][]CorrelationCandidate, error)
+
+	// QueryJobRunTestResults returns a job run and a page of the test results it reported.
+	//
+	// Returns:
+	//   - JobRunTestResults with failed and errored tests first, then by executed_at DESC
+	//   - nil (no error) if the job run does not exist
+	//   - Error if query fails or context is cancelled
+	//
+	// Used by:
+	//   - GET /api/v1/job-runs/{jobRunID}/correlations
+	QueryJobRunTestResults(ctx context.Context, runID string, pagination *Pagination) (*JobRunTestResults, error)
 }
 
 // ResolutionStore defines write operations for incident resolution lifecycle.
@@ -137,6 +137,28 @@ type (
 		ResolvedAt       *time.Time       // When the status was last changed
 		// Run retry context (computed via window functions, only populated in list queries)
 		RunRetryContext *RunRetryContext
+		// Producing run's failure detail (from the errorMessage facet, only populated in detail queries).
+		// Nil when the run did not fail or the producer sent no error facet.
+		JobError *JobError
+		// OpenLineage spec version of the producing run's latest event (e.g., "2.0.2").
+		// Only populated in detail queries; empty for runs ingested before it was recorded.
+		JobOpenLineageVersion string
+		// Scheduled slot of the producing run from the nominalTime facet (only populated in detail
+		// queries). Nil when the producer sent no nominalTime facet.
+		JobNominalStartTime *time.Time
+		JobNominalEndTime   *time.Time
+		// Plugin (API key client ID) that sent the producing run's latest event (only populated
+		// in detail queries). Empty for runs ingested before it was recorded or via Kafka.
+		JobIngestedBy string
+	}
+
+	// JobError is the failure detail a producer attached to a FAIL or ABORT run.
+	// Text is size-bounded at ingestion; StackTrace may span many lines.
+	JobError struct {
+		Message             string
+		StackTrace          string
+		ProgrammingLanguage string
+		Classification      string
 	}
 
 	// OrchestrationNode represents one level in the orchestration chain.
@@ -205,6 +227,34 @@ type (
 		Total     int
 	}
 
+	// JobRunTestResults is a job run with a page of the test results it reported
+	// (test_results.run_id), failed and errored tests first.
+	//
+	// Fields:
+	//   - TestResults: Test results for the requested page
+	//   - Total: Total count of the run's test results (before pagination)
+	JobRunTestResults struct {
+		RunID        string
+		JobName      string
+		JobNamespace string
+		JobStatus    string
+		IngestedBy   string // Plugin that sent the run's latest event (empty when unknown)
+		TestResults  []JobRunTestResult
+		Total        int
+	}
+
+	// JobRunTestResult is one test result reported by a job run.
+	JobRunTestResult struct {
+		TestResultID int64
+		TestName     string
+		TestType     string
+		Status       string
+		Message      string
+		DatasetURN   string
+		ExecutedAt   time.Time
+		ProducerName string
+	}
+
 	// DownstreamResult represents a downstream dataset with parent relationship.
 	// This type is used for building lineage tree visualizations in the UI.
 	//
@@ -252,6 +302,31 @@ type (
 		Producer    string
 	}
 
+	// ColumnLineage is the column-level lineage a job run reported for one output dataset
+	// through the OpenLineage columnLineage facet.
+	//
+	// Fields:
+	//   - Edges: One entry per (output column, input column), ordered by output column
+	//   - UnmappedColumns: Columns of the dataset's schema facet with no lineage entry
+	//
+	// Producers often report lineage for only some columns, so UnmappedColumns is how a
+	// client tells "not reported" apart from "no such column". Both are empty when the run
+	// reported no column lineage and the dataset has no schema facet.
+	ColumnLineage struct {
+		Edges           []ColumnLineageEdge
+		UnmappedColumns []string
+	}
+
+	// ColumnLineageEdge maps an output column to one input column it is derived from.
+	// TransformationType and TransformationSubtype are empty when not reported.
+	ColumnLineageEdge struct {
+		OutputColumn          string
+		InputDatasetURN       string
+		InputColumn           string
+		TransformationType    string
+		TransformationSubtype string
+	}
+
 	// OrphanNamespace represents a namespace that appears in validation tests
 	// but has no corresponding data producer output edges.
 	//
@@ -413,6 +488,9 @@ type (
 		Reason   string // "manual", "false_positive", "expected"
 		Note     string
 		MuteDays int // Only for muted; default 30
+		// UnmodifiedSince is an optional optimistic-concurrency precondition (If-Unmodified-Since):
+		// the change is rejected if the resolution was updated after this time (second precision).
+		UnmodifiedSince *time.Time
 	}
 
 	// ResolutionStatusFilter represents the status filter for listing incidents.
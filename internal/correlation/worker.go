@@ -0,0 +1,341 @@
+// Package correlation provides correlation engine functionality for linking incidents to job runs.
+package correlation
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Worker defaults.
+const (
+	// DefaultWorkerCount is the number of goroutines processing the correlation queue.
+	DefaultWorkerCount = 2
+	// DefaultWorkerQueueSize is the maximum number of pending test result IDs.
+	// Enqueue drops (and logs) IDs once the queue is full rather than blocking ingestion.
+	DefaultWorkerQueueSize = 1000
+
+	// processedCacheSize bounds the set of already-notified test result IDs used for idempotency.
+	processedCacheSize = 10000
+	// workerProcessTimeout is the maximum time allowed to correlate and notify a single incident.
+	workerProcessTimeout = 10 * time.Second
+	// workerShutdownTimeout is the maximum time Close waits for queued work to drain.
+	workerShutdownTimeout = 5 * time.Second
+)
+
+type (
+	// IncidentReader looks up a correlated incident by test result ID.
+	//
+	// Implemented by: storage.LineageStore (via correlation.Store).
+	IncidentReader interface {
+		QueryIncidentByID(ctx context.Context, testResultID int64) (*Incident, error)
+	}
+
+	// Notifier is called once per newly correlated incident.
+	// Implementations must be safe for concurrent use by multiple workers.
+	Notifier interface {
+		NotifyIncident(ctx context.Context, incident *Incident) error
+	}
+
+	// WorkerConfig configures the background correlation Worker.
+	//
+	// Fields:
+	//   - Workers: Number of processing goroutines (<= 0 uses DefaultWorkerCount)
+	//   - QueueSize: Capacity of the pending ID queue (<= 0 uses DefaultWorkerQueueSize)
+	WorkerConfig struct {
+		Workers   int
+		QueueSize int
+	}
+
+	// Worker correlates newly stored test failures and failed runs in the background.
+	//
+	// The ingestion path enqueues failing test result IDs once they are visible in
+	// incident_correlation_view (i.e., after the debounced view refresh): newly stored ones, and
+	// those the view correlates to a newly failed run. Workers look up the correlated incident and
+	// hand it to the Notifier, delivering incidents within seconds of ingestion instead of waiting
+	// for someone to open the UI.
+	//
+	// Properties:
+	//   - Bounded: Enqueue never blocks; IDs are dropped and logged when the queue is full
+	//   - Idempotent: An ID that was already notified is skipped when enqueued again
+	//   - Graceful: Close stops intake, drains queued IDs, and waits for in-flight work
+	//
+	// Lifecycle:
+	//
+	//	worker := correlation.NewWorker(cfg, notifier, logger)
+	//	store, _ := storage.NewLineageStore(conn, interval, storage.WithIncidentSink(worker))
+	//	worker.Start(store)
+	//	defer worker.Close()
+	Worker struct {
+		notifier Notifier
+		logger   *slog.Logger
+		workers  int
+		queue    chan int64
+
+		ctx    context.Context //nolint:containedctx // Cancelled when Close times out
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+
+		mu        sync.Mutex // Guards closed, started, and queue sends
+		closed    bool
+		started   bool
+		closeOnce sync.Once
+
+		processedMu    sync.Mutex
+		processed      map[int64]bool // true = notified, false = in flight
+		processedOrder []int64        // FIFO eviction order for notified IDs
+
+		dropped atomic.Int64
+	}
+
+	// LogNotifier is a Notifier that emits a structured log line per correlated incident.
+	// It is the default notifier until external notification channels are configured.
+	LogNotifier struct {
+		logger *slog.Logger
+	}
+)
+
+// NewWorker creates a background correlation worker. Goroutines are not started until Start.
+// IDs enqueued before Start are buffered (up to QueueSize).
+func NewWorker(cfg WorkerConfig, notifier Notifier, logger *slog.Logger) *Worker {
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkerCount
+	}
+
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultWorkerQueueSize
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	if notifier == nil {
+		notifier = NewLogNotifier(logger)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Worker{
+		notifier:  notifier,
+		logger:    logger,
+		workers:   cfg.Workers,
+		queue:     make(chan int64, cfg.QueueSize),
+		ctx:       ctx,
+		cancel:    cancel,
+		processed: make(map[int64]bool),
+	}
+}
+
+// Start launches the worker goroutines using reader to look up correlated incidents.
+// Calling Start more than once, or after Close, is a no-op.
+func (w *Worker) Start(reader IncidentReader) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.started || w.closed {
+		return
+	}
+
+	w.started = true
+
+	for range w.workers {
+		w.wg.Add(1)
+
+		go w.run(reader)
+	}
+
+	w.logger.Info("Correlation worker started",
+		slog.Int("workers", w.workers),
+		slog.Int("queue_size", cap(w.queue)),
+	)
+}
+
+// Enqueue schedules a test result ID for correlation and notification.
+// Never blocks: returns false if the worker is closed or the queue is full (backpressure).
+func (w *Worker) Enqueue(testResultID int64) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return false
+	}
+
+	select {
+	case w.queue <- testResultID:
+		return true
+	default:
+		dropped := w.dropped.Add(1)
+
+		w.logger.Warn("Correlation queue full, dropping test result",
+			slog.Int64("test_result_id", testResultID),
+			slog.Int("queue_size", cap(w.queue)),
+			slog.Int64("dropped_total", dropped),
+		)
+
+		return false
+	}
+}
+
+// Dropped returns the number of IDs dropped due to a full queue since the worker was created.
+func (w *Worker) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// Close stops accepting new IDs, drains the queue, and waits for workers to finish.
+// If draining exceeds the shutdown timeout, in-flight work is cancelled.
+// This method is safe to call multiple times.
+func (w *Worker) Close() error {
+	w.closeOnce.Do(func() {
+		w.mu.Lock()
+		w.closed = true
+		started := w.started
+		close(w.queue)
+		w.mu.Unlock()
+
+		if !started {
+			w.cancel()
+
+			return
+		}
+
+		done := make(chan struct{})
+
+		go func() {
+			w.wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			w.logger.Info("Correlation worker stopped gracefully")
+		case <-time.After(workerShutdownTimeout):
+			w.logger.Warn("Correlation worker did not drain within timeout, cancelling in-flight work",
+				slog.Int("pending", len(w.queue)),
+			)
+			w.cancel()
+			<-done
+		}
+
+		w.cancel()
+	})
+
+	return nil
+}
+
+// run processes IDs until the queue is closed and drained.
+func (w *Worker) run(reader IncidentReader) {
+	defer w.wg.Done()
+
+	for testResultID := range w.queue {
+		if w.ctx.Err() != nil {
+			continue // Shutdown timed out: discard remaining IDs
+		}
+
+		w.process(reader, testResultID)
+	}
+}
+
+// process correlates a single test result and notifies at most once per ID.
+// IDs whose incident is not (yet) visible are released, so a later enqueue retries them.
+func (w *Worker) process(reader IncidentReader, testResultID int64) {
+	if !w.claim(testResultID) {
+		w.logger.Debug("Test result already correlated, skipping",
+			slog.Int64("test_result_id", testResultID))
+
+		return
+	}
+
+	notified := false
+
+	defer func() { w.finish(testResultID, notified) }()
+
+	ctx, cancel := context.WithTimeout(w.ctx, workerProcessTimeout)
+	defer cancel()
+
+	incident, err := reader.QueryIncidentByID(ctx, testResultID)
+	if err != nil {
+		w.logger.Error("Failed to correlate test result",
+			slog.Int64("test_result_id", testResultID),
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	if incident == nil {
+		w.logger.Debug("Test result not correlated yet (no incident in view)",
+			slog.Int64("test_result_id", testResultID))
+
+		return
+	}
+
+	if err := w.notifier.NotifyIncident(ctx, incident); err != nil {
+		w.logger.Error("Failed to notify incident",
+			slog.Int64("test_result_id", testResultID),
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	notified = true
+}
+
+// claim marks the ID as in flight. Returns false if it is already notified or being
+// processed by another worker, which makes reprocessing the same ID a no-op.
+func (w *Worker) claim(testResultID int64) bool {
+	w.processedMu.Lock()
+	defer w.processedMu.Unlock()
+
+	if _, ok := w.processed[testResultID]; ok {
+		return false
+	}
+
+	w.processed[testResultID] = false
+
+	return true
+}
+
+// finish records the outcome of a claimed ID. Notified IDs are remembered (evicting the
+// oldest entry when the set is full); failed IDs are released so they can be retried.
+func (w *Worker) finish(testResultID int64, notified bool) {
+	w.processedMu.Lock()
+	defer w.processedMu.Unlock()
+
+	if !notified {
+		delete(w.processed, testResultID)
+
+		return
+	}
+
+	if len(w.processedOrder) >= processedCacheSize {
+		oldest := w.processedOrder[0]
+		w.processedOrder = w.processedOrder[1:]
+		delete(w.processed, oldest)
+	}
+
+	w.processed[testResultID] = true
+	w.processedOrder = append(w.processedOrder, testResultID)
+}
+
+// NewLogNotifier creates a Notifier that logs correlated incidents.
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// NotifyIncident logs the correlated incident with its producing job run.
+func (n *LogNotifier) NotifyIncident(ctx context.Context, incident *Incident) error {
+	n.logger.InfoContext(ctx, "Incident correlated",
+		slog.Int64("test_result_id", incident.TestResultID),
+		slog.String("test_name", incident.TestName),
+		slog.String("dataset_urn", incident.DatasetURN),
+		slog.String("run_id", incident.RunID),
+		slog.String("job_name", incident.JobName),
+		slog.String("job_status", incident.JobStatus),
+	)
+
+	return nil
+}
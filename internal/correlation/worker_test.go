@@ -0,0 +1,237 @@
+package correlation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errLookupFailed = errors.New("lookup failed")
+
+// fakeIncidentReader returns an incident for every ID in incidents; other IDs are "not correlated yet".
+type fakeIncidentReader struct {
+	mu        sync.Mutex
+	incidents map[int64]bool
+	err       error
+	block     chan struct{} // When non-nil, lookups wait until closed
+}
+
+func (r *fakeIncidentReader) QueryIncidentByID(ctx context.Context, testResultID int64) (*Incident, error) {
+	if r.block != nil {
+		select {
+		case <-r.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if !r.incidents[testResultID] {
+		return nil, nil //nolint:nilnil // Mirrors QueryIncidentByID contract: not found is not an error
+	}
+
+	return &Incident{TestResultID: testResultID}, nil
+}
+
+func (r *fakeIncidentReader) setIncident(testResultID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.incidents[testResultID] = true
+}
+
+// recordingNotifier records every notified test result ID.
+type recordingNotifier struct {
+	mu       sync.Mutex
+	notified []int64
+}
+
+func (n *recordingNotifier) NotifyIncident(_ context.Context, incident *Incident) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.notified = append(n.notified, incident.TestResultID)
+
+	return nil
+}
+
+func (n *recordingNotifier) ids() []int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]int64(nil), n.notified...)
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestWorker_NotifiesCorrelatedIncidents(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	reader := &fakeIncidentReader{incidents: map[int64]bool{1: true, 2: true}}
+	notifier := &recordingNotifier{}
+
+	worker := NewWorker(WorkerConfig{Workers: 2, QueueSize: 10}, notifier, discardLogger())
+	worker.Start(reader)
+
+	assert.True(t, worker.Enqueue(1))
+	assert.True(t, worker.Enqueue(2))
+	assert.True(t, worker.Enqueue(3)) // Not correlated: no notification
+
+	require.NoError(t, worker.Close())
+
+	assert.ElementsMatch(t, []int64{1, 2}, notifier.ids())
+}
+
+func TestWorker_IsIdempotent(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	reader := &fakeIncidentReader{incidents: map[int64]bool{42: true}}
+	notifier := &recordingNotifier{}
+
+	worker := NewWorker(WorkerConfig{Workers: 4, QueueSize: 10}, notifier, discardLogger())
+	worker.Start(reader)
+
+	for range 5 {
+		worker.Enqueue(42)
+	}
+
+	require.NoError(t, worker.Close())
+
+	assert.Equal(t, []int64{42}, notifier.ids(), "Reprocessing the same ID must not notify twice")
+}
+
+func TestWorker_RetriesIDNotYetCorrelated(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	reader := &fakeIncidentReader{incidents: map[int64]bool{}}
+	notifier := &recordingNotifier{}
+
+	worker := NewWorker(WorkerConfig{Workers: 1, QueueSize: 10}, notifier, discardLogger())
+	worker.Start(reader)
+
+	worker.Enqueue(7)
+
+	// Wait for the first (unsuccessful) attempt to be dequeued and released
+	require.Eventually(t, func() bool {
+		worker.processedMu.Lock()
+		defer worker.processedMu.Unlock()
+
+		_, tracked := worker.processed[7]
+
+		return len(worker.queue) == 0 && !tracked
+	}, time.Second, 5*time.Millisecond)
+
+	reader.setIncident(7)
+	worker.Enqueue(7)
+
+	require.NoError(t, worker.Close())
+
+	assert.Equal(t, []int64{7}, notifier.ids())
+}
+
+func TestWorker_LookupErrorDoesNotNotify(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	reader := &fakeIncidentReader{incidents: map[int64]bool{1: true}, err: errLookupFailed}
+	notifier := &recordingNotifier{}
+
+	worker := NewWorker(WorkerConfig{Workers: 1, QueueSize: 10}, notifier, discardLogger())
+	worker.Start(reader)
+
+	worker.Enqueue(1)
+
+	require.NoError(t, worker.Close())
+
+	assert.Empty(t, notifier.ids())
+}
+
+func TestWorker_DropsWhenQueueFull(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	worker := NewWorker(WorkerConfig{Workers: 1, QueueSize: 2}, &recordingNotifier{}, discardLogger())
+
+	// Not started: nothing drains the queue
+	assert.True(t, worker.Enqueue(1))
+	assert.True(t, worker.Enqueue(2))
+	assert.False(t, worker.Enqueue(3), "Enqueue must not block when the queue is full")
+	assert.Equal(t, int64(1), worker.Dropped())
+
+	require.NoError(t, worker.Close())
+}
+
+func TestWorker_CloseDrainsQueueAndRejectsNewIDs(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	block := make(chan struct{})
+	reader := &fakeIncidentReader{incidents: map[int64]bool{1: true, 2: true, 3: true}, block: block}
+	notifier := &recordingNotifier{}
+
+	worker := NewWorker(WorkerConfig{Workers: 1, QueueSize: 10}, notifier, discardLogger())
+	worker.Start(reader)
+
+	worker.Enqueue(1)
+	worker.Enqueue(2)
+	worker.Enqueue(3)
+
+	closed := make(chan struct{})
+
+	go func() {
+		_ = worker.Close()
+
+		close(closed)
+	}()
+
+	// Close must wait for queued work
+	select {
+	case <-closed:
+		t.Fatal("Close returned before queued work was drained")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(block)
+	<-closed
+
+	assert.ElementsMatch(t, []int64{1, 2, 3}, notifier.ids())
+	assert.False(t, worker.Enqueue(4), "Enqueue after Close must be rejected")
+	require.NoError(t, worker.Close(), "Close must be safe to call twice")
+}
+
+func TestNewWorker_Defaults(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	worker := NewWorker(WorkerConfig{}, nil, nil)
+	t.Cleanup(func() { _ = worker.Close() })
+
+	assert.Equal(t, DefaultWorkerCount, worker.workers)
+	assert.Equal(t, DefaultWorkerQueueSize, cap(worker.queue))
+	assert.IsType(t, &LogNotifier{}, worker.notifier)
+}
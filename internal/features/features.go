@@ -0,0 +1,251 @@
+// Package features evaluates runtime feature flags, so experimental behavior (strict schema,
+// async ingestion, auto-correlation, ...) can be rolled out without a redeploy.
+//
+// Flags are stored in the feature_flags table (see storage.FeatureFlag): a global value per flag
+// and optional per-plugin overrides. A flag is evaluated as:
+//
+//  1. the value set for the plugin (API key client ID), if any
+//  2. otherwise the global value, if any
+//  3. otherwise off
+//
+// Handlers check flags through the request context:
+//
+//	if features.Enabled(r.Context(), "strict_schema") {
+//	    ...
+//	}
+//
+// Middleware takes one snapshot of the flags per request, so a flag cannot change value halfway
+// through a request.
+package features
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+const (
+	// DefaultCacheTTL bounds how long flag values are reused before the store is read again, i.e.
+	// how long a change takes to reach running instances.
+	DefaultCacheTTL = 30 * time.Second
+
+	// readTimeout bounds one read of the store.
+	readTimeout = 5 * time.Second
+
+	// retryInterval is how long the store is left alone after a failed read (at most the TTL).
+	retryInterval = 5 * time.Second
+)
+
+type (
+	// Store is the interface Flags reads flag values from.
+	//
+	// Implemented by: storage.LineageStore.
+	Store interface {
+		FeatureFlags(ctx context.Context) ([]storage.FeatureFlag, error)
+	}
+
+	// Flags evaluates feature flags read from a Store, cached for a TTL.
+	//
+	// Safe for concurrent use. A nil *Flags evaluates every flag as off.
+	Flags struct {
+		store  Store
+		ttl    time.Duration
+		logger *slog.Logger
+		now    func() time.Time // Injectable clock for tests
+
+		mu       sync.Mutex
+		current  *snapshot
+		loadedAt time.Time     // Time of the last successful read
+		retryAt  time.Time     // After a failed read, the store is not read again before this time
+		loading  chan struct{} // Closed when the read in progress ends; nil when none is
+	}
+
+	// snapshot is the flag values read from the store at one point in time.
+	snapshot struct {
+		global  map[string]bool            // flag name -> enabled
+		plugins map[string]map[string]bool // plugin -> flag name -> enabled
+	}
+
+	// requestFlags is the flag evaluation bound to one request.
+	requestFlags struct {
+		snapshot *snapshot
+		plugin   string
+	}
+
+	contextKey struct{}
+)
+
+// NewFlags creates a flag evaluator reading store at most once per ttl (DefaultCacheTTL when
+// ttl is not positive).
+func NewFlags(store Store, ttl time.Duration, logger *slog.Logger) *Flags {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &Flags{
+		store:  store,
+		ttl:    ttl,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Enabled reports whether flag name is on for plugin. Use it outside of HTTP requests (e.g. in
+// workers); handlers use the package-level Enabled.
+func (f *Flags) Enabled(ctx context.Context, name, plugin string) bool {
+	return f.load(ctx).enabled(name, plugin)
+}
+
+// WithContext returns a copy of ctx carrying the current flag values for plugin, for Enabled.
+func WithContext(ctx context.Context, f *Flags, plugin string) context.Context {
+	return context.WithValue(ctx, contextKey{}, requestFlags{snapshot: f.load(ctx), plugin: plugin})
+}
+
+// Enabled reports whether flag name is on for the request of ctx. Always false when ctx carries
+// no flags (see WithContext and Middleware).
+func Enabled(ctx context.Context, name string) bool {
+	flags, ok := ctx.Value(contextKey{}).(requestFlags)
+	if !ok {
+		return false
+	}
+
+	return flags.snapshot.enabled(name, flags.plugin)
+}
+
+// Middleware returns middleware that puts the flag values of the authenticated plugin into the
+// request context. Must run after Authenticate (and Impersonate, so overrides of the
+// impersonated plugin apply); without a ClientContext only global values apply.
+// If f is nil, requests pass unchanged and every flag evaluates as off.
+func Middleware(f *Flags) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if f == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var plugin string
+			if clientCtx, ok := middleware.GetClientContext(r.Context()); ok {
+				plugin = clientCtx.ClientID
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithContext(r.Context(), f, plugin)))
+		})
+	}
+}
+
+// load returns the cached flag values, reading the store when they are older than the TTL.
+// One caller reads the store at a time, without holding the lock: the others get the values it
+// replaces, or wait for it when there are none yet. When the store cannot be read, the last
+// values are kept (every flag is off until a first read succeeds) and the store is read again
+// after retryInterval.
+func (f *Flags) load(ctx context.Context) *snapshot {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+
+	now := f.now()
+	if f.current != nil && (now.Sub(f.loadedAt) < f.ttl || now.Before(f.retryAt)) {
+		defer f.mu.Unlock()
+
+		return f.current
+	}
+
+	if loading := f.loading; loading != nil {
+		current := f.current
+		f.mu.Unlock()
+
+		if current != nil {
+			return current
+		}
+
+		<-loading
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		return f.current
+	}
+
+	loading := make(chan struct{})
+	f.loading = loading
+	f.mu.Unlock()
+
+	flags, err := f.read(ctx)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.loading = nil
+	close(loading)
+
+	if err != nil {
+		f.logger.Warn("Failed to read feature flags, keeping previous values",
+			slog.String("error", err.Error()))
+
+		f.retryAt = now.Add(min(retryInterval, f.ttl))
+
+		if f.current == nil {
+			f.current = newSnapshot(nil)
+		}
+
+		return f.current
+	}
+
+	f.current = newSnapshot(flags)
+	f.loadedAt = now
+
+	return f.current
+}
+
+// read reads the flag values from the store. The values are shared by every caller, so the read
+// is not canceled with the request of ctx; it is bounded by readTimeout instead.
+func (f *Flags) read(ctx context.Context) ([]storage.FeatureFlag, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), readTimeout)
+	defer cancel()
+
+	return f.store.FeatureFlags(ctx)
+}
+
+// newSnapshot indexes flags by plugin and name.
+func newSnapshot(flags []storage.FeatureFlag) *snapshot {
+	s := &snapshot{
+		global:  make(map[string]bool),
+		plugins: make(map[string]map[string]bool),
+	}
+
+	for _, flag := range flags {
+		if flag.Plugin == "" {
+			s.global[flag.Name] = flag.Enabled
+
+			continue
+		}
+
+		if s.plugins[flag.Plugin] == nil {
+			s.plugins[flag.Plugin] = make(map[string]bool)
+		}
+
+		s.plugins[flag.Plugin][flag.Name] = flag.Enabled
+	}
+
+	return s
+}
+
+// enabled evaluates flag name for plugin: the plugin's override, else the global value, else off.
+func (s *snapshot) enabled(name, plugin string) bool {
+	if s == nil {
+		return false
+	}
+
+	if enabled, ok := s.plugins[plugin][name]; ok && plugin != "" {
+		return enabled
+	}
+
+	return s.global[name]
+}
@@ -0,0 +1,243 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/api/middleware"
+	"github.com/correlator-io/correlator/internal/storage"
+)
+
+// fakeStore serves flags and counts reads. With block set, reads signal started and wait for
+// block to be closed.
+type fakeStore struct {
+	mu      sync.Mutex
+	flags   []storage.FeatureFlag
+	err     error
+	reads   int
+	started chan struct{}
+	block   chan struct{}
+
+	ctxErr      error // Error of the context of the last read, during the read
+	ctxDeadline bool  // Whether the context of the last read had a deadline
+}
+
+func (s *fakeStore) FeatureFlags(ctx context.Context) ([]storage.FeatureFlag, error) {
+	s.mu.Lock()
+	s.reads++
+	s.ctxErr = ctx.Err()
+	_, s.ctxDeadline = ctx.Deadline()
+	started, block := s.started, s.block
+	s.mu.Unlock()
+
+	if block != nil {
+		close(started)
+		<-block
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flags, s.err
+}
+
+func (s *fakeStore) readCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reads
+}
+
+// requestContext returns the context a handler sees for a request of plugin ("" = unauthenticated).
+func requestContext(t *testing.T, f *Flags, plugin string) context.Context {
+	t.Helper()
+
+	var ctx context.Context
+
+	handler := Middleware(f)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/lineage", nil)
+	if plugin != "" {
+		req = req.WithContext(middleware.SetClientContext(req.Context(), middleware.ClientContext{ClientID: plugin}))
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	return ctx
+}
+
+func TestEnabled_GlobalEnable(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	f := NewFlags(&fakeStore{flags: []storage.FeatureFlag{
+		{Name: "strict_schema", Enabled: true},
+	}}, time.Minute, slog.Default())
+
+	assert.True(t, Enabled(requestContext(t, f, "dbt"), "strict_schema"))
+	assert.True(t, Enabled(requestContext(t, f, ""), "strict_schema"), "global value applies without a plugin")
+	assert.False(t, Enabled(requestContext(t, f, "dbt"), "async_ingestion"))
+}
+
+func TestEnabled_PluginOverride(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	f := NewFlags(&fakeStore{flags: []storage.FeatureFlag{
+		{Name: "strict_schema", Enabled: true},
+		{Name: "strict_schema", Plugin: "dbt", Enabled: false},
+		{Name: "auto_correlation", Plugin: "airflow", Enabled: true},
+	}}, time.Minute, slog.Default())
+
+	assert.False(t, Enabled(requestContext(t, f, "dbt"), "strict_schema"), "override disables the global value")
+	assert.True(t, Enabled(requestContext(t, f, "airflow"), "strict_schema"), "other plugins keep the global value")
+	assert.True(t, Enabled(requestContext(t, f, "airflow"), "auto_correlation"), "override enables for its plugin")
+	assert.False(t, Enabled(requestContext(t, f, "dbt"), "auto_correlation"), "override applies to its plugin only")
+	assert.True(t, f.Enabled(context.Background(), "auto_correlation", "airflow"))
+}
+
+func TestEnabled_DefaultOff(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	f := NewFlags(&fakeStore{}, time.Minute, slog.Default())
+
+	assert.False(t, Enabled(requestContext(t, f, "dbt"), "strict_schema"), "flag without a value is off")
+	assert.False(t, Enabled(context.Background(), "strict_schema"), "context without flags is off")
+	assert.False(t, Enabled(requestContext(t, nil, "dbt"), "strict_schema"), "nil Flags is off")
+
+	failing := NewFlags(&fakeStore{err: errors.New("connection refused")}, time.Minute, slog.Default())
+	assert.False(t, Enabled(requestContext(t, failing, "dbt"), "strict_schema"), "unreadable store is off")
+}
+
+func TestFlags_CachedForTTL(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &fakeStore{flags: []storage.FeatureFlag{{Name: "strict_schema", Enabled: true}}}
+	f := NewFlags(store, time.Minute, slog.Default())
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+
+	assert.True(t, f.Enabled(context.Background(), "strict_schema", "dbt"))
+
+	store.flags = []storage.FeatureFlag{{Name: "strict_schema", Enabled: false}}
+	now = now.Add(30 * time.Second)
+
+	assert.True(t, f.Enabled(context.Background(), "strict_schema", "dbt"), "cached value within the TTL")
+	assert.Equal(t, 1, store.reads)
+
+	now = now.Add(time.Minute)
+
+	assert.False(t, f.Enabled(context.Background(), "strict_schema", "dbt"), "toggle applies after the TTL")
+	assert.Equal(t, 2, store.reads)
+
+	// A failed read keeps the last values
+	store.flags = []storage.FeatureFlag{{Name: "strict_schema", Enabled: true}}
+	now = now.Add(time.Minute)
+
+	assert.True(t, f.Enabled(context.Background(), "strict_schema", "dbt"))
+
+	store.err = errors.New("connection refused")
+	store.flags = nil
+	now = now.Add(time.Minute)
+
+	assert.True(t, f.Enabled(context.Background(), "strict_schema", "dbt"))
+	assert.Equal(t, 4, store.reads)
+}
+
+func TestFlags_RetriesAfterFailedRead(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &fakeStore{err: errors.New("connection refused")}
+	f := NewFlags(store, time.Minute, slog.Default())
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+
+	assert.False(t, f.Enabled(context.Background(), "strict_schema", "dbt"))
+
+	store.err = nil
+	store.flags = []storage.FeatureFlag{{Name: "strict_schema", Enabled: true}}
+	now = now.Add(retryInterval - time.Second)
+
+	assert.False(t, f.Enabled(context.Background(), "strict_schema", "dbt"), "no read before the retry interval")
+	assert.Equal(t, 1, store.reads)
+
+	now = now.Add(time.Second)
+
+	assert.True(t, f.Enabled(context.Background(), "strict_schema", "dbt"), "read again after the retry interval")
+	assert.Equal(t, 2, store.reads)
+}
+
+func TestFlags_ReadOutlivesRequest(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &fakeStore{flags: []storage.FeatureFlag{{Name: "strict_schema", Enabled: true}}}
+	f := NewFlags(store, time.Minute, slog.Default())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.True(t, f.Enabled(ctx, "strict_schema", "dbt"))
+	require.NoError(t, store.ctxErr, "a canceled request does not cancel the read")
+	assert.True(t, store.ctxDeadline, "the read is bounded by a timeout")
+}
+
+func TestFlags_OneReadAtATime(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &fakeStore{flags: []storage.FeatureFlag{{Name: "strict_schema", Enabled: true}}}
+	f := NewFlags(store, time.Minute, slog.Default())
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+
+	assert.True(t, f.Enabled(context.Background(), "strict_schema", "dbt"))
+
+	// The next read blocks until released
+	now = now.Add(2 * time.Minute)
+	store.flags = []storage.FeatureFlag{{Name: "strict_schema", Enabled: false}}
+	store.started = make(chan struct{})
+	store.block = make(chan struct{})
+
+	refreshed := make(chan bool)
+
+	go func() {
+		refreshed <- f.Enabled(context.Background(), "strict_schema", "dbt")
+	}()
+
+	<-store.started
+
+	assert.True(t, f.Enabled(context.Background(), "strict_schema", "dbt"),
+		"previous values are served while the store is read")
+	assert.Equal(t, 2, store.readCount(), "no second read while one is in progress")
+
+	close(store.block)
+
+	assert.False(t, <-refreshed)
+	assert.False(t, f.Enabled(context.Background(), "strict_schema", "dbt"))
+	assert.Equal(t, 2, store.readCount())
+}
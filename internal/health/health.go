@@ -9,6 +9,8 @@
 //	api  ──imports──▶  health  ◀──imports──  kafka
 package health
 
+import "time"
+
 // ComponentResult reports the health of a single subsystem (e.g., PostgreSQL, Kafka).
 type ComponentResult struct {
 	Status    string
@@ -26,3 +28,19 @@ type KafkaDetails struct {
 	Messages      int64
 	Errors        int64
 }
+
+// MaintenanceDetails reports dead-tuple bloat of high-churn tables, for tuning autovacuum.
+type MaintenanceDetails struct {
+	Threshold float64 // Dead-tuple ratio above which a table is reported as bloated
+	Tables    []TableBloat
+}
+
+// TableBloat is the vacuum status of one table.
+type TableBloat struct {
+	Table          string
+	LiveTuples     int64
+	DeadTuples     int64
+	DeadTupleRatio float64
+	Bloated        bool
+	LastAutoVacuum *time.Time
+}
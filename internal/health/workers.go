@@ -0,0 +1,80 @@
+package health
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// WorkerRun is the outcome of the latest run of a background worker.
+type WorkerRun struct {
+	Name    string
+	LastRun time.Time // Zero until the worker has run once
+	Error   string    // Error of the latest run; empty when it succeeded
+}
+
+// Workers records the latest run of each background worker (idempotency cleanup, view refresh,
+// batch processing, ...) so health endpoints can show whether they are still running.
+//
+// Safe for concurrent use. A nil *Workers ignores every call, so workers record unconditionally
+// and tracking is enabled by passing a non-nil tracker.
+type Workers struct {
+	mu   sync.Mutex
+	runs map[string]WorkerRun
+}
+
+// NewWorkers creates an empty worker tracker.
+func NewWorkers() *Workers {
+	return &Workers{runs: make(map[string]WorkerRun)}
+}
+
+// Register adds a worker that has not run yet, so it is reported before its first run.
+// Registering a known worker keeps its last run.
+func (w *Workers) Register(name string) {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.runs[name]; !ok {
+		w.runs[name] = WorkerRun{Name: name}
+	}
+}
+
+// Record stores a run of worker name that finished now with err (nil = success).
+func (w *Workers) Record(name string, err error) {
+	if w == nil {
+		return
+	}
+
+	run := WorkerRun{Name: name, LastRun: time.Now()}
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.runs[name] = run
+}
+
+// Runs returns the latest run of every registered or recorded worker, sorted by name.
+func (w *Workers) Runs() []WorkerRun {
+	if w == nil {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	runs := make([]WorkerRun, 0, len(w.runs))
+	for _, run := range w.runs {
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Name < runs[j].Name })
+
+	return runs
+}
@@ -0,0 +1,50 @@
+package health
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkers_RegisterAndRecord(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	workers := NewWorkers()
+	workers.Register("view_refresh")
+	workers.Record("cleanup", errors.New("statement timeout"))
+	workers.Record("batches", nil)
+	workers.Register("batches") // Keeps the recorded run
+
+	runs := workers.Runs()
+	if len(runs) != 3 {
+		t.Fatalf("Runs() returned %d workers, want 3: %+v", len(runs), runs)
+	}
+
+	if runs[0].Name != "batches" || runs[0].LastRun.IsZero() || runs[0].Error != "" {
+		t.Errorf("batches = %+v, want a successful run", runs[0])
+	}
+
+	if runs[1].Name != "cleanup" || runs[1].Error != "statement timeout" {
+		t.Errorf("cleanup = %+v, want the failed run", runs[1])
+	}
+
+	if runs[2].Name != "view_refresh" || !runs[2].LastRun.IsZero() {
+		t.Errorf("view_refresh = %+v, want no run yet", runs[2])
+	}
+}
+
+func TestWorkers_NilIsNoOp(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	var workers *Workers
+
+	workers.Register("cleanup")
+	workers.Record("cleanup", nil)
+
+	if runs := workers.Runs(); runs != nil {
+		t.Errorf("Runs() on nil tracker = %+v, want nil", runs)
+	}
+}
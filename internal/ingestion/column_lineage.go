@@ -0,0 +1,169 @@
+package ingestion
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/correlator-io/correlator/internal/canonicalization"
+)
+
+// ColumnLineageFacet is the standard OpenLineage ColumnLineageDatasetFacet, attached to output
+// datasets: {"fields": {"<output column>": {"inputFields": [{"namespace", "name", "field",
+// "transformations": [{"type", "subtype", ...}]}]}}}.
+// Spec: https://openlineage.io/docs/spec/facets/dataset-facets/column_lineage_facet
+const ColumnLineageFacet = "columnLineage"
+
+// Bounds for column lineage persisted from a single output dataset.
+const (
+	MaxColumnLineageEdges         = 10000 // Edges kept per output dataset; the rest are dropped
+	MaxColumnNameLength           = 1024  // Longer column names are skipped, not truncated
+	MaxTransformationLength       = 50    // Matches column_lineage_edges.transformation_type/subtype
+	maxColumnLineageDatasetURNLen = 500   // Matches column_lineage_edges.input_dataset_urn
+)
+
+// ColumnLineageEdge maps one column of an output dataset to one input column it is derived
+// from, parsed from the columnLineage facet - Domain Model.
+type ColumnLineageEdge struct {
+	// OutputColumn is the column of the dataset the facet is attached to.
+	OutputColumn string
+
+	// InputDatasetURN is the URN of the dataset holding InputColumn (see Dataset.URN).
+	InputDatasetURN string
+
+	// InputColumn is the source column.
+	InputColumn string
+
+	// TransformationType and TransformationSubtype describe the first reported transformation
+	// (e.g., DIRECT/IDENTITY, DIRECT/AGGREGATION, INDIRECT/FILTER). Empty when not reported;
+	// the deprecated field-level transformationType is used as a fallback for the type.
+	TransformationType    string
+	TransformationSubtype string
+}
+
+// ParseColumnLineage extracts column-level edges from a dataset's columnLineage facet.
+//
+// Producers commonly report lineage for only some columns, and sometimes send partial or
+// malformed entries. Parsing is lenient: an output column whose entry is not an object, and
+// any input field without namespace, name, or field, is skipped while the rest are kept.
+// Returns nil when the facet is missing or has no usable entries.
+//
+// Edges are ordered by output column, with inputs in reported order; a repeated input column
+// of the same output column is kept once. At most MaxColumnLineageEdges are returned.
+func ParseColumnLineage(facets Facets) []ColumnLineageEdge {
+	facet, ok := facets.GetFacet(ColumnLineageFacet)
+	if !ok {
+		return nil
+	}
+
+	fields, ok := facet["fields"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	outputColumns := make([]string, 0, len(fields))
+	for column := range fields {
+		outputColumns = append(outputColumns, column)
+	}
+
+	sort.Strings(outputColumns)
+
+	type edgeKey struct{ outputColumn, inputURN, inputColumn string }
+
+	var edges []ColumnLineageEdge
+
+	seen := make(map[edgeKey]bool)
+
+	for _, outputColumn := range outputColumns {
+		if !validColumnName(outputColumn) {
+			continue
+		}
+
+		entry, ok := fields[outputColumn].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		inputFields, _ := entry["inputFields"].([]interface{})
+		fallbackType := facetString(entry, "transformationType")
+
+		for _, raw := range inputFields {
+			edge, ok := parseColumnLineageInput(raw, outputColumn, fallbackType)
+			if !ok {
+				continue
+			}
+
+			// The first reported transformation wins for a repeated input column
+			key := edgeKey{edge.OutputColumn, edge.InputDatasetURN, edge.InputColumn}
+			if seen[key] {
+				continue
+			}
+
+			if len(edges) == MaxColumnLineageEdges {
+				return edges
+			}
+
+			seen[key] = true
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges
+}
+
+// parseColumnLineageInput converts one inputFields entry into an edge.
+// Returns false when the entry is not an object or lacks a namespace, name, or field.
+func parseColumnLineageInput(raw interface{}, outputColumn, fallbackType string) (ColumnLineageEdge, bool) {
+	input, ok := raw.(map[string]interface{})
+	if !ok {
+		return ColumnLineageEdge{}, false
+	}
+
+	namespace := strings.TrimSpace(facetString(input, "namespace"))
+	name := strings.TrimSpace(facetString(input, "name"))
+	column := facetString(input, "field")
+
+	if namespace == "" || name == "" || !validColumnName(column) {
+		return ColumnLineageEdge{}, false
+	}
+
+	urn := canonicalization.GenerateDatasetURN(namespace, name)
+	if len(urn) > maxColumnLineageDatasetURNLen {
+		return ColumnLineageEdge{}, false
+	}
+
+	edge := ColumnLineageEdge{
+		OutputColumn:       outputColumn,
+		InputDatasetURN:    urn,
+		InputColumn:        column,
+		TransformationType: fallbackType,
+	}
+
+	if transformations, ok := input["transformations"].([]interface{}); ok && len(transformations) > 0 {
+		if first, ok := transformations[0].(map[string]interface{}); ok {
+			edge.TransformationType = facetString(first, "type")
+			edge.TransformationSubtype = facetString(first, "subtype")
+		}
+	}
+
+	edge.TransformationType = boundTransformation(edge.TransformationType)
+	edge.TransformationSubtype = boundTransformation(edge.TransformationSubtype)
+
+	return edge, true
+}
+
+// validColumnName reports whether name can be stored as a column: non-blank, bounded, and
+// free of NUL bytes (PostgreSQL rejects them in TEXT).
+func validColumnName(name string) bool {
+	return strings.TrimSpace(name) != "" && len(name) <= MaxColumnNameLength && !strings.Contains(name, "\x00")
+}
+
+// boundTransformation upper-cases a transformation type or subtype and drops values that do
+// not fit the column (the spec's values are short enum names).
+func boundTransformation(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) > MaxTransformationLength || strings.Contains(value, "\x00") {
+		return ""
+	}
+
+	return value
+}
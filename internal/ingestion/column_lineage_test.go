@@ -0,0 +1,129 @@
+package ingestion
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseColumnLineage(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	ordersInput := func(field string, transformations ...interface{}) map[string]interface{} {
+		input := map[string]interface{}{
+			"namespace": "postgres://prod:5432",
+			"name":      "raw.public.orders",
+			"field":     field,
+		}
+		if len(transformations) > 0 {
+			input["transformations"] = transformations
+		}
+
+		return input
+	}
+
+	facets := Facets{
+		"columnLineage": map[string]interface{}{
+			"_producer": "https://github.com/OpenLineage/OpenLineage/tree/1.0.0/integration/spark",
+			"fields": map[string]interface{}{
+				"total": map[string]interface{}{
+					"inputFields": []interface{}{
+						ordersInput("amount", map[string]interface{}{"type": "DIRECT", "subtype": "AGGREGATION"}),
+						ordersInput("status", map[string]interface{}{"type": "indirect", "subtype": "filter"}),
+						ordersInput("amount"), // Repeated input column: first one wins
+					},
+				},
+				"order_id": map[string]interface{}{
+					"inputFields":        []interface{}{ordersInput("id")},
+					"transformationType": "IDENTITY", // Deprecated field-level type
+				},
+				// Partial or malformed entries are skipped, the rest are kept
+				"customer_id": "not an object",
+				"note":        map[string]interface{}{},
+				"region": map[string]interface{}{
+					"inputFields": []interface{}{
+						map[string]interface{}{"namespace": "postgres://prod:5432", "name": "raw.public.orders"},
+						map[string]interface{}{"name": "raw.public.orders", "field": "region"},
+						"raw.public.orders.region",
+					},
+				},
+				"  ": map[string]interface{}{"inputFields": []interface{}{ordersInput("blank")}},
+			},
+		},
+	}
+
+	want := []ColumnLineageEdge{
+		{
+			OutputColumn: "order_id", InputDatasetURN: "postgresql://prod/raw.public.orders",
+			InputColumn: "id", TransformationType: "IDENTITY",
+		},
+		{
+			OutputColumn: "total", InputDatasetURN: "postgresql://prod/raw.public.orders",
+			InputColumn: "amount", TransformationType: "DIRECT", TransformationSubtype: "AGGREGATION",
+		},
+		{
+			OutputColumn: "total", InputDatasetURN: "postgresql://prod/raw.public.orders",
+			InputColumn: "status", TransformationType: "INDIRECT", TransformationSubtype: "FILTER",
+		},
+	}
+
+	if got := ParseColumnLineage(facets); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseColumnLineage() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	for name, facets := range map[string]Facets{
+		"no facets":        nil,
+		"no column facet":  {"schema": map[string]interface{}{}},
+		"facet not object": {"columnLineage": "broken"},
+		"fields missing":   {"columnLineage": map[string]interface{}{}},
+		"no usable inputs": {"columnLineage": map[string]interface{}{
+			"fields": map[string]interface{}{"a": map[string]interface{}{"inputFields": []interface{}{}}},
+		}},
+	} {
+		if got := ParseColumnLineage(facets); got != nil {
+			t.Errorf("%s: expected nil, got %+v", name, got)
+		}
+	}
+}
+
+func TestParseColumnLineage_Bounds(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	inputs := make([]interface{}, 0, MaxColumnLineageEdges+10)
+	for i := range MaxColumnLineageEdges + 10 {
+		inputs = append(inputs, map[string]interface{}{
+			"namespace": "s3://bucket",
+			"name":      "wide_table",
+			"field":     fmt.Sprintf("c%d", i),
+			"transformations": []interface{}{
+				map[string]interface{}{"type": strings.Repeat("T", MaxTransformationLength+1)},
+			},
+		})
+	}
+
+	edges := ParseColumnLineage(Facets{"columnLineage": map[string]interface{}{
+		"fields": map[string]interface{}{
+			"out": map[string]interface{}{"inputFields": inputs},
+			strings.Repeat("c", MaxColumnNameLength+1): map[string]interface{}{"inputFields": inputs[:1]},
+		},
+	}})
+
+	if len(edges) != MaxColumnLineageEdges {
+		t.Fatalf("expected %d edges, got %d", MaxColumnLineageEdges, len(edges))
+	}
+
+	for _, edge := range edges {
+		if edge.OutputColumn != "out" {
+			t.Fatalf("overlong output column must be skipped, got %q", edge.OutputColumn)
+		}
+
+		if edge.TransformationType != "" {
+			t.Fatalf("overlong transformation type must be dropped, got %q", edge.TransformationType)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidDefaultNamespaces indicates a malformed per-producer default dataset namespace list.
+var ErrInvalidDefaultNamespaces = errors.New("invalid default dataset namespaces")
+
+// WithDefaultDatasetNamespace fills in the namespace of input and output datasets that arrive
+// without one, instead of rejecting the event with ErrDatasetMissingNamespace. Some producers
+// emit bare names (schema.table) and rely on an implicit connection; resolving them to the
+// namespace the rest of the lineage uses keeps their URNs, and therefore correlation, intact.
+//
+// byProducer maps producer URL prefixes (e.g. "https://github.com/dbt-labs/dbt-core") to the
+// namespace used for their events; the longest matching prefix wins. global applies to events
+// of every other producer. Datasets that still have no namespace are rejected. Empty global and
+// nil byProducer keep the default (reject).
+func WithDefaultDatasetNamespace(global string, byProducer map[string]string) ValidatorOption {
+	return func(v *Validator) {
+		v.defaultNamespace = global
+		v.defaultNamespaces = byProducer
+	}
+}
+
+// ParseDefaultNamespaces parses per-producer default dataset namespaces from a comma-separated
+// list of producer=namespace pairs, e.g.
+// "https://github.com/dbt-labs/dbt-core=postgres://warehouse:5432". The first '=' separates the
+// namespace, so namespaces may contain '='. Empty input returns nil.
+func ParseDefaultNamespaces(s string) (map[string]string, error) {
+	var namespaces map[string]string
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		producer, namespace, ok := strings.Cut(pair, "=")
+		producer, namespace = strings.TrimSpace(producer), strings.TrimSpace(namespace)
+
+		if !ok || producer == "" || namespace == "" {
+			return nil, fmt.Errorf("%w: %q (expected producer=namespace)", ErrInvalidDefaultNamespaces, pair)
+		}
+
+		if namespaces == nil {
+			namespaces = make(map[string]string)
+		}
+
+		namespaces[producer] = namespace
+	}
+
+	return namespaces, nil
+}
+
+// defaultNamespaceFor returns the default dataset namespace for events of producer
+// ("" = none configured).
+func (v *Validator) defaultNamespaceFor(producer string) string {
+	namespace, matched := v.defaultNamespace, ""
+
+	for prefix, candidate := range v.defaultNamespaces {
+		if strings.HasPrefix(producer, prefix) && len(prefix) > len(matched) {
+			namespace, matched = candidate, prefix
+		}
+	}
+
+	return namespace
+}
+
+// validateDatasets resolves missing dataset namespaces (see WithDefaultDatasetNamespace) in
+// place, then checks every input and output dataset with ValidateDataset.
+func (v *Validator) validateDatasets(event *RunEvent) error {
+	defaultNamespace := v.defaultNamespaceFor(event.Producer)
+
+	for _, group := range []struct {
+		path     string
+		datasets []Dataset
+	}{
+		{"inputs", event.Inputs},
+		{"outputs", event.Outputs},
+	} {
+		for i := range group.datasets {
+			dataset := &group.datasets[i]
+			if dataset.Namespace == "" {
+				dataset.Namespace = defaultNamespace
+			}
+
+			if err := v.ValidateDataset(dataset); err != nil {
+				return fmt.Errorf("%s[%d]: %w", group.path, i, err)
+			}
+		}
+	}
+
+	return nil
+}
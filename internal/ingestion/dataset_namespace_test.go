@@ -0,0 +1,119 @@
+package ingestion
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const dbtProducer = "https://github.com/dbt-labs/dbt-core/tree/1.5.0"
+
+// newNamespaceTestEvent returns a valid event from producer with a bare-named input and output.
+func newNamespaceTestEvent(producer string) *RunEvent {
+	return &RunEvent{
+		EventTime: time.Now().UTC(),
+		EventType: EventTypeComplete,
+		Producer:  producer,
+		SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+		Run:       Run{ID: "550e8400-e29b-41d4-a716-446655440000"},
+		Job:       Job{Namespace: "dbt_prod", Name: "models.orders"},
+		Inputs:    []Dataset{{Namespace: "postgres://prod:5432", Name: "raw.orders"}},
+		Outputs:   []Dataset{{Name: "analytics.orders"}},
+	}
+}
+
+func TestParseDefaultNamespaces(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	got, err := ParseDefaultNamespaces(
+		" https://github.com/dbt-labs/dbt-core = postgres://warehouse:5432 ,,airflow=bigquery")
+	if err != nil {
+		t.Fatalf("ParseDefaultNamespaces() unexpected error: %v", err)
+	}
+
+	if len(got) != 2 || got["https://github.com/dbt-labs/dbt-core"] != "postgres://warehouse:5432" ||
+		got["airflow"] != "bigquery" {
+		t.Errorf("ParseDefaultNamespaces() = %v", got)
+	}
+
+	if got, err := ParseDefaultNamespaces(""); err != nil || got != nil {
+		t.Errorf("ParseDefaultNamespaces(\"\") = %v, %v; want nil, nil", got, err)
+	}
+
+	for _, input := range []string{"airflow", "=bigquery", "airflow="} {
+		if _, err := ParseDefaultNamespaces(input); !errors.Is(err, ErrInvalidDefaultNamespaces) {
+			t.Errorf("ParseDefaultNamespaces(%q) error = %v, want ErrInvalidDefaultNamespaces", input, err)
+		}
+	}
+}
+
+func TestValidateRunEvent_DefaultDatasetNamespace(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	byProducer := map[string]string{
+		"https://github.com/dbt-labs":          "postgres://other:5432",
+		"https://github.com/dbt-labs/dbt-core": "postgres://warehouse:5432",
+	}
+
+	tests := []struct {
+		name       string
+		global     string
+		byProducer map[string]string
+		producer   string
+		want       string
+	}{
+		{"global default", "postgres://prod:5432", nil, dbtProducer, "postgres://prod:5432"},
+		{"longest producer prefix wins", "postgres://prod:5432", byProducer, dbtProducer, "postgres://warehouse:5432"},
+		{"unmatched producer uses global", "postgres://prod:5432", byProducer,
+			"https://github.com/apache/airflow/tree/2.7.0", "postgres://prod:5432"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewValidator(WithDefaultDatasetNamespace(tt.global, tt.byProducer))
+			event := newNamespaceTestEvent(tt.producer)
+
+			if err := validator.ValidateRunEvent(event); err != nil {
+				t.Fatalf("ValidateRunEvent() unexpected error: %v", err)
+			}
+
+			if got := event.Outputs[0].Namespace; got != tt.want {
+				t.Errorf("Output namespace = %q, want %q", got, tt.want)
+			}
+
+			if got := event.Inputs[0].Namespace; got != "postgres://prod:5432" {
+				t.Errorf("Explicit input namespace changed to %q", got)
+			}
+		})
+	}
+}
+
+func TestValidateRunEvent_MissingDatasetNamespaceWithoutDefault(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	validators := map[string]*Validator{
+		"no default": NewValidator(),
+		"no default for producer": NewValidator(WithDefaultDatasetNamespace("", map[string]string{
+			"https://github.com/apache/airflow": "postgres://prod:5432",
+		})),
+	}
+
+	for name, validator := range validators {
+		t.Run(name, func(t *testing.T) {
+			err := validator.ValidateRunEvent(newNamespaceTestEvent(dbtProducer))
+			if !errors.Is(err, ErrDatasetMissingNamespace) {
+				t.Fatalf("ValidateRunEvent() error = %v, want ErrDatasetMissingNamespace", err)
+			}
+
+			if err.Error() != "outputs[0]: dataset.namespace is required" {
+				t.Errorf("Error does not identify the dataset: %q", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/correlator-io/correlator/internal/canonicalization"
+)
+
+// DatasetRolesMode controls how the Validator treats a dataset listed as both an input and an
+// output of one event.
+//
+// Reading and writing the same dataset in one run is usually a producer bug (e.g., a table
+// reported under both roles by a broken integration), and it creates a self-loop in the lineage
+// graph. Genuine in-place updates (incremental models, MERGE into the source table) can be
+// allowlisted (see WithDatasetRolesCheck).
+type DatasetRolesMode string
+
+const (
+	// DatasetRolesOff accepts datasets that are both input and output silently.
+	DatasetRolesOff DatasetRolesMode = "off"
+
+	// DatasetRolesWarn accepts them and reports them as warnings (see Validator.Warnings) (default).
+	DatasetRolesWarn DatasetRolesMode = "warn"
+
+	// DatasetRolesReject rejects them with ErrDatasetInputAndOutput (422 over HTTP).
+	DatasetRolesReject DatasetRolesMode = "reject"
+)
+
+var (
+	// ErrDatasetInputAndOutput indicates a dataset listed as both an input and an output of one event.
+	// Returned by ValidateRunEvent in DatasetRolesReject and by Warnings in DatasetRolesWarn.
+	ErrDatasetInputAndOutput = errors.New("dataset is both an input and an output")
+
+	// ErrInvalidDatasetRolesMode indicates an unknown DatasetRolesMode value.
+	ErrInvalidDatasetRolesMode = errors.New("invalid dataset roles mode")
+
+	// ErrInvalidInPlaceDataset indicates an in-place dataset that is not a {namespace}/{name} URN.
+	ErrInvalidInPlaceDataset = errors.New("invalid in-place dataset")
+)
+
+// ParseDatasetRolesMode parses a dataset roles mode name (case-insensitive). Empty means DatasetRolesWarn.
+func ParseDatasetRolesMode(s string) (DatasetRolesMode, error) {
+	switch mode := DatasetRolesMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return DatasetRolesWarn, nil
+	case DatasetRolesOff, DatasetRolesWarn, DatasetRolesReject:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q (valid: off, warn, reject)", ErrInvalidDatasetRolesMode, s)
+	}
+}
+
+// ParseInPlaceDatasets parses a comma-separated list of dataset URNs
+// (e.g. "postgres://prod:5432/analytics.public.orders"). Empty input returns nil.
+func ParseInPlaceDatasets(s string) ([]string, error) {
+	var urns []string
+
+	for _, urn := range strings.Split(s, ",") {
+		urn = strings.TrimSpace(urn)
+		if urn == "" {
+			continue
+		}
+
+		if _, _, err := canonicalization.ParseDatasetURN(urn); err != nil {
+			return nil, fmt.Errorf("%w: %q (expected namespace/name)", ErrInvalidInPlaceDataset, urn)
+		}
+
+		urns = append(urns, urn)
+	}
+
+	return urns, nil
+}
+
+// WithDatasetRolesCheck sets how datasets listed as both input and output of one event are
+// handled (see DatasetRolesMode). inPlace lists the URNs ({namespace}/{name}, see Dataset.URN)
+// of datasets that are legitimately updated in place; they are never flagged. The URNs are
+// canonicalized like event datasets, so "postgresql://prod/x" also covers "postgres://prod:5432/x".
+// An empty mode is ignored and the default (DatasetRolesWarn) is kept.
+func WithDatasetRolesCheck(mode DatasetRolesMode, inPlace []string) ValidatorOption {
+	return func(v *Validator) {
+		if mode != "" {
+			v.datasetRoles = mode
+		}
+
+		v.inPlaceDatasets = make(map[string]bool, len(inPlace))
+
+		for _, urn := range inPlace {
+			if namespace, name, err := canonicalization.ParseDatasetURN(urn); err == nil {
+				urn = canonicalization.GenerateDatasetURN(namespace, name)
+			}
+
+			v.inPlaceDatasets[urn] = true
+		}
+	}
+}
+
+// validateDatasetRoles rejects datasets that are both input and output in DatasetRolesReject.
+func (v *Validator) validateDatasetRoles(event *RunEvent) error {
+	if v.datasetRoles != DatasetRolesReject {
+		return nil
+	}
+
+	return v.inputAndOutputError(event)
+}
+
+// inputAndOutputError returns ErrDatasetInputAndOutput naming every non-allowlisted dataset
+// that event lists as both an input and an output, or nil when there is none.
+func (v *Validator) inputAndOutputError(event *RunEvent) error {
+	if len(event.Inputs) == 0 || len(event.Outputs) == 0 {
+		return nil
+	}
+
+	inputs := make(map[string]bool, len(event.Inputs))
+	for i := range event.Inputs {
+		inputs[event.Inputs[i].URN()] = true
+	}
+
+	var both []string
+
+	for i := range event.Outputs {
+		urn := event.Outputs[i].URN()
+		if inputs[urn] && !v.inPlaceDatasets[urn] {
+			both = append(both, urn)
+			inputs[urn] = false // Report duplicate outputs once
+		}
+	}
+
+	if len(both) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s (job %s/%s)", ErrDatasetInputAndOutput, strings.Join(both, ", "),
+		event.Job.Namespace, event.Job.Name)
+}
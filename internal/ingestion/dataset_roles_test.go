@@ -0,0 +1,137 @@
+package ingestion
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const (
+	rolesTestOrders  = "postgres://prod:5432/analytics.orders"
+	rolesTestRawData = "postgres://prod:5432/raw.orders"
+)
+
+// newRolesTestEvent returns a valid event reading raw.orders and analytics.orders and writing
+// analytics.orders, i.e. with analytics.orders in both roles.
+func newRolesTestEvent() *RunEvent {
+	return &RunEvent{
+		EventTime: time.Now().UTC(),
+		EventType: EventTypeComplete,
+		Producer:  dbtProducer,
+		SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+		Run:       Run{ID: "550e8400-e29b-41d4-a716-446655440000"},
+		Job:       Job{Namespace: "dbt_prod", Name: "models.orders"},
+		Inputs: []Dataset{
+			{Namespace: "postgres://prod:5432", Name: "raw.orders"},
+			{Namespace: "postgres://prod:5432", Name: "analytics.orders"},
+		},
+		Outputs: []Dataset{{Namespace: "postgres://prod:5432", Name: "analytics.orders"}},
+	}
+}
+
+func TestParseDatasetRolesMode(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		input   string
+		want    DatasetRolesMode
+		wantErr bool
+	}{
+		{"", DatasetRolesWarn, false},
+		{"off", DatasetRolesOff, false},
+		{" WARN ", DatasetRolesWarn, false},
+		{"reject", DatasetRolesReject, false},
+		{"strict", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDatasetRolesMode(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidDatasetRolesMode) {
+				t.Errorf("ParseDatasetRolesMode(%q) error = %v, want ErrInvalidDatasetRolesMode", tt.input, err)
+			}
+
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDatasetRolesMode(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	got, err := ParseInPlaceDatasets(" " + rolesTestOrders + ",," + rolesTestRawData)
+	if err != nil || len(got) != 2 || got[0] != rolesTestOrders || got[1] != rolesTestRawData {
+		t.Errorf("ParseInPlaceDatasets() = %v, %v", got, err)
+	}
+
+	if _, err := ParseInPlaceDatasets("analytics.orders"); !errors.Is(err, ErrInvalidInPlaceDataset) {
+		t.Errorf("ParseInPlaceDatasets() error = %v, want ErrInvalidInPlaceDataset", err)
+	}
+}
+
+func TestDatasetRolesCheck_WarnByDefault(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	validator := NewValidator()
+	event := newRolesTestEvent()
+
+	if err := validator.ValidateRunEvent(event); err != nil {
+		t.Fatalf("ValidateRunEvent() unexpected error: %v", err)
+	}
+
+	warnings := validator.Warnings(event)
+	if len(warnings) != 1 || !errors.Is(warnings[0], ErrDatasetInputAndOutput) {
+		t.Fatalf("Warnings() = %v, want one ErrDatasetInputAndOutput", warnings)
+	}
+
+	// Reported by canonical URN
+	want := "dataset is both an input and an output: postgresql://prod/analytics.orders (job dbt_prod/models.orders)"
+	if warnings[0].Error() != want {
+		t.Errorf("Warning = %q, want %q", warnings[0].Error(), want)
+	}
+
+	event.Inputs = event.Inputs[:1]
+	if warnings := validator.Warnings(event); len(warnings) != 0 {
+		t.Errorf("Warnings() for distinct inputs and outputs = %v, want none", warnings)
+	}
+}
+
+func TestDatasetRolesCheck_Reject(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	validator := NewValidator(WithDatasetRolesCheck(DatasetRolesReject, nil))
+
+	err := validator.ValidateRunEvent(newRolesTestEvent())
+	if !errors.Is(err, ErrDatasetInputAndOutput) {
+		t.Fatalf("ValidateRunEvent() error = %v, want ErrDatasetInputAndOutput", err)
+	}
+
+	if warnings := validator.Warnings(newRolesTestEvent()); len(warnings) != 0 {
+		t.Errorf("Warnings() in reject mode = %v, want none", warnings)
+	}
+
+	// Allowlisted in-place updates pass in every mode
+	for _, mode := range []DatasetRolesMode{DatasetRolesWarn, DatasetRolesReject} {
+		validator := NewValidator(WithDatasetRolesCheck(mode, []string{rolesTestOrders}))
+		event := newRolesTestEvent()
+
+		if err := validator.ValidateRunEvent(event); err != nil {
+			t.Errorf("%s: ValidateRunEvent() of an in-place update unexpected error: %v", mode, err)
+		}
+
+		if warnings := validator.Warnings(event); len(warnings) != 0 {
+			t.Errorf("%s: Warnings() of an in-place update = %v, want none", mode, warnings)
+		}
+	}
+
+	validator = NewValidator(WithDatasetRolesCheck(DatasetRolesOff, nil))
+	if err := validator.ValidateRunEvent(newRolesTestEvent()); err != nil {
+		t.Errorf("off: ValidateRunEvent() unexpected error: %v", err)
+	}
+}
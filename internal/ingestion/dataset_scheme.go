@@ -0,0 +1,147 @@
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DatasetSchemeMode controls how the Validator treats input and output datasets whose namespace
+// scheme is not in the supported list (see WithDatasetSchemeCheck).
+//
+// Namespaces with a scheme that cannot be canonicalized or correlated meaningfully are usually a
+// typo in the producer configuration (postgre://, s33://), and silently split the lineage graph
+// into datasets that never match.
+type DatasetSchemeMode string
+
+const (
+	// DatasetSchemeOff accepts any namespace (default).
+	DatasetSchemeOff DatasetSchemeMode = "off"
+
+	// DatasetSchemeWarn accepts unsupported schemes and reports them as warnings (see Validator.Warnings).
+	DatasetSchemeWarn DatasetSchemeMode = "warn"
+
+	// DatasetSchemeReject rejects unsupported schemes with ErrUnsupportedDatasetScheme (422 over HTTP).
+	DatasetSchemeReject DatasetSchemeMode = "reject"
+)
+
+// DefaultSupportedDatasetSchemes are the dataset namespace schemes of the OpenLineage naming
+// conventions (https://openlineage.io/docs/spec/naming) that the check accepts unless
+// configured otherwise. Namespaces without "://" (bigquery, kafka) are their own scheme.
+var DefaultSupportedDatasetSchemes = []string{ //nolint:gochecknoglobals
+	"abfss", "awsathena", "azurecosmos", "azurekusto", "bigquery", "cassandra", "clickhouse",
+	"databricks", "db2", "dbfs", "duckdb", "file", "gs", "gcs", "hdfs", "hive", "kafka", "mongodb",
+	"mssql", "mysql", "oracle", "postgres", "postgresql", "presto", "redshift", "s3", "s3a", "s3n",
+	"snowflake", "sqlite", "sqlserver", "teradata", "trino", "wasbs",
+}
+
+var (
+	// ErrUnsupportedDatasetScheme indicates a dataset namespace whose scheme is not supported.
+	// Returned by ValidateRunEvent in DatasetSchemeReject and by Warnings in DatasetSchemeWarn.
+	ErrUnsupportedDatasetScheme = errors.New("unsupported dataset namespace scheme")
+
+	// ErrInvalidDatasetSchemeMode indicates an unknown DatasetSchemeMode value.
+	ErrInvalidDatasetSchemeMode = errors.New("invalid dataset scheme mode")
+)
+
+// ParseDatasetSchemeMode parses a dataset scheme mode name (case-insensitive). Empty means DatasetSchemeOff.
+func ParseDatasetSchemeMode(s string) (DatasetSchemeMode, error) {
+	switch mode := DatasetSchemeMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return DatasetSchemeOff, nil
+	case DatasetSchemeOff, DatasetSchemeWarn, DatasetSchemeReject:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q (valid: off, warn, reject)", ErrInvalidDatasetSchemeMode, s)
+	}
+}
+
+// ParseDatasetSchemes parses a comma-separated list of schemes (e.g. "postgres,snowflake,s3"),
+// lowercased. A trailing "://" is accepted and dropped. Empty input returns nil.
+func ParseDatasetSchemes(s string) []string {
+	var schemes []string
+
+	for _, scheme := range strings.Split(s, ",") {
+		scheme = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(scheme), "://"))
+		if scheme != "" {
+			schemes = append(schemes, scheme)
+		}
+	}
+
+	return schemes
+}
+
+// WithDatasetSchemeCheck sets how datasets with an unsupported namespace scheme are handled (see
+// DatasetSchemeMode). supported lists the accepted schemes (case-insensitive); empty keeps
+// DefaultSupportedDatasetSchemes. An empty mode is ignored and the default (DatasetSchemeOff) is kept.
+func WithDatasetSchemeCheck(mode DatasetSchemeMode, supported []string) ValidatorOption {
+	return func(v *Validator) {
+		if mode != "" {
+			v.datasetScheme = mode
+		}
+
+		if len(supported) == 0 {
+			supported = DefaultSupportedDatasetSchemes
+		}
+
+		v.supportedSchemes = make(map[string]bool, len(supported))
+
+		for _, scheme := range supported {
+			v.supportedSchemes[strings.ToLower(scheme)] = true
+		}
+	}
+}
+
+// DatasetScheme returns the lowercased scheme of a dataset namespace: the part before "://", or
+// the whole namespace when it has none (e.g. "bigquery").
+func DatasetScheme(namespace string) string {
+	scheme, _, _ := strings.Cut(namespace, "://")
+
+	return strings.ToLower(strings.TrimSpace(scheme))
+}
+
+// validateDatasetSchemes rejects datasets with an unsupported scheme in DatasetSchemeReject.
+func (v *Validator) validateDatasetSchemes(event *RunEvent) error {
+	if v.datasetScheme != DatasetSchemeReject {
+		return nil
+	}
+
+	return v.unsupportedSchemeError(event)
+}
+
+// unsupportedSchemeError returns ErrUnsupportedDatasetScheme naming the first input or output
+// dataset with an unsupported scheme and listing the supported ones, or nil when there is none.
+func (v *Validator) unsupportedSchemeError(event *RunEvent) error {
+	for _, group := range []struct {
+		path     string
+		datasets []Dataset
+	}{
+		{"inputs", event.Inputs},
+		{"outputs", event.Outputs},
+	} {
+		for i := range group.datasets {
+			scheme := DatasetScheme(group.datasets[i].Namespace)
+			if v.supportedSchemes[scheme] {
+				continue
+			}
+
+			return fmt.Errorf("%s[%d]: %w %q in namespace %q (supported: %s)", group.path, i,
+				ErrUnsupportedDatasetScheme, scheme, group.datasets[i].Namespace, v.supportedSchemeList())
+		}
+	}
+
+	return nil
+}
+
+// supportedSchemeList returns the supported schemes, sorted and comma-separated.
+func (v *Validator) supportedSchemeList() string {
+	schemes := make([]string, 0, len(v.supportedSchemes))
+	for scheme := range v.supportedSchemes {
+		schemes = append(schemes, scheme)
+	}
+
+	slices.Sort(schemes)
+
+	return strings.Join(schemes, ", ")
+}
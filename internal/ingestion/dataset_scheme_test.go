@@ -0,0 +1,147 @@
+package ingestion
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSchemeTestEvent returns a valid event reading from inputNamespace and writing to Snowflake.
+func newSchemeTestEvent(inputNamespace string) *RunEvent {
+	return &RunEvent{
+		EventTime: time.Now().UTC(),
+		EventType: EventTypeComplete,
+		Producer:  dbtProducer,
+		SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+		Run:       Run{ID: "550e8400-e29b-41d4-a716-446655440000"},
+		Job:       Job{Namespace: "dbt_prod", Name: "models.orders"},
+		Inputs:    []Dataset{{Namespace: inputNamespace, Name: "raw.orders"}},
+		Outputs:   []Dataset{{Namespace: "snowflake://acme.us-east-1", Name: "analytics.orders"}},
+	}
+}
+
+func TestParseDatasetSchemeMode(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		input   string
+		want    DatasetSchemeMode
+		wantErr bool
+	}{
+		{"", DatasetSchemeOff, false},
+		{"off", DatasetSchemeOff, false},
+		{" WARN ", DatasetSchemeWarn, false},
+		{"reject", DatasetSchemeReject, false},
+		{"strict", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDatasetSchemeMode(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidDatasetSchemeMode) {
+				t.Errorf("ParseDatasetSchemeMode(%q) error = %v, want ErrInvalidDatasetSchemeMode", tt.input, err)
+			}
+
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDatasetSchemeMode(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	got := ParseDatasetSchemes(" Postgres ,,s3://, kafka")
+	if want := []string{"postgres", "s3", "kafka"}; !slices.Equal(got, want) {
+		t.Errorf("ParseDatasetSchemes() = %v, want %v", got, want)
+	}
+
+	if got := ParseDatasetSchemes(""); got != nil {
+		t.Errorf("ParseDatasetSchemes(\"\") = %v, want nil", got)
+	}
+}
+
+func TestDatasetSchemeCheck_SupportedScheme(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	for _, mode := range []DatasetSchemeMode{DatasetSchemeWarn, DatasetSchemeReject} {
+		validator := NewValidator(WithDatasetSchemeCheck(mode, nil))
+
+		for _, namespace := range []string{"postgres://prod:5432", "POSTGRESQL://prod", "s3://bucket", "bigquery", "kafka"} {
+			event := newSchemeTestEvent(namespace)
+
+			if err := validator.ValidateRunEvent(event); err != nil {
+				t.Errorf("%s: ValidateRunEvent(%s) unexpected error: %v", mode, namespace, err)
+			}
+
+			if warnings := validator.Warnings(event); len(warnings) != 0 {
+				t.Errorf("%s: Warnings(%s) = %v, want none", mode, namespace, warnings)
+			}
+		}
+	}
+}
+
+func TestDatasetSchemeCheck_TypoWarns(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	// Off by default: any namespace is accepted silently
+	event := newSchemeTestEvent("postgre://prod:5432")
+	if warnings := NewValidator().Warnings(event); len(warnings) != 0 {
+		t.Errorf("Warnings() with the check off = %v, want none", warnings)
+	}
+
+	validator := NewValidator(WithDatasetSchemeCheck(DatasetSchemeWarn, nil))
+
+	if err := validator.ValidateRunEvent(event); err != nil {
+		t.Fatalf("ValidateRunEvent() unexpected error: %v", err)
+	}
+
+	warnings := validator.Warnings(event)
+	if len(warnings) != 1 || !errors.Is(warnings[0], ErrUnsupportedDatasetScheme) {
+		t.Fatalf("Warnings() = %v, want one ErrUnsupportedDatasetScheme", warnings)
+	}
+
+	if !strings.HasPrefix(warnings[0].Error(), `inputs[0]: unsupported dataset namespace scheme "postgre"`) {
+		t.Errorf("Warning = %q, want it to name the input and its scheme", warnings[0].Error())
+	}
+}
+
+func TestDatasetSchemeCheck_Reject(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	validator := NewValidator(WithDatasetSchemeCheck(DatasetSchemeReject, []string{"Snowflake", "s3"}))
+
+	event := newSchemeTestEvent("s33://bucket")
+
+	err := validator.ValidateRunEvent(event)
+	if !errors.Is(err, ErrUnsupportedDatasetScheme) {
+		t.Fatalf("ValidateRunEvent() error = %v, want ErrUnsupportedDatasetScheme", err)
+	}
+
+	want := `inputs[0]: unsupported dataset namespace scheme "s33" in namespace "s33://bucket" (supported: s3, snowflake)`
+	if err.Error() != want {
+		t.Errorf("ValidateRunEvent() error = %q, want %q", err.Error(), want)
+	}
+
+	if warnings := validator.Warnings(event); len(warnings) != 0 {
+		t.Errorf("Warnings() in reject mode = %v, want none", warnings)
+	}
+
+	// Schemes outside a configured list are rejected even when supported by default
+	if err := validator.ValidateRunEvent(newSchemeTestEvent("postgres://prod:5432")); err == nil {
+		t.Error("ValidateRunEvent() of a scheme outside the configured list: want error")
+	}
+
+	if err := validator.ValidateRunEvent(newSchemeTestEvent("s3://bucket")); err != nil {
+		t.Errorf("ValidateRunEvent() of a configured scheme unexpected error: %v", err)
+	}
+}
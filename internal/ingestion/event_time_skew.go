@@ -0,0 +1,70 @@
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultMaxEventTimeSkew is the event time skew the server allows by default
+// (CORRELATOR_MAX_EVENT_TIME_SKEW). NewValidator itself leaves the check off.
+const DefaultMaxEventTimeSkew = 24 * time.Hour
+
+// ErrEventTimeSkew indicates an eventTime too far ahead of or behind server time.
+//
+// Run state is "latest eventTime wins" (GREATEST(event_time) upserts), so a single event
+// from a producer with a broken clock, stamped years ahead, would pin the run's state for good.
+var ErrEventTimeSkew = errors.New("eventTime is too far from server time")
+
+// WithMaxEventTimeSkew rejects events whose eventTime is more than skew ahead of or behind
+// server time with ErrEventTimeSkew (422 over HTTP). Zero disables the check (default);
+// negative values are ignored.
+//
+// Backfills legitimately carry old event times; callers ingesting them validate with
+// WithoutEventTimeSkewCheck.
+func WithMaxEventTimeSkew(skew time.Duration) ValidatorOption {
+	return func(v *Validator) {
+		if skew >= 0 {
+			v.maxEventTimeSkew = skew
+		}
+	}
+}
+
+// WithoutEventTimeSkewCheck returns a copy of the validator that accepts any eventTime,
+// for backfills and replays. The receiver is not modified.
+func (v *Validator) WithoutEventTimeSkewCheck() *Validator {
+	if v.maxEventTimeSkew == 0 {
+		return v
+	}
+
+	skipped := *v
+	skipped.maxEventTimeSkew = 0
+
+	return &skipped
+}
+
+// validateEventTimeSkew rejects events outside the allowed skew. Events exactly at the
+// boundary are accepted.
+func (v *Validator) validateEventTimeSkew(event *RunEvent) error {
+	if v.maxEventTimeSkew == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if v.now != nil {
+		now = v.now()
+	}
+
+	skew := event.EventTime.Sub(now)
+
+	switch {
+	case skew > v.maxEventTimeSkew:
+		return fmt.Errorf("%w: %s is %s ahead (max %s)",
+			ErrEventTimeSkew, event.EventTime.UTC().Format(time.RFC3339), skew.Round(time.Second), v.maxEventTimeSkew)
+	case -skew > v.maxEventTimeSkew:
+		return fmt.Errorf("%w: %s is %s behind (max %s)",
+			ErrEventTimeSkew, event.EventTime.UTC().Format(time.RFC3339), (-skew).Round(time.Second), v.maxEventTimeSkew)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package ingestion
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newSkewTestValidator returns a validator with a fixed clock and the given max skew.
+func newSkewTestValidator(now time.Time, skew time.Duration) *Validator {
+	validator := NewValidator(WithMaxEventTimeSkew(skew))
+	validator.now = func() time.Time { return now }
+
+	return validator
+}
+
+func TestValidateRunEvent_EventTimeSkewBoundaries(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	validator := newSkewTestValidator(now, DefaultMaxEventTimeSkew)
+
+	tests := []struct {
+		name      string
+		eventTime time.Time
+		wantErr   bool
+	}{
+		{name: "server time", eventTime: now},
+		{name: "future boundary", eventTime: now.Add(DefaultMaxEventTimeSkew)},
+		{name: "past boundary", eventTime: now.Add(-DefaultMaxEventTimeSkew)},
+		{name: "just past future boundary", eventTime: now.Add(DefaultMaxEventTimeSkew + time.Millisecond), wantErr: true},
+		{name: "just past past boundary", eventTime: now.Add(-DefaultMaxEventTimeSkew - time.Millisecond), wantErr: true},
+		{name: "years ahead (producer clock bug)", eventTime: now.AddDate(3, 0, 0), wantErr: true},
+		{name: "other timezone within skew", eventTime: now.In(time.FixedZone("UTC+14", 14*3600))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := newOutputsTestEvent(EventTypeStart, "dbt_prod", false)
+			event.EventTime = tt.eventTime
+
+			err := validator.ValidateRunEvent(event)
+
+			if tt.wantErr && !errors.Is(err, ErrEventTimeSkew) {
+				t.Errorf("ValidateRunEvent() error = %v, want ErrEventTimeSkew", err)
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Errorf("ValidateRunEvent() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateRunEvent_EventTimeSkewDisabled(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	event := newOutputsTestEvent(EventTypeStart, "dbt_prod", false)
+	event.EventTime = now.AddDate(-2, 0, 0)
+
+	// Off by default, and with zero
+	for _, validator := range []*Validator{NewValidator(), newSkewTestValidator(now, 0)} {
+		if err := validator.ValidateRunEvent(event); err != nil {
+			t.Errorf("ValidateRunEvent() without skew check error = %v", err)
+		}
+	}
+
+	// Negative values are ignored
+	v := NewValidator(WithMaxEventTimeSkew(time.Hour), WithMaxEventTimeSkew(-time.Hour))
+	if v.maxEventTimeSkew != time.Hour {
+		t.Errorf("maxEventTimeSkew = %v, want 1h", v.maxEventTimeSkew)
+	}
+
+	// Backfills skip the check without changing the shared validator
+	validator := newSkewTestValidator(now, time.Hour)
+
+	if err := validator.WithoutEventTimeSkewCheck().ValidateRunEvent(event); err != nil {
+		t.Errorf("WithoutEventTimeSkewCheck().ValidateRunEvent() error = %v", err)
+	}
+
+	if err := validator.ValidateRunEvent(event); !errors.Is(err, ErrEventTimeSkew) {
+		t.Errorf("ValidateRunEvent() error = %v, want ErrEventTimeSkew", err)
+	}
+}
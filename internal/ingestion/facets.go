@@ -0,0 +1,232 @@
+package ingestion
+
+import "strings"
+
+// Well-known facet keys with typed accessors on Facets.
+const (
+	// SchemaFacet is the standard OpenLineage SchemaDatasetFacet:
+	// {"fields": [{"name": "...", "type": "...", "description": "...", "fields": [...]}]}.
+	// Spec: https://openlineage.io/docs/spec/facets/dataset-facets/schema
+	SchemaFacet = "schema"
+
+	// DataSourceFacet is the standard OpenLineage DatasourceDatasetFacet: {"name": "...", "uri": "..."}.
+	// Spec: https://openlineage.io/docs/spec/facets/dataset-facets/data_source
+	DataSourceFacet = "dataSource"
+
+	// SQLFacet is the standard OpenLineage SQLJobFacet: {"query": "...", "dialect": "..."}.
+	// Spec: https://openlineage.io/docs/spec/facets/job-facets/sql
+	SQLFacet = "sql"
+
+	// ParentFacet is the standard OpenLineage ParentRunFacet:
+	// {"run": {"runId": "..."}, "job": {"namespace": "...", "name": "..."}, "root": {"run": ..., "job": ...}}.
+	// Spec: https://openlineage.io/docs/spec/facets/run-facets/parent_run
+	ParentFacet = "parent"
+)
+
+type (
+	// Facet is a single facet object, e.g. the value of Facets["schema"]. Like Facets it keeps
+	// the raw decoded JSON, so fields correlator does not model stay accessible.
+	Facet map[string]interface{}
+
+	// Schema is the column list of a dataset, parsed from the schema facet - Domain Model.
+	Schema struct {
+		Fields []SchemaField
+	}
+
+	// SchemaField is one column of a Schema. Fields holds the nested columns of struct-typed
+	// columns (empty otherwise).
+	SchemaField struct {
+		Name        string
+		Type        string
+		Description string
+		Fields      []SchemaField
+	}
+
+	// DataSource identifies the system holding a dataset, parsed from the dataSource facet - Domain Model.
+	DataSource struct {
+		Name string
+		URI  string
+	}
+
+	// SQL is the query a job ran, parsed from the sql job facet - Domain Model.
+	SQL struct {
+		Query   string
+		Dialect string // Empty when the producer did not report one
+	}
+
+	// ParentRun is the run that triggered a run, parsed from the parent run facet - Domain Model.
+	// Root identifies the top-level run of the hierarchy; nil when the producer sent none.
+	ParentRun struct {
+		RunID        string
+		JobNamespace string
+		JobName      string
+		Root         *ParentRun
+	}
+)
+
+// GetFacet returns the facet stored under name. ok is false when the facet is missing or is
+// not a JSON object.
+func (f Facets) GetFacet(name string) (Facet, bool) {
+	facet, ok := f[name].(map[string]interface{})
+
+	return facet, ok
+}
+
+// GetString returns the string at a dot-separated path of object keys, e.g.
+// "parent.run.runId". ok is false when any step of the path is missing or has another type.
+func (f Facets) GetString(path string) (string, bool) {
+	return Facet(f).GetString(path)
+}
+
+// GetObject returns the JSON object at a dot-separated path of object keys (see GetString).
+func (f Facet) GetObject(path string) (Facet, bool) {
+	value, ok := f.lookup(path)
+	if !ok {
+		return nil, false
+	}
+
+	object, ok := value.(map[string]interface{})
+
+	return object, ok
+}
+
+// GetString returns the string at a dot-separated path of object keys, e.g. "run.runId".
+// ok is false when any step of the path is missing or has another type.
+func (f Facet) GetString(path string) (string, bool) {
+	value, ok := f.lookup(path)
+	if !ok {
+		return "", false
+	}
+
+	s, ok := value.(string)
+
+	return s, ok
+}
+
+// lookup walks a dot-separated path of object keys.
+func (f Facet) lookup(path string) (interface{}, bool) {
+	var current interface{} = map[string]interface{}(f)
+
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// Schema returns the schema facet. Returns nil when the facet is missing or malformed; fields
+// without a name are skipped.
+func (f Facets) Schema() *Schema {
+	facet, ok := f.GetFacet(SchemaFacet)
+	if !ok {
+		return nil
+	}
+
+	fields, ok := facet["fields"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	return &Schema{Fields: parseSchemaFields(fields)}
+}
+
+// parseSchemaFields converts raw schema facet fields, recursing into nested fields.
+func parseSchemaFields(raw []interface{}) []SchemaField {
+	var fields []SchemaField
+
+	for _, entry := range raw {
+		object, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		field := SchemaField{
+			Name:        facetString(object, "name"),
+			Type:        facetString(object, "type"),
+			Description: facetString(object, "description"),
+		}
+
+		if field.Name == "" {
+			continue
+		}
+
+		if nested, ok := object["fields"].([]interface{}); ok {
+			field.Fields = parseSchemaFields(nested)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// DataSource returns the dataSource facet. Returns nil when the facet is missing or has
+// neither a name nor a uri.
+func (f Facets) DataSource() *DataSource {
+	facet, ok := f.GetFacet(DataSourceFacet)
+	if !ok {
+		return nil
+	}
+
+	source := &DataSource{Name: facetString(facet, "name"), URI: facetString(facet, "uri")}
+	if source.Name == "" && source.URI == "" {
+		return nil
+	}
+
+	return source
+}
+
+// SQL returns the sql facet. Returns nil when the facet is missing or has no query.
+func (f Facets) SQL() *SQL {
+	facet, ok := f.GetFacet(SQLFacet)
+	if !ok {
+		return nil
+	}
+
+	query := facetString(facet, "query")
+	if query == "" {
+		return nil
+	}
+
+	return &SQL{Query: query, Dialect: facetString(facet, "dialect")}
+}
+
+// Parent returns the parent run facet. Returns nil when the facet is missing or has no
+// run.runId; Root is set only when root.run.runId is present.
+func (f Facets) Parent() *ParentRun {
+	facet, ok := f.GetFacet(ParentFacet)
+	if !ok {
+		return nil
+	}
+
+	parent := parseParentRun(facet)
+	if parent == nil {
+		return nil
+	}
+
+	if root, ok := facet.GetObject("root"); ok {
+		parent.Root = parseParentRun(root)
+	}
+
+	return parent
+}
+
+// parseParentRun reads the run and job references of a parent facet or of its root object.
+func parseParentRun(facet Facet) *ParentRun {
+	runID, _ := facet.GetString("run.runId")
+	if runID == "" {
+		return nil
+	}
+
+	namespace, _ := facet.GetString("job.namespace")
+	name, _ := facet.GetString("job.name")
+
+	return &ParentRun{RunID: runID, JobNamespace: namespace, JobName: name}
+}
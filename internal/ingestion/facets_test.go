@@ -0,0 +1,148 @@
+package ingestion
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newWellKnownFacets returns facets as decoded from JSON, with one of each well-known facet.
+func newWellKnownFacets() Facets {
+	return Facets{
+		"schema": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"name": "id", "type": "BIGINT", "description": "Order ID"},
+				map[string]interface{}{"type": "TEXT"}, // No name: skipped
+				"not an object",
+				map[string]interface{}{
+					"name": "address", "type": "STRUCT",
+					"fields": []interface{}{map[string]interface{}{"name": "city", "type": "TEXT"}},
+				},
+			},
+		},
+		"dataSource": map[string]interface{}{"name": "prod", "uri": "postgres://prod:5432"},
+		"sql":        map[string]interface{}{"query": "SELECT 1", "dialect": "postgres"},
+		"parent": map[string]interface{}{
+			"run": map[string]interface{}{"runId": "019c628f-d07e-7000-8000-000000000000"},
+			"job": map[string]interface{}{"namespace": "airflow://demo", "name": "demo.dbt_run"},
+			"root": map[string]interface{}{
+				"run": map[string]interface{}{"runId": "019c628f-0000-0000-0000-000000000000"},
+				"job": map[string]interface{}{"namespace": "airflow://demo", "name": "demo"},
+			},
+		},
+		"vendor_stats": "not an object",
+	}
+}
+
+func TestFacets_GetFacetAndGetString(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	facets := newWellKnownFacets()
+
+	if facet, ok := facets.GetFacet("sql"); !ok || facet["query"] != "SELECT 1" {
+		t.Errorf("GetFacet(sql) = %v, %v", facet, ok)
+	}
+
+	for _, name := range []string{"vendor_stats", "missing"} {
+		if facet, ok := facets.GetFacet(name); ok || facet != nil {
+			t.Errorf("GetFacet(%q) = %v, %v; want nil, false", name, facet, ok)
+		}
+	}
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"parent.root.run.runId", "019c628f-0000-0000-0000-000000000000", true},
+		{"dataSource.uri", "postgres://prod:5432", true},
+		{"vendor_stats", "not an object", true},
+		{"parent.run", "", false},             // Object, not a string
+		{"parent.run.runId.extra", "", false}, // Walks past a string
+		{"parent.missing.runId", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := facets.GetString(tt.path); got != tt.want || ok != tt.wantOK {
+			t.Errorf("GetString(%q) = %q, %v; want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	var empty Facets
+	if got, ok := empty.GetString("parent.run.runId"); ok || got != "" {
+		t.Errorf("GetString on nil facets = %q, %v", got, ok)
+	}
+}
+
+func TestFacets_WellKnownFacets(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	facets := newWellKnownFacets()
+
+	wantSchema := &Schema{Fields: []SchemaField{
+		{Name: "id", Type: "BIGINT", Description: "Order ID"},
+		{Name: "address", Type: "STRUCT", Fields: []SchemaField{{Name: "city", Type: "TEXT"}}},
+	}}
+
+	if got := facets.Schema(); !reflect.DeepEqual(got, wantSchema) {
+		t.Errorf("Schema() = %+v, want %+v", got, wantSchema)
+	}
+
+	if got := facets.DataSource(); got == nil || *got != (DataSource{Name: "prod", URI: "postgres://prod:5432"}) {
+		t.Errorf("DataSource() = %+v", got)
+	}
+
+	if got := facets.SQL(); got == nil || *got != (SQL{Query: "SELECT 1", Dialect: "postgres"}) {
+		t.Errorf("SQL() = %+v", got)
+	}
+
+	want := &ParentRun{
+		RunID:        "019c628f-d07e-7000-8000-000000000000",
+		JobNamespace: "airflow://demo",
+		JobName:      "demo.dbt_run",
+		Root: &ParentRun{
+			RunID:        "019c628f-0000-0000-0000-000000000000",
+			JobNamespace: "airflow://demo",
+			JobName:      "demo",
+		},
+	}
+
+	if got := facets.Parent(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parent() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFacets_WellKnownFacetsMissingOrMalformed(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	malformed := Facets{
+		"schema":     map[string]interface{}{"fields": "id,name"},
+		"dataSource": map[string]interface{}{"name": 42},
+		"sql":        map[string]interface{}{"dialect": "postgres"},
+		"parent":     map[string]interface{}{"job": map[string]interface{}{"name": "demo"}},
+	}
+
+	for name, facets := range map[string]Facets{"missing": nil, "malformed": malformed} {
+		if got := facets.Schema(); got != nil {
+			t.Errorf("%s: Schema() = %+v, want nil", name, got)
+		}
+
+		if got := facets.DataSource(); got != nil {
+			t.Errorf("%s: DataSource() = %+v, want nil", name, got)
+		}
+
+		if got := facets.SQL(); got != nil {
+			t.Errorf("%s: SQL() = %+v, want nil", name, got)
+		}
+
+		if got := facets.Parent(); got != nil {
+			t.Errorf("%s: Parent() = %+v, want nil", name, got)
+		}
+	}
+}
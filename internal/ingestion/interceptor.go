@@ -0,0 +1,46 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrEventRejected wraps the error of an EventInterceptor that rejected an event.
+var ErrEventRejected = errors.New("event rejected")
+
+type (
+	// EventInterceptor is a pre-store hook for organization-specific enrichment or rejection,
+	// e.g. adding cost center or data domain facets based on the job namespace, without forking
+	// the ingestion pipeline.
+	//
+	// BeforeStore runs after validation, on events every transport (HTTP and Kafka) is about to
+	// store. It may modify event in place; returning an error rejects that event only (non-retriable,
+	// reported with the error message). Implementations must be safe for concurrent use.
+	EventInterceptor interface {
+		BeforeStore(ctx context.Context, event *RunEvent) error
+	}
+
+	// EventInterceptorFunc adapts a function to EventInterceptor.
+	EventInterceptorFunc func(ctx context.Context, event *RunEvent) error
+
+	// Interceptors is an ordered list of EventInterceptor. The zero value intercepts nothing.
+	Interceptors []EventInterceptor
+)
+
+// BeforeStore calls f(ctx, event).
+func (f EventInterceptorFunc) BeforeStore(ctx context.Context, event *RunEvent) error {
+	return f(ctx, event)
+}
+
+// BeforeStore runs every interceptor in order, so later ones see the enrichment of earlier ones.
+// Stops at the first rejection and returns it wrapped in ErrEventRejected.
+func (is Interceptors) BeforeStore(ctx context.Context, event *RunEvent) error {
+	for _, interceptor := range is {
+		if err := interceptor.BeforeStore(ctx, event); err != nil {
+			return fmt.Errorf("%w: %w", ErrEventRejected, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var errNotOnboarded = errors.New("namespace is not onboarded")
+
+func TestInterceptors_BeforeStore(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	var calls []string
+
+	// Enriches events of the finance namespace with a cost center facet
+	enrich := EventInterceptorFunc(func(_ context.Context, event *RunEvent) error {
+		calls = append(calls, "enrich")
+
+		if event.Job.Namespace == "finance" {
+			event.Run.Facets["costCenter"] = map[string]interface{}{"id": "cc-42"}
+		}
+
+		return nil
+	})
+
+	// Rejects events of namespaces without an owner, after enrichment ran
+	reject := EventInterceptorFunc(func(_ context.Context, event *RunEvent) error {
+		calls = append(calls, "reject")
+
+		if _, ok := event.Run.Facets["costCenter"]; !ok {
+			return errNotOnboarded
+		}
+
+		return nil
+	})
+
+	audit := EventInterceptorFunc(func(_ context.Context, _ *RunEvent) error {
+		calls = append(calls, "audit")
+
+		return nil
+	})
+
+	interceptors := Interceptors{enrich, reject, audit}
+
+	t.Run("enriched event passes every interceptor in order", func(t *testing.T) {
+		calls = nil
+		event := &RunEvent{Run: Run{Facets: Facets{}}, Job: Job{Namespace: "finance"}}
+
+		if err := interceptors.BeforeStore(t.Context(), event); err != nil {
+			t.Fatalf("BeforeStore() error = %v, want nil", err)
+		}
+
+		if costCenter, _ := event.Run.Facets.GetString("costCenter.id"); costCenter != "cc-42" {
+			t.Errorf("costCenter.id = %q, want %q", costCenter, "cc-42")
+		}
+
+		if got := len(calls); got != 3 {
+			t.Errorf("calls = %v, want enrich, reject, audit", calls)
+		}
+	})
+
+	t.Run("rejection stops the chain", func(t *testing.T) {
+		calls = nil
+		event := &RunEvent{Run: Run{Facets: Facets{}}, Job: Job{Namespace: "marketing"}}
+
+		err := interceptors.BeforeStore(t.Context(), event)
+		if !errors.Is(err, ErrEventRejected) || !errors.Is(err, errNotOnboarded) {
+			t.Fatalf("BeforeStore() error = %v, want ErrEventRejected wrapping %v", err, errNotOnboarded)
+		}
+
+		if err.Error() != "event rejected: namespace is not onboarded" {
+			t.Errorf("error message = %q", err.Error())
+		}
+
+		if got := len(calls); got != 2 {
+			t.Errorf("calls = %v, want enrich, reject", calls)
+		}
+	})
+
+	t.Run("no interceptors", func(t *testing.T) {
+		if err := Interceptors(nil).BeforeStore(t.Context(), &RunEvent{}); err != nil {
+			t.Errorf("BeforeStore() error = %v, want nil", err)
+		}
+	})
+}
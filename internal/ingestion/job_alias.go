@@ -0,0 +1,123 @@
+package ingestion
+
+import (
+	"context"
+)
+
+type (
+	// JobAlias maps a job identity to the canonical job its runs are stored under, e.g. a dbt
+	// model whose name changed across dbt versions but is the same logical transformation.
+	JobAlias struct {
+		Namespace          string
+		Name               string
+		CanonicalNamespace string
+		CanonicalName      string
+	}
+
+	// jobKey is a (namespace, name) job identity.
+	jobKey struct {
+		namespace string
+		name      string
+	}
+
+	// JobIdentityResolver canonicalizes the job identity of events with explicit aliases, so runs
+	// of a renamed job keep a continuous history under one (namespace, name). Immutable after
+	// construction and safe for concurrent use.
+	//
+	// It is an EventInterceptor: BeforeStore rewrites the job of the event and of its
+	// ParentRunFacet (parent and root job) in place and never rejects an event.
+	JobIdentityResolver struct {
+		aliases map[jobKey]jobKey
+	}
+)
+
+// Ensure JobIdentityResolver can be registered as a pre-store interceptor.
+var _ EventInterceptor = (*JobIdentityResolver)(nil)
+
+// Valid reports whether every field of a is set and a does not point to itself.
+func (a JobAlias) Valid() bool {
+	return a.Namespace != "" && a.Name != "" && a.CanonicalNamespace != "" && a.CanonicalName != "" &&
+		(a.Namespace != a.CanonicalNamespace || a.Name != a.CanonicalName)
+}
+
+// NewJobIdentityResolver builds a resolver from aliases. When an alias is defined more than
+// once, the last definition wins. Invalid aliases (see JobAlias.Valid) are ignored.
+func NewJobIdentityResolver(aliases []JobAlias) *JobIdentityResolver {
+	resolved := make(map[jobKey]jobKey, len(aliases))
+
+	for _, alias := range aliases {
+		if !alias.Valid() {
+			continue
+		}
+
+		from := jobKey{namespace: alias.Namespace, name: alias.Name}
+		resolved[from] = jobKey{namespace: alias.CanonicalNamespace, name: alias.CanonicalName}
+	}
+
+	return &JobIdentityResolver{aliases: resolved}
+}
+
+// Len returns the number of aliases.
+func (r *JobIdentityResolver) Len() int {
+	return len(r.aliases)
+}
+
+// Resolve returns the canonical identity of the job namespace/name, or namespace/name unchanged
+// if it is not an alias. Aliases chain, so a job renamed twice resolves to its latest name; a
+// cycle stops at the last identity before it repeats.
+func (r *JobIdentityResolver) Resolve(namespace, name string) (string, string) {
+	current := jobKey{namespace: namespace, name: name}
+	seen := map[jobKey]bool{current: true}
+
+	for {
+		next, ok := r.aliases[current]
+		if !ok || seen[next] {
+			return current.namespace, current.name
+		}
+
+		seen[next] = true
+		current = next
+	}
+}
+
+// BeforeStore rewrites the job identity of event, and of the parent and root job of its
+// ParentRunFacet, to their canonical identity.
+func (r *JobIdentityResolver) BeforeStore(_ context.Context, event *RunEvent) error {
+	if event == nil || len(r.aliases) == 0 {
+		return nil
+	}
+
+	event.Job.Namespace, event.Job.Name = r.Resolve(event.Job.Namespace, event.Job.Name)
+
+	for _, job := range parentFacetJobs(event.Run.Facets) {
+		namespace, _ := job["namespace"].(string)
+		name, _ := job["name"].(string)
+
+		if canonicalNamespace, canonicalName := r.Resolve(namespace, name); canonicalName != name ||
+			canonicalNamespace != namespace {
+			job["namespace"], job["name"] = canonicalNamespace, canonicalName
+		}
+	}
+
+	return nil
+}
+
+// parentFacetJobs returns the "job" objects of the ParentRunFacet (parent.job and parent.root.job).
+func parentFacetJobs(runFacets Facets) []map[string]interface{} {
+	parent, ok := runFacets.GetFacet(ParentFacet)
+	if !ok {
+		return nil
+	}
+
+	var jobs []map[string]interface{}
+
+	if job, ok := parent.GetObject("job"); ok {
+		jobs = append(jobs, job)
+	}
+
+	if job, ok := parent.GetObject("root.job"); ok {
+		jobs = append(jobs, job)
+	}
+
+	return jobs
+}
@@ -0,0 +1,85 @@
+package ingestion
+
+import (
+	"context"
+	"testing"
+)
+
+func TestJobIdentityResolver_Resolve(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	resolver := NewJobIdentityResolver([]JobAlias{
+		// Two historical names of the same dbt model resolve to one canonical job
+		{"dbt://analytics", "jaffle_shop.stg_orders", "dbt://analytics", "jaffle_shop.orders"},
+		{"dbt://analytics", "staging.orders", "dbt://analytics", "jaffle_shop.orders"},
+		// Redefined alias: the last definition wins
+		{"airflow://prod", "etl_v1", "airflow://prod", "etl_v2"},
+		{"airflow://prod", "etl_v1", "airflow://prod", "etl"},
+		// Renamed twice: aliases chain
+		{"airflow://prod", "load_v1", "airflow://prod", "load_v2"},
+		{"airflow://prod", "load_v2", "airflow://prod", "load"},
+		// Cycle
+		{"airflow://prod", "ping", "airflow://prod", "pong"},
+		{"airflow://prod", "pong", "airflow://prod", "ping"},
+		// Invalid: ignored
+		{"airflow://prod", "self", "airflow://prod", "self"},
+		{"airflow://prod", "", "airflow://prod", "empty"},
+	})
+
+	if got := resolver.Len(); got != 7 {
+		t.Errorf("Len() = %d, want 7", got)
+	}
+
+	tests := []struct {
+		namespace, name         string
+		wantNamespace, wantName string
+	}{
+		{"dbt://analytics", "jaffle_shop.stg_orders", "dbt://analytics", "jaffle_shop.orders"},
+		{"dbt://analytics", "staging.orders", "dbt://analytics", "jaffle_shop.orders"},
+		{"dbt://analytics", "jaffle_shop.orders", "dbt://analytics", "jaffle_shop.orders"},
+		{"dbt://other", "staging.orders", "dbt://other", "staging.orders"},
+		{"airflow://prod", "etl_v1", "airflow://prod", "etl"},
+		{"airflow://prod", "load_v1", "airflow://prod", "load"},
+		{"airflow://prod", "ping", "airflow://prod", "pong"},
+		{"airflow://prod", "self", "airflow://prod", "self"},
+	}
+
+	for _, tt := range tests {
+		namespace, name := resolver.Resolve(tt.namespace, tt.name)
+		if namespace != tt.wantNamespace || name != tt.wantName {
+			t.Errorf("Resolve(%q, %q) = %q, %q, want %q, %q",
+				tt.namespace, tt.name, namespace, name, tt.wantNamespace, tt.wantName)
+		}
+	}
+}
+
+func TestJobIdentityResolver_BeforeStore(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	resolver := NewJobIdentityResolver([]JobAlias{
+		{"airflow://production", "daily_etl.load_users", "airflow://production", "daily_etl.load_customers"},
+		{"airflow://production", "daily_etl", "airflow://production", "nightly_etl"},
+	})
+
+	event := newRunIDTestEvent("run-1", "parent-1", "root-1")
+	event.Run.Facets["parent"].(map[string]interface{})["root"].(map[string]interface{})["job"] = //nolint:forcetypeassert
+		map[string]interface{}{"namespace": "airflow://production", "name": "daily_etl"}
+
+	if err := resolver.BeforeStore(context.Background(), event); err != nil {
+		t.Fatalf("BeforeStore() error = %v", err)
+	}
+
+	if event.Job.Name != "daily_etl.load_customers" || event.Job.Namespace != "airflow://production" {
+		t.Errorf("job = %s/%s, want airflow://production/daily_etl.load_customers", event.Job.Namespace, event.Job.Name)
+	}
+
+	for _, job := range parentFacetJobs(event.Run.Facets) {
+		if job["name"] != "nightly_etl" {
+			t.Errorf("parent facet job name = %v, want nightly_etl", job["name"])
+		}
+	}
+}
@@ -136,6 +136,32 @@ func SortEventsByTime(events []*RunEvent) []*RunEvent {
 	return sorted
 }
 
+// ApplyOrder returns the indices of events in the order a store applies them: by eventTime when
+// every event belongs to the same run, arrival order otherwise (runs are independent). The sort
+// is stable, so events with equal times keep their arrival order.
+//
+// This is the ordering buffer for tightly interleaved single-run batches (e.g. a client
+// flushing COMPLETE before START): applied chronologically, each event transitions the run
+// from the state before it. Returning indices lets callers report results in input order.
+func ApplyOrder(events []*RunEvent) []int {
+	order := make([]int, len(events))
+	for i := range order {
+		order[i] = i
+	}
+
+	for _, event := range events[min(1, len(events)):] {
+		if event.Run.ID != events[0].Run.ID {
+			return order
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return events[order[i]].EventTime.Before(events[order[j]].EventTime)
+	})
+
+	return order
+}
+
 // ValidateEventSequence validates state transitions and returns events in chronological order.
 //
 // Events are sorted by eventTime (not arrival time) to handle out-of-order delivery
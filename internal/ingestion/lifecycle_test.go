@@ -422,3 +422,67 @@ func TestValidateEventSequence_EmptyEventList(t *testing.T) {
 		t.Errorf("Expected ErrEmptyEventList, got %v", err)
 	}
 }
+
+func TestApplyOrder(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	base := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	event := func(runID string, eventType EventType, offset time.Duration) *RunEvent {
+		return &RunEvent{EventTime: base.Add(offset), EventType: eventType, Run: Run{ID: runID}}
+	}
+
+	tests := []struct {
+		name   string
+		events []*RunEvent
+		want   []int
+	}{
+		{
+			name:   "empty batch",
+			events: nil,
+			want:   []int{},
+		},
+		{
+			name: "single-run batch in reverse is applied chronologically",
+			events: []*RunEvent{
+				event("run-1", EventTypeComplete, 5*time.Minute),
+				event("run-1", EventTypeStart, 0),
+				event("run-1", EventTypeRunning, 3*time.Minute),
+			},
+			want: []int{1, 2, 0},
+		},
+		{
+			name: "equal times keep arrival order",
+			events: []*RunEvent{
+				event("run-1", EventTypeRunning, time.Minute),
+				event("run-1", EventTypeOther, time.Minute),
+				event("run-1", EventTypeStart, 0),
+			},
+			want: []int{2, 0, 1},
+		},
+		{
+			name: "multi-run batch keeps arrival order",
+			events: []*RunEvent{
+				event("run-1", EventTypeComplete, 5*time.Minute),
+				event("run-2", EventTypeStart, 0),
+			},
+			want: []int{0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ApplyOrder(tt.events)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ApplyOrder() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ApplyOrder() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -51,6 +51,23 @@ type (
 		// Outputs are datasets produced by this run (optional).
 		// Typically specified in COMPLETE event.
 		Outputs []Dataset
+
+		// Error is the failure detail parsed from the errorMessage run facet (see ParseRunError).
+		// Only set on FAIL and ABORT events that carry an error message.
+		Error *RunError
+
+		// NominalTime is the scheduled slot parsed from the nominalTime run facet (see ParseNominalTime).
+		// Nil when the producer sent no nominalTime facet.
+		NominalTime *NominalTime
+
+		// ProcessingEngine is the engine that executed the run, parsed from the processing_engine
+		// run facet (see ParseProcessingEngine). Nil when the producer sent no such facet.
+		ProcessingEngine *ProcessingEngine
+
+		// IngestedBy is the plugin (API key client ID) that sent this event, or AnonymousPlugin when
+		// authentication is disabled. Set by the transport, not part of the OpenLineage payload;
+		// empty when the transport has no notion of plugins (Kafka).
+		IngestedBy string
 	}
 
 	// EventType represents OpenLineage run states.
@@ -232,6 +249,70 @@ func (d *Dataset) URN() string {
 	return canonicalization.GenerateDatasetURN(d.Namespace, d.Name)
 }
 
+// DedupeDatasets collapses datasets that share a URN into one, keeping the first occurrence's
+// position. Facets of later duplicates are merged into the kept dataset (later values win, as
+// in the datasets table merge), so metadata reported by any stage is retained.
+//
+// Some producers list a dataset several times in one event (e.g., a Spark job reading a table
+// in multiple stages). Deduplicate Inputs and Outputs separately: a dataset that is both read
+// and written by a run needs an input and an output edge.
+//
+// Returns datasets unchanged (same slice) when it contains no duplicates.
+func DedupeDatasets(datasets []Dataset) []Dataset {
+	seen := make(map[string]int, len(datasets))
+
+	var deduped []Dataset
+
+	for i := range datasets {
+		urn := datasets[i].URN()
+
+		idx, dup := seen[urn]
+		if !dup {
+			seen[urn] = len(seen)
+
+			if deduped != nil {
+				deduped = append(deduped, datasets[i])
+			}
+
+			continue
+		}
+
+		if deduped == nil {
+			deduped = append(make([]Dataset, 0, len(datasets)), datasets[:i]...)
+		}
+
+		kept := &deduped[idx]
+		kept.Facets = mergeFacets(kept.Facets, datasets[i].Facets)
+		kept.InputFacets = mergeFacets(kept.InputFacets, datasets[i].InputFacets)
+		kept.OutputFacets = mergeFacets(kept.OutputFacets, datasets[i].OutputFacets)
+	}
+
+	if deduped == nil {
+		return datasets
+	}
+
+	return deduped
+}
+
+// mergeFacets returns a new map with the facets of both, b winning on conflicting keys.
+func mergeFacets(a, b Facets) Facets {
+	if len(b) == 0 {
+		return a
+	}
+
+	merged := make(Facets, len(a)+len(b))
+
+	for k, v := range a {
+		merged[k] = v
+	}
+
+	for k, v := range b {
+		merged[k] = v
+	}
+
+	return merged
+}
+
 // ============================================================================
 // Test Result Domain Models
 // ============================================================================
@@ -337,6 +418,16 @@ const (
 	// TestStatusWarning indicates test passed but with warnings.
 	TestStatusWarning TestStatus = "warning"
 
+	// TestResultSourceKey is the Metadata key recording where a test result came from.
+	TestResultSourceKey = "source"
+
+	// TestResultSourceAssertionFacet marks test results derived from an assertion facet
+	// (e.g., dataQualityAssertions) on a lineage event's input datasets.
+	TestResultSourceAssertionFacet = "assertion_facet"
+
+	// AnonymousPlugin is the RunEvent.IngestedBy of events ingested with authentication disabled.
+	AnonymousPlugin = "anonymous"
+
 	maxTestNameLength = 750
 )
 
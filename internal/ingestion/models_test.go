@@ -506,3 +506,45 @@ func TestTestResult_ValidationErrorMessages(t *testing.T) {
 		assert.Contains(t, err3.Error(), "bad_status", "Error should include invalid status")
 	})
 }
+
+func TestDedupeDatasets(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	orders := Dataset{Namespace: "postgres://prod-db:5432", Name: "analytics.public.orders"}
+	users := Dataset{Namespace: "postgres://prod-db:5432", Name: "analytics.public.users"}
+
+	t.Run("no duplicates returns input unchanged", func(t *testing.T) {
+		datasets := []Dataset{orders, users}
+
+		deduped := DedupeDatasets(datasets)
+
+		require.Len(t, deduped, 2)
+		assert.Same(t, &datasets[0], &deduped[0], "expected the same backing array")
+	})
+
+	t.Run("collapses duplicates and merges facets", func(t *testing.T) {
+		stage1 := orders
+		stage1.Facets = Facets{"owner": "alice", "stage": "1"}
+		stage1.InputFacets = Facets{"dataQualityMetrics": "m1"}
+
+		stage2 := orders
+		stage2.Facets = Facets{"stage": "2"}
+
+		deduped := DedupeDatasets([]Dataset{stage1, users, stage2, users})
+
+		require.Len(t, deduped, 2)
+		assert.Equal(t, orders.URN(), deduped[0].URN())
+		assert.Equal(t, users.URN(), deduped[1].URN())
+		assert.Equal(t, Facets{"owner": "alice", "stage": "2"}, deduped[0].Facets)
+		assert.Equal(t, Facets{"dataQualityMetrics": "m1"}, deduped[0].InputFacets)
+
+		// The caller's datasets are not modified
+		assert.Equal(t, "1", stage1.Facets["stage"])
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, DedupeDatasets(nil))
+	})
+}
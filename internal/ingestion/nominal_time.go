@@ -0,0 +1,44 @@
+package ingestion
+
+import "time"
+
+// NominalTimeFacet is the standard OpenLineage NominalTimeRunFacet: the schedule slot a run was
+// meant to cover, {"nominalStartTime": "...", "nominalEndTime": "..."}. Sent by schedulers
+// (e.g., Airflow's data interval); most producers omit it.
+// Spec: https://openlineage.io/docs/spec/facets/run-facets/nominal_time
+const NominalTimeFacet = "nominalTime"
+
+// NominalTime is the scheduled time slot of a run, parsed from the nominalTime run facet - Domain Model.
+// Compared with the run's actual start and completion, it shows whether a job ran late.
+type NominalTime struct {
+	// StartTime is the nominal (scheduled) start of the run. Always set.
+	StartTime time.Time
+
+	// EndTime is the nominal end of the run; nil when the producer sent none.
+	EndTime *time.Time
+}
+
+// ParseNominalTime extracts the nominalTime facet from a run's facets.
+//
+// Returns nil when the facet is missing or nominalStartTime is absent or unparseable.
+// Timestamps are parsed like eventTime (see ParseEventTime). An unparseable nominalEndTime,
+// or one before nominalStartTime, is dropped rather than rejecting the event.
+func ParseNominalTime(runFacets Facets) *NominalTime {
+	facet, ok := runFacets.GetFacet(NominalTimeFacet)
+	if !ok {
+		return nil
+	}
+
+	start := ParseEventTime(facetString(facet, "nominalStartTime"))
+	if start.IsZero() {
+		return nil
+	}
+
+	nominal := &NominalTime{StartTime: start}
+
+	if end := ParseEventTime(facetString(facet, "nominalEndTime")); !end.IsZero() && !end.Before(start) {
+		nominal.EndTime = &end
+	}
+
+	return nominal
+}
@@ -0,0 +1,100 @@
+package ingestion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNominalTime(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	start := time.Date(2025, 6, 1, 2, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		facets    Facets
+		wantStart time.Time
+		wantEnd   *time.Time
+		wantNil   bool
+	}{
+		{
+			name: "start and end",
+			facets: Facets{"nominalTime": map[string]interface{}{
+				"nominalStartTime": "2025-06-01T02:00:00Z",
+				"nominalEndTime":   "2025-06-01T03:00:00Z",
+			}},
+			wantStart: start,
+			wantEnd:   &end,
+		},
+		{
+			name: "start only, without timezone",
+			facets: Facets{"nominalTime": map[string]interface{}{
+				"nominalStartTime": "2025-06-01T02:00:00",
+			}},
+			wantStart: start,
+		},
+		{
+			name: "end before start is dropped",
+			facets: Facets{"nominalTime": map[string]interface{}{
+				"nominalStartTime": "2025-06-01T02:00:00Z",
+				"nominalEndTime":   "2025-06-01T01:00:00Z",
+			}},
+			wantStart: start,
+		},
+		{
+			name: "unparseable end is dropped",
+			facets: Facets{"nominalTime": map[string]interface{}{
+				"nominalStartTime": "2025-06-01T02:00:00Z",
+				"nominalEndTime":   "tomorrow",
+			}},
+			wantStart: start,
+		},
+		{
+			name:    "facet missing",
+			facets:  Facets{},
+			wantNil: true,
+		},
+		{
+			name:    "start missing",
+			facets:  Facets{"nominalTime": map[string]interface{}{"nominalEndTime": "2025-06-01T03:00:00Z"}},
+			wantNil: true,
+		},
+		{
+			name:    "malformed facet",
+			facets:  Facets{"nominalTime": "2025-06-01T02:00:00Z"},
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseNominalTime(tt.facets)
+
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("ParseNominalTime() = %+v, want nil", got)
+				}
+
+				return
+			}
+
+			if got == nil {
+				t.Fatal("ParseNominalTime() = nil, want nominal time")
+			}
+
+			if !got.StartTime.Equal(tt.wantStart) {
+				t.Errorf("StartTime = %v, want %v", got.StartTime, tt.wantStart)
+			}
+
+			switch {
+			case tt.wantEnd == nil && got.EndTime != nil:
+				t.Errorf("EndTime = %v, want nil", *got.EndTime)
+			case tt.wantEnd != nil && (got.EndTime == nil || !got.EndTime.Equal(*tt.wantEnd)):
+				t.Errorf("EndTime = %v, want %v", got.EndTime, *tt.wantEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// OutputsCheckMode controls how the Validator treats COMPLETE events without output datasets.
+//
+// A successful transform usually produces something, so a COMPLETE event with zero outputs often
+// points to a broken producer. Some jobs legitimately have no outputs (tests, notifications,
+// exports to systems without lineage), hence the check is off by default and can be overridden
+// per job namespace (see WithCompleteOutputsCheck).
+type OutputsCheckMode string
+
+const (
+	// OutputsCheckOff accepts COMPLETE events without outputs silently (default).
+	OutputsCheckOff OutputsCheckMode = "off"
+
+	// OutputsCheckWarn accepts COMPLETE events without outputs and reports them as warnings
+	// (see Validator.Warnings), so producer health can be monitored without breaking ingestion.
+	OutputsCheckWarn OutputsCheckMode = "warn"
+
+	// OutputsCheckReject rejects COMPLETE events without outputs with ErrCompleteWithoutOutputs
+	// (422 over HTTP).
+	OutputsCheckReject OutputsCheckMode = "reject"
+)
+
+var (
+	// ErrCompleteWithoutOutputs indicates a COMPLETE event with no output datasets.
+	// Returned by ValidateRunEvent in OutputsCheckReject and by Warnings in OutputsCheckWarn.
+	ErrCompleteWithoutOutputs = errors.New("COMPLETE event has no outputs")
+
+	// ErrInvalidOutputsCheckMode indicates an unknown OutputsCheckMode value.
+	ErrInvalidOutputsCheckMode = errors.New("invalid outputs check mode")
+)
+
+// ParseOutputsCheckMode parses an outputs check mode name (case-insensitive). Empty means OutputsCheckOff.
+func ParseOutputsCheckMode(s string) (OutputsCheckMode, error) {
+	switch mode := OutputsCheckMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return OutputsCheckOff, nil
+	case OutputsCheckOff, OutputsCheckWarn, OutputsCheckReject:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q (valid: off, warn, reject)", ErrInvalidOutputsCheckMode, s)
+	}
+}
+
+// ParseOutputsCheckOverrides parses per-namespace modes from a comma-separated list of
+// namespace=mode pairs, e.g. "airflow_prod=reject,notifications=off". The last '=' separates
+// the mode, so namespaces may contain '='. Empty input returns nil.
+func ParseOutputsCheckOverrides(s string) (map[string]OutputsCheckMode, error) {
+	var overrides map[string]OutputsCheckMode
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		sep := strings.LastIndex(pair, "=")
+		if sep <= 0 {
+			return nil, fmt.Errorf("%w: %q (expected namespace=mode)", ErrInvalidOutputsCheckMode, pair)
+		}
+
+		mode, err := ParseOutputsCheckMode(pair[sep+1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if overrides == nil {
+			overrides = make(map[string]OutputsCheckMode)
+		}
+
+		overrides[strings.TrimSpace(pair[:sep])] = mode
+	}
+
+	return overrides, nil
+}
+
+// WithCompleteOutputsCheck sets how COMPLETE events without outputs are handled (see OutputsCheckMode).
+// overrides maps job namespaces to the mode used for their events instead of mode.
+// An empty mode is ignored and the default (OutputsCheckOff) is kept.
+func WithCompleteOutputsCheck(mode OutputsCheckMode, overrides map[string]OutputsCheckMode) ValidatorOption {
+	return func(v *Validator) {
+		if mode != "" {
+			v.outputsCheck = mode
+		}
+
+		v.outputsCheckOverrides = overrides
+	}
+}
+
+// Warnings returns soft validation findings for an event that passed ValidateRunEvent.
+// Warnings never block ingestion; callers surface them to producers and operators.
+// Reports ErrCompleteWithoutOutputs for namespaces in OutputsCheckWarn,
+// ErrDatasetInputAndOutput in DatasetRolesWarn and ErrUnsupportedDatasetScheme in DatasetSchemeWarn.
+func (v *Validator) Warnings(event *RunEvent) []error {
+	if event == nil {
+		return nil
+	}
+
+	var warnings []error
+
+	if v.outputsCheckMode(event.Job.Namespace) == OutputsCheckWarn && completeWithoutOutputs(event) {
+		warnings = append(warnings, completeWithoutOutputsError(event))
+	}
+
+	if v.datasetRoles == DatasetRolesWarn {
+		if err := v.inputAndOutputError(event); err != nil {
+			warnings = append(warnings, err)
+		}
+	}
+
+	if v.datasetScheme == DatasetSchemeWarn {
+		if err := v.unsupportedSchemeError(event); err != nil {
+			warnings = append(warnings, err)
+		}
+	}
+
+	return warnings
+}
+
+// validateOutputs rejects COMPLETE events without outputs for namespaces in OutputsCheckReject.
+func (v *Validator) validateOutputs(event *RunEvent) error {
+	if v.outputsCheckMode(event.Job.Namespace) == OutputsCheckReject && completeWithoutOutputs(event) {
+		return completeWithoutOutputsError(event)
+	}
+
+	return nil
+}
+
+// outputsCheckMode returns the outputs check mode for a job namespace.
+func (v *Validator) outputsCheckMode(namespace string) OutputsCheckMode {
+	if mode, ok := v.outputsCheckOverrides[namespace]; ok {
+		return mode
+	}
+
+	return v.outputsCheck
+}
+
+func completeWithoutOutputs(event *RunEvent) bool {
+	return event.EventType == EventTypeComplete && len(event.Outputs) == 0
+}
+
+func completeWithoutOutputsError(event *RunEvent) error {
+	return fmt.Errorf("%w (job %s/%s)", ErrCompleteWithoutOutputs, event.Job.Namespace, event.Job.Name)
+}
@@ -0,0 +1,176 @@
+package ingestion
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newOutputsTestEvent returns a valid event of the given type in namespace, with one output
+// dataset when withOutput is true.
+func newOutputsTestEvent(eventType EventType, namespace string, withOutput bool) *RunEvent {
+	event := &RunEvent{
+		EventTime: time.Now().UTC(),
+		EventType: eventType,
+		Producer:  "https://github.com/OpenLineage/OpenLineage/tree/1.0.0/integration/dbt",
+		SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+		Run:       Run{ID: "550e8400-e29b-41d4-a716-446655440000"},
+		Job:       Job{Namespace: namespace, Name: "models.orders"},
+		Inputs:    []Dataset{},
+		Outputs:   []Dataset{},
+	}
+
+	if withOutput {
+		event.Outputs = []Dataset{{Namespace: "postgres://prod:5432", Name: "analytics.public.orders"}}
+	}
+
+	return event
+}
+
+func TestParseOutputsCheckMode(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		input   string
+		want    OutputsCheckMode
+		wantErr bool
+	}{
+		{"", OutputsCheckOff, false},
+		{"off", OutputsCheckOff, false},
+		{" WARN ", OutputsCheckWarn, false},
+		{"reject", OutputsCheckReject, false},
+		{"strict", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseOutputsCheckMode(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidOutputsCheckMode) {
+				t.Errorf("ParseOutputsCheckMode(%q) error = %v, want ErrInvalidOutputsCheckMode", tt.input, err)
+			}
+
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("ParseOutputsCheckMode(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseOutputsCheckOverrides(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	overrides, err := ParseOutputsCheckOverrides(" dbt_prod=reject, airflow://host?a=b=off ,")
+	if err != nil {
+		t.Fatalf("ParseOutputsCheckOverrides() error = %v", err)
+	}
+
+	if len(overrides) != 2 || overrides["dbt_prod"] != OutputsCheckReject ||
+		overrides["airflow://host?a=b"] != OutputsCheckOff {
+		t.Errorf("unexpected overrides: %v", overrides)
+	}
+
+	if overrides, err := ParseOutputsCheckOverrides(""); err != nil || overrides != nil {
+		t.Errorf("empty input = %v, %v; want nil, nil", overrides, err)
+	}
+
+	for _, input := range []string{"dbt_prod", "=warn", "dbt_prod=loud"} {
+		if _, err := ParseOutputsCheckOverrides(input); !errors.Is(err, ErrInvalidOutputsCheckMode) {
+			t.Errorf("ParseOutputsCheckOverrides(%q) error = %v, want ErrInvalidOutputsCheckMode", input, err)
+		}
+	}
+}
+
+func TestValidateRunEvent_CompleteOutputsCheck(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	t.Run("off accepts COMPLETE without outputs and warns nothing", func(t *testing.T) {
+		validator := NewValidator()
+		event := newOutputsTestEvent(EventTypeComplete, "dbt_prod", false)
+
+		if err := validator.ValidateRunEvent(event); err != nil {
+			t.Fatalf("ValidateRunEvent() error = %v", err)
+		}
+
+		if warnings := validator.Warnings(event); len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+	})
+
+	t.Run("warn accepts COMPLETE without outputs with a warning", func(t *testing.T) {
+		validator := NewValidator(WithCompleteOutputsCheck(OutputsCheckWarn, nil))
+		event := newOutputsTestEvent(EventTypeComplete, "dbt_prod", false)
+
+		if err := validator.ValidateRunEvent(event); err != nil {
+			t.Fatalf("warn mode must not reject, got %v", err)
+		}
+
+		warnings := validator.Warnings(event)
+		if len(warnings) != 1 || !errors.Is(warnings[0], ErrCompleteWithoutOutputs) {
+			t.Fatalf("expected ErrCompleteWithoutOutputs warning, got %v", warnings)
+		}
+
+		if warnings[0].Error() != "COMPLETE event has no outputs (job dbt_prod/models.orders)" {
+			t.Errorf("unexpected warning message: %q", warnings[0].Error())
+		}
+
+		for _, ok := range []*RunEvent{
+			newOutputsTestEvent(EventTypeComplete, "dbt_prod", true),
+			newOutputsTestEvent(EventTypeStart, "dbt_prod", false),
+			newOutputsTestEvent(EventTypeFail, "dbt_prod", false),
+		} {
+			if warnings := validator.Warnings(ok); len(warnings) != 0 {
+				t.Errorf("%s event (outputs=%d): expected no warnings, got %v", ok.EventType, len(ok.Outputs), warnings)
+			}
+		}
+	})
+
+	t.Run("reject rejects COMPLETE without outputs", func(t *testing.T) {
+		validator := NewValidator(WithCompleteOutputsCheck(OutputsCheckReject, nil))
+
+		err := validator.ValidateRunEvent(newOutputsTestEvent(EventTypeComplete, "dbt_prod", false))
+		if !errors.Is(err, ErrCompleteWithoutOutputs) {
+			t.Fatalf("expected ErrCompleteWithoutOutputs, got %v", err)
+		}
+
+		if err := validator.ValidateRunEvent(newOutputsTestEvent(EventTypeComplete, "dbt_prod", true)); err != nil {
+			t.Errorf("COMPLETE with outputs must be accepted, got %v", err)
+		}
+
+		if err := validator.ValidateRunEvent(newOutputsTestEvent(EventTypeStart, "dbt_prod", false)); err != nil {
+			t.Errorf("START without outputs must be accepted, got %v", err)
+		}
+	})
+
+	t.Run("namespace overrides take precedence", func(t *testing.T) {
+		validator := NewValidator(WithCompleteOutputsCheck(OutputsCheckReject, map[string]OutputsCheckMode{
+			"notifications":      OutputsCheckOff,
+			"great_expectations": OutputsCheckWarn,
+		}))
+
+		if err := validator.ValidateRunEvent(newOutputsTestEvent(EventTypeComplete, "notifications", false)); err != nil {
+			t.Errorf("exempt namespace must be accepted, got %v", err)
+		}
+
+		event := newOutputsTestEvent(EventTypeComplete, "great_expectations", false)
+		if err := validator.ValidateRunEvent(event); err != nil {
+			t.Errorf("warn namespace must be accepted, got %v", err)
+		}
+
+		if warnings := validator.Warnings(event); len(warnings) != 1 {
+			t.Errorf("warn namespace: expected 1 warning, got %v", warnings)
+		}
+
+		err := validator.ValidateRunEvent(newOutputsTestEvent(EventTypeComplete, "dbt_prod", false))
+		if !errors.Is(err, ErrCompleteWithoutOutputs) {
+			t.Errorf("other namespaces use the default mode, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package ingestion
+
+// ProcessingEngineFacet is the standard OpenLineage ProcessingEngineRunFacet: the engine that
+// executed a run, {"name": "spark", "version": "3.5.0", "openlineageAdapterVersion": "1.9.0"}.
+// Spec: https://openlineage.io/docs/spec/facets/run-facets/processing_engine
+const ProcessingEngineFacet = "processing_engine"
+
+// ProcessingEngine is the engine that executed a run, parsed from the processing_engine run
+// facet - Domain Model. More reliable than the producer URL (see Producer) for telling which
+// tool and version produced a run.
+type ProcessingEngine struct {
+	// Name is the engine, e.g. "spark" or "dbt". Always set.
+	Name string
+
+	// Version is the engine version, e.g. "3.5.0"; empty when the producer sent none.
+	Version string
+
+	// OpenLineageAdapterVersion is the version of the OpenLineage integration running in the
+	// engine; empty when the producer sent none.
+	OpenLineageAdapterVersion string
+}
+
+// ParseProcessingEngine extracts the processing_engine facet from a run's facets.
+//
+// Returns nil when the facet is missing or has no name: the spec only requires version, but
+// a version without the engine it belongs to says nothing about the producer.
+func ParseProcessingEngine(runFacets Facets) *ProcessingEngine {
+	facet, ok := runFacets.GetFacet(ProcessingEngineFacet)
+	if !ok {
+		return nil
+	}
+
+	engine := &ProcessingEngine{
+		Name:                      facetString(facet, "name"),
+		Version:                   facetString(facet, "version"),
+		OpenLineageAdapterVersion: facetString(facet, "openlineageAdapterVersion"),
+	}
+
+	if engine.Name == "" {
+		return nil
+	}
+
+	return engine
+}
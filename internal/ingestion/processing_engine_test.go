@@ -0,0 +1,79 @@
+package ingestion
+
+import "testing"
+
+func TestParseProcessingEngine(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		name   string
+		facets Facets
+		want   *ProcessingEngine
+	}{
+		{
+			name: "name, version and adapter version",
+			facets: Facets{"processing_engine": map[string]interface{}{
+				"_producer":                 "https://github.com/OpenLineage/OpenLineage/tree/1.9.0/integration/spark",
+				"name":                      "spark",
+				"version":                   "3.5.0",
+				"openlineageAdapterVersion": "1.9.0",
+			}},
+			want: &ProcessingEngine{Name: "spark", Version: "3.5.0", OpenLineageAdapterVersion: "1.9.0"},
+		},
+		{
+			name: "name only",
+			facets: Facets{"processing_engine": map[string]interface{}{
+				"name": "dbt",
+			}},
+			want: &ProcessingEngine{Name: "dbt"},
+		},
+		{
+			name: "version without name",
+			facets: Facets{"processing_engine": map[string]interface{}{
+				"version": "1.7.4",
+			}},
+		},
+		{
+			name: "name is not a string",
+			facets: Facets{"processing_engine": map[string]interface{}{
+				"name":    42.0,
+				"version": "1.7.4",
+			}},
+		},
+		{
+			name:   "facet is not an object",
+			facets: Facets{"processing_engine": "spark"},
+		},
+		{
+			name:   "no facet",
+			facets: Facets{"nominalTime": map[string]interface{}{"nominalStartTime": "2025-06-01T02:00:00Z"}},
+		},
+		{
+			name: "nil facets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseProcessingEngine(tt.facets)
+
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParseProcessingEngine() = %+v, want nil", got)
+				}
+
+				return
+			}
+
+			if got == nil {
+				t.Fatalf("ParseProcessingEngine() = nil, want %+v", tt.want)
+			}
+
+			if *got != *tt.want {
+				t.Errorf("ParseProcessingEngine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package ingestion
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Size bounds for error text persisted from run facets.
+// Stack traces from JVM and Python jobs routinely run to hundreds of lines; the message is what
+// on-call reads first, the trace is kept for context and truncated well below the facet size limit.
+const (
+	MaxErrorMessageLength        = 4 * 1024  // Bytes of errorMessage.message kept
+	MaxErrorStackTraceLength     = 32 * 1024 // Bytes of errorMessage.stackTrace kept
+	MaxErrorLanguageLength       = 50        // Matches job_runs.error_programming_language
+	MaxErrorClassificationLength = 255       // Matches job_runs.error_classification
+
+	errorTruncatedSuffix = "\n... [truncated]"
+)
+
+// Run facet keys carrying failure details.
+const (
+	// ErrorMessageFacet is the standard OpenLineage ErrorMessageRunFacet:
+	// {"message": "...", "programmingLanguage": "...", "stackTrace": "..."}.
+	// Spec: https://openlineage.io/docs/spec/facets/run-facets/error_message
+	ErrorMessageFacet = "errorMessage"
+
+	// ErrorClassificationFacet is an optional run facet with a producer-assigned error category:
+	// {"classification": "..."} (e.g., "timeout", "oom", "data_quality").
+	ErrorClassificationFacet = "errorClassification"
+)
+
+// RunError is the failure detail of a FAIL or ABORT run, parsed from run facets - Domain Model.
+// All fields are size-bounded and safe to store in TEXT and JSONB columns.
+type RunError struct {
+	// Message is the human-readable error (exception message). Always non-empty.
+	Message string
+
+	// ProgrammingLanguage is the language of the failing code (e.g., "python", "java").
+	ProgrammingLanguage string
+
+	// StackTrace is the multi-line stack trace, if the producer sent one.
+	StackTrace string
+
+	// Classification is the producer-assigned error category from the errorClassification facet.
+	Classification string
+}
+
+// ParseRunError extracts the error facets from a FAIL or ABORT event's run facets.
+//
+// Returns nil for other event types, or when the errorMessage facet is missing or has no message.
+// Text is made JSONB-safe (invalid UTF-8 replaced, NUL bytes removed — PostgreSQL rejects
+// \u0000 in JSONB and TEXT) and truncated on a rune boundary to the Max*Length bounds.
+// Line breaks in stack traces are preserved; JSON encoding escapes them.
+func ParseRunError(eventType EventType, runFacets Facets) *RunError {
+	if eventType != EventTypeFail && eventType != EventTypeAbort {
+		return nil
+	}
+
+	facet, ok := runFacets.GetFacet(ErrorMessageFacet)
+	if !ok {
+		return nil
+	}
+
+	message := sanitizeErrorText(facetString(facet, "message"), MaxErrorMessageLength)
+	if strings.TrimSpace(message) == "" {
+		return nil
+	}
+
+	runError := &RunError{
+		Message:             message,
+		ProgrammingLanguage: sanitizeErrorText(facetString(facet, "programmingLanguage"), MaxErrorLanguageLength),
+		StackTrace:          sanitizeErrorText(facetString(facet, "stackTrace"), MaxErrorStackTraceLength),
+	}
+
+	if classification, ok := runFacets.GetFacet(ErrorClassificationFacet); ok {
+		runError.Classification = sanitizeErrorText(
+			strings.TrimSpace(facetString(classification, "classification")), MaxErrorClassificationLength,
+		)
+	}
+
+	return runError
+}
+
+// Facet returns the bounded errorMessage facet for persisting in place of the raw facet.
+func (e *RunError) Facet() map[string]interface{} {
+	facet := map[string]interface{}{
+		"message": e.Message,
+	}
+
+	if e.ProgrammingLanguage != "" {
+		facet["programmingLanguage"] = e.ProgrammingLanguage
+	}
+
+	if e.StackTrace != "" {
+		facet["stackTrace"] = e.StackTrace
+	}
+
+	return facet
+}
+
+// facetString returns a string field from a facet, or "" when absent or not a string.
+func facetString(facet map[string]interface{}, key string) string {
+	value, _ := facet[key].(string)
+
+	return value
+}
+
+// sanitizeErrorText makes text safe for PostgreSQL TEXT/JSONB and bounds it to maxBytes.
+// Truncated text ends with a marker so readers know the trace was cut.
+func sanitizeErrorText(text string, maxBytes int) string {
+	text = strings.ToValidUTF8(text, "�")
+	text = strings.ReplaceAll(text, "\x00", "")
+
+	if len(text) <= maxBytes {
+		return text
+	}
+
+	suffix := errorTruncatedSuffix
+	if len(suffix) >= maxBytes {
+		suffix = ""
+	}
+
+	cut := maxBytes - len(suffix)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+
+	return text[:cut] + suffix
+}
@@ -0,0 +1,146 @@
+package ingestion
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseRunError(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	errorFacets := Facets{
+		"errorMessage": map[string]interface{}{
+			"message":             "division by zero",
+			"programmingLanguage": "python",
+			"stackTrace":          "Traceback (most recent call last):\n  File \"job.py\", line 3\nZeroDivisionError",
+		},
+		"errorClassification": map[string]interface{}{
+			"classification": " data_quality ",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		eventType EventType
+		facets    Facets
+		want      *RunError
+	}{
+		{
+			name:      "fail event with error facets",
+			eventType: EventTypeFail,
+			facets:    errorFacets,
+			want: &RunError{
+				Message:             "division by zero",
+				ProgrammingLanguage: "python",
+				StackTrace:          "Traceback (most recent call last):\n  File \"job.py\", line 3\nZeroDivisionError",
+				Classification:      "data_quality",
+			},
+		},
+		{
+			name:      "abort event with message only",
+			eventType: EventTypeAbort,
+			facets:    Facets{"errorMessage": map[string]interface{}{"message": "killed"}},
+			want:      &RunError{Message: "killed"},
+		},
+		{
+			name:      "complete event ignores error facet",
+			eventType: EventTypeComplete,
+			facets:    errorFacets,
+		},
+		{
+			name:      "fail event without error facet",
+			eventType: EventTypeFail,
+			facets:    Facets{},
+		},
+		{
+			name:      "blank message",
+			eventType: EventTypeFail,
+			facets:    Facets{"errorMessage": map[string]interface{}{"message": "  ", "stackTrace": "trace"}},
+		},
+		{
+			name:      "malformed facet",
+			eventType: EventTypeFail,
+			facets:    Facets{"errorMessage": "not an object"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseRunError(tt.eventType, tt.facets)
+
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParseRunError() = %+v, want nil", got)
+				}
+
+				return
+			}
+
+			if got == nil || *got != *tt.want {
+				t.Errorf("ParseRunError() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRunError_SanitizesAndBoundsText(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	// Multi-byte runes straddle the limit so truncation must back off to a rune boundary
+	longTrace := strings.Repeat("at com.example.Job.run(Job.java:42) — é\n", 2000)
+
+	got := ParseRunError(EventTypeFail, Facets{
+		"errorMessage": map[string]interface{}{
+			"message":    "bad\x00byte \xff here",
+			"stackTrace": longTrace,
+		},
+	})
+	if got == nil {
+		t.Fatal("ParseRunError() = nil, want error")
+	}
+
+	if strings.Contains(got.Message, "\x00") {
+		t.Error("Message contains NUL byte; PostgreSQL rejects it in TEXT and JSONB")
+	}
+
+	if !utf8.ValidString(got.Message) {
+		t.Errorf("Message is not valid UTF-8: %q", got.Message)
+	}
+
+	if len(got.StackTrace) > MaxErrorStackTraceLength {
+		t.Errorf("len(StackTrace) = %d, want <= %d", len(got.StackTrace), MaxErrorStackTraceLength)
+	}
+
+	if !utf8.ValidString(got.StackTrace) {
+		t.Error("StackTrace was truncated inside a multi-byte rune")
+	}
+
+	if !strings.HasSuffix(got.StackTrace, errorTruncatedSuffix) {
+		t.Error("truncated StackTrace is missing the truncation marker")
+	}
+
+	if !strings.Contains(got.StackTrace, "\n") {
+		t.Error("StackTrace line breaks were not preserved")
+	}
+}
+
+func TestRunError_Facet(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	facet := (&RunError{Message: "boom", StackTrace: "line1\nline2"}).Facet()
+
+	if facet["message"] != "boom" || facet["stackTrace"] != "line1\nline2" {
+		t.Errorf("Facet() = %v", facet)
+	}
+
+	if _, ok := facet["programmingLanguage"]; ok {
+		t.Error("Facet() includes empty programmingLanguage")
+	}
+}
@@ -0,0 +1,139 @@
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// RunIDMode controls how the Validator treats run IDs that are not UUIDs.
+//
+// job_runs.run_id (and parent_run_id / root_parent_run_id) are UUID columns, so a non-UUID
+// run ID accepted by the permissive mode fails later at storage time.
+type RunIDMode string
+
+const (
+	// RunIDModePermissive accepts any non-empty run ID (default, backward compatible).
+	RunIDModePermissive RunIDMode = "permissive"
+
+	// RunIDModeStrict rejects non-UUID run IDs with ErrInvalidRunID (422 over HTTP).
+	RunIDModeStrict RunIDMode = "strict"
+
+	// RunIDModeCanonicalize rewrites non-UUID run IDs to a deterministic UUID v5 (see CanonicalRunID),
+	// so producers that send opaque IDs (e.g., "scheduled__2026-02-10T00:00:00") are stored consistently.
+	RunIDModeCanonicalize RunIDMode = "canonicalize"
+)
+
+// uuidStringLength is the length of the canonical 8-4-4-4-12 UUID form.
+const uuidStringLength = 36
+
+var (
+	// ErrInvalidRunID indicates run.runId is not a UUID (RunIDModeStrict only).
+	ErrInvalidRunID = errors.New("run.runId must be a UUID")
+
+	// ErrInvalidParentRunID indicates the ParentRunFacet references a non-UUID run (RunIDModeStrict only).
+	ErrInvalidParentRunID = errors.New("run.facets.parent runId must be a UUID")
+
+	// ErrInvalidRunIDMode indicates an unknown RunIDMode value.
+	ErrInvalidRunIDMode = errors.New("invalid run ID mode")
+
+	// RunIDNamespace is the UUID v5 namespace for canonicalized run IDs.
+	// Derived from a fixed URL so the mapping is stable across releases and deployments.
+	RunIDNamespace = uuid.NewSHA1( //nolint:gochecknoglobals
+		uuid.NameSpaceURL, []byte("https://correlator.io/openlineage/run-id"),
+	)
+)
+
+// ParseRunIDMode parses a run ID mode name (case-insensitive). Empty means RunIDModePermissive.
+func ParseRunIDMode(s string) (RunIDMode, error) {
+	switch mode := RunIDMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return RunIDModePermissive, nil
+	case RunIDModePermissive, RunIDModeStrict, RunIDModeCanonicalize:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q (valid: permissive, strict, canonicalize)", ErrInvalidRunIDMode, s)
+	}
+}
+
+// IsUUID reports whether s is a UUID in the canonical 8-4-4-4-12 hex form.
+// Braced and urn:uuid: forms are rejected so stored IDs always match what producers sent.
+func IsUUID(s string) bool {
+	if len(s) != uuidStringLength {
+		return false
+	}
+
+	_, err := uuid.Parse(s)
+
+	return err == nil
+}
+
+// CanonicalRunID returns runID unchanged if it is a UUID (or empty), otherwise the UUID v5 of
+// runID in RunIDNamespace. The mapping is deterministic: the same producer run ID always yields
+// the same UUID, so START/COMPLETE events of a run and child ParentRunFacets stay linked.
+func CanonicalRunID(runID string) string {
+	if runID == "" || IsUUID(runID) {
+		return runID
+	}
+
+	return uuid.NewSHA1(RunIDNamespace, []byte(runID)).String()
+}
+
+// CanonicalizeRunIDs rewrites run.runId and the ParentRunFacet parent/root run IDs of event
+// in place using CanonicalRunID.
+func CanonicalizeRunIDs(event *RunEvent) {
+	if event == nil {
+		return
+	}
+
+	event.Run.ID = CanonicalRunID(event.Run.ID)
+
+	for _, run := range parentFacetRuns(event.Run.Facets) {
+		if runID, ok := run["runId"].(string); ok {
+			run["runId"] = CanonicalRunID(runID)
+		}
+	}
+}
+
+// validateRunIDs enforces the validator's RunIDMode on a RunEvent whose run.runId is non-empty.
+func (v *Validator) validateRunIDs(event *RunEvent) error {
+	switch v.runIDMode {
+	case RunIDModeStrict:
+		if !IsUUID(event.Run.ID) {
+			return fmt.Errorf("%w, got: %q", ErrInvalidRunID, event.Run.ID)
+		}
+
+		for _, run := range parentFacetRuns(event.Run.Facets) {
+			if runID, ok := run["runId"].(string); ok && !IsUUID(runID) {
+				return fmt.Errorf("%w, got: %q", ErrInvalidParentRunID, runID)
+			}
+		}
+	case RunIDModeCanonicalize:
+		CanonicalizeRunIDs(event)
+	case RunIDModePermissive:
+	}
+
+	return nil
+}
+
+// parentFacetRuns returns the "run" objects of the ParentRunFacet (parent.run and parent.root.run).
+func parentFacetRuns(runFacets Facets) []map[string]interface{} {
+	parent, ok := runFacets.GetFacet(ParentFacet)
+	if !ok {
+		return nil
+	}
+
+	var runs []map[string]interface{}
+
+	if run, ok := parent.GetObject("run"); ok {
+		runs = append(runs, run)
+	}
+
+	if run, ok := parent.GetObject("root.run"); ok {
+		runs = append(runs, run)
+	}
+
+	return runs
+}
@@ -0,0 +1,210 @@
+package ingestion
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newRunIDTestEvent returns a valid event whose run (and parent/root runs) use the given IDs.
+func newRunIDTestEvent(runID, parentRunID, rootRunID string) *RunEvent {
+	return &RunEvent{
+		EventTime: time.Now().UTC(),
+		EventType: EventTypeStart,
+		Producer:  "https://github.com/OpenLineage/OpenLineage/tree/0.30.0/integration/airflow",
+		SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+		Run: Run{
+			ID: runID,
+			Facets: Facets{
+				"parent": map[string]interface{}{
+					"job":  map[string]interface{}{"namespace": "airflow://production", "name": "daily_etl"},
+					"run":  map[string]interface{}{"runId": parentRunID},
+					"root": map[string]interface{}{"run": map[string]interface{}{"runId": rootRunID}},
+				},
+			},
+		},
+		Job: Job{Namespace: "airflow://production", Name: "daily_etl.load_users"},
+	}
+}
+
+func parentRunIDs(event *RunEvent) (string, string) {
+	runs := parentFacetRuns(event.Run.Facets)
+
+	return runs[0]["runId"].(string), runs[1]["runId"].(string) //nolint:forcetypeassert
+}
+
+func TestParseRunIDMode(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		input   string
+		want    RunIDMode
+		wantErr bool
+	}{
+		{"", RunIDModePermissive, false},
+		{"permissive", RunIDModePermissive, false},
+		{" STRICT ", RunIDModeStrict, false},
+		{"canonicalize", RunIDModeCanonicalize, false},
+		{"lenient", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRunIDMode(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidRunIDMode) {
+				t.Errorf("ParseRunIDMode(%q) error = %v, want ErrInvalidRunIDMode", tt.input, err)
+			}
+
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRunIDMode(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	valid := []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"019C628F-D07E-7000-8000-000000000000",
+	}
+	invalid := []string{
+		"",
+		"airflow-run-id",
+		"550e8400e29b41d4a716446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"550e8400-e29b-41d4-a716-44665544000z",
+	}
+
+	for _, s := range valid {
+		if !IsUUID(s) {
+			t.Errorf("IsUUID(%q) = false, want true", s)
+		}
+	}
+
+	for _, s := range invalid {
+		if IsUUID(s) {
+			t.Errorf("IsUUID(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestCanonicalRunID(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	const uuidRunID = "550e8400-e29b-41d4-a716-446655440000"
+
+	if got := CanonicalRunID(uuidRunID); got != uuidRunID {
+		t.Errorf("CanonicalRunID must keep UUIDs unchanged, got %q", got)
+	}
+
+	first := CanonicalRunID("scheduled__2026-02-10T00:00:00")
+	second := CanonicalRunID("scheduled__2026-02-10T00:00:00")
+	other := CanonicalRunID("scheduled__2026-02-11T00:00:00")
+
+	if !IsUUID(first) {
+		t.Fatalf("CanonicalRunID must return a UUID, got %q", first)
+	}
+
+	if first != second {
+		t.Errorf("CanonicalRunID must be deterministic: %q != %q", first, second)
+	}
+
+	if first == other {
+		t.Error("CanonicalRunID must map different run IDs to different UUIDs")
+	}
+
+	// Pinned so the mapping never changes across releases (stored run IDs depend on it)
+	if first != "47c1bdf2-bc15-520d-8c01-0f856cb1f56c" {
+		t.Errorf("CanonicalRunID mapping changed: got %q", first)
+	}
+}
+
+func TestValidateRunEvent_RunIDModes(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	const uuidRunID = "550e8400-e29b-41d4-a716-446655440000"
+
+	t.Run("permissive accepts non-UUID run IDs unchanged", func(t *testing.T) {
+		event := newRunIDTestEvent("airflow-run-id", "parent-run", "root-run")
+
+		if err := NewValidator().ValidateRunEvent(event); err != nil {
+			t.Fatalf("ValidateRunEvent() error = %v", err)
+		}
+
+		if event.Run.ID != "airflow-run-id" {
+			t.Errorf("permissive mode must not rewrite run.runId, got %q", event.Run.ID)
+		}
+	})
+
+	t.Run("strict rejects non-UUID run.runId", func(t *testing.T) {
+		validator := NewValidator(WithRunIDMode(RunIDModeStrict))
+
+		err := validator.ValidateRunEvent(newRunIDTestEvent("airflow-run-id", uuidRunID, uuidRunID))
+		if !errors.Is(err, ErrInvalidRunID) {
+			t.Fatalf("expected ErrInvalidRunID, got %v", err)
+		}
+
+		if err.Error() != `run.runId must be a UUID, got: "airflow-run-id"` {
+			t.Errorf("unexpected error message: %q", err.Error())
+		}
+	})
+
+	t.Run("strict rejects non-UUID parent run IDs", func(t *testing.T) {
+		validator := NewValidator(WithRunIDMode(RunIDModeStrict))
+
+		err := validator.ValidateRunEvent(newRunIDTestEvent(uuidRunID, uuidRunID, "root-run"))
+		if !errors.Is(err, ErrInvalidParentRunID) {
+			t.Fatalf("expected ErrInvalidParentRunID, got %v", err)
+		}
+
+		if err := validator.ValidateRunEvent(newRunIDTestEvent(uuidRunID, uuidRunID, uuidRunID)); err != nil {
+			t.Errorf("strict mode must accept UUID run IDs, got %v", err)
+		}
+	})
+
+	t.Run("canonicalize rewrites run and parent run IDs", func(t *testing.T) {
+		validator := NewValidator(WithRunIDMode(RunIDModeCanonicalize))
+		event := newRunIDTestEvent("airflow-run-id", "parent-run", uuidRunID)
+
+		if err := validator.ValidateRunEvent(event); err != nil {
+			t.Fatalf("ValidateRunEvent() error = %v", err)
+		}
+
+		if event.Run.ID != CanonicalRunID("airflow-run-id") {
+			t.Errorf("run.runId = %q, want canonical UUID", event.Run.ID)
+		}
+
+		parent, root := parentRunIDs(event)
+
+		if parent != CanonicalRunID("parent-run") {
+			t.Errorf("parent runId = %q, want canonical UUID", parent)
+		}
+
+		if root != uuidRunID {
+			t.Errorf("UUID root runId must be unchanged, got %q", root)
+		}
+
+		// A child event referencing the parent by its original ID links to the same stored run
+		parentEvent := newRunIDTestEvent("parent-run", uuidRunID, uuidRunID)
+		if err := validator.ValidateRunEvent(parentEvent); err != nil {
+			t.Fatalf("ValidateRunEvent() error = %v", err)
+		}
+
+		if parentEvent.Run.ID != parent {
+			t.Errorf("parent run stored as %q, child references %q", parentEvent.Run.ID, parent)
+		}
+	})
+}
@@ -5,7 +5,14 @@
 // implementations (PostgreSQL, in-memory, etc.) live in the internal/storage package.
 package ingestion
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrBatchRolledBack is the error of the events of an atomic batch (see Store.StoreEventsAtomic)
+// that were not stored only because another event of the batch failed.
+var ErrBatchRolledBack = errors.New("not stored: atomic batch rolled back")
 
 // Store defines the interface for OpenLineage event persistence.
 //
@@ -71,6 +78,16 @@ type Store interface {
 	//   // Return 207 if partial success, 200 if all success, 422 if all failed
 	StoreEvents(ctx context.Context, events []*RunEvent) ([]*EventStoreResult, error)
 
+	// StoreEventsAtomic stores multiple events in a single transaction: all of them or none.
+	//
+	// For batches that represent one logical transaction, where partial success would leave
+	// inconsistent lineage. Duplicates (idempotency hits) count as success, like in StoreEvents.
+	// If any event fails, the whole batch is rolled back: the failed event's result carries its
+	// error and every other result carries ErrBatchRolledBack.
+	//
+	// Returns an error only for catastrophic failures, like StoreEvents.
+	StoreEventsAtomic(ctx context.Context, events []*RunEvent) ([]*EventStoreResult, error)
+
 	// HealthCheck verifies the storage backend is healthy and ready to serve requests.
 	//
 	// This is used by:
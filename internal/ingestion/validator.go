@@ -2,12 +2,27 @@
 package ingestion
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 )
 
+// Default facet limits applied by NewValidator.
+// These protect the store from pathological payloads (multi-megabyte or deeply nested facets)
+// while leaving real-world facets from dbt, Airflow, and Great Expectations untouched.
+const (
+	DefaultMaxFacetSize  = 256 * 1024 // 256KB of serialized JSON per facet map
+	DefaultMaxFacetDepth = 32         // Maximum nesting depth of objects/arrays per facet map
+)
+
+// DefaultMaxDatasetsPerEvent is the default limit on inputs and outputs combined per event.
+// Every dataset becomes a lineage edge, so an event listing a partitioned table file by file
+// (tens of thousands of inputs) would otherwise stall the store.
+const DefaultMaxDatasetsPerEvent = 1000
+
 // Sentinel errors for validation failures.
 var (
 	ErrNilEvent                = errors.New("event cannot be nil")
@@ -22,6 +37,9 @@ var (
 	ErrNilDataset              = errors.New("dataset cannot be nil")
 	ErrDatasetMissingNamespace = errors.New("dataset.namespace is required")
 	ErrDatasetMissingName      = errors.New("dataset.name is required")
+	ErrFacetTooLarge           = errors.New("facets exceed maximum size")
+	ErrFacetTooDeep            = errors.New("facets exceed maximum nesting depth")
+	ErrTooManyDatasets         = errors.New("event exceeds maximum datasets")
 )
 
 // openLineageSchemaURLPattern is a pre-compiled regex for validating OpenLineage schema URLs.
@@ -39,18 +57,109 @@ var openLineageSchemaURLPattern = regexp.MustCompile(`^https://openlineage\.io/s
 // rather than formal JSON schema validation due to OpenLineage schema complexity.
 //
 // Performance: ~5µs per event validation (232K events/sec throughput).
-type Validator struct{}
+// Events carrying facets additionally pay for one JSON encoding per facet map (size limit).
+type Validator struct {
+	maxFacetSize  int
+	maxFacetDepth int
+	maxDatasets   int // Inputs and outputs combined
+	runIDMode     RunIDMode
+
+	maxEventTimeSkew time.Duration    // 0 = any eventTime accepted
+	now              func() time.Time // Server clock for the skew check; nil = time.Now
+
+	outputsCheck          OutputsCheckMode
+	outputsCheckOverrides map[string]OutputsCheckMode
+
+	datasetRoles    DatasetRolesMode
+	inPlaceDatasets map[string]bool // Dataset URNs exempt from the dataset roles check
+
+	datasetScheme    DatasetSchemeMode
+	supportedSchemes map[string]bool // Lowercased dataset namespace schemes accepted by the scheme check
+
+	defaultNamespace  string            // Namespace for datasets without one ("" = reject)
+	defaultNamespaces map[string]string // Per producer URL prefix, overrides defaultNamespace
+}
+
+// ValidatorOption configures optional Validator behavior.
+type ValidatorOption func(*Validator)
+
+// WithMaxFacetSize sets the maximum serialized JSON size (in bytes) of a single facet map
+// (run facets, job facets, or one dataset's facets/inputFacets/outputFacets).
+// Non-positive values are ignored and the default (DefaultMaxFacetSize) is kept.
+func WithMaxFacetSize(bytes int) ValidatorOption {
+	return func(v *Validator) {
+		if bytes > 0 {
+			v.maxFacetSize = bytes
+		}
+	}
+}
+
+// WithMaxFacetDepth sets the maximum nesting depth of a single facet map.
+// The facet map itself is depth 1; each nested object or array adds one level.
+// Non-positive values are ignored and the default (DefaultMaxFacetDepth) is kept.
+func WithMaxFacetDepth(depth int) ValidatorOption {
+	return func(v *Validator) {
+		if depth > 0 {
+			v.maxFacetDepth = depth
+		}
+	}
+}
+
+// WithMaxDatasetsPerEvent sets the maximum number of inputs and outputs combined per event.
+// Non-positive values are ignored and the default (DefaultMaxDatasetsPerEvent) is kept.
+func WithMaxDatasetsPerEvent(n int) ValidatorOption {
+	return func(v *Validator) {
+		if n > 0 {
+			v.maxDatasets = n
+		}
+	}
+}
+
+// WithRunIDMode sets how non-UUID run IDs are handled (see RunIDMode).
+// Empty values are ignored and the default (RunIDModePermissive) is kept.
+func WithRunIDMode(mode RunIDMode) ValidatorOption {
+	return func(v *Validator) {
+		if mode != "" {
+			v.runIDMode = mode
+		}
+	}
+}
 
 // NewValidator creates a new Validator instance.
-func NewValidator() *Validator {
-	return &Validator{}
+// Facet limits default to DefaultMaxFacetSize and DefaultMaxFacetDepth; the dataset limit to
+// DefaultMaxDatasetsPerEvent; run IDs default to RunIDModePermissive; the COMPLETE outputs
+// check defaults to OutputsCheckOff; the dataset roles check to DatasetRolesWarn; the dataset
+// scheme check to DatasetSchemeOff; the event time skew check is off (see WithMaxEventTimeSkew).
+//
+// Example:
+//
+//	validator := ingestion.NewValidator(
+//	    ingestion.WithMaxFacetSize(512 * 1024),
+//	    ingestion.WithMaxFacetDepth(16),
+//	    ingestion.WithRunIDMode(ingestion.RunIDModeStrict))
+func NewValidator(opts ...ValidatorOption) *Validator {
+	v := &Validator{
+		maxFacetSize:  DefaultMaxFacetSize,
+		maxFacetDepth: DefaultMaxFacetDepth,
+		maxDatasets:   DefaultMaxDatasetsPerEvent,
+		runIDMode:     RunIDModePermissive,
+		outputsCheck:  OutputsCheckOff,
+		datasetRoles:  DatasetRolesWarn,
+		datasetScheme: DatasetSchemeOff,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
 }
 
 // ValidateBaseEvent validates that a RunEvent contains all required OpenLineage fields in the BaseEvent as
 // per OpenLineage v2 spec.
 //
 // Required fields (per OpenLineage v2 spec):
-//   - eventTime: Must not be zero value
+//   - eventTime: Must not be zero value (and within the allowed skew, see WithMaxEventTimeSkew)
 //   - producer: Must not be empty
 //   - schemaURL: Must not be empty
 //
@@ -74,6 +183,10 @@ func (v *Validator) ValidateBaseEvent(event *RunEvent) error {
 		return ErrMissingEventTime
 	}
 
+	if err := v.validateEventTimeSkew(event); err != nil {
+		return err
+	}
+
 	// Validate producer (required)
 	if event.Producer == "" {
 		return ErrMissingProducer
@@ -100,15 +213,35 @@ func (v *Validator) ValidateBaseEvent(event *RunEvent) error {
 //   - eventTime: Must not be zero value
 //   - eventType: Must be valid OpenLineage event type (START, RUNNING, COMPLETE, FAIL, ABORT, OTHER)
 //   - producer: Must not be empty
-//   - run.runId: Must not be empty
+//   - run.runId: Must not be empty (and must be a UUID in RunIDModeStrict)
 //   - job.namespace: Must not be empty
 //   - job.name: Must not be empty
+//   - inputs/outputs: Every dataset needs a namespace and a name (see ValidateDataset)
 //
 // Optional fields:
 //   - inputs: May be empty or nil (especially for START/OTHER events)
 //   - outputs: May be empty or nil
 //   - facets: May be nil or contain unknown facets (extensibility)
 //
+// Events with more inputs and outputs combined than WithMaxDatasetsPerEvent allows are rejected
+// with ErrTooManyDatasets.
+//
+// Facet limits (see WithMaxFacetSize, WithMaxFacetDepth) apply to run facets, job facets,
+// and every input/output dataset facet map.
+//
+// Datasets without a namespace get the configured default namespace in place (see
+// WithDefaultDatasetNamespace) and are rejected with ErrDatasetMissingNamespace when none applies.
+//
+// In OutputsCheckReject (see WithCompleteOutputsCheck), COMPLETE events without outputs are
+// rejected with ErrCompleteWithoutOutputs. In DatasetRolesReject (see WithDatasetRolesCheck),
+// events listing a dataset as both an input and an output are rejected with
+// ErrDatasetInputAndOutput. In DatasetSchemeReject (see WithDatasetSchemeCheck), events with an
+// input or output namespace whose scheme is not supported are rejected with
+// ErrUnsupportedDatasetScheme.
+//
+// In RunIDModeCanonicalize, non-UUID run IDs (run.runId and ParentRunFacet run IDs) are rewritten
+// in place to their UUID v5 (see CanonicalRunID), so every transport stores the same ID.
+//
 // Returns nil if valid, error with descriptive message if validation fails.
 func (v *Validator) ValidateRunEvent(event *RunEvent) error {
 	// Validate the required fields in the base event specified in OpenLineage v2 spec
@@ -121,6 +254,10 @@ func (v *Validator) ValidateRunEvent(event *RunEvent) error {
 		return ErrMissingRunID
 	}
 
+	if err := v.validateRunIDs(event); err != nil {
+		return err
+	}
+
 	// Validate job.namespace (required)
 	if event.Job.Namespace == "" {
 		return ErrMissingJobNamespace
@@ -131,9 +268,133 @@ func (v *Validator) ValidateRunEvent(event *RunEvent) error {
 		return ErrMissingJobName
 	}
 
+	if n := len(event.Inputs) + len(event.Outputs); n > v.maxDatasets {
+		return fmt.Errorf("%w: %d inputs and outputs (max %d)", ErrTooManyDatasets, n, v.maxDatasets)
+	}
+
+	if err := v.validateDatasets(event); err != nil {
+		return err
+	}
+
+	if err := v.validateDatasetSchemes(event); err != nil {
+		return err
+	}
+
+	if err := v.validateOutputs(event); err != nil {
+		return err
+	}
+
+	if err := v.validateDatasetRoles(event); err != nil {
+		return err
+	}
+
+	return v.validateEventFacets(event)
+}
+
+// validateEventFacets applies facet size and depth limits to every facet map in the event.
+func (v *Validator) validateEventFacets(event *RunEvent) error {
+	if err := v.ValidateFacets("run.facets", event.Run.Facets); err != nil {
+		return err
+	}
+
+	if err := v.ValidateFacets("job.facets", event.Job.Facets); err != nil {
+		return err
+	}
+
+	for i := range event.Inputs {
+		if err := v.validateDatasetFacets(fmt.Sprintf("inputs[%d]", i), &event.Inputs[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range event.Outputs {
+		if err := v.validateDatasetFacets(fmt.Sprintf("outputs[%d]", i), &event.Outputs[i]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// validateDatasetFacets applies facet limits to a dataset's facets, inputFacets, and outputFacets.
+func (v *Validator) validateDatasetFacets(path string, dataset *Dataset) error {
+	if err := v.ValidateFacets(path+".facets", dataset.Facets); err != nil {
+		return err
+	}
+
+	if err := v.ValidateFacets(path+".inputFacets", dataset.InputFacets); err != nil {
+		return err
+	}
+
+	return v.ValidateFacets(path+".outputFacets", dataset.OutputFacets)
+}
+
+// ValidateFacets checks a single facet map against the configured size and depth limits.
+// The path identifies the facet map in error messages (e.g., "run.facets", "outputs[0].facets").
+//
+// Depth is checked first because it is cheap and bounds the cost of the size check.
+// Empty or nil facet maps are always valid.
+//
+// Returns ErrFacetTooDeep or ErrFacetTooLarge (wrapped with details) when a limit is exceeded.
+func (v *Validator) ValidateFacets(path string, facets Facets) error {
+	if len(facets) == 0 {
+		return nil
+	}
+
+	if depth := facetDepth(facets, 1, v.maxFacetDepth); depth > v.maxFacetDepth {
+		return fmt.Errorf("%w: %s exceeds depth %d", ErrFacetTooDeep, path, v.maxFacetDepth)
+	}
+
+	encoded, err := json.Marshal(facets)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	if len(encoded) > v.maxFacetSize {
+		return fmt.Errorf("%w: %s is %d bytes (max %d)", ErrFacetTooLarge, path, len(encoded), v.maxFacetSize)
+	}
+
+	return nil
+}
+
+// facetDepth returns the nesting depth of a decoded JSON value, where the given value
+// sits at level current. Traversal stops as soon as limit is exceeded so that
+// pathologically deep payloads are rejected without walking them entirely.
+func facetDepth(value interface{}, current, limit int) int {
+	if current > limit {
+		return current
+	}
+
+	deepest := current
+
+	switch typed := value.(type) {
+	case Facets:
+		for _, child := range typed {
+			deepest = max(deepest, childDepth(child, current, limit))
+		}
+	case map[string]interface{}:
+		for _, child := range typed {
+			deepest = max(deepest, childDepth(child, current, limit))
+		}
+	case []interface{}:
+		for _, child := range typed {
+			deepest = max(deepest, childDepth(child, current, limit))
+		}
+	}
+
+	return deepest
+}
+
+// childDepth returns the depth contributed by a child value: containers add a level, scalars don't.
+func childDepth(child interface{}, current, limit int) int {
+	switch child.(type) {
+	case Facets, map[string]interface{}, []interface{}:
+		return facetDepth(child, current+1, limit)
+	default:
+		return current
+	}
+}
+
 // ValidateDataset validates that a Dataset contains all required OpenLineage fields.
 //
 // Validation rules:
@@ -2,7 +2,10 @@
 package ingestion
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -1099,3 +1102,245 @@ func TestExtractOpenLineageVersion(t *testing.T) {
 		})
 	}
 }
+
+// ==============================================================================
+// Unit Tests: Facet Limits
+// ==============================================================================
+
+// nestedFacets builds a facet map whose nesting depth is exactly depth
+// (the facet map itself counts as depth 1).
+func nestedFacets(depth int) Facets {
+	var inner interface{} = "leaf"
+
+	for i := 1; i < depth; i++ {
+		inner = map[string]interface{}{"nested": inner}
+	}
+
+	return Facets{"custom": inner}
+}
+
+// facetsOfSize builds a facet map whose JSON encoding is exactly size bytes.
+func facetsOfSize(t *testing.T, size int) Facets {
+	t.Helper()
+
+	// {"p":""} is 8 bytes of overhead around the padding string.
+	const overhead = 8
+
+	facets := Facets{"p": strings.Repeat("x", size-overhead)}
+
+	encoded, err := json.Marshal(facets)
+	if err != nil {
+		t.Fatalf("failed to encode facets: %v", err)
+	}
+
+	if len(encoded) != size {
+		t.Fatalf("facetsOfSize produced %d bytes, want %d", len(encoded), size)
+	}
+
+	return facets
+}
+
+func validEventWithFacets(runFacets Facets) *RunEvent {
+	return &RunEvent{
+		EventTime: time.Now().UTC(),
+		EventType: EventTypeComplete,
+		Producer:  "https://github.com/dbt-labs/dbt-core/tree/1.5.0",
+		SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+		Run: Run{
+			ID:     "550e8400-e29b-41d4-a716-446655440000",
+			Facets: runFacets,
+		},
+		Job: Job{
+			Namespace: "dbt://analytics",
+			Name:      "transform_orders",
+		},
+	}
+}
+
+func TestNewValidator_DefaultFacetLimits(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	validator := NewValidator()
+
+	if validator.maxFacetSize != DefaultMaxFacetSize {
+		t.Errorf("maxFacetSize = %d, want %d", validator.maxFacetSize, DefaultMaxFacetSize)
+	}
+
+	if validator.maxFacetDepth != DefaultMaxFacetDepth {
+		t.Errorf("maxFacetDepth = %d, want %d", validator.maxFacetDepth, DefaultMaxFacetDepth)
+	}
+
+	// Non-positive overrides keep the defaults
+	validator = NewValidator(WithMaxFacetSize(0), WithMaxFacetDepth(-1))
+
+	if validator.maxFacetSize != DefaultMaxFacetSize || validator.maxFacetDepth != DefaultMaxFacetDepth {
+		t.Errorf("non-positive options changed limits: size=%d depth=%d",
+			validator.maxFacetSize, validator.maxFacetDepth)
+	}
+}
+
+func TestValidateRunEvent_FacetDepthBoundary(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	const maxDepth = 5
+
+	validator := NewValidator(WithMaxFacetDepth(maxDepth))
+
+	if err := validator.ValidateRunEvent(validEventWithFacets(nestedFacets(maxDepth))); err != nil {
+		t.Errorf("ValidateRunEvent() rejected facets at max depth: %v", err)
+	}
+
+	err := validator.ValidateRunEvent(validEventWithFacets(nestedFacets(maxDepth + 1)))
+	if !errors.Is(err, ErrFacetTooDeep) {
+		t.Errorf("ValidateRunEvent() error = %v, want ErrFacetTooDeep", err)
+	}
+}
+
+func TestValidateRunEvent_FacetDepthCountsArrays(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	validator := NewValidator(WithMaxFacetDepth(2))
+
+	// Depth 3: facets map → array → object
+	facets := Facets{"list": []interface{}{map[string]interface{}{"k": "v"}}}
+
+	err := validator.ValidateRunEvent(validEventWithFacets(facets))
+	if !errors.Is(err, ErrFacetTooDeep) {
+		t.Errorf("ValidateRunEvent() error = %v, want ErrFacetTooDeep", err)
+	}
+}
+
+func TestValidateRunEvent_FacetSizeBoundary(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	const maxSize = 1024
+
+	validator := NewValidator(WithMaxFacetSize(maxSize))
+
+	if err := validator.ValidateRunEvent(validEventWithFacets(facetsOfSize(t, maxSize))); err != nil {
+		t.Errorf("ValidateRunEvent() rejected facets at max size: %v", err)
+	}
+
+	err := validator.ValidateRunEvent(validEventWithFacets(facetsOfSize(t, maxSize+1)))
+	if !errors.Is(err, ErrFacetTooLarge) {
+		t.Errorf("ValidateRunEvent() error = %v, want ErrFacetTooLarge", err)
+	}
+}
+
+func TestValidateRunEvent_DatasetFacetLimits(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	const maxSize = 512
+
+	validator := NewValidator(WithMaxFacetSize(maxSize))
+
+	tests := []struct {
+		name    string
+		dataset Dataset
+		isInput bool
+	}{
+		{
+			name:    "output dataset facets",
+			dataset: Dataset{Namespace: "postgres://db", Name: "t", Facets: facetsOfSize(t, maxSize+1)},
+		},
+		{
+			name:    "output dataset outputFacets",
+			dataset: Dataset{Namespace: "postgres://db", Name: "t", OutputFacets: facetsOfSize(t, maxSize+1)},
+		},
+		{
+			name:    "input dataset inputFacets",
+			dataset: Dataset{Namespace: "postgres://db", Name: "t", InputFacets: facetsOfSize(t, maxSize+1)},
+			isInput: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := validEventWithFacets(nil)
+			if tt.isInput {
+				event.Inputs = []Dataset{tt.dataset}
+			} else {
+				event.Outputs = []Dataset{tt.dataset}
+			}
+
+			err := validator.ValidateRunEvent(event)
+			if !errors.Is(err, ErrFacetTooLarge) {
+				t.Errorf("ValidateRunEvent() error = %v, want ErrFacetTooLarge", err)
+			}
+		})
+	}
+}
+
+// eventWithDatasets returns a valid event with the given number of inputs and outputs.
+func eventWithDatasets(inputs, outputs int) *RunEvent {
+	const namespace = "postgres://prod:5432"
+
+	event := validEventWithFacets(nil)
+
+	for i := range inputs {
+		event.Inputs = append(event.Inputs, Dataset{Namespace: namespace, Name: fmt.Sprintf("raw.part_%d", i)})
+	}
+
+	for i := range outputs {
+		event.Outputs = append(event.Outputs, Dataset{Namespace: namespace, Name: fmt.Sprintf("mart.part_%d", i)})
+	}
+
+	return event
+}
+
+func TestValidateRunEvent_MaxDatasetsBoundary(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	validator := NewValidator(WithMaxDatasetsPerEvent(10))
+
+	// Inputs and outputs share the limit
+	if err := validator.ValidateRunEvent(eventWithDatasets(6, 4)); err != nil {
+		t.Errorf("ValidateRunEvent() at the limit unexpected error: %v", err)
+	}
+
+	err := validator.ValidateRunEvent(eventWithDatasets(6, 5))
+	if !errors.Is(err, ErrTooManyDatasets) {
+		t.Fatalf("ValidateRunEvent() over the limit error = %v, want ErrTooManyDatasets", err)
+	}
+
+	if err.Error() != "event exceeds maximum datasets: 11 inputs and outputs (max 10)" {
+		t.Errorf("Error does not name the count and limit: %q", err.Error())
+	}
+}
+
+func TestValidateRunEvent_MaxDatasetsWellOver(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	validator := NewValidator()
+	if validator.maxDatasets != DefaultMaxDatasetsPerEvent {
+		t.Errorf("maxDatasets = %d, want %d", validator.maxDatasets, DefaultMaxDatasetsPerEvent)
+	}
+
+	err := validator.ValidateRunEvent(eventWithDatasets(50000, 1))
+	if !errors.Is(err, ErrTooManyDatasets) {
+		t.Fatalf("ValidateRunEvent() error = %v, want ErrTooManyDatasets", err)
+	}
+
+	if !strings.Contains(err.Error(), "50001 inputs and outputs (max 1000)") {
+		t.Errorf("Error does not name the count and limit: %q", err.Error())
+	}
+
+	// Non-positive overrides keep the default
+	if v := NewValidator(WithMaxDatasetsPerEvent(0)); v.maxDatasets != DefaultMaxDatasetsPerEvent {
+		t.Errorf("WithMaxDatasetsPerEvent(0) changed the limit to %d", v.maxDatasets)
+	}
+}
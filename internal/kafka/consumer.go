@@ -28,17 +28,29 @@ type Consumer struct {
 	reader           *kafkago.Reader
 	store            ingestion.Store
 	validator        *ingestion.Validator
+	interceptors     ingestion.Interceptors
 	logger           *slog.Logger
 	wg               sync.WaitGroup
 	messagesConsumed atomic.Int64
 	errorsCount      atomic.Int64
+
+	// pauseMu is held while a message is processed, so Pause returns once the in-flight message is done
+	pauseMu sync.Mutex
+	resumed chan struct{} // Closed by Resume; nil while not paused
 }
 
 // NewConsumer creates a Kafka consumer that reads from the configured topic
-// and stores events via the ingestion pipeline.
+// and stores events via the ingestion pipeline. Valid events pass interceptors
+// (nil = none) before they are stored, like events ingested over HTTP.
 //
 //nolint:mnd
-func NewConsumer(cfg *Config, store ingestion.Store, validator *ingestion.Validator, logger *slog.Logger) *Consumer {
+func NewConsumer(
+	cfg *Config,
+	store ingestion.Store,
+	validator *ingestion.Validator,
+	interceptors ingestion.Interceptors,
+	logger *slog.Logger,
+) *Consumer {
 	reader := kafkago.NewReader(kafkago.ReaderConfig{
 		Brokers:  cfg.Brokers,
 		Topic:    cfg.Topic,
@@ -48,10 +60,11 @@ func NewConsumer(cfg *Config, store ingestion.Store, validator *ingestion.Valida
 	})
 
 	return &Consumer{
-		reader:    reader,
-		store:     store,
-		validator: validator,
-		logger:    logger,
+		reader:       reader,
+		store:        store,
+		validator:    validator,
+		interceptors: interceptors,
+		logger:       logger,
 	}
 }
 
@@ -96,6 +109,33 @@ func (c *Consumer) Close() error {
 	return nil
 }
 
+// Pause stops the consumer from processing messages until Resume, e.g. during maintenance mode.
+// It returns once the message being processed, if any, is stored and committed. Fetched messages
+// are held uncommitted while paused.
+func (c *Consumer) Pause() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	if c.resumed == nil {
+		c.resumed = make(chan struct{})
+
+		c.logger.Info("Kafka consumer paused")
+	}
+}
+
+// Resume continues processing after Pause. A no-op when not paused.
+func (c *Consumer) Resume() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	if c.resumed != nil {
+		close(c.resumed)
+		c.resumed = nil
+
+		c.logger.Info("Kafka consumer resumed")
+	}
+}
+
 // HealthCheck checks Kafka broker connectivity and returns consumer diagnostics.
 // It dials the first configured broker with the provided context timeout.
 // Message and error counts are maintained via local atomic counters — not
@@ -162,15 +202,43 @@ func (c *Consumer) run(ctx context.Context) error {
 			return fmt.Errorf("fetch message: %w", err)
 		}
 
+		if !c.waitWhilePaused(ctx) {
+			c.logger.Info("Kafka consumer stopping (context cancelled)")
+
+			return nil
+		}
+
 		func() {
 			c.wg.Add(1)
 			defer c.wg.Done()
+			defer c.pauseMu.Unlock()
 
 			c.processMessage(ctx, msg)
 		}()
 	}
 }
 
+// waitWhilePaused blocks while the consumer is paused and returns holding pauseMu, or returns
+// false without it once ctx is cancelled.
+func (c *Consumer) waitWhilePaused(ctx context.Context) bool {
+	c.pauseMu.Lock()
+
+	for c.resumed != nil {
+		resumed := c.resumed
+		c.pauseMu.Unlock()
+
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return false
+		}
+
+		c.pauseMu.Lock()
+	}
+
+	return true
+}
+
 // processMessage handles a single Kafka message: detects event type,
 // deserializes RunEvents, validates, and stores. Non-RunEvents and
 // invalid messages are skipped.
@@ -217,6 +285,29 @@ func (c *Consumer) processMessage(ctx context.Context, msg kafkago.Message) {
 		return
 	}
 
+	for _, warning := range c.validator.Warnings(event) {
+		c.logger.Warn("RunEvent accepted with validation warning",
+			slog.Int("partition", msg.Partition),
+			slog.Int64("offset", msg.Offset),
+			slog.String("run_id", event.Run.ID),
+			slog.String("reason", warning.Error()),
+		)
+	}
+
+	// Rejected events are permanent failures, like invalid ones: commit and move on
+	if err := c.interceptors.BeforeStore(ctx, event); err != nil {
+		c.logger.Warn("RunEvent rejected by interceptor",
+			slog.Int("partition", msg.Partition),
+			slog.Int64("offset", msg.Offset),
+			slog.String("run_id", event.Run.ID),
+			slog.String("error", err.Error()),
+		)
+
+		c.commitMessage(ctx, msg)
+
+		return
+	}
+
 	// Store via shared ingestion pipeline
 	stored, duplicate, err := c.store.StoreEvent(ctx, event)
 	if err != nil {
@@ -316,7 +407,7 @@ type rawDataset struct {
 
 // parseRunEvent deserializes a raw JSON Kafka message into an ingestion.RunEvent.
 // Performs the same mapping as the HTTP handler's mapLineageRequest: whitespace
-// trimming, dataset URN normalization, and nil facet initialization.
+// trimming, dataset URN normalization, nil facet initialization, and error facet parsing.
 func parseRunEvent(data []byte) (*ingestion.RunEvent, error) {
 	var raw rawRunEvent
 	if err := json.Unmarshal(data, &raw); err != nil {
@@ -334,6 +425,10 @@ func parseRunEvent(data []byte) (*ingestion.RunEvent, error) {
 		Outputs:   mapDatasets(raw.Outputs),
 	}
 
+	event.Error = ingestion.ParseRunError(event.EventType, event.Run.Facets)
+	event.NominalTime = ingestion.ParseNominalTime(event.Run.Facets)
+	event.ProcessingEngine = ingestion.ParseProcessingEngine(event.Run.Facets)
+
 	return event, nil
 }
 
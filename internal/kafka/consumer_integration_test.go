@@ -303,7 +303,7 @@ func createConsumer(t *testing.T, brokers []string, store *storage.LineageStore)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 	validator := ingestion.NewValidator()
 
-	consumer := correlatorKafka.NewConsumer(cfg, store, validator, logger)
+	consumer := correlatorKafka.NewConsumer(cfg, store, validator, nil, logger)
 
 	return consumer
 }
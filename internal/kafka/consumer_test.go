@@ -1,6 +1,8 @@
 package kafka
 
 import (
+	"context"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -252,3 +254,50 @@ func TestParseRunEvent_NilFacetsNormalized(t *testing.T) {
 	assert.NotNil(t, event.Run.Facets, "Run.Facets should be non-nil")
 	assert.NotNil(t, event.Job.Facets, "Job.Facets should be non-nil")
 }
+
+func TestConsumerPause(t *testing.T) {
+	c := &Consumer{logger: slog.New(slog.DiscardHandler)}
+
+	t.Run("not paused proceeds", func(t *testing.T) {
+		require.True(t, c.waitWhilePaused(context.Background()))
+		c.pauseMu.Unlock()
+	})
+
+	t.Run("paused waits for resume", func(t *testing.T) {
+		c.Pause()
+		c.Pause() // Idempotent
+
+		proceeded := make(chan bool, 1)
+
+		go func() {
+			proceeded <- c.waitWhilePaused(context.Background())
+		}()
+
+		select {
+		case <-proceeded:
+			t.Fatal("paused consumer must not process messages")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		c.Resume()
+		c.Resume() // Idempotent
+
+		select {
+		case ok := <-proceeded:
+			require.True(t, ok)
+			c.pauseMu.Unlock()
+		case <-time.After(time.Second):
+			t.Fatal("resumed consumer must process messages")
+		}
+	})
+
+	t.Run("cancellation ends the wait", func(t *testing.T) {
+		c.Pause()
+		defer c.Resume()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.False(t, c.waitWhilePaused(ctx))
+	})
+}
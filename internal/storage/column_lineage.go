@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/correlator-io/correlator/internal/correlation"
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// createColumnLineageEdges stores the columnLineage facet of an output dataset as one row per
+// (output column, input column). Datasets without the facet, or with no usable entries, are a
+// no-op. Re-delivered events hit the unique key and insert nothing.
+func (s *LineageStore) createColumnLineageEdges(
+	ctx context.Context,
+	tx *sql.Tx,
+	runID string,
+	dataset *ingestion.Dataset,
+) error {
+	edges := ingestion.ParseColumnLineage(dataset.Facets)
+	if len(edges) == 0 {
+		return nil
+	}
+
+	outputColumns := make([]string, len(edges))
+	inputURNs := make([]string, len(edges))
+	inputColumns := make([]string, len(edges))
+	types := make([]string, len(edges))
+	subtypes := make([]string, len(edges))
+
+	for i, edge := range edges {
+		outputColumns[i] = edge.OutputColumn
+		inputURNs[i] = edge.InputDatasetURN
+		inputColumns[i] = edge.InputColumn
+		types[i] = edge.TransformationType
+		subtypes[i] = edge.TransformationSubtype
+	}
+
+	// One round trip per dataset: a wide model can report thousands of column edges
+	query := `
+		INSERT INTO column_lineage_edges (
+			run_id, output_dataset_urn, output_column,
+			input_dataset_urn, input_column, transformation_type, transformation_subtype
+		)
+		SELECT $1, $2, e.output_column, e.input_urn, e.input_column,
+			NULLIF(e.type, ''), NULLIF(e.subtype, '')
+		FROM unnest($3::text[], $4::text[], $5::text[], $6::text[], $7::text[])
+			AS e(output_column, input_urn, input_column, type, subtype)
+		ON CONFLICT (run_id, output_dataset_urn, output_column, input_dataset_urn, input_column) DO NOTHING
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		runID, dataset.URN(),
+		pq.Array(outputColumns), pq.Array(inputURNs), pq.Array(inputColumns),
+		pq.Array(types), pq.Array(subtypes),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert column lineage edges: %w", err)
+	}
+
+	return nil
+}
+
+// QueryColumnLineage implements correlation.Store.
+// Returns the column edges runID reported for datasetURN, plus the dataset's schema columns
+// that have none.
+func (s *LineageStore) QueryColumnLineage(
+	ctx context.Context,
+	datasetURN string,
+	runID string,
+) (*correlation.ColumnLineage, error) {
+	start := time.Now()
+
+	edges, err := s.queryColumnLineageEdges(ctx, datasetURN, runID)
+	if err != nil {
+		s.logger.Error("Failed to query column lineage",
+			slog.Any("error", err),
+			slog.String("dataset_urn", datasetURN),
+			slog.String("run_id", runID))
+
+		return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	schemaColumns, err := s.querySchemaColumns(ctx, datasetURN)
+	if err != nil {
+		s.logger.Error("Failed to query dataset schema columns",
+			slog.Any("error", err),
+			slog.String("dataset_urn", datasetURN))
+
+		return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	mapped := make(map[string]bool, len(edges))
+	for _, edge := range edges {
+		mapped[edge.OutputColumn] = true
+	}
+
+	lineage := &correlation.ColumnLineage{Edges: edges}
+
+	for _, column := range schemaColumns {
+		if !mapped[column] {
+			lineage.UnmappedColumns = append(lineage.UnmappedColumns, column)
+		}
+	}
+
+	s.logger.Debug("Queried column lineage",
+		slog.Duration("duration", time.Since(start)),
+		slog.String("dataset_urn", datasetURN),
+		slog.Int("edge_count", len(lineage.Edges)),
+		slog.Int("unmapped_count", len(lineage.UnmappedColumns)))
+
+	return lineage, nil
+}
+
+// queryColumnLineageEdges returns the stored column edges of one output dataset and run.
+func (s *LineageStore) queryColumnLineageEdges(
+	ctx context.Context,
+	datasetURN string,
+	runID string,
+) ([]correlation.ColumnLineageEdge, error) {
+	query := `
+		SELECT output_column, input_dataset_urn, input_column,
+			COALESCE(transformation_type, ''), COALESCE(transformation_subtype, '')
+		FROM column_lineage_edges
+		WHERE output_dataset_urn = $1 AND run_id = $2
+		ORDER BY output_column, input_dataset_urn, input_column
+	`
+
+	rows, err := s.conn.QueryContext(ctx, query, datasetURN, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var edges []correlation.ColumnLineageEdge
+
+	for rows.Next() {
+		var e correlation.ColumnLineageEdge
+
+		err := rows.Scan(&e.OutputColumn, &e.InputDatasetURN, &e.InputColumn,
+			&e.TransformationType, &e.TransformationSubtype)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		edges = append(edges, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return edges, nil
+}
+
+// querySchemaColumns returns the column names of a dataset's schema facet, in schema order.
+// Returns nil when the dataset has no schema facet or its fields are not a list.
+func (s *LineageStore) querySchemaColumns(ctx context.Context, datasetURN string) ([]string, error) {
+	query := `
+		SELECT f.field->>'name'
+		FROM datasets d,
+			jsonb_array_elements(
+				CASE WHEN jsonb_typeof(d.facets->'schema'->'fields') = 'array'
+					THEN d.facets->'schema'->'fields'
+					ELSE '[]'::jsonb
+				END
+			) WITH ORDINALITY AS f(field, position)
+		WHERE d.dataset_urn = $1
+		  AND jsonb_typeof(f.field) = 'object'
+		  AND COALESCE(f.field->>'name', '') != ''
+		ORDER BY f.position
+	`
+
+	rows, err := s.conn.QueryContext(ctx, query, datasetURN)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var columns []string
+
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/correlation"
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestColumnLineage_StoreAndQuery verifies that the columnLineage facet of an output dataset is
+// stored as column edges, that re-delivery adds nothing, and that schema columns without
+// lineage are reported as unmapped.
+func TestColumnLineage_StoreAndQuery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	start := createTestEvent("column-lineage", ingestion.EventTypeStart, 1, 1)
+	inputURN := start.Inputs[0].URN()
+	outputURN := start.Outputs[0].URN()
+
+	inputField := func(field, transformation string) map[string]interface{} {
+		return map[string]interface{}{
+			"namespace": start.Inputs[0].Namespace,
+			"name":      start.Inputs[0].Name,
+			"field":     field,
+			"transformations": []interface{}{
+				map[string]interface{}{"type": "DIRECT", "subtype": transformation},
+			},
+		}
+	}
+
+	start.Outputs[0].Facets = ingestion.Facets{
+		"schema": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"name": "order_id", "type": "INTEGER"},
+				map[string]interface{}{"name": "total", "type": "NUMERIC"},
+				map[string]interface{}{"name": "loaded_at", "type": "TIMESTAMP"},
+			},
+		},
+		ingestion.ColumnLineageFacet: map[string]interface{}{
+			"fields": map[string]interface{}{
+				"order_id": map[string]interface{}{
+					"inputFields": []interface{}{inputField("id", "IDENTITY")},
+				},
+				"total": map[string]interface{}{
+					"inputFields": []interface{}{
+						inputField("price", "TRANSFORMATION"),
+						inputField("quantity", "TRANSFORMATION"),
+					},
+				},
+				"broken": "not an object",
+			},
+		},
+	}
+
+	_, _, err := store.StoreEvent(ctx, start)
+	require.NoError(t, err)
+
+	// COMPLETE re-reports the same facet
+	complete := createTestEvent("column-lineage", ingestion.EventTypeComplete, 1, 1)
+	complete.Outputs = start.Outputs
+
+	_, _, err = store.StoreEvent(ctx, complete)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, countRows(ctx, t, store,
+		`SELECT COUNT(*) FROM column_lineage_edges WHERE run_id = $1`, start.Run.ID))
+
+	lineage, err := store.QueryColumnLineage(ctx, outputURN, start.Run.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []correlation.ColumnLineageEdge{
+		{OutputColumn: "order_id", InputDatasetURN: inputURN, InputColumn: "id",
+			TransformationType: "DIRECT", TransformationSubtype: "IDENTITY"},
+		{OutputColumn: "total", InputDatasetURN: inputURN, InputColumn: "price",
+			TransformationType: "DIRECT", TransformationSubtype: "TRANSFORMATION"},
+		{OutputColumn: "total", InputDatasetURN: inputURN, InputColumn: "quantity",
+			TransformationType: "DIRECT", TransformationSubtype: "TRANSFORMATION"},
+	}, lineage.Edges)
+	assert.Equal(t, []string{"loaded_at"}, lineage.UnmappedColumns)
+
+	t.Run("NoColumnLineage", func(t *testing.T) {
+		lineage, err := store.QueryColumnLineage(ctx, inputURN, start.Run.ID)
+		require.NoError(t, err)
+		assert.Empty(t, lineage.Edges)
+		assert.Empty(t, lineage.UnmappedColumns)
+	})
+
+	t.Run("DeletedWithJobRun", func(t *testing.T) {
+		_, err := store.conn.ExecContext(ctx, `DELETE FROM job_runs WHERE run_id = $1`, start.Run.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM column_lineage_edges WHERE run_id = $1`, start.Run.ID))
+	})
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -15,11 +16,30 @@ const (
 	defaultConnMaxIdleTime  = 10 * time.Minute
 	defaultCleanupInterval  = 1 * time.Hour   // Default cleanup interval for idempotency table
 	defaultViewRefreshDelay = 2 * time.Second // Default debounce delay for post-ingestion view refresh
+	defaultPoolMinConns     = 5
+	defaultPoolInterval     = 30 * time.Second
+	defaultStaleRunInterval = 5 * time.Minute
+	defaultStaleRunLimit    = 100
 )
 
 var (
 	// ErrDatabaseURLEmpty is returned when the database url is an empty string.
 	ErrDatabaseURLEmpty = errors.New("database URL cannot be empty")
+
+	// ErrInvalidAdaptivePool is returned when adaptive pool sizing is enabled with bounds outside
+	// 1 <= min <= DATABASE_MAX_OPEN_CONNS or a non-positive interval.
+	ErrInvalidAdaptivePool = errors.New("invalid adaptive pool configuration")
+
+	// ErrInvalidStaleRunSweep is returned when the stale run sweeper is enabled with a non-positive
+	// interval or limit.
+	ErrInvalidStaleRunSweep = errors.New("invalid stale run sweep configuration")
+
+	// ErrInvalidOrphanReconcile is returned when the orphan reconciliation interval is negative.
+	ErrInvalidOrphanReconcile = errors.New("orphan reconcile interval must be zero (disabled) or positive")
+
+	// ErrInvalidWriteBuffer is returned when the write buffer size is negative, or the buffer is
+	// enabled with a non-positive flush interval.
+	ErrInvalidWriteBuffer = errors.New("invalid write buffer configuration")
 )
 
 // Config holds PostgreSQL connection configuration with production-ready defaults.
@@ -31,6 +51,37 @@ type Config struct {
 	ConnMaxIdleTime  time.Duration // Maximum idle time for connections
 	CleanupInterval  time.Duration // Cleanup interval for idempotency table (TTL cleanup)
 	ViewRefreshDelay time.Duration // Debounce delay for post-ingestion materialized view refresh
+	FacetAudit       bool          // Record dataset facet merge history (off by default: extra writes)
+	AssertionFacets  bool          // Derive test results from assertion facets on lineage events
+	JobRunNotify     bool          // NOTIFY job_run_changes for every stored event (off by default: serializes commits)
+	MigrationTable   string        // Migration tracking table checked by the deep readiness probe
+
+	// Schema puts all of correlator's tables in this PostgreSQL schema by setting the search_path
+	// of every connection (empty = server default, usually public). Several correlator
+	// deployments can share one database with a schema each; the migrator creates the schema.
+	// The search_path continues with public, where the extensions all schemas share live.
+	Schema string
+
+	// Adaptive pool sizing (off by default): MaxOpenConns becomes the upper bound (see PoolTuner)
+	AdaptivePool         bool
+	AdaptivePoolMinConns int           // Lower bound for the adaptive MaxOpenConns
+	AdaptivePoolInterval time.Duration // How often wait counts are sampled
+
+	// Stale run sweeping (off by default): runs without an event for StaleRunAbortAfter are
+	// moved to ABORT (see StaleRunSweeper)
+	StaleRunAbortAfter    time.Duration // Age of the latest event after which a run is aborted (0 = disabled)
+	StaleRunSweepInterval time.Duration // How often stale runs are swept
+	StaleRunSweepLimit    int           // Max runs aborted per sweep
+
+	// OrphanReconcileInterval is how often orphaned test results are matched to late job runs
+	// (see OrphanReconciler); 0 = disabled
+	OrphanReconcileInterval time.Duration
+
+	// Write buffering (off by default): single events are stored together, one transaction per
+	// flush (see WithWriteBuffer)
+	WriteBufferSize          int           // Pending events that trigger a flush (0 = disabled)
+	WriteBufferFlushInterval time.Duration // Max wait of the first pending event before a flush
+	WriteBufferAsync         bool          // Acknowledge events once buffered, before they are stored
 }
 
 // LoadConfig loads PostgreSQL configuration from environment variables with fallback to defaults.
@@ -43,6 +94,26 @@ func LoadConfig() *Config {
 		ConnMaxIdleTime:  config.GetEnvDuration("DATABASE_CONN_MAX_IDLE_TIME", defaultConnMaxIdleTime),
 		CleanupInterval:  config.GetEnvDuration("IDEMPOTENCY_CLEANUP_INTERVAL", defaultCleanupInterval),
 		ViewRefreshDelay: config.GetEnvDuration("CORRELATOR_VIEW_REFRESH_DELAY", defaultViewRefreshDelay),
+		FacetAudit:       config.GetEnvBool("CORRELATOR_FACET_AUDIT_ENABLED", false),
+		AssertionFacets:  config.GetEnvBool("CORRELATOR_ASSERTION_FACET_INGESTION_ENABLED", true),
+		JobRunNotify:     config.GetEnvBool("CORRELATOR_JOB_RUN_NOTIFY_ENABLED", false),
+		MigrationTable:   config.GetEnvStr("MIGRATION_TABLE", DefaultMigrationTable),
+		Schema:           config.GetEnvStr("DATABASE_SCHEMA", ""),
+
+		AdaptivePool:         config.GetEnvBool("DATABASE_ADAPTIVE_POOL_ENABLED", false),
+		AdaptivePoolMinConns: config.GetEnvInt("DATABASE_ADAPTIVE_POOL_MIN_CONNS", defaultPoolMinConns),
+		AdaptivePoolInterval: config.GetEnvDuration("DATABASE_ADAPTIVE_POOL_INTERVAL", defaultPoolInterval),
+
+		StaleRunAbortAfter:    config.GetEnvDuration("CORRELATOR_STALE_RUN_ABORT_AFTER", 0),
+		StaleRunSweepInterval: config.GetEnvDuration("CORRELATOR_STALE_RUN_SWEEP_INTERVAL", defaultStaleRunInterval),
+		StaleRunSweepLimit:    config.GetEnvInt("CORRELATOR_STALE_RUN_SWEEP_LIMIT", defaultStaleRunLimit),
+
+		OrphanReconcileInterval: config.GetEnvDuration("CORRELATOR_ORPHAN_RECONCILE_INTERVAL", 0),
+
+		WriteBufferSize: config.GetEnvInt("CORRELATOR_WRITE_BUFFER_SIZE", 0),
+		WriteBufferFlushInterval: config.GetEnvDuration("CORRELATOR_WRITE_BUFFER_FLUSH_INTERVAL",
+			DefaultWriteBufferFlushInterval),
+		WriteBufferAsync: config.GetEnvBool("CORRELATOR_WRITE_BUFFER_ASYNC", false),
 	}
 }
 
@@ -52,6 +123,35 @@ func (c *Config) Validate() error {
 		return ErrDatabaseURLEmpty
 	}
 
+	if err := validateSchema(c.Schema); err != nil {
+		return err
+	}
+
+	if c.AdaptivePool {
+		if c.AdaptivePoolMinConns < 1 || c.AdaptivePoolMinConns > c.MaxOpenConns {
+			return fmt.Errorf("%w: min conns %d must be between 1 and max open conns %d",
+				ErrInvalidAdaptivePool, c.AdaptivePoolMinConns, c.MaxOpenConns)
+		}
+
+		if c.AdaptivePoolInterval <= 0 {
+			return fmt.Errorf("%w: interval must be positive, got %v", ErrInvalidAdaptivePool, c.AdaptivePoolInterval)
+		}
+	}
+
+	if c.StaleRunAbortAfter > 0 && (c.StaleRunSweepInterval <= 0 || c.StaleRunSweepLimit < 1) {
+		return fmt.Errorf("%w: interval and limit must be positive, got %v and %d",
+			ErrInvalidStaleRunSweep, c.StaleRunSweepInterval, c.StaleRunSweepLimit)
+	}
+
+	if c.OrphanReconcileInterval < 0 {
+		return fmt.Errorf("%w: got %v", ErrInvalidOrphanReconcile, c.OrphanReconcileInterval)
+	}
+
+	if c.WriteBufferSize < 0 || (c.WriteBufferSize > 0 && c.WriteBufferFlushInterval <= 0) {
+		return fmt.Errorf("%w: size must not be negative and flush interval must be positive, got %d and %v",
+			ErrInvalidWriteBuffer, c.WriteBufferSize, c.WriteBufferFlushInterval)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/correlator-io/correlator/internal/correlation"
+)
+
+// QueryCorrelationCandidates implements correlation.Store.
+// Returns every job run incident_correlation_view correlates testResultID to, most recently
+// started first (ties broken by run ID), the order QueryIncidentByID selects by.
+//
+// A run that output the dataset under several URNs resolving to the same canonical URN is
+// returned once, with the test's own URN preferred as OutputURN.
+func (s *LineageStore) QueryCorrelationCandidates(
+	ctx context.Context,
+	testResultID int64,
+) ([]correlation.CorrelationCandidate, error) {
+	candidates, err := s.QueryCorrelationCandidatesBatch(ctx, []int64{testResultID})
+	if err != nil {
+		return nil, err
+	}
+
+	if candidates[testResultID] == nil {
+		return make([]correlation.CorrelationCandidate, 0), nil
+	}
+
+	return candidates[testResultID], nil
+}
+
+// QueryCorrelationCandidatesBatch implements correlation.Store.
+// Each test result's candidates are ordered as in QueryCorrelationCandidates.
+func (s *LineageStore) QueryCorrelationCandidatesBatch(
+	ctx context.Context,
+	testResultIDs []int64,
+) (map[int64][]correlation.CorrelationCandidate, error) {
+	start := time.Now()
+
+	query := `
+		SELECT DISTINCT ON (icv.test_result_id, icv.job_started_at, icv.job_run_id)
+			icv.test_result_id,
+			icv.job_run_id, icv.job_name, icv.job_namespace, icv.job_status,
+			icv.job_started_at, icv.job_completed_at, icv.job_producer_name,
+			le.dataset_urn, tr.dataset_urn
+		FROM incident_correlation_view icv
+		JOIN test_results tr ON tr.id = icv.test_result_id
+		JOIN resolved_datasets rd ON rd.canonical_urn = icv.dataset_urn
+		JOIN lineage_edges le
+			ON le.dataset_urn = rd.raw_urn AND le.run_id = icv.job_run_id AND le.edge_type = 'output'
+		WHERE icv.test_result_id = ANY($1)
+		ORDER BY icv.test_result_id, icv.job_started_at DESC, icv.job_run_id, (le.dataset_urn = tr.dataset_urn) DESC
+	`
+
+	rows, err := s.conn.QueryContext(ctx, query, pq.Array(testResultIDs))
+	if err != nil {
+		s.logger.Error("Failed to query correlation candidates",
+			slog.Any("error", err),
+			slog.Int("test_result_count", len(testResultIDs)))
+
+		return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	candidates := make(map[int64][]correlation.CorrelationCandidate)
+	count := 0
+
+	for rows.Next() {
+		var (
+			testResultID int64
+			candidate    correlation.CorrelationCandidate
+			completedAt  sql.NullTime
+		)
+
+		if err := rows.Scan(
+			&testResultID,
+			&candidate.RunID, &candidate.JobName, &candidate.JobNamespace, &candidate.JobStatus,
+			&candidate.JobStartedAt, &completedAt, &candidate.JobProducerName,
+			&candidate.OutputURN, &candidate.TestDatasetURN,
+		); err != nil {
+			return nil, fmt.Errorf("%w: failed to scan correlation candidate: %w", ErrCorrelationQueryFailed, err)
+		}
+
+		if completedAt.Valid {
+			candidate.JobCompletedAt = &completedAt.Time
+		}
+
+		candidates[testResultID] = append(candidates[testResultID], candidate)
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	s.logger.Debug("Queried correlation candidates",
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("test_result_count", len(testResultIDs)),
+		slog.Int("candidate_count", count))
+
+	return candidates, nil
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+
+	"github.com/correlator-io/correlator/internal/aliasing"
+	"github.com/correlator-io/correlator/internal/config"
+)
+
+// TestQueryCorrelationCandidates verifies that every run that output a failing dataset is a
+// candidate, most recently started first, and that the first candidate is the run
+// QueryIncidentByID reports.
+func TestQueryCorrelationCandidates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := config.SetupTestDatabase(ctx, t)
+
+	t.Cleanup(func() {
+		_ = testDB.Connection.Close()
+		_ = testcontainers.TerminateContainer(testDB.Container)
+	})
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	olderRunID := uuid.New().String()
+	newerRunID := uuid.New().String()
+	geRunID := uuid.New().String()
+
+	// dbt writes the canonical URN, GE tests the same table under its own format
+	dbtDatasetURN := "postgresql://demo/marts.customers"
+	geDatasetURN := "demo_postgres/customers"
+
+	_, err := testDB.Connection.ExecContext(ctx, `
+		INSERT INTO job_runs (
+			run_id, job_name, job_namespace, current_state,
+			event_type, event_time, started_at, completed_at, producer_name
+		)
+		VALUES
+			($1, 'dbt_customers', 'dbt_prod', 'COMPLETE', 'COMPLETE', $2, $3, $2, 'dbt'),
+			($4, 'dbt_customers', 'dbt_prod', 'RUNNING', 'START', $5, $5, NULL, 'dbt'),
+			($6, 'ge_validation', 'validation', 'COMPLETE', 'COMPLETE', $7, $7, $7, 'great_expectations')
+	`, olderRunID, now.Add(-50*time.Minute), now.Add(-60*time.Minute),
+		newerRunID, now.Add(-5*time.Minute),
+		geRunID, now)
+	require.NoError(t, err)
+
+	_, err = testDB.Connection.ExecContext(ctx, `
+		INSERT INTO datasets (dataset_urn, name, namespace)
+		VALUES ($1, 'customers', 'postgresql://demo/marts'), ($2, 'customers', 'demo_postgres')
+	`, dbtDatasetURN, geDatasetURN)
+	require.NoError(t, err)
+
+	_, err = testDB.Connection.ExecContext(ctx, `
+		INSERT INTO lineage_edges (run_id, dataset_urn, edge_type)
+		VALUES ($1, $3, 'output'), ($2, $3, 'output')
+	`, olderRunID, newerRunID, dbtDatasetURN)
+	require.NoError(t, err)
+
+	var testResultID int64
+
+	err = testDB.Connection.QueryRowContext(ctx, `
+		INSERT INTO test_results (
+			test_name, test_type, dataset_urn, run_id, status, message, executed_at, duration_ms
+		)
+		VALUES ('not_null_customers_id', 'not_null', $1, $2, 'failed', 'Found 3 nulls', $3, 100)
+		RETURNING id
+	`, geDatasetURN, geRunID, now).Scan(&testResultID)
+	require.NoError(t, err)
+
+	resolver := aliasing.NewResolver(&aliasing.Config{
+		DatasetPatterns: []aliasing.DatasetPattern{
+			{Pattern: "demo_postgres/{name}", Canonical: "postgresql://demo/marts.{name}"},
+		},
+	})
+
+	store, err := NewLineageStore(&Connection{DB: testDB.Connection}, 1*time.Hour, WithAliasResolver(resolver))
+	require.NoError(t, err)
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	require.NoError(t, store.InitResolvedDatasets(ctx))
+	require.NoError(t, store.refreshViews(ctx))
+
+	candidates, err := store.QueryCorrelationCandidates(ctx, testResultID)
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+
+	assert.Equal(t, newerRunID, candidates[0].RunID, "Most recently started run first")
+	assert.Nil(t, candidates[0].JobCompletedAt)
+	assert.Equal(t, olderRunID, candidates[1].RunID)
+	require.NotNil(t, candidates[1].JobCompletedAt)
+	assert.True(t, now.Add(-50*time.Minute).Equal(*candidates[1].JobCompletedAt))
+
+	for _, candidate := range candidates {
+		assert.Equal(t, dbtDatasetURN, candidate.OutputURN)
+		assert.Equal(t, geDatasetURN, candidate.TestDatasetURN)
+		assert.Equal(t, "dbt", candidate.JobProducerName)
+	}
+
+	incident, err := store.QueryIncidentByID(ctx, testResultID)
+	require.NoError(t, err)
+	require.NotNil(t, incident)
+	assert.Equal(t, candidates[0].RunID, incident.RunID, "The incident reports the first candidate")
+
+	candidates, err = store.QueryCorrelationCandidates(ctx, testResultID+1000)
+	require.NoError(t, err)
+	assert.Empty(t, candidates, "Unknown test results have no candidates")
+
+	batch, err := store.QueryCorrelationCandidatesBatch(ctx, []int64{testResultID, testResultID + 1000})
+	require.NoError(t, err)
+	assert.Len(t, batch, 1, "Only incidents have candidates")
+	assert.Len(t, batch[testResultID], 2)
+}
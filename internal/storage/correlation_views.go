@@ -392,8 +392,8 @@ func buildIncidentCorrelationQuery(
 	// Two-stage CTE:
 	// 1. deduped: Deduplicate by test_result_id (the materialized view can produce
 	//    multiple rows per test result when multiple runs output the same dataset).
-	//    We keep the row whose job_run_id matches the test_result's own run_id,
-	//    falling back to the most recent job run.
+	//    We keep the most recently started job run, ties broken by run ID: the same run
+	//    QueryIncidentByID reports (see correlation.SelectionRuleLatestStart).
 	// 2. ranked: Apply retry grouping via (test_name, dataset_urn, test_root_parent_run_id)
 	//    with ROW_NUMBER() for dedup and window functions for retry context.
 	query := `
@@ -413,7 +413,7 @@ func buildIncidentCorrelationQuery(
 				COALESCE(icv.test_root_parent_run_id::text, '') AS test_root_parent_run_id
 			FROM incident_correlation_view icv
 			LEFT JOIN incident_resolutions ir ON icv.test_result_id = ir.test_result_id` + whereClause + `
-			ORDER BY icv.test_result_id, icv.job_started_at DESC
+			ORDER BY icv.test_result_id, icv.job_started_at DESC, icv.job_run_id
 		),
 		ranked AS (
 			SELECT
@@ -566,7 +566,7 @@ func buildFilterConditions(filter *correlation.IncidentFilter) ([]string, []inte
 //   - Pointer to Incident (nil if not found, no error)
 //   - Error if query fails or context is cancelled
 //
-//nolint:funlen // Long due to scanning 36 columns from the correlation view + resolution JOIN
+//nolint:funlen // Long due to scanning 40 columns from the correlation view + resolution/job_runs JOINs
 func (s *LineageStore) QueryIncidentByID(ctx context.Context, testResultID int64) (*correlation.Incident, error) {
 	start := time.Now()
 
@@ -587,10 +587,14 @@ func (s *LineageStore) QueryIncidentByID(ctx context.Context, testResultID int64
 			ir.resolved_by,
 			ir.resolution_reason,
 			ir.mute_expires_at,
-			ir.updated_at AS resolution_updated_at
+			ir.updated_at AS resolution_updated_at,
+			jr.error_message, jr.error_stack_trace, jr.error_programming_language, jr.error_classification,
+			jr.openlineage_version, jr.nominal_start_time, jr.nominal_end_time, jr.ingested_by_plugin
 		FROM incident_correlation_view icv
 		LEFT JOIN incident_resolutions ir ON icv.test_result_id = ir.test_result_id
+		LEFT JOIN job_runs jr ON jr.run_id = icv.job_run_id
 		WHERE icv.test_result_id = $1
+		ORDER BY icv.job_started_at DESC, icv.job_run_id
 		LIMIT 1
 	`
 
@@ -612,6 +616,14 @@ func (s *LineageStore) QueryIncidentByID(ctx context.Context, testResultID int64
 
 	var testRootParentRunID sql.NullString
 
+	var errMessage, errStackTrace, errLanguage, errClassification sql.NullString
+
+	var openLineageVersion sql.NullString
+
+	var nominalStartTime, nominalEndTime sql.NullTime
+
+	var ingestedBy sql.NullString
+
 	err := row.Scan(
 		&r.TestResultID, &r.TestName, &r.TestType, &r.TestStatus, &r.TestMessage,
 		&r.TestExecutedAt, &r.TestDurationMs, &r.TestProducerName,
@@ -625,6 +637,8 @@ func (s *LineageStore) QueryIncidentByID(ctx context.Context, testResultID int64
 		&rootParentJobStatus, &rootParentJobCompletedAt, &rootParentProducerName,
 		&testRootParentRunID,
 		&resStatus, &resResolvedBy, &resReason, &resMuteExpires, &resUpdatedAt,
+		&errMessage, &errStackTrace, &errLanguage, &errClassification,
+		&openLineageVersion, &nominalStartTime, &nominalEndTime, &ingestedBy,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -683,6 +697,27 @@ func (s *LineageStore) QueryIncidentByID(ctx context.Context, testResultID int64
 		r.ResolvedAt = &resUpdatedAt.Time
 	}
 
+	r.JobOpenLineageVersion = openLineageVersion.String
+
+	if nominalStartTime.Valid {
+		r.JobNominalStartTime = &nominalStartTime.Time
+	}
+
+	if nominalEndTime.Valid {
+		r.JobNominalEndTime = &nominalEndTime.Time
+	}
+
+	r.JobIngestedBy = ingestedBy.String
+
+	if errMessage.Valid {
+		r.JobError = &correlation.JobError{
+			Message:             errMessage.String,
+			StackTrace:          errStackTrace.String,
+			ProgrammingLanguage: errLanguage.String,
+			Classification:      errClassification.String,
+		}
+	}
+
 	s.logger.Info("Queried incident by ID from view",
 		slog.Duration("duration", time.Since(start)),
 		slog.Int64("id", testResultID))
@@ -1624,3 +1659,68 @@ func (s *LineageStore) QueryOtherAttempts(
 
 	return attempts, nil
 }
+
+// QueryDatasetConsumers implements correlation.Store.
+// Returns one hop of downstream lineage (consuming runs and their outputs) for datasetURNs.
+func (s *LineageStore) QueryDatasetConsumers(
+	ctx context.Context,
+	datasetURNs []string,
+) ([]correlation.DatasetConsumer, error) {
+	if len(datasetURNs) == 0 {
+		return nil, nil
+	}
+
+	start := time.Now()
+
+	query := `
+		SELECT
+			le_in.dataset_urn,
+			le_in.run_id::text,
+			COALESCE(jr.job_namespace, ''),
+			jr.job_name,
+			COALESCE(le_out.dataset_urn, '')
+		FROM lineage_edges le_in
+			JOIN job_runs jr ON le_in.run_id = jr.run_id
+			LEFT JOIN lineage_edges le_out ON le_in.run_id = le_out.run_id
+				AND le_out.edge_type = 'output'
+				-- Prevent self-loops
+				AND le_out.dataset_urn != le_in.dataset_urn
+		WHERE le_in.dataset_urn = ANY($1)
+		  AND le_in.edge_type = 'input'
+		ORDER BY le_in.dataset_urn, le_in.run_id, le_out.dataset_urn
+	`
+
+	rows, err := s.conn.QueryContext(ctx, query, pq.Array(datasetURNs))
+	if err != nil {
+		s.logger.Error("Failed to query dataset consumers",
+			slog.Any("error", err),
+			slog.Int("dataset_count", len(datasetURNs)))
+
+		return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var consumers []correlation.DatasetConsumer
+
+	for rows.Next() {
+		var c correlation.DatasetConsumer
+
+		if err := rows.Scan(&c.InputURN, &c.RunID, &c.JobNamespace, &c.JobName, &c.OutputURN); err != nil {
+			return nil, fmt.Errorf("%w: failed to scan row: %w", ErrCorrelationQueryFailed, err)
+		}
+
+		consumers = append(consumers, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: row iteration error: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	s.logger.Debug("Queried dataset consumers",
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("dataset_count", len(datasetURNs)),
+		slog.Int("result_count", len(consumers)))
+
+	return consumers, nil
+}
@@ -314,6 +314,96 @@ func TestQueryUpstreamWithChildren(t *testing.T) {
 	assert.Empty(t, upstream, "Should return empty slice for non-existent job")
 }
 
+// TestQueryDatasetConsumers tests one-hop downstream lookup and, through ImpactAnalysis,
+// that a diamond (raw → stg_a, stg_b → mart) counts the shared dataset once.
+func TestQueryDatasetConsumers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := config.SetupTestDatabase(ctx, t)
+
+	t.Cleanup(func() {
+		_ = testDB.Connection.Close()
+		_ = testcontainers.TerminateContainer(testDB.Container)
+	})
+
+	now := time.Now()
+	runA := uuid.New().String()
+	runB := uuid.New().String()
+	runMart := uuid.New().String()
+	raw := "urn:postgres:warehouse:public.raw_orders"
+	stagedA := "urn:postgres:warehouse:public.stg_orders_a"
+	stagedB := "urn:postgres:warehouse:public.stg_orders_b"
+	mart := "urn:postgres:warehouse:public.fact_orders"
+
+	_, err := testDB.Connection.ExecContext(ctx, `
+		INSERT INTO job_runs (
+		  run_id, job_name, job_namespace, current_state, event_type, event_time, started_at, producer_name
+		)
+		VALUES
+			($1, 'stage_a', 'dbt', 'COMPLETE', 'COMPLETE', $4, $4, 'dbt'),
+			($2, 'stage_b', 'dbt', 'COMPLETE', 'COMPLETE', $4, $4, 'dbt'),
+			($3, 'fact_orders', 'dbt', 'COMPLETE', 'COMPLETE', $4, $4, 'dbt')
+	`, runA, runB, runMart, now)
+	require.NoError(t, err)
+
+	_, err = testDB.Connection.ExecContext(ctx, `
+		INSERT INTO datasets (dataset_urn, name, namespace)
+		VALUES
+			($1, 'raw_orders', 'public'),
+			($2, 'stg_orders_a', 'public'),
+			($3, 'stg_orders_b', 'public'),
+			($4, 'fact_orders', 'public')
+	`, raw, stagedA, stagedB, mart)
+	require.NoError(t, err)
+
+	_, err = testDB.Connection.ExecContext(ctx, `
+		INSERT INTO lineage_edges (run_id, dataset_urn, edge_type)
+		VALUES
+			($1, $4, 'input'), ($1, $5, 'output'),
+			($2, $4, 'input'), ($2, $6, 'output'),
+			($3, $5, 'input'), ($3, $6, 'input'), ($3, $7, 'output')
+	`, runA, runB, runMart, raw, stagedA, stagedB, mart)
+	require.NoError(t, err)
+
+	store, err := NewLineageStore(&Connection{DB: testDB.Connection}, 1*time.Hour)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	consumers, err := store.QueryDatasetConsumers(ctx, []string{raw})
+	require.NoError(t, err)
+	require.Len(t, consumers, 2, "raw_orders is read by stage_a and stage_b")
+
+	outputs := []string{consumers[0].OutputURN, consumers[1].OutputURN}
+	assert.ElementsMatch(t, []string{stagedA, stagedB}, outputs)
+	assert.Equal(t, raw, consumers[0].InputURN)
+	assert.Equal(t, "dbt", consumers[0].JobNamespace)
+
+	consumers, err = store.QueryDatasetConsumers(ctx, []string{stagedA, stagedB})
+	require.NoError(t, err)
+	require.Len(t, consumers, 2, "fact_orders reads both staged datasets")
+	assert.Equal(t, mart, consumers[0].OutputURN)
+	assert.Equal(t, mart, consumers[1].OutputURN)
+
+	consumers, err = store.QueryDatasetConsumers(ctx, []string{mart})
+	require.NoError(t, err)
+	assert.Empty(t, consumers)
+
+	impact, err := correlation.ImpactAnalysis(ctx, store, raw)
+	require.NoError(t, err)
+
+	require.Len(t, impact.Datasets, 3, "fact_orders is counted once")
+	assert.Equal(t, mart, impact.Datasets[2].DatasetURN)
+	assert.Equal(t, 2, impact.Datasets[2].Distance)
+	assert.Len(t, impact.Runs, 3)
+	assert.InDelta(t, 2.5, impact.Severity, 1e-9)
+}
+
 // TestQueryCorrelationHealth_EmptyState tests correlation health with no data.
 func TestQueryCorrelationHealth_EmptyState(t *testing.T) {
 	if testing.Short() {
@@ -2196,3 +2286,88 @@ func TestParentRunFacetOutOfOrder(t *testing.T) {
 	assert.Equal(t, "COMPLETE", incident.ParentJobStatus, "ParentJobStatus should be COMPLETE")
 	assert.NotNil(t, incident.ParentJobCompletedAt, "ParentJobCompletedAt should be populated")
 }
+
+// TestCrossNamespaceCorrelation verifies that correlation bridges job namespaces: a dbt test in
+// dbt://analytics correlates to the Airflow run in airflow://prod that produced the same physical
+// table, even though each tool spells the dataset namespace differently.
+func TestCrossNamespaceCorrelation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := config.SetupTestDatabase(ctx, t)
+
+	t.Cleanup(func() {
+		_ = testDB.Connection.Close()
+		_ = testcontainers.TerminateContainer(testDB.Container)
+	})
+
+	conn := &Connection{DB: testDB.Connection}
+	store, err := NewLineageStore(conn, 1*time.Hour)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	now := time.Now()
+	producingRunID := uuid.New().String()
+	testRunID := uuid.New().String()
+
+	// Airflow (airflow://prod) loads the table, with the port in the dataset namespace
+	produced, _, err := store.StoreEvent(ctx, &ingestion.RunEvent{
+		EventTime: now.Add(-10 * time.Minute),
+		EventType: ingestion.EventTypeComplete,
+		Producer:  "https://github.com/apache/airflow/tree/2.7.0",
+		SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+		Run:       ingestion.Run{ID: producingRunID, Facets: map[string]interface{}{}},
+		Job:       ingestion.Job{Namespace: "airflow://prod", Name: "load_orders", Facets: map[string]interface{}{}},
+		Inputs:    []ingestion.Dataset{},
+		Outputs: []ingestion.Dataset{
+			{Namespace: "postgres://warehouse:5432", Name: "analytics.public.orders", Facets: ingestion.Facets{}},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, produced)
+
+	// dbt (dbt://analytics) tests the same physical table, without the port
+	tested, _, err := store.StoreEvent(ctx, &ingestion.RunEvent{
+		EventTime: now,
+		EventType: ingestion.EventTypeComplete,
+		Producer:  "https://github.com/dbt-labs/dbt-core/tree/1.5.0",
+		SchemaURL: "https://openlineage.io/spec/2-0-2/OpenLineage.json",
+		Run:       ingestion.Run{ID: testRunID, Facets: map[string]interface{}{}},
+		Job:       ingestion.Job{Namespace: "dbt://analytics", Name: "test.orders", Facets: map[string]interface{}{}},
+		Inputs: []ingestion.Dataset{
+			{
+				Namespace: "postgresql://warehouse",
+				Name:      "analytics.public.orders",
+				Facets:    ingestion.Facets{},
+				InputFacets: ingestion.Facets{
+					"dataQualityAssertions": map[string]interface{}{
+						"assertions": []interface{}{
+							map[string]interface{}{"assertion": "not_null_orders_id", "success": false},
+						},
+					},
+				},
+			},
+		},
+		Outputs: []ingestion.Dataset{},
+	})
+	require.NoError(t, err)
+	require.True(t, tested)
+
+	require.NoError(t, store.InitResolvedDatasets(ctx))
+	require.NoError(t, store.refreshViews(ctx))
+
+	result, err := store.QueryIncidents(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Incidents, 1, "The dbt test should correlate to the Airflow run")
+
+	incident := result.Incidents[0]
+	assert.Equal(t, "not_null_orders_id", incident.TestName)
+	assert.Equal(t, producingRunID, incident.RunID)
+	assert.Equal(t, "airflow://prod", incident.JobNamespace)
+	assert.Equal(t, "load_orders", incident.JobName)
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 
 	"github.com/correlator-io/correlator/internal/config"
+	"github.com/correlator-io/correlator/internal/ingestion"
 )
 
 // setupDebounceStore creates a LineageStore with the given debounce delay backed
@@ -263,3 +265,92 @@ func TestWithViewRefreshDelay_Zero(t *testing.T) {
 
 	assert.Equal(t, time.Duration(0), store.refreshDelay)
 }
+
+// recordingSink is an IncidentSink that records enqueued test result IDs.
+type recordingSink struct {
+	mu  sync.Mutex
+	ids []int64
+}
+
+func (s *recordingSink) Enqueue(testResultID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ids = append(s.ids, testResultID)
+
+	return true
+}
+
+func (s *recordingSink) enqueued() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]int64(nil), s.ids...)
+}
+
+// TestFailingTestsHandedOffAfterRefresh verifies that failing test result IDs reach the
+// IncidentSink only after the debounced view refresh, so the worker sees them in the view.
+func TestFailingTestsHandedOffAfterRefresh(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	sink := &recordingSink{}
+	store := setupTestLineageStore(t, WithViewRefreshDelay(200*time.Millisecond), WithIncidentSink(sink))
+
+	store.queueFailingTests([]int64{101, 102})
+	store.notifyDataChanged()
+
+	assert.Empty(t, sink.enqueued(), "IDs must not be handed off before the view refresh")
+
+	require.Eventually(t, func() bool { return len(sink.enqueued()) == 2 }, 2*time.Second, 20*time.Millisecond)
+	assert.Equal(t, []int64{101, 102}, sink.enqueued())
+}
+
+// TestFailingTestsHandedOffImmediatelyWithoutDebounce verifies that IDs are handed off
+// right after commit when debounced refresh is disabled.
+func TestFailingTestsHandedOffImmediatelyWithoutDebounce(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	sink := &recordingSink{}
+	store := setupTestLineageStore(t, WithIncidentSink(sink))
+
+	store.queueFailingTests([]int64{7})
+
+	assert.Equal(t, []int64{7}, sink.enqueued())
+}
+
+// TestFailedRunHandsOffCorrelatedFailingTests verifies that a run failing without assertions of its
+// own hands off the failing test results the view correlates to it, once the view is refreshed.
+func TestFailedRunHandsOffCorrelatedFailingTests(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	sink := &recordingSink{}
+	store := setupTestLineageStore(t, WithViewRefreshDelay(100*time.Millisecond), WithIncidentSink(sink))
+
+	// A failing test on a dataset no run has produced yet: handed off, but not correlated
+	validation := createEventWithAssertions("failed-run-validate", []assertionData{
+		{assertion: "not_null", success: false, column: "order_id"},
+	})
+
+	_, _, err := store.StoreEvent(ctx, validation)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(sink.enqueued()) == 1 }, 2*time.Second, 20*time.Millisecond)
+
+	testResultID := sink.enqueued()[0]
+
+	producer := createTestEvent("failed-run-producer", ingestion.EventTypeFail, 0, 1)
+	producer.Outputs[0].Namespace = validation.Inputs[0].Namespace
+	producer.Outputs[0].Name = validation.Inputs[0].Name
+
+	_, _, err = store.StoreEvent(ctx, producer)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(sink.enqueued()) == 2 }, 2*time.Second, 20*time.Millisecond)
+
+	assert.Equal(t, testResultID, sink.enqueued()[1], "failing test correlated to the failed run")
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidFacetFilter indicates a malformed facet allowlist or denylist.
+var ErrInvalidFacetFilter = errors.New("invalid facet filter")
+
+type (
+	// FacetFilter selects which facets are persisted, independently for run, job, and dataset
+	// facets. The zero value stores every facet.
+	FacetFilter struct {
+		Run     FacetRule
+		Job     FacetRule
+		Dataset FacetRule // Applies to common, input, and output facets of every dataset
+	}
+
+	// FacetRule is an allowlist or a denylist of facet keys. With Allow set, only those keys are
+	// stored; otherwise keys in Deny are dropped. The zero value stores every facet.
+	FacetRule struct {
+		Allow []string
+		Deny  []string
+	}
+)
+
+// WithFacetFilter drops facets that operators don't need before they are persisted, keeping
+// large vendor-specific facets out of the job_runs metadata, datasets facets, and test_results
+// facets JSONB columns. Default: store every facet.
+//
+// Filtering only affects what is stored: facets correlator interprets itself (e.g. the parent
+// run facet and assertion facets) are read from the full event, so dropping them from storage
+// does not change parent linkage or test result extraction.
+//
+// Example:
+//
+//	filter, err := storage.ParseFacetFilter("", "dataset:vendor_stats")
+//	store, err := storage.NewLineageStore(conn, interval,
+//	    storage.WithFacetFilter(filter))
+func WithFacetFilter(filter FacetFilter) LineageStoreOption {
+	return func(s *LineageStore) {
+		s.facetFilter = filter
+	}
+}
+
+// ParseFacetFilter parses comma-separated scope:key lists into a FacetFilter, e.g.
+// allow "run:parent,run:nominalTime" and deny "dataset:vendor_stats". Scope is one of run, job,
+// or dataset. A scope may have an allowlist or a denylist, not both. Empty input stores
+// every facet.
+func ParseFacetFilter(allow, deny string) (FacetFilter, error) {
+	var filter FacetFilter
+
+	rules := map[string]*FacetRule{"run": &filter.Run, "job": &filter.Job, "dataset": &filter.Dataset}
+
+	for _, list := range []struct {
+		keys  string
+		allow bool
+	}{
+		{allow, true},
+		{deny, false},
+	} {
+		for _, entry := range strings.Split(list.keys, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+
+			scope, key, ok := strings.Cut(entry, ":")
+			scope, key = strings.TrimSpace(scope), strings.TrimSpace(key)
+
+			rule, known := rules[scope]
+			if !ok || !known || key == "" {
+				return FacetFilter{}, fmt.Errorf("%w: %q (expected run|job|dataset:facet)",
+					ErrInvalidFacetFilter, entry)
+			}
+
+			if list.allow {
+				rule.Allow = append(rule.Allow, key)
+			} else {
+				rule.Deny = append(rule.Deny, key)
+			}
+		}
+	}
+
+	for _, scope := range []string{"run", "job", "dataset"} {
+		if rule := rules[scope]; len(rule.Allow) > 0 && len(rule.Deny) > 0 {
+			return FacetFilter{}, fmt.Errorf("%w: %s facets have both an allowlist and a denylist",
+				ErrInvalidFacetFilter, scope)
+		}
+	}
+
+	return filter, nil
+}
+
+// apply returns facets without the keys the rule drops. facets itself is returned when the
+// rule keeps everything, so the common case allocates nothing.
+func (r FacetRule) apply(facets map[string]interface{}) map[string]interface{} {
+	if len(facets) == 0 || (len(r.Allow) == 0 && len(r.Deny) == 0) {
+		return facets
+	}
+
+	kept := make(map[string]interface{}, len(facets))
+
+	for key, value := range facets {
+		if len(r.Allow) > 0 && !slices.Contains(r.Allow, key) || slices.Contains(r.Deny, key) {
+			continue
+		}
+
+		kept[key] = value
+	}
+
+	return kept
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestFacetFilter_DropsDenylistedFacets verifies that denylisted dataset and run facets are not
+// persisted while every other facet is.
+func TestFacetFilter_DropsDenylistedFacets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	filter, err := ParseFacetFilter("", "dataset:vendor_stats,run:spark_properties")
+	require.NoError(t, err)
+
+	store := setupTestLineageStore(t, WithFacetFilter(filter))
+
+	event := createTestEvent("facet-filter-1", ingestion.EventTypeComplete, 0, 1)
+	event.Run.Facets = ingestion.Facets{"nominalTime": "n", "spark_properties": "big"}
+	event.Outputs[0].Facets = ingestion.Facets{"schema": "s", "vendor_stats": "big"}
+	event.Outputs[0].OutputFacets = ingestion.Facets{"outputStatistics": "o", "vendor_stats": "big"}
+
+	stored, _, err := store.StoreEvent(ctx, event)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	var datasetFacets string
+
+	err = store.conn.QueryRowContext(ctx,
+		`SELECT facets::text FROM datasets WHERE dataset_urn = $1`, event.Outputs[0].URN()).Scan(&datasetFacets)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schema": "s", "output_outputStatistics": "o"}`, datasetFacets)
+
+	var runFacets string
+
+	err = store.conn.QueryRowContext(ctx,
+		`SELECT (metadata->'run_facets')::text FROM job_runs WHERE run_id = $1`, event.Run.ID).Scan(&runFacets)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"nominalTime": "n"}`, runFacets)
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+func TestParseFacetFilter(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	filter, err := ParseFacetFilter(" run:parent, run:nominalTime ,,job:sql", "dataset:vendor_stats")
+	require.NoError(t, err)
+	assert.Equal(t, FacetRule{Allow: []string{"parent", "nominalTime"}}, filter.Run)
+	assert.Equal(t, FacetRule{Allow: []string{"sql"}}, filter.Job)
+	assert.Equal(t, FacetRule{Deny: []string{"vendor_stats"}}, filter.Dataset)
+
+	filter, err = ParseFacetFilter("", "")
+	require.NoError(t, err)
+	assert.Equal(t, FacetFilter{}, filter)
+
+	for _, tt := range []struct{ allow, deny string }{
+		{"parent", ""},
+		{"", "table:schema"},
+		{"run:", ""},
+		{"run:parent", "run:nominalTime"},
+	} {
+		_, err := ParseFacetFilter(tt.allow, tt.deny)
+		assert.ErrorIs(t, err, ErrInvalidFacetFilter, "allow=%q deny=%q", tt.allow, tt.deny)
+	}
+}
+
+func TestFacetRuleApply(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	facets := map[string]interface{}{"schema": "s", "vendor_stats": "v", "documentation": "d"}
+
+	assert.Equal(t, facets, FacetRule{}.apply(facets))
+	assert.Equal(t,
+		map[string]interface{}{"schema": "s", "documentation": "d"},
+		FacetRule{Deny: []string{"vendor_stats"}}.apply(facets))
+	assert.Equal(t,
+		map[string]interface{}{"schema": "s"},
+		FacetRule{Allow: []string{"schema", "missing"}}.apply(facets))
+	assert.Len(t, facets, 3, "apply must not modify its input")
+}
+
+func TestBuildJobRunMetadata_FacetFilter(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	event := &ingestion.RunEvent{
+		Run: ingestion.Run{ID: "run-1", Facets: ingestion.Facets{"parent": "p", "spark_properties": "big"}},
+		Job: ingestion.Job{Facets: ingestion.Facets{"sql": "select 1", "vendor": "v"}},
+	}
+
+	metadataJSON, err := buildJobRunMetadata(event, FacetFilter{
+		Run: FacetRule{Deny: []string{"spark_properties"}},
+		Job: FacetRule{Allow: []string{"sql"}},
+	})
+	require.NoError(t, err)
+
+	var metadata map[string]interface{}
+	require.NoError(t, json.Unmarshal(metadataJSON, &metadata))
+	assert.Equal(t, map[string]interface{}{"parent": "p"}, metadata["run_facets"])
+	assert.Equal(t, map[string]interface{}{"sql": "select 1"}, metadata["job_facets"])
+	assert.Len(t, event.Run.Facets, 2, "filtering must not modify the event")
+}
@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type (
+	// FacetChange is the before/after value of a single top-level dataset facet.
+	// Before is JSON null when the facet was newly added by the merge.
+	FacetChange struct {
+		Before json.RawMessage `json:"before"`
+		After  json.RawMessage `json:"after"`
+	}
+
+	// FacetHistoryEntry is one audited facet merge for a dataset.
+	// Only facets whose value changed are included in Changes.
+	FacetHistoryEntry struct {
+		ID         int64
+		DatasetURN string
+		RunID      string // Producing run; empty when the change came from a consuming run
+		Changes    map[string]FacetChange
+		CreatedAt  time.Time
+	}
+)
+
+// WithFacetAudit enables dataset facet merge auditing.
+//
+// Dataset facets are merged destructively (newer values override older ones). When enabled,
+// every merge that changes a top-level facet writes a dataset_facet_history row with the
+// before/after values, readable via GetFacetHistory. Off by default: each dataset upsert
+// costs an extra row lock and, on change, an extra insert.
+//
+// Example:
+//
+//	store, err := storage.NewLineageStore(conn, interval,
+//	    storage.WithFacetAudit(true))
+func WithFacetAudit(enabled bool) LineageStoreOption {
+	return func(s *LineageStore) {
+		s.facetAudit = enabled
+	}
+}
+
+// GetFacetHistory returns the audited facet merges for a dataset, newest first.
+// Returns an empty slice when auditing was never enabled or the dataset has no recorded changes.
+func (s *LineageStore) GetFacetHistory(ctx context.Context, datasetURN string) ([]FacetHistoryEntry, error) {
+	query := `
+		SELECT id, dataset_urn, run_id, changes, created_at
+		FROM dataset_facet_history
+		WHERE dataset_urn = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := s.conn.QueryContext(ctx, query, datasetURN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query facet history: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	history := make([]FacetHistoryEntry, 0)
+
+	for rows.Next() {
+		var (
+			entry       FacetHistoryEntry
+			runID       sql.NullString
+			changesJSON []byte
+		)
+
+		if err := rows.Scan(&entry.ID, &entry.DatasetURN, &runID, &changesJSON, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan facet history row: %w", err)
+		}
+
+		if err := json.Unmarshal(changesJSON, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("failed to parse facet history changes: %w", err)
+		}
+
+		entry.RunID = runID.String
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate facet history: %w", err)
+	}
+
+	return history, nil
+}
+
+// lockFacetsForAudit reads the dataset's current facets before a merge, locking the row so the
+// before/after diff is not interleaved with a concurrent merge. Returns nil when auditing is
+// disabled or the dataset does not exist yet.
+func (s *LineageStore) lockFacetsForAudit(
+	ctx context.Context, tx *sql.Tx, datasetURN string,
+) (map[string]json.RawMessage, error) {
+	if !s.facetAudit {
+		return nil, nil //nolint:nilnil // Auditing disabled: nothing to diff against
+	}
+
+	var facetsJSON []byte
+
+	err := tx.QueryRowContext(ctx,
+		`SELECT facets FROM datasets WHERE dataset_urn = $1 FOR UPDATE`, datasetURN,
+	).Scan(&facetsJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil //nolint:nilnil // New dataset: every merged facet is an addition
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset facets for audit: %w", err)
+	}
+
+	before := make(map[string]json.RawMessage)
+
+	if len(facetsJSON) > 0 {
+		if err := json.Unmarshal(facetsJSON, &before); err != nil {
+			return nil, fmt.Errorf("failed to parse dataset facets for audit: %w", err)
+		}
+	}
+
+	return before, nil
+}
+
+// recordFacetChanges writes a dataset_facet_history row for the facets changed by a merge.
+// No-op when auditing is disabled or nothing changed.
+func (s *LineageStore) recordFacetChanges(
+	ctx context.Context,
+	tx *sql.Tx,
+	datasetURN, runID string,
+	before map[string]json.RawMessage,
+	merged map[string]interface{},
+) error {
+	if !s.facetAudit {
+		return nil
+	}
+
+	changes, err := diffFacets(before, merged)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal facet changes: %w", err)
+	}
+
+	var runIDParam interface{}
+	if runID != "" {
+		runIDParam = runID
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dataset_facet_history (dataset_urn, run_id, changes)
+		VALUES ($1, $2, $3)
+	`, datasetURN, runIDParam, changesJSON)
+	if err != nil {
+		return fmt.Errorf("failed to record facet history: %w", err)
+	}
+
+	return nil
+}
+
+// diffFacets returns the top-level facets in merged whose value differs from before.
+// Mirrors the JSONB merge semantics (datasets.facets || new): keys absent from merged are
+// untouched and never reported. Values are compared semantically, so JSONB key ordering and
+// whitespace do not produce spurious changes.
+func diffFacets(
+	before map[string]json.RawMessage, merged map[string]interface{},
+) (map[string]FacetChange, error) {
+	changes := make(map[string]FacetChange)
+
+	for key, value := range merged {
+		after, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal facet %q: %w", key, err)
+		}
+
+		previous, existed := before[key]
+		if existed {
+			same, err := jsonEqual(previous, after)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare facet %q: %w", key, err)
+			}
+
+			if same {
+				continue
+			}
+		}
+
+		change := FacetChange{Before: json.RawMessage("null"), After: after}
+		if existed {
+			change.Before = previous
+		}
+
+		changes[key] = change
+	}
+
+	return changes, nil
+}
+
+// jsonEqual reports whether two JSON documents are semantically equal.
+func jsonEqual(a, b json.RawMessage) (bool, error) {
+	if bytes.Equal(a, b) {
+		return true, nil
+	}
+
+	var va, vb interface{}
+
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(va, vb), nil
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestFacetHistory_RecordsMergeChanges verifies that each facet merge records before/after
+// values for changed facets only, newest first.
+func TestFacetHistory_RecordsMergeChanges(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t, WithFacetAudit(true))
+
+	event1 := createTestEvent("facet-audit-1", ingestion.EventTypeStart, 0, 1)
+	event1.Outputs[0].Facets = ingestion.Facets{
+		"schema": map[string]interface{}{"version": "v1"},
+		"owner":  "alice",
+	}
+
+	_, _, err := store.StoreEvent(ctx, event1)
+	require.NoError(t, err)
+
+	// Same dataset: schema flips to v2, owner unchanged
+	event2 := createTestEvent("facet-audit-2", ingestion.EventTypeComplete, 0, 1)
+	event2.Outputs[0].Namespace = event1.Outputs[0].Namespace
+	event2.Outputs[0].Name = event1.Outputs[0].Name
+	event2.Outputs[0].Facets = ingestion.Facets{
+		"schema": map[string]interface{}{"version": "v2"},
+		"owner":  "alice",
+	}
+
+	_, _, err = store.StoreEvent(ctx, event2)
+	require.NoError(t, err)
+
+	history, err := store.GetFacetHistory(ctx, event1.Outputs[0].URN())
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	latest := history[0]
+	assert.Equal(t, event2.Run.ID, latest.RunID)
+	require.Len(t, latest.Changes, 1, "unchanged owner facet must not be recorded")
+	assert.JSONEq(t, `{"version": "v1"}`, string(latest.Changes["schema"].Before))
+	assert.JSONEq(t, `{"version": "v2"}`, string(latest.Changes["schema"].After))
+
+	initial := history[1]
+	assert.Equal(t, event1.Run.ID, initial.RunID)
+	assert.JSONEq(t, `null`, string(initial.Changes["owner"].Before))
+	assert.JSONEq(t, `"alice"`, string(initial.Changes["owner"].After))
+}
+
+// TestFacetHistory_DisabledByDefault verifies no history is written without WithFacetAudit.
+func TestFacetHistory_DisabledByDefault(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	event := createTestEvent("facet-audit-off", ingestion.EventTypeStart, 0, 1)
+	event.Outputs[0].Facets = ingestion.Facets{"owner": "alice"}
+
+	_, _, err := store.StoreEvent(ctx, event)
+	require.NoError(t, err)
+
+	history, err := store.GetFacetHistory(ctx, event.Outputs[0].URN())
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
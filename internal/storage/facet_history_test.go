@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDiffFacets verifies that only added or changed top-level facets are reported.
+func TestDiffFacets(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	// JSONB output format: spaces after separators, keys in storage order
+	before := map[string]json.RawMessage{
+		"schema": json.RawMessage(`{"fields": [{"name": "id", "type": "int"}], "version": "v1"}`),
+		"owner":  json.RawMessage(`"alice"`),
+		"rows":   json.RawMessage(`1000`),
+	}
+
+	merged := map[string]interface{}{
+		// Same value, different key order and whitespace: not a change
+		"schema": map[string]interface{}{
+			"version": "v1",
+			"fields":  []interface{}{map[string]interface{}{"type": "int", "name": "id"}},
+		},
+		"rows":     float64(2000),
+		"location": "s3://bucket/orders",
+	}
+
+	changes, err := diffFacets(before, merged)
+	require.NoError(t, err)
+
+	assert.Len(t, changes, 2, "unchanged schema and untouched owner must not be reported")
+
+	assert.JSONEq(t, `1000`, string(changes["rows"].Before))
+	assert.JSONEq(t, `2000`, string(changes["rows"].After))
+
+	assert.JSONEq(t, `null`, string(changes["location"].Before), "new facet has null before")
+	assert.JSONEq(t, `"s3://bucket/orders"`, string(changes["location"].After))
+}
+
+// TestDiffFacets_NewDataset verifies every facet is an addition when the dataset did not exist.
+func TestDiffFacets_NewDataset(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	changes, err := diffFacets(nil, map[string]interface{}{"owner": "alice"})
+	require.NoError(t, err)
+
+	require.Contains(t, changes, "owner")
+	assert.JSONEq(t, `null`, string(changes["owner"].Before))
+	assert.JSONEq(t, `"alice"`, string(changes["owner"].After))
+
+	changes, err = diffFacets(nil, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// maxFacetSearchResults caps the rows returned by one FindDatasetsByFacet call.
+const maxFacetSearchResults = 1000
+
+// findDatasetsByFacetQuery uses JSONB containment (@>) so it is served by idx_datasets_facets.
+const findDatasetsByFacetQuery = `
+	SELECT dataset_urn, name, namespace, facets, updated_at
+	FROM datasets
+	WHERE facets @> $1::jsonb
+	ORDER BY dataset_urn
+	LIMIT $2
+`
+
+// ErrInvalidFacetPath is returned by FindDatasetsByFacet for an empty path or path segment.
+var ErrInvalidFacetPath = errors.New("invalid facet path")
+
+// FacetDataset is a dataset matched by FindDatasetsByFacet, with its full facets.
+type FacetDataset struct {
+	DatasetURN string
+	Name       string
+	Namespace  string
+	Facets     json.RawMessage
+	UpdatedAt  time.Time
+}
+
+// FindDatasetsByFacet returns datasets whose facets contain value at jsonPath, ordered by URN
+// (at most 1000).
+//
+// jsonPath is a dot-separated list of object keys below datasets.facets. Matching follows
+// JSONB containment: objects match on a subset of keys and arrays on a subset of elements,
+// so the owners of a dataset are found by giving the owner(s) to look for:
+//
+//	store.FindDatasetsByFacet(ctx, "ownership.owners",
+//	    []map[string]string{{"name": "user:alice"}})
+//
+// A scalar value matches only an equal scalar (not an array containing it). Index-backed by
+// idx_datasets_facets (migration 005).
+func (s *LineageStore) FindDatasetsByFacet(ctx context.Context, jsonPath string, value any) ([]FacetDataset, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	containment, err := facetContainment(jsonPath, value)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	rows, err := s.conn.QueryContext(ctx, findDatasetsByFacetQuery, containment, maxFacetSearchResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query datasets by facet: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	datasets := make([]FacetDataset, 0)
+
+	for rows.Next() {
+		var d FacetDataset
+
+		if err := rows.Scan(&d.DatasetURN, &d.Name, &d.Namespace, &d.Facets, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan dataset: %w", err)
+		}
+
+		datasets = append(datasets, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating datasets: %w", err)
+	}
+
+	s.logger.Debug("Queried datasets by facet",
+		slog.String("path", jsonPath),
+		slog.Int("count", len(datasets)),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	return datasets, nil
+}
+
+// facetContainment nests value under the keys of jsonPath and encodes it as the JSONB
+// containment document, e.g. ("ownership.owners", [...]) -> {"ownership":{"owners":[...]}}.
+func facetContainment(jsonPath string, value any) ([]byte, error) {
+	keys := strings.Split(jsonPath, ".")
+
+	doc := value
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i] == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFacetPath, jsonPath)
+		}
+
+		doc = map[string]any{keys[i]: doc}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode facet value: %w", err)
+	}
+
+	return data, nil
+}
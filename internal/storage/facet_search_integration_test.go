@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDatasetsByFacet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	datasets := map[string]string{
+		"postgresql://prod/public.orders": `{"ownership": {"owners": [
+			{"name": "user:alice", "type": "MAINTAINER"}, {"name": "user:bob"}
+		]}}`,
+		"postgresql://prod/public.customers": `{"ownership": {"owners": [{"name": "user:bob"}]}}`,
+		"postgresql://prod/public.events":    `{"documentation": {"description": "raw events"}}`,
+	}
+
+	for urn, facets := range datasets {
+		name := urn[strings.LastIndex(urn, "/")+1:]
+		_, err := store.conn.ExecContext(ctx,
+			`INSERT INTO datasets (dataset_urn, name, namespace, facets) VALUES ($1, $2, 'postgresql://prod', $3)`,
+			urn, name, facets)
+		require.NoError(t, err)
+	}
+
+	t.Run("Finds Datasets By Owner", func(t *testing.T) {
+		found, err := store.FindDatasetsByFacet(ctx, "ownership.owners", []map[string]string{{"name": "user:alice"}})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "postgresql://prod/public.orders", found[0].DatasetURN)
+		assert.Contains(t, string(found[0].Facets), "MAINTAINER")
+
+		found, err = store.FindDatasetsByFacet(ctx, "ownership.owners", []map[string]string{{"name": "user:bob"}})
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "postgresql://prod/public.customers", found[0].DatasetURN)
+		assert.Equal(t, "postgresql://prod/public.orders", found[1].DatasetURN)
+	})
+
+	t.Run("No Match Returns Empty", func(t *testing.T) {
+		found, err := store.FindDatasetsByFacet(ctx, "ownership.owners", []map[string]string{{"name": "user:carol"}})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("Query Uses GIN Index", func(t *testing.T) {
+		doc, err := facetContainment("ownership.owners", []map[string]string{{"name": "user:alice"}})
+		require.NoError(t, err)
+
+		tx, err := store.conn.BeginTx(ctx, nil)
+		require.NoError(t, err)
+
+		defer func() { _ = tx.Rollback() }()
+
+		// A three-row table is cheaper to scan; disable seq scans so the planner shows it can use the index
+		_, err = tx.ExecContext(ctx, `SET LOCAL enable_seqscan = off`)
+		require.NoError(t, err)
+
+		rows, err := tx.QueryContext(ctx, "EXPLAIN "+findDatasetsByFacetQuery, doc, maxFacetSearchResults)
+		require.NoError(t, err)
+
+		var plan strings.Builder
+
+		for rows.Next() {
+			var line string
+			require.NoError(t, rows.Scan(&line))
+			plan.WriteString(line + "\n")
+		}
+
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+
+		assert.Contains(t, plan.String(), "idx_datasets_facets", "plan:\n%s", plan.String())
+	})
+
+	t.Run("Job Run Metadata Index Exists", func(t *testing.T) {
+		var indexDef string
+
+		err := store.conn.QueryRowContext(ctx,
+			`SELECT indexdef FROM pg_indexes WHERE indexname = 'idx_job_runs_metadata'`).Scan(&indexDef)
+		require.NoError(t, err)
+		assert.Contains(t, indexDef, "gin")
+	})
+}
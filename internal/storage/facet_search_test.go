@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFacetContainment(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		value    any
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "nested array of objects",
+			path:     "ownership.owners",
+			value:    []map[string]string{{"name": "user:alice"}},
+			expected: `{"ownership":{"owners":[{"name":"user:alice"}]}}`,
+		},
+		{
+			name:     "single key scalar",
+			path:     "documentation",
+			value:    "orders table",
+			expected: `{"documentation":"orders table"}`,
+		},
+		{name: "empty path", path: "", value: "x", wantErr: true},
+		{name: "empty segment", path: "ownership..owners", value: "x", wantErr: true},
+		{name: "trailing dot", path: "ownership.", value: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := facetContainment(tt.path, tt.value)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidFacetPath)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(doc))
+		})
+	}
+}
+
+func TestFindDatasetsByFacet_NoConnection(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &LineageStore{}
+
+	_, err := store.FindDatasetsByFacet(context.Background(), "ownership.owners", []string{})
+	require.ErrorIs(t, err, ErrNoDatabaseConnection)
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// FeatureFlag is one row of feature_flags (migration 015): the value of flag Name for Plugin
+// (an API key client ID), or the global value when Plugin is empty.
+type FeatureFlag struct {
+	Name    string
+	Plugin  string
+	Enabled bool
+}
+
+// FeatureFlags returns every stored feature flag value, ordered by name and plugin (global
+// value first).
+func (s *LineageStore) FeatureFlags(ctx context.Context) ([]FeatureFlag, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT name, plugin, enabled
+		FROM feature_flags
+		ORDER BY name, plugin`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature flags: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var flags []FeatureFlag
+
+	for rows.Next() {
+		var flag FeatureFlag
+		if err := rows.Scan(&flag.Name, &flag.Plugin, &flag.Enabled); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+
+		flags = append(flags, flag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feature flags: %w", err)
+	}
+
+	return flags, nil
+}
+
+// SetFeatureFlag stores the value of a feature flag, replacing the previous value for the same
+// name and plugin.
+func (s *LineageStore) SetFeatureFlag(ctx context.Context, flag FeatureFlag) error {
+	if s.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	_, err := s.conn.ExecContext(ctx, `
+		INSERT INTO feature_flags (name, plugin, enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (name, plugin) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_at = NOW()`,
+		flag.Name, flag.Plugin, flag.Enabled,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set feature flag %s: %w", flag.Name, err)
+	}
+
+	return nil
+}
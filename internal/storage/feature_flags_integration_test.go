@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeatureFlags verifies that feature flag values are stored per name and plugin, and that
+// setting a value again replaces it.
+func TestFeatureFlags(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	require.NoError(t, store.SetFeatureFlag(ctx, FeatureFlag{Name: "strict_schema", Enabled: false}))
+	require.NoError(t, store.SetFeatureFlag(ctx, FeatureFlag{Name: "strict_schema", Enabled: true}))
+	require.NoError(t, store.SetFeatureFlag(ctx, FeatureFlag{Name: "strict_schema", Plugin: "dbt", Enabled: false}))
+	require.NoError(t, store.SetFeatureFlag(ctx, FeatureFlag{Name: "async_ingestion", Plugin: "airflow", Enabled: true}))
+
+	flags, err := store.FeatureFlags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []FeatureFlag{
+		{Name: "async_ingestion", Plugin: "airflow", Enabled: true},
+		{Name: "strict_schema", Plugin: "", Enabled: true},
+		{Name: "strict_schema", Plugin: "dbt", Enabled: false},
+	}, flags)
+}
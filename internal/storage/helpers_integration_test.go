@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+
+	"github.com/correlator-io/correlator/internal/config"
+)
+
+// setupTestLineageStore creates a LineageStore with the given options, backed by a real
+// PostgreSQL testcontainer. The store and container are cleaned up with the test.
+func setupTestLineageStore(t *testing.T, opts ...LineageStoreOption) *LineageStore {
+	t.Helper()
+
+	ctx := context.Background()
+	testDB := config.SetupTestDatabase(ctx, t)
+
+	t.Cleanup(func() {
+		_ = testDB.Connection.Close()
+		_ = testcontainers.TerminateContainer(testDB.Container)
+	})
+
+	store, err := NewLineageStore(&Connection{DB: testDB.Connection}, 1*time.Hour, opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = store.Close() })
+
+	return store
+}
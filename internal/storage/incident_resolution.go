@@ -22,6 +22,10 @@ var (
 
 	// ErrIncidentNotFound is returned when the test result ID does not exist in the incident view.
 	ErrIncidentNotFound = errors.New("incident not found")
+
+	// ErrResolutionModified is returned when ResolutionRequest.UnmodifiedSince is set and the
+	// resolution was updated after it.
+	ErrResolutionModified = errors.New("incident resolution modified since the given time")
 )
 
 // GetResolution returns the current resolution state for an incident.
@@ -70,6 +74,10 @@ func (s *LineageStore) GetResolution(ctx context.Context, testResultID int64) (*
 // Transition validation is pushed into the SQL WHERE clause to eliminate
 // the TOCTOU race between reading current status and writing the update.
 // If the WHERE clause matches zero rows, the status was concurrently changed.
+//
+// When req.UnmodifiedSince is set, the update also requires the existing row's updated_at
+// (truncated to seconds, like HTTP dates) to be no later than it; otherwise ErrResolutionModified
+// is returned. An incident without a resolution row has never been modified and always passes.
 func (s *LineageStore) SetResolution(
 	ctx context.Context,
 	testResultID int64,
@@ -102,6 +110,7 @@ func (s *LineageStore) SetResolution(
 			mute_expires_at = EXCLUDED.mute_expires_at,
 			resolved_by_test_result_id = NULL
 		WHERE incident_resolutions.status = ANY($7)
+		  AND ($8::timestamptz IS NULL OR date_trunc('second', incident_resolutions.updated_at) <= $8)
 		RETURNING id, test_result_id, status,
 		          COALESCE(resolved_by, ''), COALESCE(resolution_reason, ''), COALESCE(resolution_note, ''),
 		          resolved_by_test_result_id, mute_expires_at,
@@ -115,7 +124,7 @@ func (s *LineageStore) SetResolution(
 
 	err := s.conn.QueryRowContext(ctx, upsert,
 		testResultID, req.Status, resolvedBy, req.Reason, req.Note, muteExpiresAt,
-		pq.Array(allowedSources),
+		pq.Array(allowedSources), req.UnmodifiedSince,
 	).Scan(
 		&r.ID, &r.TestResultID, &r.Status,
 		&r.ResolvedBy, &r.ResolutionReason, &r.ResolutionNote,
@@ -124,7 +133,7 @@ func (s *LineageStore) SetResolution(
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
-		return nil, resolveTransitionError(ctx, s, testResultID, req.Status)
+		return nil, resolveTransitionError(ctx, s, testResultID, req)
 	}
 
 	if err != nil {
@@ -159,12 +168,13 @@ func allowedSourceStatuses(target correlation.ResolutionStatus) []string {
 // resolveTransitionError distinguishes "incident not found" from "invalid transition"
 // when the UPSERT returns no rows. A no-row result means either:
 // (a) no resolution row existed AND the INSERT somehow failed (shouldn't happen for open→X), or
-// (b) a row existed but the WHERE clause rejected the transition.
+// (b) a row existed but the WHERE clause rejected the transition or the UnmodifiedSince precondition.
+// A failed precondition takes precedence over the transition check.
 func resolveTransitionError(
 	ctx context.Context,
 	s *LineageStore,
 	testResultID int64,
-	targetStatus correlation.ResolutionStatus,
+	req correlation.ResolutionRequest,
 ) error {
 	existing, err := s.GetResolution(ctx, testResultID)
 	if err != nil {
@@ -175,7 +185,11 @@ func resolveTransitionError(
 		return fmt.Errorf("set resolution: %w (test_result_id=%d)", ErrIncidentNotFound, testResultID)
 	}
 
-	return fmt.Errorf("%w: %s → %s", ErrInvalidResolutionTransition, existing.Status, targetStatus)
+	if req.UnmodifiedSince != nil && existing.UpdatedAt.Truncate(time.Second).After(*req.UnmodifiedSince) {
+		return fmt.Errorf("%w: updated at %s", ErrResolutionModified, existing.UpdatedAt.UTC().Format(time.RFC3339))
+	}
+
+	return fmt.Errorf("%w: %s → %s", ErrInvalidResolutionTransition, existing.Status, req.Status)
 }
 
 // AutoResolveIncidents finds open/acknowledged incidents matching the given (testName, datasetURN)
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestJobRunIngestedBy verifies that a run records the plugin of its latest event, keeps it for
+// events without one (Kafka), and stays NULL when no event carried one.
+func TestJobRunIngestedBy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	ingestedByOf := func(runID string) sql.NullString {
+		t.Helper()
+
+		var plugin sql.NullString
+
+		err := store.conn.QueryRowContext(ctx,
+			`SELECT ingested_by_plugin FROM job_runs WHERE run_id = $1`, runID,
+		).Scan(&plugin)
+		require.NoError(t, err)
+
+		return plugin
+	}
+
+	start := createTestEvent("plugin-run", ingestion.EventTypeStart, 0, 1)
+	start.IngestedBy = "dbt-ol"
+
+	_, _, err := store.StoreEvent(ctx, start)
+	require.NoError(t, err)
+	assert.Equal(t, "dbt-ol", ingestedByOf(start.Run.ID).String)
+
+	// An event without a plugin (e.g., from Kafka) keeps the recorded one
+	running := createTestEvent("plugin-run", ingestion.EventTypeRunning, 0, 1)
+
+	_, _, err = store.StoreEvent(ctx, running)
+	require.NoError(t, err)
+	assert.Equal(t, "dbt-ol", ingestedByOf(start.Run.ID).String)
+
+	// The latest sender wins
+	complete := createTestEvent("plugin-run", ingestion.EventTypeComplete, 0, 1)
+	complete.IngestedBy = ingestion.AnonymousPlugin
+
+	_, _, err = store.StoreEvent(ctx, complete)
+	require.NoError(t, err)
+	assert.Equal(t, ingestion.AnonymousPlugin, ingestedByOf(start.Run.ID).String)
+
+	plain := createTestEvent("no-plugin-run", ingestion.EventTypeStart, 0, 1)
+
+	_, _, err = store.StoreEvent(ctx, plain)
+	require.NoError(t, err)
+	assert.False(t, ingestedByOf(plain.Run.ID).Valid, "Runs without a plugin keep NULL")
+}
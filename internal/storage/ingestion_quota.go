@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	quotaPeriodDay   = "day"
+	quotaPeriodMonth = "month"
+)
+
+// ErrQuotaExceeded is returned by ConsumeIngestionQuota when storing the events would take the
+// plugin past its daily or monthly quota.
+var ErrQuotaExceeded = errors.New("ingestion quota exceeded")
+
+type (
+	// EventQuota caps the events a plugin may store per UTC day and per calendar month
+	// (0 = unlimited). Stored in api_keys.metadata (migration 014).
+	EventQuota struct {
+		Daily   int64 `json:"daily_event_quota"`   //nolint:tagliatelle
+		Monthly int64 `json:"monthly_event_quota"` //nolint:tagliatelle
+	}
+
+	// quotaPeriod is one quota a batch of events is counted against.
+	quotaPeriod struct {
+		name  string // quotaPeriodDay or quotaPeriodMonth
+		start time.Time
+		limit int64
+	}
+)
+
+// IsZero reports whether q sets no quota.
+func (q EventQuota) IsZero() bool {
+	return q.Daily <= 0 && q.Monthly <= 0
+}
+
+// PluginQuota returns the quota of the plugin keys belong to: the largest daily and monthly
+// quota among its active, unexpired keys. A key without a quota does not lift the others'.
+func PluginQuota(keys []*APIKey, now time.Time) EventQuota {
+	var quota EventQuota
+
+	for _, key := range keys {
+		if key == nil || !key.Active || (key.ExpiresAt != nil && !key.ExpiresAt.After(now)) {
+			continue
+		}
+
+		quota.Daily = max(quota.Daily, key.Quota.Daily)
+		quota.Monthly = max(quota.Monthly, key.Quota.Monthly)
+	}
+
+	return quota
+}
+
+// ConsumeIngestionQuota counts events against the daily and monthly quota of plugin (the API
+// key client ID; see PluginQuota). Returns an error wrapping ErrQuotaExceeded, and counts
+// nothing, when either quota would be exceeded: a request is admitted whole or not at all.
+//
+// Counters are per period (see ingestion_quota_usage), so they reset when a new UTC day or
+// month starts. Nothing is counted for a plugin without a quota.
+func (s *LineageStore) ConsumeIngestionQuota(ctx context.Context, plugin string, quota EventQuota, events int) error {
+	if s.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	if events <= 0 || quota.IsZero() {
+		return nil
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	for _, period := range quotaPeriods(quota, time.Now()) {
+		if err := consumeQuotaPeriod(ctx, tx, plugin, period, int64(events)); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit quota usage: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseIngestionQuota gives back events counted by ConsumeIngestionQuota at time at that were not
+// stored after all (duplicates, failures), so only stored events use up the quota. Counters of the
+// periods of at are decremented, never below zero.
+func (s *LineageStore) ReleaseIngestionQuota(
+	ctx context.Context,
+	plugin string,
+	quota EventQuota,
+	at time.Time,
+	events int,
+) error {
+	if s.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	if events <= 0 || quota.IsZero() {
+		return nil
+	}
+
+	for _, period := range quotaPeriods(quota, at) {
+		_, err := s.conn.ExecContext(ctx, `
+			UPDATE ingestion_quota_usage
+			SET event_count = GREATEST(event_count - $4, 0), updated_at = NOW()
+			WHERE plugin = $1 AND period = $2 AND period_start = $3`,
+			plugin, period.name, period.start, int64(events),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to release %s quota usage of plugin %s: %w", period.name, plugin, err)
+		}
+	}
+
+	return nil
+}
+
+// quotaPeriods returns the periods of quota that are limited, starting at the UTC day and month
+// of now.
+func quotaPeriods(quota EventQuota, now time.Time) []quotaPeriod {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var periods []quotaPeriod
+
+	if quota.Daily > 0 {
+		periods = append(periods, quotaPeriod{name: quotaPeriodDay, start: day, limit: quota.Daily})
+	}
+
+	if quota.Monthly > 0 {
+		month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		periods = append(periods, quotaPeriod{name: quotaPeriodMonth, start: month, limit: quota.Monthly})
+	}
+
+	return periods
+}
+
+// consumeQuotaPeriod adds events to the counter of period, unless that takes it past the limit.
+func consumeQuotaPeriod(ctx context.Context, tx *sql.Tx, plugin string, period quotaPeriod, events int64) error {
+	exceeded := fmt.Errorf("%w: %s quota of %d events reached for plugin %s",
+		ErrQuotaExceeded, period.name, period.limit, plugin)
+
+	// A new row is inserted without the WHERE check, so a batch larger than the whole quota is
+	// caught here
+	if events > period.limit {
+		return exceeded
+	}
+
+	var used int64
+
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO ingestion_quota_usage (plugin, period, period_start, event_count)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (plugin, period, period_start) DO UPDATE SET
+			event_count = ingestion_quota_usage.event_count + EXCLUDED.event_count,
+			updated_at = NOW()
+		WHERE ingestion_quota_usage.event_count + EXCLUDED.event_count <= $5
+		RETURNING event_count`,
+		plugin, period.name, period.start, events, period.limit,
+	).Scan(&used)
+	if errors.Is(err, sql.ErrNoRows) {
+		return exceeded
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to update %s quota usage of plugin %s: %w", period.name, plugin, err)
+	}
+
+	return nil
+}
+
+// quotaToMetadata returns the api_keys.metadata fields of quota.
+func quotaToMetadata(quota EventQuota) ([]byte, error) {
+	return json.Marshal(quota)
+}
+
+// quotaFromMetadata reads the quota fields of an api_keys.metadata value. Other fields are ignored.
+func quotaFromMetadata(metadata []byte) (EventQuota, error) {
+	var quota EventQuota
+
+	if len(metadata) == 0 {
+		return quota, nil
+	}
+
+	if err := json.Unmarshal(metadata, &quota); err != nil {
+		return EventQuota{}, err
+	}
+
+	return quota, nil
+}
@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConsumeIngestionQuota verifies that events are counted per plugin until a quota is reached,
+// and that a request exceeding it is rejected without being counted.
+func TestConsumeIngestionQuota(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	quota := EventQuota{Daily: 10, Monthly: 12}
+
+	require.NoError(t, store.ConsumeIngestionQuota(ctx, "dbt", quota, 6))
+
+	err := store.ConsumeIngestionQuota(ctx, "dbt", quota, 5)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.Contains(t, err.Error(), "day quota of 10 events")
+
+	require.NoError(t, store.ConsumeIngestionQuota(ctx, "dbt", quota, 4), "a rejected request is not counted")
+
+	err = store.ConsumeIngestionQuota(ctx, "dbt", EventQuota{Daily: 100, Monthly: 12}, 3)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.Contains(t, err.Error(), "month quota of 12 events")
+
+	require.ErrorIs(t, store.ConsumeIngestionQuota(ctx, "spark", quota, 11), ErrQuotaExceeded)
+	require.NoError(t, store.ConsumeIngestionQuota(ctx, "airflow", quota, 10), "quotas are per plugin")
+	require.NoError(t, store.ConsumeIngestionQuota(ctx, "airflow", EventQuota{}, 1000), "no quota, no limit")
+
+	usage := make(map[string]int64)
+
+	rows, err := store.conn.QueryContext(ctx,
+		`SELECT plugin || '/' || period, event_count FROM ingestion_quota_usage`)
+	require.NoError(t, err)
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			key   string
+			count int64
+		)
+
+		require.NoError(t, rows.Scan(&key, &count))
+		usage[key] = count
+	}
+
+	require.NoError(t, rows.Err())
+	assert.Equal(t, map[string]int64{
+		"dbt/day": 10, "dbt/month": 10, "airflow/day": 10, "airflow/month": 10,
+	}, usage)
+}
+
+// TestReleaseIngestionQuota verifies that released events can be consumed again, and that
+// counters never go below zero.
+func TestReleaseIngestionQuota(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	quota := EventQuota{Daily: 5, Monthly: 5}
+	now := time.Now()
+
+	require.NoError(t, store.ConsumeIngestionQuota(ctx, "dbt", quota, 5))
+	require.ErrorIs(t, store.ConsumeIngestionQuota(ctx, "dbt", quota, 1), ErrQuotaExceeded)
+
+	require.NoError(t, store.ReleaseIngestionQuota(ctx, "dbt", quota, now, 2))
+	require.NoError(t, store.ConsumeIngestionQuota(ctx, "dbt", quota, 2), "released events are available again")
+	require.ErrorIs(t, store.ConsumeIngestionQuota(ctx, "dbt", quota, 1), ErrQuotaExceeded)
+
+	require.NoError(t, store.ReleaseIngestionQuota(ctx, "dbt", quota, now, 100))
+	require.NoError(t, store.ReleaseIngestionQuota(ctx, "spark", quota, now, 1), "nothing to release")
+
+	var used int64
+	require.NoError(t, store.conn.QueryRowContext(ctx,
+		`SELECT event_count FROM ingestion_quota_usage WHERE plugin = 'dbt' AND period = 'day'`).Scan(&used))
+	assert.Equal(t, int64(0), used)
+}
+
+// TestPersistentKeyStoreQuota verifies that key quotas round-trip through api_keys.metadata.
+func TestPersistentKeyStoreQuota(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	keyStore, err := NewPersistentKeyStore(store.conn)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = keyStore.Close() })
+
+	plaintext, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	apiKey := &APIKey{
+		ID:          "quota-key",
+		Key:         plaintext,
+		ClientID:    "dbt",
+		Name:        "dbt production",
+		Permissions: []string{"lineage:write"},
+		CreatedAt:   time.Now(),
+		Active:      true,
+		Quota:       EventQuota{Daily: 1000, Monthly: 20000},
+	}
+	require.NoError(t, keyStore.Add(ctx, apiKey))
+
+	found, ok := keyStore.FindByKey(ctx, plaintext)
+	require.True(t, ok)
+	assert.Equal(t, apiKey.Quota, found.Quota)
+
+	apiKey.Quota = EventQuota{Monthly: 50000}
+	require.NoError(t, keyStore.Update(ctx, apiKey))
+
+	byID, err := keyStore.FindByID(ctx, apiKey.ID)
+	require.NoError(t, err)
+	assert.Equal(t, EventQuota{Monthly: 50000}, byID.Quota)
+
+	keys, err := keyStore.ListByClientID(ctx, "dbt")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, EventQuota{Monthly: 50000}, keys[0].Quota)
+}
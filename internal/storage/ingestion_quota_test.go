@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginQuota(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Hour)
+
+	keys := []*APIKey{
+		{Active: true, Quota: EventQuota{Daily: 100}},
+		{Active: true, Quota: EventQuota{Daily: 50, Monthly: 1000}},
+		{Active: true},
+		{Active: false, Quota: EventQuota{Daily: 5000}},
+		{Active: true, ExpiresAt: &expired, Quota: EventQuota{Monthly: 9000}},
+		nil,
+	}
+
+	assert.Equal(t, EventQuota{Daily: 100, Monthly: 1000}, PluginQuota(keys, now))
+	assert.True(t, PluginQuota(nil, now).IsZero())
+}
+
+func TestQuotaPeriods(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	// 23:30 on March 31 in UTC-5 is April 1 in UTC
+	now := time.Date(2026, 3, 31, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+
+	periods := quotaPeriods(EventQuota{Daily: 10, Monthly: 100}, now)
+	require.Len(t, periods, 2)
+
+	assert.Equal(t, quotaPeriod{
+		name: quotaPeriodDay, start: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), limit: 10,
+	}, periods[0])
+	assert.Equal(t, quotaPeriod{
+		name: quotaPeriodMonth, start: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), limit: 100,
+	}, periods[1])
+
+	periods = quotaPeriods(EventQuota{Monthly: 100}, now)
+	require.Len(t, periods, 1)
+	assert.Equal(t, quotaPeriodMonth, periods[0].name)
+
+	assert.Empty(t, quotaPeriods(EventQuota{}, now))
+}
+
+func TestQuotaFromMetadata(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	quota, err := quotaFromMetadata([]byte(`{"daily_event_quota": 10, "owner": "data-platform"}`))
+	require.NoError(t, err)
+	assert.Equal(t, EventQuota{Daily: 10}, quota)
+
+	quota, err = quotaFromMetadata(nil)
+	require.NoError(t, err)
+	assert.True(t, quota.IsZero())
+
+	_, err = quotaFromMetadata([]byte(`{"daily_event_quota": "lots"}`))
+	require.Error(t, err)
+}
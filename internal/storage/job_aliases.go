@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// UpsertJobAliases stores aliases in job_aliases (migration 013). Redefining an existing alias
+// replaces its canonical job (last write wins). Invalid aliases (see ingestion.JobAlias.Valid)
+// are skipped. All aliases are written in one transaction.
+func (s *LineageStore) UpsertJobAliases(ctx context.Context, aliases []ingestion.JobAlias) error {
+	if s.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	const query = `
+		INSERT INTO job_aliases (job_namespace, job_name, canonical_job_namespace, canonical_job_name)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (job_namespace, job_name) DO UPDATE SET
+			canonical_job_namespace = EXCLUDED.canonical_job_namespace,
+			canonical_job_name = EXCLUDED.canonical_job_name,
+			updated_at = NOW()
+		WHERE (job_aliases.canonical_job_namespace, job_aliases.canonical_job_name)
+		   IS DISTINCT FROM (EXCLUDED.canonical_job_namespace, EXCLUDED.canonical_job_name)`
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	for _, alias := range aliases {
+		if !alias.Valid() {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, query,
+			alias.Namespace, alias.Name, alias.CanonicalNamespace, alias.CanonicalName); err != nil {
+			return fmt.Errorf("failed to upsert job alias %s/%s: %w", alias.Namespace, alias.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit job aliases: %w", err)
+	}
+
+	return nil
+}
+
+// JobAliases returns every stored job alias, ordered by alias.
+func (s *LineageStore) JobAliases(ctx context.Context) ([]ingestion.JobAlias, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT job_namespace, job_name, canonical_job_namespace, canonical_job_name
+		FROM job_aliases
+		ORDER BY job_namespace, job_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job aliases: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var aliases []ingestion.JobAlias
+
+	for rows.Next() {
+		var alias ingestion.JobAlias
+		if err := rows.Scan(&alias.Namespace, &alias.Name, &alias.CanonicalNamespace, &alias.CanonicalName); err != nil {
+			return nil, fmt.Errorf("failed to scan job alias: %w", err)
+		}
+
+		aliases = append(aliases, alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate job aliases: %w", err)
+	}
+
+	return aliases, nil
+}
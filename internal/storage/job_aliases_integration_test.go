@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestJobAliases verifies that job aliases are upserted (last write wins), and that runs of two
+// renamed jobs resolved through them are stored under one canonical job.
+func TestJobAliases(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	alias := func(name, canonical string) ingestion.JobAlias {
+		return ingestion.JobAlias{
+			Namespace: "dbt://analytics", Name: name, CanonicalNamespace: "dbt://analytics", CanonicalName: canonical,
+		}
+	}
+
+	err := store.UpsertJobAliases(ctx, []ingestion.JobAlias{
+		alias("stg_orders", "old"),
+		alias("self", "self"),
+	})
+	require.NoError(t, err)
+
+	err = store.UpsertJobAliases(ctx, []ingestion.JobAlias{
+		alias("stg_orders", "orders"),
+		alias("staging.orders", "orders"),
+	})
+	require.NoError(t, err)
+
+	aliases, err := store.JobAliases(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ingestion.JobAlias{
+		alias("staging.orders", "orders"),
+		alias("stg_orders", "orders"),
+	}, aliases)
+
+	resolver := ingestion.NewJobIdentityResolver(aliases)
+
+	for _, name := range []string{"stg_orders", "staging.orders"} {
+		event := createTestEvent("job-alias-"+name, ingestion.EventTypeComplete, 0, 1)
+		event.Job = ingestion.Job{Namespace: "dbt://analytics", Name: name}
+
+		require.NoError(t, resolver.BeforeStore(ctx, event))
+
+		_, _, err := store.StoreEvent(ctx, event)
+		require.NoError(t, err)
+	}
+
+	var runs int
+
+	err = store.conn.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM job_runs WHERE job_namespace = 'dbt://analytics' AND job_name = 'orders'`,
+	).Scan(&runs)
+	require.NoError(t, err)
+	assert.Equal(t, 2, runs, "runs of both renamed jobs are stored under the canonical job")
+}
@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// JobRunChangesChannel is the PostgreSQL NOTIFY channel for job run changes.
+	// External consumers can LISTEN on it directly; the payload is a JobRunChange as JSON.
+	// NOTIFY channels are database-wide, so a store using a schema (DATABASE_SCHEMA) notifies on
+	// "<schema>.job_run_changes" instead (see JobRunChangesChannelFor).
+	JobRunChangesChannel = "job_run_changes"
+
+	// jobRunChangeBuffer is the Go channel buffer of each Subscribe call.
+	jobRunChangeBuffer = 64
+
+	// Reconnect backoff for the dedicated LISTEN connection.
+	listenerMinReconnect = 1 * time.Second
+	listenerMaxReconnect = 30 * time.Second
+
+	// listenerPingInterval detects a silently dropped LISTEN connection while no notifications arrive.
+	listenerPingInterval = 90 * time.Second
+
+	// notifyJobRunChangeQuery queues a notification with the run's state after the upsert.
+	// NOTIFY is transactional: it is delivered on COMMIT and discarded on ROLLBACK.
+	notifyJobRunChangeQuery = `
+		SELECT pg_notify($1, json_build_object(
+			'run_id', run_id,
+			'job_namespace', job_namespace,
+			'job_name', job_name,
+			'state', current_state,
+			'event_type', event_type,
+			'event_time', event_time
+		)::text)
+		FROM job_runs
+		WHERE run_id = $2
+	`
+)
+
+// ErrNotificationsUnavailable is returned by Subscribe when the store's connection was not opened
+// from a connection string (e.g., WrapConnection), so no dedicated LISTEN connection can be made.
+var ErrNotificationsUnavailable = errors.New("job run notifications require a connection opened with NewConnection")
+
+// JobRunChange is the payload of a job_run_changes notification: a job run after an event was stored.
+type JobRunChange struct {
+	RunID        string    `json:"run_id"`        //nolint:tagliatelle
+	JobNamespace string    `json:"job_namespace"` //nolint:tagliatelle
+	JobName      string    `json:"job_name"`      //nolint:tagliatelle
+	State        string    `json:"state"`         // current_state after the event (out-of-order events keep the newer state)
+	EventType    string    `json:"event_type"`    //nolint:tagliatelle
+	EventTime    time.Time `json:"event_time"`    //nolint:tagliatelle
+}
+
+// WithJobRunNotifications makes StoreEvent/StoreEvents emit a NOTIFY on JobRunChangesChannel for
+// every stored event. Default: disabled, since NOTIFY serializes commits on the notification queue.
+//
+// Example:
+//
+//	store, err := storage.NewLineageStore(conn, interval,
+//	    storage.WithJobRunNotifications(true))
+func WithJobRunNotifications(enabled bool) LineageStoreOption {
+	return func(s *LineageStore) {
+		s.jobRunNotifications = enabled
+	}
+}
+
+// JobRunChangesChannelFor returns the NOTIFY channel of a store using schema
+// (JobRunChangesChannel for the default schema), keeping deployments that share a database apart.
+func JobRunChangesChannelFor(schema string) string {
+	if schema == "" {
+		return JobRunChangesChannel
+	}
+
+	return schema + "." + JobRunChangesChannel
+}
+
+// jobRunChangesChannel returns the NOTIFY channel of this store's schema.
+func (s *LineageStore) jobRunChangesChannel() string {
+	if s.conn == nil {
+		return JobRunChangesChannel
+	}
+
+	return JobRunChangesChannelFor(s.conn.schema)
+}
+
+// notifyJobRunChange queues a job_run_changes notification inside the event transaction.
+func (s *LineageStore) notifyJobRunChange(ctx context.Context, tx *sql.Tx, runID string) error {
+	if !s.jobRunNotifications {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, notifyJobRunChangeQuery, s.jobRunChangesChannel(), runID); err != nil {
+		return fmt.Errorf("failed to notify job run change: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe LISTENs on the store's job run changes channel (see JobRunChangesChannelFor) over a
+// dedicated connection and delivers each change on the returned channel until ctx is cancelled or
+// the store is closed; the channel is then closed.
+//
+// Properties:
+//   - Reconnecting: a dropped connection is re-established with backoff (1s-30s) and LISTEN re-issued.
+//     Notifications sent while disconnected are lost; a warning is logged on reconnect
+//   - Backpressure: a slow reader stalls only its own subscription (PostgreSQL queues the rest)
+//   - Cross-process: receives changes stored by every Correlator instance sharing the database
+//
+// Notifications are only emitted by stores created with WithJobRunNotifications(true).
+func (s *LineageStore) Subscribe(ctx context.Context) (<-chan JobRunChange, error) {
+	if s.conn == nil || s.conn.dsn == "" {
+		return nil, ErrNotificationsUnavailable
+	}
+
+	listener := pq.NewListener(s.conn.dsn, listenerMinReconnect, listenerMaxReconnect,
+		func(event pq.ListenerEventType, err error) {
+			s.logListenerEvent(event, err)
+		})
+
+	channel := s.jobRunChangesChannel()
+
+	if err := listener.Listen(channel); err != nil {
+		_ = listener.Close()
+
+		return nil, fmt.Errorf("failed to listen on %s: %w", channel, err)
+	}
+
+	changes := make(chan JobRunChange, jobRunChangeBuffer)
+
+	s.subscribersWg.Add(1)
+
+	go func() {
+		defer s.subscribersWg.Done()
+		defer close(changes)
+		defer func() { _ = listener.Close() }()
+
+		s.forwardJobRunChanges(ctx, listener, changes)
+	}()
+
+	return changes, nil
+}
+
+// forwardJobRunChanges decodes notifications from listener into changes until ctx is done or
+// the store is closed.
+func (s *LineageStore) forwardJobRunChanges(
+	ctx context.Context,
+	listener *pq.Listener,
+	changes chan<- JobRunChange,
+) {
+	ping := time.NewTicker(listenerPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.subscribersStop:
+			return
+		case <-ping.C:
+			_ = listener.Ping() // A failed ping makes the listener reconnect
+		case notification, ok := <-listener.NotificationChannel():
+			if !ok {
+				return
+			}
+
+			if notification == nil {
+				continue // Sent after a reconnect; logged by logListenerEvent
+			}
+
+			var change JobRunChange
+			if err := json.Unmarshal([]byte(notification.Extra), &change); err != nil {
+				s.logger.Warn("Ignoring malformed job run change notification",
+					slog.String("payload", notification.Extra),
+					slog.String("error", err.Error()),
+				)
+
+				continue
+			}
+
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			case <-s.subscribersStop:
+				return
+			}
+		}
+	}
+}
+
+// logListenerEvent logs connection state changes of a LISTEN connection.
+func (s *LineageStore) logListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventConnected:
+		s.logger.Info("Job run change listener connected", slog.String("channel", JobRunChangesChannel))
+	case pq.ListenerEventDisconnected:
+		s.logger.Warn("Job run change listener disconnected, reconnecting", slog.Any("error", err))
+	case pq.ListenerEventReconnected:
+		s.logger.Warn("Job run change listener reconnected; changes sent while disconnected were missed")
+	case pq.ListenerEventConnectionAttemptFailed:
+		s.logger.Warn("Job run change listener reconnect attempt failed", slog.Any("error", err))
+	}
+}
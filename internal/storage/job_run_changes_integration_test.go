@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+
+	"github.com/correlator-io/correlator/internal/config"
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// setupNotifyingStore creates a store whose connection carries its DSN, as NewConnection does,
+// so Subscribe can open a LISTEN connection.
+func setupNotifyingStore(t *testing.T, opts ...LineageStoreOption) *LineageStore {
+	t.Helper()
+
+	ctx := context.Background()
+	testDB := config.SetupTestDatabase(ctx, t)
+
+	t.Cleanup(func() {
+		_ = testDB.Connection.Close()
+		_ = testcontainers.TerminateContainer(testDB.Container)
+	})
+
+	conn := &Connection{DB: testDB.Connection, dsn: testDB.ConnectionString}
+
+	store, err := NewLineageStore(conn, 1*time.Hour, opts...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = store.Close() })
+
+	return store
+}
+
+// TestSubscribe_DeliversJobRunChanges verifies that a stored event is delivered to subscribers
+// with the run's resulting state, and that cancelling the context closes the channel.
+func TestSubscribe_DeliversJobRunChanges(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	store := setupNotifyingStore(t, WithJobRunNotifications(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := store.Subscribe(ctx)
+	require.NoError(t, err)
+
+	event := createTestEvent("notify-run", ingestion.EventTypeComplete, 1, 1)
+
+	_, _, err = store.StoreEvent(context.Background(), event)
+	require.NoError(t, err)
+
+	select {
+	case change := <-changes:
+		assert.Equal(t, event.Run.ID, change.RunID)
+		assert.Equal(t, event.Job.Namespace, change.JobNamespace)
+		assert.Equal(t, event.Job.Name, change.JobName)
+		assert.Equal(t, string(ingestion.EventTypeComplete), change.EventType)
+		assert.NotEmpty(t, change.State)
+		assert.WithinDuration(t, event.EventTime, change.EventTime, time.Second)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for job run change")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-changes:
+		assert.False(t, ok, "Channel should be closed after the context is cancelled")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Channel was not closed after the context was cancelled")
+	}
+}
+
+// TestSubscribe_NotificationsDisabled verifies that stores without WithJobRunNotifications
+// emit nothing, and that Close ends open subscriptions.
+func TestSubscribe_NotificationsDisabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	store := setupNotifyingStore(t)
+
+	changes, err := store.Subscribe(context.Background())
+	require.NoError(t, err)
+
+	_, _, err = store.StoreEvent(context.Background(), createTestEvent("silent-run", ingestion.EventTypeStart, 0, 1))
+	require.NoError(t, err)
+
+	select {
+	case change := <-changes:
+		t.Fatalf("Unexpected job run change: %+v", change)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	require.NoError(t, store.Close())
+
+	_, ok := <-changes
+	assert.False(t, ok, "Channel should be closed after the store is closed")
+}
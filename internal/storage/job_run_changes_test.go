@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe_RequiresConnectionString(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	for name, store := range map[string]*LineageStore{
+		"NoConnection":      {},
+		"WrappedConnection": {conn: WrapConnection(nil)}, // No DSN to open a LISTEN connection with
+	} {
+		t.Run(name, func(t *testing.T) {
+			changes, err := store.Subscribe(context.Background())
+			require.ErrorIs(t, err, ErrNotificationsUnavailable)
+			require.Nil(t, changes)
+		})
+	}
+}
+
+func TestJobRunChangesChannelFor(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	assert.Equal(t, JobRunChangesChannel, JobRunChangesChannelFor(""))
+	assert.Equal(t, "team_a.job_run_changes", JobRunChangesChannelFor("team_a"))
+}
@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// MaxJobRunDeleteLimit caps the number of job runs removed by one DeleteJobRuns call.
+// Larger cleanups are done by calling DeleteJobRuns repeatedly until it returns zero.
+const MaxJobRunDeleteLimit = 10000
+
+// ErrUnfilteredJobRunDelete is returned when DeleteJobRuns is called without both a namespace
+// and a cutoff time. Unfiltered deletes are always refused.
+var ErrUnfilteredJobRunDelete = errors.New("job run delete requires a namespace and a before time")
+
+type (
+	// JobRunDeleteFilter selects the job runs removed by DeleteJobRuns.
+	// Namespace and Before are both required.
+	JobRunDeleteFilter struct {
+		Namespace string    // Exact job namespace (e.g., "test://e2e")
+		Before    time.Time // Only runs first recorded (created_at) before this time
+		Limit     int       // Max runs per call, oldest first (<= 0 or > MaxJobRunDeleteLimit uses the max)
+		DryRun    bool      // Count what would be deleted, then roll back
+	}
+
+	// JobRunDeleteResult counts the rows removed (or, for a dry run, that would be removed).
+	JobRunDeleteResult struct {
+		JobRuns      int64
+		LineageEdges int64
+		TestResults  int64
+		Datasets     int64 // Datasets left without any lineage edge or test result
+	}
+)
+
+// DeleteJobRuns deletes the job runs matching filter together with their lineage edges and
+// test results (and, via cascade, incident resolutions), then deletes datasets that the removed
+// runs touched and nothing else references. Everything happens in one transaction.
+//
+// Intended for non-production hygiene (CI / e2e data). Guards:
+//   - Namespace and Before are required (ErrUnfilteredJobRunDelete otherwise)
+//   - At most MaxJobRunDeleteLimit runs per call
+//   - Matched runs are locked (FOR UPDATE) so concurrent ingestion cannot attach new rows mid-delete
+//
+// Correlation views are refreshed through the usual debounced refresh when rows were deleted.
+func (s *LineageStore) DeleteJobRuns(ctx context.Context, filter JobRunDeleteFilter) (JobRunDeleteResult, error) {
+	if strings.TrimSpace(filter.Namespace) == "" || filter.Before.IsZero() {
+		return JobRunDeleteResult{}, ErrUnfilteredJobRunDelete
+	}
+
+	if s.conn == nil {
+		return JobRunDeleteResult{}, ErrNoDatabaseConnection
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > MaxJobRunDeleteLimit {
+		limit = MaxJobRunDeleteLimit
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return JobRunDeleteResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := deleteJobRunsTx(ctx, tx, filter, limit)
+	if err != nil {
+		return JobRunDeleteResult{}, err
+	}
+
+	if filter.DryRun {
+		return result, nil // Deferred rollback discards the deletes
+	}
+
+	if err := tx.Commit(); err != nil {
+		return JobRunDeleteResult{}, fmt.Errorf("failed to commit job run delete: %w", err)
+	}
+
+	s.logger.Warn("Deleted job runs",
+		slog.String("namespace", filter.Namespace),
+		slog.Time("before", filter.Before),
+		slog.Int64("job_runs", result.JobRuns),
+		slog.Int64("lineage_edges", result.LineageEdges),
+		slog.Int64("test_results", result.TestResults),
+		slog.Int64("datasets", result.Datasets),
+	)
+
+	if result.JobRuns > 0 {
+		s.notifyDataChanged()
+	}
+
+	return result, nil
+}
+
+// deleteJobRunsTx locks the job runs matching filter and deletes them inside tx.
+func deleteJobRunsTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	filter JobRunDeleteFilter,
+	limit int,
+) (JobRunDeleteResult, error) {
+	runIDs, err := queryStrings(ctx, tx, `
+		SELECT run_id::text FROM job_runs
+		WHERE job_namespace = $1 AND created_at < $2
+		ORDER BY created_at
+		LIMIT $3
+		FOR UPDATE
+	`, filter.Namespace, filter.Before, limit)
+	if err != nil {
+		return JobRunDeleteResult{}, fmt.Errorf("failed to select job runs: %w", err)
+	}
+
+	return deleteRunsTx(ctx, tx, runIDs)
+}
+
+// deleteRunsTx deletes the given (locked) job runs inside tx with their lineage edges and test
+// results, then the datasets they touched that nothing else references.
+// Children are deleted explicitly (rather than relying on ON DELETE CASCADE) to count them
+// and to collect the dataset URNs that may have become orphaned.
+func deleteRunsTx(ctx context.Context, tx *sql.Tx, runIDs []string) (JobRunDeleteResult, error) {
+	var result JobRunDeleteResult
+
+	if len(runIDs) == 0 {
+		return result, nil
+	}
+
+	runs := pq.Array(runIDs)
+
+	edgeURNs, err := queryStrings(ctx, tx,
+		`DELETE FROM lineage_edges WHERE run_id = ANY($1::uuid[]) RETURNING dataset_urn`, runs)
+	if err != nil {
+		return result, fmt.Errorf("failed to delete lineage edges: %w", err)
+	}
+
+	testURNs, err := queryStrings(ctx, tx,
+		`DELETE FROM test_results WHERE run_id = ANY($1::uuid[]) RETURNING dataset_urn`, runs)
+	if err != nil {
+		return result, fmt.Errorf("failed to delete test results: %w", err)
+	}
+
+	deleted, err := tx.ExecContext(ctx, `DELETE FROM job_runs WHERE run_id = ANY($1::uuid[])`, runs)
+	if err != nil {
+		return result, fmt.Errorf("failed to delete job runs: %w", err)
+	}
+
+	result.JobRuns, _ = deleted.RowsAffected()
+	result.LineageEdges = int64(len(edgeURNs))
+	result.TestResults = int64(len(testURNs))
+
+	orphans, err := tx.ExecContext(ctx, `
+		DELETE FROM datasets d
+		WHERE d.dataset_urn = ANY($1)
+		  AND NOT EXISTS (SELECT 1 FROM lineage_edges e WHERE e.dataset_urn = d.dataset_urn)
+		  AND NOT EXISTS (SELECT 1 FROM test_results t WHERE t.dataset_urn = d.dataset_urn)
+	`, pq.Array(append(edgeURNs, testURNs...)))
+	if err != nil {
+		return result, fmt.Errorf("failed to delete orphaned datasets: %w", err)
+	}
+
+	result.Datasets, _ = orphans.RowsAffected()
+
+	return result, nil
+}
+
+// queryStrings runs a query returning a single text column and collects the values.
+func queryStrings(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var values []string
+
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, rows.Err()
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+func countRows(ctx context.Context, t *testing.T, store *LineageStore, query string, args ...any) int {
+	t.Helper()
+
+	var n int
+	require.NoError(t, store.conn.QueryRowContext(ctx, query, args...).Scan(&n))
+
+	return n
+}
+
+// TestDeleteJobRuns verifies that only matching runs are deleted with their edges, and that
+// datasets still referenced by surviving runs are kept.
+func TestDeleteJobRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	// Throwaway run: reads its own input, writes a dataset that a production run also reads
+	testRun := createTestEvent("cleanup-e2e", ingestion.EventTypeComplete, 1, 1)
+	testRun.Job.Namespace = "test://e2e"
+
+	prodRun := createTestEvent("cleanup-prod", ingestion.EventTypeComplete, 0, 0)
+	prodRun.Inputs = []ingestion.Dataset{testRun.Outputs[0]}
+
+	for _, event := range []*ingestion.RunEvent{testRun, prodRun} {
+		_, _, err := store.StoreEvent(ctx, event)
+		require.NoError(t, err)
+	}
+
+	sharedURN := testRun.Outputs[0].URN()
+	orphanURN := testRun.Inputs[0].URN()
+	before := time.Now().Add(time.Minute)
+
+	t.Run("RefusesUnfilteredDelete", func(t *testing.T) {
+		_, err := store.DeleteJobRuns(ctx, JobRunDeleteFilter{Before: before})
+		require.ErrorIs(t, err, ErrUnfilteredJobRunDelete)
+
+		_, err = store.DeleteJobRuns(ctx, JobRunDeleteFilter{Namespace: "test://e2e"})
+		require.ErrorIs(t, err, ErrUnfilteredJobRunDelete)
+	})
+
+	expected := JobRunDeleteResult{JobRuns: 1, LineageEdges: 2, TestResults: 0, Datasets: 1}
+
+	t.Run("DryRunDeletesNothing", func(t *testing.T) {
+		result, err := store.DeleteJobRuns(ctx, JobRunDeleteFilter{
+			Namespace: "test://e2e", Before: before, DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+
+		assert.Equal(t, 2, countRows(ctx, t, store, `SELECT COUNT(*) FROM job_runs`))
+	})
+
+	t.Run("DeletesMatchingRunsOnly", func(t *testing.T) {
+		// Cutoff before the runs were recorded matches nothing
+		result, err := store.DeleteJobRuns(ctx, JobRunDeleteFilter{
+			Namespace: "test://e2e", Before: time.Now().Add(-time.Hour),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, JobRunDeleteResult{}, result)
+
+		result, err = store.DeleteJobRuns(ctx, JobRunDeleteFilter{Namespace: "test://e2e", Before: before})
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+
+		assert.Equal(t, 0, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM job_runs WHERE job_namespace = 'test://e2e'`))
+		assert.Equal(t, 1, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM job_runs WHERE job_namespace = 'dbt://analytics'`))
+		assert.Equal(t, 1, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM datasets WHERE dataset_urn = $1`, sharedURN),
+			"dataset read by a surviving run must be kept")
+		assert.Equal(t, 0, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM datasets WHERE dataset_urn = $1`, orphanURN),
+			"dataset only touched by deleted runs must be removed")
+	})
+}
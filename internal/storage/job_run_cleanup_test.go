@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteJobRuns_RequiresFilters(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &LineageStore{} // No connection: filters must be rejected before touching the database
+
+	tests := []JobRunDeleteFilter{
+		{},
+		{Namespace: "test://e2e"},
+		{Before: time.Now()},
+		{Namespace: "   ", Before: time.Now()},
+	}
+
+	for _, filter := range tests {
+		_, err := store.DeleteJobRuns(context.Background(), filter)
+		require.ErrorIs(t, err, ErrUnfilteredJobRunDelete, "filter %+v", filter)
+	}
+}
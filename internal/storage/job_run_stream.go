@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// DefaultJobRunStreamPageSize is the number of job runs StreamJobRuns reads per query.
+const DefaultJobRunStreamPageSize = 500
+
+// streamJobRunsQuery reads one keyset page of job runs in run_id order, served by the primary
+// key index. $1 is the last run_id of the previous page (NULL for the first page).
+const streamJobRunsQuery = `
+	SELECT run_id, COALESCE(job_namespace, ''), job_name, event_type, current_state, event_time,
+		started_at, completed_at, parent_run_id, producer_name, COALESCE(ingested_by_plugin, ''),
+		COALESCE(metadata, '{}'::jsonb)
+	FROM job_runs
+	WHERE ($1::uuid IS NULL OR run_id > $1::uuid)
+	  AND ($2::text = '' OR job_namespace = $2)
+	  AND ($3::timestamptz IS NULL OR event_time >= $3)
+	  AND ($4::timestamptz IS NULL OR event_time < $4)
+	ORDER BY run_id
+	LIMIT $5
+`
+
+type (
+	// JobRunStreamFilter selects the job runs read by StreamJobRuns. The zero value reads all.
+	JobRunStreamFilter struct {
+		Namespace string    // Exact job namespace (empty = all namespaces)
+		Since     time.Time // Only runs whose latest event_time is at or after this time (zero = unbounded)
+		Until     time.Time // Only runs whose latest event_time is before this time (zero = unbounded)
+		PageSize  int       // Rows per query (<= 0 uses DefaultJobRunStreamPageSize)
+	}
+
+	// JobRun is one job run read by StreamJobRuns.
+	JobRun struct {
+		RunID            string
+		JobNamespace     string
+		JobName          string
+		EventType        string // Type of the latest event
+		State            string // current_state
+		EventTime        time.Time
+		StartedAt        time.Time
+		CompletedAt      *time.Time
+		ParentRunID      string // Empty when the run has no parent
+		ProducerName     string
+		IngestedByPlugin string // Empty for runs stored before plugins were recorded
+		Metadata         json.RawMessage
+	}
+)
+
+// StreamJobRuns calls fn for every job run matching filter, in run ID order, without loading
+// the result set into memory: runs are read in keyset pages of filter.PageSize rows, so memory
+// stays bounded by one page however many runs match. Export and listing endpoints use it to
+// encode runs as they arrive.
+//
+// Each page is a separate query and no transaction is held open between pages: runs stored
+// while streaming are included if their run ID sorts after the current page, and a run updated
+// mid-stream is returned at most once.
+//
+// fn gets its own copy of each run, which it may retain; the page buffer behind it is reused.
+// An error from fn stops the stream and is returned unchanged.
+func (s *LineageStore) StreamJobRuns(ctx context.Context, filter JobRunStreamFilter, fn func(*JobRun) error) error {
+	if s.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultJobRunStreamPageSize
+	}
+
+	start := time.Now()
+	page := make([]JobRun, 0, pageSize)
+
+	var (
+		after sql.NullString
+		total int
+	)
+
+	for {
+		var err error
+
+		page, err = s.readJobRunPage(ctx, filter, after, pageSize, page[:0])
+		if err != nil {
+			return err
+		}
+
+		for i := range page {
+			run := page[i]
+			if err := fn(&run); err != nil {
+				return err
+			}
+		}
+
+		total += len(page)
+
+		if len(page) < pageSize {
+			break
+		}
+
+		after = sql.NullString{String: page[len(page)-1].RunID, Valid: true}
+	}
+
+	s.logger.Debug("Streamed job runs",
+		slog.String("namespace", filter.Namespace),
+		slog.Int("count", total),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	return nil
+}
+
+// readJobRunPage appends the page of job runs after the run ID after to page. The rows are
+// closed before StreamJobRuns calls back, so a slow consumer does not hold a pooled connection.
+func (s *LineageStore) readJobRunPage(
+	ctx context.Context,
+	filter JobRunStreamFilter,
+	after sql.NullString,
+	pageSize int,
+	page []JobRun,
+) ([]JobRun, error) {
+	rows, err := s.conn.QueryContext(ctx, streamJobRunsQuery,
+		after, filter.Namespace, nullTime(filter.Since), nullTime(filter.Until), pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job runs: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			run         JobRun
+			completedAt sql.NullTime
+			parentRunID sql.NullString
+		)
+
+		if err := rows.Scan(&run.RunID, &run.JobNamespace, &run.JobName, &run.EventType, &run.State,
+			&run.EventTime, &run.StartedAt, &completedAt, &parentRunID, &run.ProducerName,
+			&run.IngestedByPlugin, &run.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to scan job run: %w", err)
+		}
+
+		if completedAt.Valid {
+			run.CompletedAt = &completedAt.Time
+		}
+
+		run.ParentRunID = parentRunID.String
+		page = append(page, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job runs: %w", err)
+	}
+
+	return page, nil
+}
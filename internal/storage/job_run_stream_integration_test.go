@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insertSyntheticJobRuns inserts count job runs in namespace, each with roughly 1 KiB of
+// metadata, with event times one second apart starting at base.
+func insertSyntheticJobRuns(ctx context.Context, t *testing.T, store *LineageStore, namespace string,
+	count int, base time.Time,
+) {
+	t.Helper()
+
+	_, err := store.conn.ExecContext(ctx, `
+		INSERT INTO job_runs (run_id, event_type, event_time, job_name, job_namespace, started_at,
+			current_state, metadata, producer_name)
+		SELECT md5($1 || i::text)::uuid, 'COMPLETE', $2::timestamptz + i * interval '1 second',
+			'stream_job_' || i, $1, $2::timestamptz, 'COMPLETE',
+			jsonb_build_object('padding', repeat('x', 1024)), 'dbt'
+		FROM generate_series(1, $3) AS i`,
+		namespace, base, count)
+	require.NoError(t, err)
+}
+
+// TestStreamJobRuns_BoundedMemory streams ~50 MiB of job runs and verifies every run is
+// delivered once, in run ID order, while the heap stays far below the size of the result set.
+func TestStreamJobRuns_BoundedMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	const runs = 50000
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	insertSyntheticJobRuns(ctx, t, store, "stream://bulk", runs, time.Now().Add(-24*time.Hour))
+
+	runtime.GC()
+
+	var baseline runtime.MemStats
+
+	runtime.ReadMemStats(&baseline)
+
+	var (
+		count, streamed int
+		peakHeap        uint64
+		lastRunID       string
+	)
+
+	err := store.StreamJobRuns(ctx, JobRunStreamFilter{}, func(run *JobRun) error {
+		count++
+		streamed += len(run.Metadata) + len(run.JobName)
+
+		assert.Greater(t, run.RunID, lastRunID, "runs must arrive in run ID order without duplicates")
+		lastRunID = run.RunID
+
+		if count%10000 == 0 {
+			runtime.GC()
+
+			var stats runtime.MemStats
+
+			runtime.ReadMemStats(&stats)
+			peakHeap = max(peakHeap, stats.HeapAlloc)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, runs, count)
+	assert.Greater(t, streamed, 48<<20, "the result set must be large enough to prove streaming")
+
+	growth := int64(peakHeap) - int64(baseline.HeapAlloc)
+	assert.Less(t, growth, int64(16<<20), "heap grew by %d bytes while streaming %d bytes", growth, streamed)
+}
+
+func TestStreamJobRuns_FilterAndStop(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	insertSyntheticJobRuns(ctx, t, store, "stream://a", 25, base)
+	insertSyntheticJobRuns(ctx, t, store, "stream://b", 10, base)
+
+	t.Run("NamespaceAndTimeRange", func(t *testing.T) {
+		var names []string
+
+		// Runs 6..15 of namespace a; page size 3 exercises keyset paging across pages
+		err := store.StreamJobRuns(ctx, JobRunStreamFilter{
+			Namespace: "stream://a",
+			Since:     base.Add(6 * time.Second),
+			Until:     base.Add(16 * time.Second),
+			PageSize:  3,
+		}, func(run *JobRun) error {
+			assert.Equal(t, "stream://a", run.JobNamespace)
+			assert.Equal(t, "COMPLETE", run.State)
+			assert.Nil(t, run.CompletedAt)
+			assert.Empty(t, run.ParentRunID)
+			names = append(names, run.JobName)
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, names, 10)
+	})
+
+	t.Run("RetainedRunsAreNotOverwritten", func(t *testing.T) {
+		var retained []*JobRun
+
+		// Page size 4 reuses the page buffer several times
+		err := store.StreamJobRuns(ctx, JobRunStreamFilter{Namespace: "stream://b", PageSize: 4},
+			func(run *JobRun) error {
+				retained = append(retained, run)
+
+				return nil
+			})
+		require.NoError(t, err)
+		require.Len(t, retained, 10)
+
+		seen := make(map[string]bool, len(retained))
+		for i, run := range retained {
+			if i > 0 {
+				assert.Greater(t, run.RunID, retained[i-1].RunID, "retained runs keep their own values")
+			}
+
+			seen[run.RunID] = true
+		}
+
+		assert.Len(t, seen, 10)
+	})
+
+	t.Run("CallbackErrorStopsStream", func(t *testing.T) {
+		errStop := errors.New("client went away")
+		calls := 0
+
+		err := store.StreamJobRuns(ctx, JobRunStreamFilter{PageSize: 4}, func(*JobRun) error {
+			calls++
+			if calls == 5 {
+				return errStop
+			}
+
+			return nil
+		})
+		require.ErrorIs(t, err, errStop)
+		assert.Equal(t, 5, calls)
+	})
+}
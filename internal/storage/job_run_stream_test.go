@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamJobRuns_NoConnection(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &LineageStore{}
+
+	err := store.StreamJobRuns(context.Background(), JobRunStreamFilter{}, func(*JobRun) error {
+		t.Fatal("callback must not be called without a connection")
+
+		return nil
+	})
+	require.ErrorIs(t, err, ErrNoDatabaseConnection)
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/correlation"
+)
+
+// QueryJobRunTestResults implements correlation.Store.
+// Failed and errored tests sort first so a page bounded by pagination keeps the incidents.
+// Uses idx_test_results_run_id.
+func (s *LineageStore) QueryJobRunTestResults(
+	ctx context.Context,
+	runID string,
+	pagination *correlation.Pagination,
+) (*correlation.JobRunTestResults, error) {
+	start := time.Now()
+
+	result := &correlation.JobRunTestResults{TestResults: make([]correlation.JobRunTestResult, 0)}
+
+	err := s.conn.QueryRowContext(ctx, `
+		SELECT run_id, job_name, job_namespace, current_state, COALESCE(ingested_by_plugin, '')
+		FROM job_runs WHERE run_id = $1
+	`, runID).Scan(&result.RunID, &result.JobName, &result.JobNamespace, &result.JobStatus, &result.IngestedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil //nolint:nilnil // Not found returns nil result, not an error
+	}
+
+	if err != nil {
+		s.logger.Error("Failed to query job run", slog.Any("error", err), slog.String("run_id", runID))
+
+		return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	query := `
+		SELECT id, test_name, COALESCE(test_type, ''), status, COALESCE(message, ''),
+			dataset_urn, executed_at, producer_name,
+			COUNT(*) OVER() AS total
+		FROM test_results
+		WHERE run_id = $1
+		ORDER BY (status IN ('failed', 'error')) DESC, executed_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.conn.QueryContext(ctx, query, runID, pagination.Limit, pagination.Offset)
+	if err != nil {
+		s.logger.Error("Failed to query job run test results", slog.Any("error", err), slog.String("run_id", runID))
+
+		return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var tr correlation.JobRunTestResult
+
+		if err := rows.Scan(
+			&tr.TestResultID, &tr.TestName, &tr.TestType, &tr.Status, &tr.Message,
+			&tr.DatasetURN, &tr.ExecutedAt, &tr.ProducerName,
+			&result.Total,
+		); err != nil {
+			return nil, fmt.Errorf("%w: failed to scan job run test result: %w", ErrCorrelationQueryFailed, err)
+		}
+
+		result.TestResults = append(result.TestResults, tr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+	}
+
+	// COUNT(*) OVER() is not available when the page is past the end
+	if len(result.TestResults) == 0 && pagination.Offset > 0 {
+		if err := s.conn.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM test_results WHERE run_id = $1`, runID,
+		).Scan(&result.Total); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCorrelationQueryFailed, err)
+		}
+	}
+
+	s.logger.Debug("Queried job run test results",
+		slog.Duration("duration", time.Since(start)),
+		slog.String("run_id", runID),
+		slog.Int("returned_count", len(result.TestResults)),
+		slog.Int("total_count", result.Total))
+
+	return result, nil
+}
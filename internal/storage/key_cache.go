@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Validation cache defaults for PersistentKeyStore.
+const (
+	// DefaultKeyCacheSize is the maximum number of validated keys kept in memory.
+	DefaultKeyCacheSize = 1000
+	// DefaultKeyCacheTTL bounds how long a successful bcrypt verification is reused.
+	DefaultKeyCacheTTL = 5 * time.Minute
+)
+
+type (
+	// keyValidationCache is a bounded LRU of successful bcrypt verifications.
+	//
+	// Entries are keyed by the SHA256 lookup hash of the presented key and remember the
+	// bcrypt hash the key was verified against. A cache hit only counts when the database row
+	// still carries the same bcrypt hash, so key rotation invalidates entries implicitly.
+	// Deactivation is enforced by the authentication layer from the fresh database row;
+	// Update/Delete additionally evict entries by key ID.
+	//
+	// Only positive results are cached. Failed verifications always pay the full bcrypt cost,
+	// so the cache cannot be used to distinguish invalid keys by response time.
+	//
+	// Thread-safe: all methods may be called concurrently.
+	keyValidationCache struct {
+		mu       sync.Mutex
+		capacity int
+		ttl      time.Duration
+		order    *list.List               // Front = most recently used
+		entries  map[string]*list.Element // lookupHash -> element holding *keyCacheEntry
+		now      func() time.Time         // Injectable clock for tests
+	}
+
+	// keyCacheEntry is a single verified key.
+	keyCacheEntry struct {
+		lookupHash string
+		keyID      string
+		keyHash    string // bcrypt hash the presented key was verified against
+		expiresAt  time.Time
+	}
+)
+
+// newKeyValidationCache creates an LRU cache. Returns nil (caching disabled) when
+// capacity or ttl is not positive; all methods are nil-safe.
+func newKeyValidationCache(capacity int, ttl time.Duration) *keyValidationCache {
+	if capacity <= 0 || ttl <= 0 {
+		return nil
+	}
+
+	return &keyValidationCache{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element, capacity),
+		now:      time.Now,
+	}
+}
+
+// verified reports whether the key identified by lookupHash was recently verified
+// against keyHash. Expired or mismatched entries are evicted.
+func (c *keyValidationCache) verified(lookupHash, keyHash string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[lookupHash]
+	if !ok {
+		return false
+	}
+
+	entry, _ := elem.Value.(*keyCacheEntry)
+	if entry.keyHash != keyHash || c.now().After(entry.expiresAt) {
+		c.removeElement(elem)
+
+		return false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return true
+}
+
+// store records a successful verification, evicting the least recently used entry when full.
+func (c *keyValidationCache) store(lookupHash, keyID, keyHash string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &keyCacheEntry{
+		lookupHash: lookupHash,
+		keyID:      keyID,
+		keyHash:    keyHash,
+		expiresAt:  c.now().Add(c.ttl),
+	}
+
+	if elem, ok := c.entries[lookupHash]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	if c.order.Len() >= c.capacity {
+		if oldest := c.order.Back(); oldest != nil {
+			c.removeElement(oldest)
+		}
+	}
+
+	c.entries[lookupHash] = c.order.PushFront(entry)
+}
+
+// invalidateKeyID evicts every entry belonging to the given API key ID.
+// Called on Update (deactivation, permission change) and Delete.
+func (c *keyValidationCache) invalidateKeyID(keyID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+
+		if entry, _ := elem.Value.(*keyCacheEntry); entry.keyID == keyID {
+			c.removeElement(elem)
+		}
+
+		elem = next
+	}
+}
+
+// len returns the number of cached entries.
+func (c *keyValidationCache) len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// removeElement deletes an element from both the list and the index. Caller must hold mu.
+func (c *keyValidationCache) removeElement(elem *list.Element) {
+	entry, _ := elem.Value.(*keyCacheEntry)
+	delete(c.entries, entry.lookupHash)
+	c.order.Remove(elem)
+}
@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestKeyValidationCache_Disabled(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	if c := newKeyValidationCache(0, time.Minute); c != nil {
+		t.Errorf("newKeyValidationCache(0, ttl) = %v, want nil", c)
+	}
+
+	if c := newKeyValidationCache(10, 0); c != nil {
+		t.Errorf("newKeyValidationCache(size, 0) = %v, want nil", c)
+	}
+
+	// nil cache must be safe to use and never report a hit
+	var c *keyValidationCache
+
+	c.store("lookup", "key-1", "hash")
+	c.invalidateKeyID("key-1")
+
+	if c.verified("lookup", "hash") {
+		t.Error("nil cache verified() = true, want false")
+	}
+}
+
+func TestKeyValidationCache_HitRequiresSameHash(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	c := newKeyValidationCache(10, time.Minute)
+	c.store("lookup", "key-1", "hash-v1")
+
+	if !c.verified("lookup", "hash-v1") {
+		t.Error("verified() = false for cached key, want true")
+	}
+
+	// Rotation: the stored bcrypt hash changed, so the cached verification no longer applies
+	if c.verified("lookup", "hash-v2") {
+		t.Error("verified() = true after rotation, want false")
+	}
+
+	if c.len() != 0 {
+		t.Errorf("len() = %d after rotation miss, want 0", c.len())
+	}
+}
+
+func TestKeyValidationCache_Expires(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	now := time.Now()
+	c := newKeyValidationCache(10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.store("lookup", "key-1", "hash")
+
+	now = now.Add(59 * time.Second)
+
+	if !c.verified("lookup", "hash") {
+		t.Error("verified() = false before TTL, want true")
+	}
+
+	now = now.Add(2 * time.Second)
+
+	if c.verified("lookup", "hash") {
+		t.Error("verified() = true after TTL, want false")
+	}
+}
+
+func TestKeyValidationCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	c := newKeyValidationCache(2, time.Minute)
+	c.store("a", "key-a", "hash-a")
+	c.store("b", "key-b", "hash-b")
+
+	// Touch "a" so "b" becomes least recently used
+	c.verified("a", "hash-a")
+	c.store("c", "key-c", "hash-c")
+
+	if c.len() != 2 {
+		t.Errorf("len() = %d, want 2", c.len())
+	}
+
+	if !c.verified("a", "hash-a") {
+		t.Error("recently used entry was evicted")
+	}
+
+	if c.verified("b", "hash-b") {
+		t.Error("least recently used entry was not evicted")
+	}
+
+	if !c.verified("c", "hash-c") {
+		t.Error("newest entry missing")
+	}
+}
+
+func TestKeyValidationCache_InvalidateKeyID(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	c := newKeyValidationCache(10, time.Minute)
+	c.store("a", "key-1", "hash-a")
+	c.store("b", "key-2", "hash-b")
+
+	c.invalidateKeyID("key-1")
+
+	if c.verified("a", "hash-a") {
+		t.Error("verified() = true after invalidation, want false")
+	}
+
+	if !c.verified("b", "hash-b") {
+		t.Error("invalidation removed an unrelated key")
+	}
+}
+
+func TestPersistentKeyStore_VerifyKeyHashCachesOnlySuccess(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	hash, err := HashAPIKey(testAPIKey)
+	if err != nil {
+		t.Fatalf("HashAPIKey() error = %v", err)
+	}
+
+	store, err := NewPersistentKeyStore(nil)
+	if err != nil {
+		t.Fatalf("NewPersistentKeyStore() error = %v", err)
+	}
+
+	lookupHash := ComputeKeyLookupHash(testAPIKey)
+	apiKey := &APIKey{ID: "key-1", Key: hash}
+
+	if store.verifyKeyHash(lookupHash, apiKey, "sk-wrong-key") {
+		t.Fatal("verifyKeyHash() = true for wrong key, want false")
+	}
+
+	if store.cache.len() != 0 {
+		t.Fatalf("cache len = %d after failed verification, want 0 (negative results are never cached)",
+			store.cache.len())
+	}
+
+	if !store.verifyKeyHash(lookupHash, apiKey, testAPIKey) {
+		t.Fatal("verifyKeyHash() = false for valid key, want true")
+	}
+
+	if !store.cache.verified(lookupHash, hash) {
+		t.Error("successful verification was not cached")
+	}
+}
+
+func TestNewPersistentKeyStore_CacheOptions(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store, _ := NewPersistentKeyStore(nil)
+	if store.cache == nil || store.cache.capacity != DefaultKeyCacheSize || store.cache.ttl != DefaultKeyCacheTTL {
+		t.Errorf("default cache = %+v, want size=%d ttl=%v", store.cache, DefaultKeyCacheSize, DefaultKeyCacheTTL)
+	}
+
+	store, _ = NewPersistentKeyStore(nil, WithKeyCacheSize(5), WithKeyCacheTTL(time.Second))
+	if store.cache == nil || store.cache.capacity != 5 || store.cache.ttl != time.Second {
+		t.Errorf("custom cache = %+v, want size=5 ttl=1s", store.cache)
+	}
+
+	store, _ = NewPersistentKeyStore(nil, WithKeyCacheSize(0))
+	if store.cache != nil {
+		t.Error("WithKeyCacheSize(0) should disable the cache")
+	}
+}
+
+// BenchmarkPersistentKeyStore_VerifyKey compares a bcrypt compare on every request
+// (cache disabled) against a warm validation cache.
+func BenchmarkPersistentKeyStore_VerifyKey(b *testing.B) {
+	hash, err := HashAPIKey(testAPIKey)
+	if err != nil {
+		b.Fatalf("HashAPIKey() error = %v", err)
+	}
+
+	lookupHash := ComputeKeyLookupHash(testAPIKey)
+
+	for _, size := range []int{0, DefaultKeyCacheSize} {
+		b.Run(fmt.Sprintf("cache_size=%d", size), func(b *testing.B) {
+			store, _ := NewPersistentKeyStore(nil, WithKeyCacheSize(size))
+			apiKey := &APIKey{ID: "key-1", Key: hash}
+
+			b.ResetTimer()
+
+			for range b.N {
+				if !store.verifyKeyHash(lookupHash, apiKey, testAPIKey) {
+					b.Fatal("verifyKeyHash() = false for valid key")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// keyOutputFileMode restricts the provisioned key output file to the owner.
+const keyOutputFileMode = 0o600
+
+var (
+	// ErrInvalidKeySpec is returned by LoadKeySpecs for an entry without plugin_id or name,
+	// with a negative quota, or a (plugin_id, name) pair declared twice.
+	ErrInvalidKeySpec = errors.New("invalid API key spec")
+
+	// ErrKeyOutputExists is returned by WriteProvisionedKeys when the output file already exists.
+	// Collect the keys from it and delete it before provisioning more.
+	ErrKeyOutputExists = errors.New("API key output file already exists")
+)
+
+type (
+	// KeySpec declares an API key to provision at startup.
+	// A key is identified by (PluginID, Name); PluginID becomes the key's client ID.
+	// The quotas cap the events the plugin may ingest (see EventQuota; 0 = unlimited).
+	KeySpec struct {
+		PluginID          string   `json:"plugin_id"                     yaml:"plugin_id"` //nolint:tagliatelle
+		Name              string   `json:"name"                          yaml:"name"`
+		Permissions       []string `json:"permissions"                   yaml:"permissions"`
+		DailyEventQuota   int64    `json:"daily_event_quota,omitempty"   yaml:"daily_event_quota"`   //nolint:tagliatelle
+		MonthlyEventQuota int64    `json:"monthly_event_quota,omitempty" yaml:"monthly_event_quota"` //nolint:tagliatelle
+	}
+
+	// ProvisionedKey is a key created by ProvisionKeys. Key is the plaintext value, which is
+	// only available at creation time.
+	ProvisionedKey struct {
+		KeySpec
+
+		ID  string `json:"key_id"` //nolint:tagliatelle
+		Key string `json:"key"`
+	}
+
+	// keySpecFile is the layout of the key provisioning file.
+	keySpecFile struct {
+		APIKeys []KeySpec `yaml:"api_keys"` //nolint:tagliatelle
+	}
+)
+
+// LoadKeySpecs reads the API keys to provision from a YAML file:
+//
+//	api_keys:
+//	  - plugin_id: dbt
+//	    name: dbt production
+//	    permissions: [lineage:write]
+//	    daily_event_quota: 1000000   # optional
+//
+// Unlike the optional pattern config, a configured file that is missing is an error.
+// Permissions default to lineage:write when omitted.
+func LoadKeySpecs(path string) ([]KeySpec, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is from trusted config source
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	var file keySpecFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidKeySpec, path, err)
+	}
+
+	seen := make(map[string]bool, len(file.APIKeys))
+
+	for i := range file.APIKeys {
+		spec := &file.APIKeys[i]
+		spec.PluginID = strings.TrimSpace(spec.PluginID)
+		spec.Name = strings.TrimSpace(spec.Name)
+
+		if spec.PluginID == "" || spec.Name == "" {
+			return nil, fmt.Errorf("%w: entry %d needs plugin_id and name", ErrInvalidKeySpec, i+1)
+		}
+
+		if spec.DailyEventQuota < 0 || spec.MonthlyEventQuota < 0 {
+			return nil, fmt.Errorf("%w: %s/%s has a negative quota", ErrInvalidKeySpec, spec.PluginID, spec.Name)
+		}
+
+		id := spec.PluginID + "\x00" + spec.Name
+		if seen[id] {
+			return nil, fmt.Errorf("%w: %s/%s declared twice", ErrInvalidKeySpec, spec.PluginID, spec.Name)
+		}
+
+		seen[id] = true
+
+		if len(spec.Permissions) == 0 {
+			spec.Permissions = []string{"lineage:write"}
+		}
+	}
+
+	return file.APIKeys, nil
+}
+
+// ProvisionKeys creates a key for every spec that has no key yet and returns the created keys.
+//
+// Idempotent: a spec is satisfied by any existing key with the same client ID (plugin_id) and
+// name, active or not, so keys are never regenerated and revoked keys stay revoked. Permissions
+// of existing keys are left unchanged.
+//
+// deliver receives the new plaintext keys before any of them is stored, so a key can never
+// exist without its plaintext having been handed over; if deliver fails nothing is stored.
+// deliver is not called when every spec already has a key.
+func ProvisionKeys(
+	ctx context.Context,
+	store APIKeyStore,
+	specs []KeySpec,
+	deliver func([]ProvisionedKey) error,
+) ([]ProvisionedKey, error) {
+	var pending []ProvisionedKey
+
+	for _, spec := range specs {
+		_, err := store.FindByName(ctx, spec.PluginID, spec.Name)
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, ErrKeyNotFound) {
+			return nil, fmt.Errorf("failed to look up key %s/%s: %w", spec.PluginID, spec.Name, err)
+		}
+
+		plaintext, err := GenerateAPIKey()
+		if err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, ProvisionedKey{KeySpec: spec, ID: uuid.New().String(), Key: plaintext})
+	}
+
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	if err := deliver(pending); err != nil {
+		return nil, fmt.Errorf("failed to deliver provisioned keys: %w", err)
+	}
+
+	created := make([]ProvisionedKey, 0, len(pending))
+
+	for _, key := range pending {
+		apiKey := &APIKey{
+			ID:          key.ID,
+			Key:         key.Key,
+			ClientID:    key.PluginID,
+			Name:        key.Name,
+			Permissions: key.Permissions,
+			CreatedAt:   time.Now(),
+			Active:      true,
+			Quota:       EventQuota{Daily: key.DailyEventQuota, Monthly: key.MonthlyEventQuota},
+		}
+
+		if err := store.Add(ctx, apiKey); err != nil {
+			return created, fmt.Errorf("failed to add key %s/%s: %w", key.PluginID, key.Name, err)
+		}
+
+		created = append(created, key)
+	}
+
+	return created, nil
+}
+
+// WriteProvisionedKeys writes keys as JSON to a new file readable only by the owner; use it as
+// the ProvisionKeys deliver function. The file is never overwritten (ErrKeyOutputExists), so
+// plaintext keys from an earlier boot that were not collected yet cannot be lost.
+func WriteProvisionedKeys(path string, keys []ProvisionedKey) error {
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode provisioned keys: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, keyOutputFileMode) //nolint:gosec // trusted path
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%w: %s", ErrKeyOutputExists, path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to create API key output file: %w", err)
+	}
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("failed to write API key output file: %w", err)
+	}
+
+	return f.Close()
+}
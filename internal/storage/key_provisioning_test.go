@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDeliveryFailed = errors.New("delivery failed")
+
+func writeKeySpecFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "api-keys.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadKeySpecs(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	t.Run("parses specs and defaults permissions", func(t *testing.T) {
+		path := writeKeySpecFile(t, `
+api_keys:
+  - plugin_id: dbt
+    name: dbt production
+    permissions: [lineage:write, lineage:read]
+    daily_event_quota: 1000000
+  - plugin_id: airflow
+    name: " airflow prod "
+`)
+
+		specs, err := LoadKeySpecs(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, []KeySpec{
+			{
+				PluginID: "dbt", Name: "dbt production", Permissions: []string{"lineage:write", "lineage:read"},
+				DailyEventQuota: 1000000,
+			},
+			{PluginID: "airflow", Name: "airflow prod", Permissions: []string{"lineage:write"}},
+		}, specs)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadKeySpecs(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		_, err := LoadKeySpecs(writeKeySpecFile(t, "api_keys:\n  - plugin_id: dbt\n"))
+		require.ErrorIs(t, err, ErrInvalidKeySpec)
+	})
+
+	t.Run("duplicate spec", func(t *testing.T) {
+		_, err := LoadKeySpecs(writeKeySpecFile(t, `
+api_keys:
+  - {plugin_id: dbt, name: prod}
+  - {plugin_id: dbt, name: prod}
+`))
+		require.ErrorIs(t, err, ErrInvalidKeySpec)
+	})
+
+	t.Run("negative quota", func(t *testing.T) {
+		_, err := LoadKeySpecs(writeKeySpecFile(t, `
+api_keys:
+  - {plugin_id: dbt, name: prod, monthly_event_quota: -1}
+`))
+		require.ErrorIs(t, err, ErrInvalidKeySpec)
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		_, err := LoadKeySpecs(writeKeySpecFile(t, "api_keys: [unterminated"))
+		require.ErrorIs(t, err, ErrInvalidKeySpec)
+	})
+}
+
+func TestProvisionKeys(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	ctx := context.Background()
+	specs := []KeySpec{
+		{PluginID: "dbt", Name: "dbt production", Permissions: []string{"lineage:write"}, DailyEventQuota: 1000},
+		{PluginID: "airflow", Name: "airflow production", Permissions: []string{"lineage:write"}},
+	}
+
+	collect := func(delivered *[]ProvisionedKey) func([]ProvisionedKey) error {
+		return func(keys []ProvisionedKey) error {
+			*delivered = append(*delivered, keys...)
+
+			return nil
+		}
+	}
+
+	t.Run("creates missing keys and is idempotent", func(t *testing.T) {
+		store := NewInMemoryKeyStore()
+
+		var delivered []ProvisionedKey
+
+		created, err := ProvisionKeys(ctx, store, specs, collect(&delivered))
+		require.NoError(t, err)
+		require.Len(t, created, 2)
+		assert.Equal(t, created, delivered)
+
+		apiKey, ok := store.FindByKey(ctx, created[0].Key)
+		require.True(t, ok, "provisioned plaintext key authenticates")
+		assert.Equal(t, "dbt", apiKey.ClientID)
+		assert.Equal(t, "dbt production", apiKey.Name)
+		assert.Equal(t, EventQuota{Daily: 1000}, apiKey.Quota)
+
+		created, err = ProvisionKeys(ctx, store, specs, func([]ProvisionedKey) error {
+			t.Fatal("deliver must not be called when every key exists")
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Empty(t, created)
+	})
+
+	t.Run("revoked key is not regenerated", func(t *testing.T) {
+		store := NewInMemoryKeyStore()
+
+		created, err := ProvisionKeys(ctx, store, specs[:1], collect(new([]ProvisionedKey)))
+		require.NoError(t, err)
+
+		apiKey, err := store.FindByID(ctx, created[0].ID)
+		require.NoError(t, err)
+
+		apiKey.Active = false
+		require.NoError(t, store.Update(ctx, apiKey))
+
+		created, err = ProvisionKeys(ctx, store, specs[:1], collect(new([]ProvisionedKey)))
+		require.NoError(t, err)
+		assert.Empty(t, created)
+	})
+
+	t.Run("nothing stored when delivery fails", func(t *testing.T) {
+		store := NewInMemoryKeyStore()
+
+		_, err := ProvisionKeys(ctx, store, specs, func([]ProvisionedKey) error { return errDeliveryFailed })
+		require.ErrorIs(t, err, errDeliveryFailed)
+
+		keys, err := store.ListByClientID(ctx, "dbt")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+}
+
+func TestWriteProvisionedKeys(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	path := filepath.Join(t.TempDir(), "provisioned-keys.json")
+	keys := []ProvisionedKey{{
+		KeySpec: KeySpec{PluginID: "dbt", Name: "dbt production", Permissions: []string{"lineage:write"}},
+		ID:      "key-1",
+		Key:     "correlator_ak_secret", // pragma: allowlist secret
+	}}
+
+	require.NoError(t, WriteProvisionedKeys(path, keys))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var written []map[string]any
+
+	require.NoError(t, json.Unmarshal(data, &written))
+	require.Len(t, written, 1)
+	assert.Equal(t, "dbt", written[0]["plugin_id"])
+	assert.Equal(t, "key-1", written[0]["key_id"])
+	assert.Equal(t, "correlator_ak_secret", written[0]["key"]) // pragma: allowlist secret
+
+	err = WriteProvisionedKeys(path, keys)
+	require.ErrorIs(t, err, ErrKeyOutputExists, "existing output is never overwritten")
+}
@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lineage batch statuses (lineage_batches.status).
+const (
+	LineageBatchPending    = "pending"
+	LineageBatchProcessing = "processing"
+	LineageBatchDone       = "done"
+	LineageBatchFailed     = "failed"
+)
+
+// lineageBatchRetention is how long completed batches (and their results) stay pollable.
+const lineageBatchRetention = 7 * 24 * time.Hour
+
+// ErrLineageBatchNotClaimed is returned when a progress or completion update targets a batch
+// that is no longer processing (e.g., it went stale and another worker reclaimed it).
+var ErrLineageBatchNotClaimed = errors.New("lineage batch is not being processed")
+
+type (
+	// LineageBatch is the tracking state of an async lineage batch, as returned to pollers.
+	// Result is the stored batch response once Status is done; Error is set once it is failed.
+	LineageBatch struct {
+		ID               string
+		Status           string
+		ClientID         string
+		CorrelationID    string
+		TotalEvents      int
+		ProcessedEvents  int
+		ProcessingEvents int
+		Result           json.RawMessage
+		Error            string
+		CreatedAt        time.Time
+		StartedAt        *time.Time
+		CompletedAt      *time.Time
+	}
+
+	// ClaimedLineageBatch is a batch claimed for processing, with its submitted events.
+	ClaimedLineageBatch struct {
+		ID            string
+		ClientID      string // Submitter (API key client ID, empty when auth is disabled)
+		CorrelationID string
+		Events        json.RawMessage
+		TotalEvents   int
+		Backfill      bool // Events skip the event time skew check
+	}
+)
+
+// CreateLineageBatch queues a JSON array of totalEvents OpenLineage events for async processing
+// and returns the new batch ID. The batch is pending until a worker claims it.
+// backfill is handed to the worker with the events (see ClaimedLineageBatch).
+func (s *LineageStore) CreateLineageBatch(
+	ctx context.Context,
+	clientID, correlationID string,
+	events json.RawMessage,
+	totalEvents int,
+	backfill bool,
+) (string, error) {
+	if s.conn == nil {
+		return "", ErrNoDatabaseConnection
+	}
+
+	batchID := uuid.New().String()
+
+	_, err := s.conn.ExecContext(ctx, `
+		INSERT INTO lineage_batches (batch_id, client_id, correlation_id, events, total_events, backfill)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, batchID, clientID, correlationID, string(events), totalEvents, backfill)
+	if err != nil {
+		return "", fmt.Errorf("failed to create lineage batch: %w", err)
+	}
+
+	s.logger.Info("Lineage batch queued",
+		slog.String("batch_id", batchID),
+		slog.String("correlation_id", correlationID),
+		slog.Int("total_events", totalEvents))
+
+	return batchID, nil
+}
+
+// ClaimLineageBatch marks the oldest pending batch as processing and returns it, or nil when
+// there is nothing to do. A processing batch whose progress has not been updated for staleAfter
+// is claimed again (its worker is assumed dead), starting over from the first event.
+//
+// SKIP LOCKED lets several server instances claim concurrently without handing out a batch twice.
+func (s *LineageStore) ClaimLineageBatch(ctx context.Context, staleAfter time.Duration) (*ClaimedLineageBatch, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	var (
+		batch  ClaimedLineageBatch
+		events []byte
+	)
+
+	err := s.conn.QueryRowContext(ctx, `
+		UPDATE lineage_batches b
+		SET status = 'processing',
+			started_at = COALESCE(b.started_at, NOW()),
+			processed_events = 0,
+			processing_events = 0,
+			updated_at = NOW()
+		WHERE b.batch_id = (
+			SELECT batch_id FROM lineage_batches
+			WHERE status = 'pending'
+			   OR (status = 'processing' AND updated_at < NOW() - make_interval(secs => $1))
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING b.batch_id::text, b.client_id, b.correlation_id, b.events::text, b.total_events, b.backfill
+	`, staleAfter.Seconds()).Scan(
+		&batch.ID, &batch.ClientID, &batch.CorrelationID, &events, &batch.TotalEvents, &batch.Backfill,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil //nolint:nilnil // No pending batch is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim lineage batch: %w", err)
+	}
+
+	batch.Events = events
+
+	return &batch, nil
+}
+
+// UpdateLineageBatchProgress records how many events of a processing batch are done and how
+// many are being stored right now. Also serves as the worker's heartbeat (see ClaimLineageBatch).
+func (s *LineageStore) UpdateLineageBatchProgress(
+	ctx context.Context,
+	batchID string,
+	processed, processing int,
+) error {
+	return s.updateClaimedLineageBatch(ctx, `
+		UPDATE lineage_batches
+		SET processed_events = $2, processing_events = $3, updated_at = NOW()
+		WHERE batch_id = $1 AND status = 'processing'
+	`, batchID, processed, processing)
+}
+
+// CompleteLineageBatch marks a processing batch done with its batch response, and drops the
+// submitted events.
+func (s *LineageStore) CompleteLineageBatch(ctx context.Context, batchID string, result json.RawMessage) error {
+	return s.updateClaimedLineageBatch(ctx, `
+		UPDATE lineage_batches
+		SET status = 'done', result = $2, events = NULL,
+			processed_events = total_events, processing_events = 0,
+			completed_at = NOW(), updated_at = NOW()
+		WHERE batch_id = $1 AND status = 'processing'
+	`, batchID, string(result))
+}
+
+// FailLineageBatch marks a processing batch failed (the batch as a whole could not be
+// processed, e.g. an invalid event sequence), and drops the submitted events.
+func (s *LineageStore) FailLineageBatch(ctx context.Context, batchID, message string) error {
+	return s.updateClaimedLineageBatch(ctx, `
+		UPDATE lineage_batches
+		SET status = 'failed', error_message = $2, events = NULL, processing_events = 0,
+			completed_at = NOW(), updated_at = NOW()
+		WHERE batch_id = $1 AND status = 'processing'
+	`, batchID, message)
+}
+
+// ReleaseLineageBatch returns a processing batch to pending so another worker picks it up
+// without waiting for it to go stale. Used when processing is interrupted by shutdown.
+func (s *LineageStore) ReleaseLineageBatch(ctx context.Context, batchID string) error {
+	return s.updateClaimedLineageBatch(ctx, `
+		UPDATE lineage_batches
+		SET status = 'pending', processed_events = 0, processing_events = 0, updated_at = NOW()
+		WHERE batch_id = $1 AND status = 'processing'
+	`, batchID)
+}
+
+// updateClaimedLineageBatch runs an update guarded by status = 'processing'.
+// Returns ErrLineageBatchNotClaimed when no row matched.
+func (s *LineageStore) updateClaimedLineageBatch(ctx context.Context, query, batchID string, args ...any) error {
+	if s.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	result, err := s.conn.ExecContext(ctx, query, append([]any{batchID}, args...)...)
+	if err != nil {
+		return fmt.Errorf("failed to update lineage batch %s: %w", batchID, err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update lineage batch %s: %w", batchID, err)
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%w: %s", ErrLineageBatchNotClaimed, batchID)
+	}
+
+	return nil
+}
+
+// GetLineageBatch returns the tracking state of a batch, or nil if it does not exist
+// (never created, malformed ID, or deleted after the retention period).
+func (s *LineageStore) GetLineageBatch(ctx context.Context, batchID string) (*LineageBatch, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	if _, err := uuid.Parse(batchID); err != nil {
+		return nil, nil //nolint:nilnil // Not a batch ID, so no such batch
+	}
+
+	var (
+		batch    LineageBatch
+		result   []byte
+		errorMsg sql.NullString
+	)
+
+	err := s.conn.QueryRowContext(ctx, `
+		SELECT batch_id::text, status, client_id, correlation_id,
+			total_events, processed_events, processing_events,
+			result::text, error_message, created_at, started_at, completed_at
+		FROM lineage_batches
+		WHERE batch_id = $1
+	`, batchID).Scan(
+		&batch.ID, &batch.Status, &batch.ClientID, &batch.CorrelationID,
+		&batch.TotalEvents, &batch.ProcessedEvents, &batch.ProcessingEvents,
+		&result, &errorMsg, &batch.CreatedAt, &batch.StartedAt, &batch.CompletedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil //nolint:nilnil // Unknown batch is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lineage batch: %w", err)
+	}
+
+	batch.Result = result
+	batch.Error = errorMsg.String
+
+	return &batch, nil
+}
+
+// cleanupExpiredLineageBatches deletes batches completed more than lineageBatchRetention ago.
+// Called by runCleanup alongside the idempotency key cleanup.
+func (s *LineageStore) cleanupExpiredLineageBatches(ctx context.Context) {
+	if s.conn == nil {
+		return
+	}
+
+	result, err := s.conn.ExecContext(ctx, `
+		DELETE FROM lineage_batches
+		WHERE completed_at < NOW() - make_interval(secs => $1)
+	`, lineageBatchRetention.Seconds())
+	if err != nil {
+		s.logger.Error("Failed to delete expired lineage batches", slog.String("error", err.Error()))
+
+		return
+	}
+
+	if n, err := result.RowsAffected(); err == nil && n > 0 {
+		s.logger.Info("Deleted expired lineage batches", slog.Int64("rows_deleted", n))
+	}
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLineageBatches_Lifecycle verifies that a queued batch is claimed once, tracks progress,
+// and keeps its result (but not its events) once complete.
+func TestLineageBatches_Lifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	claimed, err := store.ClaimLineageBatch(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, claimed, "Empty queue should claim nothing")
+
+	events := json.RawMessage(`[{"eventType":"START"},{"eventType":"COMPLETE"}]`)
+
+	batchID, err := store.CreateLineageBatch(ctx, "client-a", "corr-1", events, 2, true)
+	require.NoError(t, err)
+
+	batch, err := store.GetLineageBatch(ctx, batchID)
+	require.NoError(t, err)
+	require.NotNil(t, batch)
+	assert.Equal(t, LineageBatchPending, batch.Status)
+	assert.Equal(t, "client-a", batch.ClientID)
+	assert.Equal(t, 2, batch.TotalEvents)
+	assert.Nil(t, batch.StartedAt)
+
+	claimed, err = store.ClaimLineageBatch(ctx, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, batchID, claimed.ID)
+	assert.Equal(t, "corr-1", claimed.CorrelationID)
+	assert.JSONEq(t, string(events), string(claimed.Events))
+	assert.True(t, claimed.Backfill)
+
+	again, err := store.ClaimLineageBatch(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, again, "A batch being processed must not be claimed twice")
+
+	require.NoError(t, store.UpdateLineageBatchProgress(ctx, batchID, 1, 1))
+
+	batch, err = store.GetLineageBatch(ctx, batchID)
+	require.NoError(t, err)
+	assert.Equal(t, LineageBatchProcessing, batch.Status)
+	assert.Equal(t, 1, batch.ProcessedEvents)
+	assert.Equal(t, 1, batch.ProcessingEvents)
+	assert.NotNil(t, batch.StartedAt)
+
+	require.NoError(t, store.CompleteLineageBatch(ctx, batchID, json.RawMessage(`{"status":"success"}`)))
+
+	batch, err = store.GetLineageBatch(ctx, batchID)
+	require.NoError(t, err)
+	assert.Equal(t, LineageBatchDone, batch.Status)
+	assert.Equal(t, 2, batch.ProcessedEvents)
+	assert.Equal(t, 0, batch.ProcessingEvents)
+	assert.JSONEq(t, `{"status":"success"}`, string(batch.Result))
+	assert.NotNil(t, batch.CompletedAt)
+
+	assert.Equal(t, 0, countRows(ctx, t, store,
+		"SELECT COUNT(*) FROM lineage_batches WHERE batch_id = $1 AND events IS NOT NULL", batchID),
+		"Events should be dropped once the batch is done")
+
+	err = store.UpdateLineageBatchProgress(ctx, batchID, 2, 0)
+	require.ErrorIs(t, err, ErrLineageBatchNotClaimed, "A done batch is no longer claimed")
+}
+
+// TestLineageBatches_ReleaseAndReclaim verifies that released and stale batches are claimed
+// again, and that a failed batch records its error.
+func TestLineageBatches_ReleaseAndReclaim(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	batchID, err := store.CreateLineageBatch(ctx, "client-a", "corr-1", json.RawMessage(`[{}]`), 1, false)
+	require.NoError(t, err)
+
+	claimed, err := store.ClaimLineageBatch(ctx, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+
+	require.NoError(t, store.ReleaseLineageBatch(ctx, batchID))
+
+	claimed, err = store.ClaimLineageBatch(ctx, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, claimed, "A released batch should be claimable again")
+
+	// Simulate a dead worker: no progress update for longer than staleAfter
+	_, err = store.conn.ExecContext(ctx,
+		"UPDATE lineage_batches SET updated_at = NOW() - INTERVAL '1 hour' WHERE batch_id = $1", batchID)
+	require.NoError(t, err)
+
+	claimed, err = store.ClaimLineageBatch(ctx, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, claimed, "A stale batch should be reclaimed")
+	assert.Equal(t, batchID, claimed.ID)
+
+	require.NoError(t, store.FailLineageBatch(ctx, batchID, "invalid event sequence"))
+
+	batch, err := store.GetLineageBatch(ctx, batchID)
+	require.NoError(t, err)
+	assert.Equal(t, LineageBatchFailed, batch.Status)
+	assert.Equal(t, "invalid event sequence", batch.Error)
+	assert.NotNil(t, batch.CompletedAt)
+}
+
+// TestLineageBatches_GetUnknown verifies that unknown and malformed batch IDs are not found.
+func TestLineageBatches_GetUnknown(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	for _, batchID := range []string{"6f1f6f5e-0000-4000-8000-000000000000", "not-a-uuid"} {
+		batch, err := store.GetLineageBatch(ctx, batchID)
+		require.NoError(t, err)
+		assert.Nil(t, batch, batchID)
+	}
+}
@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MinLineageSearchLength is the shortest query SearchLineage accepts. The trigram indexes
+	// (migration 017) only narrow substring matches of three or more characters; shorter
+	// queries would scan them whole.
+	MinLineageSearchLength = 3
+
+	// MaxLineageSearchLimit caps the results of one SearchLineage call.
+	MaxLineageSearchLimit = 100
+)
+
+// LineageSearchType selects what SearchLineage matches.
+type LineageSearchType string
+
+const (
+	// LineageSearchDatasets matches dataset URNs.
+	LineageSearchDatasets LineageSearchType = "dataset"
+
+	// LineageSearchJobs matches job names.
+	LineageSearchJobs LineageSearchType = "job"
+)
+
+// ErrLineageSearchTooShort is returned by SearchLineage for queries shorter than MinLineageSearchLength.
+var ErrLineageSearchTooShort = errors.New("search query must be at least 3 characters")
+
+// searchDatasetsQuery matches URNs with ILIKE so it is served by idx_datasets_urn_trgm.
+// similarity() is qualified with extensionSchema, where pg_trgm is installed.
+const searchDatasetsQuery = `
+	SELECT dataset_urn, name, namespace, COALESCE(last_producing_run_id::text, ''), updated_at,
+	       public.similarity(dataset_urn, $1) AS score
+	FROM datasets
+	WHERE dataset_urn ILIKE $2
+	ORDER BY score DESC, dataset_urn
+	LIMIT $3
+`
+
+// searchJobsQuery matches job names with ILIKE so it is served by idx_job_runs_job_name_trgm,
+// and returns each matching job once, with its latest run.
+const searchJobsQuery = `
+	SELECT job_name, COALESCE(job_namespace, ''), run_id::text, current_state, event_time,
+	       public.similarity(job_name, $1) AS score
+	FROM (
+		SELECT DISTINCT ON (job_namespace, job_name)
+		       job_name, job_namespace, run_id, current_state, event_time
+		FROM job_runs
+		WHERE job_name ILIKE $2
+		ORDER BY job_namespace, job_name, event_time DESC
+	) latest
+	ORDER BY score DESC, job_name, job_namespace
+	LIMIT $3
+`
+
+// LineageSearchResult is a dataset or job matched by SearchLineage, with what is needed to
+// navigate to it.
+type LineageSearchResult struct {
+	Type      LineageSearchType
+	Name      string
+	Namespace string
+
+	// DatasetURN is the dataset's URN (datasets only).
+	DatasetURN string
+
+	// RunID is the latest run of a job, or the run that last produced a dataset ("" when no run
+	// wrote it).
+	RunID string
+
+	// State is the current state of the job's latest run (jobs only).
+	State string
+
+	// UpdatedAt is when the dataset was last updated, or the event time of the job's latest run.
+	UpdatedAt time.Time
+
+	// Score is the trigram similarity of the query to the URN or job name (0-1, higher first).
+	Score float64
+}
+
+// SearchLineage finds datasets whose URN and jobs whose name contain query (case-insensitive),
+// ranked by trigram similarity to the whole URN or job name, so the closest names come first
+// (analytics.orders above analytics.orders_archive). searchType limits the search to datasets or
+// jobs; "" searches both. limit is capped at MaxLineageSearchLimit (<= 0 uses the max).
+//
+// Index-backed by the trigram indexes of migration 017, so substring search stays fast over
+// millions of rows. Queries shorter than MinLineageSearchLength return ErrLineageSearchTooShort.
+func (s *LineageStore) SearchLineage(
+	ctx context.Context,
+	query string,
+	searchType LineageSearchType,
+	limit int,
+) ([]LineageSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) < MinLineageSearchLength {
+		return nil, ErrLineageSearchTooShort
+	}
+
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	if limit <= 0 || limit > MaxLineageSearchLimit {
+		limit = MaxLineageSearchLimit
+	}
+
+	start := time.Now()
+	pattern := "%" + escapeLikePattern(query) + "%"
+	results := make([]LineageSearchResult, 0)
+
+	if searchType == "" || searchType == LineageSearchDatasets {
+		datasets, err := s.searchDatasets(ctx, query, pattern, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, datasets...)
+	}
+
+	if searchType == "" || searchType == LineageSearchJobs {
+		jobs, err := s.searchJobs(ctx, query, pattern, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, jobs...)
+	}
+
+	// Each search is already ranked; merging both keeps the best matches of either
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	s.logger.Debug("Searched lineage",
+		slog.String("query", query),
+		slog.String("type", string(searchType)),
+		slog.Int("count", len(results)),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	return results, nil
+}
+
+// searchDatasets returns up to limit datasets whose URN matches pattern, ranked by similarity to query.
+func (s *LineageStore) searchDatasets(
+	ctx context.Context,
+	query, pattern string,
+	limit int,
+) ([]LineageSearchResult, error) {
+	rows, err := s.conn.QueryContext(ctx, searchDatasetsQuery, query, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search datasets: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var results []LineageSearchResult
+
+	for rows.Next() {
+		var (
+			r         = LineageSearchResult{Type: LineageSearchDatasets}
+			updatedAt sql.NullTime
+		)
+
+		if err := rows.Scan(&r.DatasetURN, &r.Name, &r.Namespace, &r.RunID, &updatedAt, &r.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan dataset: %w", err)
+		}
+
+		r.UpdatedAt = updatedAt.Time
+		results = append(results, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating datasets: %w", err)
+	}
+
+	return results, nil
+}
+
+// searchJobs returns up to limit jobs whose name matches pattern, ranked by similarity to query.
+func (s *LineageStore) searchJobs(
+	ctx context.Context,
+	query, pattern string,
+	limit int,
+) ([]LineageSearchResult, error) {
+	rows, err := s.conn.QueryContext(ctx, searchJobsQuery, query, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search jobs: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var results []LineageSearchResult
+
+	for rows.Next() {
+		r := LineageSearchResult{Type: LineageSearchJobs}
+
+		if err := rows.Scan(&r.Name, &r.Namespace, &r.RunID, &r.State, &r.UpdatedAt, &r.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+
+		results = append(results, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+
+	return results, nil
+}
+
+// escapeLikePattern escapes the LIKE wildcards in s, so "customer_pii" matches an underscore
+// rather than any character.
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestSearchLineage verifies substring search over dataset URNs and job names, its ranking, and
+// that both queries are served by the trigram indexes.
+func TestSearchLineage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	dataset := func(name string) ingestion.Dataset {
+		return ingestion.Dataset{Namespace: "postgresql://prod-db:5432", Name: name, Facets: ingestion.Facets{}}
+	}
+
+	// load_orders runs twice: the search lists the job once, with its latest run
+	first := createTestEventWithTime("search-first", ingestion.EventTypeComplete, 0, 0, time.Now().Add(-time.Hour))
+	first.Job.Name = "load_orders"
+	first.Outputs = []ingestion.Dataset{dataset("analytics.public.orders")}
+
+	latest := createTestEventWithTime("search-latest", ingestion.EventTypeFail, 0, 0, time.Now().Add(-time.Minute))
+	latest.Job.Name = "load_orders"
+	latest.Inputs = []ingestion.Dataset{dataset("analytics.public.orders_archive")}
+
+	pii := createTestEvent("search-pii", ingestion.EventTypeComplete, 0, 0)
+	pii.Job.Name = "mask_customers"
+	pii.Outputs = []ingestion.Dataset{dataset("analytics.public.customer_pii"), dataset("analytics.public.customerXpii")}
+
+	for _, event := range []*ingestion.RunEvent{first, latest, pii} {
+		_, _, err := store.StoreEvent(ctx, event)
+		require.NoError(t, err)
+	}
+
+	t.Run("Datasets", func(t *testing.T) {
+		results, err := store.SearchLineage(ctx, "ORDERS", LineageSearchDatasets, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, "postgresql://prod-db/analytics.public.orders", results[0].DatasetURN)
+		assert.Equal(t, "analytics.public.orders", results[0].Name)
+		assert.Equal(t, first.Run.ID, results[0].RunID, "dataset links to the run that produced it")
+		assert.Equal(t, "postgresql://prod-db/analytics.public.orders_archive", results[1].DatasetURN)
+		assert.Empty(t, results[1].RunID, "dataset only read has no producing run")
+		assert.Greater(t, results[0].Score, results[1].Score, "exact name ranks above a longer one")
+	})
+
+	t.Run("UnderscoreIsLiteral", func(t *testing.T) {
+		results, err := store.SearchLineage(ctx, "customer_pii", LineageSearchDatasets, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "postgresql://prod-db/analytics.public.customer_pii", results[0].DatasetURN)
+	})
+
+	t.Run("JobsListedOnceWithLatestRun", func(t *testing.T) {
+		results, err := store.SearchLineage(ctx, "orders", LineageSearchJobs, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+
+		assert.Equal(t, LineageSearchJobs, results[0].Type)
+		assert.Equal(t, "load_orders", results[0].Name)
+		assert.Equal(t, "dbt://analytics", results[0].Namespace)
+		assert.Equal(t, latest.Run.ID, results[0].RunID)
+		assert.Equal(t, "FAIL", results[0].State)
+	})
+
+	t.Run("AllTypesAndLimit", func(t *testing.T) {
+		results, err := store.SearchLineage(ctx, "orders", "", 10)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+
+		results, err = store.SearchLineage(ctx, "orders", "", 2)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+
+		results, err = store.SearchLineage(ctx, "invoices", "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("QueriesUseTrigramIndexes", func(t *testing.T) {
+		for query, index := range map[string]string{
+			searchDatasetsQuery: "idx_datasets_urn_trgm",
+			searchJobsQuery:     "idx_job_runs_job_name_trgm",
+		} {
+			tx, err := store.conn.BeginTx(ctx, nil)
+			require.NoError(t, err)
+
+			// A few rows are cheaper to scan; disable seq scans so the planner shows it can use the index
+			_, err = tx.ExecContext(ctx, `SET LOCAL enable_seqscan = off`)
+			require.NoError(t, err)
+
+			rows, err := tx.QueryContext(ctx, "EXPLAIN "+query, "orders", "%orders%", MaxLineageSearchLimit)
+			require.NoError(t, err)
+
+			var plan strings.Builder
+
+			for rows.Next() {
+				var line string
+				require.NoError(t, rows.Scan(&line))
+				plan.WriteString(line + "\n")
+			}
+
+			require.NoError(t, rows.Err())
+			require.NoError(t, rows.Close())
+			require.NoError(t, tx.Rollback())
+
+			assert.Contains(t, plan.String(), index, "plan:\n%s", plan.String())
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchLineage_RequiresMinimumLength(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &LineageStore{} // No connection: short queries must be rejected before touching the database
+
+	for _, query := range []string{"", "or", "  or  ", "日本"} {
+		_, err := store.SearchLineage(context.Background(), query, "", 10)
+		require.ErrorIs(t, err, ErrLineageSearchTooShort, "query %q", query)
+	}
+}
+
+func TestEscapeLikePattern(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	assert.Equal(t, "orders", escapeLikePattern("orders"))
+	assert.Equal(t, `customer\_pii`, escapeLikePattern("customer_pii"))
+	assert.Equal(t, `100\%\\done`, escapeLikePattern(`100%\done`))
+}
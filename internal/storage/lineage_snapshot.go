@@ -0,0 +1,424 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// Lineage snapshot stream format (see Export).
+const (
+	snapshotFormat  = "correlator-lineage-snapshot"
+	snapshotVersion = 1
+
+	snapshotRecordHeader         = "header"
+	snapshotRecordJobRun         = "job_run"
+	snapshotRecordDataset        = "dataset"
+	snapshotRecordLineageEdge    = "lineage_edge"
+	snapshotRecordIdempotencyKey = "idempotency_key"
+	snapshotRecordFooter         = "footer"
+)
+
+// snapshotRecordOrder is the position of each data record type in a snapshot stream.
+// Job runs come first because datasets.last_producing_run_id references them (not deferrable),
+// then datasets, then the edges and idempotency keys that reference both.
+//
+//nolint:gochecknoglobals
+var snapshotRecordOrder = map[string]int{
+	snapshotRecordJobRun:         1,
+	snapshotRecordDataset:        2,
+	snapshotRecordLineageEdge:    3,
+	snapshotRecordIdempotencyKey: 4,
+}
+
+// snapshotRunsCTE selects the job runs of a snapshot. $1/$2 bound event_time (NULL = unbounded),
+// $3 is the job namespace (empty = all namespaces).
+const snapshotRunsCTE = `
+	WITH snapshot_runs AS (
+		SELECT run_id FROM job_runs
+		WHERE ($1::timestamptz IS NULL OR event_time >= $1)
+		  AND ($2::timestamptz IS NULL OR event_time < $2)
+		  AND ($3::text = '' OR job_namespace = $3)
+	)
+`
+
+// Export queries, one per record type in stream order. Every row is encoded by PostgreSQL
+// (row_to_json), so columns added by later migrations are exported without code changes.
+//
+//nolint:gochecknoglobals
+var snapshotExportQueries = []struct {
+	recordType string
+	query      string
+}{
+	{snapshotRecordJobRun, snapshotRunsCTE + `
+		SELECT row_to_json(j)::text FROM job_runs j
+		WHERE j.run_id IN (SELECT run_id FROM snapshot_runs)
+		ORDER BY j.event_time, j.run_id`},
+	{snapshotRecordDataset, snapshotRunsCTE + `
+		SELECT row_to_json(d)::text FROM datasets d
+		WHERE d.dataset_urn IN (
+			SELECT e.dataset_urn FROM lineage_edges e WHERE e.run_id IN (SELECT run_id FROM snapshot_runs)
+		)
+		ORDER BY d.dataset_urn`},
+	{snapshotRecordLineageEdge, snapshotRunsCTE + `
+		SELECT json_build_object(
+			'run_id', e.run_id, 'dataset_urn', e.dataset_urn, 'edge_type', e.edge_type, 'created_at', e.created_at
+		)::text
+		FROM lineage_edges e
+		WHERE e.run_id IN (SELECT run_id FROM snapshot_runs)
+		ORDER BY e.run_id, e.edge_type, e.dataset_urn`},
+	{snapshotRecordIdempotencyKey, snapshotRunsCTE + `
+		SELECT row_to_json(k)::text FROM lineage_event_idempotency k
+		WHERE k.expires_at > NOW()
+		  AND (k.event_metadata->>'run_id') IN (SELECT run_id::text FROM snapshot_runs)
+		ORDER BY k.idempotency_key`},
+}
+
+// Import statements, one per data record type. $1 is the record's JSON object.
+//
+// Rows that already exist are kept unless the snapshot row is newer: job runs by event_time
+// (same rule as event ingestion), datasets by updated_at. Edges and idempotency keys are
+// insert-only. last_producing_run_id is dropped when the referenced run is not in the target.
+//
+//nolint:gochecknoglobals
+var snapshotImportStatements = map[string]string{
+	snapshotRecordJobRun: `
+		INSERT INTO job_runs
+		SELECT * FROM json_populate_record(NULL::job_runs, $1::json)
+		ON CONFLICT (run_id) DO UPDATE SET
+			event_type = EXCLUDED.event_type,
+			event_time = EXCLUDED.event_time,
+			completed_at = EXCLUDED.completed_at,
+			current_state = EXCLUDED.current_state,
+			state_history = EXCLUDED.state_history,
+			metadata = EXCLUDED.metadata,
+			error_message = EXCLUDED.error_message,
+			error_stack_trace = EXCLUDED.error_stack_trace,
+			error_programming_language = EXCLUDED.error_programming_language,
+			error_classification = EXCLUDED.error_classification,
+			openlineage_version = COALESCE(EXCLUDED.openlineage_version, job_runs.openlineage_version),
+			nominal_start_time = COALESCE(EXCLUDED.nominal_start_time, job_runs.nominal_start_time),
+			nominal_end_time = CASE
+				WHEN EXCLUDED.nominal_start_time IS NOT NULL THEN EXCLUDED.nominal_end_time
+				ELSE job_runs.nominal_end_time
+			END,
+			ingested_by_plugin = COALESCE(EXCLUDED.ingested_by_plugin, job_runs.ingested_by_plugin),
+			processing_engine_name = COALESCE(EXCLUDED.processing_engine_name, job_runs.processing_engine_name),
+			processing_engine_version = CASE
+				WHEN EXCLUDED.processing_engine_name IS NOT NULL THEN EXCLUDED.processing_engine_version
+				ELSE job_runs.processing_engine_version
+			END,
+			updated_at = EXCLUDED.updated_at
+		WHERE EXCLUDED.event_time > job_runs.event_time`,
+	snapshotRecordDataset: `
+		INSERT INTO datasets (dataset_urn, name, namespace, facets, last_producing_run_id, created_at, updated_at)
+		SELECT r.dataset_urn, r.name, r.namespace, r.facets,
+			(SELECT run_id FROM job_runs WHERE run_id = r.last_producing_run_id),
+			r.created_at, r.updated_at
+		FROM json_populate_record(NULL::datasets, $1::json) r
+		ON CONFLICT (dataset_urn) DO UPDATE SET
+			facets = EXCLUDED.facets,
+			last_producing_run_id = COALESCE(EXCLUDED.last_producing_run_id, datasets.last_producing_run_id),
+			updated_at = EXCLUDED.updated_at
+		WHERE EXCLUDED.updated_at > datasets.updated_at`,
+	snapshotRecordLineageEdge: `
+		INSERT INTO lineage_edges (run_id, dataset_urn, edge_type, created_at)
+		SELECT r.run_id, r.dataset_urn, r.edge_type, r.created_at
+		FROM json_populate_record(NULL::lineage_edges, $1::json) r
+		ON CONFLICT (run_id, dataset_urn, edge_type) DO NOTHING`,
+	snapshotRecordIdempotencyKey: `
+		INSERT INTO lineage_event_idempotency
+		SELECT * FROM json_populate_record(NULL::lineage_event_idempotency, $1::json)
+		ON CONFLICT (idempotency_key) DO NOTHING`,
+}
+
+var (
+	// ErrInvalidSnapshot is returned by Import for a malformed, truncated, or out-of-order stream.
+	ErrInvalidSnapshot = errors.New("invalid lineage snapshot")
+
+	// ErrUnsupportedSnapshotVersion is returned by Import for a stream written by an
+	// incompatible (newer) snapshot format.
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported lineage snapshot version")
+)
+
+type (
+	// SnapshotFilter selects the job runs exported by Export. Zero values mean unbounded.
+	// Datasets, lineage edges, and idempotency keys follow the selected runs.
+	SnapshotFilter struct {
+		Since     time.Time `json:"since,omitzero"`      // Runs whose last event_time is at or after Since
+		Until     time.Time `json:"until,omitzero"`      // Runs whose last event_time is before Until
+		Namespace string    `json:"namespace,omitempty"` // Exact job namespace
+	}
+
+	// SnapshotCounts counts snapshot records by type.
+	SnapshotCounts struct {
+		JobRuns         int64 `json:"job_runs"`         //nolint:tagliatelle
+		Datasets        int64 `json:"datasets"`         //nolint:tagliatelle
+		LineageEdges    int64 `json:"lineage_edges"`    //nolint:tagliatelle
+		IdempotencyKeys int64 `json:"idempotency_keys"` //nolint:tagliatelle
+	}
+
+	// SnapshotImportResult describes an Import: Records counts the records read from the
+	// stream, Applied the rows inserted or updated (existing, up-to-date rows are skipped).
+	SnapshotImportResult struct {
+		Records SnapshotCounts
+		Applied SnapshotCounts
+	}
+
+	// snapshotRecord is one NDJSON line of a snapshot stream. The header carries Format,
+	// Version, ExportedAt and Filter; the footer carries Counts; data records carry Data.
+	snapshotRecord struct {
+		Type       string          `json:"type"`
+		Format     string          `json:"format,omitempty"`
+		Version    int             `json:"version,omitempty"`
+		ExportedAt time.Time       `json:"exported_at,omitzero"` //nolint:tagliatelle
+		Filter     *SnapshotFilter `json:"filter,omitempty"`
+		Counts     *SnapshotCounts `json:"counts,omitempty"`
+		Data       json.RawMessage `json:"data,omitempty"`
+	}
+)
+
+// Total returns the sum of all counts.
+func (c SnapshotCounts) Total() int64 {
+	return c.JobRuns + c.Datasets + c.LineageEdges + c.IdempotencyKeys
+}
+
+// add increments the count of recordType by n.
+func (c *SnapshotCounts) add(recordType string, n int64) {
+	switch recordType {
+	case snapshotRecordJobRun:
+		c.JobRuns += n
+	case snapshotRecordDataset:
+		c.Datasets += n
+	case snapshotRecordLineageEdge:
+		c.LineageEdges += n
+	case snapshotRecordIdempotencyKey:
+		c.IdempotencyKeys += n
+	}
+}
+
+// Export writes a snapshot of the job runs matching filter, with the datasets and lineage edges
+// they touch and their unexpired idempotency keys, to w as a versioned NDJSON stream:
+//
+//	{"type":"header","format":"correlator-lineage-snapshot","version":1,"exported_at":...,"filter":{...}}
+//	{"type":"job_run","data":{...}}         ... then dataset, lineage_edge, idempotency_key records
+//	{"type":"footer","counts":{...}}
+//
+// Records are written in foreign-key order and read from one repeatable-read transaction, so
+// the stream is consistent while ingestion continues. Test results and incident state are not
+// included. Use Import to load the stream into another database.
+func (s *LineageStore) Export(ctx context.Context, w io.Writer, filter SnapshotFilter) (SnapshotCounts, error) {
+	if s.conn == nil {
+		return SnapshotCounts{}, ErrNoDatabaseConnection
+	}
+
+	start := time.Now()
+
+	tx, err := s.conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return SnapshotCounts{}, fmt.Errorf("failed to begin snapshot transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	encoder := json.NewEncoder(w)
+
+	if err := encoder.Encode(snapshotRecord{
+		Type:       snapshotRecordHeader,
+		Format:     snapshotFormat,
+		Version:    snapshotVersion,
+		ExportedAt: start.UTC(),
+		Filter:     &filter,
+	}); err != nil {
+		return SnapshotCounts{}, fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+
+	var counts SnapshotCounts
+
+	for _, q := range snapshotExportQueries {
+		n, err := exportSnapshotRecords(ctx, tx, encoder, q.recordType, q.query, filter)
+		if err != nil {
+			return SnapshotCounts{}, err
+		}
+
+		counts.add(q.recordType, n)
+	}
+
+	if err := encoder.Encode(snapshotRecord{Type: snapshotRecordFooter, Counts: &counts}); err != nil {
+		return SnapshotCounts{}, fmt.Errorf("failed to write snapshot footer: %w", err)
+	}
+
+	s.logger.Info("Exported lineage snapshot",
+		slog.Time("since", filter.Since),
+		slog.Time("until", filter.Until),
+		slog.String("namespace", filter.Namespace),
+		slog.Int64("job_runs", counts.JobRuns),
+		slog.Int64("datasets", counts.Datasets),
+		slog.Int64("lineage_edges", counts.LineageEdges),
+		slog.Int64("idempotency_keys", counts.IdempotencyKeys),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	return counts, nil
+}
+
+// exportSnapshotRecords streams the rows of one export query as records of recordType.
+func exportSnapshotRecords(
+	ctx context.Context,
+	tx *sql.Tx,
+	encoder *json.Encoder,
+	recordType, query string,
+	filter SnapshotFilter,
+) (int64, error) {
+	rows, err := tx.QueryContext(ctx, query, nullTime(filter.Since), nullTime(filter.Until), filter.Namespace)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s records: %w", recordType, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var n int64
+
+	for rows.Next() {
+		var data string
+
+		if err := rows.Scan(&data); err != nil {
+			return n, fmt.Errorf("failed to scan %s record: %w", recordType, err)
+		}
+
+		if err := encoder.Encode(snapshotRecord{Type: recordType, Data: json.RawMessage(data)}); err != nil {
+			return n, fmt.Errorf("failed to write %s record: %w", recordType, err)
+		}
+
+		n++
+	}
+
+	if err := rows.Err(); err != nil {
+		return n, fmt.Errorf("error iterating %s records: %w", recordType, err)
+	}
+
+	return n, nil
+}
+
+// Import loads a snapshot stream written by Export in one transaction: either the whole
+// snapshot is applied or nothing is (truncated streams fail on the missing footer).
+//
+// Import is idempotent. Existing rows are only updated by newer snapshot rows (see
+// snapshotImportStatements), and idempotency keys keep their original expiry, so events that
+// were already ingested are still recognized as duplicates after the import and importing the
+// same snapshot twice applies nothing the second time.
+//
+// Correlation views are refreshed through the usual debounced refresh when rows were applied.
+func (s *LineageStore) Import(ctx context.Context, r io.Reader) (SnapshotImportResult, error) {
+	if s.conn == nil {
+		return SnapshotImportResult{}, ErrNoDatabaseConnection
+	}
+
+	start := time.Now()
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return SnapshotImportResult{}, fmt.Errorf("failed to begin snapshot transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := importSnapshotTx(ctx, tx, json.NewDecoder(r))
+	if err != nil {
+		return SnapshotImportResult{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return SnapshotImportResult{}, fmt.Errorf("failed to commit snapshot import: %w", err)
+	}
+
+	s.logger.Info("Imported lineage snapshot",
+		slog.Int64("job_runs", result.Applied.JobRuns),
+		slog.Int64("datasets", result.Applied.Datasets),
+		slog.Int64("lineage_edges", result.Applied.LineageEdges),
+		slog.Int64("idempotency_keys", result.Applied.IdempotencyKeys),
+		slog.Int64("records", result.Records.Total()),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	if result.Applied != (SnapshotCounts{}) {
+		s.notifyDataChanged()
+	}
+
+	return result, nil
+}
+
+// importSnapshotTx validates the header, applies the data records in order, and checks the
+// footer counts against the records read.
+func importSnapshotTx(ctx context.Context, tx *sql.Tx, decoder *json.Decoder) (SnapshotImportResult, error) {
+	var header snapshotRecord
+
+	if err := decoder.Decode(&header); err != nil {
+		return SnapshotImportResult{}, fmt.Errorf("%w: failed to read header: %w", ErrInvalidSnapshot, err)
+	}
+
+	if header.Type != snapshotRecordHeader || header.Format != snapshotFormat {
+		return SnapshotImportResult{}, fmt.Errorf("%w: missing %s header", ErrInvalidSnapshot, snapshotFormat)
+	}
+
+	if header.Version != snapshotVersion {
+		return SnapshotImportResult{}, fmt.Errorf("%w: %d (supported: %d)",
+			ErrUnsupportedSnapshotVersion, header.Version, snapshotVersion)
+	}
+
+	var (
+		result   SnapshotImportResult
+		position int
+	)
+
+	for {
+		var record snapshotRecord
+
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return SnapshotImportResult{}, fmt.Errorf("%w: missing footer (truncated stream)", ErrInvalidSnapshot)
+			}
+
+			return SnapshotImportResult{}, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+
+		if record.Type == snapshotRecordFooter {
+			if record.Counts == nil || *record.Counts != result.Records {
+				return SnapshotImportResult{}, fmt.Errorf("%w: footer counts %+v do not match records read %+v",
+					ErrInvalidSnapshot, record.Counts, result.Records)
+			}
+
+			return result, nil
+		}
+
+		order, ok := snapshotRecordOrder[record.Type]
+		if !ok || order < position {
+			return SnapshotImportResult{}, fmt.Errorf("%w: unexpected %q record", ErrInvalidSnapshot, record.Type)
+		}
+
+		position = order
+
+		applied, err := tx.ExecContext(ctx, snapshotImportStatements[record.Type], string(record.Data))
+		if err != nil {
+			return SnapshotImportResult{}, fmt.Errorf("failed to import %s record: %w", record.Type, err)
+		}
+
+		n, err := applied.RowsAffected()
+		if err != nil {
+			return SnapshotImportResult{}, fmt.Errorf("failed to import %s record: %w", record.Type, err)
+		}
+
+		result.Records.add(record.Type, 1)
+		result.Applied.add(record.Type, n)
+	}
+}
+
+// nullTime maps the zero time to NULL (an unbounded filter).
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestLineageSnapshot_ExportImportRoundTrip verifies that a namespace snapshot restores the
+// deleted runs, datasets, and edges, that restored idempotency keys still detect duplicates,
+// and that importing the same snapshot again applies nothing.
+func TestLineageSnapshot_ExportImportRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	snapshotRun := createTestEvent("snapshot-run", ingestion.EventTypeComplete, 1, 1)
+	snapshotRun.Job.Namespace = "snapshot://prod"
+
+	otherRun := createTestEvent("snapshot-other", ingestion.EventTypeComplete, 1, 0)
+	otherRun.Job.Namespace = "snapshot://other"
+
+	for _, event := range []*ingestion.RunEvent{snapshotRun, otherRun} {
+		_, _, err := store.StoreEvent(ctx, event)
+		require.NoError(t, err)
+	}
+
+	var snapshot bytes.Buffer
+
+	counts, err := store.Export(ctx, &snapshot, SnapshotFilter{Namespace: "snapshot://prod"})
+	require.NoError(t, err)
+
+	expected := SnapshotCounts{JobRuns: 1, Datasets: 2, LineageEdges: 2, IdempotencyKeys: 1}
+	assert.Equal(t, expected, counts)
+
+	lines := strings.Split(strings.TrimSpace(snapshot.String()), "\n")
+	require.Len(t, lines, 7, "header + 6 records + footer")
+	assert.Contains(t, lines[0], `"format":"correlator-lineage-snapshot"`)
+	assert.Contains(t, lines[1], `"type":"job_run"`)
+	assert.Contains(t, lines[6], `"type":"footer"`)
+
+	t.Run("TimeFilter", func(t *testing.T) {
+		var empty bytes.Buffer
+
+		counts, err := store.Export(ctx, &empty, SnapshotFilter{Since: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+		assert.Equal(t, SnapshotCounts{}, counts)
+	})
+
+	// Simulate an empty target: drop the runs and their idempotency keys
+	_, err = store.DeleteJobRuns(ctx, JobRunDeleteFilter{
+		Namespace: "snapshot://prod", Before: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	_, err = store.conn.ExecContext(ctx, `DELETE FROM lineage_event_idempotency`)
+	require.NoError(t, err)
+
+	t.Run("ImportRestoresSnapshot", func(t *testing.T) {
+		result, err := store.Import(ctx, bytes.NewReader(snapshot.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, expected, result.Records)
+		assert.Equal(t, expected, result.Applied)
+
+		assert.Equal(t, 2, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM lineage_edges WHERE run_id = $1`, snapshotRun.Run.ID))
+		assert.Equal(t, 1, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM datasets WHERE last_producing_run_id = $1`, snapshotRun.Run.ID))
+
+		stored, duplicate, err := store.StoreEvent(ctx, snapshotRun)
+		require.NoError(t, err)
+		assert.False(t, stored)
+		assert.True(t, duplicate, "Imported idempotency keys must keep detecting duplicates")
+	})
+
+	t.Run("ReimportIsIdempotent", func(t *testing.T) {
+		result, err := store.Import(ctx, bytes.NewReader(snapshot.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, expected, result.Records)
+		assert.Equal(t, SnapshotCounts{}, result.Applied)
+	})
+
+	t.Run("TruncatedStreamAppliesNothing", func(t *testing.T) {
+		_, err := store.conn.ExecContext(ctx, `DELETE FROM lineage_edges WHERE run_id = $1`, snapshotRun.Run.ID)
+		require.NoError(t, err)
+
+		truncated := strings.Join(lines[:len(lines)-1], "\n")
+
+		_, err = store.Import(ctx, strings.NewReader(truncated))
+		require.ErrorIs(t, err, ErrInvalidSnapshot)
+
+		assert.Equal(t, 0, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM lineage_edges WHERE run_id = $1`, snapshotRun.Run.ID))
+	})
+}
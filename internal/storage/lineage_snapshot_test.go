@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestImportSnapshot_RejectsInvalidStreams verifies that stream-level problems are reported
+// before any record is applied (no transaction is needed to reach them).
+func TestImportSnapshot_RejectsInvalidStreams(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	const header = `{"type":"header","format":"correlator-lineage-snapshot","version":1}` + "\n"
+
+	tests := []struct {
+		name    string
+		stream  string
+		wantErr error
+	}{
+		{"empty stream", "", ErrInvalidSnapshot},
+		{"missing header", `{"type":"job_run","data":{}}`, ErrInvalidSnapshot},
+		{"foreign format", `{"type":"header","format":"other","version":1}`, ErrInvalidSnapshot},
+		{"newer version", `{"type":"header","format":"correlator-lineage-snapshot","version":2}`,
+			ErrUnsupportedSnapshotVersion},
+		{"truncated", header, ErrInvalidSnapshot},
+		{"unknown record", header + `{"type":"test_result","data":{}}`, ErrInvalidSnapshot},
+		{"footer mismatch", header + `{"type":"footer","counts":{"job_runs":1}}`, ErrInvalidSnapshot},
+		{"footer without counts", header + `{"type":"footer"}`, ErrInvalidSnapshot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := importSnapshotTx(context.Background(), nil, json.NewDecoder(strings.NewReader(tt.stream)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("importSnapshotTx() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	result, err := importSnapshotTx(context.Background(), nil,
+		json.NewDecoder(strings.NewReader(header+`{"type":"footer","counts":{}}`)))
+	if err != nil || result != (SnapshotImportResult{}) {
+		t.Errorf("empty snapshot = %+v, %v; want zero result", result, err)
+	}
+}
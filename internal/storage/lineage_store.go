@@ -10,6 +10,8 @@ import (
 	"log/slog"
 	"os"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +21,7 @@ import (
 	"github.com/correlator-io/correlator/internal/aliasing"
 	"github.com/correlator-io/correlator/internal/config"
 	"github.com/correlator-io/correlator/internal/correlation"
+	"github.com/correlator-io/correlator/internal/health"
 	"github.com/correlator-io/correlator/internal/ingestion"
 )
 
@@ -58,6 +61,14 @@ var (
 	versionPattern = regexp.MustCompile(`^v?\d+\.\d+`)
 )
 
+// Background worker names reported through WithWorkers.
+const (
+	WorkerIdempotencyCleanup = "idempotency_cleanup"
+	WorkerViewRefresh        = "view_refresh"
+	WorkerStaleRunSweep      = "stale_run_sweep"
+	WorkerOrphanReconcile    = "orphan_reconcile"
+)
+
 // Cleanup configuration constants.
 const (
 	// cleanupQueryTimeout is the maximum time allowed for a single cleanup query execution.
@@ -75,6 +86,13 @@ const (
 	stateComplete = "COMPLETE"
 	stateFail     = "FAIL"
 	stateAbort    = "ABORT"
+	// maxOpenLineageVersionLength matches job_runs.openlineage_version; longer versions are not stored.
+	maxOpenLineageVersionLength = 20
+
+	// maxProcessingEngineNameLength and maxProcessingEngineVersionLength match
+	// job_runs.processing_engine_name / processing_engine_version; longer values are not stored.
+	maxProcessingEngineNameLength    = 100
+	maxProcessingEngineVersionLength = 50
 
 	// autoResolveGracePeriod prevents auto-resolve from triggering on intermittent test passes.
 	// A test must remain failing for at least this duration before a subsequent pass triggers auto-resolve.
@@ -105,11 +123,41 @@ type (
 		refreshTimer *time.Timer    // Debounce timer; nil when no refresh pending
 		refreshStop  chan struct{}  // Signal to stop in-flight refresh (closed on Close)
 		refreshWg    sync.WaitGroup // Tracks in-flight refresh goroutines for graceful shutdown
+		// Background correlation hand-off
+		incidentSink      IncidentSink // Optional sink for newly stored failures (nil = disabled)
+		pendingFailures   []int64      // Failing test result IDs awaiting view refresh (guarded by refreshMu)
+		pendingFailedRuns []string     // Failed run IDs awaiting view refresh (guarded by refreshMu)
+		// Optional dataset facet merge auditing (see facet_history.go)
+		facetAudit bool
+		// Derive test results from assertion facets on input datasets (enabled by default)
+		assertionFacets bool
+		// Facets dropped before persistence (see facet_filter.go)
+		facetFilter FacetFilter
+		// Prepared statements for hot insert paths (see prepared_statements.go)
+		preparedStatements bool
+		stmts              *statementCache
+		// Optional job_run_changes NOTIFY and LISTEN subscriptions (see job_run_changes.go)
+		jobRunNotifications bool
+		subscribersStop     chan struct{}  // Closed on Close to end Subscribe goroutines
+		subscribersWg       sync.WaitGroup // Tracks Subscribe goroutines for graceful shutdown
+		// Optional last-run tracking of background workers (nil = disabled)
+		workers *health.Workers
+		// Optional StoreEvent buffering across requests (nil = disabled, see write_buffer.go)
+		writeBuffer *writeBuffer
 	}
 
 	// LineageStoreOption configures optional LineageStore behavior.
 	LineageStoreOption func(*LineageStore)
 
+	// IncidentSink receives IDs of newly stored failing test results for background correlation,
+	// and of the failing test results correlated to newly failed runs. Enqueue must not block;
+	// returning false means the ID was dropped.
+	//
+	// Implemented by: correlation.Worker.
+	IncidentSink interface {
+		Enqueue(testResultID int64) bool
+	}
+
 	// stateTransition represents a single state transition entry in state_history.
 	stateTransition struct {
 		From      interface{} `json:"from"` // nil for initial state, string otherwise
@@ -155,6 +203,59 @@ func WithViewRefreshDelay(d time.Duration) LineageStoreOption {
 	}
 }
 
+// WithWorkers records the last run of the store's background workers (idempotency cleanup,
+// view refresh, and the stale run sweep when enabled) in workers, for the detailed health
+// endpoint. Default: nil (not tracked).
+func WithWorkers(workers *health.Workers) LineageStoreOption {
+	return func(s *LineageStore) {
+		s.workers = workers
+	}
+}
+
+// WithIncidentSink hands failing test result IDs to a background correlation worker.
+// When view refresh debouncing is enabled, IDs are handed off only after the refresh that
+// makes them visible in incident_correlation_view succeeds; otherwise right after commit.
+//
+// Example:
+//
+//	worker := correlation.NewWorker(correlation.WorkerConfig{}, notifier, logger)
+//	store, err := storage.NewLineageStore(conn, interval,
+//	    storage.WithIncidentSink(worker))
+func WithIncidentSink(sink IncidentSink) LineageStoreOption {
+	return func(s *LineageStore) {
+		s.incidentSink = sink
+	}
+}
+
+// WithAssertionFacetIngestion enables or disables deriving test results from assertion facets
+// (dataQualityAssertions, greatExpectations_assertions) on lineage event input datasets.
+// Default: enabled. Operators who report test results through a dedicated integration can
+// disable it so lineage events never create test results.
+//
+// Example:
+//
+//	store, err := storage.NewLineageStore(conn, interval,
+//	    storage.WithAssertionFacetIngestion(false))
+func WithAssertionFacetIngestion(enabled bool) LineageStoreOption {
+	return func(s *LineageStore) {
+		s.assertionFacets = enabled
+	}
+}
+
+// WithPreparedStatements enables or disables prepared statement caching for the per-event
+// insert paths. Default: enabled. Disabling runs every query ad-hoc, which is only useful for
+// comparison benchmarks or behind poolers that do not support server-side prepared statements.
+//
+// Example:
+//
+//	store, err := storage.NewLineageStore(conn, interval,
+//	    storage.WithPreparedStatements(false))
+func WithPreparedStatements(enabled bool) LineageStoreOption {
+	return func(s *LineageStore) {
+		s.preparedStatements = enabled
+	}
+}
+
 // NewLineageStore creates a PostgreSQL-backed OpenLineage event store with background cleanup.
 // Returns error if connection is nil (ErrNoDatabaseConnection).
 //
@@ -182,9 +283,12 @@ func NewLineageStore(
 		logger: slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			Level: config.GetEnvLogLevel("LOG_LEVEL", slog.LevelInfo),
 		})),
-		cleanupInterval: cleanupInterval,
-		cleanupStop:     make(chan struct{}), // Signal to stop cleanup goroutine
-		cleanupDone:     make(chan struct{}), // Signal cleanup has stopped
+		cleanupInterval:    cleanupInterval,
+		cleanupStop:        make(chan struct{}), // Signal to stop cleanup goroutine
+		cleanupDone:        make(chan struct{}), // Signal cleanup has stopped
+		subscribersStop:    make(chan struct{}),
+		assertionFacets:    true,
+		preparedStatements: true,
 	}
 
 	// Apply optional configuration
@@ -196,10 +300,26 @@ func NewLineageStore(
 	store.refreshStop = make(chan struct{})
 
 	if store.refreshDelay > 0 {
+		store.workers.Register(WorkerViewRefresh)
 		store.logger.Info("View refresh debounce enabled", slog.Duration("delay", store.refreshDelay))
 	}
 
+	// Prepare hot-path statements; any that fail to prepare run ad-hoc
+	store.stmts = newStatementCache(conn, store.logger, store.preparedStatements)
+
+	if store.writeBuffer != nil {
+		go store.runWriteBuffer()
+
+		store.logger.Info("Write buffer enabled",
+			slog.Int("max_events", store.writeBuffer.config.MaxEvents),
+			slog.Duration("flush_interval", store.writeBuffer.config.FlushInterval),
+			slog.Bool("async", store.writeBuffer.config.Async),
+		)
+	}
+
 	// Start cleanup goroutine
+	store.workers.Register(WorkerIdempotencyCleanup)
+
 	go store.runCleanup()
 
 	store.logger.Info("Started idempotency cleanup goroutine", slog.Duration("interval", cleanupInterval))
@@ -225,11 +345,19 @@ func (s *LineageStore) InitResolvedDatasets(ctx context.Context) error {
 // via dependency injection. The caller is responsible for closing the connection.
 //
 // Shutdown sequence:
-//  1. Stop debounced view refresh timer and cancel in-flight refresh
-//  2. Signal cleanup goroutine to stop (close cleanupStop channel)
-//  3. Wait for cleanup goroutine to finish (with 5-second timeout)
+//  1. Flush the write buffer (when enabled); later StoreEvent calls store directly
+//  2. Stop debounced view refresh timer and cancel in-flight refresh
+//  3. Signal cleanup goroutine to stop (close cleanupStop channel)
+//  4. Wait for cleanup goroutine to finish (with 5-second timeout)
+//  5. End Subscribe goroutines and close their LISTEN connections
+//  6. Close cached prepared statements
 func (s *LineageStore) Close() error {
+	var err error
+
 	s.closeOnce.Do(func() {
+		// Store buffered events first: flushing still refreshes views and uses the statements
+		s.closeWriteBuffer()
+
 		// Stop debounced view refresh: signal stop, cancel timer, wait for in-flight
 		close(s.refreshStop)
 
@@ -257,9 +385,14 @@ func (s *LineageStore) Close() error {
 		case <-time.After(shutdownTimeout):
 			s.logger.Warn("Cleanup goroutine did not stop within timeout")
 		}
+
+		close(s.subscribersStop)
+		s.subscribersWg.Wait()
+
+		err = s.stmts.close()
 	})
 
-	return nil
+	return err
 }
 
 // HealthCheck verifies the database connection is healthy and ready to serve requests.
@@ -302,7 +435,7 @@ func (s *LineageStore) HealthCheck(ctx context.Context) error {
 //  3. Begins transaction with deferred FK constraints
 //  4. Upserts job_run record (handles out-of-order via eventTime comparison)
 //  5. Upserts datasets and creates lineage edges (separate row per input/output)
-//  6. Extracts dataQualityAssertions from input facets and stores test results
+//  6. Extracts dataQualityAssertions from input facets and stores test results (unless disabled)
 //  7. Records idempotency key with 24-hour expiration
 //  8. Commits transaction
 //
@@ -312,7 +445,25 @@ func (s *LineageStore) HealthCheck(ctx context.Context) error {
 // Idempotency: Duplicate events (within 24 hours) return (false, true, nil) instead of
 // storing again. This follows industry standard where duplicates return
 // 200 OK (success) not 409 Conflict (error).
+//
+// With a write buffer (see WithWriteBuffer) the event shares the transaction of a flush with the
+// events of other requests; the results are the same, except in async mode.
 func (s *LineageStore) StoreEvent(ctx context.Context, event *ingestion.RunEvent) (bool, bool, error) {
+	if s.writeBuffer != nil {
+		if err := s.validateRunEvent(event); err != nil {
+			return false, false, err
+		}
+
+		if result, ok := s.bufferEvent(ctx, event); ok {
+			return result.stored, result.duplicate, result.err
+		}
+	}
+
+	return s.storeEvent(ctx, event)
+}
+
+// storeEvent is StoreEvent without the write buffer: the event is stored in its own transaction.
+func (s *LineageStore) storeEvent(ctx context.Context, event *ingestion.RunEvent) (bool, bool, error) {
 	if err := s.validateRunEvent(event); err != nil {
 		return false, false, err
 	}
@@ -335,55 +486,105 @@ func (s *LineageStore) StoreEvent(ctx context.Context, event *ingestion.RunEvent
 		return false, true, nil
 	}
 
+	// 2-7. Store the event in a single transaction
+	passingTests, failingTestIDs, err := s.storeEventTx(ctx, event, idempotencyKey)
+	if err != nil && s.stmts.invalidate(err) {
+		// A cached statement went stale (e.g., schema migrated underneath a running server).
+		// The failed transaction was rolled back, so replay it once with re-prepared statements.
+		passingTests, failingTestIDs, err = s.storeEventTx(ctx, event, idempotencyKey)
+	}
+
+	if err != nil {
+		return false, false, err
+	}
+
+	s.logger.Info("event stored successfully",
+		slog.String("run_id", event.Run.ID),
+		slog.String("event_type", string(event.EventType)),
+		slog.Time("event_time", event.EventTime),
+	)
+
+	// 8. Auto-resolve incidents for any passing tests (non-blocking, after commit)
+	s.autoResolvePassingTests(ctx, passingTests)
+
+	// Queue failing tests and failed runs for background correlation (handed off after view refresh)
+	s.queueFailingTests(failingTestIDs)
+
+	if event.EventType == ingestion.EventTypeFail {
+		s.queueFailedRuns([]string{event.Run.ID})
+	}
+
+	// Notify that data has changed (triggers debounced view refresh).
+	// Background refresh intentionally uses its own context, not the request context.
+	s.notifyDataChanged() //nolint:contextcheck
+
+	return true, false, nil
+}
+
+// storeEventTx runs steps 2-7 of StoreEvent: everything from BEGIN to COMMIT.
+// Returns the passing test results and failing test result IDs extracted from the event,
+// which StoreEvent acts on only after a successful commit.
+func (s *LineageStore) storeEventTx(
+	ctx context.Context,
+	event *ingestion.RunEvent,
+	idempotencyKey string,
+) ([]passingTestInfo, []int64, error) {
 	// 2. Begin transaction with deferred FK constraints
 	tx, err := s.conn.BeginTx(ctx, nil)
 	if err != nil {
-		return false, false, fmt.Errorf("%w: failed to begin transaction: %w", ErrLineageStoreFailed, err)
+		return nil, nil, fmt.Errorf("%w: failed to begin transaction: %w", ErrLineageStoreFailed, err)
 	}
 
 	defer func() {
 		_ = tx.Rollback() // Safe to call even after commit
 	}()
 
+	passingTests, failingTestIDs, err := s.storeEventInTx(ctx, tx, event, idempotencyKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 7. Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
+	}
+
+	return passingTests, failingTestIDs, nil
+}
+
+// storeEventInTx runs steps 3-6 of StoreEvent within tx, which the caller begins and commits.
+func (s *LineageStore) storeEventInTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	event *ingestion.RunEvent,
+	idempotencyKey string,
+) ([]passingTestInfo, []int64, error) {
 	// 3. Upsert job_run (handles out-of-order events via eventTime comparison)
 	if err := s.upsertJobRun(ctx, tx, event); err != nil {
-		return false, false, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
+		return nil, nil, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
+	}
+
+	// Queue a job_run_changes notification (delivered on commit; no-op unless enabled)
+	if err := s.notifyJobRunChange(ctx, tx, event.Run.ID); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
 	}
 
 	// 4. Upsert datasets and create lineage edges
 	if err := s.upsertDatasetsAndEdges(ctx, tx, event); err != nil {
-		return false, false, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
+		return nil, nil, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
 	}
 
 	// 5. Extract test results from dataQualityAssertions facets (non-blocking)
 	// This extracts test assertions from input datasets and stores them in test_results table
 	// for correlation. Errors are logged but don't fail the event storage.
-	passingTests := s.extractDataQualityAssertions(ctx, tx, event)
+	passingTests, failingTestIDs := s.extractDataQualityAssertions(ctx, tx, event)
 
 	// 6. Record idempotency key (24-hour TTL)
 	if err := s.recordIdempotency(ctx, tx, idempotencyKey, event); err != nil {
-		return false, false, fmt.Errorf("%w: %w", ErrIdempotencyCheckFailed, err)
+		return nil, nil, fmt.Errorf("%w: %w", ErrIdempotencyCheckFailed, err)
 	}
 
-	// 7. Commit transaction
-	if err := tx.Commit(); err != nil {
-		return false, false, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
-	}
-
-	s.logger.Info("event stored successfully",
-		slog.String("run_id", event.Run.ID),
-		slog.String("event_type", string(event.EventType)),
-		slog.Time("event_time", event.EventTime),
-	)
-
-	// 8. Auto-resolve incidents for any passing tests (non-blocking, after commit)
-	s.autoResolvePassingTests(ctx, passingTests)
-
-	// Notify that data has changed (triggers debounced view refresh).
-	// Background refresh intentionally uses its own context, not the request context.
-	s.notifyDataChanged() //nolint:contextcheck
-
-	return true, false, nil
+	return passingTests, failingTestIDs, nil
 }
 
 // StoreEvents implements ingestion.Store interface.
@@ -393,6 +594,9 @@ func (s *LineageStore) StoreEvent(ctx context.Context, event *ingestion.RunEvent
 // one bad event doesn't prevent other events from being stored. This is critical for
 // production reliability where 99 good events shouldn't fail because of 1 bad event.
 //
+// Events of a single-run batch are applied in eventTime order (see ingestion.ApplyOrder), so
+// the run's state_history follows the true sequence however the client ordered the batch.
+//
 // Parameters:
 //   - ctx: Context for cancellation and timeout control.
 //   - events: Slice of pointers to RunEvent structs to store. Pointers avoid copying large structs.
@@ -409,7 +613,7 @@ func (s *LineageStore) StoreEvents(
 	results := make([]*ingestion.EventStoreResult, len(events))
 
 	// Process each event independently (per-event transactions)
-	for i := range events {
+	for _, i := range ingestion.ApplyOrder(events) {
 		// Check for operation-level failures (context cancellation)
 		if ctx.Err() != nil {
 			if errors.Is(ctx.Err(), context.Canceled) {
@@ -421,7 +625,7 @@ func (s *LineageStore) StoreEvents(
 			}
 		}
 
-		stored, duplicate, err := s.StoreEvent(ctx, events[i])
+		stored, duplicate, err := s.storeEvent(ctx, events[i])
 		results[i] = &ingestion.EventStoreResult{
 			Event:     events[i],
 			Stored:    stored,
@@ -438,6 +642,158 @@ func (s *LineageStore) StoreEvents(
 	return results, nil
 }
 
+// StoreEventsAtomic implements ingestion.Store interface.
+// Stores multiple OpenLineage events in a single transaction: all of them or none.
+//
+// Events are stored in order (eventTime order for single-run batches, see ingestion.ApplyOrder);
+// the first failure rolls back the whole batch. Its result carries
+// the error and every other result carries ingestion.ErrBatchRolledBack. A failure no single
+// event is to blame for (e.g. a deferred constraint violated at commit) is reported on every
+// event. Duplicates, including repeats within the batch, are idempotent successes.
+//
+// Returns operation-level error only for catastrophic failures (context cancelled, database connection lost).
+func (s *LineageStore) StoreEventsAtomic(
+	ctx context.Context,
+	events []*ingestion.RunEvent,
+) ([]*ingestion.EventStoreResult, error) {
+	results := make([]*ingestion.EventStoreResult, len(events))
+
+	for i := range events {
+		results[i] = &ingestion.EventStoreResult{Event: events[i]}
+	}
+
+	for i := range events {
+		if err := s.validateRunEvent(events[i]); err != nil {
+			return rollBackResults(results, i, err), nil
+		}
+	}
+
+	batch, failed, err := s.storeBatchTx(ctx, events)
+	if err != nil && s.stmts.invalidate(err) {
+		// A cached statement went stale: the batch was rolled back, so replay it once (see StoreEvent)
+		batch, failed, err = s.storeBatchTx(ctx, events)
+	}
+
+	if err != nil {
+		if ctx.Err() != nil || isDatabaseConnectionError(err) {
+			return rollBackResults(results, failed, err), fmt.Errorf("%w: atomic batch rolled back: %w",
+				ErrLineageStoreFailed, err)
+		}
+
+		return rollBackResults(results, failed, err), nil
+	}
+
+	stored := 0
+
+	for i := range results {
+		results[i].Duplicate = batch.duplicate[i]
+		results[i].Stored = !batch.duplicate[i]
+
+		if results[i].Stored {
+			stored++
+		}
+	}
+
+	s.logger.Info("atomic batch stored successfully",
+		slog.Int("event_count", len(events)),
+		slog.Int("stored", stored),
+	)
+
+	// Same post-commit steps as StoreEvent
+	s.autoResolvePassingTests(ctx, batch.passingTests)
+	s.queueFailingTests(batch.failingTestIDs)
+	s.queueFailedRuns(batch.failedRunIDs)
+
+	if stored > 0 {
+		s.notifyDataChanged() //nolint:contextcheck
+	}
+
+	return results, nil
+}
+
+// atomicBatch is the outcome of storeBatchTx: which events were duplicates, and the test results
+// extracted from the stored ones and the runs they failed, to act on after commit.
+type atomicBatch struct {
+	duplicate      []bool
+	passingTests   []passingTestInfo
+	failingTestIDs []int64
+	failedRunIDs   []string
+}
+
+// storeBatchTx stores events in one transaction (steps 1-7 of StoreEvent for each event, with a
+// single BEGIN and COMMIT), stopping at the first failure. Returns the index of the failed event,
+// or -1 when the failure is not specific to one event.
+func (s *LineageStore) storeBatchTx(ctx context.Context, events []*ingestion.RunEvent) (atomicBatch, int, error) {
+	batch := atomicBatch{duplicate: make([]bool, len(events))}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return batch, -1, fmt.Errorf("%w: failed to begin transaction: %w", ErrLineageStoreFailed, err)
+	}
+
+	defer func() {
+		_ = tx.Rollback() // Safe to call even after commit
+	}()
+
+	// Idempotency keys recorded by this (uncommitted) transaction are invisible to checkIdempotency
+	seen := make(map[string]bool, len(events))
+
+	for _, i := range ingestion.ApplyOrder(events) {
+		event := events[i]
+		idempotencyKey := event.IdempotencyKey()
+
+		duplicate := seen[idempotencyKey]
+		if !duplicate {
+			duplicate, err = s.checkIdempotency(ctx, idempotencyKey)
+			if err != nil {
+				return batch, i, fmt.Errorf("%w: idempotency check failed: %w", ErrIdempotencyCheckFailed, err)
+			}
+		}
+
+		seen[idempotencyKey] = true
+
+		if duplicate {
+			batch.duplicate[i] = true
+
+			continue
+		}
+
+		passingTests, failingTestIDs, err := s.storeEventInTx(ctx, tx, event, idempotencyKey)
+		if err != nil {
+			return batch, i, err
+		}
+
+		batch.passingTests = append(batch.passingTests, passingTests...)
+		batch.failingTestIDs = append(batch.failingTestIDs, failingTestIDs...)
+
+		if event.EventType == ingestion.EventTypeFail {
+			batch.failedRunIDs = append(batch.failedRunIDs, event.Run.ID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return batch, -1, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
+	}
+
+	return batch, -1, nil
+}
+
+// rollBackResults marks every result of a rolled back atomic batch as failed: the event at index
+// failed with err, the others with ingestion.ErrBatchRolledBack. A negative index blames them all.
+func rollBackResults(results []*ingestion.EventStoreResult, failed int, err error) []*ingestion.EventStoreResult {
+	for i := range results {
+		results[i].Stored, results[i].Duplicate = false, false
+
+		if failed < 0 || i == failed {
+			results[i].Error = err
+		} else {
+			results[i].Error = ingestion.ErrBatchRolledBack
+		}
+	}
+
+	return results
+}
+
 // notifyDataChanged resets the debounce timer for materialized view refresh.
 // Called after each successful StoreEvent commit. The timer fires in its own goroutine
 // using a background context (not the request context).
@@ -481,19 +837,154 @@ func (s *LineageStore) notifyDataChanged() {
 		ctx, cancel := context.WithTimeout(context.Background(), viewRefreshTimeout)
 		defer cancel()
 
+		// Snapshot failures stored before this refresh; later ones wait for the next refresh.
+		pending, pendingRuns := s.takePendingFailures()
+
 		// Refresh resolved_datasets BEFORE views (views depend on this table)
 		if err := s.refreshResolvedDatasets(ctx); err != nil {
 			s.logger.Error("Background resolved_datasets refresh failed", slog.Any("error", err))
+			s.restorePendingFailures(pending, pendingRuns)
+			s.workers.Record(WorkerViewRefresh, err)
 
 			return // Don't refresh views if lookup table failed
 		}
 
 		if err := s.refreshViews(ctx); err != nil {
 			s.logger.Error("Background view refresh failed", slog.Any("error", err))
+			s.restorePendingFailures(pending, pendingRuns)
+			s.workers.Record(WorkerViewRefresh, err)
+
+			return
 		}
+
+		s.workers.Record(WorkerViewRefresh, nil)
+		s.handOffFailures(pending)
+		s.handOffFailedRuns(ctx, pendingRuns)
 	})
 }
 
+// queueFailingTests schedules failing test result IDs for background correlation.
+// With debounced refresh enabled, IDs are held until the next successful view refresh;
+// otherwise they are handed off immediately. No-op when no IncidentSink is configured.
+func (s *LineageStore) queueFailingTests(testResultIDs []int64) {
+	if s.incidentSink == nil || len(testResultIDs) == 0 {
+		return
+	}
+
+	if s.refreshDelay <= 0 {
+		s.handOffFailures(testResultIDs)
+
+		return
+	}
+
+	s.refreshMu.Lock()
+	s.pendingFailures = append(s.pendingFailures, testResultIDs...)
+	s.refreshMu.Unlock()
+}
+
+// queueFailedRuns schedules failed runs for background correlation: once the view is refreshed,
+// the failing test results correlated to them are handed off like newly stored ones. This covers
+// a run that fails without a failing assertion in its own events, whose output datasets already
+// have failing tests. No-op when no IncidentSink is configured.
+func (s *LineageStore) queueFailedRuns(runIDs []string) {
+	if s.incidentSink == nil || len(runIDs) == 0 {
+		return
+	}
+
+	if s.refreshDelay <= 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), viewRefreshTimeout)
+		defer cancel()
+
+		s.handOffFailedRuns(ctx, runIDs)
+
+		return
+	}
+
+	s.refreshMu.Lock()
+	s.pendingFailedRuns = append(s.pendingFailedRuns, runIDs...)
+	s.refreshMu.Unlock()
+}
+
+// takePendingFailures returns and clears the failing test result IDs and failed run IDs awaiting
+// view refresh.
+func (s *LineageStore) takePendingFailures() ([]int64, []string) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	pending, pendingRuns := s.pendingFailures, s.pendingFailedRuns
+	s.pendingFailures, s.pendingFailedRuns = nil, nil
+
+	return pending, pendingRuns
+}
+
+// restorePendingFailures puts IDs back after a failed refresh so the next refresh hands them off.
+func (s *LineageStore) restorePendingFailures(testResultIDs []int64, runIDs []string) {
+	if len(testResultIDs) == 0 && len(runIDs) == 0 {
+		return
+	}
+
+	s.refreshMu.Lock()
+	s.pendingFailures = append(testResultIDs, s.pendingFailures...)
+	s.pendingFailedRuns = append(runIDs, s.pendingFailedRuns...)
+	s.refreshMu.Unlock()
+}
+
+// handOffFailures enqueues failing test result IDs on the IncidentSink.
+// The sink never blocks; dropped IDs are logged by the sink itself.
+func (s *LineageStore) handOffFailures(testResultIDs []int64) {
+	if s.incidentSink == nil {
+		return
+	}
+
+	for _, id := range testResultIDs {
+		s.incidentSink.Enqueue(id)
+	}
+}
+
+// handOffFailedRuns enqueues the failing test results incident_correlation_view correlates to the
+// failed runs. Errors are logged: the runs are still correlated on demand.
+func (s *LineageStore) handOffFailedRuns(ctx context.Context, runIDs []string) {
+	if s.incidentSink == nil || len(runIDs) == 0 {
+		return
+	}
+
+	rows, err := s.conn.QueryContext(ctx,
+		`SELECT DISTINCT test_result_id FROM incident_correlation_view WHERE job_run_id = ANY($1)`,
+		pq.Array(runIDs),
+	)
+	if err != nil {
+		s.logger.Error("Failed to look up failing tests of failed runs",
+			slog.Int("run_count", len(runIDs)),
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var testResultIDs []int64
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			s.logger.Error("Failed to scan failing test of failed run", slog.String("error", err.Error()))
+
+			return
+		}
+
+		testResultIDs = append(testResultIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("Failed to look up failing tests of failed runs", slog.String("error", err.Error()))
+
+		return
+	}
+
+	s.handOffFailures(testResultIDs)
+}
+
 // validateRunEvent performs defensive validation of a RunEvent before storage.
 // This prevents panics from malformed events at the storage layer boundary.
 // It checks for nil pointers and empty required fields that would cause runtime errors
@@ -714,7 +1205,9 @@ func looksLikeVersion(s string) bool {
 //
 // Returns empty string if ParentRunFacet is not present or malformed.
 func extractParentRunID(runFacets map[string]interface{}) string {
-	return extractRunIDFromFacet(getParentFacetObject(runFacets))
+	runID, _ := ingestion.Facets(runFacets).GetString("parent.run.runId")
+
+	return runID
 }
 
 // extractRootParentRunID extracts the root parent run UUID from OpenLineage ParentRunFacet.
@@ -732,46 +1225,7 @@ func extractParentRunID(runFacets map[string]interface{}) string {
 //
 // Returns empty string if root is not present or malformed.
 func extractRootParentRunID(runFacets map[string]interface{}) string {
-	parent := getParentFacetObject(runFacets)
-	if parent == nil {
-		return ""
-	}
-
-	root, ok := parent["root"].(map[string]interface{})
-	if !ok {
-		return ""
-	}
-
-	return extractRunIDFromFacet(root)
-}
-
-// getParentFacetObject extracts the "parent" object from run facets.
-func getParentFacetObject(runFacets map[string]interface{}) map[string]interface{} {
-	if runFacets == nil {
-		return nil
-	}
-
-	parent, ok := runFacets["parent"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-
-	return parent
-}
-
-// extractRunIDFromFacet extracts the raw run UUID from an object
-// containing "run" sub-object with "runId" field (used for both parent and root).
-func extractRunIDFromFacet(obj map[string]interface{}) string {
-	if obj == nil {
-		return ""
-	}
-
-	run, ok := obj["run"].(map[string]interface{})
-	if !ok {
-		return ""
-	}
-
-	runID, _ := run["runId"].(string)
+	runID, _ := ingestion.Facets(runFacets).GetString("parent.root.run.runId")
 
 	return runID
 }
@@ -779,15 +1233,9 @@ func extractRunIDFromFacet(obj map[string]interface{}) string {
 // checkIdempotency checks if an event with the given idempotency key already exists.
 // Returns (true, nil) if duplicate found, (false, nil) if not duplicate, (false, error) on query error.
 func (s *LineageStore) checkIdempotency(ctx context.Context, idempotencyKey string) (bool, error) {
-	query := `
-		SELECT 1 FROM lineage_event_idempotency
-		WHERE idempotency_key = $1 AND expires_at > NOW()
-		LIMIT 1
-	`
-
 	var exists int
 
-	err := s.conn.QueryRowContext(ctx, query, idempotencyKey).Scan(&exists)
+	err := s.stmts.queryRow(ctx, stmtCheckIdempotency, []any{&exists}, idempotencyKey)
 	if errors.Is(err, sql.ErrNoRows) {
 		// Not a duplicate
 		return false, nil
@@ -919,11 +1367,116 @@ func buildUpdatedStateHistory(
 	return json.Marshal(history)
 }
 
+// buildBackfilledStateHistory inserts the state of an event older than the run's latest event
+// into its state_history at the event's chronological position, so the history reflects the
+// true sequence of states however events arrive (e.g. COMPLETE, then START, then RUNNING).
+//
+// The inserted transition starts from the state the run was in at eventTime. The transition
+// that follows it now starts from newState, or is dropped when it no longer changes the state.
+// When no later transition is recorded (the run returned to its current state, which it was in
+// as of its latest event), one back to the current state is added. The history is unchanged if
+// the run was already in newState at eventTime.
+func buildBackfilledStateHistory(existing jobRunState, newState string, eventTime time.Time) ([]byte, error) {
+	var history map[string]interface{}
+	if err := json.Unmarshal(existing.stateHistory, &history); err != nil || history == nil {
+		history = map[string]interface{}{}
+	}
+
+	transitions := historyTransitions(history)
+
+	// Transitions are chronological: insert before the first one after eventTime
+	pos := sort.Search(len(transitions), func(i int) bool {
+		return transitionTime(transitions[i]).After(eventTime)
+	})
+
+	var from interface{}
+	if pos > 0 {
+		from = transitions[pos-1]["to"]
+	}
+
+	if from == newState {
+		return json.Marshal(history)
+	}
+
+	updatedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	inserted := map[string]interface{}{
+		"from":       from,
+		"to":         newState,
+		"event_time": eventTime.Format(time.RFC3339Nano),
+		"updated_at": updatedAt,
+	}
+
+	rest := transitions[pos:]
+
+	switch {
+	case len(rest) == 0 && newState != existing.currentState:
+		rest = []map[string]interface{}{{
+			"from":       newState,
+			"to":         existing.currentState,
+			"event_time": existing.eventTime.Format(time.RFC3339Nano),
+			"updated_at": updatedAt,
+		}}
+	case len(rest) > 0 && rest[0]["to"] == newState:
+		rest = rest[1:]
+	case len(rest) > 0:
+		rest[0]["from"] = newState
+	}
+
+	merged := slices.Concat(transitions[:pos], []map[string]interface{}{inserted}, rest)
+
+	history["transitions"] = merged
+
+	return json.Marshal(history)
+}
+
+// historyTransitions returns the transitions of a decoded state_history, skipping malformed
+// entries.
+func historyTransitions(history map[string]interface{}) []map[string]interface{} {
+	raw, _ := history["transitions"].([]interface{})
+
+	transitions := make([]map[string]interface{}, 0, len(raw))
+
+	for _, entry := range raw {
+		if transition, ok := entry.(map[string]interface{}); ok {
+			transitions = append(transitions, transition)
+		}
+	}
+
+	return transitions
+}
+
+// transitionTime returns the event time of a state_history transition, or the zero time when
+// it is missing or malformed (such transitions sort first).
+func transitionTime(transition map[string]interface{}) time.Time {
+	value, _ := transition["event_time"].(string)
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
 // buildJobRunMetadata creates the metadata JSONB for a job run event.
-func buildJobRunMetadata(event *ingestion.RunEvent) ([]byte, error) {
+// When the event carries a parsed RunError, the raw errorMessage facet is replaced with its
+// bounded, NUL-free form so an oversized or binary stack trace cannot break the JSONB write.
+// Run and job facets are stored as selected by filter.
+func buildJobRunMetadata(event *ingestion.RunEvent, filter FacetFilter) ([]byte, error) {
+	runFacets := event.Run.Facets
+
+	if event.Error != nil {
+		runFacets = make(map[string]interface{}, len(event.Run.Facets))
+		for key, value := range event.Run.Facets {
+			runFacets[key] = value
+		}
+
+		runFacets[ingestion.ErrorMessageFacet] = event.Error.Facet()
+	}
+
 	metadata := map[string]interface{}{
-		"job_facets": event.Job.Facets,
-		"run_facets": event.Run.Facets,
+		"job_facets": filter.Job.apply(event.Job.Facets),
+		"run_facets": filter.Run.apply(runFacets),
 		"producer":   event.Producer,
 		"schema_url": event.SchemaURL,
 	}
@@ -944,7 +1497,7 @@ func (s *LineageStore) upsertJobRun(ctx context.Context, tx *sql.Tx, event *inge
 	runID := event.Run.ID
 	newState := string(event.EventType)
 
-	metadataJSON, err := buildJobRunMetadata(event)
+	metadataJSON, err := buildJobRunMetadata(event, s.facetFilter)
 	if err != nil {
 		return fmt.Errorf("failed to build metadata: %w", err)
 	}
@@ -971,13 +1524,18 @@ func (s *LineageStore) upsertJobRun(ctx context.Context, tx *sql.Tx, event *inge
 			}
 		}
 
-		stateHistoryJSON, err = buildUpdatedStateHistory(
-			existing.stateHistory,
-			existing.currentState,
-			newState,
-			event.EventTime,
-			stateWillChange,
-		)
+		if event.EventTime.Before(existing.eventTime) {
+			// Late arrival: current state is kept, but the history gains the state it missed
+			stateHistoryJSON, err = buildBackfilledStateHistory(existing, newState, event.EventTime)
+		} else {
+			stateHistoryJSON, err = buildUpdatedStateHistory(
+				existing.stateHistory,
+				existing.currentState,
+				newState,
+				event.EventTime,
+				stateWillChange,
+			)
+		}
 	}
 
 	if err != nil {
@@ -1004,52 +1562,6 @@ func (s *LineageStore) executeJobRunUpsert(
 	parentRunID := extractParentRunID(event.Run.Facets)
 	rootParentRunID := extractRootParentRunID(event.Run.Facets)
 
-	query := `
-		INSERT INTO job_runs (
-			run_id,
-			job_name,
-			job_namespace,
-			current_state,
-			event_type,
-			event_time,
-			state_history,
-			metadata,
-			producer_name,
-			producer_version,
-			started_at,
-			completed_at,
-			parent_run_id,
-			root_parent_run_id,
-			created_at,
-			updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())
-		ON CONFLICT (run_id) DO UPDATE
-		SET
-			current_state = CASE
-				WHEN EXCLUDED.event_time > job_runs.event_time THEN EXCLUDED.current_state
-				ELSE job_runs.current_state
-			END,
-			event_type = CASE
-				WHEN EXCLUDED.event_time > job_runs.event_time THEN EXCLUDED.event_type
-				ELSE job_runs.event_type
-			END,
-			event_time = GREATEST(job_runs.event_time, EXCLUDED.event_time),
-			state_history = EXCLUDED.state_history,
-			metadata = CASE
-				WHEN EXCLUDED.event_time > job_runs.event_time THEN EXCLUDED.metadata
-				ELSE job_runs.metadata
-			END,
-			producer_version = COALESCE(NULLIF(EXCLUDED.producer_version, ''), job_runs.producer_version),
-			completed_at = CASE
-				WHEN EXCLUDED.completed_at IS NOT NULL AND EXCLUDED.event_time > job_runs.event_time
-					THEN EXCLUDED.completed_at
-				ELSE job_runs.completed_at
-			END,
-			parent_run_id = COALESCE(EXCLUDED.parent_run_id, job_runs.parent_run_id),
-			root_parent_run_id = COALESCE(EXCLUDED.root_parent_run_id, job_runs.root_parent_run_id),
-			updated_at = NOW()
-	`
-
 	var parentRunIDParam sql.NullString
 	if parentRunID != "" {
 		parentRunIDParam = sql.NullString{String: parentRunID, Valid: true}
@@ -1060,11 +1572,43 @@ func (s *LineageStore) executeJobRunUpsert(
 		rootParentRunIDParam = sql.NullString{String: rootParentRunID, Valid: true}
 	}
 
+	var errorMessage, errorStackTrace, errorLanguage, errorClassification sql.NullString
+	if event.Error != nil {
+		errorMessage = sql.NullString{String: event.Error.Message, Valid: true}
+		errorStackTrace = sql.NullString{String: event.Error.StackTrace, Valid: event.Error.StackTrace != ""}
+		errorLanguage = sql.NullString{String: event.Error.ProgrammingLanguage, Valid: event.Error.ProgrammingLanguage != ""}
+		errorClassification = sql.NullString{String: event.Error.Classification, Valid: event.Error.Classification != ""}
+	}
+
+	var openLineageVersion sql.NullString
+	if v := ingestion.ExtractOpenLineageVersion(event.SchemaURL); v != "" && len(v) <= maxOpenLineageVersionLength {
+		openLineageVersion = sql.NullString{String: v, Valid: true}
+	}
+
+	var nominalStartTime, nominalEndTime sql.NullTime
+	if event.NominalTime != nil {
+		nominalStartTime = sql.NullTime{Time: event.NominalTime.StartTime, Valid: true}
+
+		if event.NominalTime.EndTime != nil {
+			nominalEndTime = sql.NullTime{Time: *event.NominalTime.EndTime, Valid: true}
+		}
+	}
+
+	var engineName, engineVersion sql.NullString
+	if engine := event.ProcessingEngine; engine != nil && len(engine.Name) <= maxProcessingEngineNameLength {
+		engineName = sql.NullString{String: engine.Name, Valid: true}
+		engineVersion = sql.NullString{
+			String: engine.Version,
+			Valid:  engine.Version != "" && len(engine.Version) <= maxProcessingEngineVersionLength,
+		}
+	}
+
 	producerName, producerVersion := s.resolveProducer(event.Producer, event.Run.ID)
 
-	_, err := tx.ExecContext(
+	_, err := s.stmts.execTx(
 		ctx,
-		query,
+		tx,
+		stmtUpsertJobRun,
 		event.Run.ID,
 		event.Job.Name,
 		event.Job.Namespace,
@@ -1079,6 +1623,16 @@ func (s *LineageStore) executeJobRunUpsert(
 		completedAt,
 		parentRunIDParam,
 		rootParentRunIDParam,
+		errorMessage,
+		errorStackTrace,
+		errorLanguage,
+		errorClassification,
+		openLineageVersion,
+		nominalStartTime,
+		nominalEndTime,
+		event.IngestedBy,
+		engineName,
+		engineVersion,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to upsert job_run: %w", err)
@@ -1093,8 +1647,10 @@ func (s *LineageStore) upsertDatasetsAndEdges(ctx context.Context, tx *sql.Tx, e
 	runID := event.Run.ID
 	isValidator := len(event.Outputs) == 0
 
-	// Process output datasets (producer events only — validators have no outputs)
-	for _, dataset := range event.Outputs {
+	// Process output datasets (producer events only — validators have no outputs).
+	// Duplicates within a list are collapsed first; inputs and outputs are deduped separately
+	// so a dataset the run both reads and writes keeps both edges.
+	for _, dataset := range ingestion.DedupeDatasets(event.Outputs) {
 		if err := s.upsertProducedDataset(ctx, tx, &dataset, runID); err != nil {
 			return fmt.Errorf("failed to upsert output dataset: %w", err)
 		}
@@ -1102,10 +1658,14 @@ func (s *LineageStore) upsertDatasetsAndEdges(ctx context.Context, tx *sql.Tx, e
 		if err := s.createLineageEdge(ctx, tx, runID, "output", dataset); err != nil {
 			return fmt.Errorf("failed to create output edge: %w", err)
 		}
+
+		if err := s.createColumnLineageEdges(ctx, tx, runID, &dataset); err != nil {
+			return fmt.Errorf("failed to create column lineage edges: %w", err)
+		}
 	}
 
 	// Process input datasets for both producers and validators
-	for _, dataset := range event.Inputs {
+	for _, dataset := range ingestion.DedupeDatasets(event.Inputs) {
 		if isValidator {
 			// Validator: create skeleton record only if dataset doesn't exist.
 			// Validator observations belong in test_results, not datasets.
@@ -1136,11 +1696,11 @@ func (s *LineageStore) upsertProducedDataset(
 ) error {
 	allFacets := make(map[string]interface{})
 
-	for k, v := range dataset.Facets {
+	for k, v := range s.facetFilter.Dataset.apply(dataset.Facets) {
 		allFacets[k] = v
 	}
 
-	for k, v := range dataset.OutputFacets {
+	for k, v := range s.facetFilter.Dataset.apply(dataset.OutputFacets) {
 		allFacets["output_"+k] = v
 	}
 
@@ -1149,23 +1709,18 @@ func (s *LineageStore) upsertProducedDataset(
 		return fmt.Errorf("failed to marshal facets: %w", err)
 	}
 
-	query := `
-		INSERT INTO datasets (
-			dataset_urn, name, namespace, facets, last_producing_run_id,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
-		ON CONFLICT (dataset_urn) DO UPDATE SET
-			facets = datasets.facets || EXCLUDED.facets,
-			last_producing_run_id = EXCLUDED.last_producing_run_id,
-			updated_at = NOW()
-	`
+	before, err := s.lockFacetsForAudit(ctx, tx, dataset.URN())
+	if err != nil {
+		return err
+	}
 
-	_, err = tx.ExecContext(ctx, query, dataset.URN(), dataset.Name, dataset.Namespace, facetsJSON, runID)
+	_, err = s.stmts.execTx(ctx, tx, stmtUpsertProducedDataset,
+		dataset.URN(), dataset.Name, dataset.Namespace, facetsJSON, runID)
 	if err != nil {
 		return fmt.Errorf("failed to upsert produced dataset: %w", err)
 	}
 
-	return nil
+	return s.recordFacetChanges(ctx, tx, dataset.URN(), runID, before, allFacets)
 }
 
 // upsertConsumedDataset inserts or updates a dataset that a producer run reads as input.
@@ -1174,11 +1729,11 @@ func (s *LineageStore) upsertProducedDataset(
 func (s *LineageStore) upsertConsumedDataset(ctx context.Context, tx *sql.Tx, dataset *ingestion.Dataset) error {
 	allFacets := make(map[string]interface{})
 
-	for k, v := range dataset.Facets {
+	for k, v := range s.facetFilter.Dataset.apply(dataset.Facets) {
 		allFacets[k] = v
 	}
 
-	for k, v := range dataset.InputFacets {
+	for k, v := range s.facetFilter.Dataset.apply(dataset.InputFacets) {
 		allFacets["input_"+k] = v
 	}
 
@@ -1187,37 +1742,25 @@ func (s *LineageStore) upsertConsumedDataset(ctx context.Context, tx *sql.Tx, da
 		return fmt.Errorf("failed to marshal facets: %w", err)
 	}
 
-	query := `
-		INSERT INTO datasets (
-			dataset_urn, name, namespace, facets,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, NOW(), NOW())
-		ON CONFLICT (dataset_urn) DO UPDATE SET
-			facets = datasets.facets || EXCLUDED.facets,
-			updated_at = NOW()
-	`
+	before, err := s.lockFacetsForAudit(ctx, tx, dataset.URN())
+	if err != nil {
+		return err
+	}
 
-	_, err = tx.ExecContext(ctx, query, dataset.URN(), dataset.Name, dataset.Namespace, facetsJSON)
+	_, err = s.stmts.execTx(ctx, tx, stmtUpsertConsumedDataset,
+		dataset.URN(), dataset.Name, dataset.Namespace, facetsJSON)
 	if err != nil {
 		return fmt.Errorf("failed to upsert consumed dataset: %w", err)
 	}
 
-	return nil
+	return s.recordFacetChanges(ctx, tx, dataset.URN(), "", before, allFacets)
 }
 
 // ensureDatasetExists creates a minimal dataset record if none exists.
 // Used for validator events whose input datasets may not have been created by a producer yet
 // (out-of-order event arrival). Never overwrites existing data — ON CONFLICT DO NOTHING.
 func (s *LineageStore) ensureDatasetExists(ctx context.Context, tx *sql.Tx, dataset *ingestion.Dataset) error {
-	query := `
-		INSERT INTO datasets (
-			dataset_urn, name, namespace, facets,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, '{}', NOW(), NOW())
-		ON CONFLICT (dataset_urn) DO NOTHING
-	`
-
-	_, err := tx.ExecContext(ctx, query, dataset.URN(), dataset.Name, dataset.Namespace)
+	_, err := s.stmts.execTx(ctx, tx, stmtEnsureDatasetExists, dataset.URN(), dataset.Name, dataset.Namespace)
 	if err != nil {
 		return fmt.Errorf("failed to ensure dataset exists: %w", err)
 	}
@@ -1238,19 +1781,10 @@ func (s *LineageStore) createLineageEdge(
 		return fmt.Errorf("%w: got %q", ErrInvalidEdgeType, edgeType)
 	}
 
-	query := `
-		INSERT INTO lineage_edges (
-			run_id,
-			dataset_urn,
-			edge_type,
-			created_at
-		) VALUES ($1, $2, $3, NOW())
-		ON CONFLICT (run_id, dataset_urn, edge_type) DO NOTHING
-	`
-
-	_, err := tx.ExecContext(
+	_, err := s.stmts.execTx(
 		ctx,
-		query,
+		tx,
+		stmtCreateLineageEdge,
 		runID,
 		dataset.URN(),
 		edgeType,
@@ -1284,16 +1818,7 @@ func (s *LineageStore) recordIdempotency(
 		return fmt.Errorf("failed to marshal event metadata: %w", err)
 	}
 
-	query := `
-		INSERT INTO lineage_event_idempotency (
-			idempotency_key,
-			created_at,
-			expires_at,
-			event_metadata
-		) VALUES ($1, NOW(), NOW() + INTERVAL '24 hours', $2)
-	`
-
-	_, err = tx.ExecContext(ctx, query, idempotencyKey, metadataJSON)
+	_, err = s.stmts.execTx(ctx, tx, stmtRecordIdempotency, idempotencyKey, metadataJSON)
 	if err != nil {
 		return fmt.Errorf("failed to insert idempotency key: %w", err)
 	}
@@ -1330,7 +1855,9 @@ func (s *LineageStore) runCleanup() {
 			// Create context with timeout for cleanup query
 			cleanupCtx, cleanupCancel := context.WithTimeout(ctx, cleanupQueryTimeout)
 			s.cleanupExpiredIdempotencyKeys(cleanupCtx)
+			s.cleanupExpiredLineageBatches(cleanupCtx)
 			cleanupCancel()
+			s.workers.Record(WorkerIdempotencyCleanup, nil)
 		}
 	}
 }
@@ -1466,27 +1993,42 @@ func (s *LineageStore) cleanupExpiredIdempotencyKeys(ctx context.Context) {
 //   - Non-blocking: Errors are logged but don't fail the event storage
 //   - Same transaction: Test results are stored atomically with the event
 //   - Maps success=true to "passed", success=false to "failed"
-//   - Stores optional column in metadata.column
+//   - Stores optional column and severity in metadata.column / metadata.severity
+//   - Attributes every result with metadata.source = "assertion_facet"
+//   - Disabled entirely by WithAssertionFacetIngestion(false)
+//
+// Returns passing tests (for auto-resolve) and failing test result IDs (for background correlation).
 func (s *LineageStore) extractDataQualityAssertions(
 	ctx context.Context,
 	tx *sql.Tx,
 	event *ingestion.RunEvent,
-) []passingTestInfo {
-	var passing []passingTestInfo
+) ([]passingTestInfo, []int64) {
+	if !s.assertionFacets {
+		return nil, nil
+	}
+
+	var (
+		passing []passingTestInfo
+		failing []int64
+	)
 
 	for _, input := range event.Inputs {
-		passing = append(passing,
-			s.extractAssertionsFromFacet(ctx, tx, event, &input,
-				"dataQualityAssertions", "assertion", "dataQualityAssertion")...)
-		passing = append(passing,
-			s.extractAssertionsFromFacet(ctx, tx, event, &input,
-				"greatExpectations_assertions", "expectationType", "greatExpectationsAssertion")...)
+		p, f := s.extractAssertionsFromFacet(ctx, tx, event, &input,
+			"dataQualityAssertions", "assertion", "dataQualityAssertion")
+		passing = append(passing, p...)
+		failing = append(failing, f...)
+
+		p, f = s.extractAssertionsFromFacet(ctx, tx, event, &input,
+			"greatExpectations_assertions", "expectationType", "greatExpectationsAssertion")
+		passing = append(passing, p...)
+		failing = append(failing, f...)
 	}
 
-	return passing
+	return passing, failing
 }
 
 // extractAssertionsFromFacet extracts test results from a single assertion facet on an input dataset.
+// Returns the passing tests and the IDs of failing tests that were stored.
 //
 // Parameters:
 //   - facetKey: The key in inputFacets (e.g. "dataQualityAssertions", "greatExpectations_assertions")
@@ -1502,12 +2044,12 @@ func (s *LineageStore) extractAssertionsFromFacet(
 	facetKey string,
 	testNameField string,
 	testType string,
-) []passingTestInfo {
+) ([]passingTestInfo, []int64) {
 	runID := event.Run.ID
 
 	facet, ok := input.InputFacets[facetKey]
 	if !ok {
-		return nil
+		return nil, nil
 	}
 
 	facetMap, ok := facet.(map[string]interface{})
@@ -1518,7 +2060,7 @@ func (s *LineageStore) extractAssertionsFromFacet(
 			slog.String("dataset_urn", input.URN()),
 		)
 
-		return nil
+		return nil, nil
 	}
 
 	assertionsRaw, ok := facetMap["assertions"]
@@ -1529,7 +2071,7 @@ func (s *LineageStore) extractAssertionsFromFacet(
 			slog.String("dataset_urn", input.URN()),
 		)
 
-		return nil
+		return nil, nil
 	}
 
 	assertions, ok := assertionsRaw.([]interface{})
@@ -1540,12 +2082,15 @@ func (s *LineageStore) extractAssertionsFromFacet(
 			slog.String("dataset_urn", input.URN()),
 		)
 
-		return nil
+		return nil, nil
 	}
 
 	producerName, producerVersion := s.resolveProducer(event.Producer, runID)
 
-	var passing []passingTestInfo
+	var (
+		passing []passingTestInfo
+		failing []int64
+	)
 
 	for _, assertionRaw := range assertions {
 		assertion, ok := assertionRaw.(map[string]interface{})
@@ -1587,15 +2132,18 @@ func (s *LineageStore) extractAssertionsFromFacet(
 			)
 		}
 
-		// Extract optional column into metadata
-		var metadata map[string]interface{}
+		// Attribute the result to its facet so it can be told apart from other test result sources
+		metadata := map[string]interface{}{
+			ingestion.TestResultSourceKey: ingestion.TestResultSourceAssertionFacet,
+		}
+
+		// Extract optional column and severity into metadata
 		if column, ok := assertion["column"].(string); ok && column != "" {
-			metadata = map[string]interface{}{"column": column}
+			metadata["column"] = column
 		}
 
-		// Extract optional severity into metadata
-		if column, ok := assertion["severity"].(string); ok && column != "" {
-			metadata = map[string]interface{}{"severity": column}
+		if severity, ok := assertion["severity"].(string); ok && severity != "" {
+			metadata["severity"] = severity
 		}
 
 		// NOTE: duration_ms and message columns remain in the test_results schema but are not
@@ -1611,7 +2159,7 @@ func (s *LineageStore) extractAssertionsFromFacet(
 			RunID:           runID,
 			Status:          status,
 			Metadata:        metadata,
-			Facets:          input.InputFacets,
+			Facets:          s.facetFilter.Dataset.apply(input.InputFacets),
 			ExecutedAt:      event.EventTime,
 			ProducerName:    producerName,
 			ProducerVersion: producerVersion,
@@ -1633,10 +2181,12 @@ func (s *LineageStore) extractAssertionsFromFacet(
 				testName:     testName,
 				datasetURN:   input.URN(),
 			})
+		} else {
+			failing = append(failing, testResultID)
 		}
 	}
 
-	return passing
+	return passing, failing
 }
 
 // storeTestResult stores a single test result within an existing transaction.
@@ -1645,8 +2195,10 @@ func (s *LineageStore) extractAssertionsFromFacet(
 // Behavior:
 //   - Uses existing transaction (same as event storage for atomicity)
 //   - Skips validation (facet data is already semi-validated)
-//   - UPSERT on (test_name, dataset_urn, run_id) — one result per test per job run
-//   - On conflict, updates with latest event data (COMPLETE overwrites START)
+//   - UPSERT on the test_results_upsert_key constraint (test_name, dataset_urn, run_id) — one
+//     result per test per job run
+//   - On conflict, updates with latest event data: last write wins on status, message,
+//     executed_at, etc. (COMPLETE overwrites START, a re-run overwrites the previous run)
 func (s *LineageStore) storeTestResult(
 	ctx context.Context,
 	tx *sql.Tx,
@@ -1677,7 +2229,7 @@ func (s *LineageStore) storeTestResult(
 			producer_name,
 			producer_version
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		ON CONFLICT (test_name, dataset_urn, run_id)
+		ON CONFLICT ON CONSTRAINT test_results_upsert_key
 		DO UPDATE SET
 			test_type = EXCLUDED.test_type,
 			status = EXCLUDED.status,
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,12 +31,12 @@ const (
 
 // setupBenchmarkStore creates a test database and lineage store for benchmarks.
 // Caller is responsible for calling cleanup function.
-func setupBenchmarkStore(ctx context.Context, b *testing.B) (*LineageStore, func()) {
+func setupBenchmarkStore(ctx context.Context, b *testing.B, opts ...LineageStoreOption) (*LineageStore, func()) {
 	b.Helper()
 
 	container, conn := setupTestDatabase(ctx, b)
 
-	store, err := NewLineageStore(conn, 1*time.Hour) //nolint: contextcheck
+	store, err := NewLineageStore(conn, 1*time.Hour, opts...) //nolint: contextcheck
 	if err != nil {
 		b.Fatalf("NewLineageStore() error = %v", err)
 	}
@@ -164,3 +165,105 @@ func BenchmarkLineageStore_StoreEvent_Duplicate(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkLineageStore_StoreEvent_PreparedStatements compares single event storage with
+// cached prepared statements (default) against ad-hoc queries parsed on every call.
+func BenchmarkLineageStore_StoreEvent_PreparedStatements(b *testing.B) {
+	if testing.Short() {
+		b.Skip("skipping benchmark in short mode")
+	}
+
+	for _, bc := range []struct {
+		name     string
+		prepared bool
+	}{
+		{name: "prepared", prepared: true},
+		{name: "ad-hoc", prepared: false},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := context.Background()
+
+			store, cleanup := setupBenchmarkStore(ctx, b, WithPreparedStatements(bc.prepared))
+			defer cleanup()
+
+			events := make([]*ingestion.RunEvent, b.N)
+			for i := range b.N {
+				events[i] = createTestEvent(
+					fmt.Sprintf("bench-%s-%d", bc.name, i),
+					ingestion.EventTypeStart,
+					2, // 2 input datasets
+					1, // 1 output dataset
+				)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := range b.N {
+				if _, _, err := store.StoreEvent(ctx, events[i]); err != nil {
+					b.Fatalf("StoreEvent() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLineageStore_StoreEvent_WriteBuffer compares a burst of concurrent single events
+// stored immediately (one transaction each) against the write buffer (one transaction per flush).
+//
+// Each iteration stores writeBufferBurstSize events, one per goroutine, as concurrent requests do.
+func BenchmarkLineageStore_StoreEvent_WriteBuffer(b *testing.B) {
+	if testing.Short() {
+		b.Skip("skipping benchmark in short mode")
+	}
+
+	const writeBufferBurstSize = 200
+
+	for _, bc := range []struct {
+		name   string
+		buffer WriteBufferConfig
+	}{
+		{name: "immediate"},
+		{name: "buffered", buffer: WriteBufferConfig{MaxEvents: 100, FlushInterval: 5 * time.Millisecond}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := context.Background()
+
+			store, cleanup := setupBenchmarkStore(ctx, b, WithWriteBuffer(bc.buffer))
+			defer cleanup()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := range b.N {
+				b.StopTimer()
+
+				events := make([]*ingestion.RunEvent, writeBufferBurstSize)
+				for j := range events {
+					events[j] = createTestEvent(fmt.Sprintf("bench-%s-%d-%d", bc.name, i, j),
+						ingestion.EventTypeStart, 1, 1)
+				}
+
+				b.StartTimer()
+
+				var wg sync.WaitGroup
+
+				for _, event := range events {
+					wg.Add(1)
+
+					go func() {
+						defer wg.Done()
+
+						if _, _, err := store.StoreEvent(ctx, event); err != nil {
+							b.Errorf("StoreEvent() error = %v", err)
+						}
+					}()
+				}
+
+				wg.Wait()
+			}
+
+			b.ReportMetric(float64(b.N*writeBufferBurstSize)/b.Elapsed().Seconds(), "events/s")
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -73,12 +74,17 @@ func TestLineageStoreIntegration(t *testing.T) {
 	t.Run("StoreEvent_OutOfOrder", testStoreEventOutOfOrder(ctx, store, conn))
 	t.Run("StoreEvent_TerminalStateProtection", testStoreEventTerminalStateProtection(ctx, store, conn))
 	t.Run("StoreEvent_MultipleInputsOutputs", testStoreEventMultipleInputsOutputs(ctx, store, conn))
+	t.Run("StoreEvent_DuplicateDatasetsInEvent", testStoreEventDuplicateDatasetsInEvent(ctx, store, conn))
 	t.Run("StoreEvent_IdempotencyTTL", testStoreEventIdempotencyTTL(ctx, store, conn))
 	t.Run("StoreEvents_AllSuccess", testStoreEventsAllSuccess(ctx, store))
 	t.Run("StoreEvents_PartialSuccess", testStoreEventsPartialSuccess(ctx, store))
 	t.Run("StoreEvents_AllDuplicates", testStoreEventsAllDuplicates(ctx, store))
+	t.Run("StoreEventsAtomic_AllSuccess", testStoreEventsAtomicAllSuccess(ctx, store, conn))
+	t.Run("StoreEventsAtomic_RollbackOnFailure", testStoreEventsAtomicRollbackOnFailure(ctx, store, conn))
 	t.Run("DeferredFKConstraints_TableLevel", testDeferredFKConstraintsAtTableLevel(ctx, conn))
 	t.Run("StoreEvent_StateHistoryUpdate", testStoreEventStateHistoryUpdate(ctx, store, conn))
+	t.Run("StoreEvent_ReversedArrivalStateHistory", testStoreEventReversedArrivalStateHistory(ctx, store, conn))
+	t.Run("StoreEvents_ReversedSingleRunBatch", testStoreEventsReversedSingleRunBatch(ctx, store, conn))
 	t.Run("StoreEvent_SameStateNoRedundantTransitions", testStoreEventSameStateNoRedundantTransitions(ctx, store, conn))
 	t.Run("StoreEvent_ProducerExtraction", testStoreEventProducerExtraction(ctx, store, conn))
 	t.Run("StoreEvent_DatasetFacetMerge", testStoreEventDatasetFacetMerge(ctx, store, conn))
@@ -354,6 +360,52 @@ func testStoreEventMultipleInputsOutputs(ctx context.Context, store *LineageStor
 	}
 }
 
+// testStoreEventDuplicateDatasetsInEvent verifies datasets listed twice in one event.
+// Expected: The duplicated input collapses to one input edge with both stages' facets; a dataset
+// that is both input and output keeps one edge of each type.
+func testStoreEventDuplicateDatasetsInEvent(
+	ctx context.Context,
+	store *LineageStore,
+	conn *Connection,
+) func(*testing.T) {
+	return func(t *testing.T) {
+		event := createTestEvent("spark-dup-inputs-1", ingestion.EventTypeComplete, 2, 1)
+
+		// Spark reads the first input in two stages, reporting different facets each time
+		stage2 := event.Inputs[0]
+		stage2.Facets = ingestion.Facets{"stage": "2"}
+		event.Inputs[0].Facets = ingestion.Facets{"owner": "alice", "stage": "1"}
+
+		// The second input is also the output (self-referential, e.g. an incremental merge)
+		event.Outputs[0].Namespace = event.Inputs[1].Namespace
+		event.Outputs[0].Name = event.Inputs[1].Name
+
+		event.Inputs = append(event.Inputs, stage2)
+
+		stored, _, err := store.StoreEvent(ctx, event)
+		if err != nil {
+			t.Fatalf("StoreEvent() error = %v", err)
+		}
+
+		if !stored {
+			t.Errorf("StoreEvent() stored = false, want true")
+		}
+
+		if got := countLineageEdgesByType(ctx, t, conn, event.Run.ID, "input"); got != 2 {
+			t.Errorf("Input edge count = %d, want 2", got)
+		}
+
+		if got := countLineageEdgesByType(ctx, t, conn, event.Run.ID, "output"); got != 1 {
+			t.Errorf("Output edge count = %d, want 1", got)
+		}
+
+		facets := getDatasetFacets(ctx, t, conn, stage2.URN())
+		if facets["owner"] != "alice" || facets["stage"] != "2" {
+			t.Errorf("facets = %v, want owner=alice from stage 1 and stage=2 from stage 2", facets)
+		}
+	}
+}
+
 // testStoreEventIdempotencyTTL verifies idempotency key expiration.
 // Expected: Expired idempotency key (>24 hours) allows re-storage.
 func testStoreEventIdempotencyTTL(ctx context.Context, store *LineageStore, conn *Connection) func(*testing.T) {
@@ -537,6 +589,103 @@ func testStoreEventsAllDuplicates(ctx context.Context, store *LineageStore) func
 	}
 }
 
+// testStoreEventsAtomicAllSuccess verifies an atomic batch where every event succeeds.
+// Expected: All events stored in one transaction; a repeat within the batch is a duplicate.
+func testStoreEventsAtomicAllSuccess(ctx context.Context, store *LineageStore, conn *Connection) func(*testing.T) {
+	return func(t *testing.T) {
+		first := createTestEvent("dbt-atomic-ok-1", ingestion.EventTypeStart, 1, 1)
+		second := createTestEvent("dbt-atomic-ok-2", ingestion.EventTypeStart, 1, 1)
+
+		results, err := store.StoreEventsAtomic(ctx, []*ingestion.RunEvent{first, second, first})
+		if err != nil {
+			t.Fatalf("StoreEventsAtomic() error = %v", err)
+		}
+
+		if len(results) != 3 {
+			t.Fatalf("StoreEventsAtomic() returned %d results, want 3", len(results))
+		}
+
+		for i, want := range []bool{true, true, false} {
+			if results[i].Error != nil {
+				t.Errorf("Result[%d] error = %v, want nil", i, results[i].Error)
+			}
+
+			if results[i].Stored != want || results[i].Duplicate == want {
+				t.Errorf("Result[%d] stored = %t, duplicate = %t, want stored = %t", i,
+					results[i].Stored, results[i].Duplicate, want)
+			}
+		}
+
+		for _, event := range []*ingestion.RunEvent{first, second} {
+			if count := countJobRuns(ctx, t, conn, event.Run.ID); count != 1 {
+				t.Errorf("job_runs for %s = %d, want 1", event.Run.ID, count)
+			}
+		}
+	}
+}
+
+// testStoreEventsAtomicRollbackOnFailure verifies an atomic batch where one event fails in the
+// database (invalid transition out of a terminal state).
+// Expected: Nothing stored; the failed event carries its error, the others ErrBatchRolledBack.
+func testStoreEventsAtomicRollbackOnFailure(
+	ctx context.Context,
+	store *LineageStore,
+	conn *Connection,
+) func(*testing.T) {
+	return func(t *testing.T) {
+		baseTime := time.Now()
+
+		completed := createTestEventWithTime("dbt-atomic-terminal", ingestion.EventTypeComplete, 1, 1, baseTime)
+		if _, _, err := store.StoreEvent(ctx, completed); err != nil {
+			t.Fatalf("StoreEvent(COMPLETE) error = %v", err)
+		}
+
+		good := createTestEventWithTime("dbt-atomic-good", ingestion.EventTypeStart, 1, 1, baseTime)
+		restart := createTestEventWithTime("dbt-atomic-terminal", ingestion.EventTypeStart, 1, 1,
+			baseTime.Add(time.Minute))
+		after := createTestEventWithTime("dbt-atomic-after", ingestion.EventTypeStart, 1, 1, baseTime)
+
+		results, err := store.StoreEventsAtomic(ctx, []*ingestion.RunEvent{good, restart, after})
+		if err != nil {
+			t.Fatalf("StoreEventsAtomic() error = %v", err)
+		}
+
+		if !containsString(results[1].Error.Error(), "invalid state transition from terminal state") {
+			t.Errorf("Result[1] error = %v, want terminal state error", results[1].Error)
+		}
+
+		for _, i := range []int{0, 2} {
+			if !errors.Is(results[i].Error, ingestion.ErrBatchRolledBack) {
+				t.Errorf("Result[%d] error = %v, want ErrBatchRolledBack", i, results[i].Error)
+			}
+		}
+
+		for i, result := range results {
+			if result.Stored || result.Duplicate {
+				t.Errorf("Result[%d] stored = %t, duplicate = %t, want neither", i, result.Stored, result.Duplicate)
+			}
+		}
+
+		for _, event := range []*ingestion.RunEvent{good, after} {
+			if count := countJobRuns(ctx, t, conn, event.Run.ID); count != 0 {
+				t.Errorf("job_runs for %s = %d, want 0 (batch rolled back)", event.Run.ID, count)
+			}
+		}
+
+		// Rolled back events are not remembered as processed: the corrected batch goes through
+		results, err = store.StoreEventsAtomic(ctx, []*ingestion.RunEvent{good, after})
+		if err != nil {
+			t.Fatalf("StoreEventsAtomic() error = %v", err)
+		}
+
+		for i, result := range results {
+			if !result.Stored {
+				t.Errorf("Retry result[%d] stored = false, want true (error = %v)", i, result.Error)
+			}
+		}
+	}
+}
+
 // testDeferredFKConstraintsAtTableLevel verifies PostgreSQL deferred FK constraints work correctly.
 // This test directly verifies the database-level deferred FK behavior by inserting a lineage_edge
 // BEFORE the referenced dataset exists, which would fail with immediate FK constraints.
@@ -1280,6 +1429,94 @@ func getProducerName(ctx context.Context, t *testing.T, conn *Connection, runID
 	return producerName
 }
 
+// reversedRunEvents returns the COMPLETE, RUNNING and START events of runID, newest first.
+func reversedRunEvents(runID string) []*ingestion.RunEvent {
+	baseTime := time.Now().Add(-1 * time.Hour)
+
+	return []*ingestion.RunEvent{
+		createTestEventWithTime(runID, ingestion.EventTypeComplete, 1, 1, baseTime.Add(5*time.Minute)),
+		createTestEventWithTime(runID, ingestion.EventTypeStart, 1, 1, baseTime),
+		createTestEventWithTime(runID, ingestion.EventTypeRunning, 1, 1, baseTime.Add(2*time.Minute)),
+	}
+}
+
+// verifyChronologicalStateHistory checks that the run ended COMPLETE with the state_history
+// null → START → RUNNING → COMPLETE, in event time order.
+func verifyChronologicalStateHistory(ctx context.Context, t *testing.T, conn *Connection, runID string) {
+	t.Helper()
+
+	if state := getJobRunState(ctx, t, conn, runID); state != string(ingestion.EventTypeComplete) {
+		t.Errorf("current_state = %s, want COMPLETE", state)
+	}
+
+	history := getStateHistory(ctx, t, conn, runID)
+	want := []struct{ from, to interface{} }{{nil, "START"}, {"START", "RUNNING"}, {"RUNNING", "COMPLETE"}}
+
+	if len(history) != len(want) {
+		t.Fatalf("state_history = %v, want %d transitions", history, len(want))
+	}
+
+	var previous time.Time
+
+	for i, w := range want {
+		if history[i]["from"] != w.from || history[i]["to"] != w.to {
+			t.Errorf("transition %d = %v → %v, want %v → %v", i, history[i]["from"], history[i]["to"], w.from, w.to)
+		}
+
+		eventTime, err := time.Parse(time.RFC3339Nano, fmt.Sprint(history[i]["event_time"]))
+		if err != nil || eventTime.Before(previous) {
+			t.Errorf("transition %d event_time = %v, want chronological order", i, history[i]["event_time"])
+		}
+
+		previous = eventTime
+	}
+}
+
+// testStoreEventReversedArrivalStateHistory verifies that events of one run stored newest first,
+// one at a time, still produce a chronological state_history.
+func testStoreEventReversedArrivalStateHistory(
+	ctx context.Context,
+	store *LineageStore,
+	conn *Connection,
+) func(*testing.T) {
+	return func(t *testing.T) {
+		events := reversedRunEvents("dbt-history-reversed")
+
+		for _, event := range events {
+			if _, _, err := store.StoreEvent(ctx, event); err != nil {
+				t.Fatalf("StoreEvent(%s) error = %v", event.EventType, err)
+			}
+		}
+
+		verifyChronologicalStateHistory(ctx, t, conn, events[0].Run.ID)
+	}
+}
+
+// testStoreEventsReversedSingleRunBatch verifies that a single-run batch submitted newest first
+// is applied in event time order, with results still reported in input order.
+func testStoreEventsReversedSingleRunBatch(
+	ctx context.Context,
+	store *LineageStore,
+	conn *Connection,
+) func(*testing.T) {
+	return func(t *testing.T) {
+		events := reversedRunEvents("dbt-history-reversed-batch")
+
+		results, err := store.StoreEvents(ctx, events)
+		if err != nil {
+			t.Fatalf("StoreEvents() error = %v", err)
+		}
+
+		for i, result := range results {
+			if result.Event != events[i] || !result.Stored || result.Error != nil {
+				t.Errorf("results[%d] = %+v, want stored result of events[%d]", i, result, i)
+			}
+		}
+
+		verifyChronologicalStateHistory(ctx, t, conn, events[0].Run.ID)
+	}
+}
+
 func getStateHistory(ctx context.Context, t *testing.T, conn *Connection, runID string) []map[string]interface{} {
 	t.Helper()
 
@@ -1680,6 +1917,12 @@ func TestExtractDataQualityAssertions(t *testing.T) {
 	t.Run("RawFacetsStored", func(t *testing.T) {
 		testExtractRawFacetsStored(ctx, t, store, conn)
 	})
+	t.Run("SourceAttribution", func(t *testing.T) {
+		testExtractSourceAttribution(ctx, t, store, conn)
+	})
+	t.Run("RerunUpserts", func(t *testing.T) {
+		testExtractRerunUpserts(ctx, t, store, conn)
+	})
 }
 
 // testExtractSingleAssertion verifies extraction of a single assertion
@@ -1720,6 +1963,47 @@ func testExtractSingleAssertion(ctx context.Context, t *testing.T, store *Lineag
 	assert.Equal(t, expectedDatasetURN, testResult.datasetURN, "Dataset URN should match exactly")
 }
 
+// testExtractRerunUpserts verifies that re-running a test within the same job run updates its
+// result (test_results_upsert_key: test_name + dataset_urn + run_id) instead of adding a row.
+func testExtractRerunUpserts(ctx context.Context, t *testing.T, store *LineageStore, conn *Connection) {
+	t.Helper()
+
+	first := createEventWithAssertions(
+		"rerun-upsert-test",
+		[]assertionData{{assertion: "not_null_rerun_id", success: false, column: "id"}},
+	)
+
+	stored, _, err := store.StoreEvent(ctx, first)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	// Same test, dataset and run, reported again a minute later: now passing
+	rerun := createEventWithAssertions(
+		"rerun-upsert-test",
+		[]assertionData{{assertion: "not_null_rerun_id", success: true, column: "id"}},
+	)
+	rerun.EventTime = first.EventTime.Add(time.Minute)
+
+	stored, duplicate, err := store.StoreEvent(ctx, rerun)
+	require.NoError(t, err)
+	require.True(t, stored, "Re-run event should be stored")
+	require.False(t, duplicate, "Re-run event should not be an idempotency duplicate")
+
+	assert.Equal(t, 1, countTestResultsForJobRun(ctx, t, conn, rerun.Run.ID),
+		"Re-run should update the test result, not add one")
+
+	testResult := getTestResultByTestName(ctx, t, conn, "not_null_rerun_id")
+	assert.Equal(t, "passed", testResult.status, "Last write should win on status")
+
+	var executedAt time.Time
+
+	err = conn.QueryRowContext(ctx, `SELECT executed_at FROM test_results WHERE test_name = $1`,
+		"not_null_rerun_id").Scan(&executedAt)
+	require.NoError(t, err)
+	assert.WithinDuration(t, rerun.EventTime, executedAt, time.Millisecond,
+		"Last write should win on executed_at")
+}
+
 // testExtractMultipleAssertions verifies extraction of multiple assertions
 // from a single dataQualityAssertions facet.
 func testExtractMultipleAssertions(ctx context.Context, t *testing.T, store *LineageStore, conn *Connection) {
@@ -1843,6 +2127,57 @@ func testExtractRawFacetsStored(ctx context.Context, t *testing.T, store *Lineag
 	assert.Contains(t, failedResult.facets, "dataQualityAssertions", "facets should contain the assertion facet")
 }
 
+// testExtractSourceAttribution verifies that derived test results are tagged with
+// metadata.source = "assertion_facet" alongside the column and severity fields.
+func testExtractSourceAttribution(ctx context.Context, t *testing.T, store *LineageStore, conn *Connection) {
+	t.Helper()
+
+	event := createEventWithAssertions("source-attribution-test",
+		[]assertionData{{assertion: "not_null_orders_source", success: false, column: "source"}})
+
+	facet, _ := event.Inputs[0].InputFacets["dataQualityAssertions"].(map[string]interface{})
+	assertions, _ := facet["assertions"].([]interface{})
+	assertions[0].(map[string]interface{})["severity"] = "error"
+
+	_, _, err := store.StoreEvent(ctx, event)
+	require.NoError(t, err)
+
+	var metadataRaw []byte
+
+	err = conn.QueryRowContext(ctx, `SELECT metadata FROM test_results WHERE test_name = $1`,
+		"not_null_orders_source").Scan(&metadataRaw)
+	require.NoError(t, err)
+
+	var metadata map[string]interface{}
+	require.NoError(t, json.Unmarshal(metadataRaw, &metadata))
+
+	assert.Equal(t, map[string]interface{}{
+		ingestion.TestResultSourceKey: ingestion.TestResultSourceAssertionFacet,
+		"column":                      "source",
+		"severity":                    "error",
+	}, metadata)
+}
+
+// TestExtractDataQualityAssertions_Disabled verifies that no test results are derived from
+// assertion facets when assertion facet ingestion is turned off.
+func TestExtractDataQualityAssertions_Disabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t, WithAssertionFacetIngestion(false))
+
+	event := createEventWithAssertions("assertions-disabled-test",
+		[]assertionData{{assertion: "not_null_orders_disabled", success: false}})
+
+	stored, _, err := store.StoreEvent(ctx, event)
+	require.NoError(t, err)
+	assert.True(t, stored, "Lineage must still be stored")
+
+	assert.Equal(t, 0, countTestResultsForJobRun(ctx, t, store.conn, event.Run.ID))
+}
+
 // TestExtractGEAssertions tests extraction of test results from
 // greatExpectations_assertions facets emitted by the standard GE-ol integration.
 func TestExtractGEAssertions(t *testing.T) {
@@ -1,9 +1,12 @@
 package storage
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestExtractProducerName verifies producer name extraction from OpenLineage URLs.
@@ -394,3 +397,89 @@ func TestExtractRootParentRunID(t *testing.T) {
 		})
 	}
 }
+
+// TestBuildBackfilledStateHistory verifies that late events are inserted into state_history at
+// their chronological position.
+func TestBuildBackfilledStateHistory(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	base := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+	// sequence returns the from→to chain of a state_history, e.g. "→START, START→COMPLETE".
+	sequence := func(t *testing.T, historyJSON []byte) []string {
+		t.Helper()
+
+		var history struct {
+			Transitions []stateTransition `json:"transitions"`
+		}
+
+		require.NoError(t, json.Unmarshal(historyJSON, &history))
+
+		chain := make([]string, 0, len(history.Transitions))
+		for _, transition := range history.Transitions {
+			from, _ := transition.From.(string)
+			chain = append(chain, from+"→"+transition.To)
+		}
+
+		return chain
+	}
+
+	t.Run("reversed arrival yields the chronological sequence", func(t *testing.T) {
+		history, err := buildInitialStateHistory("COMPLETE", at(5))
+		require.NoError(t, err)
+
+		existing := jobRunState{exists: true, currentState: "COMPLETE", eventTime: at(5), stateHistory: history}
+
+		existing.stateHistory, err = buildBackfilledStateHistory(existing, "START", at(0))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"→START", "START→COMPLETE"}, sequence(t, existing.stateHistory))
+
+		existing.stateHistory, err = buildBackfilledStateHistory(existing, "RUNNING", at(3))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"→START", "START→RUNNING", "RUNNING→COMPLETE"}, sequence(t, existing.stateHistory))
+	})
+
+	t.Run("state already held at event time leaves history unchanged", func(t *testing.T) {
+		history, err := buildInitialStateHistory("START", at(0))
+		require.NoError(t, err)
+
+		history, err = buildUpdatedStateHistory(history, "START", "COMPLETE", at(5), true)
+		require.NoError(t, err)
+
+		existing := jobRunState{exists: true, currentState: "COMPLETE", eventTime: at(5), stateHistory: history}
+
+		got, err := buildBackfilledStateHistory(existing, "START", at(2))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"→START", "START→COMPLETE"}, sequence(t, got))
+	})
+
+	t.Run("redundant following transition is dropped", func(t *testing.T) {
+		history, err := buildInitialStateHistory("START", at(0))
+		require.NoError(t, err)
+
+		history, err = buildUpdatedStateHistory(history, "START", "COMPLETE", at(5), true)
+		require.NoError(t, err)
+
+		existing := jobRunState{exists: true, currentState: "COMPLETE", eventTime: at(5), stateHistory: history}
+
+		got, err := buildBackfilledStateHistory(existing, "COMPLETE", at(4))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"→START", "START→COMPLETE"}, sequence(t, got))
+		assert.Contains(t, string(got), at(4).Format(time.RFC3339Nano), "COMPLETE should date from the earlier event")
+	})
+
+	t.Run("return to the current state is recorded", func(t *testing.T) {
+		history, err := buildInitialStateHistory("RUNNING", at(1))
+		require.NoError(t, err)
+
+		// RUNNING at 1 and again at 4; a late START at 3 means the run left RUNNING in between
+		existing := jobRunState{exists: true, currentState: "RUNNING", eventTime: at(4), stateHistory: history}
+
+		got, err := buildBackfilledStateHistory(existing, "START", at(3))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"→RUNNING", "RUNNING→START", "START→RUNNING"}, sequence(t, got))
+	})
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// maintenanceTables are the high-churn tables whose bloat is reported by MaintenanceStats.
+// Every stored event upserts job_runs and datasets and inserts lineage_edges.
+var maintenanceTables = []string{"job_runs", "datasets", "lineage_edges"} //nolint:gochecknoglobals
+
+// TableMaintenance is the vacuum status of one table, read from pg_stat_user_tables.
+// Statistics are approximate and reset with the server's statistics (e.g., after a crash).
+type TableMaintenance struct {
+	Table          string
+	LiveTuples     int64
+	DeadTuples     int64
+	LastVacuum     *time.Time // Latest of manual VACUUM and autovacuum (nil = never)
+	LastAutoVacuum *time.Time
+}
+
+// DeadTupleRatio returns dead / (live + dead) tuples, or 0 for an empty table.
+func (m TableMaintenance) DeadTupleRatio() float64 {
+	total := m.LiveTuples + m.DeadTuples
+	if total == 0 {
+		return 0
+	}
+
+	return float64(m.DeadTuples) / float64(total)
+}
+
+// MaintenanceStats returns the vacuum status of job_runs, datasets, and lineage_edges.
+//
+// Read-only diagnostics: a high dead-tuple ratio means autovacuum is not keeping up with
+// upsert traffic and should be tuned before queries slow down. Nothing is vacuumed here.
+// Tables missing from pg_stat_user_tables (e.g., not yet migrated) are omitted.
+func (s *LineageStore) MaintenanceStats(ctx context.Context) ([]TableMaintenance, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT relname,
+		       n_live_tup,
+		       n_dead_tup,
+		       GREATEST(last_vacuum, last_autovacuum),
+		       last_autovacuum
+		FROM pg_stat_user_tables
+		WHERE schemaname = current_schema() AND relname = ANY($1)
+		ORDER BY relname
+	`, pq.Array(maintenanceTables))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table statistics: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var stats []TableMaintenance
+
+	for rows.Next() {
+		var (
+			m                          TableMaintenance
+			lastVacuum, lastAutoVacuum sql.NullTime
+		)
+
+		if err := rows.Scan(&m.Table, &m.LiveTuples, &m.DeadTuples, &lastVacuum, &lastAutoVacuum); err != nil {
+			return nil, fmt.Errorf("failed to scan table statistics: %w", err)
+		}
+
+		if lastVacuum.Valid {
+			m.LastVacuum = &lastVacuum.Time
+		}
+
+		if lastAutoVacuum.Valid {
+			m.LastAutoVacuum = &lastAutoVacuum.Time
+		}
+
+		stats = append(stats, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating table statistics: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMaintenanceStats verifies that vacuum statistics are reported for the high-churn tables.
+func TestMaintenanceStats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	stats, err := store.MaintenanceStats(ctx)
+	require.NoError(t, err)
+
+	tables := make([]string, 0, len(stats))
+	for _, m := range stats {
+		tables = append(tables, m.Table)
+
+		assert.GreaterOrEqual(t, m.LiveTuples, int64(0))
+		assert.GreaterOrEqual(t, m.DeadTuples, int64(0))
+	}
+
+	assert.Equal(t, []string{"datasets", "job_runs", "lineage_edges"}, tables)
+}
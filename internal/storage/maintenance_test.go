@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableMaintenanceDeadTupleRatio(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		name string
+		m    TableMaintenance
+		want float64
+	}{
+		{name: "empty table", m: TableMaintenance{}, want: 0},
+		{name: "no dead tuples", m: TableMaintenance{LiveTuples: 100}, want: 0},
+		{name: "quarter dead", m: TableMaintenance{LiveTuples: 75, DeadTuples: 25}, want: 0.25},
+		{name: "only dead tuples", m: TableMaintenance{DeadTuples: 10}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.m.DeadTupleRatio(), 1e-9)
+		})
+	}
+}
+
+func TestMaintenanceStats_NoConnection(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &LineageStore{}
+
+	_, err := store.MaintenanceStats(context.Background())
+	require.ErrorIs(t, err, ErrNoDatabaseConnection)
+}
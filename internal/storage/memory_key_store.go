@@ -49,6 +49,38 @@ func (s *InMemoryKeyStore) FindByKey(_ context.Context, key string) (*APIKey, bo
 	return &keyCopy, true
 }
 
+// FindByID retrieves an API key by its ID.
+func (s *InMemoryKeyStore) FindByID(_ context.Context, keyID string) (*APIKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	apiKey, exists := s.keysByID[keyID]
+	if !exists {
+		return nil, ErrKeyNotFound
+	}
+
+	// Return a copy to prevent external modification
+	keyCopy := *apiKey
+
+	return &keyCopy, nil
+}
+
+// FindByName retrieves a client's API key by name, active or not.
+func (s *InMemoryKeyStore) FindByName(_ context.Context, clientID, name string) (*APIKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, apiKey := range s.keysByClient[clientID] {
+		if apiKey.Name == name {
+			keyCopy := *apiKey
+
+			return &keyCopy, nil
+		}
+	}
+
+	return nil, ErrKeyNotFound
+}
+
 // Add stores a new API key.
 func (s *InMemoryKeyStore) Add(_ context.Context, apiKey *APIKey) error {
 	if apiKey == nil { // pragma: allowlist secret
@@ -60,6 +60,36 @@ func TestInMemoryKeyStore(t *testing.T) {
 		}
 	})
 
+	t.Run("find key by ID", func(t *testing.T) {
+		store := NewInMemoryKeyStore()
+
+		if err := store.Add(ctx, testKey); err != nil {
+			t.Errorf("Add() unexpected error: %v", err)
+		}
+
+		found, err := store.FindByID(ctx, testKey.ID)
+		if err != nil {
+			t.Fatalf("FindByID() unexpected error: %v", err)
+		}
+
+		if found.Name != testKey.Name {
+			t.Errorf("FindByID() Name = %v, want %v", found.Name, testKey.Name)
+		}
+
+		if _, err := store.FindByID(ctx, "non-existent-id"); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, ErrKeyNotFound)
+		}
+
+		byName, err := store.FindByName(ctx, testKey.ClientID, testKey.Name)
+		if err != nil || byName.ID != testKey.ID {
+			t.Errorf("FindByName() = %+v, %v; want key %s", byName, err, testKey.ID)
+		}
+
+		if _, err := store.FindByName(ctx, testKey.ClientID, "other"); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("FindByName() error = %v, want %v", err, ErrKeyNotFound)
+		}
+	})
+
 	t.Run("update existing key", func(t *testing.T) {
 		store := NewInMemoryKeyStore()
 		// Add initial key
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestJobRunNominalTime verifies that the nominal times of a run are stored from the event that
+// carries the nominalTime facet, kept by later events without it, and left NULL for runs without it.
+func TestJobRunNominalTime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	nominalStart := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Microsecond)
+	nominalEnd := nominalStart.Add(time.Hour)
+
+	start := createTestEventWithTime("nominal-run", ingestion.EventTypeStart, 0, 1, time.Now().Add(-time.Minute))
+	start.NominalTime = &ingestion.NominalTime{StartTime: nominalStart, EndTime: &nominalEnd}
+
+	plain := createTestEventWithTime("plain-run", ingestion.EventTypeStart, 0, 1, time.Now().Add(-time.Minute))
+
+	results, err := store.StoreEvents(ctx, []*ingestion.RunEvent{start, plain})
+	require.NoError(t, err)
+
+	for _, r := range results {
+		require.NoError(t, r.Error)
+	}
+
+	nominalTimesOf := func(runID string) (sql.NullTime, sql.NullTime) {
+		t.Helper()
+
+		var startTime, endTime sql.NullTime
+
+		err := store.conn.QueryRowContext(ctx,
+			`SELECT nominal_start_time, nominal_end_time FROM job_runs WHERE run_id = $1`, runID,
+		).Scan(&startTime, &endTime)
+		require.NoError(t, err)
+
+		return startTime, endTime
+	}
+
+	// COMPLETE events usually omit the facet; the nominal times must survive them
+	_, _, err = store.StoreEvent(ctx, createTestEvent("nominal-run", ingestion.EventTypeComplete, 0, 1))
+	require.NoError(t, err)
+
+	startTime, endTime := nominalTimesOf(start.Run.ID)
+	require.True(t, startTime.Valid)
+	require.True(t, endTime.Valid)
+	assert.True(t, nominalStart.Equal(startTime.Time))
+	assert.True(t, nominalEnd.Equal(endTime.Time))
+
+	startTime, endTime = nominalTimesOf(plain.Run.ID)
+	assert.False(t, startTime.Valid, "Runs without a nominalTime facet keep NULL")
+	assert.False(t, endTime.Valid)
+}
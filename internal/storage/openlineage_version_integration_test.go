@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestJobRunOpenLineageVersion_MixedBatch verifies that each run in a batch records the spec
+// version of its own event, and that a later event without a recognisable version keeps it.
+func TestJobRunOpenLineageVersion_MixedBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	older := createTestEventWithTime("ol-version-1", ingestion.EventTypeStart, 0, 1, time.Now().Add(-time.Minute))
+	older.SchemaURL = "https://openlineage.io/spec/1-0-5/OpenLineage.json"
+
+	newer := createTestEventWithTime("ol-version-2", ingestion.EventTypeStart, 0, 1, time.Now().Add(-time.Minute))
+	newer.SchemaURL = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/RunEvent"
+
+	results, err := store.StoreEvents(ctx, []*ingestion.RunEvent{older, newer})
+	require.NoError(t, err)
+
+	for _, r := range results {
+		require.NoError(t, r.Error)
+	}
+
+	versionOf := func(runID string) sql.NullString {
+		t.Helper()
+
+		var version sql.NullString
+
+		err := store.conn.QueryRowContext(ctx,
+			`SELECT openlineage_version FROM job_runs WHERE run_id = $1`, runID,
+		).Scan(&version)
+		require.NoError(t, err)
+
+		return version
+	}
+
+	assert.Equal(t, "1.0.5", versionOf(older.Run.ID).String)
+	assert.Equal(t, "2.0.2", versionOf(newer.Run.ID).String)
+
+	complete := createTestEvent("ol-version-1", ingestion.EventTypeComplete, 0, 1)
+	complete.SchemaURL = "https://example.com/custom-schema.json"
+
+	_, _, err = store.StoreEvent(ctx, complete)
+	require.NoError(t, err)
+
+	version := versionOf(older.Run.ID)
+	assert.True(t, version.Valid, "an event without a version must not clear it")
+	assert.Equal(t, "1.0.5", version.String)
+}
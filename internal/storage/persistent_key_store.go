@@ -2,11 +2,13 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/correlator-io/correlator/internal/config"
 )
@@ -30,17 +32,54 @@ var (
 type PersistentKeyStore struct {
 	conn   *Connection
 	logger *slog.Logger
+
+	cacheSize int
+	cacheTTL  time.Duration
+	cache     *keyValidationCache // nil when caching is disabled
+}
+
+// PersistentKeyStoreOption configures optional PersistentKeyStore behavior.
+type PersistentKeyStoreOption func(*PersistentKeyStore)
+
+// WithKeyCacheSize sets the maximum number of validated keys kept in memory.
+// A value <= 0 disables the validation cache (every lookup pays the bcrypt cost).
+func WithKeyCacheSize(size int) PersistentKeyStoreOption {
+	return func(s *PersistentKeyStore) {
+		s.cacheSize = size
+	}
+}
+
+// WithKeyCacheTTL sets how long a successful bcrypt verification is reused.
+// A value <= 0 disables the validation cache.
+func WithKeyCacheTTL(ttl time.Duration) PersistentKeyStoreOption {
+	return func(s *PersistentKeyStore) {
+		s.cacheTTL = ttl
+	}
 }
 
 // NewPersistentKeyStore creates a production-ready PostgreSQL key store with connection pooling.
 // Performs immediate health check to ensure database connectivity.
-func NewPersistentKeyStore(conn *Connection) (*PersistentKeyStore, error) {
-	return &PersistentKeyStore{
+//
+// Successful bcrypt verifications are cached in memory (DefaultKeyCacheSize entries for
+// DefaultKeyCacheTTL) so repeated requests from the same plugin skip the bcrypt compare.
+// Use WithKeyCacheSize / WithKeyCacheTTL to tune or disable the cache.
+func NewPersistentKeyStore(conn *Connection, opts ...PersistentKeyStoreOption) (*PersistentKeyStore, error) {
+	store := &PersistentKeyStore{
 		conn: conn,
 		logger: slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			Level: config.GetEnvLogLevel("LOG_LEVEL", slog.LevelDebug),
 		})),
-	}, nil
+		cacheSize: DefaultKeyCacheSize,
+		cacheTTL:  DefaultKeyCacheTTL,
+	}
+
+	for _, opt := range opts {
+		opt(store)
+	}
+
+	store.cache = newKeyValidationCache(store.cacheSize, store.cacheTTL)
+
+	return store, nil
 }
 
 // Close performs cleanup for the PersistentKeyStore.
@@ -69,6 +108,10 @@ func (s *PersistentKeyStore) HealthCheck(ctx context.Context) error {
 // Uses key_lookup_hash (SHA256) for fast database query, then verifies with bcrypt.
 // Returns (nil, false) if key not found or invalid.
 // Note: Active/inactive status is checked by the authentication layer, not here.
+//
+// The database row is always read, so active status, expiration, and permissions are never
+// stale. Only the bcrypt compare is skipped when the same key was recently verified against
+// the same stored hash (see keyValidationCache).
 func (s *PersistentKeyStore) FindByKey(ctx context.Context, key string) (*APIKey, bool) {
 	if key == "" {
 		return nil, false
@@ -80,7 +123,7 @@ func (s *PersistentKeyStore) FindByKey(ctx context.Context, key string) (*APIKey
 	// Query by lookup_hash for O(1) performance
 	// Authentication layer will check active status and return appropriate error
 	query := `
-		SELECT id, key_hash, client_id, name, permissions, created_at, expires_at, active, updated_at
+		SELECT id, key_hash, client_id, name, permissions, created_at, expires_at, active, updated_at, metadata
 		FROM api_keys
 		WHERE key_lookup_hash = $1
 		LIMIT 1
@@ -89,6 +132,7 @@ func (s *PersistentKeyStore) FindByKey(ctx context.Context, key string) (*APIKey
 	var (
 		apiKey          APIKey
 		permissionsJSON []byte
+		metadataJSON    []byte
 		updatedAt       interface{} // Not used in APIKey struct yet
 	)
 
@@ -102,6 +146,7 @@ func (s *PersistentKeyStore) FindByKey(ctx context.Context, key string) (*APIKey
 		&apiKey.ExpiresAt,
 		&apiKey.Active,
 		&updatedAt,
+		&metadataJSON,
 	)
 	if err != nil {
 		return nil, false
@@ -114,8 +159,14 @@ func (s *PersistentKeyStore) FindByKey(ctx context.Context, key string) (*APIKey
 		return nil, false
 	}
 
+	if apiKey.Quota, err = quotaFromMetadata(metadataJSON); err != nil {
+		s.logger.Error("failed to parse key metadata", slog.String("error", err.Error()))
+
+		return nil, false
+	}
+
 	// Verify with bcrypt for security (protects against SHA256 collision attacks)
-	if !CompareAPIKeyHash(apiKey.Key, key) {
+	if !s.verifyKeyHash(lookupHash, &apiKey, key) {
 		// Hash collision (extremely unlikely) or tampered lookup_hash
 		s.logger.Warn("key lookup hash matched but bcrypt verification failed",
 			slog.String("key_id", apiKey.ID),
@@ -131,6 +182,95 @@ func (s *PersistentKeyStore) FindByKey(ctx context.Context, key string) (*APIKey
 	return &apiKey, true
 }
 
+// FindByID retrieves an API key by its ID, including inactive and expired keys.
+// Returns ErrKeyNotFound if no key has this ID. The returned key hash is masked.
+func (s *PersistentKeyStore) FindByID(ctx context.Context, keyID string) (*APIKey, error) {
+	if keyID == "" {
+		return nil, ErrKeyNotFound
+	}
+
+	return s.findOne(ctx, `
+		SELECT id, key_hash, client_id, name, permissions, created_at, expires_at, active, metadata
+		FROM api_keys
+		WHERE id = $1
+	`, keyID)
+}
+
+// FindByName retrieves a client's API key by name, including inactive and expired keys, so a
+// revoked key is still found. The newest key wins if several share the name.
+// Returns ErrKeyNotFound if the client has no key of this name. The returned key hash is masked.
+func (s *PersistentKeyStore) FindByName(ctx context.Context, clientID, name string) (*APIKey, error) {
+	if clientID == "" {
+		return nil, ErrClientIDEmpty
+	}
+
+	return s.findOne(ctx, `
+		SELECT id, key_hash, client_id, name, permissions, created_at, expires_at, active, metadata
+		FROM api_keys
+		WHERE client_id = $1 AND name = $2
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, clientID, name)
+}
+
+// findOne runs a query selecting at most one api_keys row and returns it with the hash masked,
+// or ErrKeyNotFound.
+func (s *PersistentKeyStore) findOne(ctx context.Context, query string, args ...any) (*APIKey, error) {
+	var (
+		apiKey          APIKey
+		permissionsJSON []byte
+		metadataJSON    []byte
+	)
+
+	err := s.conn.QueryRowContext(ctx, query, args...).Scan(
+		&apiKey.ID,
+		&apiKey.Key,
+		&apiKey.ClientID,
+		&apiKey.Name,
+		&permissionsJSON,
+		&apiKey.CreatedAt,
+		&apiKey.ExpiresAt,
+		&apiKey.Active,
+		&metadataJSON,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrKeyNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API key: %w", err)
+	}
+
+	if err := json.Unmarshal(permissionsJSON, &apiKey.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to parse permissions: %w", err)
+	}
+
+	if apiKey.Quota, err = quotaFromMetadata(metadataJSON); err != nil {
+		return nil, fmt.Errorf("failed to parse key metadata: %w", err)
+	}
+
+	// Mask the key hash for security
+	apiKey.Key = MaskKey(apiKey.Key)
+
+	return &apiKey, nil
+}
+
+// verifyKeyHash checks the presented key against the stored bcrypt hash in apiKey.Key,
+// consulting the validation cache first. Only successful verifications are cached.
+func (s *PersistentKeyStore) verifyKeyHash(lookupHash string, apiKey *APIKey, key string) bool {
+	if s.cache.verified(lookupHash, apiKey.Key) {
+		return true
+	}
+
+	if !CompareAPIKeyHash(apiKey.Key, key) {
+		return false
+	}
+
+	s.cache.store(lookupHash, apiKey.ID, apiKey.Key)
+
+	return true
+}
+
 // Add stores a new API key with bcrypt hashing, SHA256 lookup hash, and audit logging.
 // The plaintext key is hashed with:
 //   - bcrypt (cost=10) for security validation
@@ -163,10 +303,17 @@ func (s *PersistentKeyStore) Add(ctx context.Context, apiKey *APIKey) error {
 		return fmt.Errorf("failed to serialize permissions: %w", err)
 	}
 
+	metadataJSON, err := quotaToMetadata(apiKey.Quota)
+	if err != nil {
+		return fmt.Errorf("failed to serialize key metadata: %w", err)
+	}
+
 	// Insert API key into database with both hashes
 	query := `
-		INSERT INTO api_keys (id, key_hash, key_lookup_hash, client_id, name, permissions, created_at, expires_at, active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO api_keys (
+			id, key_hash, key_lookup_hash, client_id, name, permissions, created_at, expires_at, active, metadata
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	_, err = s.conn.ExecContext(
@@ -181,6 +328,7 @@ func (s *PersistentKeyStore) Add(ctx context.Context, apiKey *APIKey) error {
 		apiKey.CreatedAt,
 		apiKey.ExpiresAt,
 		apiKey.Active,
+		metadataJSON,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert API key: %w", err)
@@ -201,7 +349,7 @@ func (s *PersistentKeyStore) Add(ctx context.Context, apiKey *APIKey) error {
 }
 
 // Update modifies an existing API key with audit logging.
-// Updates name, permissions, active status, and expiration.
+// Updates name, permissions, active status, expiration, and quota (other metadata is kept).
 // The key hash itself cannot be updated for security reasons.
 func (s *PersistentKeyStore) Update(ctx context.Context, apiKey *APIKey) error {
 	// Validate input
@@ -219,11 +367,16 @@ func (s *PersistentKeyStore) Update(ctx context.Context, apiKey *APIKey) error {
 		return fmt.Errorf("failed to serialize permissions: %w", err)
 	}
 
+	metadataJSON, err := quotaToMetadata(apiKey.Quota)
+	if err != nil {
+		return fmt.Errorf("failed to serialize key metadata: %w", err)
+	}
+
 	// Update API key in database
 	query := `
 		UPDATE api_keys
-		SET name = $1, permissions = $2, active = $3, expires_at = $4
-		WHERE id = $5
+		SET name = $1, permissions = $2, active = $3, expires_at = $4, metadata = metadata || $5::jsonb
+		WHERE id = $6
 	`
 
 	result, err := s.conn.ExecContext(
@@ -233,6 +386,7 @@ func (s *PersistentKeyStore) Update(ctx context.Context, apiKey *APIKey) error {
 		permissionsJSON,
 		apiKey.Active,
 		apiKey.ExpiresAt,
+		metadataJSON,
 		apiKey.ID,
 	)
 	if err != nil {
@@ -249,6 +403,9 @@ func (s *PersistentKeyStore) Update(ctx context.Context, apiKey *APIKey) error {
 		return ErrKeyNotFound
 	}
 
+	// Drop cached verifications so deactivation takes effect on the next request
+	s.cache.invalidateKeyID(apiKey.ID)
+
 	// Synchronous audit logging (blocking for strict compliance)
 	if err := s.logAudit(ctx, keyUpdated, apiKey, nil); err != nil {
 		// Log error but don't fail the operation - audit logging is best-effort
@@ -262,6 +419,70 @@ func (s *PersistentKeyStore) Update(ctx context.Context, apiKey *APIKey) error {
 	return nil
 }
 
+// Upsert creates the API key if no key has its ID, and otherwise updates its name, permissions,
+// active status, expiration, and quota, so declarative key management can be re-run safely.
+// Returns true when the key was created.
+//
+// The stored hash is only replaced when apiKey.Key is set and no longer matches it (a rotated
+// key); an empty or unchanged Key keeps it, so re-applying the same config never re-hashes.
+// A rotated key that already belongs to another key ID returns ErrKeyAlreadyExists.
+func (s *PersistentKeyStore) Upsert(ctx context.Context, apiKey *APIKey) (bool, error) {
+	if apiKey == nil { // pragma: allowlist secret
+		return false, ErrKeyNil
+	}
+
+	if apiKey.ID == "" {
+		return false, ErrKeyNotFound
+	}
+
+	var storedHash string
+
+	err := s.conn.QueryRowContext(ctx, `SELECT key_hash FROM api_keys WHERE id = $1`, apiKey.ID).Scan(&storedHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err := s.Add(ctx, apiKey); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to query API key: %w", err)
+	}
+
+	if apiKey.Key == "" || CompareAPIKeyHash(storedHash, apiKey.Key) {
+		return false, s.Update(ctx, apiKey)
+	}
+
+	if err := s.rotateKeyHash(ctx, apiKey); err != nil {
+		return false, err
+	}
+
+	return false, s.Update(ctx, apiKey)
+}
+
+// rotateKeyHash replaces the stored hashes of apiKey.ID with those of apiKey.Key.
+func (s *PersistentKeyStore) rotateKeyHash(ctx context.Context, apiKey *APIKey) error {
+	if existing, found := s.FindByKey(ctx, apiKey.Key); found && existing.ID != apiKey.ID {
+		return ErrKeyAlreadyExists
+	}
+
+	keyHash, err := HashAPIKey(apiKey.Key)
+	if err != nil {
+		return fmt.Errorf("failed to hash API key: %w", err)
+	}
+
+	_, err = s.conn.ExecContext(ctx,
+		`UPDATE api_keys SET key_hash = $1, key_lookup_hash = $2 WHERE id = $3`,
+		keyHash, ComputeKeyLookupHash(apiKey.Key), apiKey.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to rotate API key: %w", err)
+	}
+
+	return nil
+}
+
 // Delete performs a soft delete on an API key by setting active=FALSE.
 // The key is not physically removed from the database for audit trail purposes.
 func (s *PersistentKeyStore) Delete(ctx context.Context, keyID string) error {
@@ -292,6 +513,8 @@ func (s *PersistentKeyStore) Delete(ctx context.Context, keyID string) error {
 		return ErrKeyNotFound
 	}
 
+	s.cache.invalidateKeyID(keyID)
+
 	// Create a minimal APIKey for audit logging
 	apiKey := &APIKey{
 		ID: keyID,
@@ -320,7 +543,7 @@ func (s *PersistentKeyStore) ListByClientID(ctx context.Context, clientID string
 
 	// Query active keys for the specified client
 	query := `
-		SELECT id, key_hash, client_id, name, permissions, created_at, expires_at, active, updated_at
+		SELECT id, key_hash, client_id, name, permissions, created_at, expires_at, active, updated_at, metadata
 		FROM api_keys
 		WHERE client_id = $1 AND active = TRUE
 		ORDER BY created_at DESC
@@ -342,6 +565,7 @@ func (s *PersistentKeyStore) ListByClientID(ctx context.Context, clientID string
 		var (
 			apiKey          APIKey
 			permissionsJSON []byte
+			metadataJSON    []byte
 			updatedAt       interface{} // Not used in APIKey struct yet
 		)
 
@@ -355,6 +579,7 @@ func (s *PersistentKeyStore) ListByClientID(ctx context.Context, clientID string
 			&apiKey.ExpiresAt,
 			&apiKey.Active,
 			&updatedAt,
+			&metadataJSON,
 		)
 		if err != nil {
 			continue
@@ -365,6 +590,10 @@ func (s *PersistentKeyStore) ListByClientID(ctx context.Context, clientID string
 			continue
 		}
 
+		if apiKey.Quota, err = quotaFromMetadata(metadataJSON); err != nil {
+			continue
+		}
+
 		// Mask the key hash for security
 		apiKey.Key = MaskKey(apiKey.Key)
 
@@ -274,6 +274,122 @@ func TestPersistentKeyStoreFindByKey(t *testing.T) {
 	}
 }
 
+func TestPersistentKeyStoreFindByID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	container, conn := setupTestDatabase(ctx, t)
+
+	defer func() {
+		_ = conn.Close()
+		_ = container.Terminate(ctx)
+	}()
+
+	store, err := NewPersistentKeyStore(conn)
+	if err != nil {
+		t.Fatalf("NewPersistentKeyStore() error = %v", err)
+	}
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	testKey := &APIKey{
+		ID:          "find-by-id-1",
+		Key:         "correlator_ak_findbyid1234567890abcdef1234567890abcdef1234567890abcdef1234", // pragma: allowlist secret
+		ClientID:    "test-client",
+		Name:        "Find By ID Key",
+		Permissions: []string{"lineage:read", "admin"},
+		CreatedAt:   time.Now(),
+		Active:      true,
+	}
+
+	if err := store.Add(ctx, testKey); err != nil {
+		t.Fatalf("failed to add test key: %v", err)
+	}
+
+	// Inactive keys are still found by ID (administration needs them)
+	if err := store.Delete(ctx, testKey.ID); err != nil {
+		t.Fatalf("failed to deactivate test key: %v", err)
+	}
+
+	apiKey, err := store.FindByID(ctx, testKey.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+
+	if apiKey.Name != testKey.Name || len(apiKey.Permissions) != 2 || apiKey.Active {
+		t.Errorf("FindByID() = %+v, want name %q, 2 permissions, inactive", apiKey, testKey.Name)
+	}
+
+	if apiKey.Key == testKey.Key || !strings.Contains(apiKey.Key, "*") {
+		t.Errorf("FindByID() key hash should be masked, got %q", apiKey.Key)
+	}
+
+	for _, id := range []string{"", "missing-id"} {
+		if _, err := store.FindByID(ctx, id); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("FindByID(%q) error = %v, want %v", id, err, ErrKeyNotFound)
+		}
+	}
+}
+
+// TestPersistentKeyStoreProvisionRevokedKey verifies that a provisioned key revoked in the
+// database is found by name and not provisioned again on the next startup.
+func TestPersistentKeyStoreProvisionRevokedKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	container, conn := setupTestDatabase(ctx, t)
+
+	defer func() {
+		_ = conn.Close()
+		_ = container.Terminate(ctx)
+	}()
+
+	store, err := NewPersistentKeyStore(conn)
+	if err != nil {
+		t.Fatalf("NewPersistentKeyStore() error = %v", err)
+	}
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	specs := []KeySpec{{PluginID: "dbt", Name: "dbt production", Permissions: []string{"lineage:write"}}}
+	deliver := func([]ProvisionedKey) error { return nil }
+
+	created, err := ProvisionKeys(ctx, store, specs, deliver)
+	if err != nil || len(created) != 1 {
+		t.Fatalf("ProvisionKeys() = %d keys, %v; want 1 key", len(created), err)
+	}
+
+	if err := store.Delete(ctx, created[0].ID); err != nil {
+		t.Fatalf("failed to revoke provisioned key: %v", err)
+	}
+
+	apiKey, err := store.FindByName(ctx, "dbt", "dbt production")
+	if err != nil || apiKey.ID != created[0].ID || apiKey.Active {
+		t.Fatalf("FindByName() = %+v, %v; want the revoked key", apiKey, err)
+	}
+
+	created, err = ProvisionKeys(ctx, store, specs, deliver)
+	if err != nil {
+		t.Fatalf("ProvisionKeys() error = %v", err)
+	}
+
+	if len(created) != 0 {
+		t.Errorf("ProvisionKeys() recreated revoked key: %+v", created)
+	}
+
+	if _, err := store.FindByName(ctx, "dbt", "dbt staging"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("FindByName() error = %v, want %v", err, ErrKeyNotFound)
+	}
+}
+
 func TestPersistentKeyStoreUpdate(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -381,6 +497,107 @@ func TestPersistentKeyStoreUpdate(t *testing.T) {
 	}
 }
 
+func TestPersistentKeyStoreUpsert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	container, conn := setupTestDatabase(ctx, t)
+
+	defer func() {
+		_ = conn.Close()
+		_ = container.Terminate(ctx)
+	}()
+
+	store, err := NewPersistentKeyStore(conn)
+	if err != nil {
+		t.Fatalf("NewPersistentKeyStore() error = %v", err)
+	}
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	key := &APIKey{
+		ID:          "upsert-test-1",
+		Key:         "correlator_ak_upserttest1234567890abcdef1234567890abcdef1234567890abcde1", // pragma: allowlist secret
+		ClientID:    "test-client",
+		Name:        "Original Name",
+		Permissions: []string{"lineage:write"},
+		CreatedAt:   time.Now(),
+		Active:      true,
+	}
+
+	t.Run("creates a missing key", func(t *testing.T) {
+		created, err := store.Upsert(ctx, key)
+		if err != nil || !created {
+			t.Fatalf("Upsert() = %v, %v; want true, nil", created, err)
+		}
+
+		if _, found := store.FindByKey(ctx, key.Key); !found {
+			t.Error("Upsert() created key cannot be found by its value")
+		}
+	})
+
+	t.Run("updates metadata and keeps the hash of an unchanged key", func(t *testing.T) {
+		var before string
+		if err := conn.QueryRowContext(ctx, `SELECT key_hash FROM api_keys WHERE id = $1`, key.ID).Scan(&before); err != nil {
+			t.Fatal(err)
+		}
+
+		updated := *key
+		updated.Name = "Updated Name"
+		updated.Permissions = []string{"lineage:read", "admin"}
+
+		created, err := store.Upsert(ctx, &updated)
+		if err != nil || created {
+			t.Fatalf("Upsert() = %v, %v; want false, nil", created, err)
+		}
+
+		found, err := store.FindByID(ctx, key.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+
+		if found.Name != "Updated Name" || !found.HasPermission("admin") {
+			t.Errorf("Upsert() did not update metadata: %+v", found)
+		}
+
+		var after string
+		if err := conn.QueryRowContext(ctx, `SELECT key_hash FROM api_keys WHERE id = $1`, key.ID).Scan(&after); err != nil {
+			t.Fatal(err)
+		}
+
+		if before != after {
+			t.Error("Upsert() re-hashed an unchanged key")
+		}
+	})
+
+	t.Run("rotates a changed key", func(t *testing.T) {
+		rotated := *key
+		rotated.Key = "correlator_ak_upsertrotated1234567890abcdef1234567890abcdef1234567890abc" // pragma: allowlist secret
+
+		if _, err := store.Upsert(ctx, &rotated); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+
+		if _, found := store.FindByKey(ctx, rotated.Key); !found {
+			t.Error("Upsert() rotated key cannot be found by its new value")
+		}
+
+		if _, found := store.FindByKey(ctx, key.Key); found {
+			t.Error("Upsert() old key value still authenticates")
+		}
+	})
+
+	t.Run("rejects a nil key", func(t *testing.T) {
+		if _, err := store.Upsert(ctx, nil); !errors.Is(err, ErrKeyNil) {
+			t.Errorf("Upsert(nil) error = %v, want ErrKeyNil", err)
+		}
+	})
+}
+
 func TestPersistentKeyStoreDelete(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+)
+
+const (
+	// poolGrowAfter is the number of consecutive intervals with connection waits before growing.
+	poolGrowAfter = 2
+	// poolShrinkAfter is the number of consecutive idle intervals before shrinking.
+	poolShrinkAfter = 10
+	// poolGrowDivisor sets the grow step to a quarter of the current limit (at least 1).
+	poolGrowDivisor = 4
+	// poolShrinkUtilization is the share of the limit that must stay unused for an interval to count as idle.
+	poolShrinkUtilization = 0.5
+)
+
+// PoolTuner adjusts a connection pool's MaxOpenConns within [min, max] from the wait counts
+// reported by sql.DBStats, for spiky load (quiet nights, heavy morning batches).
+//
+// Conservative by design:
+//   - Grows by 25% (at least 1) after 2 consecutive intervals in which callers waited for a connection
+//   - Shrinks by 1 after 10 consecutive intervals without waits and with at most half the limit open
+//   - Starts at max, so enabling it never makes the pool smaller than the static configuration
+//     until the load has been observed to be low
+//
+// MaxIdleConns is capped at the current limit (database/sql lowers it on shrink but never
+// raises it back). Not safe to Start twice.
+type PoolTuner struct {
+	conn     *Connection
+	logger   *slog.Logger
+	min      int
+	max      int
+	maxIdle  int
+	interval time.Duration
+
+	limit      int
+	lastWaits  int64
+	waitTicks  int
+	quietTicks int
+}
+
+// NewPoolTuner creates a tuner for conn from the adaptive pool settings of cfg.
+// cfg.MaxOpenConns is the upper bound and cfg.AdaptivePoolMinConns the lower bound.
+func NewPoolTuner(conn *Connection, cfg *Config, logger *slog.Logger) *PoolTuner {
+	return &PoolTuner{
+		conn:     conn,
+		logger:   logger,
+		min:      cfg.AdaptivePoolMinConns,
+		max:      cfg.MaxOpenConns,
+		maxIdle:  cfg.MaxIdleConns,
+		interval: cfg.AdaptivePoolInterval,
+		limit:    cfg.MaxOpenConns,
+	}
+}
+
+// Start runs the tuner in a background goroutine until ctx is cancelled.
+func (t *PoolTuner) Start(ctx context.Context) {
+	t.lastWaits = t.conn.Stats().WaitCount
+
+	go func() {
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				t.adjust(t.conn.Stats())
+			}
+		}
+	}()
+}
+
+// adjust applies one tuning step for the stats observed at the end of an interval.
+func (t *PoolTuner) adjust(stats sql.DBStats) {
+	waits := stats.WaitCount - t.lastWaits
+	t.lastWaits = stats.WaitCount
+
+	next := t.next(waits, stats.OpenConnections)
+	if next == t.limit {
+		return
+	}
+
+	t.logger.Info("Adjusted database connection pool",
+		slog.Int("previous_max_open_conns", t.limit),
+		slog.Int("max_open_conns", next),
+		slog.Int64("waits", waits),
+		slog.Int("open_connections", stats.OpenConnections),
+		slog.Int("in_use", stats.InUse),
+	)
+
+	t.limit = next
+	t.conn.SetMaxOpenConns(next)
+	t.conn.SetMaxIdleConns(min(t.maxIdle, next))
+}
+
+// next returns the limit for the next interval given the waits and open connections observed
+// in the last one, and updates the consecutive-interval counters.
+func (t *PoolTuner) next(waits int64, open int) int {
+	if waits > 0 {
+		t.quietTicks = 0
+		t.waitTicks++
+
+		if t.waitTicks < poolGrowAfter || t.limit >= t.max {
+			return t.limit
+		}
+
+		t.waitTicks = 0
+
+		return min(t.max, t.limit+max(1, t.limit/poolGrowDivisor))
+	}
+
+	t.waitTicks = 0
+
+	if float64(open) > float64(t.limit)*poolShrinkUtilization {
+		t.quietTicks = 0
+
+		return t.limit
+	}
+
+	t.quietTicks++
+
+	if t.quietTicks < poolShrinkAfter || t.limit <= t.min {
+		return t.limit
+	}
+
+	t.quietTicks = 0
+
+	return t.limit - 1
+}
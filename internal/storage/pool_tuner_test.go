@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"database/sql"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPoolTuner(minConns, maxConns int) *PoolTuner {
+	return &PoolTuner{
+		logger:   slog.Default(),
+		min:      minConns,
+		max:      maxConns,
+		maxIdle:  5,
+		interval: time.Second,
+		limit:    maxConns,
+	}
+}
+
+func TestPoolTuner_ShrinksSlowlyWhenIdle(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tuner := newTestPoolTuner(5, 8)
+
+	for range poolShrinkAfter - 1 {
+		assert.Equal(t, 8, tuner.next(0, 1), "must not shrink before %d idle intervals", poolShrinkAfter)
+	}
+
+	assert.Equal(t, 7, tuner.next(0, 1), "shrinks by one connection")
+
+	// A busy interval (more than half the limit open) resets the idle streak
+	tuner.limit = 7
+	for range poolShrinkAfter - 1 {
+		tuner.next(0, 1)
+	}
+
+	assert.Equal(t, 7, tuner.next(0, 6))
+	assert.Equal(t, 7, tuner.next(0, 1))
+
+	// Never below min
+	tuner.limit = 5
+	for range 2 * poolShrinkAfter {
+		assert.Equal(t, 5, tuner.next(0, 0))
+	}
+}
+
+func TestPoolTuner_GrowsAfterSustainedWaits(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tuner := newTestPoolTuner(2, 25)
+	tuner.limit = 8
+
+	assert.Equal(t, 8, tuner.next(3, 8), "a single interval with waits is not enough to grow")
+	assert.Equal(t, 10, tuner.next(1, 8), "grows by a quarter of the limit")
+
+	// A quiet interval resets the wait streak
+	tuner.limit = 10
+	assert.Equal(t, 10, tuner.next(4, 10))
+	assert.Equal(t, 10, tuner.next(0, 10))
+	assert.Equal(t, 10, tuner.next(4, 10))
+
+	// Growth is capped at max and steps by at least one
+	tuner.limit = 24
+	assert.Equal(t, 25, tuner.next(4, 24))
+
+	tuner.limit = 2
+	tuner.waitTicks = 0
+	tuner.next(1, 2)
+	assert.Equal(t, 3, tuner.next(1, 2))
+}
+
+func TestPoolTuner_AdjustAppliesLimit(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	db, err := sql.Open(postgresDriver, "postgres://localhost:1/unused?sslmode=disable")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+
+	defer func() { _ = db.Close() }()
+
+	tuner := newTestPoolTuner(1, 4)
+	tuner.conn = WrapConnection(db)
+	tuner.limit = 2
+
+	tuner.adjust(sql.DBStats{WaitCount: 5})
+	tuner.adjust(sql.DBStats{WaitCount: 9})
+
+	assert.Equal(t, 3, tuner.limit)
+	assert.Equal(t, 3, db.Stats().MaxOpenConnections)
+}
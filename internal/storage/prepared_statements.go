@@ -0,0 +1,372 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// statementPrepareTimeout bounds preparing all hot-path statements at construction or after invalidation.
+const statementPrepareTimeout = 10 * time.Second
+
+// stmtKey identifies a hot-path statement cached by LineageStore.
+type stmtKey int
+
+const (
+	stmtCheckIdempotency stmtKey = iota
+	stmtRecordIdempotency
+	stmtUpsertJobRun
+	stmtUpsertProducedDataset
+	stmtUpsertConsumedDataset
+	stmtEnsureDatasetExists
+	stmtCreateLineageEdge
+)
+
+// Hot-path queries executed at least once per stored event.
+// Kept in one place so the prepared statements and the ad-hoc fallback always run the same SQL.
+const (
+	checkIdempotencyQuery = `
+		SELECT 1 FROM lineage_event_idempotency
+		WHERE idempotency_key = $1 AND expires_at > NOW()
+		LIMIT 1
+	`
+
+	recordIdempotencyQuery = `
+		INSERT INTO lineage_event_idempotency (
+			idempotency_key,
+			created_at,
+			expires_at,
+			event_metadata
+		) VALUES ($1, NOW(), NOW() + INTERVAL '24 hours', $2)
+	`
+
+	upsertJobRunQuery = `
+		INSERT INTO job_runs (
+			run_id,
+			job_name,
+			job_namespace,
+			current_state,
+			event_type,
+			event_time,
+			state_history,
+			metadata,
+			producer_name,
+			producer_version,
+			started_at,
+			completed_at,
+			parent_run_id,
+			root_parent_run_id,
+			error_message,
+			error_stack_trace,
+			error_programming_language,
+			error_classification,
+			openlineage_version,
+			nominal_start_time,
+			nominal_end_time,
+			ingested_by_plugin,
+			processing_engine_name,
+			processing_engine_version,
+			created_at,
+			updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21,
+			NULLIF($22, ''), $23, $24, NOW(), NOW()
+		)
+		ON CONFLICT (run_id) DO UPDATE
+		SET
+			current_state = CASE
+				WHEN EXCLUDED.event_time > job_runs.event_time THEN EXCLUDED.current_state
+				ELSE job_runs.current_state
+			END,
+			event_type = CASE
+				WHEN EXCLUDED.event_time > job_runs.event_time THEN EXCLUDED.event_type
+				ELSE job_runs.event_type
+			END,
+			event_time = GREATEST(job_runs.event_time, EXCLUDED.event_time),
+			state_history = EXCLUDED.state_history,
+			metadata = CASE
+				WHEN EXCLUDED.event_time > job_runs.event_time THEN EXCLUDED.metadata
+				ELSE job_runs.metadata
+			END,
+			producer_version = COALESCE(NULLIF(EXCLUDED.producer_version, ''), job_runs.producer_version),
+			completed_at = CASE
+				WHEN EXCLUDED.completed_at IS NOT NULL AND EXCLUDED.event_time > job_runs.event_time
+					THEN EXCLUDED.completed_at
+				ELSE job_runs.completed_at
+			END,
+			parent_run_id = COALESCE(EXCLUDED.parent_run_id, job_runs.parent_run_id),
+			root_parent_run_id = COALESCE(EXCLUDED.root_parent_run_id, job_runs.root_parent_run_id),
+			-- Error columns move as a group: a FAIL/ABORT event with an error facet replaces all four,
+			-- any other event leaves them untouched.
+			error_message = COALESCE(EXCLUDED.error_message, job_runs.error_message),
+			error_stack_trace = CASE
+				WHEN EXCLUDED.error_message IS NOT NULL THEN EXCLUDED.error_stack_trace
+				ELSE job_runs.error_stack_trace
+			END,
+			error_programming_language = CASE
+				WHEN EXCLUDED.error_message IS NOT NULL THEN EXCLUDED.error_programming_language
+				ELSE job_runs.error_programming_language
+			END,
+			error_classification = CASE
+				WHEN EXCLUDED.error_message IS NOT NULL THEN EXCLUDED.error_classification
+				ELSE job_runs.error_classification
+			END,
+			-- Follows the latest event like metadata, but never clears a known version.
+			openlineage_version = CASE
+				WHEN EXCLUDED.event_time > job_runs.event_time
+					THEN COALESCE(EXCLUDED.openlineage_version, job_runs.openlineage_version)
+				ELSE COALESCE(job_runs.openlineage_version, EXCLUDED.openlineage_version)
+			END,
+			-- Nominal times move as a pair: an event with a nominalTime facet replaces both,
+			-- events without one (usually all but START) leave them untouched.
+			nominal_start_time = COALESCE(EXCLUDED.nominal_start_time, job_runs.nominal_start_time),
+			nominal_end_time = CASE
+				WHEN EXCLUDED.nominal_start_time IS NOT NULL THEN EXCLUDED.nominal_end_time
+				ELSE job_runs.nominal_end_time
+			END,
+			-- Latest sender wins; events from transports without plugins (Kafka) keep the known one.
+			ingested_by_plugin = COALESCE(EXCLUDED.ingested_by_plugin, job_runs.ingested_by_plugin),
+			-- Engine name and version move as a pair: an event with a processing_engine facet
+			-- replaces both, events without one leave them untouched.
+			processing_engine_name = COALESCE(EXCLUDED.processing_engine_name, job_runs.processing_engine_name),
+			processing_engine_version = CASE
+				WHEN EXCLUDED.processing_engine_name IS NOT NULL THEN EXCLUDED.processing_engine_version
+				ELSE job_runs.processing_engine_version
+			END,
+			updated_at = NOW()
+	`
+
+	upsertProducedDatasetQuery = `
+		INSERT INTO datasets (
+			dataset_urn, name, namespace, facets, last_producing_run_id,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (dataset_urn) DO UPDATE SET
+			facets = datasets.facets || EXCLUDED.facets,
+			last_producing_run_id = EXCLUDED.last_producing_run_id,
+			updated_at = NOW()
+	`
+
+	upsertConsumedDatasetQuery = `
+		INSERT INTO datasets (
+			dataset_urn, name, namespace, facets,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, NOW(), NOW())
+		ON CONFLICT (dataset_urn) DO UPDATE SET
+			facets = datasets.facets || EXCLUDED.facets,
+			updated_at = NOW()
+	`
+
+	ensureDatasetExistsQuery = `
+		INSERT INTO datasets (
+			dataset_urn, name, namespace, facets,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, '{}', NOW(), NOW())
+		ON CONFLICT (dataset_urn) DO NOTHING
+	`
+
+	createLineageEdgeQuery = `
+		INSERT INTO lineage_edges (
+			run_id,
+			dataset_urn,
+			edge_type,
+			created_at
+		) VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (run_id, dataset_urn, edge_type) DO NOTHING
+	`
+)
+
+// hotPathQueries maps each cached statement to its SQL.
+var hotPathQueries = map[stmtKey]string{ //nolint:gochecknoglobals
+	stmtCheckIdempotency:      checkIdempotencyQuery,
+	stmtRecordIdempotency:     recordIdempotencyQuery,
+	stmtUpsertJobRun:          upsertJobRunQuery,
+	stmtUpsertProducedDataset: upsertProducedDatasetQuery,
+	stmtUpsertConsumedDataset: upsertConsumedDatasetQuery,
+	stmtEnsureDatasetExists:   ensureDatasetExistsQuery,
+	stmtCreateLineageEdge:     createLineageEdgeQuery,
+}
+
+// statementCache holds prepared statements for LineageStore's hot insert paths.
+//
+// Statements are prepared on the pool (database/sql re-prepares them lazily per connection)
+// and bound to a transaction with tx.StmtContext, so PostgreSQL parses each query once per
+// connection instead of once per call.
+//
+// Properties:
+//   - Best-effort: a statement that fails to prepare is simply absent and runs ad-hoc
+//   - Optional: a disabled cache (WithPreparedStatements(false)) runs every query ad-hoc
+//   - Self-healing: invalidate drops and re-prepares all statements after a stale-statement error
+type statementCache struct {
+	conn    *Connection
+	logger  *slog.Logger
+	enabled bool
+
+	mu    sync.RWMutex
+	stmts map[stmtKey]*sql.Stmt
+}
+
+// newStatementCache creates a cache and, when enabled, prepares every hot-path statement.
+func newStatementCache(conn *Connection, logger *slog.Logger, enabled bool) *statementCache {
+	c := &statementCache{
+		conn:    conn,
+		logger:  logger,
+		enabled: enabled,
+	}
+
+	if enabled {
+		c.stmts = c.prepareAll()
+	}
+
+	return c
+}
+
+// prepareAll prepares every hot-path statement, skipping (and logging) any that fail.
+func (c *statementCache) prepareAll() map[stmtKey]*sql.Stmt {
+	ctx, cancel := context.WithTimeout(context.Background(), statementPrepareTimeout)
+	defer cancel()
+
+	stmts := make(map[stmtKey]*sql.Stmt, len(hotPathQueries))
+
+	for key, query := range hotPathQueries {
+		stmt, err := c.conn.PrepareContext(ctx, query)
+		if err != nil {
+			c.logger.Warn("Failed to prepare statement, falling back to ad-hoc query",
+				slog.Int("statement", int(key)),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		stmts[key] = stmt
+	}
+
+	return stmts
+}
+
+// get returns the prepared statement for key, or nil when it must run ad-hoc.
+func (c *statementCache) get(key stmtKey) *sql.Stmt {
+	if c == nil || !c.enabled {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stmts[key]
+}
+
+// execTx executes a hot-path statement inside tx, using the prepared statement when available.
+func (c *statementCache) execTx(ctx context.Context, tx *sql.Tx, key stmtKey, args ...any) (sql.Result, error) {
+	stmt := c.get(key)
+	if stmt == nil {
+		return tx.ExecContext(ctx, hotPathQueries[key], args...)
+	}
+
+	txStmt := tx.StmtContext(ctx, stmt)
+	defer func() { _ = txStmt.Close() }()
+
+	return txStmt.ExecContext(ctx, args...)
+}
+
+// queryRow runs a single-row hot-path query outside a transaction. A stale prepared statement
+// is invalidated and the query retried ad-hoc, since no transaction was aborted.
+func (c *statementCache) queryRow(ctx context.Context, key stmtKey, dest []any, args ...any) error {
+	stmt := c.get(key)
+	if stmt == nil {
+		return c.adHocQueryRow(ctx, key, dest, args...)
+	}
+
+	err := stmt.QueryRowContext(ctx, args...).Scan(dest...)
+	if c.invalidate(err) {
+		return c.adHocQueryRow(ctx, key, dest, args...)
+	}
+
+	return err
+}
+
+func (c *statementCache) adHocQueryRow(ctx context.Context, key stmtKey, dest []any, args ...any) error {
+	if c == nil || c.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	return c.conn.QueryRowContext(ctx, hotPathQueries[key], args...).Scan(dest...)
+}
+
+// invalidate re-prepares all statements if err shows a cached statement went stale
+// (e.g., the schema was migrated while the server was running). Returns true if it did,
+// in which case the caller should retry the failed operation.
+func (c *statementCache) invalidate(err error) bool {
+	if c == nil || !c.enabled || !isStaleStatementError(err) {
+		return false
+	}
+
+	c.logger.Warn("Prepared statement is stale, re-preparing hot-path statements",
+		slog.String("error", err.Error()),
+	)
+
+	fresh := c.prepareAll()
+
+	c.mu.Lock()
+	stale := c.stmts
+	c.stmts = fresh
+	c.mu.Unlock()
+
+	// Closing is safe while transactions still use a statement: database/sql defers the
+	// driver-level close until they finish, and binds a closed statement by re-preparing it.
+	for _, stmt := range stale {
+		_ = stmt.Close()
+	}
+
+	return true
+}
+
+// close closes all prepared statements.
+func (c *statementCache) close() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+
+	for _, stmt := range c.stmts {
+		if err := stmt.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	c.stmts = nil
+
+	return errors.Join(errs...)
+}
+
+// isStaleStatementError reports whether err means a prepared statement can no longer be used as-is.
+//
+// PostgreSQL error codes:
+//   - 0A000 "cached plan must not change result type": a table used by the statement changed shape
+//   - 26000 invalid_sql_statement_name: the server-side statement is gone (e.g., DISCARD ALL by a pooler)
+func isStaleStatementError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	switch pqErr.Code {
+	case "26000":
+		return true
+	case "0A000":
+		return strings.Contains(pqErr.Message, "cached plan must not change result type")
+	default:
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestPreparedStatements_RecoverFromStaleStatements verifies that events are still stored after
+// the server drops every prepared statement, as a connection pooler running DISCARD ALL would.
+func TestPreparedStatements_RecoverFromStaleStatements(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	// A single connection guarantees DEALLOCATE runs on the connection the statements live on
+	store.conn.SetMaxOpenConns(1)
+
+	for key := range hotPathQueries {
+		require.NotNil(t, store.stmts.get(key), "statement %d was not prepared", key)
+	}
+
+	_, _, err := store.StoreEvent(ctx, createTestEvent("prepared-run-1", ingestion.EventTypeStart, 1, 1))
+	require.NoError(t, err)
+
+	_, err = store.conn.ExecContext(ctx, "DEALLOCATE ALL")
+	require.NoError(t, err)
+
+	stored, _, err := store.StoreEvent(ctx, createTestEvent("prepared-run-2", ingestion.EventTypeStart, 1, 1))
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	var count int
+
+	err = store.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM job_runs WHERE run_id LIKE 'prepared-run-%'`).
+		Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+// TestPreparedStatements_Disabled verifies that a store without prepared statements runs ad-hoc.
+func TestPreparedStatements_Disabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t, WithPreparedStatements(false))
+
+	assert.Nil(t, store.stmts.get(stmtUpsertJobRun))
+
+	event := createTestEvent("adhoc-run-1", ingestion.EventTypeStart, 1, 1)
+
+	stored, _, err := store.StoreEvent(ctx, event)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	_, duplicate, err := store.StoreEvent(ctx, event)
+	require.NoError(t, err)
+	assert.True(t, duplicate)
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStaleStatementError(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "prepared statement does not exist",
+			err:  &pq.Error{Code: "26000", Message: `prepared statement "1" does not exist`},
+			want: true,
+		},
+		{
+			name: "cached plan changed result type (wrapped)",
+			err: fmt.Errorf("failed to upsert job_run: %w",
+				&pq.Error{Code: "0A000", Message: "cached plan must not change result type"}),
+			want: true,
+		},
+		{
+			name: "other feature not supported",
+			err:  &pq.Error{Code: "0A000", Message: "FOR UPDATE is not allowed with aggregate functions"},
+			want: false,
+		},
+		{
+			name: "unique violation",
+			err:  &pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"},
+			want: false,
+		},
+		{
+			name: "non-postgres error",
+			err:  errors.New("connection refused"),
+			want: false,
+		},
+		{
+			name: "nil",
+			err:  nil,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isStaleStatementError(tt.err))
+		})
+	}
+}
+
+func TestStatementCache_DisabledOrNil(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	stale := &pq.Error{Code: "26000"}
+
+	var nilCache *statementCache
+
+	assert.Nil(t, nilCache.get(stmtUpsertJobRun))
+	assert.False(t, nilCache.invalidate(stale))
+	assert.NoError(t, nilCache.close())
+
+	disabled := newStatementCache(nil, nil, false)
+
+	assert.Nil(t, disabled.get(stmtUpsertJobRun))
+	assert.False(t, disabled.invalidate(stale), "a disabled cache must never re-prepare")
+	assert.NoError(t, disabled.close())
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestJobRunProcessingEngine verifies that the processing engine of a run is stored from the
+// event that carries the processing_engine facet, kept by later events without it, and left
+// NULL for runs without it.
+func TestJobRunProcessingEngine(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	start := createTestEventWithTime("engine-run", ingestion.EventTypeStart, 0, 1, time.Now().Add(-time.Minute))
+	start.ProcessingEngine = &ingestion.ProcessingEngine{Name: "spark", Version: "3.5.0"}
+
+	plain := createTestEventWithTime("plain-engine-run", ingestion.EventTypeStart, 0, 1, time.Now().Add(-time.Minute))
+
+	results, err := store.StoreEvents(ctx, []*ingestion.RunEvent{start, plain})
+	require.NoError(t, err)
+
+	for _, r := range results {
+		require.NoError(t, r.Error)
+	}
+
+	engineOf := func(runID string) (sql.NullString, sql.NullString) {
+		t.Helper()
+
+		var name, version sql.NullString
+
+		err := store.conn.QueryRowContext(ctx,
+			`SELECT processing_engine_name, processing_engine_version FROM job_runs WHERE run_id = $1`, runID,
+		).Scan(&name, &version)
+		require.NoError(t, err)
+
+		return name, version
+	}
+
+	// A later event without the facet must not clear the engine
+	_, _, err = store.StoreEvent(ctx, createTestEvent("engine-run", ingestion.EventTypeComplete, 0, 1))
+	require.NoError(t, err)
+
+	name, version := engineOf(start.Run.ID)
+	assert.Equal(t, sql.NullString{String: "spark", Valid: true}, name)
+	assert.Equal(t, sql.NullString{String: "3.5.0", Valid: true}, version)
+
+	name, version = engineOf(plain.Run.ID)
+	assert.False(t, name.Valid, "Runs without a processing_engine facet keep NULL")
+	assert.False(t, version.Valid)
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/lib/pq"
+)
+
+// producerBackfillBatchSize is the number of job runs scanned (and updated in one transaction)
+// per BackfillProducerNames batch.
+const producerBackfillBatchSize = 1000
+
+// ProducerBackfillResult counts the rows examined and fixed by BackfillProducerNames.
+type ProducerBackfillResult struct {
+	JobRunsScanned     int
+	JobRunsUpdated     int
+	TestResultsUpdated int
+}
+
+// BackfillProducerNames re-derives job_runs.producer_name from the producer URL stored in
+// job_runs.metadata, using the current extraction logic (see extractProducerName), and fixes
+// rows where it is missing or differs. Test results of a fixed run take the run's producer name.
+//
+// Job runs are scanned in run_id order, producerBackfillBatchSize at a time, each batch updated
+// in its own transaction, so the backfill can run against a live database and be interrupted
+// (ctx) and restarted at any point. Runs without a stored producer URL are left alone.
+//
+// The correlation views are refreshed at the end when anything changed.
+func (s *LineageStore) BackfillProducerNames(ctx context.Context) (ProducerBackfillResult, error) {
+	var result ProducerBackfillResult
+
+	if s.conn == nil {
+		return result, ErrNoDatabaseConnection
+	}
+
+	afterRunID := "00000000-0000-0000-0000-000000000000"
+
+	for {
+		scanned, lastRunID, err := s.backfillProducerNamesBatch(ctx, afterRunID, &result)
+		if err != nil {
+			return result, err
+		}
+
+		result.JobRunsScanned += scanned
+
+		if scanned < producerBackfillBatchSize {
+			break
+		}
+
+		afterRunID = lastRunID
+	}
+
+	s.logger.Info("Producer names backfilled",
+		slog.Int("job_runs_scanned", result.JobRunsScanned),
+		slog.Int("job_runs_updated", result.JobRunsUpdated),
+		slog.Int("test_results_updated", result.TestResultsUpdated))
+
+	if result.JobRunsUpdated > 0 || result.TestResultsUpdated > 0 {
+		if err := s.refreshViews(ctx); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// backfillProducerNamesBatch fixes the producer names of the next batch of job runs after
+// afterRunID. Returns the number of runs scanned and the last run ID.
+func (s *LineageStore) backfillProducerNamesBatch(
+	ctx context.Context,
+	afterRunID string,
+	result *ProducerBackfillResult,
+) (int, string, error) {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to begin producer backfill transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	// FOR UPDATE: a concurrent ingest of the same run waits instead of being overwritten
+	rows, err := tx.QueryContext(ctx, `
+		SELECT run_id::text, metadata->>'producer', producer_name
+		FROM job_runs
+		WHERE run_id > $1
+		ORDER BY run_id
+		LIMIT $2
+		FOR UPDATE
+	`, afterRunID, producerBackfillBatchSize)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to scan job runs: %w", err)
+	}
+
+	var (
+		scanned   int
+		lastRunID string
+		runIDs    []string
+		names     []string
+	)
+
+	for rows.Next() {
+		var (
+			runID       string
+			producerURL sql.NullString
+			currentName string
+		)
+
+		if err := rows.Scan(&runID, &producerURL, &currentName); err != nil {
+			_ = rows.Close()
+
+			return 0, "", fmt.Errorf("failed to scan job run: %w", err)
+		}
+
+		scanned++
+		lastRunID = runID
+
+		if !producerURL.Valid {
+			continue
+		}
+
+		if name := extractProducerName(producerURL.String); name != currentName {
+			runIDs = append(runIDs, runID)
+			names = append(names, name)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, "", fmt.Errorf("failed to scan job runs: %w", err)
+	}
+
+	if len(runIDs) == 0 {
+		return scanned, lastRunID, nil
+	}
+
+	jobRuns, err := tx.ExecContext(ctx, `
+		UPDATE job_runs jr
+		SET producer_name = u.producer_name
+		FROM unnest($1::uuid[], $2::text[]) AS u(run_id, producer_name)
+		WHERE jr.run_id = u.run_id
+	`, pq.Array(runIDs), pq.Array(names))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to update job run producer names: %w", err)
+	}
+
+	testResults, err := tx.ExecContext(ctx, `
+		UPDATE test_results tr
+		SET producer_name = u.producer_name
+		FROM unnest($1::uuid[], $2::text[]) AS u(run_id, producer_name)
+		WHERE tr.run_id = u.run_id AND tr.producer_name <> u.producer_name
+	`, pq.Array(runIDs), pq.Array(names))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to update test result producer names: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, "", fmt.Errorf("failed to commit producer backfill: %w", err)
+	}
+
+	n, _ := jobRuns.RowsAffected()
+	result.JobRunsUpdated += int(n)
+
+	n, _ = testResults.RowsAffected()
+	result.TestResultsUpdated += int(n)
+
+	return scanned, lastRunID, nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestBackfillProducerNames verifies that stale and blank producer names are re-derived from
+// the stored producer URL, that correct rows are left alone, and that a second run is a no-op.
+func TestBackfillProducerNames(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	stale := createTestEvent("producer-backfill-stale", ingestion.EventTypeStart, 1, 1)
+	blank := createTestEvent("producer-backfill-blank", ingestion.EventTypeStart, 1, 1)
+	current := createTestEvent("producer-backfill-current", ingestion.EventTypeStart, 1, 1)
+
+	for _, event := range []*ingestion.RunEvent{stale, blank, current} {
+		_, _, err := store.StoreEvent(ctx, event)
+		require.NoError(t, err)
+	}
+
+	want := extractProducerName(current.Producer)
+
+	// Simulate rows written by an older extraction
+	_, err := store.conn.ExecContext(ctx, "UPDATE job_runs SET producer_name = 'github.com' WHERE run_id = $1",
+		stale.Run.ID)
+	require.NoError(t, err)
+
+	_, err = store.conn.ExecContext(ctx, "UPDATE job_runs SET producer_name = '' WHERE run_id = $1", blank.Run.ID)
+	require.NoError(t, err)
+
+	result, err := store.BackfillProducerNames(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.JobRunsScanned)
+	assert.Equal(t, 2, result.JobRunsUpdated)
+
+	for _, event := range []*ingestion.RunEvent{stale, blank, current} {
+		assert.Equal(t, 1, countRows(ctx, t, store,
+			"SELECT COUNT(*) FROM job_runs WHERE run_id = $1 AND producer_name = $2", event.Run.ID, want),
+			"producer_name of %s", event.Run.ID)
+	}
+
+	result, err = store.BackfillProducerNames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ProducerBackfillResult{JobRunsScanned: 3}, result, "Second run has nothing to fix")
+}
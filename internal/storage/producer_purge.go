@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// producerPurgeBatchSize is the number of job runs deleted per PurgeProducer transaction, so
+// offboarding a large integration never holds locks on its whole history at once.
+const producerPurgeBatchSize = 1000
+
+// ErrMissingProducerName is returned when PurgeProducer is called with an empty producer name.
+var ErrMissingProducerName = errors.New("producer purge requires a producer name")
+
+// PurgeProducer deletes every job run reported by a producer, with their lineage edges and test
+// results (and, via cascade, incident resolutions), and the datasets that are left without any
+// lineage edge or test result. Datasets shared with runs of other producers are kept.
+//
+// The producer is matched the way QueryProducerSummaries names it: the engine of the
+// processing_engine run facet when the run carried one, the name derived from the producer URL
+// otherwise - so a name listed by GET /api/v1/producers purges exactly the runs counted there.
+//
+// Runs are deleted in transactions of producerPurgeBatchSize runs, oldest first, until none is
+// left. Returns the number of job runs deleted; on error, the runs of batches committed before
+// it stay deleted and are counted.
+//
+// Intended for offboarding an integration; a targeted alternative to dropping the database.
+func (s *LineageStore) PurgeProducer(ctx context.Context, producerName string) (int, error) {
+	producerName = strings.TrimSpace(producerName)
+	if producerName == "" {
+		return 0, ErrMissingProducerName
+	}
+
+	if s.conn == nil {
+		return 0, ErrNoDatabaseConnection
+	}
+
+	var total JobRunDeleteResult
+
+	defer func() {
+		if total.JobRuns == 0 {
+			return
+		}
+
+		s.logger.Warn("Purged producer",
+			slog.String("producer", producerName),
+			slog.Int64("job_runs", total.JobRuns),
+			slog.Int64("lineage_edges", total.LineageEdges),
+			slog.Int64("test_results", total.TestResults),
+			slog.Int64("datasets", total.Datasets),
+		)
+
+		s.notifyDataChanged()
+	}()
+
+	for {
+		result, err := s.purgeProducerBatch(ctx, producerName)
+		if err != nil {
+			return int(total.JobRuns), err
+		}
+
+		if result.JobRuns == 0 {
+			return int(total.JobRuns), nil
+		}
+
+		total.JobRuns += result.JobRuns
+		total.LineageEdges += result.LineageEdges
+		total.TestResults += result.TestResults
+		total.Datasets += result.Datasets
+	}
+}
+
+// purgeProducerBatch deletes up to producerPurgeBatchSize job runs of a producer in one transaction.
+func (s *LineageStore) purgeProducerBatch(ctx context.Context, producerName string) (JobRunDeleteResult, error) {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return JobRunDeleteResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	runIDs, err := queryStrings(ctx, tx, `
+		SELECT run_id::text FROM job_runs
+		WHERE COALESCE(processing_engine_name, producer_name) = $1
+		ORDER BY created_at
+		LIMIT $2
+		FOR UPDATE
+	`, producerName, producerPurgeBatchSize)
+	if err != nil {
+		return JobRunDeleteResult{}, fmt.Errorf("failed to select producer job runs: %w", err)
+	}
+
+	result, err := deleteRunsTx(ctx, tx, runIDs)
+	if err != nil {
+		return JobRunDeleteResult{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return JobRunDeleteResult{}, fmt.Errorf("failed to commit producer purge: %w", err)
+	}
+
+	return result, nil
+}
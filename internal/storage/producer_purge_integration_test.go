@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestPurgeProducer verifies that all runs of a producer are deleted with their edges, and that
+// datasets shared with another producer's runs are kept while exclusive ones are removed.
+func TestPurgeProducer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	legacy := &ingestion.ProcessingEngine{Name: "legacy-plugin", Version: "0.9.0"}
+
+	// Legacy run: reads an exclusive source, writes a table that another producer also reads
+	extract := createTestEvent("purge-extract", ingestion.EventTypeComplete, 1, 1)
+	extract.ProcessingEngine = legacy
+
+	// Legacy run: writes an exclusive table
+	load := createTestEvent("purge-load", ingestion.EventTypeComplete, 0, 1)
+	load.ProcessingEngine = legacy
+
+	// Other producer: reads the shared table
+	report := createTestEvent("purge-report", ingestion.EventTypeComplete, 0, 1)
+	report.Inputs = []ingestion.Dataset{extract.Outputs[0]}
+
+	for _, event := range []*ingestion.RunEvent{extract, load, report} {
+		_, _, err := store.StoreEvent(ctx, event)
+		require.NoError(t, err)
+	}
+
+	sharedURN := extract.Outputs[0].URN()
+	exclusiveURNs := []string{extract.Inputs[0].URN(), load.Outputs[0].URN()}
+
+	t.Run("RequiresProducerName", func(t *testing.T) {
+		_, err := store.PurgeProducer(ctx, "  ")
+		require.ErrorIs(t, err, ErrMissingProducerName)
+	})
+
+	t.Run("UnknownProducerDeletesNothing", func(t *testing.T) {
+		deleted, err := store.PurgeProducer(ctx, "never-seen")
+		require.NoError(t, err)
+		assert.Equal(t, 0, deleted)
+		assert.Equal(t, 3, countRows(ctx, t, store, `SELECT COUNT(*) FROM job_runs`))
+	})
+
+	t.Run("DeletesProducerRunsAndOrphanedDatasets", func(t *testing.T) {
+		deleted, err := store.PurgeProducer(ctx, "legacy-plugin")
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		assert.Equal(t, 0, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM job_runs WHERE processing_engine_name = 'legacy-plugin'`))
+		assert.Equal(t, 1, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM job_runs WHERE run_id = $1`, report.Run.ID))
+		assert.Equal(t, 2, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM lineage_edges WHERE run_id = $1`, report.Run.ID),
+			"edges of the other producer's run must be kept")
+
+		assert.Equal(t, 1, countRows(ctx, t, store,
+			`SELECT COUNT(*) FROM datasets WHERE dataset_urn = $1`, sharedURN),
+			"dataset read by another producer must be kept")
+
+		for _, urn := range exclusiveURNs {
+			assert.Equal(t, 0, countRows(ctx, t, store,
+				`SELECT COUNT(*) FROM datasets WHERE dataset_urn = $1`, urn),
+				"dataset only touched by the purged producer must be removed: %s", urn)
+		}
+
+		deleted, err = store.PurgeProducer(ctx, "legacy-plugin")
+		require.NoError(t, err)
+		assert.Equal(t, 0, deleted, "purging again finds nothing")
+	})
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ProducerSummary is the recent activity of one producer (e.g. "dbt", "airflow"), aggregated
+// over the job runs it reported since a point in time.
+type ProducerSummary struct {
+	// ProducerName is the engine of the processing_engine run facet (job_runs.processing_engine_name)
+	// when the run carried one, the name derived from the producer URL otherwise.
+	ProducerName string
+	// LastEventTime is the time of the latest event of any of its runs.
+	LastEventTime time.Time
+	// JobRuns is the number of runs (job_runs keeps one row per run, not per event).
+	JobRuns int64
+	// States counts the runs by current state (START, RUNNING, COMPLETE, FAIL, ABORT, OTHER).
+	States map[string]int64
+}
+
+// QueryProducerSummaries summarizes, per producer, the job runs whose latest event is at or
+// after since, most recently active producer first. A producer without such runs is absent,
+// which is how an integration that stopped emitting shows up.
+func (s *LineageStore) QueryProducerSummaries(ctx context.Context, since time.Time) ([]ProducerSummary, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT COALESCE(processing_engine_name, producer_name) AS producer, current_state, MAX(event_time), COUNT(*)
+		FROM job_runs
+		WHERE event_time >= $1
+		GROUP BY producer, current_state`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query producer summaries: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	byProducer := make(map[string]*ProducerSummary)
+
+	for rows.Next() {
+		var (
+			producer, state string
+			lastEventTime   time.Time
+			runs            int64
+		)
+
+		if err := rows.Scan(&producer, &state, &lastEventTime, &runs); err != nil {
+			return nil, fmt.Errorf("failed to scan producer summary: %w", err)
+		}
+
+		summary, ok := byProducer[producer]
+		if !ok {
+			summary = &ProducerSummary{ProducerName: producer, States: make(map[string]int64)}
+			byProducer[producer] = summary
+		}
+
+		if lastEventTime.After(summary.LastEventTime) {
+			summary.LastEventTime = lastEventTime
+		}
+
+		summary.JobRuns += runs
+		summary.States[state] = runs
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate producer summaries: %w", err)
+	}
+
+	summaries := make([]ProducerSummary, 0, len(byProducer))
+	for _, summary := range byProducer {
+		summaries = append(summaries, *summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if !summaries[i].LastEventTime.Equal(summaries[j].LastEventTime) {
+			return summaries[i].LastEventTime.After(summaries[j].LastEventTime)
+		}
+
+		return summaries[i].ProducerName < summaries[j].ProducerName
+	})
+
+	return summaries, nil
+}
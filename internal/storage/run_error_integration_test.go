@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestJobRunErrors_PersistsFailureDetail verifies that the parsed error facet of a FAIL event is
+// stored on job_runs, including a multi-line stack trace with a NUL byte that PostgreSQL would
+// reject in JSONB if the raw facet were stored unchanged.
+func TestJobRunErrors_PersistsFailureDetail(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	start := createTestEventWithTime("run-error-1", ingestion.EventTypeStart, 0, 1, time.Now().Add(-time.Minute))
+	_, _, err := store.StoreEvent(ctx, start)
+	require.NoError(t, err)
+
+	fail := createTestEvent("run-error-1", ingestion.EventTypeFail, 0, 1)
+	fail.Run.Facets["errorMessage"] = map[string]interface{}{
+		"message":             "relation \"orders\" does not exist",
+		"programmingLanguage": "python",
+		"stackTrace":          "Traceback (most recent call last):\n  File \"run.py\", line 1\x00\nProgrammingError",
+	}
+	fail.Run.Facets["errorClassification"] = map[string]interface{}{"classification": "schema"}
+	fail.Error = ingestion.ParseRunError(fail.EventType, fail.Run.Facets)
+
+	_, _, err = store.StoreEvent(ctx, fail)
+	require.NoError(t, err)
+
+	var message, stackTrace, language, classification sql.NullString
+
+	var metadata []byte
+
+	err = store.conn.QueryRowContext(ctx, `
+		SELECT error_message, error_stack_trace, error_programming_language, error_classification, metadata
+		FROM job_runs WHERE run_id = $1
+	`, fail.Run.ID).Scan(&message, &stackTrace, &language, &classification, &metadata)
+	require.NoError(t, err)
+
+	assert.Equal(t, "relation \"orders\" does not exist", message.String)
+	assert.Equal(t, "Traceback (most recent call last):\n  File \"run.py\", line 1\nProgrammingError", stackTrace.String)
+	assert.Equal(t, "python", language.String)
+	assert.Equal(t, "schema", classification.String)
+
+	var parsed struct {
+		RunFacets map[string]map[string]interface{} `json:"run_facets"` //nolint:tagliatelle
+	}
+
+	require.NoError(t, json.Unmarshal(metadata, &parsed))
+	assert.False(t, strings.Contains(parsed.RunFacets["errorMessage"]["stackTrace"].(string), "\x00"))
+}
+
+// TestJobRunErrors_NonFailureEventsLeaveErrorUntouched verifies that error columns are only
+// written by FAIL/ABORT events that carry an error facet.
+func TestJobRunErrors_NonFailureEventsLeaveErrorUntouched(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	complete := createTestEvent("run-error-2", ingestion.EventTypeComplete, 0, 1)
+	complete.Run.Facets["errorMessage"] = map[string]interface{}{"message": "ignored on COMPLETE"}
+	complete.Error = ingestion.ParseRunError(complete.EventType, complete.Run.Facets)
+
+	_, _, err := store.StoreEvent(ctx, complete)
+	require.NoError(t, err)
+
+	var message sql.NullString
+
+	err = store.conn.QueryRowContext(ctx,
+		`SELECT error_message FROM job_runs WHERE run_id = $1`, complete.Run.ID,
+	).Scan(&message)
+	require.NoError(t, err)
+
+	assert.False(t, message.Valid, "COMPLETE events must not set error_message")
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// searchPathParam is the PostgreSQL runtime parameter selecting the schema of unqualified names.
+// lib/pq sends connection string parameters it does not know as runtime parameters.
+const searchPathParam = "search_path"
+
+// extensionSchema holds the PostgreSQL extensions (uuid-ossp, pg_trgm). Extensions exist once per
+// database, not per schema, so every DATABASE_SCHEMA search_path includes it after the schema.
+const extensionSchema = "public"
+
+var (
+	// ErrInvalidSchema is returned when DATABASE_SCHEMA is not a plain PostgreSQL identifier.
+	ErrInvalidSchema = errors.New("DATABASE_SCHEMA must be a schema name (letters, digits, and underscores)")
+
+	// schemaIdentifierRegex matches an unquoted PostgreSQL identifier.
+	schemaIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// validateSchema checks that schema is empty (server default search_path) or a plain identifier,
+// so it can be used unquoted in search_path.
+func validateSchema(schema string) error {
+	if schema == "" || schemaIdentifierRegex.MatchString(schema) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: got %q", ErrInvalidSchema, schema)
+}
+
+// dsnWithSearchPath returns dsn with search_path set to "schema,public", so every connection
+// opened from it (pooled and LISTEN connections alike) resolves unqualified table names in schema
+// and extension types and functions in extensionSchema. Accepts both URL (postgres://...) and
+// key=value connection strings; an existing search_path is replaced. Returns dsn unchanged when
+// schema is empty.
+func dsnWithSearchPath(dsn, schema string) (string, error) {
+	if schema == "" {
+		return dsn, nil
+	}
+
+	searchPath := schema + "," + extensionSchema
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("invalid database URL: %w", err)
+		}
+
+		query := u.Query()
+		query.Set(searchPathParam, searchPath)
+		u.RawQuery = query.Encode()
+
+		return u.String(), nil
+	}
+
+	// key=value: lib/pq keeps the last occurrence of a key
+	return dsn + " " + searchPathParam + "=" + searchPath, nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+
+	"github.com/correlator-io/correlator/internal/config"
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestSchemaIsolation verifies that two stores configured with different schemas in one
+// database are migrated into, and read and write, only their own schema.
+func TestSchemaIsolation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := config.SetupTestDatabase(ctx, t)
+
+	t.Cleanup(func() {
+		_ = testDB.Connection.Close()
+		_ = testcontainers.TerminateContainer(testDB.Container)
+	})
+
+	newSchemaStore := func(schema string) *LineageStore {
+		_, err := testDB.Connection.ExecContext(ctx, "CREATE SCHEMA "+schema)
+		require.NoError(t, err)
+
+		conn, err := NewConnection(&Config{
+			databaseURL:  testDB.ConnectionString,
+			MaxOpenConns: defaultMaxOpenConns,
+			MaxIdleConns: defaultMaxIdleConns,
+			Schema:       schema,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, config.RunTestMigrations(conn.DB))
+
+		store, err := NewLineageStore(conn, 1*time.Hour)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = store.Close() })
+
+		return store
+	}
+
+	storeA := newSchemaStore("team_a")
+	storeB := newSchemaStore("team_b")
+
+	event := createTestEvent("schema-run", ingestion.EventTypeComplete, 1, 1)
+
+	stored, _, err := storeA.StoreEvent(ctx, event)
+	require.NoError(t, err)
+	require.True(t, stored)
+
+	assert.Equal(t, 1, countRows(ctx, t, storeA, "SELECT COUNT(*) FROM job_runs"))
+	assert.Equal(t, 1, countRows(ctx, t, storeA, "SELECT COUNT(*) FROM team_a.job_runs"))
+	assert.Equal(t, 0, countRows(ctx, t, storeB, "SELECT COUNT(*) FROM job_runs"),
+		"Store B must not see store A's runs")
+
+	var publicRuns int
+	require.NoError(t, testDB.Connection.QueryRowContext(ctx, "SELECT COUNT(*) FROM public.job_runs").Scan(&publicRuns))
+	assert.Zero(t, publicRuns, "Nothing is written to the default schema")
+
+	for _, schema := range []string{"team_a", "team_b"} {
+		var migrationTables int
+		require.NoError(t, testDB.Connection.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM information_schema.tables
+			WHERE table_schema = $1 AND table_name = 'schema_migrations'
+		`, schema).Scan(&migrationTables))
+		assert.Equal(t, 1, migrationTables, "Migrations are tracked per schema")
+	}
+
+	// Search runs on the trigram indexes of the schema, with pg_trgm from the extension schema
+	results, err := storeA.SearchLineage(ctx, "schema-run", "", 10)
+	require.NoError(t, err)
+	assert.Len(t, results, 3, "The job and its input and output datasets")
+
+	results, err = storeB.SearchLineage(ctx, "schema-run", "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	assert.Equal(t, 1, countRows(ctx, t, storeA,
+		"SELECT COUNT(*) FROM pg_indexes WHERE schemaname = 'team_a' AND indexname = 'idx_datasets_urn_trgm'"))
+
+	assert.Equal(t, "team_a.job_run_changes", storeA.jobRunChangesChannel())
+}
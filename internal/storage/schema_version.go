@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// MinSchemaVersion is the lowest migration version this build of the server can run against.
+// Bump it whenever code starts depending on a new migration in migrations/.
+const MinSchemaVersion int64 = 16
+
+// DefaultMigrationTable is the golang-migrate tracking table used by the migrator by default.
+const DefaultMigrationTable = "schema_migrations"
+
+var (
+	// ErrSchemaNotMigrated is returned when no migration has been applied yet.
+	ErrSchemaNotMigrated = errors.New("no database migrations applied")
+
+	// ErrSchemaDirty is returned when the last migration failed part-way and needs manual repair.
+	ErrSchemaDirty = errors.New("database schema is dirty")
+
+	// ErrSchemaOutdated is returned when the applied version is older than MinSchemaVersion.
+	ErrSchemaOutdated = errors.New("database schema is outdated")
+)
+
+// SchemaVersionChecker reads the migration tracking table to verify the database schema
+// matches what this build expects. Used by deep readiness checks.
+type SchemaVersionChecker struct {
+	conn  *Connection
+	table string
+}
+
+// NewSchemaVersionChecker creates a checker for the given migration tracking table, which may be
+// schema-qualified (e.g. "correlator.schema_migrations"). An empty table name falls back to
+// DefaultMigrationTable.
+func NewSchemaVersionChecker(conn *Connection, migrationTable string) *SchemaVersionChecker {
+	if migrationTable == "" {
+		migrationTable = DefaultMigrationTable
+	}
+
+	return &SchemaVersionChecker{
+		conn:  conn,
+		table: migrationTable,
+	}
+}
+
+// VerifySchema returns the applied migration version.
+//
+// Returns an error when the tracking table is missing or empty (ErrSchemaNotMigrated),
+// the last migration is dirty (ErrSchemaDirty), or the version is below MinSchemaVersion
+// (ErrSchemaOutdated). The version is returned alongside dirty/outdated errors for diagnostics.
+func (c *SchemaVersionChecker) VerifySchema(ctx context.Context) (int64, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+
+	query := "SELECT version, dirty FROM " + quoteQualifiedIdentifier(c.table) + " LIMIT 1" //nolint:gosec
+
+	err := c.conn.QueryRowContext(ctx, query).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrSchemaNotMigrated
+	}
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "42P01" { // undefined_table
+			return 0, ErrSchemaNotMigrated
+		}
+
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if dirty {
+		return version, fmt.Errorf("%w: migration %d did not complete", ErrSchemaDirty, version)
+	}
+
+	if version < MinSchemaVersion {
+		return version, fmt.Errorf("%w: version %d, requires at least %d", ErrSchemaOutdated, version, MinSchemaVersion)
+	}
+
+	return version, nil
+}
+
+// quoteQualifiedIdentifier quotes each dot-separated part of a possibly schema-qualified name.
+func quoteQualifiedIdentifier(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteQualifiedIdentifier(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	assert.Equal(t, `"schema_migrations"`, quoteQualifiedIdentifier("schema_migrations"))
+	assert.Equal(t, `"correlator"."schema_migrations"`, quoteQualifiedIdentifier("correlator.schema_migrations"))
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// SelfTestNamespace is the job namespace of the throwaway job run written by VerifyJobRunRoundTrip.
+const SelfTestNamespace = "correlator://selftest"
+
+// ErrSelfTestMismatch is returned when the job run read back by VerifyJobRunRoundTrip differs
+// from the one written.
+var ErrSelfTestMismatch = errors.New("self-test job run mismatch")
+
+// VerifyJobRunRoundTrip writes a throwaway job run through the regular ingestion upsert, reads it
+// back, and deletes it, verifying the database user can insert, select, and delete lineage rows.
+//
+// All three steps run in one transaction that is committed, so nothing is left behind: no job
+// run, no idempotency key, and no correlation view refresh. Used by `correlator selftest`.
+func (s *LineageStore) VerifyJobRunRoundTrip(ctx context.Context) error {
+	if s.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	event := &ingestion.RunEvent{
+		EventTime: time.Now().UTC(),
+		EventType: ingestion.EventTypeStart,
+		Producer:  "https://github.com/correlator-io/correlator/selftest",
+		Run:       ingestion.Run{ID: uuid.New().String()},
+		Job:       ingestion.Job{Namespace: SelfTestNamespace, Name: "selftest"},
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	if err := s.upsertJobRun(ctx, tx, event); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	var namespace, name, state string
+
+	err = tx.QueryRowContext(ctx,
+		`SELECT job_namespace, job_name, current_state FROM job_runs WHERE run_id = $1`, event.Run.ID,
+	).Scan(&namespace, &name, &state)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+
+	if namespace != event.Job.Namespace || name != event.Job.Name || state != string(event.EventType) {
+		return fmt.Errorf("read: %w: got %s/%s in state %s", ErrSelfTestMismatch, namespace, name, state)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM job_runs WHERE run_id = $1`, event.Run.ID)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete: failed to get rows affected: %w", err)
+	}
+
+	if deleted != 1 {
+		return fmt.Errorf("delete: %w: %d rows deleted", ErrSelfTestMismatch, deleted)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	return nil
+}
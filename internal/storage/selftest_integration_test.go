@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyJobRunRoundTrip verifies the self-test succeeds and leaves no rows behind.
+func TestVerifyJobRunRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	require.NoError(t, store.VerifyJobRunRoundTrip(ctx))
+	require.NoError(t, store.VerifyJobRunRoundTrip(ctx), "self-test must be repeatable")
+
+	assert.Equal(t, 0, countRows(ctx, t, store,
+		`SELECT COUNT(*) FROM job_runs WHERE job_namespace = $1`, SelfTestNamespace))
+	assert.Equal(t, 0, countRows(ctx, t, store, `SELECT COUNT(*) FROM lineage_event_idempotency`))
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// staleRunReason is recorded on the state_history transitions written by AbortStaleRuns.
+const staleRunReason = "stale_run_sweep"
+
+// abortStaleRunsQuery moves up to $2 runs whose latest event is older than $1 seconds from a
+// non-terminal state to ABORT, appending the transition to state_history. Candidate rows are
+// locked with SKIP LOCKED so the sweep never waits on (or races) an event being ingested for the
+// same run; the terminal-state check is repeated in the UPDATE for the same reason.
+const abortStaleRunsQuery = `
+	WITH stale AS (
+		SELECT run_id, current_state
+		FROM job_runs
+		WHERE current_state NOT IN ('COMPLETE', 'FAIL', 'ABORT')
+		  AND event_time < NOW() - make_interval(secs => $1)
+		ORDER BY event_time
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	UPDATE job_runs j
+	SET current_state = 'ABORT',
+		completed_at = NOW(),
+		state_history = jsonb_set(
+			COALESCE(j.state_history, '{"transitions": []}'::jsonb),
+			'{transitions}',
+			COALESCE(j.state_history->'transitions', '[]'::jsonb) || jsonb_build_array(jsonb_build_object(
+				'from', stale.current_state,
+				'to', 'ABORT',
+				'event_time', to_char(NOW() AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+				'updated_at', to_char(NOW() AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+				'reason', $3::text
+			))
+		)
+	FROM stale
+	WHERE j.run_id = stale.run_id
+	  AND j.current_state NOT IN ('COMPLETE', 'FAIL', 'ABORT')
+	RETURNING j.run_id, j.job_namespace, j.job_name, stale.current_state, j.event_time`
+
+// AbortedRun identifies a job run moved to ABORT by AbortStaleRuns.
+type AbortedRun struct {
+	RunID         string
+	JobNamespace  string
+	JobName       string
+	PreviousState string    // State the run was stuck in (START, RUNNING, or OTHER)
+	LastEventTime time.Time // Time of the run's latest event
+}
+
+// AbortStaleRuns moves at most limit job runs that are still in a non-terminal state and whose
+// latest event is older than olderThan to ABORT, oldest first. Each run gets a state_history
+// transition (reason "stale_run_sweep") like one written for an ABORT event would look.
+//
+// Runs already in a terminal state are never touched, and runs locked by concurrent ingestion
+// are skipped until the next call. A later event for an aborted run is treated like any event
+// after a terminal state (see validateStateTransition).
+func (s *LineageStore) AbortStaleRuns(ctx context.Context, olderThan time.Duration, limit int) ([]AbortedRun, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	rows, err := s.conn.QueryContext(ctx, abortStaleRunsQuery, olderThan.Seconds(), limit, staleRunReason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to abort stale job runs: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var aborted []AbortedRun
+
+	for rows.Next() {
+		var run AbortedRun
+		if err := rows.Scan(&run.RunID, &run.JobNamespace, &run.JobName, &run.PreviousState,
+			&run.LastEventTime); err != nil {
+			return nil, fmt.Errorf("failed to scan aborted job run: %w", err)
+		}
+
+		aborted = append(aborted, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read aborted job runs: %w", err)
+	}
+
+	if len(aborted) > 0 {
+		s.notifyDataChanged()
+	}
+
+	return aborted, nil
+}
+
+// StaleRunSweeper periodically aborts job runs stuck in a non-terminal state (START, RUNNING,
+// or OTHER) because their producer crashed before emitting COMPLETE, FAIL, or ABORT.
+//
+// Rate-limited by design: each interval aborts at most limit runs, so a large backlog of stale
+// runs (e.g. right after enabling the sweeper) is worked off gradually. Every aborted run is
+// logged. Not safe to Start twice.
+type StaleRunSweeper struct {
+	store      *LineageStore
+	logger     *slog.Logger
+	abortAfter time.Duration
+	interval   time.Duration
+	limit      int
+}
+
+// NewStaleRunSweeper creates a sweeper for store from the stale run settings of cfg.
+func NewStaleRunSweeper(store *LineageStore, cfg *Config, logger *slog.Logger) *StaleRunSweeper {
+	store.workers.Register(WorkerStaleRunSweep)
+
+	return &StaleRunSweeper{
+		store:      store,
+		logger:     logger,
+		abortAfter: cfg.StaleRunAbortAfter,
+		interval:   cfg.StaleRunSweepInterval,
+		limit:      cfg.StaleRunSweepLimit,
+	}
+}
+
+// Start runs the sweeper in a background goroutine until ctx is cancelled.
+func (w *StaleRunSweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.sweep(ctx)
+			}
+		}
+	}()
+}
+
+// sweep runs one AbortStaleRuns pass and logs its outcome.
+func (w *StaleRunSweeper) sweep(ctx context.Context) {
+	aborted, err := w.store.AbortStaleRuns(ctx, w.abortAfter, w.limit)
+	w.store.workers.Record(WorkerStaleRunSweep, err)
+
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("Stale run sweep failed", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	for _, run := range aborted {
+		w.logger.Warn("Aborted stale job run",
+			slog.String("run_id", run.RunID),
+			slog.String("job_namespace", run.JobNamespace),
+			slog.String("job_name", run.JobName),
+			slog.String("previous_state", run.PreviousState),
+			slog.Time("last_event_time", run.LastEventTime),
+			slog.Duration("abort_after", w.abortAfter),
+		)
+	}
+
+	if len(aborted) == w.limit {
+		w.logger.Info("Stale run sweep reached its limit; remaining runs are aborted in later sweeps",
+			slog.Int("limit", w.limit),
+		)
+	}
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// ageJobRun moves the latest event of a job run age into the past.
+func ageJobRun(ctx context.Context, t *testing.T, store *LineageStore, runID string, age time.Duration) {
+	t.Helper()
+
+	_, err := store.conn.ExecContext(ctx,
+		`UPDATE job_runs SET event_time = NOW() - make_interval(secs => $2) WHERE run_id = $1`,
+		runID, age.Seconds())
+	require.NoError(t, err)
+}
+
+// TestAbortStaleRuns verifies that only runs stuck in a non-terminal state past the cutoff are
+// aborted, at most limit per call, with a state_history transition, and that the aborted run is
+// then terminal for later events.
+func TestAbortStaleRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+	start := time.Now().Add(-time.Minute)
+
+	runs := map[string]*ingestion.RunEvent{}
+
+	for name, eventType := range map[string]ingestion.EventType{
+		"stuck_running": ingestion.EventTypeRunning,
+		"stuck_start":   ingestion.EventTypeStart,
+		"fresh":         ingestion.EventTypeRunning,
+		"complete":      ingestion.EventTypeComplete,
+	} {
+		_, _, err := store.StoreEvent(ctx, createTestEventWithTime(name, ingestion.EventTypeStart, 1, 1, start))
+		require.NoError(t, err)
+
+		event := createTestEventWithTime(name, eventType, 1, 1, start.Add(time.Second))
+		if eventType != ingestion.EventTypeStart {
+			_, _, err = store.StoreEvent(ctx, event)
+			require.NoError(t, err)
+		}
+
+		runs[name] = event
+	}
+
+	// stuck_running is the oldest, so the first (limit 1) sweep takes it
+	ageJobRun(ctx, t, store, runs["stuck_running"].Run.ID, 48*time.Hour+time.Second)
+	ageJobRun(ctx, t, store, runs["stuck_start"].Run.ID, 48*time.Hour)
+	ageJobRun(ctx, t, store, runs["complete"].Run.ID, 48*time.Hour)
+
+	aborted, err := store.AbortStaleRuns(ctx, 24*time.Hour, 1)
+	require.NoError(t, err)
+	require.Len(t, aborted, 1)
+	assert.Equal(t, runs["stuck_running"].Run.ID, aborted[0].RunID)
+	assert.Equal(t, "RUNNING", aborted[0].PreviousState)
+	assert.Equal(t, runs["stuck_running"].Job.Name, aborted[0].JobName)
+
+	aborted, err = store.AbortStaleRuns(ctx, 24*time.Hour, 10)
+	require.NoError(t, err)
+	require.Len(t, aborted, 1)
+	assert.Equal(t, runs["stuck_start"].Run.ID, aborted[0].RunID)
+	assert.Equal(t, "START", aborted[0].PreviousState)
+
+	aborted, err = store.AbortStaleRuns(ctx, 24*time.Hour, 10)
+	require.NoError(t, err)
+	assert.Empty(t, aborted, "aborted and terminal runs must not be swept again")
+
+	states := map[string]string{
+		"stuck_running": "ABORT",
+		"stuck_start":   "ABORT",
+		"fresh":         "RUNNING",
+		"complete":      "COMPLETE",
+	}
+
+	for name, want := range states {
+		var state string
+
+		err := store.conn.QueryRowContext(ctx,
+			`SELECT current_state FROM job_runs WHERE run_id = $1`, runs[name].Run.ID).Scan(&state)
+		require.NoError(t, err)
+		assert.Equal(t, want, state, name)
+	}
+
+	var (
+		historyJSON []byte
+		completedAt *time.Time
+	)
+
+	err = store.conn.QueryRowContext(ctx,
+		`SELECT state_history, completed_at FROM job_runs WHERE run_id = $1`,
+		runs["stuck_running"].Run.ID).Scan(&historyJSON, &completedAt)
+	require.NoError(t, err)
+	assert.NotNil(t, completedAt)
+
+	var history struct {
+		Transitions []map[string]interface{} `json:"transitions"`
+	}
+
+	require.NoError(t, json.Unmarshal(historyJSON, &history))
+	require.Len(t, history.Transitions, 3, "START, RUNNING, then ABORT")
+
+	last := history.Transitions[2]
+	assert.Equal(t, "RUNNING", last["from"])
+	assert.Equal(t, "ABORT", last["to"])
+	assert.Equal(t, staleRunReason, last["reason"])
+
+	_, err = time.Parse(time.RFC3339Nano, last["event_time"].(string))
+	require.NoError(t, err)
+
+	// A late COMPLETE for the aborted run is rejected like any event after a terminal state
+	late := createTestEventWithTime("stuck_running", ingestion.EventTypeComplete, 1, 1, time.Now())
+	_, _, err = store.StoreEvent(ctx, late)
+	require.ErrorIs(t, err, ErrInvalidStateTransition)
+}
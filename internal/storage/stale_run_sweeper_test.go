@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbortStaleRuns_NoConnection(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &LineageStore{}
+
+	aborted, err := store.AbortStaleRuns(context.Background(), time.Hour, 10)
+	require.ErrorIs(t, err, ErrNoDatabaseConnection)
+	require.Empty(t, aborted)
+}
@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// minStaticKeyLength rejects guessable static keys; generated keys are 78 characters.
+const minStaticKeyLength = 32
+
+// ErrKeyStoreReadOnly is returned by StaticKeyStore for Add, Update, and Delete.
+// Static keys change only by editing the key file and restarting.
+var ErrKeyStoreReadOnly = errors.New("API key store is read-only")
+
+type (
+	// StaticKey declares an API key whose plaintext value comes from configuration.
+	StaticKey struct {
+		KeySpec `yaml:",inline"`
+
+		Key string `yaml:"key"`
+	}
+
+	// staticKeyFile is the layout of the static key file.
+	staticKeyFile struct {
+		APIKeys []StaticKey `yaml:"api_keys"` //nolint:tagliatelle
+	}
+
+	// StaticKeyStore is a read-only APIKeyStore serving a fixed set of keys from configuration,
+	// so authentication can be enabled without the api_keys table.
+	//
+	// Keys are hashed (SHA-256) when the store is created and the plaintext is not retained.
+	// The set never changes while running: rotating or revoking a key requires editing the
+	// key file and restarting.
+	StaticKeyStore struct {
+		keys     map[string]*APIKey // SHA-256 lookup hash -> key
+		keysByID map[string]*APIKey
+	}
+)
+
+// LoadStaticKeys reads static API keys from a YAML file in the provisioning file layout,
+// with the plaintext key of each entry:
+//
+//	api_keys:
+//	  - plugin_id: dbt
+//	    name: dbt production
+//	    key: correlator_ak_...
+//	    permissions: [lineage:write]
+//
+// Permissions default to lineage:write when omitted.
+func LoadStaticKeys(path string) ([]StaticKey, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is from trusted config source
+	if err != nil {
+		return nil, fmt.Errorf("failed to read static API key file: %w", err)
+	}
+
+	var file staticKeyFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidKeySpec, path, err)
+	}
+
+	for i := range file.APIKeys {
+		key := &file.APIKeys[i]
+		key.PluginID = strings.TrimSpace(key.PluginID)
+		key.Name = strings.TrimSpace(key.Name)
+		key.Key = strings.TrimSpace(key.Key)
+
+		if len(key.Permissions) == 0 {
+			key.Permissions = []string{"lineage:write"}
+		}
+	}
+
+	return file.APIKeys, nil
+}
+
+// NewStaticKeyStore hashes keys into a read-only store. Every key needs plugin_id, name, and a
+// key of at least 32 characters, and no negative quota; a (plugin_id, name) pair or key value
+// declared twice is an error.
+func NewStaticKeyStore(keys []StaticKey) (*StaticKeyStore, error) {
+	store := &StaticKeyStore{
+		keys:     make(map[string]*APIKey, len(keys)),
+		keysByID: make(map[string]*APIKey, len(keys)),
+	}
+
+	createdAt := time.Now()
+
+	for i, key := range keys {
+		if key.PluginID == "" || key.Name == "" {
+			return nil, fmt.Errorf("%w: entry %d needs plugin_id and name", ErrInvalidKeySpec, i+1)
+		}
+
+		if len(key.Key) < minStaticKeyLength {
+			return nil, fmt.Errorf("%w: %s/%s key must be at least %d characters",
+				ErrInvalidKeySpec, key.PluginID, key.Name, minStaticKeyLength)
+		}
+
+		if key.DailyEventQuota < 0 || key.MonthlyEventQuota < 0 {
+			return nil, fmt.Errorf("%w: %s/%s has a negative quota", ErrInvalidKeySpec, key.PluginID, key.Name)
+		}
+
+		// Stable across restarts, so key IDs in logs stay meaningful
+		id := uuid.NewSHA1(uuid.NameSpaceURL, []byte("correlator-static-key:"+key.PluginID+"\x00"+key.Name)).String()
+		if _, exists := store.keysByID[id]; exists {
+			return nil, fmt.Errorf("%w: %s/%s declared twice", ErrInvalidKeySpec, key.PluginID, key.Name)
+		}
+
+		lookupHash := ComputeKeyLookupHash(key.Key)
+		if _, exists := store.keys[lookupHash]; exists {
+			return nil, fmt.Errorf("%w: %s/%s reuses the key of another entry",
+				ErrInvalidKeySpec, key.PluginID, key.Name)
+		}
+
+		apiKey := &APIKey{
+			ID:          id,
+			Key:         MaskKey(key.Key),
+			ClientID:    key.PluginID,
+			Name:        key.Name,
+			Permissions: append([]string(nil), key.Permissions...),
+			CreatedAt:   createdAt,
+			Active:      true,
+			Quota:       EventQuota{Daily: key.DailyEventQuota, Monthly: key.MonthlyEventQuota},
+		}
+
+		store.keys[lookupHash] = apiKey
+		store.keysByID[id] = apiKey
+	}
+
+	return store, nil
+}
+
+// HealthCheck always returns nil: the store has no external dependencies.
+func (s *StaticKeyStore) HealthCheck(_ context.Context) error {
+	return nil
+}
+
+// FindByKey retrieves a static key by its plaintext value.
+func (s *StaticKeyStore) FindByKey(_ context.Context, key string) (*APIKey, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	apiKey, exists := s.keys[ComputeKeyLookupHash(key)]
+	if !exists {
+		return nil, false
+	}
+
+	return copyAPIKey(apiKey), true
+}
+
+// FindByID retrieves a static key by its ID.
+func (s *StaticKeyStore) FindByID(_ context.Context, keyID string) (*APIKey, error) {
+	apiKey, exists := s.keysByID[keyID]
+	if !exists {
+		return nil, ErrKeyNotFound
+	}
+
+	return copyAPIKey(apiKey), nil
+}
+
+// FindByName retrieves a client's static key by name.
+func (s *StaticKeyStore) FindByName(_ context.Context, clientID, name string) (*APIKey, error) {
+	for _, apiKey := range s.keysByID {
+		if apiKey.ClientID == clientID && apiKey.Name == name {
+			return copyAPIKey(apiKey), nil
+		}
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// Add always fails with ErrKeyStoreReadOnly.
+func (s *StaticKeyStore) Add(_ context.Context, _ *APIKey) error {
+	return ErrKeyStoreReadOnly
+}
+
+// Update always fails with ErrKeyStoreReadOnly.
+func (s *StaticKeyStore) Update(_ context.Context, _ *APIKey) error {
+	return ErrKeyStoreReadOnly
+}
+
+// Delete always fails with ErrKeyStoreReadOnly.
+func (s *StaticKeyStore) Delete(_ context.Context, _ string) error {
+	return ErrKeyStoreReadOnly
+}
+
+// ListByClientID returns the static keys of a client, ordered by name.
+func (s *StaticKeyStore) ListByClientID(_ context.Context, clientID string) ([]*APIKey, error) {
+	result := []*APIKey{}
+
+	for _, apiKey := range s.keysByID {
+		if apiKey.ClientID == clientID {
+			result = append(result, copyAPIKey(apiKey))
+		}
+	}
+
+	slices.SortFunc(result, func(a, b *APIKey) int { return strings.Compare(a.Name, b.Name) })
+
+	return result, nil
+}
+
+// copyAPIKey returns a copy of apiKey that callers may modify.
+func copyAPIKey(apiKey *APIKey) *APIKey {
+	keyCopy := *apiKey
+	keyCopy.Permissions = append([]string(nil), apiKey.Permissions...)
+
+	return &keyCopy
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticKeyStore(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	ctx := t.Context()
+
+	// pragma: allowlist nextline secret
+	dbtKey := "correlator_ak_1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	airflowKey := "airflow-static-key-0123456789abcdef" // pragma: allowlist secret
+
+	store, err := NewStaticKeyStore([]StaticKey{
+		{KeySpec: KeySpec{PluginID: "dbt", Name: "dbt production", Permissions: []string{"lineage:write"}}, Key: dbtKey},
+		{KeySpec: KeySpec{PluginID: "airflow", Name: "airflow", Permissions: []string{"lineage:read"}}, Key: airflowKey},
+	})
+	if err != nil {
+		t.Fatalf("NewStaticKeyStore() unexpected error: %v", err)
+	}
+
+	t.Run("find by key", func(t *testing.T) {
+		found, exists := store.FindByKey(ctx, dbtKey)
+		if !exists {
+			t.Fatal("FindByKey() key not found")
+		}
+
+		if found.ClientID != "dbt" || !found.Active || !found.HasPermission("lineage:write") {
+			t.Errorf("FindByKey() = %+v, want active dbt key with lineage:write", found)
+		}
+
+		if found.Key == dbtKey {
+			t.Error("FindByKey() must not return the plaintext key")
+		}
+
+		if _, exists := store.FindByKey(ctx, dbtKey+"x"); exists {
+			t.Error("FindByKey() found an unknown key")
+		}
+
+		if _, exists := store.FindByKey(ctx, ""); exists {
+			t.Error("FindByKey() found an empty key")
+		}
+	})
+
+	t.Run("find by ID is stable", func(t *testing.T) {
+		found, _ := store.FindByKey(ctx, airflowKey)
+
+		byID, err := store.FindByID(ctx, found.ID)
+		if err != nil || byID.Name != "airflow" {
+			t.Errorf("FindByID() = %+v, %v", byID, err)
+		}
+
+		again, err := NewStaticKeyStore([]StaticKey{
+			{KeySpec: KeySpec{PluginID: "airflow", Name: "airflow"}, Key: airflowKey},
+		})
+		if err != nil {
+			t.Fatalf("NewStaticKeyStore() unexpected error: %v", err)
+		}
+
+		if _, err := again.FindByID(ctx, found.ID); err != nil {
+			t.Errorf("key ID changed between stores: %v", err)
+		}
+
+		if _, err := store.FindByID(ctx, "unknown"); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("FindByID() error = %v, want ErrKeyNotFound", err)
+		}
+	})
+
+	t.Run("returned keys are copies", func(t *testing.T) {
+		found, _ := store.FindByKey(ctx, dbtKey)
+		found.Active = false
+		found.Permissions[0] = "admin"
+
+		again, _ := store.FindByKey(ctx, dbtKey)
+		if !again.Active || again.Permissions[0] != "lineage:write" {
+			t.Errorf("store was modified through a returned key: %+v", again)
+		}
+	})
+
+	t.Run("list by client", func(t *testing.T) {
+		keys, err := store.ListByClientID(ctx, "dbt")
+		if err != nil || len(keys) != 1 {
+			t.Errorf("ListByClientID(dbt) = %d keys, %v; want 1", len(keys), err)
+		}
+
+		keys, err = store.ListByClientID(ctx, "unknown")
+		if err != nil || keys == nil || len(keys) != 0 {
+			t.Errorf("ListByClientID(unknown) = %v, %v; want empty slice", keys, err)
+		}
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		found, _ := store.FindByKey(ctx, dbtKey)
+
+		if err := store.Add(ctx, found); !errors.Is(err, ErrKeyStoreReadOnly) {
+			t.Errorf("Add() error = %v, want ErrKeyStoreReadOnly", err)
+		}
+
+		if err := store.Update(ctx, found); !errors.Is(err, ErrKeyStoreReadOnly) {
+			t.Errorf("Update() error = %v, want ErrKeyStoreReadOnly", err)
+		}
+
+		if err := store.Delete(ctx, found.ID); !errors.Is(err, ErrKeyStoreReadOnly) {
+			t.Errorf("Delete() error = %v, want ErrKeyStoreReadOnly", err)
+		}
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		if err := store.HealthCheck(ctx); err != nil {
+			t.Errorf("HealthCheck() unexpected error: %v", err)
+		}
+	})
+}
+
+func TestNewStaticKeyStoreInvalid(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	key := "static-key-0123456789abcdef0123456789" // pragma: allowlist secret
+
+	tests := []struct {
+		name string
+		keys []StaticKey
+	}{
+		{"missing plugin_id", []StaticKey{{KeySpec: KeySpec{Name: "a"}, Key: key}}},
+		{"missing name", []StaticKey{{KeySpec: KeySpec{PluginID: "dbt"}, Key: key}}},
+		{"short key", []StaticKey{{KeySpec: KeySpec{PluginID: "dbt", Name: "a"}, Key: "short"}}},
+		{"duplicate entry", []StaticKey{
+			{KeySpec: KeySpec{PluginID: "dbt", Name: "a"}, Key: key},
+			{KeySpec: KeySpec{PluginID: "dbt", Name: "a"}, Key: key + "2"},
+		}},
+		{"duplicate key", []StaticKey{
+			{KeySpec: KeySpec{PluginID: "dbt", Name: "a"}, Key: key},
+			{KeySpec: KeySpec{PluginID: "dbt", Name: "b"}, Key: key},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewStaticKeyStore(tt.keys); !errors.Is(err, ErrInvalidKeySpec) {
+				t.Errorf("NewStaticKeyStore() error = %v, want ErrInvalidKeySpec", err)
+			}
+		})
+	}
+}
+
+func TestLoadStaticKeys(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	content := `api_keys:
+  - plugin_id: " dbt "
+    name: dbt production
+    key: static-key-0123456789abcdef0123456789
+  - plugin_id: admin
+    name: ops
+    key: static-key-abcdef0123456789abcdef0123
+    permissions: [admin, lineage:read]
+`
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadStaticKeys(path)
+	if err != nil {
+		t.Fatalf("LoadStaticKeys() unexpected error: %v", err)
+	}
+
+	if len(keys) != 2 {
+		t.Fatalf("LoadStaticKeys() = %d keys, want 2", len(keys))
+	}
+
+	if keys[0].PluginID != "dbt" || keys[0].Key != "static-key-0123456789abcdef0123456789" {
+		t.Errorf("keys[0] = %+v", keys[0])
+	}
+
+	if len(keys[0].Permissions) != 1 || keys[0].Permissions[0] != "lineage:write" {
+		t.Errorf("keys[0].Permissions = %v, want default [lineage:write]", keys[0].Permissions)
+	}
+
+	if len(keys[1].Permissions) != 2 {
+		t.Errorf("keys[1].Permissions = %v, want 2", keys[1].Permissions)
+	}
+
+	if _, err := LoadStaticKeys(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadStaticKeys() expected error for missing file")
+	}
+}
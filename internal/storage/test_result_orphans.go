@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// maxOrphanedTestResults caps the rows returned by one FindOrphanedTestResults call.
+const maxOrphanedTestResults = 1000
+
+var (
+	// ErrTestResultNotFound is returned by ReassociateTestResult for an unknown test result ID.
+	ErrTestResultNotFound = errors.New("test result not found")
+
+	// ErrJobRunNotFound is returned by ReassociateTestResult when the target job run has not been ingested.
+	ErrJobRunNotFound = errors.New("job run not found")
+
+	// ErrTestResultConflict is returned by ReassociateTestResult when the target job run already has
+	// a result for the same test and dataset.
+	ErrTestResultConflict = errors.New("job run already has a result for this test and dataset")
+)
+
+// OrphanedTestResult is a test result whose run_id references a job run that does not exist.
+type OrphanedTestResult struct {
+	ID         int64
+	TestName   string
+	DatasetURN string
+	RunID      string // The missing job run
+	Status     string
+	ExecutedAt time.Time
+	CreatedAt  time.Time
+}
+
+// FindOrphanedTestResults returns test results recorded more than olderThan ago whose job run
+// does not exist, oldest first (at most 1000).
+//
+// The deferred test_results.run_id foreign key normally prevents orphans; they appear when rows
+// were loaded with FK checks bypassed (e.g., a restore with session_replication_role = replica).
+// The olderThan window skips results whose run may still be in flight. Pair with
+// ReassociateTestResult once the missing run is ingested.
+func (s *LineageStore) FindOrphanedTestResults(
+	ctx context.Context,
+	olderThan time.Duration,
+) ([]OrphanedTestResult, error) {
+	if s.conn == nil {
+		return nil, ErrNoDatabaseConnection
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+		SELECT tr.id, tr.test_name, tr.dataset_urn, tr.run_id::text, tr.status, tr.executed_at, tr.created_at
+		FROM test_results tr
+		WHERE NOT EXISTS (SELECT 1 FROM job_runs jr WHERE jr.run_id = tr.run_id)
+		  AND tr.created_at < NOW() - make_interval(secs => $1)
+		ORDER BY tr.created_at, tr.id
+		LIMIT $2
+	`, olderThan.Seconds(), maxOrphanedTestResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orphaned test results: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var orphans []OrphanedTestResult
+
+	for rows.Next() {
+		var o OrphanedTestResult
+
+		err := rows.Scan(&o.ID, &o.TestName, &o.DatasetURN, &o.RunID, &o.Status, &o.ExecutedAt, &o.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan orphaned test result: %w", err)
+		}
+
+		orphans = append(orphans, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orphaned test results: %w", err)
+	}
+
+	return orphans, nil
+}
+
+// ReassociateTestResult points a test result at jobRunID, typically an orphan whose run arrived
+// under a different ID. The job run must exist (ErrJobRunNotFound otherwise).
+//
+// Correlation views are refreshed through the usual debounced refresh.
+func (s *LineageStore) ReassociateTestResult(ctx context.Context, testResultID int64, jobRunID string) error {
+	if s.conn == nil {
+		return ErrNoDatabaseConnection
+	}
+
+	if _, err := uuid.Parse(jobRunID); err != nil {
+		return fmt.Errorf("%w: %q is not a UUID", ErrJobRunNotFound, jobRunID)
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	// Lock the run so it cannot be deleted before the FK check at commit
+	var one int
+
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM job_runs WHERE run_id = $1 FOR SHARE`, jobRunID).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrJobRunNotFound, jobRunID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to look up job run: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE test_results SET run_id = $2, updated_at = NOW() WHERE id = $1`, testResultID, jobRunID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation on (test_name, dataset_urn, run_id)
+			return ErrTestResultConflict
+		}
+
+		return fmt.Errorf("failed to reassociate test result: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if affected == 0 {
+		return ErrTestResultNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit test result reassociation: %w", err)
+	}
+
+	s.logger.Info("Reassociated test result",
+		slog.Int64("test_result_id", testResultID),
+		slog.String("run_id", jobRunID),
+	)
+
+	s.notifyDataChanged()
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insertOrphanedTestResult inserts a test result for a job run that does not exist, bypassing the
+// deferred FK the way a replica-mode restore does, and returns its ID.
+func insertOrphanedTestResult(
+	ctx context.Context, t *testing.T, store *LineageStore, datasetURN, runID string, age time.Duration,
+) int64 {
+	t.Helper()
+
+	tx, err := store.conn.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `SET LOCAL session_replication_role = replica`)
+	require.NoError(t, err)
+
+	var id int64
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO test_results (test_name, dataset_urn, run_id, status, executed_at, created_at)
+		VALUES ('not_null_id', $1, $2, 'failed', NOW(), NOW() - make_interval(secs => $3))
+		RETURNING id
+	`, datasetURN, runID, age.Seconds()).Scan(&id)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	return id
+}
+
+func TestOrphanedTestResults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+
+	datasetURN := "postgresql://prod/public.orders"
+	runID := uuid.New().String()
+
+	_, err := store.conn.ExecContext(ctx,
+		`INSERT INTO datasets (dataset_urn, name, namespace) VALUES ($1, 'orders', 'postgresql://prod')`, datasetURN)
+	require.NoError(t, err)
+
+	oldID := insertOrphanedTestResult(ctx, t, store, datasetURN, runID, 2*time.Hour)
+	_ = insertOrphanedTestResult(ctx, t, store, datasetURN, uuid.New().String(), time.Minute)
+
+	t.Run("Finds Orphans Older Than Window", func(t *testing.T) {
+		orphans, err := store.FindOrphanedTestResults(ctx, time.Hour)
+		require.NoError(t, err)
+
+		require.Len(t, orphans, 1, "the recent orphan is still within the window")
+		assert.Equal(t, oldID, orphans[0].ID)
+		assert.Equal(t, runID, orphans[0].RunID)
+		assert.Equal(t, "not_null_id", orphans[0].TestName)
+		assert.Equal(t, "failed", orphans[0].Status)
+	})
+
+	t.Run("Reassociate Fails For Missing Job Run", func(t *testing.T) {
+		err := store.ReassociateTestResult(ctx, oldID, runID)
+		require.ErrorIs(t, err, ErrJobRunNotFound)
+
+		err = store.ReassociateTestResult(ctx, oldID, "not-a-uuid")
+		require.ErrorIs(t, err, ErrJobRunNotFound)
+	})
+
+	t.Run("Reassociate Fixes Orphan Once Run Arrives", func(t *testing.T) {
+		now := time.Now()
+		_, err := store.conn.ExecContext(ctx, `
+			INSERT INTO job_runs (run_id, job_name, job_namespace, current_state, event_type, event_time, started_at)
+			VALUES ($1, 'orders_checks', 'ge', 'COMPLETE', 'COMPLETE', $2, $2)
+		`, runID, now)
+		require.NoError(t, err)
+
+		orphans, err := store.FindOrphanedTestResults(ctx, time.Hour)
+		require.NoError(t, err)
+		assert.Empty(t, orphans, "the run arrived under the referenced ID")
+
+		otherRunID := uuid.New().String()
+		_, err = store.conn.ExecContext(ctx, `
+			INSERT INTO job_runs (run_id, job_name, job_namespace, current_state, event_type, event_time, started_at)
+			VALUES ($1, 'orders_checks', 'ge', 'COMPLETE', 'COMPLETE', $2, $2)
+		`, otherRunID, now)
+		require.NoError(t, err)
+
+		require.NoError(t, store.ReassociateTestResult(ctx, oldID, otherRunID))
+
+		var got string
+
+		err = store.conn.QueryRowContext(ctx, `SELECT run_id::text FROM test_results WHERE id = $1`, oldID).Scan(&got)
+		require.NoError(t, err)
+		assert.Equal(t, otherRunID, got)
+	})
+
+	t.Run("Reassociate Unknown Test Result", func(t *testing.T) {
+		err := store.ReassociateTestResult(ctx, 999999, runID)
+		require.ErrorIs(t, err, ErrTestResultNotFound)
+	})
+}
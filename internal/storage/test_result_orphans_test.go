@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphanedTestResults_NoConnection(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &LineageStore{}
+
+	_, err := store.FindOrphanedTestResults(context.Background(), time.Hour)
+	require.ErrorIs(t, err, ErrNoDatabaseConnection)
+
+	err = store.ReassociateTestResult(context.Background(), 1, "7f3c5b1e-6c1a-4b8e-9f2a-1d2e3f4a5b6c")
+	require.ErrorIs(t, err, ErrNoDatabaseConnection)
+}
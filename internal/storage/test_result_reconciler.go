@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// orphanMatchTolerance widens a job run's [started_at, completed_at] span when matching an
+// orphaned test result to it, absorbing clock skew between the test tool and the lineage producer.
+const orphanMatchTolerance = 5 * time.Minute
+
+// orphanMatchQuery returns up to two job runs that read the test result's dataset ($1, matched
+// through its canonical URN like the correlation views do) while the test executed ($2, within
+// $3 seconds). Two rows mean the match is ambiguous.
+const orphanMatchQuery = `
+	SELECT DISTINCT jr.run_id::text
+	FROM lineage_edges le
+	JOIN job_runs jr ON jr.run_id = le.run_id
+	WHERE le.edge_type = 'input'
+	  AND le.dataset_urn IN (
+		SELECT rd_edge.raw_urn
+		FROM resolved_datasets rd_test
+		JOIN resolved_datasets rd_edge ON rd_edge.canonical_urn = rd_test.canonical_urn
+		WHERE rd_test.raw_urn = $1
+		UNION ALL
+		SELECT $1
+	  )
+	  AND $2 BETWEEN jr.started_at - make_interval(secs => $3)
+	             AND COALESCE(jr.completed_at, jr.event_time) + make_interval(secs => $3)
+	LIMIT 2`
+
+// ErrNoOrphanMatch is returned by MatchOrphanedTestResult when no job run, or more than one,
+// could have executed the test.
+var ErrNoOrphanMatch = errors.New("no unambiguous job run for orphaned test result")
+
+// MatchOrphanedTestResult finds the job run that executed an orphaned test result: the only job
+// run with the test's dataset as an input (by canonical URN) whose run spans the test's execution
+// time, give or take a few minutes. Returns ErrNoOrphanMatch when there is none or several.
+func (s *LineageStore) MatchOrphanedTestResult(ctx context.Context, orphan OrphanedTestResult) (string, error) {
+	if s.conn == nil {
+		return "", ErrNoDatabaseConnection
+	}
+
+	rows, err := s.conn.QueryContext(ctx, orphanMatchQuery,
+		orphan.DatasetURN, orphan.ExecutedAt, orphanMatchTolerance.Seconds())
+	if err != nil {
+		return "", fmt.Errorf("failed to match orphaned test result: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var runIDs []string
+
+	for rows.Next() {
+		var runID string
+		if err := rows.Scan(&runID); err != nil {
+			return "", fmt.Errorf("failed to scan matching job run: %w", err)
+		}
+
+		runIDs = append(runIDs, runID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterating matching job runs: %w", err)
+	}
+
+	if len(runIDs) != 1 {
+		return "", fmt.Errorf("%w: %d candidates for test result %d", ErrNoOrphanMatch, len(runIDs), orphan.ID)
+	}
+
+	return runIDs[0], nil
+}
+
+// OrphanReconciliationStats reports the outcome of OrphanReconciler passes for GET /metrics.
+type OrphanReconciliationStats struct {
+	Matched       int64 // Orphans reassociated with a late job run since startup
+	StillOrphaned int64 // Orphans left unmatched by the last pass
+}
+
+// OrphanReconciler periodically reassociates orphaned test results (see FindOrphanedTestResults)
+// with the job run that executed them once that run has been ingested (see
+// MatchOrphanedTestResult), closing the race where test results are uploaded before lineage.
+//
+// Orphans are only considered once they are older than the interval, and an ambiguous match is
+// left alone: it stays orphaned and is retried next pass. Not safe to Start twice.
+type OrphanReconciler struct {
+	store    *LineageStore
+	logger   *slog.Logger
+	interval time.Duration
+	wg       sync.WaitGroup
+
+	matched       atomic.Int64
+	stillOrphaned atomic.Int64
+}
+
+// NewOrphanReconciler creates a reconciler for store running every cfg.OrphanReconcileInterval.
+func NewOrphanReconciler(store *LineageStore, cfg *Config, logger *slog.Logger) *OrphanReconciler {
+	store.workers.Register(WorkerOrphanReconcile)
+
+	return &OrphanReconciler{
+		store:    store,
+		logger:   logger,
+		interval: cfg.OrphanReconcileInterval,
+	}
+}
+
+// Start runs the reconciler in a background goroutine until ctx is cancelled. A pass in progress
+// stops at the next orphan; use Wait to block until it has.
+func (r *OrphanReconciler) Start(ctx context.Context) {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.reconcile(ctx)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the goroutine started by Start has returned, for graceful shutdown.
+func (r *OrphanReconciler) Wait() {
+	r.wg.Wait()
+}
+
+// Stats returns the counters reported on GET /metrics.
+func (r *OrphanReconciler) Stats() OrphanReconciliationStats {
+	return OrphanReconciliationStats{
+		Matched:       r.matched.Load(),
+		StillOrphaned: r.stillOrphaned.Load(),
+	}
+}
+
+// reconcile runs one pass over the current orphans and records its outcome.
+func (r *OrphanReconciler) reconcile(ctx context.Context) {
+	matched, stillOrphaned, err := r.reconcileOrphans(ctx)
+	if ctx.Err() != nil {
+		return // Shutting down: a partial pass is neither a failure nor a complete count
+	}
+
+	r.store.workers.Record(WorkerOrphanReconcile, err)
+
+	if err != nil {
+		r.logger.Error("Orphaned test result reconciliation failed", slog.Any("error", err))
+
+		return
+	}
+
+	r.matched.Add(int64(matched))
+	r.stillOrphaned.Store(int64(stillOrphaned))
+
+	if matched > 0 || stillOrphaned > 0 {
+		r.logger.Info("Reconciled orphaned test results",
+			slog.Int("matched", matched),
+			slog.Int("still_orphaned", stillOrphaned),
+		)
+	}
+}
+
+// reconcileOrphans matches and reassociates every orphan older than the interval. Orphans without
+// an unambiguous match, or whose match already has a result for the same test, stay orphaned.
+func (r *OrphanReconciler) reconcileOrphans(ctx context.Context) (int, int, error) {
+	orphans, err := r.store.FindOrphanedTestResults(ctx, r.interval)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	matched := 0
+
+	for _, orphan := range orphans {
+		if ctx.Err() != nil {
+			return matched, len(orphans) - matched, ctx.Err()
+		}
+
+		runID, err := r.store.MatchOrphanedTestResult(ctx, orphan)
+		if errors.Is(err, ErrNoOrphanMatch) {
+			continue
+		}
+
+		if err != nil {
+			return matched, len(orphans) - matched, err
+		}
+
+		err = r.store.ReassociateTestResult(ctx, orphan.ID, runID)
+		if errors.Is(err, ErrTestResultConflict) || errors.Is(err, ErrJobRunNotFound) ||
+			errors.Is(err, ErrTestResultNotFound) {
+			r.logger.Warn("Orphaned test result left unmatched",
+				slog.Int64("test_result_id", orphan.ID),
+				slog.String("run_id", runID),
+				slog.String("reason", err.Error()),
+			)
+
+			continue
+		}
+
+		if err != nil {
+			return matched, len(orphans) - matched, err
+		}
+
+		matched++
+	}
+
+	return matched, len(orphans) - matched, nil
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// TestOrphanReconciler verifies the ingestion-ordering race is closed: a test result uploaded
+// before its job run is linked to the run once it arrives, and an orphan nothing matches stays
+// orphaned.
+func TestOrphanReconciler(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t)
+	reconciler := NewOrphanReconciler(store, &Config{OrphanReconcileInterval: time.Hour}, slog.Default())
+
+	// 1. The test result arrives first, referencing a run that was never ingested under that ID
+	testRun := createTestEventWithTime("ge_orders_checks", ingestion.EventTypeStart, 1, 0, time.Now().Add(-time.Minute))
+	datasetURN := testRun.Inputs[0].URN()
+
+	orphanID := insertOrphanedTestResult(ctx, t, store, datasetURN, uuid.New().String(), 2*time.Hour)
+	unmatchedID := insertOrphanedTestResult(ctx, t, store, "postgresql://prod/public.unknown", uuid.New().String(),
+		2*time.Hour)
+
+	reconciler.reconcile(ctx)
+	assert.Equal(t, OrphanReconciliationStats{Matched: 0, StillOrphaned: 2}, reconciler.Stats(),
+		"nothing to match before the run arrives")
+
+	// 2. The job run that executed the test arrives, reading the tested dataset
+	_, _, err := store.StoreEvent(ctx, testRun)
+	require.NoError(t, err)
+
+	complete := createTestEventWithTime("ge_orders_checks", ingestion.EventTypeComplete, 1, 0, time.Now())
+	_, _, err = store.StoreEvent(ctx, complete)
+	require.NoError(t, err)
+
+	// 3. Reconciliation links them
+	reconciler.reconcile(ctx)
+	assert.Equal(t, OrphanReconciliationStats{Matched: 1, StillOrphaned: 1}, reconciler.Stats())
+
+	var runID string
+
+	err = store.conn.QueryRowContext(ctx, `SELECT run_id::text FROM test_results WHERE id = $1`, orphanID).Scan(&runID)
+	require.NoError(t, err)
+	assert.Equal(t, testRun.Run.ID, runID)
+
+	orphans, err := store.FindOrphanedTestResults(ctx, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, unmatchedID, orphans[0].ID)
+
+	_, err = store.MatchOrphanedTestResult(ctx, orphans[0])
+	require.ErrorIs(t, err, ErrNoOrphanMatch)
+}
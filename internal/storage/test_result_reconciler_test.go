@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchOrphanedTestResult_NoConnection(t *testing.T) {
+	if !testing.Short() {
+		t.Skip("skipping unit test in non-short mode")
+	}
+
+	store := &LineageStore{}
+
+	runID, err := store.MatchOrphanedTestResult(context.Background(), OrphanedTestResult{ID: 1})
+	require.ErrorIs(t, err, ErrNoDatabaseConnection)
+	require.Empty(t, runID)
+}
@@ -45,6 +45,9 @@ type (
 	// Connection represents a database connection.
 	Connection struct {
 		*sql.DB
+
+		dsn    string // Connection string for dedicated LISTEN connections (empty for WrapConnection)
+		schema string // Schema set as search_path (empty = server default)
 	}
 
 	// APIKey represents an API key with client identification and permissions.
@@ -59,12 +62,17 @@ type (
 		CreatedAt   time.Time
 		ExpiresAt   *time.Time
 		Active      bool
+		Quota       EventQuota // Ingestion quotas of the key's plugin, stored in api_keys.metadata
 	}
 
 	// APIKeyStore defines the interface for API key storage and retrieval.
 	APIKeyStore interface {
 		// FindByKey retrieves an API key by its key value
 		FindByKey(ctx context.Context, key string) (*APIKey, bool)
+		// FindByID retrieves an API key by its ID (ErrKeyNotFound if it does not exist)
+		FindByID(ctx context.Context, keyID string) (*APIKey, error)
+		// FindByName retrieves a client's API key by name, active or not (ErrKeyNotFound if none)
+		FindByName(ctx context.Context, clientID, name string) (*APIKey, error)
 		// Add stores a new API key
 		Add(ctx context.Context, apiKey *APIKey) error
 		// Update modifies an existing API key
@@ -122,8 +130,18 @@ type (
 )
 
 // NewConnection returns a new Database Connection.
+// With config.Schema set, every connection uses it, then public (extensions), as search_path.
 func NewConnection(config *Config) (*Connection, error) {
-	db, err := sql.Open(postgresDriver, config.databaseURL)
+	if err := validateSchema(config.Schema); err != nil {
+		return nil, err
+	}
+
+	dsn, err := dsnWithSearchPath(config.databaseURL, config.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(postgresDriver, dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +162,7 @@ func NewConnection(config *Config) (*Connection, error) {
 		return nil, fmt.Errorf("database health check failed: %w", err)
 	}
 
-	return &Connection{db}, nil
+	return &Connection{DB: db, dsn: dsn, schema: config.Schema}, nil
 }
 
 // HealthCheck checks if the database connection is healthy with timeout.
@@ -165,7 +183,7 @@ func (c *Connection) HealthCheck(ctx context.Context) error { //nolint: contextc
 // This is primarily used by integration tests that create database connections
 // via testcontainers and need to pass them to NewLineageStore.
 func WrapConnection(db *sql.DB) *Connection {
-	return &Connection{db}
+	return &Connection{DB: db}
 }
 
 // Close closes the database connection pool gracefully.
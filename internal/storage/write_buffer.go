@@ -0,0 +1,385 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+const (
+	// DefaultWriteBufferFlushInterval is how long a buffered event waits for others to share its
+	// transaction when WriteBufferConfig.FlushInterval is not set.
+	DefaultWriteBufferFlushInterval = 10 * time.Millisecond
+
+	// writeBufferFlushTimeout bounds the transaction of one flush. Flushes serve many requests, so
+	// they use their own context rather than any request's.
+	writeBufferFlushTimeout = 30 * time.Second
+)
+
+// ErrWriteBufferFlushFailed is the error of a buffered event whose group transaction failed as a
+// whole and that could not be stored on its own either.
+var ErrWriteBufferFlushFailed = errors.New("write buffer flush failed")
+
+type (
+	// WriteBufferConfig configures write buffering (see WithWriteBuffer).
+	WriteBufferConfig struct {
+		// MaxEvents flushes the buffer as soon as this many events are pending (0 = disabled).
+		MaxEvents int
+
+		// FlushInterval flushes the buffer this long after its first pending event, however few
+		// arrived (default DefaultWriteBufferFlushInterval).
+		FlushInterval time.Duration
+
+		// Async makes StoreEvent return as soon as the event is buffered, before it is stored.
+		// Storage errors are then only logged, and duplicates are reported as stored.
+		Async bool
+	}
+
+	// writeBuffer accumulates StoreEvent calls across requests until a flush stores them together.
+	writeBuffer struct {
+		config WriteBufferConfig
+		queue  chan *bufferedEvent
+		mu     sync.RWMutex // Held for reading while enqueuing, for writing while closing
+		closed bool
+		stop   chan struct{} // Closed on Close: flush what is pending and exit
+		done   chan struct{} // Closed once the flush goroutine has exited
+	}
+
+	// bufferedEvent is an event waiting in the write buffer, with where to report its outcome
+	// (nil in async mode).
+	bufferedEvent struct {
+		event  *ingestion.RunEvent
+		result chan bufferedResult
+	}
+
+	// bufferedResult is the outcome of a buffered event, as returned by StoreEvent.
+	bufferedResult struct {
+		stored    bool
+		duplicate bool
+		err       error
+	}
+)
+
+// WithWriteBuffer makes StoreEvent buffer events across requests and store them together: one
+// transaction per flush instead of one per event, flushed when config.MaxEvents events are pending
+// or config.FlushInterval after the first of them. Meant for bursts of single-event requests (e.g.
+// every dbt model of a morning batch POSTing on its own). Default: disabled (MaxEvents 0).
+//
+// Each event keeps its own outcome: it is stored under a savepoint, so one failing event does not
+// fail the others, and idempotency is checked per event as without buffering. By default StoreEvent
+// returns only once the event is committed; with config.Async it returns right after buffering.
+// StoreEvents and StoreEventsAtomic are not buffered: a batch already shares round trips. Close
+// flushes the buffer before returning.
+//
+// Example:
+//
+//	store, err := storage.NewLineageStore(conn, interval,
+//	    storage.WithWriteBuffer(storage.WriteBufferConfig{MaxEvents: 200, FlushInterval: 10 * time.Millisecond}))
+func WithWriteBuffer(config WriteBufferConfig) LineageStoreOption {
+	return func(s *LineageStore) {
+		if config.MaxEvents <= 0 {
+			s.writeBuffer = nil
+
+			return
+		}
+
+		if config.FlushInterval <= 0 {
+			config.FlushInterval = DefaultWriteBufferFlushInterval
+		}
+
+		s.writeBuffer = &writeBuffer{
+			config: config,
+			queue:  make(chan *bufferedEvent, config.MaxEvents),
+			stop:   make(chan struct{}),
+			done:   make(chan struct{}),
+		}
+	}
+}
+
+// bufferEvent hands a validated event to the write buffer and, unless the buffer is async, waits
+// for the flush that stores it. Returns false when the buffer is closed, in which case the caller
+// stores the event itself.
+//
+// If ctx ends while the event waits, ctx's error is returned but the event may still be stored;
+// a retry is then reported as a duplicate.
+func (s *LineageStore) bufferEvent(ctx context.Context, event *ingestion.RunEvent) (bufferedResult, bool) {
+	b := s.writeBuffer
+
+	b.mu.RLock()
+
+	if b.closed {
+		b.mu.RUnlock()
+
+		return bufferedResult{}, false
+	}
+
+	buffered := &bufferedEvent{event: event}
+	if !b.config.Async {
+		buffered.result = make(chan bufferedResult, 1)
+	}
+
+	// Blocks while the buffer is full, holding back requests while a flush is running
+	select {
+	case b.queue <- buffered:
+	case <-ctx.Done():
+		b.mu.RUnlock()
+
+		return bufferedResult{err: fmt.Errorf("%w: %w", ErrLineageStoreFailed, ctx.Err())}, true
+	}
+
+	b.mu.RUnlock()
+
+	if b.config.Async {
+		return bufferedResult{stored: true}, true
+	}
+
+	select {
+	case result := <-buffered.result:
+		return result, true
+	case <-ctx.Done():
+		return bufferedResult{err: fmt.Errorf("%w: %w", ErrLineageStoreFailed, ctx.Err())}, true
+	}
+}
+
+// runWriteBuffer collects buffered events and flushes them when MaxEvents are pending or
+// FlushInterval after the first of them, until the buffer is closed; it then flushes the rest.
+func (s *LineageStore) runWriteBuffer() {
+	b := s.writeBuffer
+
+	defer close(b.done)
+
+	for {
+		var pending []*bufferedEvent
+
+		select {
+		case buffered := <-b.queue:
+			pending = append(pending, buffered)
+		case <-b.stop:
+			s.drainWriteBuffer()
+
+			return
+		}
+
+		timer := time.NewTimer(b.config.FlushInterval)
+
+	collect:
+		for len(pending) < b.config.MaxEvents {
+			select {
+			case buffered := <-b.queue:
+				pending = append(pending, buffered)
+			case <-timer.C:
+				break collect
+			case <-b.stop:
+				break collect
+			}
+		}
+
+		timer.Stop()
+		s.flushWriteBuffer(pending)
+	}
+}
+
+// drainWriteBuffer flushes the events left in the queue of a closed buffer.
+func (s *LineageStore) drainWriteBuffer() {
+	b := s.writeBuffer
+
+	for {
+		var pending []*bufferedEvent
+
+	collect:
+		for len(pending) < b.config.MaxEvents {
+			select {
+			case buffered := <-b.queue:
+				pending = append(pending, buffered)
+			default:
+				break collect
+			}
+		}
+
+		if len(pending) == 0 {
+			return
+		}
+
+		s.flushWriteBuffer(pending)
+	}
+}
+
+// closeWriteBuffer stops accepting events, then waits until every buffered event is flushed.
+// Later StoreEvent calls store their event directly.
+func (s *LineageStore) closeWriteBuffer() {
+	b := s.writeBuffer
+	if b == nil {
+		return
+	}
+
+	// Waits for in-flight bufferEvent calls, which the flush goroutine keeps unblocking
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.stop)
+	<-b.done
+}
+
+// flushWriteBuffer stores pending events in one transaction, in arrival order, and reports each
+// outcome. If the transaction fails as a whole (e.g. at commit), each event is stored on its own.
+func (s *LineageStore) flushWriteBuffer(pending []*bufferedEvent) {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeBufferFlushTimeout)
+	defer cancel()
+
+	batch, results, err := s.storeBufferedTx(ctx, pending)
+	if err != nil && s.stmts.invalidate(err) {
+		// A cached statement went stale: the flush was rolled back, so replay it once (see StoreEvent)
+		batch, results, err = s.storeBufferedTx(ctx, pending)
+	}
+
+	if err != nil {
+		s.logger.Warn("Write buffer flush failed, storing events one by one",
+			slog.Int("event_count", len(pending)),
+			slog.String("error", err.Error()),
+		)
+
+		for _, buffered := range pending {
+			stored, duplicate, err := s.storeEvent(ctx, buffered.event)
+			if err != nil {
+				err = fmt.Errorf("%w: %w", ErrWriteBufferFlushFailed, err)
+			}
+
+			s.reportBuffered(buffered, bufferedResult{stored: stored, duplicate: duplicate, err: err})
+		}
+
+		return
+	}
+
+	stored := 0
+
+	for i, buffered := range pending {
+		if results[i].stored {
+			stored++
+		}
+
+		s.reportBuffered(buffered, results[i])
+	}
+
+	s.logger.Info("write buffer flushed",
+		slog.Int("event_count", len(pending)),
+		slog.Int("stored", stored),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	// Same post-commit steps as StoreEvent
+	s.autoResolvePassingTests(ctx, batch.passingTests)
+	s.queueFailingTests(batch.failingTestIDs)
+	s.queueFailedRuns(batch.failedRunIDs)
+
+	if stored > 0 {
+		s.notifyDataChanged()
+	}
+}
+
+// storeBufferedTx stores pending events in one transaction, each under a savepoint: an event that
+// fails is rolled back to its savepoint and reported failed, the others are still committed.
+// Returns an error only when the transaction fails as a whole, in which case nothing is stored.
+func (s *LineageStore) storeBufferedTx(
+	ctx context.Context,
+	pending []*bufferedEvent,
+) (atomicBatch, []bufferedResult, error) {
+	var batch atomicBatch
+
+	results := make([]bufferedResult, len(pending))
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return batch, nil, fmt.Errorf("%w: failed to begin transaction: %w", ErrLineageStoreFailed, err)
+	}
+
+	defer func() {
+		_ = tx.Rollback() // Safe to call even after commit
+	}()
+
+	// Idempotency keys recorded by this (uncommitted) transaction are invisible to checkIdempotency
+	seen := make(map[string]bool, len(pending))
+
+	for i, buffered := range pending {
+		idempotencyKey := buffered.event.IdempotencyKey()
+
+		duplicate := seen[idempotencyKey]
+		if !duplicate {
+			duplicate, err = s.checkIdempotency(ctx, idempotencyKey)
+			if err != nil {
+				results[i].err = fmt.Errorf("%w: idempotency check failed: %w", ErrIdempotencyCheckFailed, err)
+
+				continue
+			}
+		}
+
+		if duplicate {
+			results[i].duplicate = true
+
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT buffered_event"); err != nil {
+			return batch, nil, fmt.Errorf("%w: failed to create savepoint: %w", ErrLineageStoreFailed, err)
+		}
+
+		passingTests, failingTestIDs, err := s.storeEventInTx(ctx, tx, buffered.event, idempotencyKey)
+		if err != nil {
+			if isStaleStatementError(err) || isDatabaseConnectionError(err) || ctx.Err() != nil {
+				return batch, nil, err
+			}
+
+			if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT buffered_event"); rbErr != nil {
+				return batch, nil, fmt.Errorf("%w: failed to roll back to savepoint: %w", ErrLineageStoreFailed, rbErr)
+			}
+
+			results[i].err = err
+
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT buffered_event"); err != nil {
+			return batch, nil, fmt.Errorf("%w: failed to release savepoint: %w", ErrLineageStoreFailed, err)
+		}
+
+		seen[idempotencyKey] = true
+		results[i].stored = true
+		batch.passingTests = append(batch.passingTests, passingTests...)
+		batch.failingTestIDs = append(batch.failingTestIDs, failingTestIDs...)
+
+		if buffered.event.EventType == ingestion.EventTypeFail {
+			batch.failedRunIDs = append(batch.failedRunIDs, buffered.event.Run.ID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return batch, nil, fmt.Errorf("%w: %w", ErrLineageStoreFailed, err)
+	}
+
+	return batch, results, nil
+}
+
+// reportBuffered delivers the outcome of a buffered event to its waiting StoreEvent call, or logs
+// a failure of an async one.
+func (s *LineageStore) reportBuffered(buffered *bufferedEvent, result bufferedResult) {
+	if buffered.result != nil {
+		buffered.result <- result
+
+		return
+	}
+
+	if result.err != nil {
+		s.logger.Error("Failed to store buffered event",
+			slog.String("run_id", buffered.event.Run.ID),
+			slog.String("event_type", string(buffered.event.EventType)),
+			slog.String("error", result.err.Error()),
+		)
+	}
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/correlator-io/correlator/internal/ingestion"
+)
+
+// storeConcurrently calls StoreEvent for every event at once, as concurrent requests would, and
+// returns the outcome of each.
+func storeConcurrently(ctx context.Context, store *LineageStore, events []*ingestion.RunEvent) []bufferedResult {
+	results := make([]bufferedResult, len(events))
+
+	var wg sync.WaitGroup
+
+	for i, event := range events {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			stored, duplicate, err := store.StoreEvent(ctx, event)
+			results[i] = bufferedResult{stored: stored, duplicate: duplicate, err: err}
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// TestWriteBuffer_SharesOneTransaction verifies that concurrent single events are stored by one
+// flush, in one transaction, once MaxEvents are pending.
+func TestWriteBuffer_SharesOneTransaction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// The interval never expires: only the size triggers the flush
+	store := setupTestLineageStore(t, WithWriteBuffer(WriteBufferConfig{MaxEvents: 10, FlushInterval: time.Hour}))
+
+	events := make([]*ingestion.RunEvent, 10)
+	runIDs := make([]string, 10)
+
+	for i := range events {
+		events[i] = createTestEvent(string(rune('a'+i))+"-burst", ingestion.EventTypeStart, 1, 1)
+		runIDs[i] = events[i].Run.ID
+	}
+
+	for i, result := range storeConcurrently(ctx, store, events) {
+		require.NoError(t, result.err, "event %d", i)
+		assert.True(t, result.stored, "event %d", i)
+	}
+
+	assert.Equal(t, 10, countRows(ctx, t, store,
+		`SELECT COUNT(*) FROM job_runs WHERE run_id = ANY($1)`, pq.Array(runIDs)))
+	assert.Equal(t, 1, countRows(ctx, t, store,
+		`SELECT COUNT(DISTINCT xmin::text) FROM job_runs WHERE run_id = ANY($1)`, pq.Array(runIDs)),
+		"all runs written by the same transaction")
+}
+
+// TestWriteBuffer_EventsKeepTheirOwnOutcome verifies that a failing event of a flush does not fail
+// the others, and that duplicates within a flush are detected.
+func TestWriteBuffer_EventsKeepTheirOwnOutcome(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t, WithWriteBuffer(WriteBufferConfig{MaxEvents: 4, FlushInterval: time.Hour}))
+
+	// Stored unbuffered: StoreEvents does not go through the write buffer
+	completed := createTestEvent("completed", ingestion.EventTypeComplete, 1, 1)
+	_, err := store.StoreEvents(ctx, []*ingestion.RunEvent{completed})
+	require.NoError(t, err)
+
+	started := createTestEvent("started", ingestion.EventTypeStart, 1, 1)
+	events := []*ingestion.RunEvent{
+		createTestEvent("completed", ingestion.EventTypeFail, 1, 1), // terminal state already reached
+		started,
+		started,
+		createTestEvent("other", ingestion.EventTypeStart, 2, 1),
+	}
+
+	results := storeConcurrently(ctx, store, events)
+
+	require.ErrorIs(t, results[0].err, ErrInvalidStateTransition)
+	assert.False(t, results[0].stored)
+
+	require.NoError(t, results[1].err)
+	require.NoError(t, results[2].err)
+	assert.True(t, results[1].stored != results[2].stored, "one copy stored")
+	assert.True(t, results[1].duplicate != results[2].duplicate, "the other a duplicate")
+
+	require.NoError(t, results[3].err)
+	assert.True(t, results[3].stored)
+
+	assert.Equal(t, 2, countRows(ctx, t, store,
+		`SELECT COUNT(*) FROM lineage_edges WHERE run_id = $1`, started.Run.ID))
+
+	var state string
+	require.NoError(t, store.conn.QueryRowContext(ctx,
+		`SELECT current_state FROM job_runs WHERE run_id = $1`, completed.Run.ID).Scan(&state))
+	assert.Equal(t, stateComplete, state, "rejected event rolled back to its savepoint")
+}
+
+// TestWriteBuffer_CloseFlushesAsyncEvents verifies that async events are acknowledged before they
+// are stored, and that Close stores them.
+func TestWriteBuffer_CloseFlushesAsyncEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	store := setupTestLineageStore(t, WithWriteBuffer(WriteBufferConfig{
+		MaxEvents:     100,
+		FlushInterval: time.Hour,
+		Async:         true,
+	}))
+
+	runIDs := make([]string, 5)
+
+	for i := range runIDs {
+		event := createTestEvent(string(rune('a'+i))+"-async", ingestion.EventTypeStart, 1, 1)
+		runIDs[i] = event.Run.ID
+
+		stored, duplicate, err := store.StoreEvent(ctx, event)
+		require.NoError(t, err)
+		assert.True(t, stored)
+		assert.False(t, duplicate)
+	}
+
+	assert.Equal(t, 0, countRows(ctx, t, store,
+		`SELECT COUNT(*) FROM job_runs WHERE run_id = ANY($1)`, pq.Array(runIDs)), "still buffered")
+
+	require.NoError(t, store.Close())
+
+	assert.Equal(t, 5, countRows(ctx, t, store,
+		`SELECT COUNT(*) FROM job_runs WHERE run_id = ANY($1)`, pq.Array(runIDs)), "flushed on Close")
+
+	// After Close events are stored directly
+	late := createTestEvent("late-async", ingestion.EventTypeStart, 1, 1)
+	stored, _, err := store.StoreEvent(ctx, late)
+	require.NoError(t, err)
+	assert.True(t, stored)
+	assert.Equal(t, 1, countRows(ctx, t, store, `SELECT COUNT(*) FROM job_runs WHERE run_id = $1`, late.Run.ID))
+}
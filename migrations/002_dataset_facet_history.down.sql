@@ -0,0 +1,9 @@
+-- =====================================================
+-- Rollback: Dataset Facet History
+-- =====================================================
+
+BEGIN;
+
+DROP TABLE IF EXISTS dataset_facet_history CASCADE;
+
+COMMIT;
@@ -0,0 +1,37 @@
+-- =====================================================
+-- Correlator: Dataset Facet History (optional audit)
+-- =====================================================
+--
+-- Dataset facets are merged destructively (datasets.facets || new facets): a newer facet
+-- value replaces the older one with no record. When facet auditing is enabled
+-- (CORRELATOR_FACET_AUDIT_ENABLED=true), every merge that changes a top-level facet
+-- appends one row here with the before/after value of each changed facet.
+--
+-- IMMUTABLE TABLE (created_at only, append-only audit log).
+-- Off by default to avoid write amplification on high-volume ingestion.
+-- =====================================================
+
+BEGIN;
+
+CREATE TABLE dataset_facet_history (
+    id BIGSERIAL PRIMARY KEY,
+
+    dataset_urn VARCHAR(500) NOT NULL REFERENCES datasets(dataset_urn) ON DELETE CASCADE,
+
+    -- Producing run for output datasets; NULL when the change came from a consuming run.
+    -- No FK: the audit trail must survive job_runs retention.
+    run_id UUID,
+
+    -- Changed top-level facets only: {"<facet>": {"before": <old|null>, "after": <new>}}
+    changes JSONB NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_dataset_facet_history_urn_created
+    ON dataset_facet_history (dataset_urn, created_at DESC, id DESC);
+
+COMMENT ON TABLE dataset_facet_history IS 'Append-only audit of dataset facet merges (schema-drift forensics); written only when facet auditing is enabled';
+COMMENT ON COLUMN dataset_facet_history.changes IS 'Changed top-level facets with before/after values; before is null for newly added facets';
+
+COMMIT;
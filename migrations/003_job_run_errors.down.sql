@@ -0,0 +1,13 @@
+-- =====================================================
+-- Rollback: Job Run Error Details
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    DROP COLUMN IF EXISTS error_classification,
+    DROP COLUMN IF EXISTS error_programming_language,
+    DROP COLUMN IF EXISTS error_stack_trace,
+    DROP COLUMN IF EXISTS error_message;
+
+COMMIT;
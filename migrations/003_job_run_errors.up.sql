@@ -0,0 +1,28 @@
+-- =====================================================
+-- Correlator: Job Run Error Details
+-- =====================================================
+--
+-- FAIL and ABORT events carry the actual exception in the OpenLineage errorMessage run
+-- facet (message, programmingLanguage, stackTrace) and optionally a producer-assigned
+-- category in the errorClassification facet. These columns surface that detail for
+-- on-call without digging through job_runs.metadata.
+--
+-- Text is size-bounded and NUL-stripped at ingestion (see ingestion.ParseRunError);
+-- the column limits below are a backstop, not the primary bound.
+-- All columns are NULL for runs that did not fail or sent no error facet.
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    ADD COLUMN error_message TEXT,
+    ADD COLUMN error_stack_trace TEXT,
+    ADD COLUMN error_programming_language VARCHAR(50),
+    ADD COLUMN error_classification VARCHAR(255);
+
+COMMENT ON COLUMN job_runs.error_message IS 'errorMessage.message from the latest FAIL/ABORT event (truncated to 4KB)';
+COMMENT ON COLUMN job_runs.error_stack_trace IS 'errorMessage.stackTrace from the latest FAIL/ABORT event (multi-line, truncated to 32KB)';
+COMMENT ON COLUMN job_runs.error_programming_language IS 'errorMessage.programmingLanguage (e.g., python, java)';
+COMMENT ON COLUMN job_runs.error_classification IS 'errorClassification.classification assigned by the producer (e.g., timeout, oom)';
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- =====================================================
+-- Rollback: Job Run OpenLineage Version
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    DROP COLUMN IF EXISTS openlineage_version;
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- =====================================================
+-- Correlator: Job Run OpenLineage Version
+-- =====================================================
+--
+-- A single batch may mix events from producers on different OpenLineage spec versions
+-- (e.g., an older Airflow integration next to a current dbt plugin). The version each
+-- run reported is derived from the event's schemaURL (see ingestion.ExtractOpenLineageVersion)
+-- and kept per run so version-specific producer behaviour can be diagnosed.
+--
+-- Follows the latest event of the run, like metadata. NULL for runs ingested before this
+-- migration or whose schemaURL has no recognisable version.
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    ADD COLUMN openlineage_version VARCHAR(20);
+
+COMMENT ON COLUMN job_runs.openlineage_version IS 'OpenLineage spec version from the latest event schemaURL (e.g., 2.0.2)';
+
+COMMIT;
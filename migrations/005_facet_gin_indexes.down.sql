@@ -0,0 +1,10 @@
+-- =====================================================
+-- Rollback: GIN Indexes on Facet JSONB
+-- =====================================================
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_job_runs_metadata;
+DROP INDEX IF EXISTS idx_datasets_facets;
+
+COMMIT;
@@ -0,0 +1,26 @@
+-- =====================================================
+-- Correlator: GIN Indexes on Facet JSONB
+-- =====================================================
+--
+-- Dataset facets (ownership, schema, documentation, ...) and job run metadata are
+-- queried by content, e.g. "all datasets owned by alice". Without an index every such
+-- query is a sequential scan.
+--
+-- jsonb_path_ops indexes support only the containment operator (@>), which is what
+-- LineageStore.FindDatasetsByFacet uses; they are smaller and faster than the default
+-- jsonb_ops. Queries using key-existence operators (?, ?|, ?&) are not index-backed.
+--
+-- Built inside the migration transaction (CREATE INDEX CONCURRENTLY cannot run in one):
+-- writes to both tables block while the indexes build. On large deployments apply during
+-- a quiet period.
+-- =====================================================
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_datasets_facets ON datasets USING GIN (facets jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_job_runs_metadata ON job_runs USING GIN (metadata jsonb_path_ops);
+
+COMMENT ON INDEX idx_datasets_facets IS 'Containment (@>) lookups on dataset facets, e.g. by owner';
+COMMENT ON INDEX idx_job_runs_metadata IS 'Containment (@>) lookups on job run metadata';
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- =====================================================
+-- Rollback: Column-Level Lineage
+-- =====================================================
+
+BEGIN;
+
+DROP TABLE IF EXISTS column_lineage_edges;
+
+COMMIT;
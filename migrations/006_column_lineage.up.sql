@@ -0,0 +1,54 @@
+-- =====================================================
+-- Correlator: Column-Level Lineage
+-- =====================================================
+--
+-- The OpenLineage columnLineage dataset facet maps each output column to the input
+-- columns it is derived from (fields.<column>.inputFields). One row per
+-- (run, output column, input column) lets a failing column-level test be traced to the
+-- upstream column that feeds it, instead of only to the upstream dataset.
+--
+-- Producers often report lineage for only some columns; columns without an entry simply
+-- have no rows here. Dataset-level (indirect) lineage from the facet's "dataset" list is
+-- already covered by lineage_edges and is not stored.
+--
+-- IMMUTABLE TABLE (created_at only): edges are facts about a run, re-delivery is a no-op.
+-- =====================================================
+
+BEGIN;
+
+CREATE TABLE column_lineage_edges (
+    id BIGSERIAL PRIMARY KEY,
+
+    run_id UUID NOT NULL REFERENCES job_runs(run_id) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED,
+
+    -- Output dataset the facet was attached to
+    output_dataset_urn VARCHAR(500) NOT NULL REFERENCES datasets(dataset_urn) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED,
+    output_column TEXT NOT NULL,
+
+    -- No FK: inputFields may name datasets that are not inputs of the event
+    input_dataset_urn VARCHAR(500) NOT NULL,
+    input_column TEXT NOT NULL,
+
+    -- First transformation of the input field (e.g. DIRECT/IDENTITY, INDIRECT/FILTER); NULL if not reported
+    transformation_type VARCHAR(50),
+    transformation_subtype VARCHAR(50),
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Go code uses ON CONFLICT DO NOTHING on this key
+CREATE UNIQUE INDEX idx_column_lineage_edges_unique
+    ON column_lineage_edges (run_id, output_dataset_urn, output_column, input_dataset_urn, input_column);
+
+-- Upstream lookups: "which input columns feed this dataset's columns?"
+CREATE INDEX idx_column_lineage_edges_output ON column_lineage_edges (output_dataset_urn, run_id);
+
+-- Downstream lookups: "which output columns does this column feed?"
+CREATE INDEX idx_column_lineage_edges_input ON column_lineage_edges (input_dataset_urn, input_column);
+
+COMMENT ON TABLE column_lineage_edges IS 'Column-level lineage from the OpenLineage columnLineage facet: one row per output column / input column pair per job run';
+COMMENT ON COLUMN column_lineage_edges.output_column IS 'Key of columnLineage.fields on the output dataset';
+COMMENT ON COLUMN column_lineage_edges.input_dataset_urn IS 'URN of inputFields[].namespace/name (not necessarily an input edge of the run)';
+COMMENT ON COLUMN column_lineage_edges.input_column IS 'inputFields[].field';
+
+COMMIT;
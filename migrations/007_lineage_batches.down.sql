@@ -0,0 +1,9 @@
+-- =====================================================
+-- Rollback: Async Lineage Batches
+-- =====================================================
+
+BEGIN;
+
+DROP TABLE IF EXISTS lineage_batches;
+
+COMMIT;
@@ -0,0 +1,60 @@
+-- =====================================================
+-- Correlator: Async Lineage Batches
+-- =====================================================
+--
+-- POST /api/v1/lineage/batch with "Prefer: respond-async" stores the request body here
+-- and returns 202 with a batch_id; a background worker in the server validates and stores
+-- the events in chunks, recording progress as it goes. Clients poll
+-- GET /api/v1/lineage/batches/{batch_id} for progress and the per-event results.
+--
+-- The table is the queue: any server instance may claim a pending batch
+-- (FOR UPDATE SKIP LOCKED), and a batch whose worker died is reclaimed once its
+-- updated_at goes stale. Reprocessing is safe because event storage is idempotent.
+--
+-- Lifecycle: pending -> processing -> done | failed. The events payload is cleared
+-- on completion; completed batches are deleted by the idempotency cleanup after 7 days.
+-- =====================================================
+
+BEGIN;
+
+CREATE TABLE lineage_batches (
+    batch_id UUID PRIMARY KEY,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'done', 'failed')),
+
+    -- Submitter (API key client ID, empty when auth is disabled) and request correlation ID
+    client_id VARCHAR(255) NOT NULL DEFAULT '',
+    correlation_id VARCHAR(255) NOT NULL DEFAULT '',
+
+    -- Request body (JSON array of OpenLineage events); NULL once the batch completes
+    events JSONB,
+
+    total_events INTEGER NOT NULL,
+    processed_events INTEGER NOT NULL DEFAULT 0,   -- Validated and stored (or rejected)
+    processing_events INTEGER NOT NULL DEFAULT 0,  -- In the chunk being stored right now
+
+    -- Batch response (same shape as the synchronous batch endpoint); set on done
+    result JSONB,
+    error_message TEXT,                            -- Set on failed
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Claiming: oldest pending (or stale processing) batch first
+CREATE INDEX idx_lineage_batches_queue ON lineage_batches (status, created_at)
+    WHERE status IN ('pending', 'processing');
+
+-- Retention cleanup of completed batches
+CREATE INDEX idx_lineage_batches_completed ON lineage_batches (completed_at)
+    WHERE completed_at IS NOT NULL;
+
+COMMENT ON TABLE lineage_batches IS 'Async lineage batch submissions: queue, progress, and results for GET /api/v1/lineage/batches/{batch_id}';
+COMMENT ON COLUMN lineage_batches.events IS 'Submitted JSON array of OpenLineage events; cleared on completion';
+COMMENT ON COLUMN lineage_batches.result IS 'Batch response (summary, failed_events, warnings) once done';
+COMMENT ON COLUMN lineage_batches.updated_at IS 'Progress heartbeat; a processing batch not updated recently is reclaimed';
+
+COMMIT;
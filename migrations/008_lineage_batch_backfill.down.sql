@@ -0,0 +1,10 @@
+-- =====================================================
+-- Rollback: Lineage Batch Backfill Flag
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE lineage_batches
+    DROP COLUMN IF EXISTS backfill;
+
+COMMIT;
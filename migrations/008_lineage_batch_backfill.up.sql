@@ -0,0 +1,18 @@
+-- =====================================================
+-- Correlator: Lineage Batch Backfill Flag
+-- =====================================================
+--
+-- Events whose eventTime is too far from server time are rejected
+-- (CORRELATOR_MAX_EVENT_TIME_SKEW), unless the request is a backfill
+-- ("X-Correlator-Backfill: true"). Async batches are validated by the worker after the
+-- request is gone, so the flag is kept with the batch.
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE lineage_batches
+    ADD COLUMN backfill BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN lineage_batches.backfill IS 'Submitted as a backfill: events skip the event time skew check';
+
+COMMIT;
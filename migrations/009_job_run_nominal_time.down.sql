@@ -0,0 +1,11 @@
+-- =====================================================
+-- Rollback: Job Run Nominal Time
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    DROP COLUMN IF EXISTS nominal_end_time,
+    DROP COLUMN IF EXISTS nominal_start_time;
+
+COMMIT;
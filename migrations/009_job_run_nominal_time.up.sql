@@ -0,0 +1,22 @@
+-- =====================================================
+-- Correlator: Job Run Nominal Time
+-- =====================================================
+--
+-- Scheduled jobs report the slot they were meant to cover in the OpenLineage nominalTime run
+-- facet (see ingestion.ParseNominalTime). Kept next to started_at / completed_at so runs that
+-- started or finished late against their schedule can be found (SLA tracking).
+--
+-- NULL when the producer sent no nominalTime facet (most producers) and for runs ingested
+-- before this migration.
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    ADD COLUMN nominal_start_time TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN nominal_end_time TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN job_runs.nominal_start_time IS 'Scheduled start of the run from the nominalTime run facet';
+COMMENT ON COLUMN job_runs.nominal_end_time IS 'Scheduled end of the run from the nominalTime run facet';
+
+COMMIT;
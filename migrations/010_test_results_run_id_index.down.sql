@@ -0,0 +1,9 @@
+-- =====================================================
+-- Rollback: Test Results Run Index
+-- =====================================================
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_test_results_run_id;
+
+COMMIT;
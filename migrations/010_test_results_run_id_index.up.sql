@@ -0,0 +1,14 @@
+-- =====================================================
+-- Correlator: Test Results Run Index
+-- =====================================================
+--
+-- GET /api/v1/job-runs/{jobRunID}/correlations lists every test result a run reported,
+-- passing ones included. idx_test_results_run_correlation only covers failed and errored
+-- tests, so runs with thousands of tests needed a scan of test_results.
+-- =====================================================
+
+BEGIN;
+
+CREATE INDEX idx_test_results_run_id ON test_results(run_id, executed_at DESC);
+
+COMMIT;
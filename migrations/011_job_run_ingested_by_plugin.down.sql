@@ -0,0 +1,12 @@
+-- =====================================================
+-- Rollback: Job Run Ingesting Plugin
+-- =====================================================
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_job_runs_ingested_by_plugin;
+
+ALTER TABLE job_runs
+    DROP COLUMN IF EXISTS ingested_by_plugin;
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- =====================================================
+-- Correlator: Job Run Ingesting Plugin
+-- =====================================================
+--
+-- Records which plugin (the plugin_id / client ID of the API key) sent the latest lineage event
+-- of each run, so bad data can be traced back to its integration and quality compared per
+-- plugin. Events ingested with authentication disabled record 'anonymous'.
+--
+-- NULL for runs ingested before this migration and for transports without an API key (Kafka).
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    ADD COLUMN ingested_by_plugin VARCHAR(255);
+
+COMMENT ON COLUMN job_runs.ingested_by_plugin IS 'Plugin (API key client ID) that sent the latest event of the run; anonymous when auth is disabled';
+
+CREATE INDEX idx_job_runs_ingested_by_plugin ON job_runs(ingested_by_plugin, event_time DESC)
+    WHERE ingested_by_plugin IS NOT NULL;
+
+COMMIT;
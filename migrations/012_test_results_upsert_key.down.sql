@@ -0,0 +1,11 @@
+-- =====================================================
+-- Rollback: Test Results Upsert Key
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE test_results DROP CONSTRAINT IF EXISTS test_results_upsert_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_test_results_upsert_key ON test_results(test_name, dataset_urn, run_id);
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- =====================================================
+-- Correlator: Test Results Upsert Key
+-- =====================================================
+--
+-- A test result is identified by (test_name, dataset_urn, run_id): re-running the same test on
+-- the same dataset within the same job run updates its result (last write wins on status,
+-- message, executed_at, ...) instead of adding a row. The key was only enforced by the unique
+-- index idx_test_results_upsert_key (and documented as including executed_at in 001); this
+-- promotes it to a named table constraint, which LineageStore upserts against by name, so the
+-- key shows up with the table definition and can't be dropped as "just an index".
+--
+-- The index is reused: no rebuild, no duplicate rows to clean up.
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE test_results
+    ADD CONSTRAINT test_results_upsert_key UNIQUE USING INDEX idx_test_results_upsert_key;
+
+COMMENT ON CONSTRAINT test_results_upsert_key ON test_results IS 'One result per test name, dataset and job run; re-runs upsert (last write wins)';
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- =====================================================
+-- Rollback: Job Aliases
+-- =====================================================
+
+BEGIN;
+
+DROP TABLE IF EXISTS job_aliases;
+
+COMMIT;
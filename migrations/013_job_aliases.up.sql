@@ -0,0 +1,41 @@
+-- =====================================================
+-- Correlator: Job Aliases
+-- =====================================================
+--
+-- A job renamed across tool versions (e.g. a dbt model moved to another package) keeps being
+-- the same logical transformation, but its runs arrive under a new (job_namespace, job_name),
+-- which splits its run history in two. Each row maps an old identity to the canonical one;
+-- ingestion rewrites the job of incoming events (and of their ParentRunFacet) accordingly, so
+-- renamed jobs keep a continuous run history.
+--
+-- Rows are loaded when the server starts. Aliases from .correlator.yaml (job_aliases) are
+-- upserted on startup: redefining an alias replaces its canonical job (last write wins).
+-- Runs stored before an alias existed keep the name they were ingested with.
+-- =====================================================
+
+BEGIN;
+
+CREATE TABLE job_aliases (
+    job_namespace VARCHAR(255) NOT NULL,
+    job_name VARCHAR(255) NOT NULL,
+
+    canonical_job_namespace VARCHAR(255) NOT NULL,
+    canonical_job_name VARCHAR(255) NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (job_namespace, job_name),
+
+    CONSTRAINT job_aliases_not_self CHECK (
+        (job_namespace, job_name) <> (canonical_job_namespace, canonical_job_name)
+    )
+);
+
+COMMENT ON TABLE job_aliases IS 'Maps renamed job identities to their canonical job so run history stays continuous';
+COMMENT ON COLUMN job_aliases.job_namespace IS 'Namespace of the alias (as sent by producers)';
+COMMENT ON COLUMN job_aliases.job_name IS 'Name of the alias (as sent by producers)';
+COMMENT ON COLUMN job_aliases.canonical_job_namespace IS 'Namespace runs of the alias are stored under';
+COMMENT ON COLUMN job_aliases.canonical_job_name IS 'Name runs of the alias are stored under';
+
+COMMIT;
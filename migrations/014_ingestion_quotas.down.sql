@@ -0,0 +1,11 @@
+-- =====================================================
+-- Rollback: Per-Plugin Ingestion Quotas
+-- =====================================================
+
+BEGIN;
+
+DROP TABLE IF EXISTS ingestion_quota_usage;
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS metadata;
+
+COMMIT;
@@ -0,0 +1,40 @@
+-- =====================================================
+-- Correlator: Per-Plugin Ingestion Quotas
+-- =====================================================
+--
+-- Rate limiting caps requests per second, not volume: a runaway plugin can ingest millions of
+-- events a day without ever being rate limited. Quotas cap the events a plugin (API key client
+-- ID) may store per UTC day and per calendar month.
+--
+-- api_keys.metadata holds per-key settings; the quotas are daily_event_quota and
+-- monthly_event_quota (absent or 0 = unlimited). A plugin is limited by the largest quota among
+-- its active keys.
+--
+-- ingestion_quota_usage counts the events stored per plugin and period. Each period has its own
+-- row, so counters reset when a new day or month starts; rows of past periods are kept for
+-- reporting (two rows per plugin per day). Only plugins with a quota are counted.
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE api_keys ADD COLUMN metadata JSONB DEFAULT '{}'::jsonb NOT NULL;
+
+COMMENT ON COLUMN api_keys.metadata IS 'Per-key settings, e.g. daily_event_quota and monthly_event_quota (0 or absent = unlimited)';
+
+CREATE TABLE ingestion_quota_usage (
+    plugin VARCHAR(100) NOT NULL,
+    period VARCHAR(10) NOT NULL CHECK (period IN ('day', 'month')),
+    period_start DATE NOT NULL,
+
+    event_count BIGINT NOT NULL DEFAULT 0 CHECK (event_count >= 0),
+
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (plugin, period, period_start)
+);
+
+COMMENT ON TABLE ingestion_quota_usage IS 'Events stored per plugin per quota period (UTC day or month)';
+COMMENT ON COLUMN ingestion_quota_usage.plugin IS 'API key client ID the events were ingested by';
+COMMENT ON COLUMN ingestion_quota_usage.period_start IS 'First day of the period (the day itself for daily counters)';
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- =====================================================
+-- Rollback: Feature Flags
+-- =====================================================
+
+BEGIN;
+
+DROP TABLE IF EXISTS feature_flags;
+
+COMMIT;
@@ -0,0 +1,32 @@
+-- =====================================================
+-- Correlator: Feature Flags
+-- =====================================================
+--
+-- Gates experimental behavior (strict schema, async ingestion, auto-correlation, ...) without a
+-- redeploy. Each row enables or disables one flag, either globally (plugin = '') or for one
+-- plugin (API key client ID), which overrides the global value. A flag without a row is off.
+--
+-- The server reads the table through a short-lived cache (CORRELATOR_FEATURE_FLAGS_CACHE_TTL),
+-- so a change takes effect on running instances within that TTL.
+-- =====================================================
+
+BEGIN;
+
+CREATE TABLE feature_flags (
+    name VARCHAR(100) NOT NULL,
+    plugin VARCHAR(255) NOT NULL DEFAULT '',
+
+    enabled BOOLEAN NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (name, plugin)
+);
+
+COMMENT ON TABLE feature_flags IS 'Runtime feature flags, global or overridden per plugin';
+COMMENT ON COLUMN feature_flags.name IS 'Flag name (e.g. strict_schema)';
+COMMENT ON COLUMN feature_flags.plugin IS 'API key client ID the value applies to; empty for the global value';
+COMMENT ON COLUMN feature_flags.enabled IS 'Whether the flag is on';
+
+COMMIT;
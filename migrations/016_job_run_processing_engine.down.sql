@@ -0,0 +1,11 @@
+-- =====================================================
+-- Rollback: Job Run Processing Engine
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    DROP COLUMN IF EXISTS processing_engine_version,
+    DROP COLUMN IF EXISTS processing_engine_name;
+
+COMMIT;
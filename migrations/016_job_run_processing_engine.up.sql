@@ -0,0 +1,23 @@
+-- =====================================================
+-- Correlator: Job Run Processing Engine
+-- =====================================================
+--
+-- Producers report the engine that executed a run (Spark 3.5, dbt 1.7, ...) in the OpenLineage
+-- processing_engine run facet (see ingestion.ParseProcessingEngine). More reliable than
+-- producer_name, which is derived from the producer URL and reads "unknown" or a host name for
+-- URLs outside the usual GitHub layout; the producer summary prefers it when present.
+--
+-- NULL when the producer sent no processing_engine facet and for runs ingested before this
+-- migration.
+-- =====================================================
+
+BEGIN;
+
+ALTER TABLE job_runs
+    ADD COLUMN processing_engine_name VARCHAR(100),
+    ADD COLUMN processing_engine_version VARCHAR(50);
+
+COMMENT ON COLUMN job_runs.processing_engine_name IS 'Engine that executed the run from the processing_engine run facet (e.g., spark)';
+COMMENT ON COLUMN job_runs.processing_engine_version IS 'Engine version from the processing_engine run facet (e.g., 3.5.0)';
+
+COMMIT;
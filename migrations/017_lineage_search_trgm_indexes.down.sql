@@ -0,0 +1,10 @@
+-- =====================================================
+-- Rollback: Trigram Indexes for Lineage Search
+-- =====================================================
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_job_runs_job_name_trgm;
+DROP INDEX IF EXISTS idx_datasets_urn_trgm;
+
+COMMIT;
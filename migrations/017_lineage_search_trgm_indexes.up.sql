@@ -0,0 +1,29 @@
+-- =====================================================
+-- Correlator: Trigram Indexes for Lineage Search
+-- =====================================================
+--
+-- GET /api/v1/lineage/search finds datasets and jobs by name fragment ("orders",
+-- "customer_pii") with ILIKE '%fragment%'. A leading wildcard cannot use a B-tree index, so
+-- without these every search is a sequential scan over all datasets or all job runs.
+--
+-- gin_trgm_ops (pg_trgm, enabled in 001) indexes every three-character sequence, which backs
+-- ILIKE substring matches of three or more characters and similarity() ranking. The search
+-- endpoint requires at least three characters for that reason.
+--
+-- The operator class is schema-qualified: extensions live in public (once per database), and
+-- with DATABASE_SCHEMA the migrations run with the deployment's schema first on the search_path.
+--
+-- Built inside the migration transaction (CREATE INDEX CONCURRENTLY cannot run in one):
+-- writes to both tables block while the indexes build. On large deployments apply during
+-- a quiet period.
+-- =====================================================
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_datasets_urn_trgm ON datasets USING GIN (dataset_urn public.gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_name_trgm ON job_runs USING GIN (job_name public.gin_trgm_ops);
+
+COMMENT ON INDEX idx_datasets_urn_trgm IS 'Substring (ILIKE) and similarity search on dataset URNs';
+COMMENT ON INDEX idx_job_runs_job_name_trgm IS 'Substring (ILIKE) and similarity search on job names';
+
+COMMIT;
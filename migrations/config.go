@@ -5,13 +5,29 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
 )
 
+// extensionSchema holds the PostgreSQL extensions the migrations use. Extensions exist once per
+// database, not per schema, so every Schema shares them and its search_path continues with this one.
+const extensionSchema = "public"
+
+// sharedExtensions are the extensions the migrations use, created in extensionSchema before a
+// Schema is migrated so the first schema migrated does not take them.
+var sharedExtensions = []string{"uuid-ossp", "pg_trgm"} //nolint:gochecknoglobals
+
 // Static errors for validation.
 var (
-	ErrDatabaseURLEmpty    = errors.New("DATABASE_URL cannot be empty")
-	ErrMigrationTableEmpty = errors.New("MIGRATION_TABLE cannot be empty")
+	ErrDatabaseURLEmpty      = errors.New("DATABASE_URL cannot be empty")
+	ErrMigrationTableEmpty   = errors.New("MIGRATION_TABLE cannot be empty")
+	ErrMigrationTableInvalid = errors.New(
+		"MIGRATION_TABLE must be <table> or <schema>.<table> (letters, digits, and underscores)",
+	)
+	ErrSchemaInvalid = errors.New("DATABASE_SCHEMA must be a schema name (letters, digits, and underscores)")
+
+	// migrationIdentifierRegex matches an unquoted PostgreSQL identifier.
+	migrationIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
 )
 
 // Config holds all configuration for the migration tool.
@@ -19,8 +35,16 @@ type Config struct {
 	// DatabaseURL is the PostgreSQL connection string
 	DatabaseURL string
 
-	// MigrationTable is the name of the table to track migrations
+	// MigrationTable is the name of the table to track migrations.
+	// May be schema-qualified (e.g. "correlator.schema_migrations") to keep correlator's
+	// migration state apart from other golang-migrate users of the same database.
 	MigrationTable string
+
+	// Schema is the PostgreSQL schema migrations are applied to (empty = server default).
+	// It is created if missing and set as the connection search_path, so an unqualified
+	// MigrationTable is tracked in it too. Must match the server's DATABASE_SCHEMA.
+	// The path continues with extensionSchema, which holds the extensions all schemas share.
+	Schema string
 }
 
 // LoadConfig loads configuration from environment variables with sensible defaults.
@@ -28,6 +52,7 @@ func LoadConfig() (*Config, error) {
 	config := &Config{
 		DatabaseURL:    getEnvOrDefault("DATABASE_URL", ""),
 		MigrationTable: getEnvOrDefault("MIGRATION_TABLE", "schema_migrations"),
+		Schema:         getEnvOrDefault("DATABASE_SCHEMA", ""),
 	}
 
 	err := config.Validate()
@@ -48,15 +73,66 @@ func (c *Config) Validate() error {
 		return ErrMigrationTableEmpty
 	}
 
+	parts := strings.Split(c.MigrationTable, ".")
+	if len(parts) > 2 { //nolint:mnd // schema.table
+		return fmt.Errorf("%w: got %q", ErrMigrationTableInvalid, c.MigrationTable)
+	}
+
+	for _, part := range parts {
+		if !migrationIdentifierRegex.MatchString(part) {
+			return fmt.Errorf("%w: got %q", ErrMigrationTableInvalid, c.MigrationTable)
+		}
+	}
+
+	if c.Schema != "" && !migrationIdentifierRegex.MatchString(c.Schema) {
+		return fmt.Errorf("%w: got %q", ErrSchemaInvalid, c.Schema)
+	}
+
 	return nil
 }
 
+// ConnectionURL returns DatabaseURL with search_path set to "Schema,public" (DatabaseURL itself
+// when Schema is empty). Accepts URL and key=value connection strings.
+func (c *Config) ConnectionURL() (string, error) {
+	if c.Schema == "" {
+		return c.DatabaseURL, nil
+	}
+
+	searchPath := c.Schema + "," + extensionSchema
+
+	if !strings.HasPrefix(c.DatabaseURL, "postgres://") && !strings.HasPrefix(c.DatabaseURL, "postgresql://") {
+		// key=value: lib/pq keeps the last occurrence of a key
+		return c.DatabaseURL + " search_path=" + searchPath, nil
+	}
+
+	u, err := url.Parse(c.DatabaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid DATABASE_URL: %w", err)
+	}
+
+	query := u.Query()
+	query.Set("search_path", searchPath)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// MigrationSchemaAndTable splits MigrationTable into its schema and table name.
+// The schema is empty for an unqualified table, which lives in the connection's current schema.
+func (c *Config) MigrationSchemaAndTable() (string, string) {
+	if schema, table, ok := strings.Cut(c.MigrationTable, "."); ok {
+		return schema, table
+	}
+
+	return "", c.MigrationTable
+}
+
 // String returns a string representation of the configuration (safe for logging).
 func (c *Config) String() string {
 	maskedURL := maskDatabaseURL(c.DatabaseURL)
 
-	return fmt.Sprintf("Config{DatabaseURL: %s, MigrationTable: %s}",
-		maskedURL, c.MigrationTable)
+	return fmt.Sprintf("Config{DatabaseURL: %s, MigrationTable: %s, Schema: %s}",
+		maskedURL, c.MigrationTable, c.Schema)
 }
 
 // getEnvOrDefault returns the environment variable value or a default if not set.
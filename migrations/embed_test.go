@@ -24,6 +24,38 @@ func getExpectedEmbeddedFiles() []string {
 	return []string{
 		"001_initial_openlineage_schema.down.sql",
 		"001_initial_openlineage_schema.up.sql",
+		"002_dataset_facet_history.down.sql",
+		"002_dataset_facet_history.up.sql",
+		"003_job_run_errors.down.sql",
+		"003_job_run_errors.up.sql",
+		"004_job_run_openlineage_version.down.sql",
+		"004_job_run_openlineage_version.up.sql",
+		"005_facet_gin_indexes.down.sql",
+		"005_facet_gin_indexes.up.sql",
+		"006_column_lineage.down.sql",
+		"006_column_lineage.up.sql",
+		"007_lineage_batches.down.sql",
+		"007_lineage_batches.up.sql",
+		"008_lineage_batch_backfill.down.sql",
+		"008_lineage_batch_backfill.up.sql",
+		"009_job_run_nominal_time.down.sql",
+		"009_job_run_nominal_time.up.sql",
+		"010_test_results_run_id_index.down.sql",
+		"010_test_results_run_id_index.up.sql",
+		"011_job_run_ingested_by_plugin.down.sql",
+		"011_job_run_ingested_by_plugin.up.sql",
+		"012_test_results_upsert_key.down.sql",
+		"012_test_results_upsert_key.up.sql",
+		"013_job_aliases.down.sql",
+		"013_job_aliases.up.sql",
+		"014_ingestion_quotas.down.sql",
+		"014_ingestion_quotas.up.sql",
+		"015_feature_flags.down.sql",
+		"015_feature_flags.up.sql",
+		"016_job_run_processing_engine.down.sql",
+		"016_job_run_processing_engine.up.sql",
+		"017_lineage_search_trgm_indexes.down.sql",
+		"017_lineage_search_trgm_indexes.up.sql",
 	}
 }
 
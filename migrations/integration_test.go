@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strings"
 	"testing"
 	"testing/fstest"
@@ -235,7 +236,117 @@ func TestMigrationRunnerWorkFlow(t *testing.T) {
 	})
 }
 
+// TestMigrationRunnerQualifiedTable verifies that a schema-qualified MIGRATION_TABLE is created in
+// its own schema, leaving the current schema's schema_migrations free for co-tenant apps.
+func TestMigrationRunnerQualifiedTable(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	connStr := setupPostgresContainer(ctx, t)
+
+	runner, err := NewMigrationRunner(&Config{
+		DatabaseURL:    connStr,
+		MigrationTable: "correlator.schema_migrations",
+	})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	defer func() {
+		if err := runner.Close(); err != nil {
+			t.Logf("cleanup error: %v", err)
+		}
+	}()
+
+	if err := runner.Up(); err != nil {
+		t.Fatalf("migration up failed: %v", err)
+	}
+
+	var (
+		version int
+		dirty   bool
+	)
+
+	err = runner.db.QueryRowContext(ctx, `SELECT version, dirty FROM correlator.schema_migrations`).
+		Scan(&version, &dirty)
+	if err != nil {
+		t.Fatalf("failed to read qualified migration table: %v", err)
+	}
+
+	if version != getMaxSchemaVersion() || dirty {
+		t.Errorf("version = %d (dirty=%v), want %d (clean)", version, dirty, getMaxSchemaVersion())
+	}
+
+	var publicTable sql.NullString
+
+	err = runner.db.QueryRowContext(ctx, `SELECT to_regclass('public.schema_migrations')::text`).Scan(&publicTable)
+	if err != nil {
+		t.Fatalf("failed to check public schema: %v", err)
+	}
+
+	if publicTable.Valid {
+		t.Error("public.schema_migrations should not exist when a qualified table is configured")
+	}
+}
+
 // TestMigrationRunnerConfiguration tests error conditions with bad database configuration.
+// TestVerifyCommandIntegration verifies that verify passes on a freshly migrated schema, reports
+// drift without changing anything, and leaves no scratch schema behind.
+func TestVerifyCommandIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	connStr := setupPostgresContainer(ctx, t)
+
+	runner, err := NewMigrationRunner(&Config{
+		DatabaseURL:    connStr,
+		MigrationTable: "schema_migrations",
+	})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	defer func() {
+		if err := runner.Close(); err != nil {
+			t.Logf("cleanup error: %v", err)
+		}
+	}()
+
+	if err := runner.Up(); err != nil {
+		t.Fatalf("migration up failed: %v", err)
+	}
+
+	if err := executeCommand("verify", runner, false); err != nil {
+		t.Fatalf("verify on a freshly migrated schema failed: %v", err)
+	}
+
+	_, err = runner.db.ExecContext(ctx, `ALTER TABLE job_runs ADD COLUMN verify_drift TEXT`)
+	if err != nil {
+		t.Fatalf("failed to alter job_runs: %v", err)
+	}
+
+	err = executeCommand("verify", runner, false)
+	if !errors.Is(err, ErrSchemaDrift) {
+		t.Fatalf("expected ErrSchemaDrift after adding a column, got %v", err)
+	}
+
+	var scratchSchemas int
+
+	err = runner.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM pg_namespace WHERE nspname LIKE 'migrator_verify_%'`).Scan(&scratchSchemas)
+	if err != nil {
+		t.Fatalf("failed to count scratch schemas: %v", err)
+	}
+
+	if scratchSchemas != 0 {
+		t.Errorf("verify left %d scratch schema(s) behind", scratchSchemas)
+	}
+}
+
 func TestMigrationRunnerBadConfiguration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
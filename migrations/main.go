@@ -1,7 +1,7 @@
 // Package main provides the database migration CLI tool for Correlator.
 //
 // This migrator implements a clean architecture with embedded migrations,
-// supporting up/down/status/version commands for zero-config deployment.
+// supporting up/down/status/version/verify commands for zero-config deployment.
 package main
 
 import (
@@ -87,14 +87,14 @@ func main() {
 		log.Fatalf("Failed to create migration runner: %v", err)
 	}
 
-	defer func() {
-		_ = runner.Close()
-	}()
-
 	// Execute command
 	err = executeCommand(command, runner, *force)
+
+	_ = runner.Close()
+
 	if err != nil {
 		log.Printf("Migration failed: %v\n", err)
+		os.Exit(1)
 	}
 }
 
@@ -109,6 +109,8 @@ func executeCommand(command string, runner MigrationRunner, force bool) error {
 		return runner.Status()
 	case "version":
 		return runner.Version()
+	case "verify":
+		return runner.Verify()
 	case "drop":
 		if !force {
 			return ErrDropRequiresForce
@@ -165,6 +167,8 @@ COMMANDS:
     down    Rollback the last migration
     status  Show migration status
     version Show current migration version
+    verify  Compare the live schema with the embedded migrations (read-only,
+            exits non-zero on drift)
     drop    Drop all tables (DESTRUCTIVE - requires --force flag)
 
 OPTIONS:
@@ -182,9 +186,10 @@ EXAMPLES:
     %s up                    # Apply all pending migrations
     %s status               # Show current migration status
     %s down                 # Rollback last migration
+    %s verify               # Check the live schema for drift
     %s drop --force         # Drop all tables (DESTRUCTIVE)
     %s --version           # Show version information
 
 For zero-config deployment, run without environment variables to use defaults.
-`, Name(), Version(), Name(), Name(), Name(), Name(), Name(), Name())
+`, Name(), Version(), Name(), Name(), Name(), Name(), Name(), Name(), Name())
 }
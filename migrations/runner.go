@@ -11,8 +11,7 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
-
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/lib/pq" // PostgreSQL driver
 )
 
 type (
@@ -30,6 +29,9 @@ type (
 		// Version shows the current migration version
 		Version() error
 
+		// Verify compares the live schema with the one the applied migrations produce (read-only)
+		Verify() error
+
 		// Drop drops all tables (destructive operation)
 		Drop() error
 
@@ -72,8 +74,13 @@ func NewMigrationRunner(config *Config) (*Runner, error) {
 
 	log.Println("Embedded migration validation passed")
 
+	connectionURL, err := config.ConnectionURL()
+	if err != nil {
+		return nil, err
+	}
+
 	// Open database connection
-	db, err := sql.Open("postgres", config.DatabaseURL)
+	db, err := sql.Open("postgres", connectionURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
@@ -88,10 +95,22 @@ func NewMigrationRunner(config *Config) (*Runner, error) {
 
 	log.Println("Database connection established successfully")
 
+	err = createSchema(context.Background(), db, config.Schema)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	driverConfig, err := migrationDriverConfig(context.Background(), db, config)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
 	// Create database driver
-	driver, err := postgres.WithInstance(db, &postgres.Config{
-		MigrationsTable: config.MigrationTable,
-	})
+	driver, err := postgres.WithInstance(db, driverConfig)
 	if err != nil {
 		_ = db.Close()
 
@@ -132,6 +151,56 @@ func NewMigrationRunner(config *Config) (*Runner, error) {
 	}, nil
 }
 
+// createSchema creates the schema migrations are applied to, if one is configured.
+// The connection's search_path already names it, so everything created afterwards lands in it.
+// The shared extensions are created in extensionSchema first: CREATE EXTENSION in a migration
+// would otherwise install them into schema, where no other schema's search_path finds them.
+func createSchema(ctx context.Context, db *sql.DB, schema string) error {
+	if schema == "" {
+		return nil
+	}
+
+	for _, extension := range sharedExtensions {
+		_, err := db.ExecContext(ctx, "CREATE EXTENSION IF NOT EXISTS "+pq.QuoteIdentifier(extension)+
+			" SCHEMA "+pq.QuoteIdentifier(extensionSchema))
+		if err != nil {
+			return fmt.Errorf("failed to create extension %q in schema %s: %w", extension, extensionSchema, err)
+		}
+	}
+
+	_, err := db.ExecContext(ctx, "CREATE SCHEMA IF NOT EXISTS "+pq.QuoteIdentifier(schema))
+	if err != nil {
+		return fmt.Errorf("failed to create schema %q: %w", schema, err)
+	}
+
+	log.Printf("Applying migrations to schema %s", schema)
+
+	return nil
+}
+
+// migrationDriverConfig builds the golang-migrate postgres config for the configured tracking table.
+// A schema-qualified table gets its schema created if missing, and is passed quoted because
+// golang-migrate only splits "schema"."table" when MigrationsTableQuoted is set; otherwise the
+// whole name would be treated as a table in the current schema.
+func migrationDriverConfig(ctx context.Context, db *sql.DB, config *Config) (*postgres.Config, error) {
+	schema, table := config.MigrationSchemaAndTable()
+	if schema == "" {
+		return &postgres.Config{MigrationsTable: table}, nil
+	}
+
+	_, err := db.ExecContext(ctx, "CREATE SCHEMA IF NOT EXISTS "+pq.QuoteIdentifier(schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration table schema %q: %w", schema, err)
+	}
+
+	log.Printf("Tracking migrations in %s.%s", schema, table)
+
+	return &postgres.Config{
+		MigrationsTable:       pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(table),
+		MigrationsTableQuoted: true,
+	}, nil
+}
+
 // Up applies all pending migrations.
 func (r *Runner) Up() error {
 	// Validate embedded migrations before state-changing operations
@@ -33,6 +33,7 @@ type mockMigrationRunner struct {
 	downError    error
 	statusError  error
 	versionError error
+	verifyError  error
 	dropError    error
 	closeError   error
 }
@@ -41,6 +42,7 @@ func (m *mockMigrationRunner) Up() error      { return m.upError }
 func (m *mockMigrationRunner) Down() error    { return m.downError }
 func (m *mockMigrationRunner) Status() error  { return m.statusError }
 func (m *mockMigrationRunner) Version() error { return m.versionError }
+func (m *mockMigrationRunner) Verify() error  { return m.verifyError }
 func (m *mockMigrationRunner) Drop() error    { return m.dropError }
 func (m *mockMigrationRunner) Close() error   { return m.closeError }
 
@@ -488,6 +490,25 @@ func TestExecuteCommand(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name:    "verify command works",
+			command: "verify",
+			force:   false,
+			setupMock: func() *mockMigrationRunner {
+				return &mockMigrationRunner{} // no errors
+			},
+			wantError: false,
+		},
+		{
+			name:    "verify command reports schema drift",
+			command: "verify",
+			force:   false,
+			setupMock: func() *mockMigrationRunner {
+				return &mockMigrationRunner{verifyError: ErrSchemaDrift}
+			},
+			wantError:     true,
+			errorContains: "live schema differs from embedded migrations",
+		},
 		{
 			name:    "drop command without force fails with safety error",
 			command: "drop",
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrSchemaDrift is returned by verify when the live schema differs from the one the
+	// applied migrations produce.
+	ErrSchemaDrift = errors.New("live schema differs from embedded migrations")
+	// ErrVerifyDirtySchema is returned by verify when the last migration failed halfway.
+	ErrVerifyDirtySchema = errors.New("cannot verify a dirty schema (fix the failed migration first)")
+)
+
+// transactionStatementRegex matches the BEGIN;/COMMIT; lines wrapping a migration file, which
+// would end the verification transaction early.
+var transactionStatementRegex = regexp.MustCompile(`(?mi)^[ \t]*(BEGIN|COMMIT)[ \t]*;[ \t]*$`)
+
+// Catalog queries for the relations, columns, and indexes of schema $1. pg_catalog rather than
+// information_schema, which leaves out materialized views (incident_correlation_view) and their
+// columns. Partitions are skipped: migrations do not create them. Index definitions drop the
+// schema qualifier pg_get_indexdef puts on the indexed table, which differs by design.
+const (
+	relationsQuery = `
+		SELECT c.relname,
+		       CASE c.relkind WHEN 'v' THEN 'view' WHEN 'm' THEN 'materialized view' ELSE 'table' END
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1
+		  AND c.relkind IN ('r', 'p', 'v', 'm')
+		  AND NOT c.relispartition`
+
+	columnsQuery = `
+		SELECT c.relname || '.' || a.attname,
+		       format_type(a.atttypid, a.atttypmod)
+		         || CASE WHEN a.attnotnull THEN ' NOT NULL' ELSE '' END
+		         || COALESCE(' DEFAULT ' || pg_get_expr(d.adbin, d.adrelid), '')
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = $1
+		  AND c.relkind IN ('r', 'p', 'v', 'm')
+		  AND NOT c.relispartition
+		  AND a.attnum > 0
+		  AND NOT a.attisdropped`
+
+	indexesQuery = `
+		SELECT i.relname,
+		       replace(replace(pg_get_indexdef(i.oid),
+		         ' ON ONLY ' || quote_ident(n.nspname) || '.', ' ON ONLY '),
+		         ' ON ' || quote_ident(n.nspname) || '.', ' ON ')
+		FROM pg_index x
+		JOIN pg_class i ON i.oid = x.indexrelid
+		JOIN pg_class t ON t.oid = x.indrelid
+		JOIN pg_namespace n ON n.oid = i.relnamespace
+		WHERE n.nspname = $1
+		  AND NOT t.relispartition`
+)
+
+// schemaSnapshot describes the objects of one schema, each keyed by name with a definition
+// comparable across schemas.
+type schemaSnapshot struct {
+	relations map[string]string // name -> table, view, or materialized view
+	columns   map[string]string // table.column -> type, NOT NULL, DEFAULT
+	indexes   map[string]string // name -> definition without the schema qualifier
+}
+
+// withoutTable returns the snapshot without table and its columns.
+func (s *schemaSnapshot) withoutTable(table string) *schemaSnapshot {
+	columns := maps.Clone(s.columns)
+	maps.DeleteFunc(columns, func(column, _ string) bool { return strings.HasPrefix(column, table+".") })
+
+	relations := maps.Clone(s.relations)
+	delete(relations, table)
+
+	return &schemaSnapshot{relations: relations, columns: columns, indexes: s.indexes}
+}
+
+// Verify compares the live schema with the one the applied migrations produce and logs every
+// missing, extra, or changed table, column, and index. Returns ErrSchemaDrift if they differ.
+//
+// The expected schema is built by replaying the applied up migrations into a scratch schema
+// inside a transaction that is always rolled back, so nothing is left behind.
+func (r *Runner) Verify() error {
+	ver, dirty, err := r.migrate.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("failed to get migration version: %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("%w: version %d", ErrVerifyDirtySchema, ver)
+	}
+
+	log.Printf("Verifying live schema against embedded migrations up to v%03d...", ver)
+
+	version := int(ver) // #nosec G115 - version numbers are safe to convert
+
+	expected, actual, err := r.snapshotSchemas(context.Background(), version)
+	if err != nil {
+		return err
+	}
+
+	// The tracking table is golang-migrate's, not created by any migration
+	_, migrationTable := r.config.MigrationSchemaAndTable()
+
+	differences := diffSchemas(expected, actual.withoutTable(migrationTable))
+	if len(differences) == 0 {
+		log.Println("Schema verification passed: live schema matches embedded migrations")
+
+		return nil
+	}
+
+	log.Printf("Schema verification found %d difference(s):", len(differences))
+
+	for _, difference := range differences {
+		log.Printf("  %s", difference)
+	}
+
+	return fmt.Errorf("%w: %d difference(s)", ErrSchemaDrift, len(differences))
+}
+
+// snapshotSchemas returns the schema produced by the up migrations up to version, replayed in a
+// rolled-back scratch schema, and the live schema.
+func (r *Runner) snapshotSchemas(ctx context.Context, version int) (*schemaSnapshot, *schemaSnapshot, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin verification transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	var liveSchema, searchPath string
+	if err := tx.QueryRowContext(ctx, `SELECT current_schema(), current_setting('search_path')`).
+		Scan(&liveSchema, &searchPath); err != nil {
+		return nil, nil, fmt.Errorf("failed to read current schema: %w", err)
+	}
+
+	actual, err := snapshotSchema(ctx, tx, liveSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scratch := fmt.Sprintf("migrator_verify_%d", time.Now().UnixNano())
+
+	// The live search_path stays behind the scratch schema so extension types and functions
+	// (pg_trgm, uuid-ossp) resolve as they do for a real migration.
+	if _, err := tx.ExecContext(ctx, "CREATE SCHEMA "+pq.QuoteIdentifier(scratch)+
+		"; SET LOCAL search_path TO "+pq.QuoteIdentifier(scratch)+", "+searchPath); err != nil {
+		return nil, nil, fmt.Errorf("failed to create scratch schema: %w", err)
+	}
+
+	if err := r.replayMigrations(ctx, tx, version); err != nil {
+		return nil, nil, err
+	}
+
+	expected, err := snapshotSchema(ctx, tx, scratch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return expected, actual, nil
+}
+
+// replayMigrations runs the embedded up migrations up to version in tx, without their own
+// BEGIN/COMMIT.
+func (r *Runner) replayMigrations(ctx context.Context, tx *sql.Tx, version int) error {
+	files, err := r.embeddedMigration.ListEmbeddedMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, filename := range files {
+		migration, err := r.embeddedMigration.parseMigrationFilename(filename)
+		if err != nil {
+			return err
+		}
+
+		if migration.Direction != "up" || migration.Sequence > version {
+			continue
+		}
+
+		content, err := r.embeddedMigration.GetEmbeddedMigrationContent(filename)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", filename, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, stripTransactionStatements(string(content))); err != nil {
+			return fmt.Errorf("failed to replay migration %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
+
+// stripTransactionStatements removes the BEGIN;/COMMIT; lines of a migration file.
+func stripTransactionStatements(content string) string {
+	return transactionStatementRegex.ReplaceAllString(content, "")
+}
+
+// snapshotSchema reads the relations, columns, and indexes of schema.
+func snapshotSchema(ctx context.Context, tx *sql.Tx, schema string) (*schemaSnapshot, error) {
+	relations, err := queryNamedDefinitions(ctx, tx, relationsQuery, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tables of schema %s: %w", schema, err)
+	}
+
+	columns, err := queryNamedDefinitions(ctx, tx, columnsQuery, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of schema %s: %w", schema, err)
+	}
+
+	indexes, err := queryNamedDefinitions(ctx, tx, indexesQuery, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexes of schema %s: %w", schema, err)
+	}
+
+	return &schemaSnapshot{relations: relations, columns: columns, indexes: indexes}, nil
+}
+
+// queryNamedDefinitions runs a catalog query returning (name, definition) rows for schema.
+func queryNamedDefinitions(ctx context.Context, tx *sql.Tx, query, schema string) (map[string]string, error) {
+	rows, err := tx.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	definitions := make(map[string]string)
+
+	for rows.Next() {
+		var name, definition string
+		if err := rows.Scan(&name, &definition); err != nil {
+			return nil, err
+		}
+
+		definitions[name] = definition
+	}
+
+	return definitions, rows.Err()
+}
+
+// diffSchemas lists how actual differs from expected, one line per object, sorted by kind then
+// name. Columns of missing or extra tables are not listed again.
+func diffSchemas(expected, actual *schemaSnapshot) []string {
+	tableOnlyInOne := func(column, _ string) bool {
+		table, _, _ := strings.Cut(column, ".")
+		_, inExpected := expected.relations[table]
+		_, inActual := actual.relations[table]
+
+		return inExpected != inActual
+	}
+
+	expectedColumns := maps.Clone(expected.columns)
+	maps.DeleteFunc(expectedColumns, tableOnlyInOne)
+
+	actualColumns := maps.Clone(actual.columns)
+	maps.DeleteFunc(actualColumns, tableOnlyInOne)
+
+	differences := diffObjects("table", expected.relations, actual.relations)
+	differences = append(differences, diffObjects("column", expectedColumns, actualColumns)...)
+
+	return append(differences, diffObjects("index", expected.indexes, actual.indexes)...)
+}
+
+// diffObjects lists the objects of one kind that are missing from actual, extra in actual, or
+// defined differently, sorted by name.
+func diffObjects(kind string, expected, actual map[string]string) []string {
+	names := slices.Sorted(maps.Keys(expected))
+	for name := range actual {
+		if _, ok := expected[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	var differences []string
+
+	for _, name := range names {
+		want, inExpected := expected[name]
+		got, inActual := actual[name]
+
+		switch {
+		case !inActual:
+			differences = append(differences, fmt.Sprintf("missing %s %s (%s)", kind, name, want))
+		case !inExpected:
+			differences = append(differences, fmt.Sprintf("extra %s %s (%s)", kind, name, got))
+		case want != got:
+			differences = append(differences, fmt.Sprintf("changed %s %s: expected %q, got %q", kind, name, want, got))
+		}
+	}
+
+	return differences
+}
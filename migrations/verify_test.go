@@ -0,0 +1,109 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStripTransactionStatements(t *testing.T) {
+	skipIfNotShort(t)
+
+	content := `-- header
+BEGIN;
+
+ALTER TABLE job_runs ADD COLUMN foo TEXT;
+
+CREATE FUNCTION f() RETURNS void AS $$
+BEGIN
+    PERFORM 1;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
+`
+
+	want := `-- header
+
+
+ALTER TABLE job_runs ADD COLUMN foo TEXT;
+
+CREATE FUNCTION f() RETURNS void AS $$
+BEGIN
+    PERFORM 1;
+END;
+$$ LANGUAGE plpgsql;
+
+
+`
+
+	if got := stripTransactionStatements(content); got != want {
+		t.Errorf("stripTransactionStatements() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	skipIfNotShort(t)
+
+	expected := &schemaSnapshot{
+		relations: map[string]string{"job_runs": "table", "test_results": "table"},
+		columns: map[string]string{
+			"job_runs.run_id":          "text NOT NULL",
+			"job_runs.state":           "character varying(50) NOT NULL",
+			"test_results.id":          "bigint NOT NULL",
+			"test_results.test_name":   "text NOT NULL",
+			"test_results.executed_at": "timestamp with time zone",
+		},
+		indexes: map[string]string{
+			"job_runs_pkey":      "CREATE UNIQUE INDEX job_runs_pkey ON job_runs USING btree (run_id)",
+			"idx_job_runs_state": "CREATE INDEX idx_job_runs_state ON job_runs USING btree (state)",
+		},
+	}
+
+	t.Run("identical schemas", func(t *testing.T) {
+		if got := diffSchemas(expected, expected); len(got) != 0 {
+			t.Errorf("expected no differences, got %v", got)
+		}
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		actual := &schemaSnapshot{
+			relations: map[string]string{"job_runs": "table", "scratch_backup": "table"},
+			columns: map[string]string{
+				"job_runs.run_id":       "text NOT NULL",
+				"job_runs.state":        "text NOT NULL",
+				"job_runs.debug":        "text",
+				"scratch_backup.run_id": "text",
+			},
+			indexes: map[string]string{
+				"job_runs_pkey": "CREATE UNIQUE INDEX job_runs_pkey ON job_runs USING btree (run_id)",
+			},
+		}
+
+		want := []string{
+			`extra table scratch_backup (table)`,
+			`missing table test_results (table)`,
+			`extra column job_runs.debug (text)`,
+			`changed column job_runs.state: expected "character varying(50) NOT NULL", got "text NOT NULL"`,
+			`missing index idx_job_runs_state (CREATE INDEX idx_job_runs_state ON job_runs USING btree (state))`,
+		}
+
+		if got := diffSchemas(expected, actual); !slices.Equal(got, want) {
+			t.Errorf("diffSchemas() =\n%q\nwant\n%q", got, want)
+		}
+	})
+
+	t.Run("migration table ignored", func(t *testing.T) {
+		actual := &schemaSnapshot{
+			relations: map[string]string{"job_runs": "table", "test_results": "table", "schema_migrations": "table"},
+			columns:   map[string]string{"schema_migrations.version": "bigint NOT NULL"},
+			indexes:   expected.indexes,
+		}
+		for column, definition := range expected.columns {
+			actual.columns[column] = definition
+		}
+
+		if got := diffSchemas(expected, actual.withoutTable("schema_migrations")); len(got) != 0 {
+			t.Errorf("expected no differences, got %v", got)
+		}
+	})
+}